@@ -17,6 +17,18 @@ const (
 	OrderStatusCompleted  OrderStatus = "completed"
 )
 
+// PaymentMethod способ оплаты заказа, пусто - не указан при создании
+type PaymentMethod string
+
+const (
+	PaymentMethodCard PaymentMethod = "card"
+	PaymentMethodCash PaymentMethod = "cash"
+)
+
+func (m PaymentMethod) IsValid() bool {
+	return m == "" || m == PaymentMethodCard || m == PaymentMethodCash
+}
+
 type Item struct {
 	FoodID   string
 	Name     string
@@ -41,6 +53,7 @@ type Order struct {
 	Items             []Item
 	TotalPrice        int64
 	Address           DeliveryAddress
+	PaymentMethod     PaymentMethod
 	Status            OrderStatus
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
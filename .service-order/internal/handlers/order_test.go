@@ -61,15 +61,16 @@ func TestOrderHandler_Create_Success(t *testing.T) {
 	fake := fakeService{
 		CreateFn: func(ctx context.Context, userID string, in uc.CreateInput) (*entity.Order, error) {
 			return &entity.Order{
-				ID:           "o1",
-				UserID:       userID,
-				RestaurantID: in.RestaurantID,
-				Items:        in.Items,
-				TotalPrice:   in.TotalPrice,
-				Address:      in.Address,
-				Status:       entity.OrderStatusCreated,
-				CreatedAt:    time.Now().UTC(),
-				UpdatedAt:    time.Now().UTC(),
+				ID:            "o1",
+				UserID:        userID,
+				RestaurantID:  in.RestaurantID,
+				Items:         in.Items,
+				TotalPrice:    in.TotalPrice,
+				Address:       in.Address,
+				PaymentMethod: in.PaymentMethod,
+				Status:        entity.OrderStatusCreated,
+				CreatedAt:     time.Now().UTC(),
+				UpdatedAt:     time.Now().UTC(),
 			}, nil
 		},
 	}
@@ -90,6 +91,7 @@ func TestOrderHandler_Create_Success(t *testing.T) {
 		Address: transport.DeliveryAddress{
 			Street: "Main",
 		},
+		PaymentMethod: "cash",
 	}
 	b, _ := json.Marshal(body)
 	req := httptest.NewRequest(http.MethodPost, "/public/api/v1/order", bytes.NewReader(b))
@@ -103,6 +105,7 @@ func TestOrderHandler_Create_Success(t *testing.T) {
 	_ = json.NewDecoder(w.Body).Decode(&resp)
 	assert.Equal(t, "o1", resp.ID)
 	assert.Equal(t, "rest-1", resp.RestaurantID)
+	assert.Equal(t, "cash", resp.PaymentMethod)
 }
 
 func TestOrderHandler_Create_BadJSON(t *testing.T) {
@@ -10,12 +10,13 @@ import (
 
 func ToDomainCreate(in transport.CreateOrderRequest) uc.CreateInput {
 	return uc.CreateInput{
-		OrderNumber:  in.OrderNumber,
-		FIO:          in.FIO,
-		RestaurantID: in.RestaurantID,
-		Items:        toDomainItems(in.Items),
-		TotalPrice:   in.TotalPrice,
-		Address:      toDomainAddress(in.Address),
+		OrderNumber:   in.OrderNumber,
+		FIO:           in.FIO,
+		RestaurantID:  in.RestaurantID,
+		Items:         toDomainItems(in.Items),
+		TotalPrice:    in.TotalPrice,
+		Address:       toDomainAddress(in.Address),
+		PaymentMethod: entity.PaymentMethod(in.PaymentMethod),
 	}
 }
 
@@ -50,6 +51,7 @@ func ToTransport(o *entity.Order) transport.OrderResponse {
 		Items:             toTransportItems(o.Items),
 		TotalPrice:        o.TotalPrice,
 		Address:           toTransportAddress(o.Address),
+		PaymentMethod:     string(o.PaymentMethod),
 		Status:            string(o.Status),
 		CreatedAt:         o.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:         o.UpdatedAt.Format(time.RFC3339),
@@ -16,12 +16,13 @@ type DeliveryAddress struct {
 }
 
 type CreateOrderRequest struct {
-	OrderNumber  string          `json:"order_number,omitempty"`
-	FIO          string          `json:"fio,omitempty"`
-	RestaurantID string          `json:"restaurant_id"`
-	Items        []Item          `json:"items"`
-	TotalPrice   int64           `json:"total_price"`
-	Address      DeliveryAddress `json:"address"`
+	OrderNumber   string          `json:"order_number,omitempty"`
+	FIO           string          `json:"fio,omitempty"`
+	RestaurantID  string          `json:"restaurant_id"`
+	Items         []Item          `json:"items"`
+	TotalPrice    int64           `json:"total_price"`
+	Address       DeliveryAddress `json:"address"`
+	PaymentMethod string          `json:"payment_method,omitempty"`
 }
 
 type UpdateOrderRequest struct {
@@ -41,6 +42,7 @@ type OrderResponse struct {
 	Items             []Item          `json:"items"`
 	TotalPrice        int64           `json:"total_price"`
 	Address           DeliveryAddress `json:"address"`
+	PaymentMethod     string          `json:"payment_method,omitempty"`
 	Status            string          `json:"status"`
 	CreatedAt         string          `json:"created_at"`
 	UpdatedAt         string          `json:"updated_at"`
@@ -2,8 +2,8 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.9
-// 	protoc        v6.32.1
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.30.2
 // source: internal/proto/orders.proto
 
 // Пакет для определённых сущностей и служб
@@ -179,6 +179,7 @@ type Order struct {
 	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	EstimatedDelivery *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=estimated_delivery,json=estimatedDelivery,proto3" json:"estimated_delivery,omitempty"`
+	PaymentMethod     string                 `protobuf:"bytes,13,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"` // card или cash, пусто - способ оплаты неизвестен
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -297,6 +298,13 @@ func (x *Order) GetEstimatedDelivery() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Order) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
 // Запрос на получение списка заказов
 type GetOrdersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -491,7 +499,7 @@ const file_internal_proto_orders_proto_rawDesc = "" +
 	"\x05house\x18\x02 \x01(\tR\x05house\x12\x1c\n" +
 	"\tapartment\x18\x03 \x01(\tR\tapartment\x12\x14\n" +
 	"\x05floor\x18\x04 \x01(\tR\x05floor\x12\x18\n" +
-	"\acomment\x18\x05 \x01(\tR\acomment\"\xe1\x03\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\"\x88\x04\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12!\n" +
@@ -508,7 +516,8 @@ const file_internal_proto_orders_proto_rawDesc = "" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12I\n" +
-	"\x12estimated_delivery\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x11estimatedDelivery\"B\n" +
+	"\x12estimated_delivery\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x11estimatedDelivery\x12%\n" +
+	"\x0epayment_method\x18\r \x01(\tR\rpaymentMethod\"B\n" +
 	"\x10GetOrdersRequest\x12.\n" +
 	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\"%\n" +
 	"\x13GetOrderByIdRequest\x12\x0e\n" +
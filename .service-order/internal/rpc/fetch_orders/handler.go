@@ -65,6 +65,7 @@ func toOrderDtoResponse(orders []*entity.Order) []*pb.Order {
 			CreatedAt:         timestamppb.New(order.CreatedAt),
 			UpdatedAt:         timestamppb.New(order.UpdatedAt),
 			EstimatedDelivery: timestamppb.New(order.EstimatedDelivery),
+			PaymentMethod:     string(order.PaymentMethod),
 		})
 	}
 
@@ -98,6 +98,7 @@ func (s *service) Seed(ctx context.Context, userID string, n int) ([]*entity.Ord
 			Items:             items,
 			TotalPrice:        total,
 			Address:           addr,
+			PaymentMethod:     pick([]entity.PaymentMethod{entity.PaymentMethodCard, entity.PaymentMethodCash}, i),
 			Status:            st,
 			CreatedAt:         createdAt,
 			UpdatedAt:         updatedAt,
@@ -33,12 +33,13 @@ type Service interface {
 }
 
 type CreateInput struct {
-	OrderNumber  string
-	FIO          string
-	RestaurantID string
-	Items        []entity.Item
-	TotalPrice   int64
-	Address      entity.DeliveryAddress
+	OrderNumber   string
+	FIO           string
+	RestaurantID  string
+	Items         []entity.Item
+	TotalPrice    int64
+	Address       entity.DeliveryAddress
+	PaymentMethod entity.PaymentMethod
 }
 
 type UpdateInput struct {
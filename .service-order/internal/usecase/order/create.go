@@ -12,7 +12,7 @@ func (s *service) Create(ctx context.Context, userID string, in CreateInput) (*e
 	if userID == "" {
 		return nil, entity.ErrUnauthorized
 	}
-	if in.RestaurantID == "" || len(in.Items) == 0 || in.TotalPrice < 0 {
+	if in.RestaurantID == "" || len(in.Items) == 0 || in.TotalPrice < 0 || !in.PaymentMethod.IsValid() {
 		return nil, entity.ErrInvalidInput
 	}
 	now := s.clock.Now()
@@ -25,6 +25,7 @@ func (s *service) Create(ctx context.Context, userID string, in CreateInput) (*e
 		Items:           in.Items,
 		TotalPrice:      in.TotalPrice,
 		Address:         in.Address,
+		PaymentMethod:   in.PaymentMethod,
 		Status:          entity.OrderStatusCreated,
 		CreatedAt:       now,
 		UpdatedAt:       now,
@@ -50,6 +50,7 @@ func TestService_Create_ProducesAndPersists(t *testing.T) {
 		Address: entity.DeliveryAddress{
 			Street: "Main",
 		},
+		PaymentMethod: entity.PaymentMethodCash,
 	}
 
 	// Expect repository create and producer event; accept any order pointer
@@ -62,9 +63,28 @@ func TestService_Create_ProducesAndPersists(t *testing.T) {
 		assert.Equal(t, "user-1", o.UserID)
 		assert.False(t, o.CreatedAt.IsZero())
 		assert.Equal(t, entity.OrderStatusCreated, o.Status)
+		assert.Equal(t, entity.PaymentMethodCash, o.PaymentMethod)
 	}
 }
 
+func TestService_Create_InvalidPaymentMethod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockRepository(ctrl)
+	prod := NewMockProducer(ctrl)
+	svc := uc.NewWithDeps(repo, prod, fixedClock{t: time.Now().UTC()}, nopLog{}, nopMetric{})
+
+	o, err := svc.Create(context.Background(), "user-1", uc.CreateInput{
+		RestaurantID:  "rest-1",
+		Items:         []entity.Item{{FoodID: "f1", Name: "Pizza", Quantity: 1, Price: 500}},
+		TotalPrice:    500,
+		PaymentMethod: "crypto",
+	})
+	assert.ErrorIs(t, err, entity.ErrInvalidInput)
+	assert.Nil(t, o)
+}
+
 func TestService_Delete_ProducerCalled(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
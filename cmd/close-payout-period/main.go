@@ -0,0 +1,207 @@
+// close-payout-period закрывает расчетный период курьеров и выгружает ведомости в CSV, по файлу на курьера.
+// Период начинается с конца предыдущего закрытого периода и заканчивается в -end (по умолчанию сейчас).
+// В ведомость попадают все еще не выплаченные начисления за доставки, выполненные до -end
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	stdlog "log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/pgxv5"
+	"service/internal/app"
+	"service/internal/entities"
+	"service/internal/pkg/config"
+	"service/internal/pkg/dotenv"
+	"service/internal/pkg/postgres"
+	"service/pkg/logger"
+	"service/pkg/logger/zap_adapter"
+)
+
+func main() {
+	endFlag := flag.String("end", "", "period end in RFC 3339, defaults to now")
+	outDir := flag.String("out", "payouts", "directory for CSV statements")
+	flag.Parse()
+
+	// os.Exit не выполняет defer, поэтому код выхода возвращается из отдельной функции
+	os.Exit(execute(*endFlag, *outDir))
+}
+
+func execute(endFlag, outDir string) int {
+	zapLogger, err := zap_adapter.NewZapAdapter()
+	if err != nil {
+		stdlog.Fatalf("failed to initialize logger: %v", err)
+	}
+	defer func() {
+		if err := zapLogger.Sync(); err != nil {
+			stdlog.Printf("failed to sync logger: %v", err)
+		}
+	}()
+
+	var appLogger logger.Logger = zapLogger
+	mainLog := appLogger.With()
+
+	end := time.Now().UTC()
+	if endFlag != "" {
+		end, err = time.Parse(time.RFC3339, endFlag)
+		if err != nil {
+			mainLog.Error("invalid -end", logger.NewField("error", err))
+			return 1
+		}
+	}
+
+	if _, err := os.Stat(".env"); err == nil {
+		if err := dotenv.Load(); err != nil {
+			mainLog.Error("failed to load .env file", logger.NewField("error", err))
+			return 1
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		mainLog.Error("load config", logger.NewField("error", err))
+		return 1
+	}
+
+	statement, err := run(context.Background(), appLogger, cfg, end)
+	if err != nil {
+		mainLog.Error("close payout period failed", logger.NewField("error", err))
+		return 1
+	}
+
+	files, err := writeStatements(outDir, statement)
+	if err != nil {
+		// период уже закрыт, ведомости можно выгрузить повторно по payout_period_id
+		mainLog.Error("write payout statements",
+			logger.NewField("payout_period_id", statement.Period.ID),
+			logger.NewField("error", err),
+		)
+		return 1
+	}
+
+	printSummary(os.Stdout, statement, files)
+	return 0
+}
+
+func run(ctx context.Context, log logger.Logger, cfg *config.Config, end time.Time) (*entities.PayoutStatement, error) {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	pool, err := postgres.NewConnPool(ctx, log, &cfg.Database)
+	if err != nil {
+		return nil, fmt.Errorf("database: %w", err)
+	}
+	defer pool.Close()
+
+	payoutApp, err := app.InitializePayoutApp(pool, pgxv5.DefaultCtxGetter)
+	if err != nil {
+		return nil, fmt.Errorf("business logic: %w", err)
+	}
+
+	statement, err := payoutApp.ServiceEarnings.ClosePayoutPeriod(ctx, end)
+	if err != nil {
+		return nil, fmt.Errorf("close payout period: %w", err)
+	}
+
+	return statement, nil
+}
+
+// writeStatements ведомость курьера: строка на доставку и итоговая строка, суммы в копейках
+func writeStatements(outDir string, statement *entities.PayoutStatement) ([]string, error) {
+	err := os.MkdirAll(outDir, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("create output directory: %w", err)
+	}
+
+	files := make([]string, 0, len(statement.Couriers))
+	for _, payout := range statement.Couriers {
+		path := filepath.Join(outDir, fmt.Sprintf("payout-%d-courier-%d.csv", statement.Period.ID, payout.CourierID))
+
+		err := writeStatement(path, payout)
+		if err != nil {
+			return files, fmt.Errorf("courier %d: %w", payout.CourierID, err)
+		}
+		files = append(files, path)
+	}
+
+	return files, nil
+}
+
+func writeStatement(path string, payout entities.CourierPayout) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	w := csv.NewWriter(file)
+	err = w.Write([]string{
+		"order_id", "completed_at", "transport_type", "zone_id", "distance_km",
+		"base_fee", "distance_fee", "peak_bonus", "late_penalty", "total",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range payout.Entries {
+		zoneID := ""
+		if entry.ZoneID != nil {
+			zoneID = strconv.FormatInt(*entry.ZoneID, 10)
+		}
+
+		err = w.Write([]string{
+			entry.OrderID,
+			entry.CompletedAt.UTC().Format(time.RFC3339),
+			entry.TransportType.String(),
+			zoneID,
+			strconv.FormatFloat(entry.DistanceKm, 'f', 2, 64),
+			strconv.FormatInt(entry.BaseFee, 10),
+			strconv.FormatInt(entry.DistanceFee, 10),
+			strconv.FormatInt(entry.PeakBonus, 10),
+			strconv.FormatInt(entry.LatePenalty, 10),
+			strconv.FormatInt(entry.Total, 10),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = w.Write([]string{"total", "", "", "", "", "", "", "", "", strconv.FormatInt(payout.Total, 10)})
+	if err != nil {
+		return err
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func printSummary(w io.Writer, statement *entities.PayoutStatement, files []string) {
+	start := "beginning"
+	if statement.Period.Start != nil {
+		start = statement.Period.Start.UTC().Format(time.RFC3339)
+	}
+
+	var total int64
+	for _, payout := range statement.Couriers {
+		total += payout.Total
+	}
+
+	fmt.Fprintf(w, "payout period %d: %s - %s\n", statement.Period.ID, start, statement.Period.End.UTC().Format(time.RFC3339))
+	fmt.Fprintf(w, "couriers: %d\n", len(statement.Couriers))
+	fmt.Fprintf(w, "total, kopecks: %d\n", total)
+	for _, file := range files {
+		fmt.Fprintf(w, "  %s\n", file)
+	}
+}
@@ -0,0 +1,123 @@
+// normalize-phones разово приводит телефоны курьеров, сохраненные до нормализации, к E.164.
+// Запускается в окружении сервиса: регион по умолчанию берется из PHONE_DEFAULT_REGION.
+// Активные курьеры, чьи номера совпали после нормализации, и нераспознанные номера
+// не меняются и выводятся в отчет, в этом случае код выхода 2
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	stdlog "log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/avito-tech/go-transaction-manager/pgxv5"
+	"service/internal/app"
+	"service/internal/entities"
+	"service/internal/pkg/config"
+	"service/internal/pkg/dotenv"
+	"service/internal/pkg/postgres"
+	"service/pkg/logger"
+	"service/pkg/logger/zap_adapter"
+)
+
+const exitCodeProblems = 2
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "only report what would be changed")
+	flag.Parse()
+
+	// os.Exit не выполняет defer, поэтому код выхода возвращается из отдельной функции
+	os.Exit(execute(*dryRun))
+}
+
+func execute(dryRun bool) int {
+	zapLogger, err := zap_adapter.NewZapAdapter()
+	if err != nil {
+		stdlog.Fatalf("failed to initialize logger: %v", err)
+	}
+	defer func() {
+		if err := zapLogger.Sync(); err != nil {
+			stdlog.Printf("failed to sync logger: %v", err)
+		}
+	}()
+
+	var appLogger logger.Logger = zapLogger
+	mainLog := appLogger.With()
+
+	if _, err := os.Stat(".env"); err == nil {
+		if err := dotenv.Load(); err != nil {
+			mainLog.Error("failed to load .env file", logger.NewField("error", err))
+			return 1
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		mainLog.Error("load config", logger.NewField("error", err))
+		return 1
+	}
+
+	report, err := run(context.Background(), appLogger, cfg, dryRun)
+	if err != nil {
+		mainLog.Error("phone normalization failed", logger.NewField("error", err))
+		return 1
+	}
+
+	printReport(os.Stdout, report)
+	if report.HasProblems() {
+		return exitCodeProblems
+	}
+	return 0
+}
+
+func run(ctx context.Context, log logger.Logger, cfg *config.Config, dryRun bool) (*entities.PhoneNormalizationReport, error) {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	pool, err := postgres.NewConnPool(ctx, log, &cfg.Database)
+	if err != nil {
+		return nil, fmt.Errorf("database: %w", err)
+	}
+	defer pool.Close()
+
+	migrationApp, err := app.InitializePhoneMigrationApp(pool, pgxv5.DefaultCtxGetter, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("business logic: %w", err)
+	}
+
+	report, err := migrationApp.ServiceCourier.NormalizePhones(ctx, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("normalize phones: %w", err)
+	}
+
+	return report, nil
+}
+
+func printReport(w io.Writer, report *entities.PhoneNormalizationReport) {
+	updatedTitle := "updated"
+	if report.DryRun {
+		updatedTitle = "to update (dry run)"
+	}
+
+	fmt.Fprintf(w, "couriers checked: %d\n", report.Total)
+	fmt.Fprintf(w, "already in E.164: %d\n", report.AlreadyNormalized)
+	fmt.Fprintf(w, "%s: %d\n", updatedTitle, report.Updated)
+	fmt.Fprintf(w, "changed during migration, skipped: %d\n", report.Skipped)
+
+	fmt.Fprintf(w, "invalid phones: %d\n", len(report.Invalid))
+	for _, courier := range report.Invalid {
+		fmt.Fprintf(w, "  courier %d: %q\n", courier.CourierID, courier.Phone)
+	}
+
+	fmt.Fprintf(w, "collisions: %d\n", len(report.Collisions))
+	for _, collision := range report.Collisions {
+		fmt.Fprintf(w, "  %s:\n", collision.Phone)
+		for _, courier := range collision.Couriers {
+			fmt.Fprintf(w, "    courier %d: %q\n", courier.CourierID, courier.Phone)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	_ "net/http/pprof" //nolint:gosec // localhost-only ${PPROF_PORT}
 	"os"
 	"os/signal"
+	"strings"
 	"sync/atomic"
 	"syscall"
 	"time"
@@ -18,23 +19,61 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	application "service/internal/app"
 	// _ "service/internal/gateway/grpc/order"
+	"service/internal/handlers/rest/cash_reconciliation_get"
+	"service/internal/handlers/rest/courier_cash_handover_post"
+	"service/internal/handlers/rest/courier_delete"
+	"service/internal/handlers/rest/courier_earnings_get"
 	"service/internal/handlers/rest/courier_get"
+	"service/internal/handlers/rest/courier_offer_stats_get"
+	"service/internal/handlers/rest/courier_patch"
 	"service/internal/handlers/rest/courier_post"
 	"service/internal/handlers/rest/courier_put"
+	"service/internal/handlers/rest/courier_reactivate_post"
+	"service/internal/handlers/rest/courier_skills_get"
+	"service/internal/handlers/rest/courier_skills_put"
+	"service/internal/handlers/rest/courier_tariffs_get"
+	"service/internal/handlers/rest/courier_tariffs_put"
+	"service/internal/handlers/rest/couriers_export_get"
 	"service/internal/handlers/rest/couriers_get"
+	"service/internal/handlers/rest/couriers_import_post"
 	"service/internal/handlers/rest/delivery_assign_post"
+	"service/internal/handlers/rest/delivery_feedback_post"
+	"service/internal/handlers/rest/delivery_get"
+	"service/internal/handlers/rest/delivery_offer_accept_post"
+	"service/internal/handlers/rest/delivery_offer_decline_post"
+	"service/internal/handlers/rest/delivery_overdue_get"
+	"service/internal/handlers/rest/delivery_pending_get"
+	"service/internal/handlers/rest/delivery_reassign_post"
+	"service/internal/handlers/rest/delivery_schedule_delete"
+	"service/internal/handlers/rest/delivery_schedule_get"
+	"service/internal/handlers/rest/delivery_schedule_post"
+	"service/internal/handlers/rest/delivery_settings_get"
+	"service/internal/handlers/rest/delivery_settings_put"
 	"service/internal/handlers/rest/delivery_unassign_post"
 	"service/internal/handlers/rest/healthcheck_head"
+	"service/internal/handlers/rest/livez_get"
 	"service/internal/handlers/rest/ping_get"
+	"service/internal/handlers/rest/readyz_get"
+	"service/internal/handlers/rest/zone_courier_delete"
+	"service/internal/handlers/rest/zone_courier_put"
+	"service/internal/handlers/rest/zone_couriers_get"
+	"service/internal/handlers/rest/zone_delete"
+	"service/internal/handlers/rest/zone_get"
+	"service/internal/handlers/rest/zone_post"
+	"service/internal/handlers/rest/zone_put"
+	"service/internal/handlers/rest/zones_get"
 	"service/internal/pkg/config"
 	"service/internal/pkg/dotenv"
 	"service/internal/pkg/grpcclient"
+	"service/internal/pkg/kafka"
 	metrics_system "service/internal/pkg/metrics"
 	"service/internal/pkg/middlewares/graceful_shutdown"
+	"service/internal/pkg/middlewares/idempotency"
 	"service/internal/pkg/middlewares/metrics"
 	"service/internal/pkg/middlewares/rate_limiter"
 	"service/internal/pkg/middlewares/timeout"
 	"service/internal/pkg/postgres"
+	"service/pkg/health"
 	"service/pkg/logger"
 	"service/pkg/logger/zap_adapter"
 	"service/pkg/token_bucket"
@@ -113,13 +152,36 @@ func run(ctx context.Context, cfg *config.Config, log logger.Logger) error {
 		}
 	}()
 
-	businessApp, err := application.InitializeApplication(ctx, log, pool, pgxv5.DefaultCtxGetter, conn, cfg)
+	brokers := strings.Split(cfg.Kafka.Brokers, ",")
+	for i := range brokers {
+		brokers[i] = strings.TrimSpace(brokers[i])
+	}
+
+	// продюсер нужен задаче проверки дедлайнов: эскалации просроченных доставок уходят в Kafka
+	producer, err := kafka.NewSyncProducer(&cfg.Kafka, brokers)
+	if err != nil {
+		return fmt.Errorf("kafka producer: %w", err)
+	}
+	defer func() {
+		err := producer.Close()
+		if err != nil {
+			runLog.Error("failed to close kafka producer",
+				logger.NewField("error", err),
+			)
+		}
+	}()
+
+	businessApp, err := application.InitializeApplication(ctx, log, pool, pgxv5.DefaultCtxGetter, conn, producer, cfg)
 	if err != nil {
 		return fmt.Errorf("business logic: %w", err)
 	}
 
 	metrics_system.StartSystemMetricsCollector()
 
+	healthRegistry := health.NewRegistry(cfg.Healthcheck.CacheTTL)
+	healthRegistry.Register("postgres", cfg.Healthcheck.PostgresTimeout, pool.Ping)
+	healthRegistry.Register("order-service", cfg.Healthcheck.OrderServiceTimeout, grpcclient.ConnStateCheck(conn))
+
 	// ongoingCtx используется для BaseContext и не должен отменяться при SIGTERM.
 	// Он отменяется только после server.Shutdown() для завершения in-flight запросов.
 	// https://victoriametrics.com/blog/go-graceful-shutdown/#b-use-basecontext-to-provide-a-global-context-to-all-connections
@@ -129,7 +191,7 @@ func run(ctx context.Context, cfg *config.Config, log logger.Logger) error {
 	// основной http сервер
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%s", cfg.Server.Port),
-		Handler: initRouter(ongoingCtx, log, &isShuttingDown, businessApp, cfg.Server),
+		Handler: initRouter(ongoingCtx, log, &isShuttingDown, businessApp, healthRegistry, cfg.Server),
 		BaseContext: func(_ net.Listener) context.Context {
 			return ongoingCtx
 		},
@@ -226,26 +288,69 @@ func run(ctx context.Context, cfg *config.Config, log logger.Logger) error {
 	return nil
 }
 
-func initRouter(ongoingCtx context.Context, log logger.Logger, isShuttingDown *atomic.Bool, app *application.Application, cfg config.HTTPServer) http.Handler {
+func initRouter(ongoingCtx context.Context, log logger.Logger, isShuttingDown *atomic.Bool, app *application.Application, healthRegistry *health.Registry, cfg config.HTTPServer) http.Handler {
 	router := mux.NewRouter()
 
 	router.Use(graceful_shutdown.Middleware(isShuttingDown, ongoingCtx))
 
-	router.Use(timeout.Middleware(cfg.RequestTimeout))
+	// выгрузка курьеров стримится дольше таймаута запроса, дедлайн записи она продлевает сама
+	router.Use(timeout.Middleware(cfg.RequestTimeout, "/couriers/export"))
 	router.Use(metrics.Middleware(log))
 	router.Use(rate_limiter.Middleware(log, cfg.RateLimiterQPS, token_bucket.NewTokenBucket(cfg.RateLimiterQPS, float64(cfg.RateLimiterBurst))))
 	router.Handle("/metrics", promhttp.Handler())
 
+	// повтор POST запроса с тем же Idempotency-Key не выполняет действие второй раз
+	idempotent := idempotency.Middleware(log, app.ServiceIdempotency)
+
 	router.Handle("/healthcheck", healthcheck_head.New(isShuttingDown)).Methods("HEAD")
+	router.Handle("/livez", livez_get.New(log)).Methods("GET")
+	router.Handle("/readyz", readyz_get.New(log, isShuttingDown, healthRegistry)).Methods("GET")
 	router.Handle("/ping", ping_get.New(log)).Methods("GET")
 
-	router.Handle("/courier/{id}", courier_get.New(log, app.ServiceCourier)).Methods("GET")
+	router.Handle("/courier/{id}", courier_get.New(log, app.ServiceCourier, app.ServiceDeliveryRating)).Methods("GET")
 	router.Handle("/couriers", couriers_get.New(log, app.ServiceCourier)).Methods("GET")
-	router.Handle("/courier", courier_post.New(log, app.ServiceCourier)).Methods("POST")
+	// импорт без idempotent: ключ не учитывает query, а повтор и так безопасен - созданные курьеры вернутся как занятые телефоны
+	router.Handle("/couriers/import", couriers_import_post.New(log, app.ServiceCourier)).Methods("POST")
+	router.Handle("/couriers/export", couriers_export_get.New(log, app.ServiceCourier)).Methods("GET")
+	router.Handle("/courier", idempotent(courier_post.New(log, app.ServiceCourier))).Methods("POST")
 	router.Handle("/courier", courier_put.New(log, app.ServiceCourier)).Methods("PUT")
-
-	router.Handle("/delivery/assign", delivery_assign_post.New(log, app.ServiceDelivery)).Methods("POST")
-	router.Handle("/delivery/unassign", delivery_unassign_post.New(log, app.ServiceDelivery)).Methods("POST")
+	router.Handle("/courier/{id}", courier_patch.New(log, app.ServiceCourier)).Methods("PATCH")
+	router.Handle("/courier/{id}", courier_delete.New(log, app.ServiceCourier)).Methods("DELETE")
+	router.Handle("/courier/{id}/reactivate", courier_reactivate_post.New(log, app.ServiceCourier)).Methods("POST")
+	router.Handle("/courier/{id}/skills", courier_skills_get.New(log, app.ServiceCourier)).Methods("GET")
+	router.Handle("/courier/{id}/skills", courier_skills_put.New(log, app.ServiceCourier)).Methods("PUT")
+	router.Handle("/courier/{id}/offer-stats", courier_offer_stats_get.New(log, app.ServiceDelivery)).Methods("GET")
+	router.Handle("/courier/{id}/earnings", courier_earnings_get.New(log, app.ServiceEarnings)).Methods("GET")
+	router.Handle("/courier/{id}/cash/handover", idempotent(courier_cash_handover_post.New(log, app.ServiceCash))).Methods("POST")
+
+	router.Handle("/delivery/assign", idempotent(delivery_assign_post.New(log, app.ServiceDelivery, app.ServiceDeliveryETA))).Methods("POST")
+	router.Handle("/delivery/unassign", idempotent(delivery_unassign_post.New(log, app.ServiceDelivery))).Methods("POST")
+	router.Handle("/delivery/reassign", idempotent(delivery_reassign_post.New(log, app.ServiceDelivery))).Methods("POST")
+	router.Handle("/delivery/offer/{id}/accept", delivery_offer_accept_post.New(log, app.ServiceDelivery)).Methods("POST")
+	router.Handle("/delivery/offer/{id}/decline", delivery_offer_decline_post.New(log, app.ServiceDelivery)).Methods("POST")
+	router.Handle("/delivery/pending", delivery_pending_get.New(log, app.ServiceDelivery)).Methods("GET")
+	router.Handle("/delivery/overdue", delivery_overdue_get.New(log, app.ServiceOverdue)).Methods("GET")
+	router.Handle("/delivery/schedule", idempotent(delivery_schedule_post.New(log, app.ServiceDelivery))).Methods("POST")
+	router.Handle("/delivery/schedule", delivery_schedule_get.New(log, app.ServiceDelivery)).Methods("GET")
+	router.Handle("/delivery/schedule/{order_id}", delivery_schedule_delete.New(log, app.ServiceDelivery)).Methods("DELETE")
+	// регистрируется после /delivery/pending, /delivery/overdue и /delivery/schedule, иначе они попадут в order_id
+	router.Handle("/delivery/{order_id}", delivery_get.New(log, app.ServiceDelivery)).Methods("GET")
+	router.Handle("/delivery/{order_id}/feedback", delivery_feedback_post.New(log, app.ServiceDeliveryRating)).Methods("POST")
+
+	router.Handle("/admin/delivery-settings", delivery_settings_get.New(log, app.ServiceDeliverySettings)).Methods("GET")
+	router.Handle("/admin/delivery-settings", delivery_settings_put.New(log, app.ServiceDeliverySettings)).Methods("PUT")
+	router.Handle("/admin/courier-tariffs", courier_tariffs_get.New(log, app.ServiceEarnings)).Methods("GET")
+	router.Handle("/admin/courier-tariffs", courier_tariffs_put.New(log, app.ServiceEarnings)).Methods("PUT")
+	router.Handle("/admin/cash/reconciliation", cash_reconciliation_get.New(log, app.ServiceCash)).Methods("GET")
+
+	router.Handle("/zone", zone_post.New(log, app.ServiceZone)).Methods("POST")
+	router.Handle("/zones", zones_get.New(log, app.ServiceZone)).Methods("GET")
+	router.Handle("/zone/{id}", zone_get.New(log, app.ServiceZone)).Methods("GET")
+	router.Handle("/zone/{id}", zone_put.New(log, app.ServiceZone)).Methods("PUT")
+	router.Handle("/zone/{id}", zone_delete.New(log, app.ServiceZone)).Methods("DELETE")
+	router.Handle("/zone/{id}/couriers", zone_couriers_get.New(log, app.ServiceZone)).Methods("GET")
+	router.Handle("/zone/{id}/couriers/{courier_id}", zone_courier_put.New(log, app.ServiceZone)).Methods("PUT")
+	router.Handle("/zone/{id}/couriers/{courier_id}", zone_courier_delete.New(log, app.ServiceZone)).Methods("DELETE")
 
 	return router
 }
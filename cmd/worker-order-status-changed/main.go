@@ -16,14 +16,18 @@ import (
 
 	"github.com/IBM/sarama"
 	"github.com/avito-tech/go-transaction-manager/pgxv5"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"service/internal/app"
 	orderstatushandler "service/internal/handlers/kafka-consumer/order_status_changed"
 	"service/internal/handlers/rest/healthcheck_head"
+	"service/internal/handlers/rest/livez_get"
+	"service/internal/handlers/rest/readyz_get"
 	"service/internal/pkg/config"
 	"service/internal/pkg/dotenv"
 	"service/internal/pkg/grpcclient"
 	"service/internal/pkg/kafka"
 	"service/internal/pkg/postgres"
+	"service/pkg/health"
 	"service/pkg/logger"
 	"service/pkg/logger/zap_adapter"
 )
@@ -113,6 +117,29 @@ func run(ctx context.Context, log logger.Logger, cfg *config.Config) error {
 		return fmt.Errorf("business logic: %w", err)
 	}
 
+	brokers := strings.Split(cfg.Kafka.Brokers, ",")
+	for i := range brokers {
+		brokers[i] = strings.TrimSpace(brokers[i])
+	}
+
+	kafkaChecker, err := kafka.NewMetadataChecker(&cfg.Kafka, brokers)
+	if err != nil {
+		return fmt.Errorf("kafka health checker: %w", err)
+	}
+	defer func() {
+		err := kafkaChecker.Close()
+		if err != nil {
+			runLog.Error("failed to close kafka health checker",
+				logger.NewField("error", err),
+			)
+		}
+	}()
+
+	healthRegistry := health.NewRegistry(cfg.Healthcheck.CacheTTL)
+	healthRegistry.Register("postgres", cfg.Healthcheck.PostgresTimeout, pool.Ping)
+	healthRegistry.Register("order-service", cfg.Healthcheck.OrderServiceTimeout, grpcclient.ConnStateCheck(conn))
+	healthRegistry.Register("kafka", cfg.Healthcheck.KafkaTimeout, kafkaChecker.Check)
+
 	// ongoingCtx используется для BaseContext и не должен отменяться при SIGTERM.
 	// Он отменяется только после server.Shutdown() для завершения in-flight запросов.
 	// https://victoriametrics.com/blog/go-graceful-shutdown/#b-use-basecontext-to-provide-a-global-context-to-all-connections
@@ -121,7 +148,7 @@ func run(ctx context.Context, log logger.Logger, cfg *config.Config) error {
 
 	healthServer := &http.Server{
 		Addr:    fmt.Sprintf(":%s", cfg.Kafka.PortHealthcheck),
-		Handler: initHealthcheckRouter(&isShuttingDown),
+		Handler: initHealthcheckRouter(log, &isShuttingDown, healthRegistry),
 		BaseContext: func(_ net.Listener) context.Context {
 			return ongoingCtx
 		},
@@ -146,11 +173,6 @@ func run(ctx context.Context, log logger.Logger, cfg *config.Config) error {
 
 	kafkaHandler := orderstatushandler.New(log, businessApp.OrderService, cfg.Kafka.Handlers.OrderStatusChanged.ProcessTimeout)
 
-	brokers := strings.Split(cfg.Kafka.Brokers, ",")
-	for i := range brokers {
-		brokers[i] = strings.TrimSpace(brokers[i])
-	}
-
 	consumer, err := kafka.NewConsumer(
 		ctx,
 		log,
@@ -218,8 +240,12 @@ func run(ctx context.Context, log logger.Logger, cfg *config.Config) error {
 	return nil
 }
 
-func initHealthcheckRouter(isShuttingDown *atomic.Bool) http.Handler {
+func initHealthcheckRouter(log logger.Logger, isShuttingDown *atomic.Bool, healthRegistry *health.Registry) http.Handler {
 	mux := http.NewServeMux()
 	mux.Handle("/healthcheck", healthcheck_head.New(isShuttingDown))
+	mux.Handle("GET /livez", livez_get.New(log))
+	mux.Handle("GET /readyz", readyz_get.New(log, isShuttingDown, healthRegistry))
+	// заказы из Kafka назначаются здесь, поэтому метрики назначений собираются и с воркера
+	mux.Handle("/metrics", promhttp.Handler())
 	return mux
 }
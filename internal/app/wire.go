@@ -5,32 +5,102 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	orderGateway "service/internal/gateway/grpc/order"
+	escalationGateway "service/internal/gateway/kafka/escalation"
 	proto "service/internal/generated/proto/clients"
+	cash_reconciliation_get "service/internal/handlers/rest/cash_reconciliation_get"
+	courier_cash_handover_post "service/internal/handlers/rest/courier_cash_handover_post"
+	courier_delete "service/internal/handlers/rest/courier_delete"
+	courier_earnings_get "service/internal/handlers/rest/courier_earnings_get"
 	courier_get "service/internal/handlers/rest/courier_get"
+	courier_offer_stats_get "service/internal/handlers/rest/courier_offer_stats_get"
+	courier_patch "service/internal/handlers/rest/courier_patch"
 	courier_post "service/internal/handlers/rest/courier_post"
 	courier_put "service/internal/handlers/rest/courier_put"
+	courier_reactivate_post "service/internal/handlers/rest/courier_reactivate_post"
+	courier_skills_get "service/internal/handlers/rest/courier_skills_get"
+	courier_skills_put "service/internal/handlers/rest/courier_skills_put"
+	courier_tariffs_get "service/internal/handlers/rest/courier_tariffs_get"
+	courier_tariffs_put "service/internal/handlers/rest/courier_tariffs_put"
+	couriers_export_get "service/internal/handlers/rest/couriers_export_get"
 	couriers_get "service/internal/handlers/rest/couriers_get"
+	couriers_import_post "service/internal/handlers/rest/couriers_import_post"
 	delivery_assign_post "service/internal/handlers/rest/delivery_assign_post"
+	delivery_feedback_post "service/internal/handlers/rest/delivery_feedback_post"
+	delivery_get "service/internal/handlers/rest/delivery_get"
+	delivery_offer_accept_post "service/internal/handlers/rest/delivery_offer_accept_post"
+	delivery_offer_decline_post "service/internal/handlers/rest/delivery_offer_decline_post"
+	delivery_overdue_get "service/internal/handlers/rest/delivery_overdue_get"
+	delivery_pending_get "service/internal/handlers/rest/delivery_pending_get"
+	delivery_reassign_post "service/internal/handlers/rest/delivery_reassign_post"
+	delivery_schedule_delete "service/internal/handlers/rest/delivery_schedule_delete"
+	delivery_schedule_get "service/internal/handlers/rest/delivery_schedule_get"
+	delivery_schedule_post "service/internal/handlers/rest/delivery_schedule_post"
+	delivery_settings_get "service/internal/handlers/rest/delivery_settings_get"
+	delivery_settings_put "service/internal/handlers/rest/delivery_settings_put"
 	delivery_unassign_post "service/internal/handlers/rest/delivery_unassign_post"
+	zone_courier_delete "service/internal/handlers/rest/zone_courier_delete"
+	zone_courier_put "service/internal/handlers/rest/zone_courier_put"
+	zone_couriers_get "service/internal/handlers/rest/zone_couriers_get"
+	zone_delete "service/internal/handlers/rest/zone_delete"
+	zone_get "service/internal/handlers/rest/zone_get"
+	zone_post "service/internal/handlers/rest/zone_post"
+	zone_put "service/internal/handlers/rest/zone_put"
+	zones_get "service/internal/handlers/rest/zones_get"
 	"service/internal/handlers/tasks/delivery_cleanup"
+	deliveryETATask "service/internal/handlers/tasks/delivery_eta"
+	"service/internal/handlers/tasks/delivery_partitions"
+	"service/internal/handlers/tasks/dispatch"
+	"service/internal/handlers/tasks/idempotency_cleanup"
+	"service/internal/handlers/tasks/offer_expiration"
+	"service/internal/handlers/tasks/pending_assignment"
+	"service/internal/handlers/tasks/pool_metrics"
+	scheduledDeliveryTask "service/internal/handlers/tasks/scheduled_delivery"
+	"service/internal/pkg/archive"
 	"service/internal/pkg/config"
 	"service/internal/pkg/factory/delivery_deadline"
 	"service/internal/pkg/factory/order_handle"
+	"service/internal/pkg/factory/order_priority"
+	"service/internal/pkg/factory/order_requirements"
+	idempotencyMiddleware "service/internal/pkg/middlewares/idempotency"
+	"service/internal/pkg/phone"
 
+	cashRepo "service/internal/repository/cash"
 	courierRepo "service/internal/repository/courier"
 	deliveryRepo "service/internal/repository/delivery"
+	deliveryETARepo "service/internal/repository/delivery_eta"
+	deliveryOfferRepo "service/internal/repository/delivery_offer"
+	deliveryPartitionRepo "service/internal/repository/delivery_partition"
+	deliveryRatingRepo "service/internal/repository/delivery_rating"
+	deliverySettingsRepo "service/internal/repository/delivery_settings"
+	earningsRepo "service/internal/repository/earnings"
+	idempotencyRepo "service/internal/repository/idempotency_key"
+	pendingRepo "service/internal/repository/pending_assignment"
+	scheduledDeliveryRepo "service/internal/repository/scheduled_delivery"
+	zoneRepo "service/internal/repository/zone"
+	cashService "service/internal/service/cash"
 	courierService "service/internal/service/courier"
 	deliveryService "service/internal/service/delivery"
+	deliveryETAService "service/internal/service/delivery_eta"
+	deliveryPartitionService "service/internal/service/delivery_partition"
+	deliveryRatingService "service/internal/service/delivery_rating"
+	deliverySettingsService "service/internal/service/delivery_settings"
+	earningsService "service/internal/service/earnings"
+	idempotencyService "service/internal/service/idempotency"
 	orderService "service/internal/service/order"
+	overdueService "service/internal/service/overdue"
+	zoneService "service/internal/service/zone"
 
 	"service/pkg/background"
 	"service/pkg/logger"
+	"service/pkg/notifier"
 	"service/pkg/querier"
 	"service/pkg/tx"
 
+	"github.com/IBM/sarama"
 	"github.com/avito-tech/go-transaction-manager/pgxv5"
 	"github.com/google/wire"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -38,25 +108,98 @@ import (
 )
 
 type (
-	CleanupInterval time.Duration
+	CleanupInterval             time.Duration
+	PendingAssignmentInterval   time.Duration
+	IdempotencyKeyTTL           time.Duration
+	IdempotencyCleanupInterval  time.Duration
+	PoolMetricsInterval         time.Duration
+	DeliveryPartitionsInterval  time.Duration
+	OfferExpirationInterval     time.Duration
+	DispatchInterval            time.Duration
+	ScheduledDeliveriesInterval time.Duration
+	DeliveryETAInterval         time.Duration
 )
 
 type Application struct {
-	ServiceCourier    ServiceCourier
-	ServiceDelivery   ServiceDelivery
-	BackgroundWorkers *background.Worker
+	ServiceCash             ServiceCash
+	ServiceCourier          ServiceCourier
+	ServiceDelivery         ServiceDelivery
+	ServiceDeliveryETA      ServiceDeliveryETA
+	ServiceDeliveryRating   ServiceDeliveryRating
+	ServiceDeliverySettings ServiceDeliverySettings
+	ServiceEarnings         ServiceEarnings
+	ServiceOverdue          ServiceOverdue
+	ServiceZone             ServiceZone
+	ServiceIdempotency      idempotencyMiddleware.Service
+	BackgroundWorkers       *background.Worker
 }
 
 type ServiceCourier interface {
 	courier_get.Service
 	courier_post.Service
 	courier_put.Service
+	courier_patch.Service
+	courier_delete.Service
+	courier_reactivate_post.Service
+	courier_skills_get.Service
+	courier_skills_put.Service
 	couriers_get.Service
+	couriers_import_post.Service
+	couriers_export_get.Service
 }
 
 type ServiceDelivery interface {
 	delivery_assign_post.Service
 	delivery_unassign_post.Service
+	delivery_reassign_post.Service
+	delivery_pending_get.Service
+	delivery_get.Service
+	delivery_offer_accept_post.Service
+	delivery_offer_decline_post.Service
+	delivery_schedule_post.Service
+	delivery_schedule_get.Service
+	delivery_schedule_delete.Service
+	courier_offer_stats_get.Service
+}
+
+type ServiceDeliveryETA interface {
+	delivery_assign_post.ETAService
+}
+
+type ServiceDeliveryRating interface {
+	delivery_feedback_post.Service
+	courier_get.RatingService
+}
+
+type ServiceOverdue interface {
+	delivery_overdue_get.Service
+}
+
+type ServiceZone interface {
+	zone_post.Service
+	zones_get.Service
+	zone_get.Service
+	zone_put.Service
+	zone_delete.Service
+	zone_couriers_get.Service
+	zone_courier_put.Service
+	zone_courier_delete.Service
+}
+
+type ServiceDeliverySettings interface {
+	delivery_settings_get.Service
+	delivery_settings_put.Service
+}
+
+type ServiceCash interface {
+	courier_cash_handover_post.Service
+	cash_reconciliation_get.Service
+}
+
+type ServiceEarnings interface {
+	courier_earnings_get.Service
+	courier_tariffs_get.Service
+	courier_tariffs_put.Service
 }
 
 // InitializeApplication для HTTP сервиса (cmd/service)
@@ -66,21 +209,76 @@ func InitializeApplication(
 	pool *pgxpool.Pool,
 	getter *pgxv5.CtxGetter,
 	conn *grpc.ClientConn,
+	producer sarama.SyncProducer,
 	cfg *config.Config,
 ) (*Application, error) {
 	wire.Build(
 		provideTxManager,
 		provideQuerier,
 		provideCleanupInterval,
+		providePendingAssignmentInterval,
+		provideAvailabilityNotifier,
 
 		provideCourierRepository,
 		provideDeliveryRepository,
+		providePendingRepository,
+		provideDeliveryOfferRepository,
+		provideScheduledDeliveryRepository,
+		provideDeliverySettingsRepository,
+		provideZoneRepository,
+		provideIdempotencyRepository,
+		provideDeliveryPartitionRepository,
+		provideDeliveryETARepository,
+		provideDeliveryRatingRepository,
+		provideEarningsRepository,
+		provideCashRepository,
+		provideArchiveStorage,
 
 		provideServiceCourier,
+		providePhoneNormalizer,
 		provideServiceDelivery,
-		delivery_deadline.New,
+		provideZonePolicy,
+		provideOfferPolicy,
+		provideBatchPolicy,
+		provideDispatchPolicy,
+		providePriorityPolicy,
+		provideRatingPolicy,
+		provideCashPolicy,
+		provideServiceZone,
+		provideServiceDeliverySettings,
+		provideServiceIdempotency,
+		provideServiceOverdue,
+		provideOverdueReleasePolicy,
+		provideEscalationGateway,
+		provideServiceDeliveryPartition,
+		provideDeliveryPartitionPolicy,
+		provideServiceDeliveryETA,
+		provideDeliveryETAPolicy,
+		provideServiceDeliveryRating,
+		provideDeliveryRatingPolicy,
+		provideServiceEarnings,
+		provideServiceCash,
+		provideDeliveryCashPolicy,
+		provideDeliveryTimeFactory,
+
+		provideIdempotencyKeyTTL,
+		provideIdempotencyCleanupInterval,
+		providePoolMetricsInterval,
+		provideDeliveryPartitionsInterval,
+		provideOfferExpirationInterval,
+		provideDispatchInterval,
+		provideScheduledDeliveriesInterval,
+		provideDeliveryETAInterval,
 
 		provideDeliveryCleanupTask,
+		providePendingAssignmentTask,
+		provideIdempotencyCleanupTask,
+		providePoolMetricsTask,
+		provideDeliveryPartitionsTask,
+		provideOfferExpirationTask,
+		provideDispatchTask,
+		provideScheduledDeliveryTask,
+		provideDeliveryETATask,
 		provideTaskList,
 		provideBackgroundWorkers,
 
@@ -88,22 +286,70 @@ func InitializeApplication(
 
 		wire.Bind(new(ServiceCourier), new(*courierService.Courier)),
 		wire.Bind(new(ServiceDelivery), new(*deliveryService.Delivery)),
+		wire.Bind(new(ServiceDeliveryETA), new(*deliveryETAService.DeliveryETA)),
+		wire.Bind(new(ServiceDeliveryRating), new(*deliveryRatingService.DeliveryRating)),
+		wire.Bind(new(ServiceDeliverySettings), new(*deliverySettingsService.DeliverySettings)),
+		wire.Bind(new(ServiceEarnings), new(*earningsService.Earnings)),
+		wire.Bind(new(ServiceCash), new(*cashService.Cash)),
+		wire.Bind(new(ServiceZone), new(*zoneService.Zone)),
+		wire.Bind(new(ServiceOverdue), new(*overdueService.Overdue)),
+		wire.Bind(new(idempotencyMiddleware.Service), new(*idempotencyService.Idempotency)),
 
 		wire.Bind(new(courierService.Repository), new(*courierRepo.Repository)),
 		wire.Bind(new(deliveryService.Repository), new(*deliveryRepo.Repository)),
+		wire.Bind(new(deliveryService.PendingRepository), new(*pendingRepo.Repository)),
+		wire.Bind(new(deliveryService.OfferRepository), new(*deliveryOfferRepo.Repository)),
+		wire.Bind(new(deliveryService.ScheduledRepository), new(*scheduledDeliveryRepo.Repository)),
 		wire.Bind(new(deliveryService.CourierService), new(*courierService.Courier)),
 		wire.Bind(new(deliveryService.DeliveryTimeFactory), new(*delivery_deadline.DeliveryTimeFactory)),
+		wire.Bind(new(delivery_deadline.SettingsRepository), new(*deliverySettingsRepo.Repository)),
+		wire.Bind(new(delivery_deadline.ETAPredictor), new(*deliveryETAService.DeliveryETA)),
+		wire.Bind(new(courierService.AvailabilityNotifier), new(*notifier.Notifier)),
+		wire.Bind(new(courierService.PhoneNormalizer), new(*phone.Normalizer)),
+		wire.Bind(new(deliveryService.AvailabilityNotifier), new(*notifier.Notifier)),
+		wire.Bind(new(deliveryService.ZoneResolver), new(*zoneService.Zone)),
+		wire.Bind(new(zoneService.Repository), new(*zoneRepo.Repository)),
 
 		wire.Bind(new(courierService.TxManager), new(*tx.Manager)),
 		wire.Bind(new(deliveryService.TxManager), new(*tx.Manager)),
-
-		wire.Bind(new(delivery_cleanup.Service), new(*deliveryService.Delivery)),
+		wire.Bind(new(deliverySettingsService.Repository), new(*deliverySettingsRepo.Repository)),
+		wire.Bind(new(deliverySettingsService.TxManager), new(*tx.Manager)),
+		wire.Bind(new(idempotencyService.Repository), new(*idempotencyRepo.Repository)),
+		wire.Bind(new(overdueService.Repository), new(*deliveryRepo.Repository)),
+		wire.Bind(new(overdueService.Escalator), new(*escalationGateway.Gateway)),
+		wire.Bind(new(overdueService.TxManager), new(*tx.Manager)),
+		wire.Bind(new(overdueService.AvailabilityNotifier), new(*notifier.Notifier)),
+		wire.Bind(new(deliveryPartitionService.Repository), new(*deliveryPartitionRepo.Repository)),
+		wire.Bind(new(deliveryPartitionService.ArchiveStorage), new(*archive.JSONLStorage)),
+		wire.Bind(new(deliveryPartitionService.TxManager), new(*tx.Manager)),
+		wire.Bind(new(deliveryETAService.Repository), new(*deliveryETARepo.Repository)),
+		wire.Bind(new(deliveryETAService.TxManager), new(*tx.Manager)),
+		wire.Bind(new(deliveryRatingService.Repository), new(*deliveryRatingRepo.Repository)),
+		wire.Bind(new(deliveryService.EarningsRecorder), new(*earningsService.Earnings)),
+		wire.Bind(new(earningsService.Repository), new(*earningsRepo.Repository)),
+		wire.Bind(new(earningsService.SettingsRepository), new(*deliverySettingsRepo.Repository)),
+		wire.Bind(new(earningsService.ZoneResolver), new(*zoneService.Zone)),
+		wire.Bind(new(earningsService.TxManager), new(*tx.Manager)),
+		wire.Bind(new(deliveryService.CashRecorder), new(*cashService.Cash)),
+		wire.Bind(new(cashService.Repository), new(*cashRepo.Repository)),
+		wire.Bind(new(cashService.TxManager), new(*tx.Manager)),
+
+		wire.Bind(new(delivery_cleanup.Service), new(*overdueService.Overdue)),
+		wire.Bind(new(pending_assignment.Service), new(*deliveryService.Delivery)),
+		wire.Bind(new(offer_expiration.Service), new(*deliveryService.Delivery)),
+		wire.Bind(new(dispatch.Service), new(*deliveryService.Delivery)),
+		wire.Bind(new(scheduledDeliveryTask.Service), new(*deliveryService.Delivery)),
+		wire.Bind(new(idempotency_cleanup.Service), new(*idempotencyService.Idempotency)),
+		wire.Bind(new(pool_metrics.Service), new(*deliveryService.Delivery)),
+		wire.Bind(new(delivery_partitions.Service), new(*deliveryPartitionService.DeliveryPartition)),
+		wire.Bind(new(deliveryETATask.Service), new(*deliveryETAService.DeliveryETA)),
 	)
 	return &Application{}, nil
 }
 
 type KafkaWorkerApp struct {
-	OrderService *orderService.Service
+	OrderService      *orderService.Service
+	BackgroundWorkers *background.Worker
 }
 
 // InitializeKafkaWorkerApp для Kafka воркера (cmd/worker-order-status-changed)
@@ -118,33 +364,148 @@ func InitializeKafkaWorkerApp(
 	wire.Build(
 		provideTxManager,
 		provideQuerier,
+		providePendingAssignmentInterval,
+		provideAvailabilityNotifier,
 
 		provideCourierRepository,
 		provideDeliveryRepository,
+		providePendingRepository,
+		provideDeliveryOfferRepository,
+		provideScheduledDeliveryRepository,
+		provideDeliverySettingsRepository,
+		provideZoneRepository,
+		provideDeliveryETARepository,
+		provideEarningsRepository,
+		provideCashRepository,
 
 		provideServiceCourier,
+		providePhoneNormalizer,
 		provideServiceDelivery,
-		delivery_deadline.New,
+		provideZonePolicy,
+		provideOfferPolicy,
+		provideBatchPolicy,
+		provideDispatchPolicy,
+		providePriorityPolicy,
+		provideRatingPolicy,
+		provideCashPolicy,
+		provideServiceZone,
+		provideServiceDeliveryETA,
+		provideDeliveryETAPolicy,
+		provideServiceEarnings,
+		provideServiceCash,
+		provideDeliveryCashPolicy,
+		provideDeliveryTimeFactory,
+
+		// заказы из очереди ожидания назначаются и в воркере: здесь курьеры освобождаются по событиям Kafka
+		providePendingAssignmentTask,
+		provideKafkaWorkerTaskList,
+		provideBackgroundWorkers,
 
 		provideOrderServiceClient,
 		provideOrderGateway,
 		provideStatusHandlerFabric,
+		provideOrderRequirementsFactory,
+		provideOrderPriorityFactory,
 		provideOrderService,
 
 		wire.Bind(new(courierService.Repository), new(*courierRepo.Repository)),
 		wire.Bind(new(deliveryService.Repository), new(*deliveryRepo.Repository)),
+		wire.Bind(new(deliveryService.PendingRepository), new(*pendingRepo.Repository)),
+		wire.Bind(new(deliveryService.OfferRepository), new(*deliveryOfferRepo.Repository)),
+		wire.Bind(new(deliveryService.ScheduledRepository), new(*scheduledDeliveryRepo.Repository)),
 		wire.Bind(new(deliveryService.CourierService), new(*courierService.Courier)),
 		wire.Bind(new(deliveryService.DeliveryTimeFactory), new(*delivery_deadline.DeliveryTimeFactory)),
+		wire.Bind(new(delivery_deadline.SettingsRepository), new(*deliverySettingsRepo.Repository)),
+		wire.Bind(new(delivery_deadline.ETAPredictor), new(*deliveryETAService.DeliveryETA)),
+		wire.Bind(new(courierService.AvailabilityNotifier), new(*notifier.Notifier)),
+		wire.Bind(new(courierService.PhoneNormalizer), new(*phone.Normalizer)),
+		wire.Bind(new(deliveryService.AvailabilityNotifier), new(*notifier.Notifier)),
+		wire.Bind(new(deliveryService.ZoneResolver), new(*zoneService.Zone)),
+		wire.Bind(new(zoneService.Repository), new(*zoneRepo.Repository)),
 		wire.Bind(new(orderService.HandlerFactory), new(*order_handle.StatusHandlerFactory)),
+		wire.Bind(new(order_handle.RequirementsFactory), new(*order_requirements.RequirementsFactory)),
+		wire.Bind(new(order_handle.PriorityFactory), new(*order_priority.PriorityFactory)),
 
 		wire.Bind(new(courierService.TxManager), new(*tx.Manager)),
 		wire.Bind(new(deliveryService.TxManager), new(*tx.Manager)),
 
+		wire.Bind(new(deliveryETAService.Repository), new(*deliveryETARepo.Repository)),
+		wire.Bind(new(deliveryETAService.TxManager), new(*tx.Manager)),
+		wire.Bind(new(deliveryService.EarningsRecorder), new(*earningsService.Earnings)),
+		wire.Bind(new(earningsService.Repository), new(*earningsRepo.Repository)),
+		wire.Bind(new(earningsService.SettingsRepository), new(*deliverySettingsRepo.Repository)),
+		wire.Bind(new(earningsService.ZoneResolver), new(*zoneService.Zone)),
+		wire.Bind(new(earningsService.TxManager), new(*tx.Manager)),
+		wire.Bind(new(deliveryService.CashRecorder), new(*cashService.Cash)),
+		wire.Bind(new(cashService.Repository), new(*cashRepo.Repository)),
+		wire.Bind(new(cashService.TxManager), new(*tx.Manager)),
+
+		wire.Bind(new(pending_assignment.Service), new(*deliveryService.Delivery)),
+
 		wire.Struct(new(KafkaWorkerApp), "*"),
 	)
 	return nil, nil
 }
 
+type PhoneMigrationApp struct {
+	ServiceCourier *courierService.Courier
+}
+
+// InitializePhoneMigrationApp для разовой нормализации телефонов (cmd/normalize-phones)
+func InitializePhoneMigrationApp(
+	pool *pgxpool.Pool,
+	getter *pgxv5.CtxGetter,
+	cfg *config.Config,
+) (*PhoneMigrationApp, error) {
+	wire.Build(
+		provideTxManager,
+		provideQuerier,
+		provideAvailabilityNotifier,
+
+		provideCourierRepository,
+		provideServiceCourier,
+		providePhoneNormalizer,
+
+		wire.Bind(new(courierService.Repository), new(*courierRepo.Repository)),
+		wire.Bind(new(courierService.TxManager), new(*tx.Manager)),
+		wire.Bind(new(courierService.AvailabilityNotifier), new(*notifier.Notifier)),
+		wire.Bind(new(courierService.PhoneNormalizer), new(*phone.Normalizer)),
+
+		wire.Struct(new(PhoneMigrationApp), "*"),
+	)
+	return nil, nil
+}
+
+type PayoutApp struct {
+	ServiceEarnings *earningsService.Earnings
+}
+
+// InitializePayoutApp для закрытия расчетного периода (cmd/close-payout-period)
+func InitializePayoutApp(
+	pool *pgxpool.Pool,
+	getter *pgxv5.CtxGetter,
+) (*PayoutApp, error) {
+	wire.Build(
+		provideTxManager,
+		provideQuerier,
+
+		provideEarningsRepository,
+		provideDeliverySettingsRepository,
+		provideZoneRepository,
+		provideServiceZone,
+		provideServiceEarnings,
+
+		wire.Bind(new(earningsService.Repository), new(*earningsRepo.Repository)),
+		wire.Bind(new(earningsService.SettingsRepository), new(*deliverySettingsRepo.Repository)),
+		wire.Bind(new(earningsService.ZoneResolver), new(*zoneService.Zone)),
+		wire.Bind(new(earningsService.TxManager), new(*tx.Manager)),
+		wire.Bind(new(zoneService.Repository), new(*zoneRepo.Repository)),
+
+		wire.Struct(new(PayoutApp), "*"),
+	)
+	return nil, nil
+}
+
 func provideTxManager(pool *pgxpool.Pool) *tx.Manager {
 	return tx.New(pool)
 }
@@ -161,31 +522,327 @@ func provideDeliveryRepository(querier *querier.Querier) *deliveryRepo.Repositor
 	return deliveryRepo.New(querier)
 }
 
+func providePendingRepository(querier *querier.Querier) *pendingRepo.Repository {
+	return pendingRepo.New(querier)
+}
+
+func provideDeliveryOfferRepository(querier *querier.Querier) *deliveryOfferRepo.Repository {
+	return deliveryOfferRepo.New(querier)
+}
+
+func provideScheduledDeliveryRepository(querier *querier.Querier) *scheduledDeliveryRepo.Repository {
+	return scheduledDeliveryRepo.New(querier)
+}
+
+func provideDeliverySettingsRepository(querier *querier.Querier) *deliverySettingsRepo.Repository {
+	return deliverySettingsRepo.New(querier)
+}
+
+func provideZoneRepository(querier *querier.Querier) *zoneRepo.Repository {
+	return zoneRepo.New(querier)
+}
+
+func provideIdempotencyRepository(querier *querier.Querier) *idempotencyRepo.Repository {
+	return idempotencyRepo.New(querier)
+}
+
+func provideDeliveryPartitionRepository(querier *querier.Querier) *deliveryPartitionRepo.Repository {
+	return deliveryPartitionRepo.New(querier)
+}
+
+func provideDeliveryETARepository(querier *querier.Querier) *deliveryETARepo.Repository {
+	return deliveryETARepo.New(querier)
+}
+
+func provideDeliveryRatingRepository(querier *querier.Querier) *deliveryRatingRepo.Repository {
+	return deliveryRatingRepo.New(querier)
+}
+
+func provideEarningsRepository(querier *querier.Querier) *earningsRepo.Repository {
+	return earningsRepo.New(querier)
+}
+
+func provideCashRepository(querier *querier.Querier) *cashRepo.Repository {
+	return cashRepo.New(querier)
+}
+
+func provideArchiveStorage(cfg *config.Config) *archive.JSONLStorage {
+	return archive.NewJSONLStorage(cfg.Partitions.ArchiveDir)
+}
+
+// provideAvailabilityNotifier общий сигнал "освободился курьер" для сервисов и задачи разбора очереди ожидания
+func provideAvailabilityNotifier() *notifier.Notifier {
+	return notifier.New()
+}
+
 func provideServiceCourier(
 	repository courierService.Repository,
 	txManager courierService.TxManager,
+	availabilityNotifier courierService.AvailabilityNotifier,
+	phones courierService.PhoneNormalizer,
 ) *courierService.Courier {
-	return courierService.New(repository, txManager)
+	return courierService.New(repository, txManager, availabilityNotifier, phones)
+}
+
+func providePhoneNormalizer(cfg *config.Config) (*phone.Normalizer, error) {
+	normalizer, err := phone.NewNormalizer(cfg.Phone.DefaultRegion)
+	if err != nil {
+		return nil, fmt.Errorf("phone normalizer: %w", err)
+	}
+
+	return normalizer, nil
 }
 
 func provideServiceDelivery(
 	repository deliveryService.Repository,
+	pendingRepository deliveryService.PendingRepository,
 	courierService deliveryService.CourierService,
 	timeFactory deliveryService.DeliveryTimeFactory,
 	txManager deliveryService.TxManager,
+	availabilityNotifier deliveryService.AvailabilityNotifier,
+	zones deliveryService.ZoneResolver,
+	zonePolicy deliveryService.ZonePolicy,
+	offerRepository deliveryService.OfferRepository,
+	offerPolicy deliveryService.OfferPolicy,
+	batchPolicy deliveryService.BatchPolicy,
+	dispatchPolicy deliveryService.DispatchPolicy,
+	priorityPolicy deliveryService.PriorityPolicy,
+	scheduledRepository deliveryService.ScheduledRepository,
+	ratingPolicy deliveryService.RatingPolicy,
+	earningsRecorder deliveryService.EarningsRecorder,
+	cashRecorder deliveryService.CashRecorder,
+	cashPolicy deliveryService.CashPolicy,
 ) *deliveryService.Delivery {
 	return deliveryService.New(
 		repository,
+		pendingRepository,
 		courierService,
 		timeFactory,
 		txManager,
+		availabilityNotifier,
+		zones,
+		zonePolicy,
+		offerRepository,
+		offerPolicy,
+		batchPolicy,
+		dispatchPolicy,
+		priorityPolicy,
+		scheduledRepository,
+		ratingPolicy,
+		earningsRecorder,
+		cashRecorder,
+		cashPolicy,
 	)
 }
 
+func provideZonePolicy(cfg *config.Config) deliveryService.ZonePolicy {
+	return deliveryService.ZonePolicy{
+		CrossZoneFallback: cfg.Zones.CrossZoneFallback,
+	}
+}
+
+func provideOfferPolicy(cfg *config.Config) deliveryService.OfferPolicy {
+	return deliveryService.OfferPolicy{
+		Enabled:         cfg.Offers.Enabled,
+		Timeout:         cfg.Offers.Timeout,
+		DeclineCooldown: cfg.Offers.DeclineCooldown,
+	}
+}
+
+func provideBatchPolicy(cfg *config.Config) deliveryService.BatchPolicy {
+	return deliveryService.BatchPolicy{
+		Window:        cfg.Batching.Window,
+		MaxOrders:     cfg.Batching.MaxOrders,
+		ExtraStopTime: cfg.Batching.ExtraStopTime,
+	}
+}
+
+func provideDispatchPolicy(cfg *config.Config) deliveryService.DispatchPolicy {
+	return deliveryService.DispatchPolicy{
+		Enabled:     cfg.Dispatch.Enabled,
+		MaxOrders:   cfg.Dispatch.MaxOrders,
+		MaxCouriers: cfg.Dispatch.MaxCouriers,
+		SolveBudget: cfg.Dispatch.SolveBudget,
+	}
+}
+
+func providePriorityPolicy(cfg *config.Config) deliveryService.PriorityPolicy {
+	return deliveryService.PriorityPolicy{
+		PreemptWithin: cfg.Priority.PreemptWithin,
+	}
+}
+
+func provideRatingPolicy(cfg *config.Config) deliveryService.RatingPolicy {
+	return deliveryService.RatingPolicy{
+		PreferTopRated: cfg.Rating.PreferTopRated,
+		Window:         cfg.Rating.Window,
+	}
+}
+
+func provideServiceZone(repository zoneService.Repository) *zoneService.Zone {
+	return zoneService.New(repository)
+}
+
+func provideServiceOverdue(
+	repository overdueService.Repository,
+	escalator overdueService.Escalator,
+	txManager overdueService.TxManager,
+	availabilityNotifier overdueService.AvailabilityNotifier,
+	policy overdueService.ReleasePolicy,
+) *overdueService.Overdue {
+	return overdueService.New(repository, escalator, txManager, availabilityNotifier, policy)
+}
+
+func provideOverdueReleasePolicy(cfg *config.Config) overdueService.ReleasePolicy {
+	return overdueService.ReleasePolicy{
+		AutoRelease: cfg.Overdue.AutoRelease,
+		GracePeriod: cfg.Overdue.ReleaseGracePeriod,
+	}
+}
+
+func provideEscalationGateway(producer sarama.SyncProducer, cfg *config.Config) *escalationGateway.Gateway {
+	return escalationGateway.New(producer, cfg.Kafka.OverdueTopic)
+}
+
+func provideServiceDeliveryPartition(
+	repository deliveryPartitionService.Repository,
+	storage deliveryPartitionService.ArchiveStorage,
+	txManager deliveryPartitionService.TxManager,
+	policy deliveryPartitionService.Policy,
+) *deliveryPartitionService.DeliveryPartition {
+	return deliveryPartitionService.New(repository, storage, txManager, policy)
+}
+
+func provideDeliveryPartitionPolicy(cfg *config.Config) deliveryPartitionService.Policy {
+	return deliveryPartitionService.Policy{
+		PremakeMonths:   cfg.Partitions.PremakeMonths,
+		RetentionMonths: cfg.Partitions.RetentionMonths,
+		ArchiveMode:     deliveryPartitionService.ArchiveMode(cfg.Partitions.ArchiveMode),
+	}
+}
+
+func provideServiceDeliveryETA(
+	repository deliveryETAService.Repository,
+	txManager deliveryETAService.TxManager,
+	policy deliveryETAService.Policy,
+) *deliveryETAService.DeliveryETA {
+	return deliveryETAService.New(repository, txManager, policy)
+}
+
+func provideDeliveryETAPolicy(cfg *config.Config) deliveryETAService.Policy {
+	return deliveryETAService.Policy{
+		RefreshHour: cfg.ETA.RefreshHour,
+		History:     cfg.ETA.History,
+		MinSamples:  cfg.ETA.MinSamples,
+	}
+}
+
+func provideServiceDeliveryRating(
+	repository deliveryRatingService.Repository,
+	policy deliveryRatingService.Policy,
+) *deliveryRatingService.DeliveryRating {
+	return deliveryRatingService.New(repository, policy)
+}
+
+func provideDeliveryRatingPolicy(cfg *config.Config) deliveryRatingService.Policy {
+	return deliveryRatingService.Policy{
+		Window: cfg.Rating.Window,
+	}
+}
+
+func provideServiceEarnings(
+	repository earningsService.Repository,
+	settingsRepository earningsService.SettingsRepository,
+	zones earningsService.ZoneResolver,
+	txManager earningsService.TxManager,
+) *earningsService.Earnings {
+	return earningsService.New(repository, settingsRepository, zones, txManager)
+}
+
+func provideServiceCash(
+	repository cashService.Repository,
+	txManager cashService.TxManager,
+	policy cashService.Policy,
+) *cashService.Cash {
+	return cashService.New(repository, txManager, policy)
+}
+
+func provideCashPolicy(cfg *config.Config) cashService.Policy {
+	return cashService.Policy{
+		BalanceLimit: int64(cfg.Cash.BalanceLimit),
+	}
+}
+
+func provideDeliveryCashPolicy(cfg *config.Config) deliveryService.CashPolicy {
+	return deliveryService.CashPolicy{
+		BalanceLimit: int64(cfg.Cash.BalanceLimit),
+	}
+}
+
+func provideDeliveryTimeFactory(
+	settingsRepository delivery_deadline.SettingsRepository,
+	etaPredictor delivery_deadline.ETAPredictor,
+	cfg *config.Config,
+) *delivery_deadline.DeliveryTimeFactory {
+	return delivery_deadline.New(settingsRepository, etaPredictor, delivery_deadline.Policy{
+		UseETAP90: cfg.ETA.DeadlineP90,
+	})
+}
+
 func provideCleanupInterval(cfg *config.Config) CleanupInterval {
 	return CleanupInterval(cfg.Tasks.CouriersStatusUpdateInterval)
 }
 
+func provideServiceDeliverySettings(
+	repository deliverySettingsService.Repository,
+	txManager deliverySettingsService.TxManager,
+) *deliverySettingsService.DeliverySettings {
+	return deliverySettingsService.New(repository, txManager)
+}
+
+func provideServiceIdempotency(
+	repository idempotencyService.Repository,
+	ttl IdempotencyKeyTTL,
+) *idempotencyService.Idempotency {
+	return idempotencyService.New(repository, time.Duration(ttl))
+}
+
+func provideIdempotencyKeyTTL(cfg *config.Config) IdempotencyKeyTTL {
+	return IdempotencyKeyTTL(cfg.Server.IdempotencyKeyTTL)
+}
+
+func provideIdempotencyCleanupInterval(cfg *config.Config) IdempotencyCleanupInterval {
+	return IdempotencyCleanupInterval(cfg.Tasks.IdempotencyKeysCleanupInterval)
+}
+
+func providePoolMetricsInterval(cfg *config.Config) PoolMetricsInterval {
+	return PoolMetricsInterval(cfg.Tasks.PoolMetricsRefreshInterval)
+}
+
+func provideDeliveryPartitionsInterval(cfg *config.Config) DeliveryPartitionsInterval {
+	return DeliveryPartitionsInterval(cfg.Tasks.DeliveryPartitionsInterval)
+}
+
+func provideOfferExpirationInterval(cfg *config.Config) OfferExpirationInterval {
+	return OfferExpirationInterval(cfg.Tasks.OfferExpirationInterval)
+}
+
+func provideDispatchInterval(cfg *config.Config) DispatchInterval {
+	return DispatchInterval(cfg.Tasks.DispatchInterval)
+}
+
+func provideScheduledDeliveriesInterval(cfg *config.Config) ScheduledDeliveriesInterval {
+	return ScheduledDeliveriesInterval(cfg.Tasks.ScheduledDeliveriesInterval)
+}
+
+func provideDeliveryETAInterval(cfg *config.Config) DeliveryETAInterval {
+	return DeliveryETAInterval(cfg.Tasks.DeliveryETAInterval)
+}
+
+func providePendingAssignmentInterval(cfg *config.Config) PendingAssignmentInterval {
+	return PendingAssignmentInterval(cfg.Tasks.PendingAssignmentsInterval)
+}
+
 func provideOrderServiceClient(conn *grpc.ClientConn) proto.OrdersServiceClient {
 	return proto.NewOrdersServiceClient(conn)
 }
@@ -203,23 +860,132 @@ func provideOrderService(
 	return orderService.New(orderGateway, deliveryService, handlerFactory)
 }
 
-func provideStatusHandlerFabric(deliveryService *deliveryService.Delivery) *order_handle.StatusHandlerFactory {
-	return order_handle.NewStatusHandlerFactory(deliveryService)
+func provideStatusHandlerFabric(
+	deliveryService *deliveryService.Delivery,
+	requirements order_handle.RequirementsFactory,
+	priority order_handle.PriorityFactory,
+) *order_handle.StatusHandlerFactory {
+	return order_handle.NewStatusHandlerFactory(deliveryService, requirements, priority)
+}
+
+func provideOrderRequirementsFactory(cfg *config.Config) *order_requirements.RequirementsFactory {
+	return order_requirements.New(order_requirements.Rules{
+		AgeRestrictedKeywords: cfg.Requirements.AgeRestrictedKeywords,
+		ThermalKeywords:       cfg.Requirements.ThermalKeywords,
+		LargeOrderItems:       int64(cfg.Requirements.LargeOrderItems),
+		LargeOrderTotalPrice:  int64(cfg.Requirements.LargeOrderTotalPrice),
+	})
+}
+
+func provideOrderPriorityFactory(cfg *config.Config) *order_priority.PriorityFactory {
+	return order_priority.New(order_priority.Rules{
+		UrgentKeywords: cfg.Priority.UrgentKeywords,
+		HighTotalPrice: int64(cfg.Priority.HighTotalPrice),
+	})
 }
 
 func provideDeliveryCleanupTask(
 	log logger.Logger,
-	deliveryService delivery_cleanup.Service,
+	overdueService delivery_cleanup.Service,
 	interval CleanupInterval,
 ) *delivery_cleanup.DeliveryCleanup {
-	return delivery_cleanup.NewDeliveryCleanup(log, deliveryService, time.Duration(interval))
+	return delivery_cleanup.NewDeliveryCleanup(log, overdueService, time.Duration(interval))
+}
+
+func providePendingAssignmentTask(
+	log logger.Logger,
+	deliveryService pending_assignment.Service,
+	interval PendingAssignmentInterval,
+	availabilityNotifier *notifier.Notifier,
+) *pending_assignment.PendingAssignment {
+	return pending_assignment.NewPendingAssignment(log, deliveryService, time.Duration(interval), availabilityNotifier.C())
+}
+
+func provideIdempotencyCleanupTask(
+	log logger.Logger,
+	idempotencyService idempotency_cleanup.Service,
+	interval IdempotencyCleanupInterval,
+) *idempotency_cleanup.IdempotencyCleanup {
+	return idempotency_cleanup.NewIdempotencyCleanup(log, idempotencyService, time.Duration(interval))
+}
+
+func providePoolMetricsTask(
+	log logger.Logger,
+	deliveryService pool_metrics.Service,
+	interval PoolMetricsInterval,
+) *pool_metrics.PoolMetrics {
+	return pool_metrics.NewPoolMetrics(log, deliveryService, time.Duration(interval))
+}
+
+func provideDeliveryPartitionsTask(
+	log logger.Logger,
+	deliveryPartitionService delivery_partitions.Service,
+	interval DeliveryPartitionsInterval,
+) *delivery_partitions.DeliveryPartitions {
+	return delivery_partitions.NewDeliveryPartitions(log, deliveryPartitionService, time.Duration(interval))
+}
+
+func provideOfferExpirationTask(
+	log logger.Logger,
+	deliveryService offer_expiration.Service,
+	interval OfferExpirationInterval,
+) *offer_expiration.OfferExpiration {
+	return offer_expiration.NewOfferExpiration(log, deliveryService, time.Duration(interval))
+}
+
+func provideDispatchTask(
+	log logger.Logger,
+	deliveryService dispatch.Service,
+	interval DispatchInterval,
+) *dispatch.Dispatch {
+	return dispatch.NewDispatch(log, deliveryService, time.Duration(interval))
+}
+
+func provideScheduledDeliveryTask(
+	log logger.Logger,
+	deliveryService scheduledDeliveryTask.Service,
+	interval ScheduledDeliveriesInterval,
+) *scheduledDeliveryTask.ScheduledDelivery {
+	return scheduledDeliveryTask.NewScheduledDelivery(log, deliveryService, time.Duration(interval))
+}
+
+func provideDeliveryETATask(
+	log logger.Logger,
+	deliveryETAService deliveryETATask.Service,
+	interval DeliveryETAInterval,
+) *deliveryETATask.DeliveryETA {
+	return deliveryETATask.NewDeliveryETA(log, deliveryETAService, time.Duration(interval))
 }
 
 func provideTaskList(
 	deliveryCleanupTask *delivery_cleanup.DeliveryCleanup,
+	pendingAssignmentTask *pending_assignment.PendingAssignment,
+	idempotencyCleanupTask *idempotency_cleanup.IdempotencyCleanup,
+	poolMetricsTask *pool_metrics.PoolMetrics,
+	deliveryPartitionsTask *delivery_partitions.DeliveryPartitions,
+	offerExpirationTask *offer_expiration.OfferExpiration,
+	dispatchTask *dispatch.Dispatch,
+	scheduledTask *scheduledDeliveryTask.ScheduledDelivery,
+	etaTask *deliveryETATask.DeliveryETA,
 ) []background.Task {
 	return []background.Task{
 		deliveryCleanupTask,
+		pendingAssignmentTask,
+		idempotencyCleanupTask,
+		poolMetricsTask,
+		deliveryPartitionsTask,
+		offerExpirationTask,
+		dispatchTask,
+		scheduledTask,
+		etaTask,
+	}
+}
+
+func provideKafkaWorkerTaskList(
+	pendingAssignmentTask *pending_assignment.PendingAssignment,
+) []background.Task {
+	return []background.Task{
+		pendingAssignmentTask,
 	}
 }
 
@@ -8,28 +8,98 @@ package app
 
 import (
 	"context"
+	"fmt"
+	"github.com/IBM/sarama"
 	"github.com/avito-tech/go-transaction-manager/pgxv5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"google.golang.org/grpc"
 	order2 "service/internal/gateway/grpc/order"
+	"service/internal/gateway/kafka/escalation"
 	"service/internal/generated/proto/clients"
+	"service/internal/handlers/rest/cash_reconciliation_get"
+	"service/internal/handlers/rest/courier_cash_handover_post"
+	"service/internal/handlers/rest/courier_delete"
+	"service/internal/handlers/rest/courier_earnings_get"
 	"service/internal/handlers/rest/courier_get"
+	"service/internal/handlers/rest/courier_offer_stats_get"
+	"service/internal/handlers/rest/courier_patch"
 	"service/internal/handlers/rest/courier_post"
 	"service/internal/handlers/rest/courier_put"
+	"service/internal/handlers/rest/courier_reactivate_post"
+	"service/internal/handlers/rest/courier_skills_get"
+	"service/internal/handlers/rest/courier_skills_put"
+	"service/internal/handlers/rest/courier_tariffs_get"
+	"service/internal/handlers/rest/courier_tariffs_put"
+	"service/internal/handlers/rest/couriers_export_get"
 	"service/internal/handlers/rest/couriers_get"
+	"service/internal/handlers/rest/couriers_import_post"
 	"service/internal/handlers/rest/delivery_assign_post"
+	"service/internal/handlers/rest/delivery_feedback_post"
+	"service/internal/handlers/rest/delivery_get"
+	"service/internal/handlers/rest/delivery_offer_accept_post"
+	"service/internal/handlers/rest/delivery_offer_decline_post"
+	"service/internal/handlers/rest/delivery_overdue_get"
+	"service/internal/handlers/rest/delivery_pending_get"
+	"service/internal/handlers/rest/delivery_reassign_post"
+	"service/internal/handlers/rest/delivery_schedule_delete"
+	"service/internal/handlers/rest/delivery_schedule_get"
+	"service/internal/handlers/rest/delivery_schedule_post"
+	"service/internal/handlers/rest/delivery_settings_get"
+	"service/internal/handlers/rest/delivery_settings_put"
 	"service/internal/handlers/rest/delivery_unassign_post"
+	"service/internal/handlers/rest/zone_courier_delete"
+	"service/internal/handlers/rest/zone_courier_put"
+	"service/internal/handlers/rest/zone_couriers_get"
+	"service/internal/handlers/rest/zone_delete"
+	"service/internal/handlers/rest/zone_get"
+	"service/internal/handlers/rest/zone_post"
+	"service/internal/handlers/rest/zone_put"
+	"service/internal/handlers/rest/zones_get"
 	"service/internal/handlers/tasks/delivery_cleanup"
+	delivery_eta3 "service/internal/handlers/tasks/delivery_eta"
+	"service/internal/handlers/tasks/delivery_partitions"
+	"service/internal/handlers/tasks/dispatch"
+	"service/internal/handlers/tasks/idempotency_cleanup"
+	"service/internal/handlers/tasks/offer_expiration"
+	pending_assignment2 "service/internal/handlers/tasks/pending_assignment"
+	"service/internal/handlers/tasks/pool_metrics"
+	scheduled_delivery2 "service/internal/handlers/tasks/scheduled_delivery"
+	"service/internal/pkg/archive"
 	"service/internal/pkg/config"
 	"service/internal/pkg/factory/delivery_deadline"
 	"service/internal/pkg/factory/order_handle"
-	"service/internal/repository/courier"
+	"service/internal/pkg/factory/order_priority"
+	"service/internal/pkg/factory/order_requirements"
+	"service/internal/pkg/middlewares/idempotency"
+	"service/internal/pkg/phone"
+	"service/internal/repository/cash"
+	courier2 "service/internal/repository/courier"
 	"service/internal/repository/delivery"
-	courier2 "service/internal/service/courier"
+	"service/internal/repository/delivery_eta"
+	"service/internal/repository/delivery_offer"
+	"service/internal/repository/delivery_partition"
+	"service/internal/repository/delivery_rating"
+	"service/internal/repository/delivery_settings"
+	earnings2 "service/internal/repository/earnings"
+	"service/internal/repository/idempotency_key"
+	"service/internal/repository/pending_assignment"
+	"service/internal/repository/scheduled_delivery"
+	"service/internal/repository/zone"
+	cash2 "service/internal/service/cash"
+	"service/internal/service/courier"
 	delivery2 "service/internal/service/delivery"
+	delivery_eta2 "service/internal/service/delivery_eta"
+	delivery_partition2 "service/internal/service/delivery_partition"
+	delivery_rating2 "service/internal/service/delivery_rating"
+	delivery_settings2 "service/internal/service/delivery_settings"
+	"service/internal/service/earnings"
+	idempotency2 "service/internal/service/idempotency"
 	"service/internal/service/order"
+	"service/internal/service/overdue"
+	zone2 "service/internal/service/zone"
 	"service/pkg/background"
 	"service/pkg/logger"
+	"service/pkg/notifier"
 	"service/pkg/querier"
 	"service/pkg/tx"
 	"time"
@@ -38,25 +108,89 @@ import (
 // Injectors from wire.go:
 
 // InitializeApplication для HTTP сервиса (cmd/service)
-func InitializeApplication(ctx context.Context, log logger.Logger, pool *pgxpool.Pool, getter *pgxv5.CtxGetter, conn *grpc.ClientConn, cfg *config.Config) (*Application, error) {
+func InitializeApplication(ctx context.Context, log logger.Logger, pool *pgxpool.Pool, getter *pgxv5.CtxGetter, conn *grpc.ClientConn, producer sarama.SyncProducer, cfg *config.Config) (*Application, error) {
 	querier := provideQuerier(pool, getter)
-	repository := provideCourierRepository(querier)
+	repository := provideCashRepository(querier)
 	manager := provideTxManager(pool)
-	courier := provideServiceCourier(repository, manager)
+	policy := provideCashPolicy(cfg)
+	cash := provideServiceCash(repository, manager, policy)
+	courierRepository := provideCourierRepository(querier)
+	notifier := provideAvailabilityNotifier()
+	normalizer, err := providePhoneNormalizer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	courier := provideServiceCourier(courierRepository, manager, notifier, normalizer)
 	deliveryRepository := provideDeliveryRepository(querier)
-	deliveryTimeFactory := delivery_deadline.New()
-	delivery := provideServiceDelivery(deliveryRepository, courier, deliveryTimeFactory, manager)
+	pending_assignmentRepository := providePendingRepository(querier)
+	delivery_settingsRepository := provideDeliverySettingsRepository(querier)
+	delivery_etaRepository := provideDeliveryETARepository(querier)
+	delivery_etaPolicy := provideDeliveryETAPolicy(cfg)
+	deliveryETA := provideServiceDeliveryETA(delivery_etaRepository, manager, delivery_etaPolicy)
+	deliveryTimeFactory := provideDeliveryTimeFactory(delivery_settingsRepository, deliveryETA, cfg)
+	zoneRepository := provideZoneRepository(querier)
+	zone := provideServiceZone(zoneRepository)
+	zonePolicy := provideZonePolicy(cfg)
+	delivery_offerRepository := provideDeliveryOfferRepository(querier)
+	offerPolicy := provideOfferPolicy(cfg)
+	batchPolicy := provideBatchPolicy(cfg)
+	dispatchPolicy := provideDispatchPolicy(cfg)
+	priorityPolicy := providePriorityPolicy(cfg)
+	scheduled_deliveryRepository := provideScheduledDeliveryRepository(querier)
+	ratingPolicy := provideRatingPolicy(cfg)
+	earningsRepository := provideEarningsRepository(querier)
+	earnings := provideServiceEarnings(earningsRepository, delivery_settingsRepository, zone, manager)
+	cashPolicy := provideDeliveryCashPolicy(cfg)
+	delivery := provideServiceDelivery(deliveryRepository, pending_assignmentRepository, courier, deliveryTimeFactory, manager, notifier, zone, zonePolicy, delivery_offerRepository, offerPolicy, batchPolicy, dispatchPolicy, priorityPolicy, scheduled_deliveryRepository, ratingPolicy, earnings, cash, cashPolicy)
+	delivery_ratingRepository := provideDeliveryRatingRepository(querier)
+	delivery_ratingPolicy := provideDeliveryRatingPolicy(cfg)
+	deliveryRating := provideServiceDeliveryRating(delivery_ratingRepository, delivery_ratingPolicy)
+	deliverySettings := provideServiceDeliverySettings(delivery_settingsRepository, manager)
+	gateway := provideEscalationGateway(producer, cfg)
+	releasePolicy := provideOverdueReleasePolicy(cfg)
+	overdue := provideServiceOverdue(deliveryRepository, gateway, manager, notifier, releasePolicy)
+	idempotency_keyRepository := provideIdempotencyRepository(querier)
+	idempotencyKeyTTL := provideIdempotencyKeyTTL(cfg)
+	idempotency := provideServiceIdempotency(idempotency_keyRepository, idempotencyKeyTTL)
 	cleanupInterval := provideCleanupInterval(cfg)
-	deliveryCleanup := provideDeliveryCleanupTask(log, delivery, cleanupInterval)
-	v := provideTaskList(deliveryCleanup)
+	deliveryCleanup := provideDeliveryCleanupTask(log, overdue, cleanupInterval)
+	pendingAssignmentInterval := providePendingAssignmentInterval(cfg)
+	pendingAssignment := providePendingAssignmentTask(log, delivery, pendingAssignmentInterval, notifier)
+	idempotencyCleanupInterval := provideIdempotencyCleanupInterval(cfg)
+	idempotencyCleanup := provideIdempotencyCleanupTask(log, idempotency, idempotencyCleanupInterval)
+	poolMetricsInterval := providePoolMetricsInterval(cfg)
+	poolMetrics := providePoolMetricsTask(log, delivery, poolMetricsInterval)
+	delivery_partitionRepository := provideDeliveryPartitionRepository(querier)
+	jsonlStorage := provideArchiveStorage(cfg)
+	delivery_partitionPolicy := provideDeliveryPartitionPolicy(cfg)
+	deliveryPartition := provideServiceDeliveryPartition(delivery_partitionRepository, jsonlStorage, manager, delivery_partitionPolicy)
+	deliveryPartitionsInterval := provideDeliveryPartitionsInterval(cfg)
+	deliveryPartitions := provideDeliveryPartitionsTask(log, deliveryPartition, deliveryPartitionsInterval)
+	offerExpirationInterval := provideOfferExpirationInterval(cfg)
+	offerExpiration := provideOfferExpirationTask(log, delivery, offerExpirationInterval)
+	dispatchInterval := provideDispatchInterval(cfg)
+	dispatch := provideDispatchTask(log, delivery, dispatchInterval)
+	scheduledDeliveriesInterval := provideScheduledDeliveriesInterval(cfg)
+	scheduledDelivery := provideScheduledDeliveryTask(log, delivery, scheduledDeliveriesInterval)
+	deliveryETAInterval := provideDeliveryETAInterval(cfg)
+	delivery_etaDeliveryETA := provideDeliveryETATask(log, deliveryETA, deliveryETAInterval)
+	v := provideTaskList(deliveryCleanup, pendingAssignment, idempotencyCleanup, poolMetrics, deliveryPartitions, offerExpiration, dispatch, scheduledDelivery, delivery_etaDeliveryETA)
 	worker, err := provideBackgroundWorkers(ctx, log, v)
 	if err != nil {
 		return nil, err
 	}
 	application := &Application{
-		ServiceCourier:    courier,
-		ServiceDelivery:   delivery,
-		BackgroundWorkers: worker,
+		ServiceCash:             cash,
+		ServiceCourier:          courier,
+		ServiceDelivery:         delivery,
+		ServiceDeliveryETA:      deliveryETA,
+		ServiceDeliveryRating:   deliveryRating,
+		ServiceDeliverySettings: deliverySettings,
+		ServiceEarnings:         earnings,
+		ServiceOverdue:          overdue,
+		ServiceZone:             zone,
+		ServiceIdempotency:      idempotency,
+		BackgroundWorkers:       worker,
 	}
 	return application, nil
 }
@@ -67,45 +201,195 @@ func InitializeKafkaWorkerApp(ctx context.Context, log logger.Logger, pool *pgxp
 	orderGateway := provideOrderGateway(ordersServiceClient)
 	querier := provideQuerier(pool, getter)
 	repository := provideDeliveryRepository(querier)
+	pending_assignmentRepository := providePendingRepository(querier)
 	courierRepository := provideCourierRepository(querier)
 	manager := provideTxManager(pool)
-	courier := provideServiceCourier(courierRepository, manager)
-	deliveryTimeFactory := delivery_deadline.New()
-	delivery := provideServiceDelivery(repository, courier, deliveryTimeFactory, manager)
-	statusHandlerFactory := provideStatusHandlerFabric(delivery)
+	notifier := provideAvailabilityNotifier()
+	normalizer, err := providePhoneNormalizer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	courier := provideServiceCourier(courierRepository, manager, notifier, normalizer)
+	delivery_settingsRepository := provideDeliverySettingsRepository(querier)
+	delivery_etaRepository := provideDeliveryETARepository(querier)
+	policy := provideDeliveryETAPolicy(cfg)
+	deliveryETA := provideServiceDeliveryETA(delivery_etaRepository, manager, policy)
+	deliveryTimeFactory := provideDeliveryTimeFactory(delivery_settingsRepository, deliveryETA, cfg)
+	zoneRepository := provideZoneRepository(querier)
+	zone := provideServiceZone(zoneRepository)
+	zonePolicy := provideZonePolicy(cfg)
+	delivery_offerRepository := provideDeliveryOfferRepository(querier)
+	offerPolicy := provideOfferPolicy(cfg)
+	batchPolicy := provideBatchPolicy(cfg)
+	dispatchPolicy := provideDispatchPolicy(cfg)
+	priorityPolicy := providePriorityPolicy(cfg)
+	scheduled_deliveryRepository := provideScheduledDeliveryRepository(querier)
+	ratingPolicy := provideRatingPolicy(cfg)
+	earningsRepository := provideEarningsRepository(querier)
+	earnings := provideServiceEarnings(earningsRepository, delivery_settingsRepository, zone, manager)
+	cashRepository := provideCashRepository(querier)
+	cashPolicy := provideCashPolicy(cfg)
+	cash := provideServiceCash(cashRepository, manager, cashPolicy)
+	deliveryCashPolicy := provideDeliveryCashPolicy(cfg)
+	delivery := provideServiceDelivery(repository, pending_assignmentRepository, courier, deliveryTimeFactory, manager, notifier, zone, zonePolicy, delivery_offerRepository, offerPolicy, batchPolicy, dispatchPolicy, priorityPolicy, scheduled_deliveryRepository, ratingPolicy, earnings, cash, deliveryCashPolicy)
+	requirementsFactory := provideOrderRequirementsFactory(cfg)
+	priorityFactory := provideOrderPriorityFactory(cfg)
+	statusHandlerFactory := provideStatusHandlerFabric(delivery, requirementsFactory, priorityFactory)
 	service := provideOrderService(orderGateway, delivery, statusHandlerFactory)
+	pendingAssignmentInterval := providePendingAssignmentInterval(cfg)
+	pendingAssignment := providePendingAssignmentTask(log, delivery, pendingAssignmentInterval, notifier)
+	v := provideKafkaWorkerTaskList(pendingAssignment)
+	worker, err := provideBackgroundWorkers(ctx, log, v)
+	if err != nil {
+		return nil, err
+	}
 	kafkaWorkerApp := &KafkaWorkerApp{
-		OrderService: service,
+		OrderService:      service,
+		BackgroundWorkers: worker,
 	}
 	return kafkaWorkerApp, nil
 }
 
+// InitializePhoneMigrationApp для разовой нормализации телефонов (cmd/normalize-phones)
+func InitializePhoneMigrationApp(pool *pgxpool.Pool, getter *pgxv5.CtxGetter, cfg *config.Config) (*PhoneMigrationApp, error) {
+	querier := provideQuerier(pool, getter)
+	repository := provideCourierRepository(querier)
+	manager := provideTxManager(pool)
+	notifier := provideAvailabilityNotifier()
+	normalizer, err := providePhoneNormalizer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	courier := provideServiceCourier(repository, manager, notifier, normalizer)
+	phoneMigrationApp := &PhoneMigrationApp{
+		ServiceCourier: courier,
+	}
+	return phoneMigrationApp, nil
+}
+
+// InitializePayoutApp для закрытия расчетного периода (cmd/close-payout-period)
+func InitializePayoutApp(pool *pgxpool.Pool, getter *pgxv5.CtxGetter) (*PayoutApp, error) {
+	querier := provideQuerier(pool, getter)
+	repository := provideEarningsRepository(querier)
+	delivery_settingsRepository := provideDeliverySettingsRepository(querier)
+	zoneRepository := provideZoneRepository(querier)
+	zone := provideServiceZone(zoneRepository)
+	manager := provideTxManager(pool)
+	earnings := provideServiceEarnings(repository, delivery_settingsRepository, zone, manager)
+	payoutApp := &PayoutApp{
+		ServiceEarnings: earnings,
+	}
+	return payoutApp, nil
+}
+
 // wire.go:
 
 type (
-	CleanupInterval time.Duration
+	CleanupInterval             time.Duration
+	PendingAssignmentInterval   time.Duration
+	IdempotencyKeyTTL           time.Duration
+	IdempotencyCleanupInterval  time.Duration
+	PoolMetricsInterval         time.Duration
+	DeliveryPartitionsInterval  time.Duration
+	OfferExpirationInterval     time.Duration
+	DispatchInterval            time.Duration
+	ScheduledDeliveriesInterval time.Duration
+	DeliveryETAInterval         time.Duration
 )
 
 type Application struct {
-	ServiceCourier    ServiceCourier
-	ServiceDelivery   ServiceDelivery
-	BackgroundWorkers *background.Worker
+	ServiceCash             ServiceCash
+	ServiceCourier          ServiceCourier
+	ServiceDelivery         ServiceDelivery
+	ServiceDeliveryETA      ServiceDeliveryETA
+	ServiceDeliveryRating   ServiceDeliveryRating
+	ServiceDeliverySettings ServiceDeliverySettings
+	ServiceEarnings         ServiceEarnings
+	ServiceOverdue          ServiceOverdue
+	ServiceZone             ServiceZone
+	ServiceIdempotency      idempotency.Service
+	BackgroundWorkers       *background.Worker
 }
 
 type ServiceCourier interface {
 	courier_get.Service
 	courier_post.Service
 	courier_put.Service
+	courier_patch.Service
+	courier_delete.Service
+	courier_reactivate_post.Service
+	courier_skills_get.Service
+	courier_skills_put.Service
 	couriers_get.Service
+	couriers_import_post.Service
+	couriers_export_get.Service
 }
 
 type ServiceDelivery interface {
 	delivery_assign_post.Service
 	delivery_unassign_post.Service
+	delivery_reassign_post.Service
+	delivery_pending_get.Service
+	delivery_get.Service
+	delivery_offer_accept_post.Service
+	delivery_offer_decline_post.Service
+	delivery_schedule_post.Service
+	delivery_schedule_get.Service
+	delivery_schedule_delete.Service
+	courier_offer_stats_get.Service
+}
+
+type ServiceDeliveryETA interface {
+	delivery_assign_post.ETAService
+}
+
+type ServiceDeliveryRating interface {
+	delivery_feedback_post.Service
+	courier_get.RatingService
+}
+
+type ServiceOverdue interface {
+	delivery_overdue_get.Service
+}
+
+type ServiceZone interface {
+	zone_post.Service
+	zones_get.Service
+	zone_get.Service
+	zone_put.Service
+	zone_delete.Service
+	zone_couriers_get.Service
+	zone_courier_put.Service
+	zone_courier_delete.Service
+}
+
+type ServiceDeliverySettings interface {
+	delivery_settings_get.Service
+	delivery_settings_put.Service
+}
+
+type ServiceCash interface {
+	courier_cash_handover_post.Service
+	cash_reconciliation_get.Service
+}
+
+type ServiceEarnings interface {
+	courier_earnings_get.Service
+	courier_tariffs_get.Service
+	courier_tariffs_put.Service
 }
 
 type KafkaWorkerApp struct {
-	OrderService *order.Service
+	OrderService      *order.Service
+	BackgroundWorkers *background.Worker
+}
+
+type PhoneMigrationApp struct {
+	ServiceCourier *courier.Courier
+}
+
+type PayoutApp struct {
+	ServiceEarnings *earnings.Earnings
 }
 
 func provideTxManager(pool *pgxpool.Pool) *tx.Manager {
@@ -116,39 +400,334 @@ func provideQuerier(pool *pgxpool.Pool, getter *pgxv5.CtxGetter) *querier.Querie
 	return querier.New(pool, getter)
 }
 
-func provideCourierRepository(querier2 *querier.Querier) *courier.Repository {
-	return courier.New(querier2)
+func provideCourierRepository(querier2 *querier.Querier) *courier2.Repository {
+	return courier2.New(querier2)
 }
 
 func provideDeliveryRepository(querier2 *querier.Querier) *delivery.Repository {
 	return delivery.New(querier2)
 }
 
+func providePendingRepository(querier2 *querier.Querier) *pending_assignment.Repository {
+	return pending_assignment.New(querier2)
+}
+
+func provideDeliveryOfferRepository(querier2 *querier.Querier) *delivery_offer.Repository {
+	return delivery_offer.New(querier2)
+}
+
+func provideScheduledDeliveryRepository(querier2 *querier.Querier) *scheduled_delivery.Repository {
+	return scheduled_delivery.New(querier2)
+}
+
+func provideDeliverySettingsRepository(querier2 *querier.Querier) *delivery_settings.Repository {
+	return delivery_settings.New(querier2)
+}
+
+func provideZoneRepository(querier2 *querier.Querier) *zone.Repository {
+	return zone.New(querier2)
+}
+
+func provideIdempotencyRepository(querier2 *querier.Querier) *idempotency_key.Repository {
+	return idempotency_key.New(querier2)
+}
+
+func provideDeliveryPartitionRepository(querier2 *querier.Querier) *delivery_partition.Repository {
+	return delivery_partition.New(querier2)
+}
+
+func provideDeliveryETARepository(querier2 *querier.Querier) *delivery_eta.Repository {
+	return delivery_eta.New(querier2)
+}
+
+func provideDeliveryRatingRepository(querier2 *querier.Querier) *delivery_rating.Repository {
+	return delivery_rating.New(querier2)
+}
+
+func provideEarningsRepository(querier2 *querier.Querier) *earnings2.Repository {
+	return earnings2.New(querier2)
+}
+
+func provideCashRepository(querier2 *querier.Querier) *cash.Repository {
+	return cash.New(querier2)
+}
+
+func provideArchiveStorage(cfg *config.Config) *archive.JSONLStorage {
+	return archive.NewJSONLStorage(cfg.Partitions.ArchiveDir)
+}
+
+// provideAvailabilityNotifier общий сигнал "освободился курьер" для сервисов и задачи разбора очереди ожидания
+func provideAvailabilityNotifier() *notifier.Notifier {
+	return notifier.New()
+}
+
 func provideServiceCourier(
-	repository courier2.Repository,
-	txManager courier2.TxManager,
-) *courier2.Courier {
-	return courier2.New(repository, txManager)
+	repository courier.Repository,
+	txManager courier.TxManager,
+	availabilityNotifier courier.AvailabilityNotifier,
+	phones courier.PhoneNormalizer,
+) *courier.Courier {
+	return courier.New(repository, txManager, availabilityNotifier, phones)
+}
+
+func providePhoneNormalizer(cfg *config.Config) (*phone.Normalizer, error) {
+	normalizer, err := phone.NewNormalizer(cfg.Phone.DefaultRegion)
+	if err != nil {
+		return nil, fmt.Errorf("phone normalizer: %w", err)
+	}
+
+	return normalizer, nil
 }
 
 func provideServiceDelivery(
 	repository delivery2.Repository,
+	pendingRepository delivery2.PendingRepository,
 	courierService delivery2.CourierService,
 	timeFactory delivery2.DeliveryTimeFactory,
 	txManager delivery2.TxManager,
+	availabilityNotifier delivery2.AvailabilityNotifier,
+	zones delivery2.ZoneResolver,
+	zonePolicy delivery2.ZonePolicy,
+	offerRepository delivery2.OfferRepository,
+	offerPolicy delivery2.OfferPolicy,
+	batchPolicy delivery2.BatchPolicy,
+	dispatchPolicy delivery2.DispatchPolicy,
+	priorityPolicy delivery2.PriorityPolicy,
+	scheduledRepository delivery2.ScheduledRepository,
+	ratingPolicy delivery2.RatingPolicy,
+	earningsRecorder delivery2.EarningsRecorder,
+	cashRecorder delivery2.CashRecorder,
+	cashPolicy delivery2.CashPolicy,
 ) *delivery2.Delivery {
 	return delivery2.New(
 		repository,
+		pendingRepository,
 		courierService,
 		timeFactory,
 		txManager,
+		availabilityNotifier,
+		zones,
+		zonePolicy,
+		offerRepository,
+		offerPolicy,
+		batchPolicy,
+		dispatchPolicy,
+		priorityPolicy,
+		scheduledRepository,
+		ratingPolicy,
+		earningsRecorder,
+		cashRecorder,
+		cashPolicy,
 	)
 }
 
+func provideZonePolicy(cfg *config.Config) delivery2.ZonePolicy {
+	return delivery2.ZonePolicy{
+		CrossZoneFallback: cfg.Zones.CrossZoneFallback,
+	}
+}
+
+func provideOfferPolicy(cfg *config.Config) delivery2.OfferPolicy {
+	return delivery2.OfferPolicy{
+		Enabled: cfg.Offers.Enabled,
+		Timeout: cfg.Offers.Timeout,
+	}
+}
+
+func provideBatchPolicy(cfg *config.Config) delivery2.BatchPolicy {
+	return delivery2.BatchPolicy{
+		Window:        cfg.Batching.Window,
+		MaxOrders:     cfg.Batching.MaxOrders,
+		ExtraStopTime: cfg.Batching.ExtraStopTime,
+	}
+}
+
+func provideDispatchPolicy(cfg *config.Config) delivery2.DispatchPolicy {
+	return delivery2.DispatchPolicy{
+		Enabled:     cfg.Dispatch.Enabled,
+		MaxOrders:   cfg.Dispatch.MaxOrders,
+		MaxCouriers: cfg.Dispatch.MaxCouriers,
+		SolveBudget: cfg.Dispatch.SolveBudget,
+	}
+}
+
+func providePriorityPolicy(cfg *config.Config) delivery2.PriorityPolicy {
+	return delivery2.PriorityPolicy{
+		PreemptWithin: cfg.Priority.PreemptWithin,
+	}
+}
+
+func provideRatingPolicy(cfg *config.Config) delivery2.RatingPolicy {
+	return delivery2.RatingPolicy{
+		PreferTopRated: cfg.Rating.PreferTopRated,
+		Window:         cfg.Rating.Window,
+	}
+}
+
+func provideServiceZone(repository zone2.Repository) *zone2.Zone {
+	return zone2.New(repository)
+}
+
+func provideServiceOverdue(
+	repository overdue.Repository,
+	escalator overdue.Escalator,
+	txManager overdue.TxManager,
+	availabilityNotifier overdue.AvailabilityNotifier,
+	policy overdue.ReleasePolicy,
+) *overdue.Overdue {
+	return overdue.New(repository, escalator, txManager, availabilityNotifier, policy)
+}
+
+func provideOverdueReleasePolicy(cfg *config.Config) overdue.ReleasePolicy {
+	return overdue.ReleasePolicy{
+		AutoRelease: cfg.Overdue.AutoRelease,
+		GracePeriod: cfg.Overdue.ReleaseGracePeriod,
+	}
+}
+
+func provideEscalationGateway(producer sarama.SyncProducer, cfg *config.Config) *escalation.Gateway {
+	return escalation.New(producer, cfg.Kafka.OverdueTopic)
+}
+
+func provideServiceDeliveryPartition(
+	repository delivery_partition2.Repository,
+	storage delivery_partition2.ArchiveStorage,
+	txManager delivery_partition2.TxManager,
+	policy delivery_partition2.Policy,
+) *delivery_partition2.DeliveryPartition {
+	return delivery_partition2.New(repository, storage, txManager, policy)
+}
+
+func provideDeliveryPartitionPolicy(cfg *config.Config) delivery_partition2.Policy {
+	return delivery_partition2.Policy{
+		PremakeMonths:   cfg.Partitions.PremakeMonths,
+		RetentionMonths: cfg.Partitions.RetentionMonths,
+		ArchiveMode:     delivery_partition2.ArchiveMode(cfg.Partitions.ArchiveMode),
+	}
+}
+
+func provideServiceDeliveryETA(
+	repository delivery_eta2.Repository,
+	txManager delivery_eta2.TxManager,
+	policy delivery_eta2.Policy,
+) *delivery_eta2.DeliveryETA {
+	return delivery_eta2.New(repository, txManager, policy)
+}
+
+func provideDeliveryETAPolicy(cfg *config.Config) delivery_eta2.Policy {
+	return delivery_eta2.Policy{
+		RefreshHour: cfg.ETA.RefreshHour,
+		History:     cfg.ETA.History,
+		MinSamples:  cfg.ETA.MinSamples,
+	}
+}
+
+func provideServiceDeliveryRating(
+	repository delivery_rating2.Repository,
+	policy delivery_rating2.Policy,
+) *delivery_rating2.DeliveryRating {
+	return delivery_rating2.New(repository, policy)
+}
+
+func provideDeliveryRatingPolicy(cfg *config.Config) delivery_rating2.Policy {
+	return delivery_rating2.Policy{
+		Window: cfg.Rating.Window,
+	}
+}
+
+func provideServiceEarnings(
+	repository earnings.Repository,
+	settingsRepository earnings.SettingsRepository,
+	zones earnings.ZoneResolver,
+	txManager earnings.TxManager,
+) *earnings.Earnings {
+	return earnings.New(repository, settingsRepository, zones, txManager)
+}
+
+func provideServiceCash(
+	repository cash2.Repository,
+	txManager cash2.TxManager,
+	policy cash2.Policy,
+) *cash2.Cash {
+	return cash2.New(repository, txManager, policy)
+}
+
+func provideCashPolicy(cfg *config.Config) cash2.Policy {
+	return cash2.Policy{
+		BalanceLimit: int64(cfg.Cash.BalanceLimit),
+	}
+}
+
+func provideDeliveryCashPolicy(cfg *config.Config) delivery2.CashPolicy {
+	return delivery2.CashPolicy{
+		BalanceLimit: int64(cfg.Cash.BalanceLimit),
+	}
+}
+
+func provideDeliveryTimeFactory(
+	settingsRepository delivery_deadline.SettingsRepository,
+	etaPredictor delivery_deadline.ETAPredictor,
+	cfg *config.Config,
+) *delivery_deadline.DeliveryTimeFactory {
+	return delivery_deadline.New(settingsRepository, etaPredictor, delivery_deadline.Policy{
+		UseETAP90: cfg.ETA.DeadlineP90,
+	})
+}
+
 func provideCleanupInterval(cfg *config.Config) CleanupInterval {
 	return CleanupInterval(cfg.Tasks.CouriersStatusUpdateInterval)
 }
 
+func provideServiceDeliverySettings(
+	repository delivery_settings2.Repository,
+	txManager delivery_settings2.TxManager,
+) *delivery_settings2.DeliverySettings {
+	return delivery_settings2.New(repository, txManager)
+}
+
+func provideServiceIdempotency(
+	repository idempotency2.Repository,
+	ttl IdempotencyKeyTTL,
+) *idempotency2.Idempotency {
+	return idempotency2.New(repository, time.Duration(ttl))
+}
+
+func provideIdempotencyKeyTTL(cfg *config.Config) IdempotencyKeyTTL {
+	return IdempotencyKeyTTL(cfg.Server.IdempotencyKeyTTL)
+}
+
+func provideIdempotencyCleanupInterval(cfg *config.Config) IdempotencyCleanupInterval {
+	return IdempotencyCleanupInterval(cfg.Tasks.IdempotencyKeysCleanupInterval)
+}
+
+func providePoolMetricsInterval(cfg *config.Config) PoolMetricsInterval {
+	return PoolMetricsInterval(cfg.Tasks.PoolMetricsRefreshInterval)
+}
+
+func provideDeliveryPartitionsInterval(cfg *config.Config) DeliveryPartitionsInterval {
+	return DeliveryPartitionsInterval(cfg.Tasks.DeliveryPartitionsInterval)
+}
+
+func provideOfferExpirationInterval(cfg *config.Config) OfferExpirationInterval {
+	return OfferExpirationInterval(cfg.Tasks.OfferExpirationInterval)
+}
+
+func provideDispatchInterval(cfg *config.Config) DispatchInterval {
+	return DispatchInterval(cfg.Tasks.DispatchInterval)
+}
+
+func provideScheduledDeliveriesInterval(cfg *config.Config) ScheduledDeliveriesInterval {
+	return ScheduledDeliveriesInterval(cfg.Tasks.ScheduledDeliveriesInterval)
+}
+
+func provideDeliveryETAInterval(cfg *config.Config) DeliveryETAInterval {
+	return DeliveryETAInterval(cfg.Tasks.DeliveryETAInterval)
+}
+
+func providePendingAssignmentInterval(cfg *config.Config) PendingAssignmentInterval {
+	return PendingAssignmentInterval(cfg.Tasks.PendingAssignmentsInterval)
+}
+
 func provideOrderServiceClient(conn *grpc.ClientConn) orders.OrdersServiceClient {
 	return orders.NewOrdersServiceClient(conn)
 }
@@ -166,23 +745,132 @@ func provideOrderService(
 	return order.New(orderGateway, deliveryService, handlerFactory)
 }
 
-func provideStatusHandlerFabric(deliveryService *delivery2.Delivery) *order_handle.StatusHandlerFactory {
-	return order_handle.NewStatusHandlerFactory(deliveryService)
+func provideStatusHandlerFabric(
+	deliveryService *delivery2.Delivery,
+	requirements order_handle.RequirementsFactory,
+	priority order_handle.PriorityFactory,
+) *order_handle.StatusHandlerFactory {
+	return order_handle.NewStatusHandlerFactory(deliveryService, requirements, priority)
+}
+
+func provideOrderRequirementsFactory(cfg *config.Config) *order_requirements.RequirementsFactory {
+	return order_requirements.New(order_requirements.Rules{
+		AgeRestrictedKeywords: cfg.Requirements.AgeRestrictedKeywords,
+		ThermalKeywords:       cfg.Requirements.ThermalKeywords,
+		LargeOrderItems:       int64(cfg.Requirements.LargeOrderItems),
+		LargeOrderTotalPrice:  int64(cfg.Requirements.LargeOrderTotalPrice),
+	})
+}
+
+func provideOrderPriorityFactory(cfg *config.Config) *order_priority.PriorityFactory {
+	return order_priority.New(order_priority.Rules{
+		UrgentKeywords: cfg.Priority.UrgentKeywords,
+		HighTotalPrice: int64(cfg.Priority.HighTotalPrice),
+	})
 }
 
 func provideDeliveryCleanupTask(
 	log logger.Logger,
-	deliveryService delivery_cleanup.Service,
+	overdueService delivery_cleanup.Service,
 	interval CleanupInterval,
 ) *delivery_cleanup.DeliveryCleanup {
-	return delivery_cleanup.NewDeliveryCleanup(log, deliveryService, time.Duration(interval))
+	return delivery_cleanup.NewDeliveryCleanup(log, overdueService, time.Duration(interval))
+}
+
+func providePendingAssignmentTask(
+	log logger.Logger,
+	deliveryService pending_assignment2.Service,
+	interval PendingAssignmentInterval,
+	availabilityNotifier *notifier.Notifier,
+) *pending_assignment2.PendingAssignment {
+	return pending_assignment2.NewPendingAssignment(log, deliveryService, time.Duration(interval), availabilityNotifier.C())
+}
+
+func provideIdempotencyCleanupTask(
+	log logger.Logger,
+	idempotencyService idempotency_cleanup.Service,
+	interval IdempotencyCleanupInterval,
+) *idempotency_cleanup.IdempotencyCleanup {
+	return idempotency_cleanup.NewIdempotencyCleanup(log, idempotencyService, time.Duration(interval))
+}
+
+func providePoolMetricsTask(
+	log logger.Logger,
+	deliveryService pool_metrics.Service,
+	interval PoolMetricsInterval,
+) *pool_metrics.PoolMetrics {
+	return pool_metrics.NewPoolMetrics(log, deliveryService, time.Duration(interval))
+}
+
+func provideDeliveryPartitionsTask(
+	log logger.Logger,
+	deliveryPartitionService delivery_partitions.Service,
+	interval DeliveryPartitionsInterval,
+) *delivery_partitions.DeliveryPartitions {
+	return delivery_partitions.NewDeliveryPartitions(log, deliveryPartitionService, time.Duration(interval))
+}
+
+func provideOfferExpirationTask(
+	log logger.Logger,
+	deliveryService offer_expiration.Service,
+	interval OfferExpirationInterval,
+) *offer_expiration.OfferExpiration {
+	return offer_expiration.NewOfferExpiration(log, deliveryService, time.Duration(interval))
+}
+
+func provideDispatchTask(
+	log logger.Logger,
+	deliveryService dispatch.Service,
+	interval DispatchInterval,
+) *dispatch.Dispatch {
+	return dispatch.NewDispatch(log, deliveryService, time.Duration(interval))
+}
+
+func provideScheduledDeliveryTask(
+	log logger.Logger,
+	deliveryService scheduled_delivery2.Service,
+	interval ScheduledDeliveriesInterval,
+) *scheduled_delivery2.ScheduledDelivery {
+	return scheduled_delivery2.NewScheduledDelivery(log, deliveryService, time.Duration(interval))
+}
+
+func provideDeliveryETATask(
+	log logger.Logger,
+	deliveryETAService delivery_eta3.Service,
+	interval DeliveryETAInterval,
+) *delivery_eta3.DeliveryETA {
+	return delivery_eta3.NewDeliveryETA(log, deliveryETAService, time.Duration(interval))
 }
 
 func provideTaskList(
 	deliveryCleanupTask *delivery_cleanup.DeliveryCleanup,
+	pendingAssignmentTask *pending_assignment2.PendingAssignment,
+	idempotencyCleanupTask *idempotency_cleanup.IdempotencyCleanup,
+	poolMetricsTask *pool_metrics.PoolMetrics,
+	deliveryPartitionsTask *delivery_partitions.DeliveryPartitions,
+	offerExpirationTask *offer_expiration.OfferExpiration,
+	dispatchTask *dispatch.Dispatch,
+	scheduledTask *scheduled_delivery2.ScheduledDelivery,
+	etaTask *delivery_eta3.DeliveryETA,
 ) []background.Task {
 	return []background.Task{
 		deliveryCleanupTask,
+		pendingAssignmentTask,
+		idempotencyCleanupTask,
+		poolMetricsTask,
+		deliveryPartitionsTask,
+		offerExpirationTask,
+		dispatchTask,
+		scheduledTask,
+		etaTask,
+	}
+}
+
+func provideKafkaWorkerTaskList(
+	pendingAssignmentTask *pending_assignment2.PendingAssignment,
+) []background.Task {
+	return []background.Task{
+		pendingAssignmentTask,
 	}
 }
 
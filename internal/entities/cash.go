@@ -0,0 +1,70 @@
+package entities
+
+import "time"
+
+type CashTransactionKind string
+
+const (
+	// CashCollected курьер получил наличные от клиента за выполненную доставку
+	CashCollected CashTransactionKind = "collected"
+	// CashHandedOver курьер сдал наличные оператору
+	CashHandedOver CashTransactionKind = "handover"
+)
+
+func (k CashTransactionKind) String() string {
+	return string(k)
+}
+
+// CashTransaction движение наличных курьера, Amount всегда положительный
+type CashTransaction struct {
+	ID        int64
+	CourierID int64
+	Kind      CashTransactionKind
+	Amount    int64
+	// OrderID заказ, за который получены наличные, для сдачи пусто
+	OrderID string
+	// Operator кто принял наличные, для получения от клиента пусто
+	Operator  string
+	CreatedAt time.Time
+}
+
+// CashDelivery выполненная доставка с наличными, которые курьер получил от клиента
+type CashDelivery struct {
+	OrderID    string
+	CourierID  int64
+	CashAmount int64
+}
+
+// CashHandoverParams сдача наличных курьера оператору
+type CashHandoverParams struct {
+	CourierID int64
+	Amount    int64
+	Operator  string
+}
+
+// CashHandover принятая сдача наличных и остаток на руках у курьера после нее
+type CashHandover struct {
+	Transaction CashTransaction
+	Balance     int64
+}
+
+// CourierCashBalance наличные на руках у курьера
+type CourierCashBalance struct {
+	CourierID   int64
+	CourierName string
+	Balance     int64
+	// LastCollectedAt и LastHandoverAt nil, если таких движений не было
+	LastCollectedAt *time.Time
+	LastHandoverAt  *time.Time
+	// OverLimit курьер не получает заказы с оплатой наличными, пока не сдаст наличные
+	OverLimit bool
+}
+
+// CashReconciliation сверка наличных: курьеры, не сдавшие наличные, от большего остатка к меньшему
+type CashReconciliation struct {
+	GeneratedAt time.Time
+	// BalanceLimit лимит наличных при назначении заказов с оплатой наличными, 0 - без лимита
+	BalanceLimit int64
+	Outstanding  int64
+	Couriers     []CourierCashBalance
+}
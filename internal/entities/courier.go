@@ -12,6 +12,15 @@ type Courier struct {
 	TransportType CourierTransportType
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	// DeactivatedAt курьер отключен (мягкое удаление): не получает заказы и не попадает в список по умолчанию
+	DeactivatedAt      *time.Time
+	DeactivationReason *string
+	// Version растет с каждым изменением курьера, по ней обнаруживаются параллельные правки
+	Version int64
+}
+
+func (c Courier) IsDeactivated() bool {
+	return c.DeactivatedAt != nil
 }
 
 type CourierTransportType string
@@ -48,4 +57,24 @@ type CourierModify struct {
 	Phone         *string
 	Status        *CourierStatusType
 	TransportType *CourierTransportType
+	// ExpectedVersion если задана, изменение применится, только пока версия курьера не изменилась
+	ExpectedVersion *int64
+}
+
+// CourierListFilter по умолчанию отключенные курьеры в список не попадают
+type CourierListFilter struct {
+	IncludeDeactivated bool
+}
+
+// CourierDeactivateParams Reason обязателен, сохраняется вместе с временем отключения
+type CourierDeactivateParams struct {
+	ID     int64
+	Reason string
+}
+
+// CourierPoolCount количество курьеров в одном статусе с одним типом транспорта
+type CourierPoolCount struct {
+	Status        CourierStatusType
+	TransportType CourierTransportType
+	Count         int64
 }
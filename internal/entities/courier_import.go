@@ -0,0 +1,65 @@
+package entities
+
+type CourierImportMode string
+
+const (
+	// CourierImportAllOrNothing одна ошибочная строка отменяет весь импорт
+	CourierImportAllOrNothing CourierImportMode = "all_or_nothing"
+	// CourierImportBestEffort корректные строки создаются, ошибочные попадают в отчет
+	CourierImportBestEffort CourierImportMode = "best_effort"
+)
+
+const DefaultCourierImportMode = CourierImportAllOrNothing
+
+func (m CourierImportMode) String() string {
+	return string(m)
+}
+
+// CourierImportRow строка файла импорта как есть, до валидации.
+// ParseError заполнен, если строку не удалось разобрать (битый JSON, не то число колонок)
+type CourierImportRow struct {
+	Line          int
+	Name          string
+	Phone         string
+	Status        string
+	TransportType string
+	ParseError    string
+}
+
+type CourierImportOptions struct {
+	Mode   CourierImportMode
+	DryRun bool
+}
+
+type CourierImportRowStatus string
+
+const (
+	CourierImportRowCreated CourierImportRowStatus = "created"
+	// CourierImportRowValid строка прошла проверки, но курьер не создавался (dry-run)
+	CourierImportRowValid  CourierImportRowStatus = "valid"
+	CourierImportRowFailed CourierImportRowStatus = "failed"
+	// CourierImportRowSkipped строка корректна, но импорт all_or_nothing отменен из-за других строк
+	CourierImportRowSkipped CourierImportRowStatus = "skipped"
+)
+
+func (s CourierImportRowStatus) String() string {
+	return string(s)
+}
+
+type CourierImportRowResult struct {
+	Line      int
+	Status    CourierImportRowStatus
+	CourierID int64
+	Error     string
+}
+
+// CourierImportReport Committed - курьеры из отчета действительно созданы
+type CourierImportReport struct {
+	Mode      CourierImportMode
+	DryRun    bool
+	Committed bool
+	Total     int
+	Created   int
+	Failed    int
+	Rows      []CourierImportRowResult
+}
@@ -0,0 +1,55 @@
+package entities
+
+import "time"
+
+// CourierSearchFilter ограничения при подборе свободного курьера, пустой фильтр - любой курьер
+type CourierSearchFilter struct {
+	// ZoneIDs курьер должен состоять хотя бы в одной из зон
+	ZoneIDs []int64
+	// Skills курьер должен иметь все навыки
+	Skills []CourierSkill
+	// TransportTypes допустимый транспорт курьера, пусто - любой
+	TransportTypes []CourierTransportType
+	// ExcludeCourierIDs курьеры, которым заказ уже предлагали
+	ExcludeCourierIDs []int64
+	// PreferFastTransport сначала подбираются курьеры на более быстром транспорте, затем менее загруженные
+	PreferFastTransport bool
+	// TopRatedSince если задано, курьеры с более высокой средней оценкой с этого момента подбираются раньше
+	// менее загруженных, курьеры без оценок - после оцененных
+	TopRatedSince *time.Time
+	// MaxCashBalance если задано, курьер с большей суммой наличных на руках не подбирается
+	MaxCashBalance *int64
+}
+
+// OrderRequirements требования заказа к курьеру
+type OrderRequirements struct {
+	Skills []CourierSkill
+	// TransportTypes допустимый транспорт, пусто - любой
+	TransportTypes []CourierTransportType
+}
+
+func (r OrderRequirements) IsEmpty() bool {
+	return len(r.Skills) == 0 && len(r.TransportTypes) == 0
+}
+
+// Требования в объяснении, почему курьер не подобран
+const (
+	RequirementZone          = "zone"
+	RequirementTransportType = "transport_type"
+	RequirementSkill         = "skill"
+	RequirementCashBalance   = "cash_balance"
+)
+
+// CourierMismatch почему заказу не нашелся курьер: сколько свободных курьеров
+// удовлетворяет каждому требованию по отдельности
+type CourierMismatch struct {
+	AvailableCouriers int64
+	Requirements      []RequirementMatch
+}
+
+type RequirementMatch struct {
+	Requirement string
+	// Value навык, допустимый транспорт через запятую или лимит наличных, для зоны пусто
+	Value            string
+	MatchingCouriers int64
+}
@@ -0,0 +1,15 @@
+package entities
+
+// CourierSkill особенность курьера, нужная части заказов
+type CourierSkill string
+
+const (
+	// SkillThermalBag есть термосумка для горячего
+	SkillThermalBag CourierSkill = "thermal_bag"
+	// SkillAgeVerified прошел проверку для доставки товаров 18+ с проверкой документов получателя
+	SkillAgeVerified CourierSkill = "age_verified"
+)
+
+func (s CourierSkill) String() string {
+	return string(s)
+}
@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+// TransportSpeed параметры расчета времени доставки для типа транспорта
+type TransportSpeed struct {
+	TransportType    CourierTransportType
+	AverageSpeedKmh  float64
+	HandlingOverhead time.Duration
+	UpdatedAt        time.Time
+}
+
+// PeakHour интервал часов [StartHour, EndHour) по UTC, в который время в пути умножается на Multiplier
+type PeakHour struct {
+	StartHour  int
+	EndHour    int
+	Multiplier float64
+}
+
+type DeadlineSettings struct {
+	TransportSpeeds []TransportSpeed
+	PeakHours       []PeakHour
+}
@@ -3,21 +3,61 @@ package entities
 import "time"
 
 type Delivery struct {
-	ID         int64
-	CourierID  int64
-	OrderID    string
-	CreatedAt  time.Time
-	AssignedAt time.Time
-	Deadline   time.Time
+	ID                int64
+	CourierID         int64
+	OrderID           string
+	RestaurantID      string
+	Address           *Address
+	EstimatedDelivery *time.Time
+	CreatedAt         time.Time
+	AssignedAt        time.Time
+	Deadline          time.Time
+	// OverdueAt когда доставка замечена просроченной, nil пока дедлайн не пройден
+	OverdueAt *time.Time
+	// CourierReleasedAt когда курьер освобожден по политике авто-освобождения
+	CourierReleasedAt *time.Time
+	// CompletedAt nil, пока курьер не доставил заказ
+	CompletedAt *time.Time
+	// Route nil, если маршрут заказа неизвестен
+	Route        *Route
+	Priority     OrderPriority
+	Requirements OrderRequirements
+	CashAmount   int64
 }
 
 type DeliveryModify struct {
-	ID         *int64
-	CourierID  *int64
-	OrderID    *string
-	CreatedAt  *time.Time
-	AssignedAt *time.Time
-	Deadline   *time.Time
+	ID                *int64
+	CourierID         *int64
+	OrderID           *string
+	RestaurantID      *string
+	Address           *Address
+	EstimatedDelivery *time.Time
+	CreatedAt         *time.Time
+	AssignedAt        *time.Time
+	Deadline          *time.Time
+	// Route сохраняется для оплаты курьеру по расстоянию и зоне точки забора
+	Route *Route
+	// Priority и Requirements сохраняются, чтобы вернуть заказ в очередь, если курьера заберет приоритетный заказ
+	Priority     *OrderPriority
+	Requirements OrderRequirements
+	// CashAmount попадает в кассу курьера, когда доставка выполнена
+	CashAmount int64
+}
+
+// DeliveryAssignParams данные заказа для назначения, кроме OrderID все поля необязательны
+type DeliveryAssignParams struct {
+	OrderID           string
+	Route             *Route
+	RestaurantID      string
+	Address           *Address
+	EstimatedDelivery *time.Time
+	// OrderCreatedAt время создания заказа в order-service, nil для ручного назначения
+	OrderCreatedAt *time.Time
+	Requirements   OrderRequirements
+	// Priority пусто - обычный заказ
+	Priority OrderPriority
+	// CashAmount наличные, которые курьер получит от клиента, 0 - заказ оплачен не наличными
+	CashAmount int64
 }
 
 type DeliveryAssignment struct {
@@ -29,7 +69,51 @@ type DeliveryAssignment struct {
 }
 
 type DeliveryUnassignment struct {
-	CourierID int64
+	CourierID     int64
+	OrderID       string
+	Status        string
+	TransportType CourierTransportType
+}
+
+// DeliveryReassignParams при CourierID == nil курьер подбирается так же, как при назначении
+type DeliveryReassignParams struct {
 	OrderID   string
-	Status    string
+	CourierID *int64
+	Reason    string
+}
+
+// DeliveryReassignment запись о передаче заказа другому курьеру
+type DeliveryReassignment struct {
+	ID                int64
+	OrderID           string
+	PreviousCourierID int64
+	CourierID         int64
+	TransportType     CourierTransportType
+	Reason            string
+	Deadline          time.Time
+	ReassignedAt      time.Time
+}
+
+type DeliveryReassignmentModify struct {
+	ID                *int64
+	OrderID           *string
+	PreviousCourierID *int64
+	CourierID         *int64
+	Reason            *string
+	Deadline          *time.Time
+	ReassignedAt      *time.Time
+}
+
+// PreemptionCandidate доставка обычного заказа, курьера которой может забрать приоритетный заказ
+type PreemptionCandidate struct {
+	Delivery     Delivery
+	Courier      Courier
+	Requirements OrderRequirements
+	CashAmount   int64
+}
+
+// OverdueProcessing результат проверки дедлайнов: новые просроченные доставки и освобожденные по политике курьеры
+type OverdueProcessing struct {
+	Overdue          []Delivery
+	ReleasedCouriers int64
 }
@@ -0,0 +1,46 @@
+package entities
+
+import "time"
+
+// ETAAnyTime значение Weekday и Hour у квантилей по всему виду транспорта
+const ETAAnyTime = -1
+
+// ETAQuantile квантили длительности доставки от назначения курьера до выполнения заказа.
+// Weekday (0 - воскресенье) и Hour считаются по assigned_at в UTC
+type ETAQuantile struct {
+	TransportType CourierTransportType
+	Weekday       int
+	Hour          int
+	SampleCount   int64
+	P50           time.Duration
+	P90           time.Duration
+	TrainedAt     time.Time
+}
+
+type ETAConfidence string
+
+const (
+	// ETAConfidenceHigh прогноз по доставкам того же вида транспорта в тот же день недели и час
+	ETAConfidenceHigh ETAConfidence = "high"
+	// ETAConfidenceLow в ячейке мало доставок, прогноз по всем доставкам вида транспорта
+	ETAConfidenceLow ETAConfidence = "low"
+)
+
+func (c ETAConfidence) String() string {
+	return string(c)
+}
+
+// ETAPrediction прогноз времени доставки: ETA - медиана, P90 - к этому времени доставляется 90% заказов
+type ETAPrediction struct {
+	ETA         time.Time
+	P50         time.Duration
+	P90         time.Duration
+	SampleCount int64
+	Confidence  ETAConfidence
+}
+
+// ETAModelRefresh итог обновления модели ETA. Refreshed false - модель уже обновлена в этом окне
+type ETAModelRefresh struct {
+	Refreshed bool
+	Quantiles int64
+}
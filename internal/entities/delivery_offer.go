@@ -0,0 +1,55 @@
+package entities
+
+import "time"
+
+type DeliveryOfferStatus string
+
+const (
+	OfferPending   DeliveryOfferStatus = "pending"
+	OfferAccepted  DeliveryOfferStatus = "accepted"
+	OfferDeclined  DeliveryOfferStatus = "declined"
+	OfferExpired   DeliveryOfferStatus = "expired"
+	OfferCancelled DeliveryOfferStatus = "cancelled"
+)
+
+func (s DeliveryOfferStatus) String() string {
+	return string(s)
+}
+
+// DeliveryOffer предложение заказа курьеру. Пока курьер не ответил, заказ ждет в очереди ожидания,
+// а курьер не получает других заказов
+type DeliveryOffer struct {
+	ID          int64
+	OrderID     string
+	CourierID   int64
+	Status      DeliveryOfferStatus
+	OfferedAt   time.Time
+	ExpiresAt   time.Time
+	RespondedAt *time.Time
+}
+
+type DeliveryOfferModify struct {
+	OrderID   *string
+	CourierID *int64
+	OfferedAt *time.Time
+	ExpiresAt *time.Time
+}
+
+// CourierOfferStats ответы курьера на предложения заказов
+type CourierOfferStats struct {
+	CourierID int64
+	Offered   int64
+	Accepted  int64
+	Declined  int64
+	Expired   int64
+}
+
+// AcceptanceRate доля принятых среди предложений, на которые курьер ответил или не успел ответить.
+// Открытые и отмененные предложения не учитываются
+func (s CourierOfferStats) AcceptanceRate() float64 {
+	answered := s.Accepted + s.Declined + s.Expired
+	if answered == 0 {
+		return 0
+	}
+	return float64(s.Accepted) / float64(answered)
+}
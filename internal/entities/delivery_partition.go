@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+// DeliveryPartition месячная партиция доставок, в нее попадают доставки с From <= created_at < To
+type DeliveryPartition struct {
+	Name string
+	From time.Time
+	To   time.Time
+}
+
+// DeliveryPartitionMaintenance итог обслуживания партиций: созданные заранее и убранные в архив
+type DeliveryPartitionMaintenance struct {
+	Created      []string
+	Archived     []string
+	ArchivedRows int64
+}
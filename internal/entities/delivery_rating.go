@@ -0,0 +1,62 @@
+package entities
+
+import "time"
+
+// RatingAuthor кто оценил доставку
+type RatingAuthor string
+
+const (
+	RatingAuthorCustomer   RatingAuthor = "customer"
+	RatingAuthorRestaurant RatingAuthor = "restaurant"
+)
+
+func (a RatingAuthor) String() string {
+	return string(a)
+}
+
+func (a RatingAuthor) IsValid() bool {
+	return a == RatingAuthorCustomer || a == RatingAuthorRestaurant
+}
+
+const (
+	MinRatingScore = 1
+	MaxRatingScore = 5
+)
+
+// DeliveryRating оценка выполненной доставки, каждый автор оценивает доставку один раз
+type DeliveryRating struct {
+	ID        int64
+	OrderID   string
+	CourierID int64
+	Author    RatingAuthor
+	Score     int
+	Tags      []string
+	Comment   *string
+	CreatedAt time.Time
+}
+
+type DeliveryRatingParams struct {
+	OrderID string
+	Author  RatingAuthor
+	Score   int
+	Tags    []string
+	Comment *string
+}
+
+// RatedDelivery доставка, которую оценивают: оценить можно только выполненную
+type RatedDelivery struct {
+	CourierID   int64
+	CompletedAt *time.Time
+}
+
+// CourierRating средняя оценка курьера за скользящее окно, без оценок Average равен нулю
+type CourierRating struct {
+	CourierID int64
+	Average   float64
+	Count     int64
+	Window    time.Duration
+}
+
+func (r CourierRating) HasRatings() bool {
+	return r.Count > 0
+}
@@ -0,0 +1,12 @@
+package entities
+
+// DispatchCourier свободный курьер со всем, что нужно пакетному распределению для оценки пары курьер-заказ
+type DispatchCourier struct {
+	Courier Courier
+	// ActiveDeliveries доставки курьера, дедлайн которых еще не прошел
+	ActiveDeliveries int64
+	Skills           []CourierSkill
+	ZoneIDs          []int64
+	// CashBalance наличные на руках у курьера
+	CashBalance int64
+}
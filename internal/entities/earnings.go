@@ -0,0 +1,81 @@
+package entities
+
+import "time"
+
+// CourierTariff оплата курьеру за доставку в копейках. ZoneID == nil - тариф транспорта
+// для всех зон без собственного тарифа
+type CourierTariff struct {
+	TransportType CourierTransportType
+	ZoneID        *int64
+	BaseFee       int64
+	// PerKmRate за километр от точки забора до точки доставки
+	PerKmRate int64
+	// PeakBonus если курьер назначен в час пик
+	PeakBonus int64
+	// LatePenalty если доставка выполнена после дедлайна
+	LatePenalty int64
+	UpdatedAt   time.Time
+}
+
+// EarningDelivery выполненная доставка, за которую начисляется оплата
+type EarningDelivery struct {
+	OrderID       string
+	CourierID     int64
+	TransportType CourierTransportType
+	// Route nil, если маршрут заказа неизвестен: расстояние не оплачивается, действует тариф без зоны
+	Route       *Route
+	AssignedAt  time.Time
+	Deadline    time.Time
+	CompletedAt time.Time
+}
+
+// EarningEntry строка журнала начислений, суммы в копейках
+type EarningEntry struct {
+	ID            int64
+	OrderID       string
+	CourierID     int64
+	TransportType CourierTransportType
+	ZoneID        *int64
+	DistanceKm    float64
+	BaseFee       int64
+	DistanceFee   int64
+	PeakBonus     int64
+	LatePenalty   int64
+	// Total BaseFee + DistanceFee + PeakBonus - LatePenalty
+	Total          int64
+	AssignedAt     time.Time
+	Deadline       time.Time
+	CompletedAt    time.Time
+	PayoutPeriodID *int64
+}
+
+// CourierEarnings начисления курьеру за доставки, выполненные в [From, To)
+type CourierEarnings struct {
+	CourierID int64
+	From      time.Time
+	To        time.Time
+	Total     int64
+	Entries   []EarningEntry
+}
+
+// PayoutPeriod закрытый расчетный период. Start == nil у первого периода: в него попадают все
+// начисления до End
+type PayoutPeriod struct {
+	ID       int64
+	Start    *time.Time
+	End      time.Time
+	ClosedAt time.Time
+}
+
+// CourierPayout выплата курьеру за период
+type CourierPayout struct {
+	CourierID int64
+	Total     int64
+	Entries   []EarningEntry
+}
+
+// PayoutStatement ведомость закрытого периода по курьерам в порядке ID
+type PayoutStatement struct {
+	Period   PayoutPeriod
+	Couriers []CourierPayout
+}
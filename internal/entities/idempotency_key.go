@@ -0,0 +1,31 @@
+package entities
+
+import "time"
+
+// IdempotencyKey результат запроса, выполненного с заголовком Idempotency-Key.
+// Пока исходный запрос выполняется, StatusCode равен нулю
+type IdempotencyKey struct {
+	Key          string
+	Scope        string
+	Fingerprint  string
+	StatusCode   int
+	ContentType  string
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.StatusCode != 0
+}
+
+type IdempotencyKeyModify struct {
+	Key          *string
+	Scope        *string
+	Fingerprint  *string
+	StatusCode   *int
+	ContentType  *string
+	ResponseBody []byte
+	CreatedAt    *time.Time
+	ExpiresAt    *time.Time
+}
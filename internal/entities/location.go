@@ -0,0 +1,12 @@
+package entities
+
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Route маршрут доставки: откуда курьер забирает заказ и куда его везет
+type Route struct {
+	Pickup  Location
+	Dropoff Location
+}
@@ -3,9 +3,38 @@ package entities
 import "time"
 
 type Order struct {
-	ID        string
-	Status    OrderStatusType
-	CreatedAt time.Time
+	ID                string
+	Status            OrderStatusType
+	RestaurantID      string
+	Address           *Address
+	Items             []OrderItem
+	TotalPrice        int64
+	PaymentMethod     OrderPaymentMethod
+	EstimatedDelivery *time.Time
+	CreatedAt         time.Time
+}
+
+// CashAmount сколько наличных курьер получит от клиента, 0 - заказ оплачен не наличными
+func (o *Order) CashAmount() int64 {
+	if o.PaymentMethod != PaymentCash {
+		return 0
+	}
+	return o.TotalPrice
+}
+
+// Address адрес доставки в формате order-service
+type Address struct {
+	Street    string
+	House     string
+	Apartment string
+	Floor     string
+	Comment   string
+}
+
+type OrderItem struct {
+	Name     string
+	Price    int64
+	Quantity int64
 }
 
 type OrderStatusType string
@@ -20,6 +49,14 @@ func (s OrderStatusType) String() string {
 	return string(s)
 }
 
+// OrderPaymentMethod способ оплаты заказа в order-service, пусто - неизвестен
+type OrderPaymentMethod string
+
+const (
+	PaymentCard OrderPaymentMethod = "card"
+	PaymentCash OrderPaymentMethod = "cash"
+)
+
 type OrderModify struct {
 	ID        *string
 	Status    *OrderStatusType
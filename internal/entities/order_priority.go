@@ -0,0 +1,42 @@
+package entities
+
+// OrderPriority класс срочности заказа. Приоритетный заказ раньше других разбирается из очереди ожидания,
+// получает более быстрый транспорт и может забрать курьера у обычного заказа
+type OrderPriority string
+
+const (
+	PriorityNormal OrderPriority = "normal"
+	// PriorityHigh VIP-клиенты и срочные заказы, например лекарства
+	PriorityHigh OrderPriority = "high"
+)
+
+// HighPendingPriority приоритет в очереди ожидания для приоритетного заказа
+const HighPendingPriority int32 = 10
+
+// String пустой класс - обычный заказ
+func (p OrderPriority) String() string {
+	if p == "" {
+		return string(PriorityNormal)
+	}
+	return string(p)
+}
+
+func (p OrderPriority) IsHigh() bool {
+	return p == PriorityHigh
+}
+
+// PendingPriority приоритет заказа в очереди ожидания
+func (p OrderPriority) PendingPriority() int32 {
+	if p.IsHigh() {
+		return HighPendingPriority
+	}
+	return DefaultPendingPriority
+}
+
+// OrderPriorityFromPending класс заказа по его приоритету в очереди ожидания
+func OrderPriorityFromPending(priority int32) OrderPriority {
+	if priority >= HighPendingPriority {
+		return PriorityHigh
+	}
+	return PriorityNormal
+}
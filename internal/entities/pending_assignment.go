@@ -0,0 +1,37 @@
+package entities
+
+import "time"
+
+// PendingAssignment заказ, ожидающий свободного курьера
+type PendingAssignment struct {
+	ID                int64
+	OrderID           string
+	Priority          int32
+	Route             *Route
+	RestaurantID      string
+	Address           *Address
+	EstimatedDelivery *time.Time
+	OrderCreatedAt    *time.Time
+	Requirements      OrderRequirements
+	CashAmount        int64
+	EnqueuedAt        time.Time
+	// BatchUntil до этого времени заказ ждет другие заказы ресторана, nil - заказ не группируется
+	BatchUntil *time.Time
+}
+
+const DefaultPendingPriority int32 = 0
+
+type PendingAssignmentModify struct {
+	ID                *int64
+	OrderID           *string
+	Priority          *int32
+	Route             *Route
+	RestaurantID      *string
+	Address           *Address
+	EstimatedDelivery *time.Time
+	OrderCreatedAt    *time.Time
+	Requirements      OrderRequirements
+	CashAmount        int64
+	EnqueuedAt        *time.Time
+	BatchUntil        *time.Time
+}
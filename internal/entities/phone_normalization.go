@@ -0,0 +1,32 @@
+package entities
+
+// CourierPhone телефон курьера в том виде, как он хранится в БД
+type CourierPhone struct {
+	CourierID int64
+	Phone     string
+}
+
+// PhoneCollision активные курьеры, чьи телефоны после нормализации совпали.
+// Такие строки не меняются: кого из курьеров оставить, решает человек
+type PhoneCollision struct {
+	Phone    string
+	Couriers []CourierPhone
+}
+
+// PhoneNormalizationReport итог приведения сохраненных телефонов к E.164
+type PhoneNormalizationReport struct {
+	DryRun bool
+	Total  int
+	// AlreadyNormalized телефон уже был в E.164
+	AlreadyNormalized int
+	// Updated телефон переписан, в dry-run - будет переписан
+	Updated int
+	// Skipped строку изменили во время миграции, она пропущена и будет проверена при следующем запуске
+	Skipped    int
+	Invalid    []CourierPhone
+	Collisions []PhoneCollision
+}
+
+func (r PhoneNormalizationReport) HasProblems() bool {
+	return len(r.Invalid) > 0 || len(r.Collisions) > 0
+}
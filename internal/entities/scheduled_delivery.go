@@ -0,0 +1,55 @@
+package entities
+
+import "time"
+
+type ScheduledDeliveryStatus string
+
+const (
+	ScheduledWaiting   ScheduledDeliveryStatus = "scheduled"
+	ScheduledAssigned  ScheduledDeliveryStatus = "assigned"
+	ScheduledCancelled ScheduledDeliveryStatus = "cancelled"
+)
+
+func (s ScheduledDeliveryStatus) String() string {
+	return string(s)
+}
+
+// ScheduledDelivery заказ к определенному времени. Курьер не резервируется заранее: его ищут,
+// только когда до DeliverAt остается время в пути на транспорте курьера
+type ScheduledDelivery struct {
+	ID        int64
+	OrderID   string
+	DeliverAt time.Time
+	// AssignAt с этого времени ищется курьер на самом медленном из подходящих видов транспорта
+	AssignAt       time.Time
+	Route          *Route
+	RestaurantID   string
+	Address        *Address
+	OrderCreatedAt *time.Time
+	Requirements   OrderRequirements
+	Priority       OrderPriority
+	Status         ScheduledDeliveryStatus
+	CreatedAt      time.Time
+	AssignedAt     *time.Time
+	CancelledAt    *time.Time
+}
+
+type ScheduledDeliveryModify struct {
+	OrderID        *string
+	DeliverAt      *time.Time
+	AssignAt       *time.Time
+	Route          *Route
+	RestaurantID   *string
+	Address        *Address
+	OrderCreatedAt *time.Time
+	Requirements   OrderRequirements
+	Priority       *OrderPriority
+	CreatedAt      *time.Time
+}
+
+// DeliveryScheduleParams заказ, который нужно доставить к DeliverAt. EstimatedDelivery заказа не используется:
+// обещанное клиенту время - DeliverAt
+type DeliveryScheduleParams struct {
+	Order     DeliveryAssignParams
+	DeliverAt time.Time
+}
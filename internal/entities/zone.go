@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+// Zone район обслуживания. Polygon - вершины контура, последняя соединяется с первой
+type Zone struct {
+	ID        int64
+	Name      string
+	Polygon   []Location
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ZoneModify struct {
+	ID      *int64
+	Name    *string
+	Polygon []Location
+}
@@ -25,9 +25,54 @@ func toDomain(protoOrder *proto.Order) *entities.Order {
 		return nil
 	}
 
-	return &entities.Order{
-		ID:        protoOrder.Id,
-		Status:    entities.OrderStatusType(protoOrder.Status),
-		CreatedAt: protoOrder.CreatedAt.AsTime(),
+	order := &entities.Order{
+		ID:            protoOrder.Id,
+		Status:        entities.OrderStatusType(protoOrder.Status),
+		RestaurantID:  protoOrder.RestaurantId,
+		Address:       toDomainAddress(protoOrder.Address),
+		Items:         toDomainItems(protoOrder.Items),
+		TotalPrice:    protoOrder.TotalPrice,
+		PaymentMethod: entities.OrderPaymentMethod(protoOrder.PaymentMethod),
+		CreatedAt:     protoOrder.CreatedAt.AsTime(),
 	}
+	// order-service отдает обещанное время всегда, а непроставленное приходит нулевым (0001-01-01),
+	// такое время считаем отсутствующим
+	if protoOrder.EstimatedDelivery != nil {
+		estimatedDelivery := protoOrder.EstimatedDelivery.AsTime()
+		if !estimatedDelivery.IsZero() {
+			order.EstimatedDelivery = &estimatedDelivery
+		}
+	}
+
+	return order
+}
+
+func toDomainAddress(protoAddress *proto.DeliveryAddress) *entities.Address {
+	if protoAddress == nil {
+		return nil
+	}
+
+	return &entities.Address{
+		Street:    protoAddress.Street,
+		House:     protoAddress.House,
+		Apartment: protoAddress.Apartment,
+		Floor:     protoAddress.Floor,
+		Comment:   protoAddress.Comment,
+	}
+}
+
+func toDomainItems(protoItems []*proto.Item) []entities.OrderItem {
+	items := make([]entities.OrderItem, 0, len(protoItems))
+	for _, protoItem := range protoItems {
+		if protoItem == nil {
+			continue
+		}
+		items = append(items, entities.OrderItem{
+			Name:     protoItem.Name,
+			Price:    protoItem.Price,
+			Quantity: protoItem.Quantity,
+		})
+	}
+
+	return items
 }
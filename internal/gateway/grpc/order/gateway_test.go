@@ -50,6 +50,30 @@ func TestOrderGateway_GetOrderByID(t *testing.T) {
 		Status:    "created",
 		CreatedAt: timestamppb.New(fixedTime),
 	}
+	detailedOrder := &proto.Order{
+		Id:           "order-321",
+		Status:       "created",
+		RestaurantId: "restaurant-7",
+		Items: []*proto.Item{
+			{Name: "Пицца", Price: 59000, Quantity: 2},
+		},
+		TotalPrice:    118000,
+		PaymentMethod: "cash",
+		Address: &proto.DeliveryAddress{
+			Street:    "Тверская",
+			House:     "1",
+			Apartment: "15",
+		},
+		CreatedAt:         timestamppb.New(fixedTime),
+		EstimatedDelivery: timestamppb.New(fixedTime.Add(40 * time.Minute)),
+	}
+	// так order-service передает заказ, которому обещанное время не проставлено
+	zeroEstimateOrder := &proto.Order{
+		Id:                "order-789",
+		Status:            "created",
+		CreatedAt:         timestamppb.New(fixedTime),
+		EstimatedDelivery: timestamppb.New(time.Time{}),
+	}
 
 	tests := []struct {
 		name           string
@@ -74,6 +98,57 @@ func TestOrderGateway_GetOrderByID(t *testing.T) {
 			},
 			errorAssertion: require.NoError,
 		},
+		{
+			name:    "Адрес, ресторан, способ оплаты и обещанное время доставки переносятся из заказа",
+			orderID: "order-321",
+			mockSetup: func(m *mock) {
+				m.Mockclient.EXPECT().
+					GetOrderById(gomock.Any(), gomock.Any()).
+					Return(&proto.GetOrderByIdResponse{Order: detailedOrder}, nil)
+			},
+			resultChecker: func(t *testing.T, result *entities.Order) {
+				require.NotNil(t, result)
+				assert.Equal(t, "restaurant-7", result.RestaurantID)
+				assert.Equal(t, int64(118000), result.TotalPrice)
+				assert.Equal(t, entities.PaymentCash, result.PaymentMethod)
+				assert.Equal(t, []entities.OrderItem{{Name: "Пицца", Price: 59000, Quantity: 2}}, result.Items)
+				require.NotNil(t, result.Address)
+				assert.Equal(t, entities.Address{Street: "Тверская", House: "1", Apartment: "15"}, *result.Address)
+				require.NotNil(t, result.EstimatedDelivery)
+				assert.True(t, fixedTime.Add(40*time.Minute).Equal(*result.EstimatedDelivery))
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:    "Заказ без адреса и обещанного времени",
+			orderID: "order-123",
+			mockSetup: func(m *mock) {
+				m.Mockclient.EXPECT().
+					GetOrderById(gomock.Any(), gomock.Any()).
+					Return(&proto.GetOrderByIdResponse{Order: validOrder}, nil)
+			},
+			resultChecker: func(t *testing.T, result *entities.Order) {
+				require.NotNil(t, result)
+				assert.Nil(t, result.Address)
+				assert.Nil(t, result.EstimatedDelivery)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:    "Нулевое обещанное время считается отсутствующим",
+			orderID: "order-789",
+			mockSetup: func(m *mock) {
+				m.Mockclient.EXPECT().
+					GetOrderById(gomock.Any(), gomock.Any()).
+					Return(&proto.GetOrderByIdResponse{Order: zeroEstimateOrder}, nil)
+			},
+			resultChecker: func(t *testing.T, result *entities.Order) {
+				require.NotNil(t, result)
+				assert.Equal(t, "order-789", result.ID)
+				assert.Nil(t, result.EstimatedDelivery)
+			},
+			errorAssertion: require.NoError,
+		},
 		{
 			name:    "Успешное получение после retry при временной недоступности",
 			orderID: "order-456",
@@ -0,0 +1,8 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=escalation_test
+package escalation
+
+import "github.com/IBM/sarama"
+
+type producer interface {
+	SendMessages(msgs []*sarama.ProducerMessage) error
+}
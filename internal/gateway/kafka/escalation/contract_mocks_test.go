@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contract.go -destination=./contract_mocks_test.go -package=escalation_test
+//
+
+// Package escalation_test is a generated GoMock package.
+package escalation_test
+
+import (
+	reflect "reflect"
+
+	sarama "github.com/IBM/sarama"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockproducer is a mock of producer interface.
+type Mockproducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockproducerMockRecorder
+	isgomock struct{}
+}
+
+// MockproducerMockRecorder is the mock recorder for Mockproducer.
+type MockproducerMockRecorder struct {
+	mock *Mockproducer
+}
+
+// NewMockproducer creates a new mock instance.
+func NewMockproducer(ctrl *gomock.Controller) *Mockproducer {
+	mock := &Mockproducer{ctrl: ctrl}
+	mock.recorder = &MockproducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockproducer) EXPECT() *MockproducerMockRecorder {
+	return m.recorder
+}
+
+// SendMessages mocks base method.
+func (m *Mockproducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessages", msgs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMessages indicates an expected call of SendMessages.
+func (mr *MockproducerMockRecorder) SendMessages(msgs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessages", reflect.TypeOf((*Mockproducer)(nil).SendMessages), msgs)
+}
@@ -0,0 +1,15 @@
+package escalation
+
+import "time"
+
+const eventTypeDeliveryOverdue = "delivery.overdue"
+
+type overdueEvent struct {
+	EventType    string    `json:"event_type"`
+	OrderID      string    `json:"order_id"`
+	CourierID    int64     `json:"courier_id"`
+	RestaurantID string    `json:"restaurant_id,omitempty"`
+	AssignedAt   time.Time `json:"assigned_at"`
+	Deadline     time.Time `json:"deadline"`
+	OverdueAt    time.Time `json:"overdue_at"`
+}
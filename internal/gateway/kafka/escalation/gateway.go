@@ -0,0 +1,63 @@
+package escalation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/IBM/sarama"
+	"service/internal/entities"
+)
+
+type Gateway struct {
+	producer producer
+	topic    string
+}
+
+func New(producer producer, topic string) *Gateway {
+	return &Gateway{
+		producer: producer,
+		topic:    topic,
+	}
+}
+
+// EscalateOverdue отправляет по событию на доставку одним батчем, ключ - order_id,
+// чтобы события одного заказа попадали в одну партицию
+func (g *Gateway) EscalateOverdue(_ context.Context, deliveries []entities.Delivery) error {
+	messages := make([]*sarama.ProducerMessage, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		payload, err := json.Marshal(toOverdueEvent(delivery))
+		if err != nil {
+			return fmt.Errorf("gateway escalation, marshal event %s: %w", delivery.OrderID, err)
+		}
+
+		messages = append(messages, &sarama.ProducerMessage{
+			Topic: g.topic,
+			Key:   sarama.StringEncoder(delivery.OrderID),
+			Value: sarama.ByteEncoder(payload),
+		})
+	}
+
+	err := g.producer.SendMessages(messages)
+	if err != nil {
+		return fmt.Errorf("gateway escalation, send overdue events: %w", err)
+	}
+
+	return nil
+}
+
+func toOverdueEvent(delivery entities.Delivery) overdueEvent {
+	event := overdueEvent{
+		EventType:    eventTypeDeliveryOverdue,
+		OrderID:      delivery.OrderID,
+		CourierID:    delivery.CourierID,
+		RestaurantID: delivery.RestaurantID,
+		AssignedAt:   delivery.AssignedAt,
+		Deadline:     delivery.Deadline,
+	}
+	if delivery.OverdueAt != nil {
+		event.OverdueAt = *delivery.OverdueAt
+	}
+
+	return event
+}
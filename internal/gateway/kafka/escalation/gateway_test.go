@@ -0,0 +1,141 @@
+package escalation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/gateway/kafka/escalation"
+)
+
+type mock struct {
+	*Mockproducer
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		Mockproducer: NewMockproducer(ctrl),
+	}
+}
+
+func errorAssertion(expectedError error, expectedErrMsg string) require.ErrorAssertionFunc {
+	return func(t require.TestingT, err error, msgAndArgs ...interface{}) {
+		require.Error(t, err, msgAndArgs...)
+
+		if expectedError != nil {
+			assert.ErrorIs(t, err, expectedError, msgAndArgs...)
+		}
+
+		if expectedErrMsg != "" {
+			assert.Contains(t, err.Error(), expectedErrMsg, msgAndArgs...)
+		}
+	}
+}
+
+func TestEscalationGateway_EscalateOverdue(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+	overdueAt := fixedTime.Add(time.Minute)
+
+	deliveries := []entities.Delivery{
+		{
+			ID:           1,
+			CourierID:    7,
+			OrderID:      "order-123",
+			RestaurantID: "restaurant-7",
+			AssignedAt:   fixedTime.Add(-30 * time.Minute),
+			Deadline:     fixedTime,
+			OverdueAt:    &overdueAt,
+		},
+		{
+			ID:         2,
+			CourierID:  8,
+			OrderID:    "order-321",
+			AssignedAt: fixedTime.Add(-40 * time.Minute),
+			Deadline:   fixedTime,
+			OverdueAt:  &overdueAt,
+		},
+	}
+
+	sendErr := errors.New("kafka: client has run out of available brokers")
+
+	tests := []struct {
+		name           string
+		mockSetup      func(t *testing.T, m *mock)
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "События отправляются одним батчем с ключом order_id",
+			mockSetup: func(t *testing.T, m *mock) {
+				m.Mockproducer.EXPECT().
+					SendMessages(gomock.Any()).
+					DoAndReturn(func(msgs []*sarama.ProducerMessage) error {
+						require.Len(t, msgs, 2)
+
+						assert.Equal(t, "delivery.overdue", msgs[0].Topic)
+						assert.Equal(t, sarama.StringEncoder("order-123"), msgs[0].Key)
+						payload, err := msgs[0].Value.Encode()
+						require.NoError(t, err)
+						assert.JSONEq(t, `{
+							"event_type": "delivery.overdue",
+							"order_id": "order-123",
+							"courier_id": 7,
+							"restaurant_id": "restaurant-7",
+							"assigned_at": "2026-01-20T11:30:00Z",
+							"deadline": "2026-01-20T12:00:00Z",
+							"overdue_at": "2026-01-20T12:01:00Z"
+						}`, string(payload))
+
+						assert.Equal(t, sarama.StringEncoder("order-321"), msgs[1].Key)
+						payload, err = msgs[1].Value.Encode()
+						require.NoError(t, err)
+						assert.JSONEq(t, `{
+							"event_type": "delivery.overdue",
+							"order_id": "order-321",
+							"courier_id": 8,
+							"assigned_at": "2026-01-20T11:20:00Z",
+							"deadline": "2026-01-20T12:00:00Z",
+							"overdue_at": "2026-01-20T12:01:00Z"
+						}`, string(payload))
+						return nil
+					})
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Ошибка отправки в Kafka",
+			mockSetup: func(t *testing.T, m *mock) {
+				m.Mockproducer.EXPECT().
+					SendMessages(gomock.Any()).
+					Return(sendErr)
+			},
+			errorAssertion: errorAssertion(sendErr, "gateway escalation, send overdue events"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(t, m)
+			}
+
+			gateway := escalation.New(m.Mockproducer, "delivery.overdue")
+
+			err := gateway.EscalateOverdue(context.Background(), deliveries)
+
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
@@ -4,21 +4,119 @@
 package dto
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
 	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Defines values for CourierTariffTransportType.
+const (
+	Car     CourierTariffTransportType = "car"
+	OnFoot  CourierTariffTransportType = "on_foot"
+	Scooter CourierTariffTransportType = "scooter"
+)
+
+// Defines values for DeliveryFeedbackRequestAuthor.
+const (
+	Customer   DeliveryFeedbackRequestAuthor = "customer"
+	Restaurant DeliveryFeedbackRequestAuthor = "restaurant"
+)
+
+// Defines values for HealthCheckStatus.
+const (
+	HealthCheckStatusDown HealthCheckStatus = "down"
+	HealthCheckStatusUp   HealthCheckStatus = "up"
+)
+
+// Defines values for HealthReportStatus.
+const (
+	HealthReportStatusDown HealthReportStatus = "down"
+	HealthReportStatusUp   HealthReportStatus = "up"
+)
+
+// Defines values for CouriersImportPostParamsMode.
+const (
+	AllOrNothing CouriersImportPostParamsMode = "all_or_nothing"
+	BestEffort   CouriersImportPostParamsMode = "best_effort"
 )
 
+// Address defines model for Address.
+type Address struct {
+	Apartment *string `json:"apartment,omitempty"`
+	Comment   *string `json:"comment,omitempty"`
+	Floor     *string `json:"floor,omitempty"`
+	House     string  `json:"house"`
+	Street    string  `json:"street"`
+}
+
+// CashHandover defines model for CashHandover.
+type CashHandover struct {
+	ID     int64 `json:"ID"`
+	Amount int64 `json:"amount"`
+
+	// Balance Cash left with the courier after the handover
+	Balance   int64     `json:"balance"`
+	CourierID int64     `json:"courier_ID"`
+	CreatedAt time.Time `json:"created_at"`
+	Operator  string    `json:"operator"`
+}
+
+// CashHandoverRequest defines model for CashHandoverRequest.
+type CashHandoverRequest struct {
+	// Amount Kopecks, greater than zero
+	Amount int64 `json:"amount"`
+
+	// Operator Operator who received the cash
+	Operator string `json:"operator"`
+}
+
+// CashReconciliation defines model for CashReconciliation.
+type CashReconciliation struct {
+	// BalanceLimit Couriers holding more cash do not get cash orders, 0 means no limit
+	BalanceLimit int64                `json:"balance_limit"`
+	Couriers     []CourierCashBalance `json:"couriers"`
+	GeneratedAt  time.Time            `json:"generated_at"`
+
+	// Outstanding Cash held by all couriers
+	Outstanding int64 `json:"outstanding"`
+}
+
 // Courier defines model for Courier.
 type Courier struct {
-	ID            int64  `json:"ID"`
-	Name          string `json:"name"`
-	Phone         string `json:"phone"`
-	Status        string `json:"status"`
-	TransportType string `json:"transport_type"`
+	ID                 int64      `json:"ID"`
+	DeactivatedAt      *time.Time `json:"deactivated_at,omitempty"`
+	DeactivationReason *string    `json:"deactivation_reason,omitempty"`
+	Name               string     `json:"name"`
+	Phone              string     `json:"phone"`
+
+	// Rating Delivery ratings of the courier over the last RATING_WINDOW, returned by GET /courier/{ID} only
+	Rating        *CourierRating `json:"rating,omitempty"`
+	Status        string         `json:"status"`
+	TransportType string         `json:"transport_type"`
+}
+
+// CourierCashBalance defines model for CourierCashBalance.
+type CourierCashBalance struct {
+	Balance         int64      `json:"balance"`
+	CourierID       int64      `json:"courier_ID"`
+	LastCollectedAt *time.Time `json:"last_collected_at,omitempty"`
+	LastHandoverAt  *time.Time `json:"last_handover_at,omitempty"`
+	Name            string     `json:"name"`
+	OverLimit       bool       `json:"over_limit"`
 }
 
 // CourierCreate defines model for CourierCreate.
 type CourierCreate struct {
-	Name          string `json:"name"`
+	Name string `json:"name"`
+
+	// Phone Any common notation is accepted ("+7 900 000-00-00", "89000000000"), numbers without a country code belong to PHONE_DEFAULT_REGION. The phone is stored and returned in E.164
 	Phone         string `json:"phone"`
 	Status        string `json:"status"`
 	TransportType string `json:"transport_type"`
@@ -29,26 +127,225 @@ type CourierCreateResponse struct {
 	ID int64 `json:"ID"`
 }
 
+// CourierDeactivateRequest defines model for CourierDeactivateRequest.
+type CourierDeactivateRequest struct {
+	Reason string `json:"reason"`
+}
+
+// CourierEarnings defines model for CourierEarnings.
+type CourierEarnings struct {
+	CourierID int64          `json:"courier_ID"`
+	Entries   []EarningEntry `json:"entries"`
+	From      time.Time      `json:"from"`
+	To        time.Time      `json:"to"`
+	Total     int64          `json:"total"`
+}
+
+// CourierImportReport defines model for CourierImportReport.
+type CourierImportReport struct {
+	Committed bool               `json:"committed"`
+	Created   int                `json:"created"`
+	DryRun    bool               `json:"dry_run"`
+	Failed    int                `json:"failed"`
+	Mode      string             `json:"mode"`
+	Rows      []CourierImportRow `json:"rows"`
+	Total     int                `json:"total"`
+}
+
+// CourierImportRow defines model for CourierImportRow.
+type CourierImportRow struct {
+	CourierID *int64  `json:"courier_ID,omitempty"`
+	Error     *string `json:"error,omitempty"`
+	Line      int     `json:"line"`
+
+	// Status created, valid (dry run), failed or skipped (all_or_nothing import was rejected because of other rows)
+	Status string `json:"status"`
+}
+
+// CourierMismatch How many available couriers satisfy each requirement taken separately
+type CourierMismatch struct {
+	AvailableCouriers int64              `json:"available_couriers"`
+	Requirements      []RequirementMatch `json:"requirements"`
+}
+
+// CourierOfferStats defines model for CourierOfferStats.
+type CourierOfferStats struct {
+	AcceptanceRate float64 `json:"acceptance_rate"`
+	Accepted       int64   `json:"accepted"`
+	CourierID      int64   `json:"courier_ID"`
+	Declined       int64   `json:"declined"`
+	Expired        int64   `json:"expired"`
+	Offered        int64   `json:"offered"`
+}
+
+// CourierPatch defines model for CourierPatch.
+type CourierPatch struct {
+	Name *string `json:"name,omitempty"`
+
+	// Phone Any common notation is accepted ("+7 900 000-00-00", "89000000000"), numbers without a country code belong to PHONE_DEFAULT_REGION. The phone is stored and returned in E.164
+	Phone         *string `json:"phone,omitempty"`
+	Status        *string `json:"status,omitempty"`
+	TransportType *string `json:"transport_type,omitempty"`
+}
+
+// CourierRating Delivery ratings of the courier over the last RATING_WINDOW, returned by GET /courier/{ID} only
+type CourierRating struct {
+	// Average Omitted while the courier has no ratings in the window
+	Average    *float64 `json:"average,omitempty"`
+	Count      int64    `json:"count"`
+	WindowDays int      `json:"window_days"`
+}
+
+// CourierSkills defines model for CourierSkills.
+type CourierSkills struct {
+	Skills []string `json:"skills"`
+}
+
+// CourierTariff Amounts in kopecks. Without zone_ID the tariff applies to all zones without own tariff
+type CourierTariff struct {
+	BaseFee       int64                      `json:"base_fee"`
+	LatePenalty   int64                      `json:"late_penalty"`
+	PeakBonus     int64                      `json:"peak_bonus"`
+	PerKmRate     int64                      `json:"per_km_rate"`
+	TransportType CourierTariffTransportType `json:"transport_type"`
+	ZoneID        *int64                     `json:"zone_ID,omitempty"`
+}
+
+// CourierTariffTransportType defines model for CourierTariff.TransportType.
+type CourierTariffTransportType string
+
+// CourierTariffs defines model for CourierTariffs.
+type CourierTariffs struct {
+	Tariffs []CourierTariff `json:"tariffs"`
+}
+
 // CourierUpdate defines model for CourierUpdate.
 type CourierUpdate struct {
-	ID            int64   `json:"ID"`
-	Name          *string `json:"name,omitempty"`
+	ID   int64   `json:"ID"`
+	Name *string `json:"name,omitempty"`
+
+	// Phone Any common notation is accepted ("+7 900 000-00-00", "89000000000"), numbers without a country code belong to PHONE_DEFAULT_REGION. The phone is stored and returned in E.164
 	Phone         *string `json:"phone,omitempty"`
 	Status        *string `json:"status,omitempty"`
 	TransportType *string `json:"transport_type,omitempty"`
 }
 
+// Delivery defines model for Delivery.
+type Delivery struct {
+	Address           *Address   `json:"address,omitempty"`
+	AssignedAt        time.Time  `json:"assigned_at"`
+	CourierID         int64      `json:"courier_ID"`
+	CourierReleasedAt *time.Time `json:"courier_released_at,omitempty"`
+	Deadline          time.Time  `json:"deadline"`
+	EstimatedDelivery *time.Time `json:"estimated_delivery,omitempty"`
+	OrderID           string     `json:"order_ID"`
+	OverdueAt         *time.Time `json:"overdue_at,omitempty"`
+	RestaurantID      *string    `json:"restaurant_ID,omitempty"`
+}
+
+// DeliveryAssignPendingResponse defines model for DeliveryAssignPendingResponse.
+type DeliveryAssignPendingResponse struct {
+	// Offer The order is offered to the courier until expires_at
+	Offer   *DeliveryOffer `json:"offer,omitempty"`
+	OrderID string         `json:"order_ID"`
+
+	// Reason How many available couriers satisfy each requirement taken separately
+	Reason *CourierMismatch `json:"reason,omitempty"`
+}
+
 // DeliveryAssignRequest defines model for DeliveryAssignRequest.
 type DeliveryAssignRequest struct {
-	OrderID string `json:"order_ID"`
+	Address           *Address   `json:"address,omitempty"`
+	Dropoff           *Location  `json:"dropoff,omitempty"`
+	EstimatedDelivery *time.Time `json:"estimated_delivery,omitempty"`
+	OrderID           string     `json:"order_ID"`
+	Pickup            *Location  `json:"pickup,omitempty"`
+
+	// Priority Order priority class: normal (default) or high. A high priority order is assigned before others from the pending queue, gets the fastest available transport, skips restaurant batching and may take the courier of a recently assigned normal order, which goes back to the pending queue
+	Priority     *string            `json:"priority,omitempty"`
+	Requirements *OrderRequirements `json:"requirements,omitempty"`
+	RestaurantID *string            `json:"restaurant_ID,omitempty"`
 }
 
 // DeliveryAssignResponse defines model for DeliveryAssignResponse.
 type DeliveryAssignResponse struct {
 	CourierID        int64     `json:"courier_ID"`
 	DeliveryDeadline time.Time `json:"delivery_deadline"`
-	OrderID          string    `json:"order_ID"`
-	TransportType    string    `json:"transport_type"`
+
+	// EtaConfidence high - predicted from deliveries of the same transport type, weekday and hour; low - from all deliveries of the transport type
+	EtaConfidence *string `json:"eta_confidence,omitempty"`
+	OrderID       string  `json:"order_ID"`
+
+	// PredictedEta Median delivery time predicted from delivery history, omitted until the ETA model is trained
+	PredictedEta  *time.Time `json:"predicted_eta,omitempty"`
+	TransportType string     `json:"transport_type"`
+}
+
+// DeliveryFeedback defines model for DeliveryFeedback.
+type DeliveryFeedback struct {
+	ID        int64     `json:"ID"`
+	Author    string    `json:"author"`
+	Comment   *string   `json:"comment,omitempty"`
+	CourierID int64     `json:"courier_ID"`
+	CreatedAt time.Time `json:"created_at"`
+	OrderID   string    `json:"order_ID"`
+	Rating    int       `json:"rating"`
+	Tags      []string  `json:"tags"`
+}
+
+// DeliveryFeedbackRequest defines model for DeliveryFeedbackRequest.
+type DeliveryFeedbackRequest struct {
+	Author  DeliveryFeedbackRequestAuthor `json:"author"`
+	Comment *string                       `json:"comment,omitempty"`
+	Rating  int                           `json:"rating"`
+	Tags    *[]string                     `json:"tags,omitempty"`
+}
+
+// DeliveryFeedbackRequestAuthor defines model for DeliveryFeedbackRequest.Author.
+type DeliveryFeedbackRequestAuthor string
+
+// DeliveryOffer The order is offered to the courier until expires_at
+type DeliveryOffer struct {
+	ID        int64     `json:"ID"`
+	CourierID int64     `json:"courier_ID"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// DeliveryReassignRequest defines model for DeliveryReassignRequest.
+type DeliveryReassignRequest struct {
+	CourierID *int64 `json:"courier_ID,omitempty"`
+	OrderID   string `json:"order_ID"`
+	Reason    string `json:"reason"`
+}
+
+// DeliveryReassignResponse defines model for DeliveryReassignResponse.
+type DeliveryReassignResponse struct {
+	CourierID         int64     `json:"courier_ID"`
+	DeliveryDeadline  time.Time `json:"delivery_deadline"`
+	OrderID           string    `json:"order_ID"`
+	PreviousCourierID int64     `json:"previous_courier_ID"`
+	Reason            string    `json:"reason"`
+	TransportType     string    `json:"transport_type"`
+}
+
+// DeliveryScheduleRequest defines model for DeliveryScheduleRequest.
+type DeliveryScheduleRequest struct {
+	Address *Address `json:"address,omitempty"`
+
+	// DeliverAt Time the order must be delivered at, must be in the future
+	DeliverAt    time.Time          `json:"deliver_at"`
+	Dropoff      *Location          `json:"dropoff,omitempty"`
+	OrderID      string             `json:"order_ID"`
+	Pickup       *Location          `json:"pickup,omitempty"`
+	Priority     *string            `json:"priority,omitempty"`
+	Requirements *OrderRequirements `json:"requirements,omitempty"`
+	RestaurantID *string            `json:"restaurant_ID,omitempty"`
+}
+
+// DeliverySettings defines model for DeliverySettings.
+type DeliverySettings struct {
+	PeakHours       []PeakHour       `json:"peak_hours"`
+	TransportSpeeds []TransportSpeed `json:"transport_speeds"`
 }
 
 // DeliveryUnassignRequest defines model for DeliveryUnassignRequest.
@@ -63,19 +360,468 @@ type DeliveryUnassignResponse struct {
 	Status    string `json:"status"`
 }
 
+// EarningEntry total is base_fee + distance_fee + peak_bonus - late_penalty, amounts in kopecks
+type EarningEntry struct {
+	AssignedAt     time.Time `json:"assigned_at"`
+	BaseFee        int64     `json:"base_fee"`
+	CompletedAt    time.Time `json:"completed_at"`
+	Deadline       time.Time `json:"deadline"`
+	DistanceFee    int64     `json:"distance_fee"`
+	DistanceKm     float64   `json:"distance_km"`
+	LatePenalty    int64     `json:"late_penalty"`
+	OrderID        string    `json:"order_ID"`
+	PayoutPeriodID *int64    `json:"payout_period_ID,omitempty"`
+	PeakBonus      int64     `json:"peak_bonus"`
+	Total          int64     `json:"total"`
+	TransportType  string    `json:"transport_type"`
+	ZoneID         *int64    `json:"zone_ID,omitempty"`
+}
+
+// HealthCheck defines model for HealthCheck.
+type HealthCheck struct {
+	CheckedAt  time.Time         `json:"checked_at"`
+	DurationMs int64             `json:"duration_ms"`
+	Error      *string           `json:"error,omitempty"`
+	Status     HealthCheckStatus `json:"status"`
+}
+
+// HealthCheckStatus defines model for HealthCheck.Status.
+type HealthCheckStatus string
+
+// HealthReport defines model for HealthReport.
+type HealthReport struct {
+	Checks *map[string]HealthCheck `json:"checks,omitempty"`
+	Status HealthReportStatus      `json:"status"`
+}
+
+// HealthReportStatus defines model for HealthReport.Status.
+type HealthReportStatus string
+
+// Location defines model for Location.
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// OrderRequirements defines model for OrderRequirements.
+type OrderRequirements struct {
+	// Skills Known skills: thermal_bag, age_verified
+	Skills *[]string `json:"skills,omitempty"`
+
+	// TransportTypes Allowed courier transport, empty means any
+	TransportTypes *[]string `json:"transport_types,omitempty"`
+}
+
+// PeakHour Hours are UTC, interval is [start_hour, end_hour)
+type PeakHour struct {
+	EndHour    int     `json:"end_hour"`
+	Multiplier float64 `json:"multiplier"`
+	StartHour  int     `json:"start_hour"`
+}
+
+// PendingAssignment defines model for PendingAssignment.
+type PendingAssignment struct {
+	Address           *Address   `json:"address,omitempty"`
+	EnqueuedAt        time.Time  `json:"enqueued_at"`
+	EstimatedDelivery *time.Time `json:"estimated_delivery,omitempty"`
+	OrderID           string     `json:"order_ID"`
+	Priority          int32      `json:"priority"`
+	RestaurantID      *string    `json:"restaurant_ID,omitempty"`
+}
+
 // PingResponse defines model for PingResponse.
 type PingResponse struct {
 	Message *string `json:"message,omitempty"`
 }
 
+// RequirementMatch defines model for RequirementMatch.
+type RequirementMatch struct {
+	MatchingCouriers int64 `json:"matching_couriers"`
+
+	// Requirement One of: zone, transport_type, skill, cash_balance
+	Requirement string `json:"requirement"`
+
+	// Value Skill, comma separated allowed transport types or cash balance limit in kopecks, empty for zone
+	Value string `json:"value"`
+}
+
+// ScheduledDelivery defines model for ScheduledDelivery.
+type ScheduledDelivery struct {
+	Address *Address `json:"address,omitempty"`
+
+	// AssignAt From this time a courier on the slowest allowed transport is looked for
+	AssignAt     time.Time          `json:"assign_at"`
+	CreatedAt    time.Time          `json:"created_at"`
+	DeliverAt    time.Time          `json:"deliver_at"`
+	OrderID      string             `json:"order_ID"`
+	Priority     string             `json:"priority"`
+	Requirements *OrderRequirements `json:"requirements,omitempty"`
+	RestaurantID *string            `json:"restaurant_ID,omitempty"`
+
+	// Status scheduled, assigned or cancelled
+	Status string `json:"status"`
+}
+
+// TransportSpeed defines model for TransportSpeed.
+type TransportSpeed struct {
+	AverageSpeedKmh         float64 `json:"average_speed_kmh"`
+	HandlingOverheadSeconds int32   `json:"handling_overhead_seconds"`
+	TransportType           string  `json:"transport_type"`
+}
+
+// Zone defines model for Zone.
+type Zone struct {
+	ID        int64      `json:"ID"`
+	CreatedAt time.Time  `json:"created_at"`
+	Name      string     `json:"name"`
+	Polygon   []Location `json:"polygon"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// ZoneCouriers defines model for ZoneCouriers.
+type ZoneCouriers struct {
+	CourierIDs []int64 `json:"courier_IDs"`
+}
+
+// ZoneModify defines model for ZoneModify.
+type ZoneModify struct {
+	Name string `json:"name"`
+
+	// Polygon Vertices of the zone contour, the last vertex connects to the first
+	Polygon []Location `json:"polygon"`
+}
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// CourierPostParams defines parameters for CourierPost.
+type CourierPostParams struct {
+	// IdempotencyKey Client-generated key (up to 255 characters). A retry with the same key and body returns the stored response with the Idempotent-Replayed header instead of repeating the action.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// CourierPutParams defines parameters for CourierPut.
+type CourierPutParams struct {
+	// IfMatch ETag from a previous response. Only a single strong tag or * is supported, any other value never matches and results in 412.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// CourierPatchParams defines parameters for CourierPatch.
+type CourierPatchParams struct {
+	// IfMatch ETag from a previous response. Only a single strong tag or * is supported, any other value never matches and results in 412.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// CourierCashHandoverPostParams defines parameters for CourierCashHandoverPost.
+type CourierCashHandoverPostParams struct {
+	// IdempotencyKey Client-generated key (up to 255 characters). A retry with the same key and body returns the stored response with the Idempotent-Replayed header instead of repeating the action.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// CourierEarningsGetParams defines parameters for CourierEarningsGet.
+type CourierEarningsGetParams struct {
+	From time.Time `form:"from" json:"from"`
+	To   time.Time `form:"to" json:"to"`
+}
+
+// CouriersGetParams defines parameters for CouriersGet.
+type CouriersGetParams struct {
+	IncludeDeactivated *bool `form:"include_deactivated,omitempty" json:"include_deactivated,omitempty"`
+}
+
+// CouriersExportGetParams defines parameters for CouriersExportGet.
+type CouriersExportGetParams struct {
+	IncludeDeactivated *bool `form:"include_deactivated,omitempty" json:"include_deactivated,omitempty"`
+}
+
+// CouriersImportPostParams defines parameters for CouriersImportPost.
+type CouriersImportPostParams struct {
+	Mode   *CouriersImportPostParamsMode `form:"mode,omitempty" json:"mode,omitempty"`
+	DryRun *bool                         `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+}
+
+// CouriersImportPostParamsMode defines parameters for CouriersImportPost.
+type CouriersImportPostParamsMode string
+
+// DeliveryAssignPostParams defines parameters for DeliveryAssignPost.
+type DeliveryAssignPostParams struct {
+	// IdempotencyKey Client-generated key (up to 255 characters). A retry with the same key and body returns the stored response with the Idempotent-Replayed header instead of repeating the action.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// DeliveryReassignPostParams defines parameters for DeliveryReassignPost.
+type DeliveryReassignPostParams struct {
+	// IdempotencyKey Client-generated key (up to 255 characters). A retry with the same key and body returns the stored response with the Idempotent-Replayed header instead of repeating the action.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// DeliverySchedulePostParams defines parameters for DeliverySchedulePost.
+type DeliverySchedulePostParams struct {
+	// IdempotencyKey Client-generated key (up to 255 characters). A retry with the same key and body returns the stored response with the Idempotent-Replayed header instead of repeating the action.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// DeliveryUnassignPostParams defines parameters for DeliveryUnassignPost.
+type DeliveryUnassignPostParams struct {
+	// IdempotencyKey Client-generated key (up to 255 characters). A retry with the same key and body returns the stored response with the Idempotent-Replayed header instead of repeating the action.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// CourierTariffsPutJSONRequestBody defines body for CourierTariffsPut for application/json ContentType.
+type CourierTariffsPutJSONRequestBody = CourierTariffs
+
+// DeliverySettingsPutJSONRequestBody defines body for DeliverySettingsPut for application/json ContentType.
+type DeliverySettingsPutJSONRequestBody = DeliverySettings
+
 // CourierPostJSONRequestBody defines body for CourierPost for application/json ContentType.
 type CourierPostJSONRequestBody = CourierCreate
 
 // CourierPutJSONRequestBody defines body for CourierPut for application/json ContentType.
 type CourierPutJSONRequestBody = CourierUpdate
 
+// CourierDeleteJSONRequestBody defines body for CourierDelete for application/json ContentType.
+type CourierDeleteJSONRequestBody = CourierDeactivateRequest
+
+// CourierPatchApplicationMergePatchPlusJSONRequestBody defines body for CourierPatch for application/merge-patch+json ContentType.
+type CourierPatchApplicationMergePatchPlusJSONRequestBody = CourierPatch
+
+// CourierCashHandoverPostJSONRequestBody defines body for CourierCashHandoverPost for application/json ContentType.
+type CourierCashHandoverPostJSONRequestBody = CashHandoverRequest
+
+// CourierSkillsPutJSONRequestBody defines body for CourierSkillsPut for application/json ContentType.
+type CourierSkillsPutJSONRequestBody = CourierSkills
+
 // DeliveryAssignPostJSONRequestBody defines body for DeliveryAssignPost for application/json ContentType.
 type DeliveryAssignPostJSONRequestBody = DeliveryAssignRequest
 
+// DeliveryReassignPostJSONRequestBody defines body for DeliveryReassignPost for application/json ContentType.
+type DeliveryReassignPostJSONRequestBody = DeliveryReassignRequest
+
+// DeliverySchedulePostJSONRequestBody defines body for DeliverySchedulePost for application/json ContentType.
+type DeliverySchedulePostJSONRequestBody = DeliveryScheduleRequest
+
 // DeliveryUnassignPostJSONRequestBody defines body for DeliveryUnassignPost for application/json ContentType.
 type DeliveryUnassignPostJSONRequestBody = DeliveryUnassignRequest
+
+// DeliveryFeedbackPostJSONRequestBody defines body for DeliveryFeedbackPost for application/json ContentType.
+type DeliveryFeedbackPostJSONRequestBody = DeliveryFeedbackRequest
+
+// ZonePostJSONRequestBody defines body for ZonePost for application/json ContentType.
+type ZonePostJSONRequestBody = ZoneModify
+
+// ZonePutJSONRequestBody defines body for ZonePut for application/json ContentType.
+type ZonePutJSONRequestBody = ZoneModify
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+x9e3PctrX4V8Hs7zfTuKUeduK01f3LtexEN4ntkZV2JklnByLP7qIigQ0ASt5k/N3v",
+	"HLwIkiCXq8fK8bTTiSWRxOO8Xzj4fZaLai04cK1mJ7/PVkALkObHVxd0if8WoHLJ1poJPjuZvRS1ZCDJ",
+	"NUjFBCdUEUqUloIvCXDN9IZousxIvqJ8CYoITuAa5IZUomALllMch4gF0SsguR1sls1UvoKK4nR6s4bZ",
+	"yUxpyfhy9vHjx2y2ppJWoN26zgqo1kIDzzffwSaxwpIB1wdL4CCphoJcwYZ8Ua+JFuTZ8+e4MklzHO3J",
+	"IXlBJGi5ITdMr8ySFK3AfEF5QS5FscEXasmVfaqFhIJIUGvBFTSfhUXpg3NYl3QDBbGwJIwrDbTAPUtY",
+	"A9WML803NMcVH/7CZ9mM4crtB7NsxmkFs5N4pwe41RhMFf3wPfClXs1Onj1/nvXAls3OFj9Qna/6AELE",
+	"koUUFaFkLeGaiVqFLR2St7zcIE4ZX5bgUavpkghJ/kyYIqper4XUUGSE8g0ReoX0QMsaCEdckwrnBWVA",
+	"KEHVpVaEcfLV02djm10c2PVuIQb70FDCi6KQoMyPaynWIDUD8xtdU6kr4DoxRoYUP/hsUQohk09WolaQ",
+	"fKK0BEgN9zGbSfi1ZhKK2cnP/j0/1L8D0sTlfyDXONRLqlbfUl6Ia5D9bZ2d4n8XQlZUz05mjOuvv5qF",
+	"URjXsASJw9BK1FxPfPmSlpTnkGAkqlakhIVuyNwxLKELDdL8ZeVXm02Zy30/n7yTXALy8Jy2d1NQDQea",
+	"VTDL+vhAkFEt5HaMnJ3OWmsKkIsGaa2hgdY27J3DrzUonaDNgJs2tL8Ta8ivVEaWZjqELuXkN5BiGmjj",
+	"XbdHfuuekJuVIBJyYNdQWHRStZplW4DUB8nQ3s8hFzxnJaN25u7WHezmJauYHlQtiqxEWaCQrIS0aySF",
+	"IFxosgRtfxcStVRGjkkFlCvCBbGD7kKFZk1MQ2V++P8SFrOT2f87ahTikZM1R25luMd/OPx/DANTKekG",
+	"fw8aZzdqrbXSlOOGB1hwBWVBLjeEliUJS5+w0Q4eW8vLOshoryMCURLX9uEdBFQBqPyudwZW+I4JPpdA",
+	"lSWz3ntWpyQerFeCp59Io5gnUsI51UH4U12r5IhaUq5QU87to0nSyKzcrzMM3xtsBC0xlQ6x4FTpu6u4",
+	"LqnS81yUJeS7otZ86rXJTl8OItuMFISNe3wpRAmU96DfUgMOCx5araHGQG9Edx/q28mxzfYv+IaglSI4",
+	"ij1rMjNFaJ7DWkNBvvhl9pe/kr8fH5Pj4+MD8/9fZhn5Zfa3vx/7//0ye5IRXleXKFFRgYtaE4oChKPB",
+	"m4sCyCWUxrgT5N23b9+8mp++ev3ix+8v5uevvjl7++aQXKyAmCXi7M72tUYdmsRQoFX36vDp118Zsy5h",
+	"GN0bb9wDWxjcnDsz99ayq8+zI3OeBjE3aA40Qiyy6J8fH2/Tyu67kclfUckZXybM453ZGriWDKbrSzf1",
+	"K6S0lKZE52M6f2uxy7ualrdBZIv9zfrMxH7EBgYjED+rkBLPAf+bgnpVMa2hiKg9CKNgZUYPY30pN3NZ",
+	"8/SXC8rKoQ8rUQyoO3Gzs/3j9iduUjgNkN8CaLOiZkdZBJcG2B4YYXNuwduBL27ug96lHPACS9YyH6Jv",
+	"GmHXluVuKxl6yKwgXxRyQ2TNn2TEbg3danXF1muU67Qs50LOudArNICZ2RO5oYpI+I/RqeQSclorwHCC",
+	"9bwRME+2WvFm4WGVI3D8gakqHTf4VtyQCj1+ek1ZSS/L4A8qoqhmarEhQPMVcfOik000vQJOFGAMR0O5",
+	"mWVdf8gPNo/N8glIimaZTsnnzUc22tCj5K73019eZ+oRWL5dLEC+11SnYhRGmRsTXDqjoRFxor4sI/lm",
+	"1TgO7E2AhzLgCsiRUqaODx/WTE5+WyA4Jr49Jp79OBE4opU3q8p6QB7B1TtP9LQoGFI8Ld9FCFvQUkE2",
+	"qxiP//o0+6+ldw+W3hBOzoNP1obaKZTMBJWt06Y68WSC1rr5AzoV5PzFxdmbb+b/Ontz+vZfWbOlyw35",
+	"5tUFOXJfHf1+dvqRCJ6UUSDpMoG+t1ZzkZsVK6G1hBU1UQm/QMbN0xvGC3Ezy6awer5DDM+OOy/oRk1Q",
+	"wbkL6MRfjXDG+ytWlgkJpsLfg+jtY39MtroBRqa+oJItFgm2MTEpA9YrGzc7JP9ylP+b4DA/OzXw1uZ7",
+	"QtfrkoFC8scgCr7RcIq44e69Ht4vqYL5AmCy96thvgZOS72Z+Mka6NX8UvBaTf5Azq+qvsoY/qLPgMDr",
+	"CuEv+HwhhDYRdyE0mGAnjeN7DSIdVG8juzsLyBqwtrfTgkYHmltpJEGfunmwi5Vrx9tKvH70kZX9uC6S",
+	"4YDJ2vi/2uQeYmopBHkFkjDLmpzSGK341BOaZEqxJd8x2rV7IsR9IKEEqnYPmxbec5n2BSjNKhOeLSJQ",
+	"TfvWBOfdzpIxuaKGnZYvQWlaS8p1etRRW9Evpo2oCCZj9PHCfPIOTFh8OHZkDNJtJOPHNE7BVjg1caEJ",
+	"Iiv4bF1QhCm2b3I4YbUzRxRSrMVise2L74WtBnhQeluz/Kpe77KUtWRCMp2oLHiLExH/nOQlVeqEcFxk",
+	"Sb4oYEHrUj9Bh37FlissLsB/mw/MQo1AdpRILmEhJFhHXtmcPJota0tw5Ncaasgw7WXLDxZUaVA6csCD",
+	"PMxMEEGRhlfIJZIEDoOit6Ib44u37eQFoSYjyHW5aVbldmSWm6Ftm6/IUoAilzS/QqnfW2Najnc99DEM",
+	"GNiexx/szvg7UfsQL9/CZbYDz28hZTWd54IvWAHJDLwhnwOyllAwE/wxFOImZBD8HlO0EkiB4DwZuQG4",
+	"KqgtZFmJWv4PKcUNObBjoBncH6c9xO685tc5B037u/kBCka5n3ZDECoDe9uQFVNayE1GhHOxaq5ZaVb5",
+	"6uIFVhNBiaykJXVe/zSQ72pADCmTnlHbJ4MxKnwNUCA33aXIo9argSjlWIXLfkowRlVb8Or7k2m6vItH",
+	"2cVSC3sOYGEBbrbWDqfgbFhTBox4DyuvlRaVcawaWZb0ryKURYmgp8eJTFAMwYp+YBXO9dzEpuzPT7Mp",
+	"kI2m+fJZYpKKfjiz7z493hYk7UB2DIpvva3UFg7oMgT96AJ8XtV4dWVFgI3uKWvF3ZJ3dmaCaNKJTLC1",
+	"1Cgacgxe50DH7bOd9zLR7twtHxkx3Uhqsr+pT0ENb1NqpkJyvvOKRupTdtVCEXRT6+lQ1gTdNAlL7/MV",
+	"FHUJ9+ka2KEdH3VEANoDOsiBqlaaXII3CaAgVGfhry6guqh1LWGy9r+FZ/KA3sWnYzC3EDNKEqB1uqjA",
+	"xO7QzpwecHsH9OpbUctkOjnQsFoDFNMHvfAfvsfvtofxuvNk8U7GQPEj3yKYRyjnNo7Lj/zeZeYobQ9G",
+	"4cYsZPdRNr6lVn1ITw6YIgA0A3ycmPyFFEzZNJ79tQkUkwMSB4ozQnupgX465zYRux1zAUiZJegHD9TF",
+	"gJmqKf0nV9XErPMtEhvjYpNuRK3na5BMFNPJdedkyfRqoAkq+U45kFHPMcJHKznSwu1YdqQpnBmIcXbo",
+	"McWT3wIt9erlClJOaY5/3pWWa2nrdCt157KbRhZ5z6pe4/7EDU94U71zF04mxSvK4j0Nw2OwmGtlJMtg",
+	"ucC4jophnUp/38d2U3sKhkhvPyXVTNfF1DIUTP9Mf7+zwDBXPE5quX0TZyQH3TlNwTGta5+eoKWIEc35",
+	"JZ5Mo0uYY9hrwUzYaGqkocu4iUlflKW4gSL4q1FwFqq13rjDCpRvdpg3RR/BcErUaNVSESqB/HjxMiPI",
+	"XPLaatOflaZSG8MmI8AL89OTnnL0TwbqCetSs3Xpqv8nUEoz6YTChOjlrFlIa9Z/J8FhQtE2suujKHf1",
+	"VICbyPZuMu/h0hiR2xBL0i+fDbiet/UDwkRtCCTBPpoTq0ApVzMzoeCnV5fXH89lM+5QJpjI6HAgYnFi",
+	"KkIy0mbwzIqPzJw4mjdHAXq4MUcf+2O/d1+LqqKhALIg1AmJdqxdESHNRMRNZM8zRVaslyELIc1yZ9sr",
+	"xJuN+0VmCTCmUOs9/+L+E+VJ1/+1zXwxZZMCtMlQWTdfIcyUTgCPKVIKcYX5AyEnxwFuE9Nuhy7umaf3",
+	"HwoYrlhWHvlZkxI01MlzKG0x9m3CCjEBtORMsM+2xOE7nv3J7+kqPevIz6+q1UQVhYeOSmQJcQ1yBbSY",
+	"K8gFL9REYbtrMK/nAPQXPraoFGh+cuVAtw2H34IdhmuTRLlZWhtzUsAmjo51Ta56Xey4sLEzdW5hnRO9",
+	"0RxDoH0ZqZyhkEs7QjXF4RwLS8XjDq3qB1GwxWb4tFncIiCZRoow1ZYA/8TB8iYvjPqG5IJrYzuGwtpr",
+	"kBo+4AMOuVY+XbNgUulZtjv244wTLrhi3P3+5RZwdTDchxh+wPjCnCTSTJcQtbJ48e4MFaRtaDE7mT09",
+	"PD48dkeqOV2z2cnsS/OnbLamemW2dESLivEj1NhHsnfseQlTjjfjx+ZY8wa0OUePcvYaZEZKKpeo7rwt",
+	"YCB6SHzVK5UQV76Go9lM8LNidpI4i/0NaJuCNMaa2cGz42NLwFw708hUyVpsHP3HZS6aVgyjZUi9CS3E",
+	"O8bWdwjU53be9qMzrkFyWpL3ILF2+5WJBOAQqq4qivbH7BvQJDqkHEyE2GpS5huPHPvCQVQHmkSMKyAl",
+	"a5Adq8zUThjij8BNXviy4puo4jjUGN+sQNo0hk0O2KeuHLypNU5grVXO+tAYa032QNgKfrCfJZut6wQC",
+	"TMcUFDdYleJePiSvbJFIGyEm/bOi12gjJrBwSPypR0JLCdS0b8mFRNZCrkFuk5DTMq9LFPrbsPCu1u64",
+	"Dyj9D1FsHhQBjUTTsoaPj4p+94g47Yi4+ypFC/+gBXEZEHJA/oln3MyCiA3mmc++6n/2RmjyWtS8IAcE",
+	"dZnBzAL/cCeqc5TUp7xGKnjH/EBFqaykXDh3jX+ceUZsgmhITGB09gDjFaSJVihSK1vGgDioNRAfkVU9",
+	"wutm2B5YAHSneyAR4KEdNk4C2CdJA//2Ibno+MsVU9iiqCmadFxKFvidr1RcsA9QNFA/3Ar2h+P4NMT3",
+	"x/NTMO6f3QPb35mHx0gH2TmPupAIlTK4jJWvCCUcboJICL2M1lJcM1QMBdV0SA+8E8aUjTuQ/ZwGc/PK",
+	"UadD2cd/P6gKsducRk1PH2byEAVMkNRLb6aZNwui6jwHpRZ1WW7upFX+njKx+aJkOX7zso1uptyBFG8W",
+	"wAemtIm60SA52k3gOt3XMNLEOFLN0ge0vnr2LEHinc/w8Laf1GgDMwslBTNFblyH6bHb3J04xyIjpvZB",
+	"IWuPJqnAFcgDrvVb4IsFg7LAM0AoT8GLBHvQjvh2bQZe9hFCyFrBhTlGSdgicSRSu9aABVEMfRvmz7fT",
+	"wnaHS/NhfQs2dA3wHpj/LCgfyYIb4ziHsDbHZakWj6mJ3GtH5p2PH+/CqVvsP7/elgm4jb/fGW62QbwO",
+	"U5vPnyZY853x1G26lry23RcOyEVEokiJtlElFK7JXUSfd2JOSyYNY0YqzJw8tqOWoFOpBLEwphRoOGmx",
+	"lNJ0Ew4XIw9j9YBJEfQL6ZUWa+X6v6HhZHuoGduVKbJiRQG8sabcaZYwU8kwAnHuu1CkDzybbjcQHSzA",
+	"DGRe1sZfdye/iDl8q1dUkxtwLlkoTjDyuNbiwB9zQ2/bnzQxVcHdmmCmmsB0ZjajByqJbbcM952KDyk6",
+	"KjKnYaLOZFGEgaMAXLJr4DgS5a2xRsTWqUVoT3IxxCoGk6L+l6ezrvyIO2FuLzh5WCnXb2X06Qm8CHcP",
+	"LOfOuO3m4ink7BQp15XUPpjMeznGargA1pgaMSzuIrYavMc2xbi/7HvHeuhcbpApGYLJsh3C2rfHzak0",
+	"y4+Z2jcXRkEWDA3BnUYbYjbrMe+L0x6PzC297JvAb0/W9xJDvNy4NazTzYn+9/3bN+QHkEsgppML+eL8",
+	"9Uvy1y//9vWTE2uN2tSEMWnXEhRw7RWnafNMJXjL9JC8tu/llONOLoFIqMS1N955XZahDxNGG2yapDJj",
+	"eMLKSM2vTBWSm9Q+tPrzMLaUmGqcDqoM0b/7selLMmYVu4bJe6D57AHM7QrRdWAQ+pdbcYnd/ydree+d",
+	"RzNS0RJxCQVZW6EpCXOvGTK0fbrVQ9vlrOPzXm66RtOORnrQAoUA60VWwfvMa2n86I72sDM8T/i+PHQw",
+	"J+ZMKrnAIO6B63w+QqBxM407SbZ3VGpGy3LjXedBt8CmGFdxT/B0sCvYBrxQtgESfklCM1Zr2vsDicpn",
+	"E11YwyW37FFxzLEgiOFDDr5TdCLZNpg8iftgpwNojySh9hSXS7QB33d0LlpCSlD5ZyE7Nru9yLEHLlDS",
+	"hAbhDyZdHM1awlSDlPl5hfXODY7sBr0gsG5oBPGe3ICo/WvSXA+Z0ihcwEBFzjjj5GeUGhnR4skO5Qft",
+	"/rN7M8szN+yvNchNM65r5jph5NGyovTgWtx96D24Ex4Xw1m+Wzu99hDPI3sHENMyDWvqMYUJBx0o35Ez",
+	"yRednpGtBlrme0UKZiPkxqxRNybCZMJaX/g3M+L7USKMXEPKJ0Os0vQJ/Zx82GZX90x2j0xsFuWm3C1E",
+	"WS1hJChOhvjJsO3WBE5S0UctyI2QV5EaM/WyhGEhTGghFN+MMERm52H0/VlljxwrkY8YEnz4QCCzrlBM",
+	"NSj/uI8OBop1hgoGDoMhZHsI3s0w6ccGewzQnNBy0jZJmrbH6Ock/eyOPi/J53C5tXLHtLpdidKUbGB0",
+	"zH6I+Rl3igXTSS6opuIQ2iE5rS3gwRmaSy7kSKGeBfO7ep+E82BJlphm9h7QGiZY+4RIi97iTuaiD4oa",
+	"ingEcu5WCHqSjuTW9opAQ71iESUTT/uK2ztKmP/0FRGCe7dwiJ5HZGDH9XAjz9tZrwblLoMbWpf37r+5",
+	"qxDcpalu4sDDQ5Qbtq7IauH0CD74k9xJ1L7XEmilGuSZFLK18X3ayooHo3VhKTQz2LbJLfLCGP4uqZWR",
+	"l+//ie+Fhrc3K+BosbGmcBGvUzz680h0X70yS/5UCeLDAS/6RJE46wkf9FGursffu7uWNNAgEZzM51+n",
+	"hYtFl2mheUDeADOhaUQaF5JgLun7e4v1WixGUkGZifw8HTK1V34M+woXPl/VEGO+Ego4EuJLi6kDjGef",
+	"EA94H5pyCVcpbsgXnFaubNlWxUWJKwwZ1xVXmXc08LX2mTXzvljbtgem4WmKMOy8OLw9hWMqp9EnPiTn",
+	"4saKx2ubBoOClOwK+qnjsEQVOj/VnP1a26tXfRMoVtrN0ArbUreNX3VIzjjpXKxSiQLM5aU+OYJAscm5",
+	"2HrBgTOMdV2C0nNYLBCR5tvwld2GK250tXHubhviZ2OK3EimNXCsoPFXwFLu73dhiii6gJNgp7vh2ppE",
+	"gstZUOVuVbGAGZMg9lKcEW+vI0Lc5TwJmTFrQ3CWhdYUvQcRsJLdKtJTNxcC7Sqxpphj9y6s9m6etS6X",
+	"SshL+9xRyUTR+UPIFlpK96oJ5VOQAu7YOfJYlE1MStunXybyeXRTClqQCyHI9xTz8wfktRsMuS7vRfTt",
+	"NXu7Ze+iuP0+gX7hpQ961pEssUKzdW8TSwqhzB1dMLgrTUkyukF3UjiOEoLwMIm/vsrxsasjeyx7WOW4",
+	"c2y4I9c/ryX/yWWtg3BeU2VPvCwBVephfBeUbU4tWa5biRtT0CcUwtDUJaINh5CAwhnnZmajRhaOPszD",
+	"TgMFJ3spkaLWtjQxHBxgyh/A8W2GXWeltm6zJ3yycKKMaUI7J0hSp3+YPeZzSM4WpN8AxCWYmmaFzgys",
+	"lRPl0UJTorzTiv4TPo+Q7if/SKdcOm2+B3g3Ll69BOCh4QEy4LPjZw+0rO6FAonVvRFkIaFZob9Tvalu",
+	"PYh+Duu3HVtsGiS0w/FmSTtYTYCj/TtcMNt04fBX2LtaYQ+k5hrwjTOVCfOTXdpqDaZsHYqfC+wC/Gxu",
+	"uThuGNQ5VBw+6OZ7WZuL8h/uvIjt7e8NML+YDEFQFsRVgbnlflbZZUuREbK1sPjpaAlDGDaka3E97qP0",
+	"7zroFG7jz2ZMs9mKFkC0GJR+JpdknbbPI3dxj3JqBx/ZAnxiHNnAfLfEhP0kcFHIjYYUaBYX7nMRX2Uh",
+	"pCOaLhPejbytXKId6TdM3C5vO5G6O53KjdDqXY+JW7uhTAdDoHV3xjjNn9rlPCbRJ8jE4jlcufgHI8I7",
+	"FsSbTW+lKHvaZWsoOSq8uTFWcLA0kdetOZ0RURYIw/DMNQRJXAplggmgbcBRd442hXCkI9e1EKXXt/Gx",
+	"G7IWJcs3w6el39rd3cMZ9UlBZD/tnqLIDncRbjrIdfy7FbkxrzfmmGV7LwucKNHhnOVaihwQ7YPQd+bj",
+	"vqDfb6+4JzTYA2ooOc1Bf2N2tRLNASEStjmxP5hMYwNwLfxQc5OYyvC0qvXM3I0zWUuoX7bvXWqsGKpJ",
+	"BaCjsf+kmlhq5MEONP47bB1AZMq0jfF+aqeNTGaaGyAwfH8DW1PtLrREls+lUOoA/2DeNe+xYOvjcTqs",
+	"IzwQ/KARXwYg5oIne1AiNv2jMGu/PaFde+xmS2gcbSEd+MJx5D+pzu7tAP5Kg+awB44CKoK3PUq8sa1f",
+	"uN937xDUmAPt75z4I7jQ3Us/HsmJ7l3TMWCedpxQz4333zbmtKHZQCx3qZwJBJYhUfXYW2UuPNOEckKI",
+	"vnVk9TOqNbaoa0vK3tmNlvD1/SK3qkP/YhFbPUaCerb2ON2AzghQWTJr9vgmkqG33EATFzfBvjRjv03q",
+	"fjRjCpC2od+Qw9L1urxTLtlypQm9cffEuccrKAvCihKaW9c4qg7UJktpLHNN1ZUbRsXqwpudpo9rCQvd",
+	"FCgGJMKvNS2twtSSXkNp3xYtgzXWFRlRwvaAlUOtXx21yOYeaNoOoJnZcNEStCE8wQm6ZEa7Y3jJqKIA",
+	"mJjn11JUTLl5mn0cOtWc+bB41o52m9ylv/vRUHgQDBVQbo1CM2Y3Fj+mxjzN/RHUWPcCoT2fgkmwZ1p/",
+	"BWQHFRY47JYajLQoxfNV+9KiO0Ql/V8aQfBZaSGPuNjJtuZ/SU3EmVUwoISOfvfdh0c7atijfs3oTgup",
+	"jhLyrR/i3sfjbLlDx4fWlWlDAZvth0USZtL7rn7YRDvYMVgji+nBmjeir5o2Qw7cXRodmc0QmpitQxY1",
+	"3+YYxtrx1n5HZh/cMAUuoWtR5CIuqqPY/IlVW2AzJu39pU9/BGnfvRDrkZyW3j1ZU2Pqnlbu321pQieN",
+	"t0IuQd/gtHGpUxHZkUnd8OLTkvHtDkcOfpE5GIIYqYxSW1JvDaT1TbMAAncXQRbffu1bAblbhp0F55NQ",
+	"zb2sab6behLhnmT4/XNBiupPG10a3OWHUQRhpvs7gxCwfrmJ1jJAUEeL+H7lYbnvyIC4UFVEPtKcQOhd",
+	"lt64/Y1hgjT+FCnrObYfzlehHQgaDzhOK1eAB+fJBV3aoj6lhbTFQda7yamCUH9ShKMQh+Sc2sacFd7h",
+	"Xq9bK/MNagUn37xqGoeYfNaYcvEXGk9OLt2e2h9O63RvZd6zj9FdRorvLO6Iote3Nr8yYu9Yzoi9Yjkj",
+	"eJ+zDYNVPiZ/S7bc5oScRq5wu1vbxkTpg8aQTUE8U27Bd4tGWebpM51l/JW5Ri33V9atgBbDKsSWMQbD",
+	"zOgOV+CcC1N8QMytYBuiQF6zHIgdvsc93zazfoszTjHI3whfm00OyHs3PlNuiq4itTMQMwUBXqwF49pu",
+	"Gbf/24i6xPv/XUxNr0IuCaeSNceQAx5VWQMvgOfMHbEyOF2Zy/BMoIVyImqNAiU0WzGCUboxRd8L+h6X",
+	"9cAdq1sX8g3YdNF+KYKqA1hcJsfnayku7cOj9ZRE3hrryvsU0oC1B5F395OgG83LbSkhu2OgcYQKDbsP",
+	"k6G52FARVCtofmaNBXjgIbc8f/fSXxyC9jPlRebDI9/RxRU1Z57B3TZyKcWVqeQ36tWuyFTcamWvVGAV",
+	"mJAfhjBtnSn2rjS9IdFDdK6nWgmpXXOAlF48N9t6ZDJ+UZak6LJocEgtRr/c32qatRj5X/NWwY7xPxpm",
+	"UKvadOgm5qbKXmahYF3m+02MVdq8cHdvYIDZXe1irDBNSqAKpZEEQJ40l9VMvJjm0AbVHFyj4rTm5Ffn",
+	"0hvKTKw2ysUaVkhREF5l4Kyph7B5olt/9mzm/GTOivfp4ycDJHtcZUfTxhxBEtJjdoolYmZrOnqbEfq9",
+	"f+/aP9sT9G+CR2S6tUmvjfyphna8NAolvIBtZtWKrVXWUBt6CgrKa8fpV7DWSbLaeyvZbTaNQYYFxq6o",
+	"N+CZ6Ere4+0gFoQd/GbpvgQ47WfRjmCUc28TCHgk5Jm8Z4S5qCPo1l4E4bSjtV+NvMm8gI+O2A0y3x+9",
+	"v8CuimNP5LfbLSMdAszSGmRninxkpeMIdUzrpBoT9Gl0ewOBP5jUetkc5L/76fBHklrfM6WTluUQio9+",
+	"b8oSO+bGIMr3aRpkyWGbNe/D8miaO9mmzCHL4DX6rnQRVZPt2GOnndj/7e4dlXBH/fSJHzip6kISR2eY",
+	"CTF1JVFBjojL1qKF2ntBoDA3GravOihduEMBRpZL83qi0WNEgntTkJ8S/TUnO/dHeD91PrsfMfWiKNrn",
+	"cbtCalz1qH0V3P3k4PzANXZGascK2eigj/83AN1itsEtuQAA",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
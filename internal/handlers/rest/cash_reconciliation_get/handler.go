@@ -0,0 +1,57 @@
+package cash_reconciliation_get
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"service/internal/generated/dto"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reconciliation, err := h.service.GetReconciliation(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	response := dto.CashReconciliation{
+		GeneratedAt:  reconciliation.GeneratedAt,
+		BalanceLimit: reconciliation.BalanceLimit,
+		Outstanding:  reconciliation.Outstanding,
+		Couriers:     make([]dto.CourierCashBalance, len(reconciliation.Couriers)),
+	}
+	for i, balance := range reconciliation.Couriers {
+		response.Couriers[i] = dto.CourierCashBalance{
+			CourierID:       balance.CourierID,
+			Name:            balance.CourierName,
+			Balance:         balance.Balance,
+			OverLimit:       balance.OverLimit,
+			LastCollectedAt: balance.LastCollectedAt,
+			LastHandoverAt:  balance.LastHandoverAt,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
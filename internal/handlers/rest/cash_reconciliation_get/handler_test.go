@@ -0,0 +1,158 @@
+package cash_reconciliation_get_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/cash_reconciliation_get"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestCashReconciliationGetHandler(t *testing.T) {
+	t.Parallel()
+
+	generatedAt := time.Date(2026, 1, 2, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name: "Сверка наличных",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetReconciliation(gomock.Any()).
+					Return(&entities.CashReconciliation{
+						GeneratedAt:  generatedAt,
+						BalanceLimit: 500000,
+						Outstanding:  700000,
+						Couriers: []entities.CourierCashBalance{
+							{
+								CourierID:       2,
+								CourierName:     "Courier 2",
+								Balance:         600000,
+								LastCollectedAt: pointer.To(time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)),
+								OverLimit:       true,
+							},
+							{
+								CourierID:       1,
+								CourierName:     "Courier 1",
+								Balance:         100000,
+								LastCollectedAt: pointer.To(time.Date(2026, 1, 2, 12, 20, 0, 0, time.UTC)),
+								LastHandoverAt:  pointer.To(time.Date(2026, 1, 2, 14, 0, 0, 0, time.UTC)),
+							},
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"generated_at":  "2026-01-02T20:00:00Z",
+				"balance_limit": 500000,
+				"outstanding":   700000,
+				"couriers": []map[string]interface{}{
+					{
+						"courier_ID":        2,
+						"name":              "Courier 2",
+						"balance":           600000,
+						"over_limit":        true,
+						"last_collected_at": "2026-01-02T15:00:00Z",
+					},
+					{
+						"courier_ID":        1,
+						"name":              "Courier 1",
+						"balance":           100000,
+						"over_limit":        false,
+						"last_collected_at": "2026-01-02T12:20:00Z",
+						"last_handover_at":  "2026-01-02T14:00:00Z",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Все наличные сданы",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetReconciliation(gomock.Any()).
+					Return(&entities.CashReconciliation{GeneratedAt: generatedAt, Couriers: []entities.CourierCashBalance{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"generated_at":  "2026-01-02T20:00:00Z",
+				"balance_limit": 0,
+				"outstanding":   0,
+				"couriers":      []interface{}{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ошибка сервиса при сверке",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetReconciliation(gomock.Any()).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			tt.mockSetup(m)
+
+			handler := cash_reconciliation_get.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/cash/reconciliation", http.NoBody)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package courier_cash_handover_post
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"service/internal/entities"
+	"service/internal/generated/dto"
+	"service/internal/service/cash"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["id"]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var request dto.CashHandoverRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	handover, err := h.service.HandOver(r.Context(), entities.CashHandoverParams{
+		CourierID: id,
+		Amount:    request.Amount,
+		Operator:  request.Operator,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, cash.ErrInvalidCourierID),
+			errors.Is(err, cash.ErrInvalidAmount),
+			errors.Is(err, cash.ErrInvalidOperator):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, cash.ErrCourierNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, cash.ErrAmountExceedsBalance):
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := dto.CashHandover{
+		ID:        handover.Transaction.ID,
+		CourierID: handover.Transaction.CourierID,
+		Amount:    handover.Transaction.Amount,
+		Operator:  handover.Transaction.Operator,
+		CreatedAt: handover.Transaction.CreatedAt,
+		Balance:   handover.Balance,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
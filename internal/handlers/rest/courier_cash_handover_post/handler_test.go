@@ -0,0 +1,180 @@
+package courier_cash_handover_post_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/courier_cash_handover_post"
+	"service/internal/service/cash"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestCourierCashHandoverPostHandler(t *testing.T) {
+	t.Parallel()
+
+	validBody := `{"amount": 100000, "operator": "Кассир Петрова"}`
+	params := entities.CashHandoverParams{CourierID: 1, Amount: 100000, Operator: "Кассир Петрова"}
+
+	tests := []struct {
+		name           string
+		courierID      string
+		requestBody    string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name:        "Курьер сдал наличные",
+			courierID:   "1",
+			requestBody: validBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					HandOver(gomock.Any(), params).
+					Return(&entities.CashHandover{
+						Transaction: entities.CashTransaction{
+							ID:        5,
+							CourierID: 1,
+							Kind:      entities.CashHandedOver,
+							Amount:    100000,
+							Operator:  "Кассир Петрова",
+							CreatedAt: time.Date(2026, 1, 2, 18, 0, 0, 0, time.UTC),
+						},
+						Balance: 50000,
+					}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: map[string]interface{}{
+				"ID":         5,
+				"courier_ID": 1,
+				"amount":     100000,
+				"operator":   "Кассир Петрова",
+				"created_at": "2026-01-02T18:00:00Z",
+				"balance":    50000,
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Невалидный ID курьера (не число)",
+			courierID:      "abc",
+			requestBody:    validBody,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:           "Невалидный JSON",
+			courierID:      "1",
+			requestBody:    `{"amount": `,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Нулевая сумма",
+			courierID:   "1",
+			requestBody: `{"amount": 0, "operator": "Кассир Петрова"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					HandOver(gomock.Any(), entities.CashHandoverParams{CourierID: 1, Operator: "Кассир Петрова"}).
+					Return(nil, cash.ErrInvalidAmount)
+			},
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Курьер не найден",
+			courierID:   "1",
+			requestBody: validBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					HandOver(gomock.Any(), params).
+					Return(nil, cash.ErrCourierNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:        "Сумма больше остатка на руках",
+			courierID:   "1",
+			requestBody: validBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					HandOver(gomock.Any(), params).
+					Return(nil, cash.ErrAmountExceedsBalance)
+			},
+			expectedStatus: http.StatusConflict,
+			wantErr:        true,
+		},
+		{
+			name:        "Ошибка сервиса при сдаче наличных",
+			courierID:   "1",
+			requestBody: validBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					HandOver(gomock.Any(), params).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := courier_cash_handover_post.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/courier/"+tt.courierID+"/cash/handover", bytes.NewReader([]byte(tt.requestBody)))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.courierID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
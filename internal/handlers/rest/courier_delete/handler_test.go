@@ -0,0 +1,196 @@
+package courier_delete_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/courier_delete"
+	"service/internal/service/courier"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestCourierDeleteHandler(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		courierID      string
+		requestBody    string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name:        "Успешное отключение курьера",
+			courierID:   "1",
+			requestBody: `{"reason": "уволился"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeactivateCourier(gomock.Any(), entities.CourierDeactivateParams{
+						ID:     1,
+						Reason: "уволился",
+					}).
+					Return(&entities.Courier{
+						ID:                 1,
+						Name:               "Snake Plissken",
+						Phone:              "+79999991111",
+						Status:             entities.CourierAvailable,
+						TransportType:      entities.Car,
+						CreatedAt:          fixedTime,
+						UpdatedAt:          fixedTime,
+						DeactivatedAt:      &fixedTime,
+						DeactivationReason: pointer.To("уволился"),
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"ID":                  float64(1),
+				"name":                "Snake Plissken",
+				"phone":               "+79999991111",
+				"status":              "available",
+				"transport_type":      "car",
+				"deactivated_at":      "2026-01-01T12:00:00Z",
+				"deactivation_reason": "уволился",
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Невалидный ID курьера (не число)",
+			courierID:      "abc",
+			requestBody:    `{"reason": "уволился"}`,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:           "Невалидный JSON",
+			courierID:      "1",
+			requestBody:    `{"reason": `,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Пустая причина",
+			courierID:   "1",
+			requestBody: `{"reason": ""}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeactivateCourier(gomock.Any(), entities.CourierDeactivateParams{ID: 1}).
+					Return(nil, courier.ErrInvalidReason)
+			},
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Курьер не найден",
+			courierID:   "999",
+			requestBody: `{"reason": "уволился"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeactivateCourier(gomock.Any(), gomock.Any()).
+					Return(nil, courier.ErrCourierNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:        "У курьера есть активные доставки",
+			courierID:   "1",
+			requestBody: `{"reason": "уволился"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeactivateCourier(gomock.Any(), gomock.Any()).
+					Return(nil, courier.ErrCourierHasActiveDeliveries)
+			},
+			expectedStatus: http.StatusConflict,
+			wantErr:        true,
+		},
+		{
+			name:        "Курьер уже отключен",
+			courierID:   "1",
+			requestBody: `{"reason": "уволился"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeactivateCourier(gomock.Any(), gomock.Any()).
+					Return(nil, courier.ErrCourierDeactivated)
+			},
+			expectedStatus: http.StatusConflict,
+			wantErr:        true,
+		},
+		{
+			name:        "Ошибка сервиса при отключении курьера",
+			courierID:   "1",
+			requestBody: `{"reason": "уволился"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeactivateCourier(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := courier_delete.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/courier/"+tt.courierID, strings.NewReader(tt.requestBody))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.courierID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
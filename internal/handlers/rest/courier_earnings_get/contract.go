@@ -0,0 +1,21 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=courier_earnings_get_test
+package courier_earnings_get
+
+import (
+	"context"
+	"time"
+
+	"service/internal/entities"
+	"service/pkg/logger"
+)
+
+type handlerLogger interface {
+	Info(msg string, fields ...logger.Field)
+	Warn(msg string, fields ...logger.Field)
+	Error(msg string, fields ...logger.Field)
+	With(fields ...logger.Field) logger.Logger
+}
+
+type Service interface {
+	GetCourierEarnings(ctx context.Context, courierID int64, from, to time.Time) (*entities.CourierEarnings, error)
+}
@@ -0,0 +1,96 @@
+package courier_earnings_get
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"service/internal/generated/dto"
+	"service/internal/service/earnings"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["id"]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	from, err := time.Parse(time.RFC3339, r.URL.Query().Get("from"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	to, err := time.Parse(time.RFC3339, r.URL.Query().Get("to"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	courierEarnings, err := h.service.GetCourierEarnings(r.Context(), id, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, earnings.ErrInvalidCourierID),
+			errors.Is(err, earnings.ErrInvalidPeriod):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, earnings.ErrCourierNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := dto.CourierEarnings{
+		CourierID: courierEarnings.CourierID,
+		From:      courierEarnings.From,
+		To:        courierEarnings.To,
+		Total:     courierEarnings.Total,
+		Entries:   make([]dto.EarningEntry, len(courierEarnings.Entries)),
+	}
+	for i, entry := range courierEarnings.Entries {
+		response.Entries[i] = dto.EarningEntry{
+			OrderID:        entry.OrderID,
+			TransportType:  entry.TransportType.String(),
+			ZoneID:         entry.ZoneID,
+			DistanceKm:     entry.DistanceKm,
+			BaseFee:        entry.BaseFee,
+			DistanceFee:    entry.DistanceFee,
+			PeakBonus:      entry.PeakBonus,
+			LatePenalty:    entry.LatePenalty,
+			Total:          entry.Total,
+			AssignedAt:     entry.AssignedAt,
+			Deadline:       entry.Deadline,
+			CompletedAt:    entry.CompletedAt,
+			PayoutPeriodID: entry.PayoutPeriodID,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
@@ -0,0 +1,222 @@
+package courier_earnings_get_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/courier_earnings_get"
+	"service/internal/service/earnings"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestCourierEarningsGetHandler(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC)
+	validQuery := "?from=2026-01-01T00:00:00Z&to=2026-01-08T00:00:00Z"
+
+	tests := []struct {
+		name           string
+		courierID      string
+		query          string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name:      "Начисления курьеру за период",
+			courierID: "1",
+			query:     validQuery,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetCourierEarnings(gomock.Any(), int64(1), from, to).
+					Return(&entities.CourierEarnings{
+						CourierID: 1,
+						From:      from,
+						To:        to,
+						Total:     16500,
+						Entries: []entities.EarningEntry{
+							{
+								ID:            10,
+								OrderID:       "order-1",
+								CourierID:     1,
+								TransportType: entities.Car,
+								ZoneID:        pointer.To(int64(3)),
+								DistanceKm:    2.5,
+								BaseFee:       15000,
+								DistanceFee:   3000,
+								PeakBonus:     0,
+								LatePenalty:   1500,
+								Total:         16500,
+								AssignedAt:    time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC),
+								Deadline:      time.Date(2026, 1, 2, 12, 30, 0, 0, time.UTC),
+								CompletedAt:   time.Date(2026, 1, 2, 12, 40, 0, 0, time.UTC),
+							},
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"courier_ID": 1,
+				"from":       "2026-01-01T00:00:00Z",
+				"to":         "2026-01-08T00:00:00Z",
+				"total":      16500,
+				"entries": []map[string]interface{}{
+					{
+						"order_ID":       "order-1",
+						"transport_type": "car",
+						"zone_ID":        3,
+						"distance_km":    2.5,
+						"base_fee":       15000,
+						"distance_fee":   3000,
+						"peak_bonus":     0,
+						"late_penalty":   1500,
+						"total":          16500,
+						"assigned_at":    "2026-01-02T12:00:00Z",
+						"deadline":       "2026-01-02T12:30:00Z",
+						"completed_at":   "2026-01-02T12:40:00Z",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Начислений за период нет",
+			courierID: "1",
+			query:     validQuery,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetCourierEarnings(gomock.Any(), int64(1), from, to).
+					Return(&entities.CourierEarnings{CourierID: 1, From: from, To: to, Entries: []entities.EarningEntry{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"courier_ID": 1,
+				"from":       "2026-01-01T00:00:00Z",
+				"to":         "2026-01-08T00:00:00Z",
+				"total":      0,
+				"entries":    []interface{}{},
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Невалидный ID курьера (не число)",
+			courierID:      "abc",
+			query:          validQuery,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:           "Период без начала",
+			courierID:      "1",
+			query:          "?to=2026-01-08T00:00:00Z",
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:           "Конец периода не в RFC 3339",
+			courierID:      "1",
+			query:          "?from=2026-01-01T00:00:00Z&to=2026-01-08",
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:      "Начало периода не раньше конца",
+			courierID: "1",
+			query:     "?from=2026-01-08T00:00:00Z&to=2026-01-01T00:00:00Z",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetCourierEarnings(gomock.Any(), int64(1), to, from).
+					Return(nil, earnings.ErrInvalidPeriod)
+			},
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:      "Курьер не найден",
+			courierID: "999",
+			query:     validQuery,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetCourierEarnings(gomock.Any(), int64(999), from, to).
+					Return(nil, earnings.ErrCourierNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:      "Ошибка сервиса при получении начислений",
+			courierID: "1",
+			query:     validQuery,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetCourierEarnings(gomock.Any(), int64(1), from, to).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := courier_earnings_get.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/courier/"+tt.courierID+"/earnings"+tt.query, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.courierID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
@@ -18,3 +18,7 @@ type handlerLogger interface {
 type Service interface {
 	GetCourier(ctx context.Context, id int64) (*entities.Courier, error)
 }
+
+type RatingService interface {
+	GetCourierRating(ctx context.Context, courierID int64) (*entities.CourierRating, error)
+}
@@ -149,3 +149,42 @@ func (mr *MockServiceMockRecorder) GetCourier(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourier", reflect.TypeOf((*MockService)(nil).GetCourier), ctx, id)
 }
+
+// MockRatingService is a mock of RatingService interface.
+type MockRatingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatingServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockRatingServiceMockRecorder is the mock recorder for MockRatingService.
+type MockRatingServiceMockRecorder struct {
+	mock *MockRatingService
+}
+
+// NewMockRatingService creates a new mock instance.
+func NewMockRatingService(ctrl *gomock.Controller) *MockRatingService {
+	mock := &MockRatingService{ctrl: ctrl}
+	mock.recorder = &MockRatingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRatingService) EXPECT() *MockRatingServiceMockRecorder {
+	return m.recorder
+}
+
+// GetCourierRating mocks base method.
+func (m *MockRatingService) GetCourierRating(ctx context.Context, courierID int64) (*entities.CourierRating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourierRating", ctx, courierID)
+	ret0, _ := ret[0].(*entities.CourierRating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourierRating indicates an expected call of GetCourierRating.
+func (mr *MockRatingServiceMockRecorder) GetCourierRating(ctx, courierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierRating", reflect.TypeOf((*MockRatingService)(nil).GetCourierRating), ctx, courierID)
+}
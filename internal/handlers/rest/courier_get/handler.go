@@ -5,24 +5,29 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
+	"service/internal/entities"
 	"service/internal/generated/dto"
+	"service/internal/pkg/etag"
 	"service/internal/service/courier"
 	"service/pkg/logger"
 )
 
 type Handler struct {
-	log     handlerLogger
-	service Service
+	log           handlerLogger
+	service       Service
+	ratingService RatingService
 }
 
-func New(log handlerLogger, service Service) *Handler {
+func New(log handlerLogger, service Service, ratingService RatingService) *Handler {
 	handlerLog := log.With()
 
 	return &Handler{
-		service: service,
-		log:     handlerLog,
+		service:       service,
+		ratingService: ratingService,
+		log:           handlerLog,
 	}
 }
 
@@ -47,15 +52,29 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	rating, err := h.ratingService.GetCourierRating(r.Context(), courierEntity.ID)
+	if err != nil {
+		h.log.With(
+			logger.NewField("courier_id", courierEntity.ID),
+			logger.NewField("error", err),
+		).Error("get courier rating")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
 	courierDTO := dto.Courier{
-		ID:            courierEntity.ID,
-		Name:          courierEntity.Name,
-		Phone:         courierEntity.Phone,
-		Status:        courierEntity.Status.String(),
-		TransportType: courierEntity.TransportType.String(),
+		ID:                 courierEntity.ID,
+		Name:               courierEntity.Name,
+		Phone:              courierEntity.Phone,
+		Status:             courierEntity.Status.String(),
+		TransportType:      courierEntity.TransportType.String(),
+		DeactivatedAt:      courierEntity.DeactivatedAt,
+		DeactivationReason: courierEntity.DeactivationReason,
+		Rating:             ratingToDTO(rating),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag.Format(courierEntity.Version))
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(courierDTO)
 	if err != nil {
@@ -64,3 +83,16 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		).Error("encode JSON response")
 	}
 }
+
+// ratingToDTO без оценок за окно средняя в ответ не попадает
+func ratingToDTO(rating *entities.CourierRating) *dto.CourierRating {
+	ratingDTO := &dto.CourierRating{
+		Count:      rating.Count,
+		WindowDays: int(rating.Window / (24 * time.Hour)),
+	}
+	if rating.HasRatings() {
+		ratingDTO.Average = &rating.Average
+	}
+
+	return ratingDTO
+}
@@ -1,6 +1,7 @@
 package courier_get_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/AlekSi/pointer"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,12 +21,14 @@ import (
 
 type mock struct {
 	*MockService
+	*MockRatingService
 	*MockhandlerLogger
 }
 
 func newMock(ctrl *gomock.Controller) *mock {
 	return &mock{
 		MockService:       NewMockService(ctrl),
+		MockRatingService: NewMockRatingService(ctrl),
 		MockhandlerLogger: NewMockhandlerLogger(ctrl),
 	}
 }
@@ -33,12 +37,15 @@ func TestCourierGetHandler(t *testing.T) {
 	t.Parallel()
 
 	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	ratingWindow := 30 * 24 * time.Hour
+	noRatings := map[string]interface{}{"count": 0, "window_days": 30}
 
 	tests := []struct {
 		name           string
 		courierID      string
 		mockSetup      func(m *mock)
 		expectedStatus int
+		expectedETag   string
 		expectedBody   map[string]interface{}
 		wantErr        bool
 	}{
@@ -56,15 +63,18 @@ func TestCourierGetHandler(t *testing.T) {
 						TransportType: entities.Car,
 						CreatedAt:     fixedTime,
 						UpdatedAt:     fixedTime,
+						Version:       3,
 					}, nil)
 			},
 			expectedStatus: http.StatusOK,
+			expectedETag:   `"3"`,
 			expectedBody: map[string]interface{}{
 				"ID":             float64(1),
 				"name":           "Snake Plissken",
 				"phone":          "79999991111",
 				"status":         "available",
 				"transport_type": "car",
+				"rating":         noRatings,
 			},
 			wantErr: false,
 		},
@@ -91,9 +101,88 @@ func TestCourierGetHandler(t *testing.T) {
 				"phone":          "79999992222",
 				"status":         "busy",
 				"transport_type": "scooter",
+				"rating":         noRatings,
 			},
 			wantErr: false,
 		},
+		{
+			name:      "Отключенный курьер возвращается с временем и причиной отключения",
+			courierID: "3",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetCourier(gomock.Any(), int64(3)).
+					Return(&entities.Courier{
+						ID:                 3,
+						Name:               "Khan Li",
+						Phone:              "79999993333",
+						Status:             entities.CourierPaused,
+						TransportType:      entities.OnFoot,
+						CreatedAt:          fixedTime,
+						UpdatedAt:          fixedTime,
+						DeactivatedAt:      &fixedTime,
+						DeactivationReason: pointer.To("уволился"),
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"ID":                  float64(3),
+				"name":                "Khan Li",
+				"phone":               "79999993333",
+				"status":              "paused",
+				"transport_type":      "on_foot",
+				"deactivated_at":      "2026-01-01T12:00:00Z",
+				"deactivation_reason": "уволился",
+				"rating":              noRatings,
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Средняя оценка курьера за окно",
+			courierID: "4",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetCourier(gomock.Any(), int64(4)).
+					Return(&entities.Courier{
+						ID:            4,
+						Name:          "Snake Plissken",
+						Phone:         "79999994444",
+						Status:        entities.CourierAvailable,
+						TransportType: entities.Car,
+						CreatedAt:     fixedTime,
+						UpdatedAt:     fixedTime,
+					}, nil)
+				m.MockRatingService.EXPECT().
+					GetCourierRating(gomock.Any(), int64(4)).
+					Return(&entities.CourierRating{CourierID: 4, Average: 4.5, Count: 12, Window: ratingWindow}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"ID":             float64(4),
+				"name":           "Snake Plissken",
+				"phone":          "79999994444",
+				"status":         "available",
+				"transport_type": "car",
+				"rating":         map[string]interface{}{"average": 4.5, "count": 12, "window_days": 30},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Ошибка получения оценки курьера",
+			courierID: "4",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetCourier(gomock.Any(), int64(4)).
+					Return(&entities.Courier{ID: 4, Status: entities.CourierAvailable, TransportType: entities.Car}, nil)
+				m.MockRatingService.EXPECT().
+					GetCourierRating(gomock.Any(), int64(4)).
+					Return(nil, errors.New("database connection error"))
+				m.MockhandlerLogger.EXPECT().
+					Error("get courier rating")
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+			wantErr:        true,
+		},
 		{
 			name:           "Невалидный ID курьера (не число)",
 			courierID:      "abc",
@@ -156,8 +245,15 @@ func TestCourierGetHandler(t *testing.T) {
 			if tt.mockSetup != nil {
 				tt.mockSetup(m)
 			}
+			// курьер без оценок, если тест не задал оценку сам
+			m.MockRatingService.EXPECT().
+				GetCourierRating(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, courierID int64) (*entities.CourierRating, error) {
+					return &entities.CourierRating{CourierID: courierID, Window: ratingWindow}, nil
+				}).
+				AnyTimes()
 
-			handler := courier_get.New(m.MockhandlerLogger, m.MockService)
+			handler := courier_get.New(m.MockhandlerLogger, m.MockService, m.MockRatingService)
 
 			req := httptest.NewRequest(http.MethodGet, "/courier/"+tt.courierID, http.NoBody)
 			req = mux.SetURLVars(req, map[string]string{"id": tt.courierID})
@@ -166,6 +262,9 @@ func TestCourierGetHandler(t *testing.T) {
 			handler.ServeHTTP(w, req)
 
 			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+			if tt.expectedETag != "" {
+				assert.Equal(t, tt.expectedETag, w.Header().Get("ETag"))
+			}
 
 			if tt.wantErr {
 				return
@@ -0,0 +1,20 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=courier_offer_stats_get_test
+package courier_offer_stats_get
+
+import (
+	"context"
+
+	"service/internal/entities"
+	"service/pkg/logger"
+)
+
+type handlerLogger interface {
+	Info(msg string, fields ...logger.Field)
+	Warn(msg string, fields ...logger.Field)
+	Error(msg string, fields ...logger.Field)
+	With(fields ...logger.Field) logger.Logger
+}
+
+type Service interface {
+	GetCourierOfferStats(ctx context.Context, courierID int64) (*entities.CourierOfferStats, error)
+}
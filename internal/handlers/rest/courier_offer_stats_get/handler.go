@@ -0,0 +1,67 @@
+package courier_offer_stats_get
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"service/internal/generated/dto"
+	"service/internal/service/delivery"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["id"]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	stats, err := h.service.GetCourierOfferStats(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, delivery.ErrInvalidCourierID):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, delivery.ErrCourierNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := dto.CourierOfferStats{
+		CourierID:      stats.CourierID,
+		Offered:        stats.Offered,
+		Accepted:       stats.Accepted,
+		Declined:       stats.Declined,
+		Expired:        stats.Expired,
+		AcceptanceRate: stats.AcceptanceRate(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
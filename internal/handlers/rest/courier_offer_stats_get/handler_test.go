@@ -0,0 +1,154 @@
+package courier_offer_stats_get_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/courier_offer_stats_get"
+	"service/internal/service/delivery"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestCourierOfferStatsGetHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		courierID      string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name:      "Статистика ответов курьера",
+			courierID: "1",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetCourierOfferStats(gomock.Any(), int64(1)).
+					Return(&entities.CourierOfferStats{
+						CourierID: 1,
+						Offered:   5,
+						Accepted:  3,
+						Declined:  1,
+						Expired:   0,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"courier_ID":      1,
+				"offered":         5,
+				"accepted":        3,
+				"declined":        1,
+				"expired":         0,
+				"acceptance_rate": 0.75,
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Курьеру еще ничего не предлагали",
+			courierID: "1",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetCourierOfferStats(gomock.Any(), int64(1)).
+					Return(&entities.CourierOfferStats{CourierID: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"courier_ID":      1,
+				"offered":         0,
+				"accepted":        0,
+				"declined":        0,
+				"expired":         0,
+				"acceptance_rate": 0,
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Невалидный ID курьера (не число)",
+			courierID:      "abc",
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:      "Курьер не найден",
+			courierID: "999",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetCourierOfferStats(gomock.Any(), int64(999)).
+					Return(nil, delivery.ErrCourierNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:      "Ошибка сервиса при получении статистики",
+			courierID: "1",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetCourierOfferStats(gomock.Any(), int64(1)).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := courier_offer_stats_get.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/courier/"+tt.courierID+"/offer-stats", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.courierID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
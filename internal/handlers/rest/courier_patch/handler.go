@@ -0,0 +1,115 @@
+package courier_patch
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"service/internal/generated/dto"
+	"service/internal/pkg/etag"
+	"service/internal/service/courier"
+	"service/pkg/logger"
+)
+
+const contentTypeMergePatch = "application/merge-patch+json"
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != contentTypeMergePatch {
+		w.Header().Set("Accept-Patch", contentTypeMergePatch)
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	idStr := mux.Vars(r)["id"]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	expectedVersion, hasIfMatch, err := etag.ParseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+
+	courierModifyEntity, err := decodeMergePatch(r.Body)
+	if err != nil {
+		if !errors.Is(err, errInvalidPatch) {
+			h.log.With(
+				logger.NewField("error", err),
+			).Error("load courier patch schema")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	courierModifyEntity.ID = &id
+	if hasIfMatch {
+		courierModifyEntity.ExpectedVersion = &expectedVersion
+	}
+
+	// валидация общая с PUT: patch превращается в тот же CourierModify
+	res, err := h.service.UpdateCourier(r.Context(), courierModifyEntity)
+	if err != nil {
+		switch {
+		case errors.Is(err, courier.ErrMissingRequiredFields),
+			errors.Is(err, courier.ErrInvalidCourierID),
+			errors.Is(err, courier.ErrInvalidName),
+			errors.Is(err, courier.ErrInvalidPhone),
+			errors.Is(err, courier.ErrInvalidStatus),
+			errors.Is(err, courier.ErrInvalidTransport),
+			errors.Is(err, courier.ErrInvalidVersion):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, courier.ErrCourierNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, courier.ErrConflict):
+			w.WriteHeader(http.StatusConflict)
+		case errors.Is(err, courier.ErrVersionMismatch):
+			w.WriteHeader(http.StatusPreconditionFailed)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := dto.Courier{
+		ID:                 res.ID,
+		Name:               res.Name,
+		Phone:              res.Phone,
+		Status:             res.Status.String(),
+		TransportType:      res.TransportType.String(),
+		DeactivatedAt:      res.DeactivatedAt,
+		DeactivationReason: res.DeactivationReason,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag.Format(res.Version))
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
@@ -0,0 +1,371 @@
+package courier_patch_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/courier_patch"
+	"service/internal/service/courier"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestCourierPatchHandler(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		id             string
+		contentType    string
+		requestBody    string
+		ifMatch        string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedETag   string
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name:        "Успешное изменение одного поля",
+			id:          "1",
+			contentType: "application/merge-patch+json",
+			requestBody: `{"name": "Snake Plissken"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateCourier(gomock.Any(), entities.CourierModify{
+						ID:   pointer.To(int64(1)),
+						Name: pointer.To("Snake Plissken"),
+					}).
+					Return(&entities.Courier{
+						ID:            1,
+						Name:          "Snake Plissken",
+						Phone:         "79999991111",
+						Status:        entities.CourierAvailable,
+						TransportType: entities.Car,
+						CreatedAt:     fixedTime,
+						UpdatedAt:     fixedTime,
+						Version:       2,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"2"`,
+			expectedBody: map[string]interface{}{
+				"ID":             float64(1),
+				"name":           "Snake Plissken",
+				"phone":          "79999991111",
+				"status":         "available",
+				"transport_type": "car",
+			},
+			wantErr: false,
+		},
+		{
+			name:        "Успешное изменение всех полей с параметром charset",
+			id:          "2",
+			contentType: "application/merge-patch+json; charset=utf-8",
+			requestBody: `{
+				"name": "Renegade Immortal",
+				"phone": "79999992222",
+				"status": "busy",
+				"transport_type": "scooter"
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateCourier(gomock.Any(), entities.CourierModify{
+						ID:            pointer.To(int64(2)),
+						Name:          pointer.To("Renegade Immortal"),
+						Phone:         pointer.To("79999992222"),
+						Status:        pointer.To(entities.CourierBusy),
+						TransportType: pointer.To(entities.Scooter),
+					}).
+					Return(&entities.Courier{
+						ID:            2,
+						Name:          "Renegade Immortal",
+						Phone:         "79999992222",
+						Status:        entities.CourierBusy,
+						TransportType: entities.Scooter,
+						CreatedAt:     fixedTime,
+						UpdatedAt:     fixedTime,
+						Version:       7,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"7"`,
+			expectedBody: map[string]interface{}{
+				"ID":             float64(2),
+				"name":           "Renegade Immortal",
+				"phone":          "79999992222",
+				"status":         "busy",
+				"transport_type": "scooter",
+			},
+			wantErr: false,
+		},
+		{
+			name:        "If-Match передает ожидаемую версию",
+			id:          "1",
+			contentType: "application/merge-patch+json",
+			requestBody: `{"status": "paused"}`,
+			ifMatch:     `"4"`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateCourier(gomock.Any(), entities.CourierModify{
+						ID:              pointer.To(int64(1)),
+						Status:          pointer.To(entities.CourierPaused),
+						ExpectedVersion: pointer.To(int64(4)),
+					}).
+					Return(&entities.Courier{
+						ID:            1,
+						Name:          "Snake Plissken",
+						Phone:         "79999991111",
+						Status:        entities.CourierPaused,
+						TransportType: entities.Car,
+						CreatedAt:     fixedTime,
+						UpdatedAt:     fixedTime,
+						Version:       5,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"5"`,
+			expectedBody: map[string]interface{}{
+				"ID":             float64(1),
+				"name":           "Snake Plissken",
+				"phone":          "79999991111",
+				"status":         "paused",
+				"transport_type": "car",
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Content-Type application/json не поддерживается",
+			id:             "1",
+			contentType:    "application/json",
+			requestBody:    `{"name": "Snake Plissken"}`,
+			expectedStatus: http.StatusUnsupportedMediaType,
+			wantErr:        true,
+		},
+		{
+			name:           "Отсутствует Content-Type",
+			id:             "1",
+			requestBody:    `{"name": "Snake Plissken"}`,
+			expectedStatus: http.StatusUnsupportedMediaType,
+			wantErr:        true,
+		},
+		{
+			name:           "Невалидный ID в пути",
+			id:             "abc",
+			contentType:    "application/merge-patch+json",
+			requestBody:    `{"name": "Snake Plissken"}`,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:           "Слабый тег в If-Match не совпадает никогда",
+			id:             "1",
+			contentType:    "application/merge-patch+json",
+			requestBody:    `{"status": "paused"}`,
+			ifMatch:        `W/"4"`,
+			expectedStatus: http.StatusPreconditionFailed,
+			wantErr:        true,
+		},
+		{
+			name:           "Невалидный JSON в теле запроса",
+			id:             "1",
+			contentType:    "application/merge-patch+json",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:           "Тело запроса не объект",
+			id:             "1",
+			contentType:    "application/merge-patch+json",
+			requestBody:    `["name"]`,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:           "Тело запроса null",
+			id:             "1",
+			contentType:    "application/merge-patch+json",
+			requestBody:    `null`,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:           "Пустой patch",
+			id:             "1",
+			contentType:    "application/merge-patch+json",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:           "Неизвестное поле",
+			id:             "1",
+			contentType:    "application/merge-patch+json",
+			requestBody:    `{"nmae": "Snake Plissken"}`,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:           "ID нельзя менять через patch",
+			id:             "1",
+			contentType:    "application/merge-patch+json",
+			requestBody:    `{"ID": 2}`,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:           "Удаление обязательного поля через null",
+			id:             "1",
+			contentType:    "application/merge-patch+json",
+			requestBody:    `{"phone": null}`,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:           "Поле неверного типа",
+			id:             "1",
+			contentType:    "application/merge-patch+json",
+			requestBody:    `{"phone": 79999991111}`,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Невалидный статус отклоняется валидацией сервиса",
+			id:          "1",
+			contentType: "application/merge-patch+json",
+			requestBody: `{"status": "invalid_status"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateCourier(gomock.Any(), gomock.Any()).
+					Return(nil, courier.ErrInvalidStatus)
+			},
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Курьер не найден",
+			id:          "999",
+			contentType: "application/merge-patch+json",
+			requestBody: `{"name": "Snake Plissken"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateCourier(gomock.Any(), gomock.Any()).
+					Return(nil, courier.ErrCourierNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:        "Конфликт - телефон уже используется другим курьером",
+			id:          "1",
+			contentType: "application/merge-patch+json",
+			requestBody: `{"phone": "79999991111"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateCourier(gomock.Any(), gomock.Any()).
+					Return(nil, courier.ErrConflict)
+			},
+			expectedStatus: http.StatusConflict,
+			wantErr:        true,
+		},
+		{
+			name:        "Курьера изменили после чтения - версия не совпала",
+			id:          "1",
+			contentType: "application/merge-patch+json",
+			requestBody: `{"status": "paused"}`,
+			ifMatch:     `"4"`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateCourier(gomock.Any(), gomock.Any()).
+					Return(nil, courier.ErrVersionMismatch)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			wantErr:        true,
+		},
+		{
+			name:        "Ошибка сервиса при изменении курьера",
+			id:          "1",
+			contentType: "application/merge-patch+json",
+			requestBody: `{"name": "Snake Plissken"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateCourier(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := courier_patch.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodPatch, "/courier/"+tt.id, bytes.NewReader([]byte(tt.requestBody)))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+			if tt.expectedETag != "" {
+				assert.Equal(t, tt.expectedETag, w.Header().Get("ETag"))
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
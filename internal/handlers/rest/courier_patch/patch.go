@@ -0,0 +1,76 @@
+package courier_patch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"service/internal/entities"
+	"service/internal/generated/dto"
+)
+
+const courierPatchSchemaName = "CourierPatch"
+
+var errInvalidPatch = errors.New("invalid merge patch")
+
+// courierPatchSchema схема CourierPatch из спецификации API, встроенной в dto.
+// Спецификация разбирается один раз, при первом patch
+var courierPatchSchema = sync.OnceValues(func() (*openapi3.Schema, error) {
+	spec, err := dto.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("load openapi spec: %w", err)
+	}
+
+	schemaRef := spec.Components.Schemas[courierPatchSchemaName]
+	if schemaRef == nil || schemaRef.Value == nil {
+		return nil, fmt.Errorf("openapi schema %s not found", courierPatchSchemaName)
+	}
+
+	return schemaRef.Value, nil
+})
+
+// decodeMergePatch разбирает JSON Merge Patch (RFC 7386) и проверяет его по схеме CourierPatch.
+// Схема не допускает null (все поля курьера обязательны, удалить их нельзя), неизвестные поля
+// (опечатка в имени поля не должна молча превращаться в пустой patch) и пустой patch.
+// Ошибка без errInvalidPatch означает, что не загрузилась сама схема
+func decodeMergePatch(r io.Reader) (entities.CourierModify, error) {
+	var patch any
+	err := json.NewDecoder(r).Decode(&patch)
+	if err != nil {
+		return entities.CourierModify{}, fmt.Errorf("%w: %w", errInvalidPatch, err)
+	}
+
+	schema, err := courierPatchSchema()
+	if err != nil {
+		return entities.CourierModify{}, err
+	}
+
+	err = schema.VisitJSON(patch)
+	if err != nil {
+		return entities.CourierModify{}, fmt.Errorf("%w: %w", errInvalidPatch, err)
+	}
+
+	// после проверки схемой patch - объект со строковыми полями CourierPatch
+	modify := entities.CourierModify{}
+	for name, raw := range patch.(map[string]any) {
+		value := raw.(string)
+
+		switch name {
+		case "name":
+			modify.Name = &value
+		case "phone":
+			modify.Phone = &value
+		case "status":
+			status := entities.CourierStatusType(value)
+			modify.Status = &status
+		case "transport_type":
+			transportType := entities.CourierTransportType(value)
+			modify.TransportType = &transportType
+		}
+	}
+
+	return modify, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"service/internal/entities"
 	"service/internal/generated/dto"
+	"service/internal/pkg/etag"
 	"service/internal/service/courier"
 	"service/pkg/logger"
 )
@@ -26,8 +27,15 @@ func New(log handlerLogger, service Service) *Handler {
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// без If-Match курьер перезаписывается как раньше
+	expectedVersion, hasIfMatch, err := etag.ParseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+
 	var courierModifyDTO dto.CourierUpdate
-	err := json.NewDecoder(r.Body).Decode(&courierModifyDTO)
+	err = json.NewDecoder(r.Body).Decode(&courierModifyDTO)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
@@ -36,6 +44,9 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	courierModifyEntity := entities.CourierModify{
 		ID: &courierModifyDTO.ID,
 	}
+	if hasIfMatch {
+		courierModifyEntity.ExpectedVersion = &expectedVersion
+	}
 
 	// Опциональные параметры
 	if courierModifyDTO.Name != nil {
@@ -61,12 +72,15 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			errors.Is(err, courier.ErrInvalidName),
 			errors.Is(err, courier.ErrInvalidPhone),
 			errors.Is(err, courier.ErrInvalidStatus),
-			errors.Is(err, courier.ErrInvalidTransport):
+			errors.Is(err, courier.ErrInvalidTransport),
+			errors.Is(err, courier.ErrInvalidVersion):
 			w.WriteHeader(http.StatusBadRequest)
 		case errors.Is(err, courier.ErrCourierNotFound):
 			w.WriteHeader(http.StatusNotFound)
 		case errors.Is(err, courier.ErrConflict):
 			w.WriteHeader(http.StatusConflict)
+		case errors.Is(err, courier.ErrVersionMismatch):
+			w.WriteHeader(http.StatusPreconditionFailed)
 		default:
 			w.WriteHeader(http.StatusInternalServerError)
 		}
@@ -74,14 +88,17 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := dto.Courier{
-		ID:            res.ID,
-		Name:          res.Name,
-		Phone:         res.Phone,
-		Status:        res.Status.String(),
-		TransportType: res.TransportType.String(),
+		ID:                 res.ID,
+		Name:               res.Name,
+		Phone:              res.Phone,
+		Status:             res.Status.String(),
+		TransportType:      res.TransportType.String(),
+		DeactivatedAt:      res.DeactivatedAt,
+		DeactivationReason: res.DeactivationReason,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag.Format(res.Version))
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(response)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/AlekSi/pointer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -37,8 +38,10 @@ func TestCourierPutHandler(t *testing.T) {
 	tests := []struct {
 		name           string
 		requestBody    string
+		ifMatch        string
 		mockSetup      func(m *mock)
 		expectedStatus int
+		expectedETag   string
 		expectedBody   map[string]interface{}
 		wantErr        bool
 	}{
@@ -132,6 +135,58 @@ func TestCourierPutHandler(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:        "Обновление с If-Match передает ожидаемую версию",
+			requestBody: `{"ID": 1, "status": "paused"}`,
+			ifMatch:     `"4"`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateCourier(gomock.Any(), entities.CourierModify{
+						ID:              pointer.To(int64(1)),
+						Status:          pointer.To(entities.CourierPaused),
+						ExpectedVersion: pointer.To(int64(4)),
+					}).
+					Return(&entities.Courier{
+						ID:            1,
+						Name:          "Snake Plissken",
+						Phone:         "79999991111",
+						Status:        entities.CourierPaused,
+						TransportType: entities.Car,
+						CreatedAt:     fixedTime,
+						UpdatedAt:     fixedTime,
+						Version:       5,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"5"`,
+			expectedBody: map[string]interface{}{
+				"ID":             float64(1),
+				"name":           "Snake Plissken",
+				"phone":          "79999991111",
+				"status":         "paused",
+				"transport_type": "car",
+			},
+			wantErr: false,
+		},
+		{
+			name:        "Курьера изменили после чтения - версия не совпала",
+			requestBody: `{"ID": 1, "status": "paused"}`,
+			ifMatch:     `"4"`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateCourier(gomock.Any(), gomock.Any()).
+					Return(nil, courier.ErrVersionMismatch)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			wantErr:        true,
+		},
+		{
+			name:           "Слабый тег в If-Match не совпадает никогда",
+			requestBody:    `{"ID": 1, "status": "paused"}`,
+			ifMatch:        `W/"4"`,
+			expectedStatus: http.StatusPreconditionFailed,
+			wantErr:        true,
+		},
 		{
 			name:           "Невалидный JSON в теле запроса",
 			requestBody:    "invalid json",
@@ -354,11 +409,17 @@ func TestCourierPutHandler(t *testing.T) {
 
 			req := httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader([]byte(tt.requestBody)))
 			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 			w := httptest.NewRecorder()
 
 			handler.ServeHTTP(w, req)
 
 			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+			if tt.expectedETag != "" {
+				assert.Equal(t, tt.expectedETag, w.Header().Get("ETag"))
+			}
 
 			if tt.wantErr {
 				return
@@ -0,0 +1,71 @@
+package courier_reactivate_post
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"service/internal/generated/dto"
+	"service/internal/pkg/etag"
+	"service/internal/service/courier"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["id"]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	courierEntity, err := h.service.ReactivateCourier(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, courier.ErrInvalidCourierID):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, courier.ErrCourierNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, courier.ErrCourierNotDeactivated),
+			errors.Is(err, courier.ErrConflict):
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := dto.Courier{
+		ID:            courierEntity.ID,
+		Name:          courierEntity.Name,
+		Phone:         courierEntity.Phone,
+		Status:        courierEntity.Status.String(),
+		TransportType: courierEntity.TransportType.String(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag.Format(courierEntity.Version))
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
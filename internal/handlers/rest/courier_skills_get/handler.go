@@ -0,0 +1,65 @@
+package courier_skills_get
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"service/internal/generated/dto"
+	"service/internal/service/courier"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["id"]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	skills, err := h.service.GetCourierSkills(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, courier.ErrInvalidCourierID):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, courier.ErrCourierNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := dto.CourierSkills{
+		Skills: make([]string, 0, len(skills)),
+	}
+	for _, skill := range skills {
+		response.Skills = append(response.Skills, skill.String())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
@@ -0,0 +1,138 @@
+package courier_skills_get_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/courier_skills_get"
+	"service/internal/service/courier"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestCourierSkillsGetHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		courierID      string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name:      "Навыки курьера",
+			courierID: "1",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetCourierSkills(gomock.Any(), int64(1)).
+					Return([]entities.CourierSkill{entities.SkillAgeVerified, entities.SkillThermalBag}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"skills": []string{"age_verified", "thermal_bag"},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "У курьера нет навыков",
+			courierID: "1",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetCourierSkills(gomock.Any(), int64(1)).
+					Return([]entities.CourierSkill{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"skills": []string{},
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Невалидный ID курьера (не число)",
+			courierID:      "abc",
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:      "Курьер не найден",
+			courierID: "999",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetCourierSkills(gomock.Any(), int64(999)).
+					Return(nil, courier.ErrCourierNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:      "Ошибка сервиса при получении навыков",
+			courierID: "1",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetCourierSkills(gomock.Any(), int64(1)).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := courier_skills_get.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/courier/"+tt.courierID+"/skills", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.courierID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package courier_skills_put
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"service/internal/entities"
+	"service/internal/generated/dto"
+	"service/internal/service/courier"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["id"]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var skillsDTO dto.CourierSkills
+	err = json.NewDecoder(r.Body).Decode(&skillsDTO)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	skills := make([]entities.CourierSkill, 0, len(skillsDTO.Skills))
+	for _, skill := range skillsDTO.Skills {
+		skills = append(skills, entities.CourierSkill(skill))
+	}
+
+	updated, err := h.service.SetCourierSkills(r.Context(), id, skills)
+	if err != nil {
+		switch {
+		case errors.Is(err, courier.ErrInvalidCourierID),
+			errors.Is(err, courier.ErrInvalidSkill):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, courier.ErrCourierNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := dto.CourierSkills{
+		Skills: make([]string, 0, len(updated)),
+	}
+	for _, skill := range updated {
+		response.Skills = append(response.Skills, skill.String())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
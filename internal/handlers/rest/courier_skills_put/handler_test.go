@@ -0,0 +1,167 @@
+package courier_skills_put_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/courier_skills_put"
+	"service/internal/service/courier"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestCourierSkillsPutHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		courierID      string
+		requestBody    string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name:        "Успешная замена навыков",
+			courierID:   "1",
+			requestBody: `{"skills": ["thermal_bag", "age_verified", "thermal_bag"]}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					SetCourierSkills(gomock.Any(), int64(1), []entities.CourierSkill{
+						entities.SkillThermalBag, entities.SkillAgeVerified, entities.SkillThermalBag,
+					}).
+					Return([]entities.CourierSkill{entities.SkillAgeVerified, entities.SkillThermalBag}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"skills": []string{"age_verified", "thermal_bag"},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "Пустой список снимает все навыки",
+			courierID:   "1",
+			requestBody: `{"skills": []}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					SetCourierSkills(gomock.Any(), int64(1), []entities.CourierSkill{}).
+					Return([]entities.CourierSkill{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"skills": []string{},
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Невалидный ID курьера (не число)",
+			courierID:      "abc",
+			requestBody:    `{"skills": []}`,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:           "Невалидный JSON",
+			courierID:      "1",
+			requestBody:    `{"skills": "thermal_bag"`,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Неизвестный навык",
+			courierID:   "1",
+			requestBody: `{"skills": ["jetpack"]}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					SetCourierSkills(gomock.Any(), int64(1), []entities.CourierSkill{"jetpack"}).
+					Return(nil, courier.ErrInvalidSkill)
+			},
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Курьер не найден",
+			courierID:   "999",
+			requestBody: `{"skills": ["thermal_bag"]}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					SetCourierSkills(gomock.Any(), int64(999), []entities.CourierSkill{entities.SkillThermalBag}).
+					Return(nil, courier.ErrCourierNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:        "Ошибка сервиса при замене навыков",
+			courierID:   "1",
+			requestBody: `{"skills": ["thermal_bag"]}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					SetCourierSkills(gomock.Any(), int64(1), []entities.CourierSkill{entities.SkillThermalBag}).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := courier_skills_put.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodPut, "/courier/"+tt.courierID+"/skills", bytes.NewReader([]byte(tt.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"id": tt.courierID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package courier_tariffs_get
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"service/internal/generated/dto"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tariffs, err := h.service.GetTariffs(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	response := dto.CourierTariffs{
+		Tariffs: make([]dto.CourierTariff, len(tariffs)),
+	}
+	for i, tariff := range tariffs {
+		response.Tariffs[i] = dto.CourierTariff{
+			TransportType: dto.CourierTariffTransportType(tariff.TransportType),
+			ZoneID:        tariff.ZoneID,
+			BaseFee:       tariff.BaseFee,
+			PerKmRate:     tariff.PerKmRate,
+			PeakBonus:     tariff.PeakBonus,
+			LatePenalty:   tariff.LatePenalty,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
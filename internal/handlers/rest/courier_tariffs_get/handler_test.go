@@ -0,0 +1,121 @@
+package courier_tariffs_get_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/courier_tariffs_get"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestCourierTariffsGetHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name: "Тарифы транспорта и зоны",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetTariffs(gomock.Any()).
+					Return([]entities.CourierTariff{
+						{TransportType: entities.Car, BaseFee: 15000, PerKmRate: 1200, PeakBonus: 5000, LatePenalty: 5000},
+						{TransportType: entities.Car, ZoneID: pointer.To(int64(2)), BaseFee: 18000, PerKmRate: 1500, PeakBonus: 6000, LatePenalty: 5000},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"tariffs": []map[string]interface{}{
+					{"transport_type": "car", "base_fee": 15000, "per_km_rate": 1200, "peak_bonus": 5000, "late_penalty": 5000},
+					{"transport_type": "car", "zone_ID": 2, "base_fee": 18000, "per_km_rate": 1500, "peak_bonus": 6000, "late_penalty": 5000},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Тарифов нет",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetTariffs(gomock.Any()).
+					Return([]entities.CourierTariff{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"tariffs": []interface{}{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ошибка сервиса при получении тарифов",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetTariffs(gomock.Any()).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := courier_tariffs_get.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/courier-tariffs", http.NoBody)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package courier_tariffs_put
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"service/internal/entities"
+	"service/internal/generated/dto"
+	"service/internal/service/earnings"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var tariffsDTO dto.CourierTariffs
+	err := json.NewDecoder(r.Body).Decode(&tariffsDTO)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	tariffs := make([]entities.CourierTariff, len(tariffsDTO.Tariffs))
+	for i, tariff := range tariffsDTO.Tariffs {
+		tariffs[i] = entities.CourierTariff{
+			TransportType: entities.CourierTransportType(tariff.TransportType),
+			ZoneID:        tariff.ZoneID,
+			BaseFee:       tariff.BaseFee,
+			PerKmRate:     tariff.PerKmRate,
+			PeakBonus:     tariff.PeakBonus,
+			LatePenalty:   tariff.LatePenalty,
+		}
+	}
+
+	res, err := h.service.UpdateTariffs(r.Context(), tariffs)
+	if err != nil {
+		switch {
+		case errors.Is(err, earnings.ErrInvalidTransport),
+			errors.Is(err, earnings.ErrInvalidZoneID),
+			errors.Is(err, earnings.ErrDuplicateTariff),
+			errors.Is(err, earnings.ErrInvalidTariffFee),
+			errors.Is(err, earnings.ErrMissingDefaultTariff):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, earnings.ErrZoneNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := dto.CourierTariffs{
+		Tariffs: make([]dto.CourierTariff, len(res)),
+	}
+	for i, tariff := range res {
+		response.Tariffs[i] = dto.CourierTariff{
+			TransportType: dto.CourierTariffTransportType(tariff.TransportType),
+			ZoneID:        tariff.ZoneID,
+			BaseFee:       tariff.BaseFee,
+			PerKmRate:     tariff.PerKmRate,
+			PeakBonus:     tariff.PeakBonus,
+			LatePenalty:   tariff.LatePenalty,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
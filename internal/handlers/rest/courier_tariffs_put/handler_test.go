@@ -0,0 +1,162 @@
+package courier_tariffs_put_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/courier_tariffs_put"
+	"service/internal/service/earnings"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestCourierTariffsPutHandler(t *testing.T) {
+	t.Parallel()
+
+	tariffs := []entities.CourierTariff{
+		{TransportType: entities.Scooter, BaseFee: 12000, PerKmRate: 1500, PeakBonus: 5000, LatePenalty: 5000},
+		{TransportType: entities.Scooter, ZoneID: pointer.To(int64(2)), BaseFee: 14000, PerKmRate: 1500, PeakBonus: 5000, LatePenalty: 3000},
+	}
+
+	validBody := `{
+		"tariffs": [
+			{"transport_type": "scooter", "base_fee": 12000, "per_km_rate": 1500, "peak_bonus": 5000, "late_penalty": 5000},
+			{"transport_type": "scooter", "zone_ID": 2, "base_fee": 14000, "per_km_rate": 1500, "peak_bonus": 5000, "late_penalty": 3000}
+		]
+	}`
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name:        "Успешная замена тарифов",
+			requestBody: validBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateTariffs(gomock.Any(), tariffs).
+					Return(tariffs, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"tariffs": []map[string]interface{}{
+					{"transport_type": "scooter", "base_fee": 12000, "per_km_rate": 1500, "peak_bonus": 5000, "late_penalty": 5000},
+					{"transport_type": "scooter", "zone_ID": 2, "base_fee": 14000, "per_km_rate": 1500, "peak_bonus": 5000, "late_penalty": 3000},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Невалидный JSON",
+			requestBody:    `{"tariffs": [`,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Нет тарифа без зоны для транспорта",
+			requestBody: validBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateTariffs(gomock.Any(), tariffs).
+					Return(nil, earnings.ErrMissingDefaultTariff)
+			},
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Отрицательная ставка",
+			requestBody: validBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateTariffs(gomock.Any(), tariffs).
+					Return(nil, earnings.ErrInvalidTariffFee)
+			},
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Зона тарифа не найдена",
+			requestBody: validBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateTariffs(gomock.Any(), tariffs).
+					Return(nil, earnings.ErrZoneNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:        "Ошибка сервиса при замене тарифов",
+			requestBody: validBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateTariffs(gomock.Any(), tariffs).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := courier_tariffs_put.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/courier-tariffs", bytes.NewReader([]byte(tt.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package couriers_export_get
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"service/internal/entities"
+	"service/internal/pkg/courier_format"
+	"service/pkg/logger"
+)
+
+const (
+	// flushEvery сколько строк копится в буфере перед отправкой клиенту
+	flushEvery = 500
+	// chunkWriteTimeout сколько ждать отправки очередной порции строк. WriteTimeout сервера
+	// считается от начала запроса и оборвал бы выгрузку большого списка, поэтому дедлайн продлевается
+	chunkWriteTimeout = 15 * time.Second
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	format, ok := courier_format.FromAccept(r.Header.Get("Accept"))
+	if !ok {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	filter := entities.CourierListFilter{}
+
+	includeDeactivated := r.URL.Query().Get("include_deactivated")
+	if includeDeactivated != "" {
+		include, err := strconv.ParseBool(includeDeactivated)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		filter.IncludeDeactivated = include
+	}
+
+	// заголовки отправляются с первой строкой: пока ничего не записано, ошибку можно вернуть статусом
+	var encoder courier_format.Encoder
+	start := func() error {
+		err := extendWriteDeadline(w)
+		if err != nil {
+			return err
+		}
+
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Content-Disposition", `attachment; filename="couriers.`+string(format)+`"`)
+		w.WriteHeader(http.StatusOK)
+
+		encoder, err = courier_format.NewEncoder(format, w)
+		return err
+	}
+
+	written := 0
+	err := h.service.ExportCouriers(r.Context(), filter, func(courier entities.Courier) error {
+		if encoder == nil {
+			err := start()
+			if err != nil {
+				return err
+			}
+		}
+
+		err := encoder.Encode(courier)
+		if err != nil {
+			return err
+		}
+
+		written++
+		if written%flushEvery == 0 {
+			return h.flush(w, encoder)
+		}
+		return nil
+	})
+	if err != nil {
+		if encoder == nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// статус уже отправлен, клиент получит обрезанный файл
+		h.log.With(
+			logger.NewField("error", err),
+			logger.NewField("written", written),
+		).Error("export couriers interrupted")
+		return
+	}
+
+	if encoder == nil {
+		err = start()
+		if err != nil {
+			h.log.With(
+				logger.NewField("error", err),
+			).Error("write export header")
+			return
+		}
+	}
+
+	err = h.flush(w, encoder)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("flush export")
+	}
+}
+
+func (h *Handler) flush(w http.ResponseWriter, encoder courier_format.Encoder) error {
+	err := encoder.Flush()
+	if err != nil {
+		return err
+	}
+
+	// writer без поддержки Flush не ошибка: ответ уйдет целиком после обработчика
+	err = http.NewResponseController(w).Flush()
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	// следующая порция получает свое время на отправку
+	return extendWriteDeadline(w)
+}
+
+func extendWriteDeadline(w http.ResponseWriter) error {
+	err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(chunkWriteTimeout))
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
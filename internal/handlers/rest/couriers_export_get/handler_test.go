@@ -0,0 +1,222 @@
+package couriers_export_get_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/couriers_export_get"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestCouriersExportGetHandler(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	couriers := []entities.Courier{
+		{
+			ID:            1,
+			Name:          "Snake Plissken",
+			Phone:         "+79999991111",
+			Status:        entities.CourierAvailable,
+			TransportType: entities.Car,
+			CreatedAt:     fixedTime,
+			UpdatedAt:     fixedTime,
+		},
+	}
+	stream := func(couriers []entities.Courier, streamErr error) func(
+		ctx context.Context, filter entities.CourierListFilter, fn func(entities.Courier) error,
+	) error {
+		return func(ctx context.Context, filter entities.CourierListFilter, fn func(entities.Courier) error) error {
+			for _, courier := range couriers {
+				err := fn(courier)
+				if err != nil {
+					return err
+				}
+			}
+			return streamErr
+		}
+	}
+	csvHeader := "id,name,phone,status,transport_type,created_at,updated_at,deactivated_at,deactivation_reason\n"
+
+	tests := []struct {
+		name                string
+		query               string
+		accept              string
+		mockSetup           func(m *mock)
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name: "CSV по умолчанию",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					ExportCouriers(gomock.Any(), entities.CourierListFilter{}, gomock.Any()).
+					DoAndReturn(stream(couriers, nil))
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv",
+			expectedBody:        csvHeader + "1,Snake Plissken,+79999991111,available,car,2026-01-01T12:00:00Z,2026-01-01T12:00:00Z,,\n",
+		},
+		{
+			name:   "JSONL по заголовку Accept с отключенными курьерами",
+			query:  "?include_deactivated=true",
+			accept: "application/x-ndjson",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					ExportCouriers(gomock.Any(), entities.CourierListFilter{IncludeDeactivated: true}, gomock.Any()).
+					DoAndReturn(stream(couriers, nil))
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+			expectedBody: `{"id":1,"name":"Snake Plissken","phone":"+79999991111","status":"available","transport_type":"car",` +
+				`"created_at":"2026-01-01T12:00:00Z","updated_at":"2026-01-01T12:00:00Z"}` + "\n",
+		},
+		{
+			name: "Пустая выгрузка содержит только заголовок CSV",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					ExportCouriers(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(stream(nil, nil))
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv",
+			expectedBody:        csvHeader,
+		},
+		{
+			name: "Ошибка посреди выгрузки обрезает файл",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					ExportCouriers(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(stream(couriers, errors.New("connection reset")))
+				m.MockhandlerLogger.EXPECT().Error("export couriers interrupted")
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv",
+		},
+		{
+			name: "Ошибка до первой строки",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					ExportCouriers(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "Неподдерживаемый формат",
+			accept:         "application/json",
+			expectedStatus: http.StatusNotAcceptable,
+		},
+		{
+			name:           "Невалидный include_deactivated",
+			query:          "?include_deactivated=maybe",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := couriers_export_get.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/couriers/export"+tt.query, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+			if tt.expectedContentType != "" {
+				assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+			}
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
+
+func TestCouriersExportGetHandler_LongerThanWriteTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	m := newMock(ctrl)
+
+	m.MockhandlerLogger.EXPECT().
+		With(gomock.Any()).
+		Return(m.MockhandlerLogger).
+		AnyTimes()
+
+	// три порции по 500 строк, между порциями выгрузка ждет дольше WriteTimeout сервера
+	const total = 1500
+	m.MockService.EXPECT().
+		ExportCouriers(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, filter entities.CourierListFilter, fn func(entities.Courier) error) error {
+			for i := 1; i <= total; i++ {
+				if i%500 == 1 {
+					time.Sleep(150 * time.Millisecond)
+				}
+				err := fn(entities.Courier{ID: int64(i), Status: entities.CourierAvailable, TransportType: entities.Car})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	server := httptest.NewUnstartedServer(couriers_export_get.New(m.MockhandlerLogger, m.MockService))
+	server.Config.WriteTimeout = 100 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/couriers/export", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/x-ndjson")
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, total, strings.Count(string(body), "\n"))
+}
@@ -16,5 +16,5 @@ type handlerLogger interface {
 }
 
 type Service interface {
-	GetCouriers(ctx context.Context) ([]entities.Courier, error)
+	GetCouriers(ctx context.Context, filter entities.CourierListFilter) ([]entities.Courier, error)
 }
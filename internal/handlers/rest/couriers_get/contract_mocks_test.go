@@ -136,16 +136,16 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // GetCouriers mocks base method.
-func (m *MockService) GetCouriers(ctx context.Context) ([]entities.Courier, error) {
+func (m *MockService) GetCouriers(ctx context.Context, filter entities.CourierListFilter) ([]entities.Courier, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCouriers", ctx)
+	ret := m.ctrl.Call(m, "GetCouriers", ctx, filter)
 	ret0, _ := ret[0].([]entities.Courier)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCouriers indicates an expected call of GetCouriers.
-func (mr *MockServiceMockRecorder) GetCouriers(ctx any) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetCouriers(ctx, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCouriers", reflect.TypeOf((*MockService)(nil).GetCouriers), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCouriers", reflect.TypeOf((*MockService)(nil).GetCouriers), ctx, filter)
 }
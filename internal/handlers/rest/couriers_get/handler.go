@@ -3,7 +3,9 @@ package couriers_get
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
+	"service/internal/entities"
 	"service/internal/generated/dto"
 	"service/pkg/logger"
 )
@@ -23,7 +25,19 @@ func New(log handlerLogger, service Service) *Handler {
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	courierEntities, err := h.service.GetCouriers(r.Context())
+	filter := entities.CourierListFilter{}
+
+	includeDeactivated := r.URL.Query().Get("include_deactivated")
+	if includeDeactivated != "" {
+		include, err := strconv.ParseBool(includeDeactivated)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		filter.IncludeDeactivated = include
+	}
+
+	courierEntities, err := h.service.GetCouriers(r.Context(), filter)
 	if err != nil {
 		// switch {
 		// default:
@@ -39,6 +53,8 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		courierDTOs[i].Phone = courier.Phone
 		courierDTOs[i].Status = courier.Status.String()
 		courierDTOs[i].TransportType = courier.TransportType.String()
+		courierDTOs[i].DeactivatedAt = courier.DeactivatedAt
+		courierDTOs[i].DeactivationReason = courier.DeactivationReason
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/AlekSi/pointer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -34,6 +35,7 @@ func TestCouriersGetHandler(t *testing.T) {
 
 	tests := []struct {
 		name           string
+		url            string
 		mockSetup      func(m *mock)
 		expectedStatus int
 		expectedBody   []map[string]interface{}
@@ -43,7 +45,7 @@ func TestCouriersGetHandler(t *testing.T) {
 			name: "Успешное получение списка курьеров",
 			mockSetup: func(m *mock) {
 				m.MockService.EXPECT().
-					GetCouriers(gomock.Any()).
+					GetCouriers(gomock.Any(), entities.CourierListFilter{}).
 					Return([]entities.Courier{
 						{
 							ID:            1,
@@ -104,7 +106,7 @@ func TestCouriersGetHandler(t *testing.T) {
 			name: "Успешное получение одного курьера",
 			mockSetup: func(m *mock) {
 				m.MockService.EXPECT().
-					GetCouriers(gomock.Any()).
+					GetCouriers(gomock.Any(), entities.CourierListFilter{}).
 					Return([]entities.Courier{
 						{
 							ID:            1,
@@ -133,18 +135,59 @@ func TestCouriersGetHandler(t *testing.T) {
 			name: "Успешное получение пустого списка курьеров",
 			mockSetup: func(m *mock) {
 				m.MockService.EXPECT().
-					GetCouriers(gomock.Any()).
+					GetCouriers(gomock.Any(), entities.CourierListFilter{}).
 					Return([]entities.Courier{}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody:   []map[string]interface{}{},
 			wantErr:        false,
 		},
+		{
+			name: "Отключенные курьеры попадают в список по запросу",
+			url:  "/couriers?include_deactivated=true",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetCouriers(gomock.Any(), entities.CourierListFilter{IncludeDeactivated: true}).
+					Return([]entities.Courier{
+						{
+							ID:                 1,
+							Name:               "Snake Plissken",
+							Phone:              "79999991111",
+							Status:             entities.CourierAvailable,
+							TransportType:      entities.Car,
+							CreatedAt:          fixedTime,
+							UpdatedAt:          fixedTime,
+							DeactivatedAt:      &fixedTime,
+							DeactivationReason: pointer.To("уволился"),
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: []map[string]interface{}{
+				{
+					"ID":                  float64(1),
+					"name":                "Snake Plissken",
+					"phone":               "79999991111",
+					"status":              "available",
+					"transport_type":      "car",
+					"deactivated_at":      "2026-01-01T12:00:00Z",
+					"deactivation_reason": "уволился",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Некорректный include_deactivated",
+			url:            "/couriers?include_deactivated=maybe",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+			wantErr:        true,
+		},
 		{
 			name: "Ошибка сервиса при получении курьеров",
 			mockSetup: func(m *mock) {
 				m.MockService.EXPECT().
-					GetCouriers(gomock.Any()).
+					GetCouriers(gomock.Any(), entities.CourierListFilter{}).
 					Return(nil, errors.New("database connection error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -171,7 +214,11 @@ func TestCouriersGetHandler(t *testing.T) {
 			}
 
 			handler := couriers_get.New(m.MockhandlerLogger, m.MockService)
-			req := httptest.NewRequest(http.MethodGet, "/couriers", http.NoBody)
+			url := tt.url
+			if url == "" {
+				url = "/couriers"
+			}
+			req := httptest.NewRequest(http.MethodGet, url, http.NoBody)
 			w := httptest.NewRecorder()
 
 			handler.ServeHTTP(w, req)
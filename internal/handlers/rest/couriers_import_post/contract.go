@@ -0,0 +1,24 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=couriers_import_post_test
+package couriers_import_post
+
+import (
+	"context"
+
+	"service/internal/entities"
+	"service/pkg/logger"
+)
+
+type handlerLogger interface {
+	Info(msg string, fields ...logger.Field)
+	Warn(msg string, fields ...logger.Field)
+	Error(msg string, fields ...logger.Field)
+	With(fields ...logger.Field) logger.Logger
+}
+
+type Service interface {
+	ImportCouriers(
+		ctx context.Context,
+		rows []entities.CourierImportRow,
+		opts entities.CourierImportOptions,
+	) (*entities.CourierImportReport, error)
+}
@@ -0,0 +1,121 @@
+package couriers_import_post
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"service/internal/entities"
+	"service/internal/generated/dto"
+	"service/internal/pkg/courier_format"
+	"service/internal/service/courier"
+	"service/pkg/logger"
+)
+
+const (
+	// maxBodyBytes около 100 тысяч строк CSV, больше за один запрос не принимаем
+	maxBodyBytes = 10 << 20
+	// maxRows ограничивает размер транзакции в режиме all_or_nothing
+	maxRows = 10000
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	format, ok := courier_format.FromContentType(r.Header.Get("Content-Type"))
+	if !ok {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	opts := entities.CourierImportOptions{
+		Mode: entities.CourierImportMode(r.URL.Query().Get("mode")),
+	}
+
+	dryRun := r.URL.Query().Get("dry_run")
+	if dryRun != "" {
+		value, err := strconv.ParseBool(dryRun)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		opts.DryRun = value
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxBodyBytes)
+	rows, err := courier_format.Decode(format, body, maxRows)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr),
+			errors.Is(err, courier_format.ErrTooManyRows):
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		case errors.Is(err, courier_format.ErrMalformed),
+			errors.Is(err, courier_format.ErrNoHeader):
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	report, err := h.service.ImportCouriers(r.Context(), rows, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, courier.ErrInvalidImportMode),
+			errors.Is(err, courier.ErrEmptyImport):
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := dto.CourierImportReport{
+		Mode:      report.Mode.String(),
+		DryRun:    report.DryRun,
+		Committed: report.Committed,
+		Total:     report.Total,
+		Created:   report.Created,
+		Failed:    report.Failed,
+		Rows:      make([]dto.CourierImportRow, len(report.Rows)),
+	}
+	for i, row := range report.Rows {
+		response.Rows[i].Line = row.Line
+		response.Rows[i].Status = row.Status.String()
+		if row.CourierID != 0 {
+			response.Rows[i].CourierID = &row.CourierID
+		}
+		if row.Error != "" {
+			response.Rows[i].Error = &row.Error
+		}
+	}
+
+	// all_or_nothing импорт с ошибочными строками ничего не создал, отчет объясняет почему
+	status := http.StatusOK
+	if !report.DryRun && report.Mode == entities.CourierImportAllOrNothing && report.Failed > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
@@ -0,0 +1,244 @@
+package couriers_import_post_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/couriers_import_post"
+	"service/internal/service/courier"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestCouriersImportPostHandler(t *testing.T) {
+	t.Parallel()
+
+	csvBody := "name,phone,transport_type\nJohn Wick,+79161234567,car\n"
+	csvRows := []entities.CourierImportRow{
+		{Line: 2, Name: "John Wick", Phone: "+79161234567", TransportType: "car"},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		contentType    string
+		requestBody    string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name:        "Успешный импорт CSV",
+			contentType: "text/csv",
+			requestBody: csvBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					ImportCouriers(gomock.Any(), csvRows, entities.CourierImportOptions{}).
+					Return(&entities.CourierImportReport{
+						Mode:      entities.CourierImportAllOrNothing,
+						Committed: true,
+						Total:     1,
+						Created:   1,
+						Rows: []entities.CourierImportRowResult{
+							{Line: 2, Status: entities.CourierImportRowCreated, CourierID: 1},
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"mode":      "all_or_nothing",
+				"dry_run":   false,
+				"committed": true,
+				"total":     float64(1),
+				"created":   float64(1),
+				"failed":    float64(0),
+				"rows": []interface{}{
+					map[string]interface{}{"line": float64(2), "status": "created", "courier_ID": float64(1)},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "Dry-run JSONL в режиме best_effort",
+			query:       "?mode=best_effort&dry_run=true",
+			contentType: "application/x-ndjson",
+			requestBody: `{"name":"John Wick","phone":"+79161234567"}` + "\n",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					ImportCouriers(gomock.Any(), []entities.CourierImportRow{
+						{Line: 1, Name: "John Wick", Phone: "+79161234567"},
+					}, entities.CourierImportOptions{Mode: entities.CourierImportBestEffort, DryRun: true}).
+					Return(&entities.CourierImportReport{
+						Mode:   entities.CourierImportBestEffort,
+						DryRun: true,
+						Total:  1,
+						Rows: []entities.CourierImportRowResult{
+							{Line: 1, Status: entities.CourierImportRowValid},
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"mode":      "best_effort",
+				"dry_run":   true,
+				"committed": false,
+				"total":     float64(1),
+				"created":   float64(0),
+				"failed":    float64(0),
+				"rows": []interface{}{
+					map[string]interface{}{"line": float64(1), "status": "valid"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "all_or_nothing импорт отклонен из-за ошибочных строк",
+			contentType: "text/csv",
+			requestBody: csvBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					ImportCouriers(gomock.Any(), csvRows, entities.CourierImportOptions{}).
+					Return(&entities.CourierImportReport{
+						Mode:   entities.CourierImportAllOrNothing,
+						Total:  1,
+						Failed: 1,
+						Rows: []entities.CourierImportRowResult{
+							{Line: 2, Status: entities.CourierImportRowFailed, Error: "invalid phone"},
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody: map[string]interface{}{
+				"mode":      "all_or_nothing",
+				"dry_run":   false,
+				"committed": false,
+				"total":     float64(1),
+				"created":   float64(0),
+				"failed":    float64(1),
+				"rows": []interface{}{
+					map[string]interface{}{"line": float64(2), "status": "failed", "error": "invalid phone"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Неподдерживаемый Content-Type",
+			contentType:    "application/json",
+			requestBody:    `[]`,
+			expectedStatus: http.StatusUnsupportedMediaType,
+			wantErr:        true,
+		},
+		{
+			name:           "Невалидный dry_run",
+			query:          "?dry_run=maybe",
+			contentType:    "text/csv",
+			requestBody:    csvBody,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:           "В CSV нет обязательной колонки",
+			contentType:    "text/csv",
+			requestBody:    "name\nJohn Wick\n",
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:           "Слишком много строк",
+			contentType:    "text/csv",
+			requestBody:    "name,phone\n" + strings.Repeat("John Wick,+79161234567\n", 10001),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			wantErr:        true,
+		},
+		{
+			name:           "Слишком большой файл",
+			contentType:    "application/x-ndjson",
+			requestBody:    strings.Repeat(strings.Repeat(" ", 1023)+"\n", 11<<10),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			wantErr:        true,
+		},
+		{
+			name:        "Неизвестный режим импорта",
+			query:       "?mode=sometimes",
+			contentType: "text/csv",
+			requestBody: csvBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					ImportCouriers(gomock.Any(), csvRows, entities.CourierImportOptions{Mode: "sometimes"}).
+					Return(nil, courier.ErrInvalidImportMode)
+			},
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Ошибка сервиса",
+			contentType: "text/csv",
+			requestBody: csvBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					ImportCouriers(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := couriers_import_post.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/couriers/import"+tt.query, strings.NewReader(tt.requestBody))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package delivery_assign_post
 
 import (
 	"context"
+	"time"
 
 	"service/internal/entities"
 	"service/pkg/logger"
@@ -16,5 +17,9 @@ type handlerLogger interface {
 }
 
 type Service interface {
-	DeliveryAssign(ctx context.Context, orderId string) (*entities.DeliveryAssignment, error)
+	DeliveryAssign(ctx context.Context, params entities.DeliveryAssignParams) (*entities.DeliveryAssignment, error)
+}
+
+type ETAService interface {
+	Predict(ctx context.Context, transportType entities.CourierTransportType, assignedAt time.Time) (*entities.ETAPrediction, error)
 }
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 	entities "service/internal/entities"
 	logger "service/pkg/logger"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -136,16 +137,55 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // DeliveryAssign mocks base method.
-func (m *MockService) DeliveryAssign(ctx context.Context, orderId string) (*entities.DeliveryAssignment, error) {
+func (m *MockService) DeliveryAssign(ctx context.Context, params entities.DeliveryAssignParams) (*entities.DeliveryAssignment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeliveryAssign", ctx, orderId)
+	ret := m.ctrl.Call(m, "DeliveryAssign", ctx, params)
 	ret0, _ := ret[0].(*entities.DeliveryAssignment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeliveryAssign indicates an expected call of DeliveryAssign.
-func (mr *MockServiceMockRecorder) DeliveryAssign(ctx, orderId any) *gomock.Call {
+func (mr *MockServiceMockRecorder) DeliveryAssign(ctx, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryAssign", reflect.TypeOf((*MockService)(nil).DeliveryAssign), ctx, orderId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryAssign", reflect.TypeOf((*MockService)(nil).DeliveryAssign), ctx, params)
+}
+
+// MockETAService is a mock of ETAService interface.
+type MockETAService struct {
+	ctrl     *gomock.Controller
+	recorder *MockETAServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockETAServiceMockRecorder is the mock recorder for MockETAService.
+type MockETAServiceMockRecorder struct {
+	mock *MockETAService
+}
+
+// NewMockETAService creates a new mock instance.
+func NewMockETAService(ctrl *gomock.Controller) *MockETAService {
+	mock := &MockETAService{ctrl: ctrl}
+	mock.recorder = &MockETAServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockETAService) EXPECT() *MockETAServiceMockRecorder {
+	return m.recorder
+}
+
+// Predict mocks base method.
+func (m *MockETAService) Predict(ctx context.Context, transportType entities.CourierTransportType, assignedAt time.Time) (*entities.ETAPrediction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Predict", ctx, transportType, assignedAt)
+	ret0, _ := ret[0].(*entities.ETAPrediction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Predict indicates an expected call of Predict.
+func (mr *MockETAServiceMockRecorder) Predict(ctx, transportType, assignedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Predict", reflect.TypeOf((*MockETAService)(nil).Predict), ctx, transportType, assignedAt)
 }
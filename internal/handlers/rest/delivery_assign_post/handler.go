@@ -5,22 +5,26 @@ import (
 	"errors"
 	"net/http"
 
+	"service/internal/entities"
 	"service/internal/generated/dto"
 	"service/internal/service/delivery"
+	"service/internal/service/delivery_eta"
 	"service/pkg/logger"
 )
 
 type Handler struct {
-	log     handlerLogger
-	service Service
+	log        handlerLogger
+	service    Service
+	etaService ETAService
 }
 
-func New(log handlerLogger, service Service) *Handler {
+func New(log handlerLogger, service Service, etaService ETAService) *Handler {
 	handlerLog := log.With()
 
 	return &Handler{
-		log:     handlerLog,
-		service: service,
+		log:        handlerLog,
+		service:    service,
+		etaService: etaService,
 	}
 }
 
@@ -32,16 +36,53 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	orderID := deliveryAssignDTO.OrderID
+	// точки маршрута передаются только парой
+	if (deliveryAssignDTO.Pickup == nil) != (deliveryAssignDTO.Dropoff == nil) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
 
-	deliveryEntity, err := h.service.DeliveryAssign(r.Context(), orderID)
+	params := entities.DeliveryAssignParams{
+		OrderID:           deliveryAssignDTO.OrderID,
+		Address:           addressFromDTO(deliveryAssignDTO.Address),
+		EstimatedDelivery: deliveryAssignDTO.EstimatedDelivery,
+		Requirements:      requirementsFromDTO(deliveryAssignDTO.Requirements),
+	}
+	if deliveryAssignDTO.RestaurantID != nil {
+		params.RestaurantID = *deliveryAssignDTO.RestaurantID
+	}
+	if deliveryAssignDTO.Priority != nil {
+		params.Priority = entities.OrderPriority(*deliveryAssignDTO.Priority)
+	}
+	if deliveryAssignDTO.Pickup != nil {
+		params.Route = &entities.Route{
+			Pickup: entities.Location{
+				Latitude:  deliveryAssignDTO.Pickup.Latitude,
+				Longitude: deliveryAssignDTO.Pickup.Longitude,
+			},
+			Dropoff: entities.Location{
+				Latitude:  deliveryAssignDTO.Dropoff.Latitude,
+				Longitude: deliveryAssignDTO.Dropoff.Longitude,
+			},
+		}
+	}
+
+	deliveryEntity, err := h.service.DeliveryAssign(r.Context(), params)
 	if err != nil {
 		switch {
+		// заказ не назначен сразу, но принят в очередь ожидания - проверяем до ErrNoAvailableCouriers
+		case errors.Is(err, delivery.ErrAssignmentPending),
+			errors.Is(err, delivery.ErrOfferPending):
+			h.writePending(w, params.OrderID, err)
 		case errors.Is(err, delivery.ErrInvalidOrderID),
+			errors.Is(err, delivery.ErrInvalidRoute),
+			errors.Is(err, delivery.ErrInvalidRequirements),
+			errors.Is(err, delivery.ErrInvalidPriority),
 			errors.Is(err, delivery.ErrMissingRequiredFields):
 			w.WriteHeader(http.StatusBadRequest)
 		case errors.Is(err, delivery.ErrNoAvailableCouriers),
-			errors.Is(err, delivery.ErrOrderAlreadyAssigned):
+			errors.Is(err, delivery.ErrOrderAlreadyAssigned),
+			errors.Is(err, delivery.ErrCourierNotAvailable):
 			w.WriteHeader(http.StatusConflict)
 		default:
 			w.WriteHeader(http.StatusInternalServerError)
@@ -56,6 +97,20 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		TransportType:    deliveryEntity.TransportType.String(),
 	}
 
+	// курьер уже назначен, поэтому без прогноза отвечаем только контрактным дедлайном
+	prediction, err := h.etaService.Predict(r.Context(), deliveryEntity.TransportType, deliveryEntity.AssignedAt)
+	switch {
+	case err == nil:
+		confidence := prediction.Confidence.String()
+		response.PredictedEta = &prediction.ETA
+		response.EtaConfidence = &confidence
+	case !errors.Is(err, delivery_eta.ErrETAModelNotFound):
+		h.log.With(
+			logger.NewField("order_id", deliveryEntity.OrderID),
+			logger.NewField("error", err),
+		).Warn("predict delivery eta")
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(response)
@@ -65,3 +120,92 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		).Error("encode JSON response")
 	}
 }
+
+// writePending отвечает 202 и, если курьеры не подошли под требования заказа, объясняет почему.
+// Если заказ предложен курьеру, в ответе предложение, которое курьер должен принять
+func (h *Handler) writePending(w http.ResponseWriter, orderID string, err error) {
+	response := dto.DeliveryAssignPendingResponse{
+		OrderID: orderID,
+	}
+
+	var noMatch *delivery.NoCourierMatchError
+	if errors.As(err, &noMatch) {
+		response.Reason = mismatchToDTO(noMatch.Mismatch)
+	}
+
+	var offerPending *delivery.OfferPendingError
+	if errors.As(err, &offerPending) {
+		response.Offer = &dto.DeliveryOffer{
+			ID:        offerPending.Offer.ID,
+			CourierID: offerPending.Offer.CourierID,
+			ExpiresAt: offerPending.Offer.ExpiresAt,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
+
+func mismatchToDTO(mismatch entities.CourierMismatch) *dto.CourierMismatch {
+	requirements := make([]dto.RequirementMatch, 0, len(mismatch.Requirements))
+	for _, requirement := range mismatch.Requirements {
+		requirements = append(requirements, dto.RequirementMatch{
+			Requirement:      requirement.Requirement,
+			Value:            requirement.Value,
+			MatchingCouriers: requirement.MatchingCouriers,
+		})
+	}
+
+	return &dto.CourierMismatch{
+		AvailableCouriers: mismatch.AvailableCouriers,
+		Requirements:      requirements,
+	}
+}
+
+func requirementsFromDTO(requirementsDTO *dto.OrderRequirements) entities.OrderRequirements {
+	requirements := entities.OrderRequirements{}
+	if requirementsDTO == nil {
+		return requirements
+	}
+
+	if requirementsDTO.Skills != nil {
+		for _, skill := range *requirementsDTO.Skills {
+			requirements.Skills = append(requirements.Skills, entities.CourierSkill(skill))
+		}
+	}
+	if requirementsDTO.TransportTypes != nil {
+		for _, transportType := range *requirementsDTO.TransportTypes {
+			requirements.TransportTypes = append(requirements.TransportTypes, entities.CourierTransportType(transportType))
+		}
+	}
+
+	return requirements
+}
+
+func addressFromDTO(addressDTO *dto.Address) *entities.Address {
+	if addressDTO == nil {
+		return nil
+	}
+
+	address := &entities.Address{
+		Street: addressDTO.Street,
+		House:  addressDTO.House,
+	}
+	if addressDTO.Apartment != nil {
+		address.Apartment = *addressDTO.Apartment
+	}
+	if addressDTO.Floor != nil {
+		address.Floor = *addressDTO.Floor
+	}
+	if addressDTO.Comment != nil {
+		address.Comment = *addressDTO.Comment
+	}
+
+	return address
+}
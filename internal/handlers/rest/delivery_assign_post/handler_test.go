@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -15,16 +16,19 @@ import (
 	"service/internal/entities"
 	"service/internal/handlers/rest/delivery_assign_post"
 	"service/internal/service/delivery"
+	"service/internal/service/delivery_eta"
 )
 
 type mock struct {
 	*MockService
+	*MockETAService
 	*MockhandlerLogger
 }
 
 func newMock(ctrl *gomock.Controller) *mock {
 	return &mock{
 		MockService:       NewMockService(ctrl),
+		MockETAService:    NewMockETAService(ctrl),
 		MockhandlerLogger: NewMockhandlerLogger(ctrl),
 	}
 }
@@ -51,7 +55,7 @@ func TestDeliveryAssignPostHandler(t *testing.T) {
 			}`,
 			mockSetup: func(m *mock) {
 				m.MockService.EXPECT().
-					DeliveryAssign(gomock.Any(), "order-2026-001").
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{OrderID: "order-2026-001"}).
 					Return(&entities.DeliveryAssignment{
 						CourierID:     1,
 						OrderID:       "order-2026-001",
@@ -76,7 +80,7 @@ func TestDeliveryAssignPostHandler(t *testing.T) {
 			}`,
 			mockSetup: func(m *mock) {
 				m.MockService.EXPECT().
-					DeliveryAssign(gomock.Any(), "order-2026-002").
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{OrderID: "order-2026-002"}).
 					Return(&entities.DeliveryAssignment{
 						CourierID:     2,
 						OrderID:       "order-2026-002",
@@ -94,6 +98,100 @@ func TestDeliveryAssignPostHandler(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Назначение с маршрутом доставки",
+			requestBody: `{
+				"order_ID": "order-2026-003",
+				"pickup": {"latitude": 55.7558, "longitude": 37.6173},
+				"dropoff": {"latitude": 55.7602, "longitude": 37.6186}
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{
+						OrderID: "order-2026-003",
+						Route: &entities.Route{
+							Pickup:  entities.Location{Latitude: 55.7558, Longitude: 37.6173},
+							Dropoff: entities.Location{Latitude: 55.7602, Longitude: 37.6186},
+						},
+					}).
+					Return(&entities.DeliveryAssignment{
+						CourierID:     1,
+						OrderID:       "order-2026-003",
+						AssignedAt:    assignedAt,
+						Deadline:      deadline,
+						TransportType: entities.Car,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"courier_ID":        float64(1),
+				"order_ID":          "order-2026-003",
+				"transport_type":    "car",
+				"delivery_deadline": deadlineStr,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Назначение с адресом, рестораном и обещанным временем доставки",
+			requestBody: `{
+				"order_ID": "order-2026-004",
+				"restaurant_ID": "restaurant-7",
+				"address": {"street": "Тверская", "house": "1", "apartment": "15"},
+				"estimated_delivery": "2026-01-01T12:40:00Z"
+			}`,
+			mockSetup: func(m *mock) {
+				estimatedDelivery := assignedAt.Add(40 * time.Minute)
+				m.MockService.EXPECT().
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{
+						OrderID:           "order-2026-004",
+						RestaurantID:      "restaurant-7",
+						Address:           &entities.Address{Street: "Тверская", House: "1", Apartment: "15"},
+						EstimatedDelivery: &estimatedDelivery,
+					}).
+					Return(&entities.DeliveryAssignment{
+						CourierID:     1,
+						OrderID:       "order-2026-004",
+						AssignedAt:    assignedAt,
+						Deadline:      estimatedDelivery,
+						TransportType: entities.Car,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"courier_ID":        float64(1),
+				"order_ID":          "order-2026-004",
+				"transport_type":    "car",
+				"delivery_deadline": "2026-01-01T12:40:00Z",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Передана только точка забора заказа",
+			requestBody: `{
+				"order_ID": "order-2026-003",
+				"pickup": {"latitude": 55.7558, "longitude": 37.6173}
+			}`,
+			mockSetup:      nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+			wantErr:        true,
+		},
+		{
+			name: "Некорректные координаты маршрута",
+			requestBody: `{
+				"order_ID": "order-2026-003",
+				"pickup": {"latitude": 155.7558, "longitude": 37.6173},
+				"dropoff": {"latitude": 55.7602, "longitude": 37.6186}
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryAssign(gomock.Any(), gomock.Any()).
+					Return(nil, delivery.ErrInvalidRoute)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+			wantErr:        true,
+		},
 		{
 			name:           "Невалидный JSON в теле запроса",
 			requestBody:    "invalid json",
@@ -109,7 +207,7 @@ func TestDeliveryAssignPostHandler(t *testing.T) {
 			}`,
 			mockSetup: func(m *mock) {
 				m.MockService.EXPECT().
-					DeliveryAssign(gomock.Any(), "").
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{OrderID: ""}).
 					Return(nil, delivery.ErrInvalidOrderID)
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -123,13 +221,243 @@ func TestDeliveryAssignPostHandler(t *testing.T) {
 			}`,
 			mockSetup: func(m *mock) {
 				m.MockService.EXPECT().
-					DeliveryAssign(gomock.Any(), "order-2026-001").
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{OrderID: "order-2026-001"}).
 					Return(nil, delivery.ErrNoAvailableCouriers)
 			},
 			expectedStatus: http.StatusConflict,
 			expectedBody:   nil,
 			wantErr:        true,
 		},
+		{
+			name: "Выбранного курьера изменили параллельно",
+			requestBody: `{
+				"order_ID": "order-2026-001"
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{OrderID: "order-2026-001"}).
+					Return(nil, delivery.ErrCourierNotAvailable)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   nil,
+			wantErr:        true,
+		},
+		{
+			name: "Нет доступных курьеров, заказ поставлен в очередь ожидания",
+			requestBody: `{
+				"order_ID": "order-2026-001"
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{OrderID: "order-2026-001"}).
+					Return(nil, fmt.Errorf("%w: %w", delivery.ErrAssignmentPending, delivery.ErrNoAvailableCouriers))
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedBody: map[string]interface{}{
+				"order_ID": "order-2026-001",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Никто не подошел под требования заказа, ответ объясняет причину",
+			requestBody: `{
+				"order_ID": "order-2026-001",
+				"requirements": {
+					"skills": ["thermal_bag"],
+					"transport_types": ["scooter", "car"]
+				}
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{
+						OrderID: "order-2026-001",
+						Requirements: entities.OrderRequirements{
+							Skills:         []entities.CourierSkill{entities.SkillThermalBag},
+							TransportTypes: []entities.CourierTransportType{entities.Scooter, entities.Car},
+						},
+					}).
+					Return(nil, fmt.Errorf("%w: %w", delivery.ErrAssignmentPending, &delivery.NoCourierMatchError{
+						Mismatch: entities.CourierMismatch{
+							AvailableCouriers: 5,
+							Requirements: []entities.RequirementMatch{
+								{Requirement: entities.RequirementTransportType, Value: "scooter,car", MatchingCouriers: 3},
+								{Requirement: entities.RequirementSkill, Value: "thermal_bag", MatchingCouriers: 0},
+							},
+						},
+					}))
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedBody: map[string]interface{}{
+				"order_ID": "order-2026-001",
+				"reason": map[string]interface{}{
+					"available_couriers": 5,
+					"requirements": []map[string]interface{}{
+						{"requirement": "transport_type", "value": "scooter,car", "matching_couriers": 3},
+						{"requirement": "skill", "value": "thermal_bag", "matching_couriers": 0},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "Заказ предложен курьеру, ответ содержит предложение",
+			requestBody: `{"order_ID": "order-2026-001"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{OrderID: "order-2026-001"}).
+					Return(nil, &delivery.OfferPendingError{
+						Offer: entities.DeliveryOffer{
+							ID:        7,
+							OrderID:   "order-2026-001",
+							CourierID: 3,
+							Status:    entities.OfferPending,
+							ExpiresAt: time.Date(2026, 1, 1, 12, 1, 0, 0, time.UTC),
+						},
+					})
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedBody: map[string]interface{}{
+				"order_ID": "order-2026-001",
+				"offer": map[string]interface{}{
+					"ID":         7,
+					"courier_ID": 3,
+					"expires_at": "2026-01-01T12:01:00Z",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Неизвестный навык в требованиях",
+			requestBody: `{
+				"order_ID": "order-2026-001",
+				"requirements": {"skills": ["jetpack"]}
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{
+						OrderID: "order-2026-001",
+						Requirements: entities.OrderRequirements{
+							Skills: []entities.CourierSkill{"jetpack"},
+						},
+					}).
+					Return(nil, delivery.ErrInvalidRequirements)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+			wantErr:        true,
+		},
+		{
+			name: "Приоритетный заказ",
+			requestBody: `{
+				"order_ID": "order-2026-001",
+				"priority": "high"
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{
+						OrderID:  "order-2026-001",
+						Priority: entities.PriorityHigh,
+					}).
+					Return(&entities.DeliveryAssignment{
+						CourierID:     1,
+						OrderID:       "order-2026-001",
+						AssignedAt:    assignedAt,
+						Deadline:      deadline,
+						TransportType: entities.Car,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"courier_ID":        float64(1),
+				"order_ID":          "order-2026-001",
+				"transport_type":    "car",
+				"delivery_deadline": deadlineStr,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Неизвестный класс срочности",
+			requestBody: `{
+				"order_ID": "order-2026-001",
+				"priority": "urgent"
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{
+						OrderID:  "order-2026-001",
+						Priority: entities.OrderPriority("urgent"),
+					}).
+					Return(nil, delivery.ErrInvalidPriority)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+			wantErr:        true,
+		},
+		{
+			name: "Прогноз времени доставки рядом с дедлайном",
+			requestBody: `{
+				"order_ID": "order-2026-001"
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{OrderID: "order-2026-001"}).
+					Return(&entities.DeliveryAssignment{
+						CourierID:     1,
+						OrderID:       "order-2026-001",
+						AssignedAt:    assignedAt,
+						Deadline:      deadline,
+						TransportType: entities.Car,
+					}, nil)
+				m.MockETAService.EXPECT().
+					Predict(gomock.Any(), entities.Car, assignedAt).
+					Return(&entities.ETAPrediction{
+						ETA:        assignedAt.Add(22 * time.Minute),
+						P50:        22 * time.Minute,
+						P90:        35 * time.Minute,
+						Confidence: entities.ETAConfidenceHigh,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"courier_ID":        float64(1),
+				"order_ID":          "order-2026-001",
+				"transport_type":    "car",
+				"delivery_deadline": deadlineStr,
+				"predicted_eta":     "2026-01-01T12:22:00Z",
+				"eta_confidence":    "high",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ошибка прогноза не мешает назначению",
+			requestBody: `{
+				"order_ID": "order-2026-001"
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{OrderID: "order-2026-001"}).
+					Return(&entities.DeliveryAssignment{
+						CourierID:     1,
+						OrderID:       "order-2026-001",
+						AssignedAt:    assignedAt,
+						Deadline:      deadline,
+						TransportType: entities.Car,
+					}, nil)
+				m.MockETAService.EXPECT().
+					Predict(gomock.Any(), entities.Car, assignedAt).
+					Return(nil, errors.New("database connection error"))
+				m.MockhandlerLogger.EXPECT().
+					Warn("predict delivery eta", gomock.Any())
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"courier_ID":        float64(1),
+				"order_ID":          "order-2026-001",
+				"transport_type":    "car",
+				"delivery_deadline": deadlineStr,
+			},
+			wantErr: false,
+		},
 		{
 			name: "Заказ уже назначен",
 			requestBody: `{
@@ -137,7 +465,7 @@ func TestDeliveryAssignPostHandler(t *testing.T) {
 			}`,
 			mockSetup: func(m *mock) {
 				m.MockService.EXPECT().
-					DeliveryAssign(gomock.Any(), "order-2026-001").
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{OrderID: "order-2026-001"}).
 					Return(nil, delivery.ErrOrderAlreadyAssigned)
 			},
 			expectedStatus: http.StatusConflict,
@@ -149,7 +477,7 @@ func TestDeliveryAssignPostHandler(t *testing.T) {
 			requestBody: `{}`,
 			mockSetup: func(m *mock) {
 				m.MockService.EXPECT().
-					DeliveryAssign(gomock.Any(), "").
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{OrderID: ""}).
 					Return(nil, delivery.ErrMissingRequiredFields)
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -163,7 +491,7 @@ func TestDeliveryAssignPostHandler(t *testing.T) {
 			}`,
 			mockSetup: func(m *mock) {
 				m.MockService.EXPECT().
-					DeliveryAssign(gomock.Any(), "order-2026-001").
+					DeliveryAssign(gomock.Any(), entities.DeliveryAssignParams{OrderID: "order-2026-001"}).
 					Return(nil, errors.New("database connection error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -188,8 +516,13 @@ func TestDeliveryAssignPostHandler(t *testing.T) {
 			if tt.mockSetup != nil {
 				tt.mockSetup(m)
 			}
+			// пока модель ETA не обучена, в ответе только контрактный дедлайн
+			m.MockETAService.EXPECT().
+				Predict(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, delivery_eta.ErrETAModelNotFound).
+				AnyTimes()
 
-			handler := delivery_assign_post.New(m.MockhandlerLogger, m.MockService)
+			handler := delivery_assign_post.New(m.MockhandlerLogger, m.MockService, m.MockETAService)
 
 			req := httptest.NewRequest(http.MethodPost, "/delivery/assign", bytes.NewReader([]byte(tt.requestBody)))
 			req.Header.Set("Content-Type", "application/json")
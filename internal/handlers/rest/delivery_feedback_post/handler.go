@@ -0,0 +1,88 @@
+package delivery_feedback_post
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"service/internal/entities"
+	"service/internal/generated/dto"
+	"service/internal/service/delivery_rating"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	orderID := mux.Vars(r)["order_id"]
+
+	var feedbackDTO dto.DeliveryFeedbackRequest
+	err := json.NewDecoder(r.Body).Decode(&feedbackDTO)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	params := entities.DeliveryRatingParams{
+		OrderID: orderID,
+		Author:  entities.RatingAuthor(feedbackDTO.Author),
+		Score:   feedbackDTO.Rating,
+		Comment: feedbackDTO.Comment,
+	}
+	if feedbackDTO.Tags != nil {
+		params.Tags = *feedbackDTO.Tags
+	}
+
+	rating, err := h.service.RateDelivery(r.Context(), params)
+	if err != nil {
+		switch {
+		case errors.Is(err, delivery_rating.ErrInvalidOrderID),
+			errors.Is(err, delivery_rating.ErrInvalidAuthor),
+			errors.Is(err, delivery_rating.ErrInvalidScore),
+			errors.Is(err, delivery_rating.ErrInvalidTags),
+			errors.Is(err, delivery_rating.ErrInvalidComment):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, delivery_rating.ErrDeliveryNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, delivery_rating.ErrDeliveryNotCompleted),
+			errors.Is(err, delivery_rating.ErrAlreadyRated):
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ratingDTO := dto.DeliveryFeedback{
+		ID:        rating.ID,
+		OrderID:   rating.OrderID,
+		CourierID: rating.CourierID,
+		Author:    rating.Author.String(),
+		Rating:    rating.Score,
+		Tags:      rating.Tags,
+		Comment:   rating.Comment,
+		CreatedAt: rating.CreatedAt,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(ratingDTO)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
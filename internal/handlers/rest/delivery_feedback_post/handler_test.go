@@ -0,0 +1,231 @@
+package delivery_feedback_post_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/delivery_feedback_post"
+	"service/internal/service/delivery_rating"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestDeliveryFeedbackPostHandler(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2026, 1, 1, 13, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		orderID        string
+		requestBody    string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name:    "Оценка от клиента с тегами и комментарием",
+			orderID: "order-1",
+			requestBody: `{
+				"author": "customer",
+				"rating": 5,
+				"tags": ["polite", "fast"],
+				"comment": "Спасибо!"
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					RateDelivery(gomock.Any(), entities.DeliveryRatingParams{
+						OrderID: "order-1",
+						Author:  entities.RatingAuthorCustomer,
+						Score:   5,
+						Tags:    []string{"polite", "fast"},
+						Comment: pointer.To("Спасибо!"),
+					}).
+					Return(&entities.DeliveryRating{
+						ID:        1,
+						OrderID:   "order-1",
+						CourierID: 7,
+						Author:    entities.RatingAuthorCustomer,
+						Score:     5,
+						Tags:      []string{"polite", "fast"},
+						Comment:   pointer.To("Спасибо!"),
+						CreatedAt: createdAt,
+					}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: map[string]interface{}{
+				"ID":         1,
+				"order_ID":   "order-1",
+				"courier_ID": 7,
+				"author":     "customer",
+				"rating":     5,
+				"tags":       []string{"polite", "fast"},
+				"comment":    "Спасибо!",
+				"created_at": "2026-01-01T13:00:00Z",
+			},
+			wantErr: false,
+		},
+		{
+			name:        "Оценка от ресторана без тегов",
+			orderID:     "order-1",
+			requestBody: `{"author": "restaurant", "rating": 3}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					RateDelivery(gomock.Any(), entities.DeliveryRatingParams{
+						OrderID: "order-1",
+						Author:  entities.RatingAuthorRestaurant,
+						Score:   3,
+					}).
+					Return(&entities.DeliveryRating{
+						ID:        2,
+						OrderID:   "order-1",
+						CourierID: 7,
+						Author:    entities.RatingAuthorRestaurant,
+						Score:     3,
+						Tags:      []string{},
+						CreatedAt: createdAt,
+					}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: map[string]interface{}{
+				"ID":         2,
+				"order_ID":   "order-1",
+				"courier_ID": 7,
+				"author":     "restaurant",
+				"rating":     3,
+				"tags":       []string{},
+				"created_at": "2026-01-01T13:00:00Z",
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Невалидный JSON",
+			orderID:        "order-1",
+			requestBody:    `{"rating": }`,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Оценка вне диапазона",
+			orderID:     "order-1",
+			requestBody: `{"author": "customer", "rating": 7}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					RateDelivery(gomock.Any(), gomock.Any()).
+					Return(nil, delivery_rating.ErrInvalidScore)
+			},
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Доставка не найдена",
+			orderID:     "order-404",
+			requestBody: `{"author": "customer", "rating": 5}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					RateDelivery(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("get rated delivery: %w", delivery_rating.ErrDeliveryNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:        "Доставка еще не выполнена",
+			orderID:     "order-1",
+			requestBody: `{"author": "customer", "rating": 5}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					RateDelivery(gomock.Any(), gomock.Any()).
+					Return(nil, delivery_rating.ErrDeliveryNotCompleted)
+			},
+			expectedStatus: http.StatusConflict,
+			wantErr:        true,
+		},
+		{
+			name:        "Повторная оценка от того же автора",
+			orderID:     "order-1",
+			requestBody: `{"author": "customer", "rating": 5}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					RateDelivery(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("create delivery rating: %w", delivery_rating.ErrAlreadyRated))
+			},
+			expectedStatus: http.StatusConflict,
+			wantErr:        true,
+		},
+		{
+			name:        "Внутренняя ошибка сервиса",
+			orderID:     "order-1",
+			requestBody: `{"author": "customer", "rating": 5}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					RateDelivery(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := delivery_feedback_post.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/delivery/"+tt.orderID+"/feedback", strings.NewReader(tt.requestBody))
+			req = mux.SetURLVars(req, map[string]string{"order_id": tt.orderID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package delivery_get
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"service/internal/entities"
+	"service/internal/generated/dto"
+	"service/internal/service/delivery"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	orderID := mux.Vars(r)["order_id"]
+
+	deliveryEntity, err := h.service.GetDelivery(r.Context(), orderID)
+	if err != nil {
+		switch {
+		case errors.Is(err, delivery.ErrDeliveryNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, delivery.ErrInvalidOrderID):
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	deliveryDTO := dto.Delivery{
+		CourierID:         deliveryEntity.CourierID,
+		OrderID:           deliveryEntity.OrderID,
+		Address:           addressToDTO(deliveryEntity.Address),
+		EstimatedDelivery: deliveryEntity.EstimatedDelivery,
+		AssignedAt:        deliveryEntity.AssignedAt,
+		Deadline:          deliveryEntity.Deadline,
+		OverdueAt:         deliveryEntity.OverdueAt,
+		CourierReleasedAt: deliveryEntity.CourierReleasedAt,
+	}
+	if deliveryEntity.RestaurantID != "" {
+		deliveryDTO.RestaurantID = &deliveryEntity.RestaurantID
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(deliveryDTO)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
+
+// addressToDTO пустые необязательные поля адреса в ответ не попадают
+func addressToDTO(address *entities.Address) *dto.Address {
+	if address == nil {
+		return nil
+	}
+
+	addressDTO := &dto.Address{
+		Street: address.Street,
+		House:  address.House,
+	}
+	if address.Apartment != "" {
+		addressDTO.Apartment = &address.Apartment
+	}
+	if address.Floor != "" {
+		addressDTO.Floor = &address.Floor
+	}
+	if address.Comment != "" {
+		addressDTO.Comment = &address.Comment
+	}
+
+	return addressDTO
+}
@@ -0,0 +1,207 @@
+package delivery_get_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/delivery_get"
+	"service/internal/service/delivery"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestDeliveryGetHandler(t *testing.T) {
+	t.Parallel()
+
+	assignedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	estimatedDelivery := assignedAt.Add(40 * time.Minute)
+
+	tests := []struct {
+		name           string
+		orderID        string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name:    "Успешное получение доставки с адресом и обещанным временем",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetDelivery(gomock.Any(), "order-2026-001").
+					Return(&entities.Delivery{
+						ID:                1,
+						CourierID:         1,
+						OrderID:           "order-2026-001",
+						RestaurantID:      "restaurant-7",
+						Address:           &entities.Address{Street: "Тверская", House: "1", Comment: "домофон не работает"},
+						EstimatedDelivery: &estimatedDelivery,
+						AssignedAt:        assignedAt,
+						Deadline:          estimatedDelivery,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"courier_ID":    float64(1),
+				"order_ID":      "order-2026-001",
+				"restaurant_ID": "restaurant-7",
+				"address": map[string]interface{}{
+					"street":  "Тверская",
+					"house":   "1",
+					"comment": "домофон не работает",
+				},
+				"estimated_delivery": "2026-01-01T12:40:00Z",
+				"assigned_at":        "2026-01-01T12:00:00Z",
+				"deadline":           "2026-01-01T12:40:00Z",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Успешное получение доставки без данных заказа",
+			orderID: "order-2026-002",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetDelivery(gomock.Any(), "order-2026-002").
+					Return(&entities.Delivery{
+						ID:         2,
+						CourierID:  3,
+						OrderID:    "order-2026-002",
+						AssignedAt: assignedAt,
+						Deadline:   assignedAt.Add(30 * time.Minute),
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"courier_ID":  float64(3),
+				"order_ID":    "order-2026-002",
+				"assigned_at": "2026-01-01T12:00:00Z",
+				"deadline":    "2026-01-01T12:30:00Z",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Просроченная доставка с освобожденным курьером",
+			orderID: "order-2026-003",
+			mockSetup: func(m *mock) {
+				overdueAt := assignedAt.Add(31 * time.Minute)
+				courierReleasedAt := assignedAt.Add(45 * time.Minute)
+				m.MockService.EXPECT().
+					GetDelivery(gomock.Any(), "order-2026-003").
+					Return(&entities.Delivery{
+						ID:                3,
+						CourierID:         4,
+						OrderID:           "order-2026-003",
+						AssignedAt:        assignedAt,
+						Deadline:          assignedAt.Add(30 * time.Minute),
+						OverdueAt:         &overdueAt,
+						CourierReleasedAt: &courierReleasedAt,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"courier_ID":          float64(4),
+				"order_ID":            "order-2026-003",
+				"assigned_at":         "2026-01-01T12:00:00Z",
+				"deadline":            "2026-01-01T12:30:00Z",
+				"overdue_at":          "2026-01-01T12:31:00Z",
+				"courier_released_at": "2026-01-01T12:45:00Z",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Доставка не найдена",
+			orderID: "order-2026-404",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetDelivery(gomock.Any(), "order-2026-404").
+					Return(nil, delivery.ErrDeliveryNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   nil,
+			wantErr:        true,
+		},
+		{
+			name:    "Невалидный ID заказа (пустая строка)",
+			orderID: "",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetDelivery(gomock.Any(), "").
+					Return(nil, delivery.ErrInvalidOrderID)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+			wantErr:        true,
+		},
+		{
+			name:    "Ошибка сервиса при получении доставки",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetDelivery(gomock.Any(), "order-2026-001").
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := delivery_get.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/delivery/"+tt.orderID, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"order_id": tt.orderID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package delivery_offer_accept_post
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"service/internal/generated/dto"
+	"service/internal/service/delivery"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["id"]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	deliveryEntity, err := h.service.AcceptOffer(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, delivery.ErrInvalidOfferID):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, delivery.ErrOfferNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, delivery.ErrOfferNotPending),
+			errors.Is(err, delivery.ErrOfferExpired),
+			errors.Is(err, delivery.ErrCourierNotAvailable),
+			errors.Is(err, delivery.ErrOrderAlreadyAssigned):
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := dto.DeliveryAssignResponse{
+		CourierID:        deliveryEntity.CourierID,
+		DeliveryDeadline: deliveryEntity.Deadline,
+		OrderID:          deliveryEntity.OrderID,
+		TransportType:    deliveryEntity.TransportType.String(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
@@ -0,0 +1,168 @@
+package delivery_offer_accept_post_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/delivery_offer_accept_post"
+	"service/internal/service/delivery"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestDeliveryOfferAcceptPostHandler(t *testing.T) {
+	t.Parallel()
+
+	deadline := time.Date(2026, 1, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		offerID        string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name:    "Курьер принял предложение",
+			offerID: "7",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					AcceptOffer(gomock.Any(), int64(7)).
+					Return(&entities.DeliveryAssignment{
+						CourierID:     3,
+						OrderID:       "order-2026-001",
+						Deadline:      deadline,
+						TransportType: entities.Car,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"courier_ID":        3,
+				"order_ID":          "order-2026-001",
+				"delivery_deadline": "2026-01-01T12:30:00Z",
+				"transport_type":    "car",
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Невалидный ID предложения (не число)",
+			offerID:        "abc",
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:    "Некорректный ID предложения",
+			offerID: "0",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					AcceptOffer(gomock.Any(), int64(0)).
+					Return(nil, delivery.ErrInvalidOfferID)
+			},
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:    "Предложение не найдено",
+			offerID: "999",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					AcceptOffer(gomock.Any(), int64(999)).
+					Return(nil, delivery.ErrOfferNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:    "Срок предложения истек",
+			offerID: "7",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					AcceptOffer(gomock.Any(), int64(7)).
+					Return(nil, delivery.ErrOfferExpired)
+			},
+			expectedStatus: http.StatusConflict,
+			wantErr:        true,
+		},
+		{
+			name:    "На предложение уже ответили",
+			offerID: "7",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					AcceptOffer(gomock.Any(), int64(7)).
+					Return(nil, delivery.ErrOfferNotPending)
+			},
+			expectedStatus: http.StatusConflict,
+			wantErr:        true,
+		},
+		{
+			name:    "Ошибка сервиса при принятии предложения",
+			offerID: "7",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					AcceptOffer(gomock.Any(), int64(7)).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := delivery_offer_accept_post.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/delivery/offer/"+tt.offerID+"/accept", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.offerID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=delivery_offer_decline_post_test
+package delivery_offer_decline_post
+
+import (
+	"context"
+
+	"service/pkg/logger"
+)
+
+type handlerLogger interface {
+	Info(msg string, fields ...logger.Field)
+	Warn(msg string, fields ...logger.Field)
+	Error(msg string, fields ...logger.Field)
+	With(fields ...logger.Field) logger.Logger
+}
+
+type Service interface {
+	DeclineOffer(ctx context.Context, offerID int64) error
+}
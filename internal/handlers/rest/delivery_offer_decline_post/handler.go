@@ -0,0 +1,50 @@
+package delivery_offer_decline_post
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"service/internal/service/delivery"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["id"]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.DeclineOffer(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, delivery.ErrInvalidOfferID):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, delivery.ErrOfferNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, delivery.ErrOfferNotPending):
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
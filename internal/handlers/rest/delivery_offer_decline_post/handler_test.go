@@ -0,0 +1,112 @@
+package delivery_offer_decline_post_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"service/internal/handlers/rest/delivery_offer_decline_post"
+	"service/internal/service/delivery"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestDeliveryOfferDeclinePostHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		offerID        string
+		mockSetup      func(m *mock)
+		expectedStatus int
+	}{
+		{
+			name:    "Курьер отказался от предложения",
+			offerID: "7",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeclineOffer(gomock.Any(), int64(7)).
+					Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "Невалидный ID предложения (не число)",
+			offerID:        "abc",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "Предложение не найдено",
+			offerID: "999",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeclineOffer(gomock.Any(), int64(999)).
+					Return(delivery.ErrOfferNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:    "На предложение уже ответили",
+			offerID: "7",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeclineOffer(gomock.Any(), int64(7)).
+					Return(delivery.ErrOfferNotPending)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:    "Ошибка сервиса при отказе",
+			offerID: "7",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeclineOffer(gomock.Any(), int64(7)).
+					Return(errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := delivery_offer_decline_post.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/delivery/offer/"+tt.offerID+"/decline", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.offerID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+		})
+	}
+}
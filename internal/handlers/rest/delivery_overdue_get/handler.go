@@ -0,0 +1,81 @@
+package delivery_overdue_get
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"service/internal/entities"
+	"service/internal/generated/dto"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	overdueEntities, err := h.service.GetOverdueDeliveries(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	deliveryDTOs := make([]dto.Delivery, len(overdueEntities))
+	for i, delivery := range overdueEntities {
+		deliveryDTOs[i] = dto.Delivery{
+			CourierID:         delivery.CourierID,
+			OrderID:           delivery.OrderID,
+			Address:           addressToDTO(delivery.Address),
+			EstimatedDelivery: delivery.EstimatedDelivery,
+			AssignedAt:        delivery.AssignedAt,
+			Deadline:          delivery.Deadline,
+			OverdueAt:         delivery.OverdueAt,
+			CourierReleasedAt: delivery.CourierReleasedAt,
+		}
+		if delivery.RestaurantID != "" {
+			deliveryDTOs[i].RestaurantID = &delivery.RestaurantID
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(deliveryDTOs)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
+
+// addressToDTO пустые необязательные поля адреса в ответ не попадают
+func addressToDTO(address *entities.Address) *dto.Address {
+	if address == nil {
+		return nil
+	}
+
+	addressDTO := &dto.Address{
+		Street: address.Street,
+		House:  address.House,
+	}
+	if address.Apartment != "" {
+		addressDTO.Apartment = &address.Apartment
+	}
+	if address.Floor != "" {
+		addressDTO.Floor = &address.Floor
+	}
+	if address.Comment != "" {
+		addressDTO.Comment = &address.Comment
+	}
+
+	return addressDTO
+}
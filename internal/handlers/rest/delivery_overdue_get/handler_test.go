@@ -0,0 +1,157 @@
+package delivery_overdue_get_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/delivery_overdue_get"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestDeliveryOverdueGetHandler(t *testing.T) {
+	t.Parallel()
+
+	assignedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	deadline := assignedAt.Add(30 * time.Minute)
+	overdueAt := deadline.Add(time.Minute)
+	courierReleasedAt := deadline.Add(15 * time.Minute)
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   []map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name: "Успешное получение просроченных доставок",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetOverdueDeliveries(gomock.Any()).
+					Return([]entities.Delivery{
+						{
+							ID:                1,
+							CourierID:         1,
+							OrderID:           "order-2026-001",
+							RestaurantID:      "restaurant-7",
+							Address:           &entities.Address{Street: "Тверская", House: "1"},
+							AssignedAt:        assignedAt,
+							Deadline:          deadline,
+							OverdueAt:         &overdueAt,
+							CourierReleasedAt: &courierReleasedAt,
+						},
+						{
+							ID:         2,
+							CourierID:  2,
+							OrderID:    "order-2026-002",
+							AssignedAt: assignedAt,
+							Deadline:   deadline,
+							OverdueAt:  &overdueAt,
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: []map[string]interface{}{
+				{
+					"courier_ID":    float64(1),
+					"order_ID":      "order-2026-001",
+					"restaurant_ID": "restaurant-7",
+					"address": map[string]interface{}{
+						"street": "Тверская",
+						"house":  "1",
+					},
+					"assigned_at":         "2026-01-01T12:00:00Z",
+					"deadline":            "2026-01-01T12:30:00Z",
+					"overdue_at":          "2026-01-01T12:31:00Z",
+					"courier_released_at": "2026-01-01T12:45:00Z",
+				},
+				{
+					"courier_ID":  float64(2),
+					"order_ID":    "order-2026-002",
+					"assigned_at": "2026-01-01T12:00:00Z",
+					"deadline":    "2026-01-01T12:30:00Z",
+					"overdue_at":  "2026-01-01T12:31:00Z",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Просроченных доставок нет",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetOverdueDeliveries(gomock.Any()).
+					Return([]entities.Delivery{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []map[string]interface{}{},
+			wantErr:        false,
+		},
+		{
+			name: "Ошибка сервиса при получении просроченных доставок",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetOverdueDeliveries(gomock.Any()).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := delivery_overdue_get.New(m.MockhandlerLogger, m.MockService)
+			req := httptest.NewRequest(http.MethodGet, "/delivery/overdue", http.NoBody)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
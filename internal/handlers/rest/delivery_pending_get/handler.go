@@ -0,0 +1,76 @@
+package delivery_pending_get
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"service/internal/entities"
+	"service/internal/generated/dto"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	pendingEntities, err := h.service.GetPendingAssignments(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	pendingDTOs := make([]dto.PendingAssignment, len(pendingEntities))
+	for i, pending := range pendingEntities {
+		pendingDTOs[i].OrderID = pending.OrderID
+		pendingDTOs[i].Priority = pending.Priority
+		pendingDTOs[i].EnqueuedAt = pending.EnqueuedAt
+		pendingDTOs[i].Address = addressToDTO(pending.Address)
+		pendingDTOs[i].EstimatedDelivery = pending.EstimatedDelivery
+		if pending.RestaurantID != "" {
+			pendingDTOs[i].RestaurantID = &pending.RestaurantID
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(pendingDTOs)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
+
+// addressToDTO пустые необязательные поля адреса в ответ не попадают
+func addressToDTO(address *entities.Address) *dto.Address {
+	if address == nil {
+		return nil
+	}
+
+	addressDTO := &dto.Address{
+		Street: address.Street,
+		House:  address.House,
+	}
+	if address.Apartment != "" {
+		addressDTO.Apartment = &address.Apartment
+	}
+	if address.Floor != "" {
+		addressDTO.Floor = &address.Floor
+	}
+	if address.Comment != "" {
+		addressDTO.Comment = &address.Comment
+	}
+
+	return addressDTO
+}
@@ -0,0 +1,172 @@
+package delivery_pending_get_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/delivery_pending_get"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestDeliveryPendingGetHandler(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   []map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name: "Успешное получение очереди ожидания",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetPendingAssignments(gomock.Any()).
+					Return([]entities.PendingAssignment{
+						{
+							ID:         2,
+							OrderID:    "order-2026-002",
+							Priority:   5,
+							EnqueuedAt: fixedTime.Add(time.Minute),
+						},
+						{
+							ID:         1,
+							OrderID:    "order-2026-001",
+							Priority:   0,
+							EnqueuedAt: fixedTime,
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: []map[string]interface{}{
+				{
+					"order_ID":    "order-2026-002",
+					"priority":    float64(5),
+					"enqueued_at": "2026-01-01T12:01:00Z",
+				},
+				{
+					"order_ID":    "order-2026-001",
+					"priority":    float64(0),
+					"enqueued_at": "2026-01-01T12:00:00Z",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Заказ в очереди с адресом, рестораном и обещанным временем",
+			mockSetup: func(m *mock) {
+				estimatedDelivery := fixedTime.Add(40 * time.Minute)
+				m.MockService.EXPECT().
+					GetPendingAssignments(gomock.Any()).
+					Return([]entities.PendingAssignment{
+						{
+							ID:                1,
+							OrderID:           "order-2026-001",
+							Priority:          0,
+							RestaurantID:      "restaurant-7",
+							Address:           &entities.Address{Street: "Тверская", House: "1", Floor: "3"},
+							EstimatedDelivery: &estimatedDelivery,
+							EnqueuedAt:        fixedTime,
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: []map[string]interface{}{
+				{
+					"order_ID":      "order-2026-001",
+					"priority":      float64(0),
+					"restaurant_ID": "restaurant-7",
+					"address": map[string]interface{}{
+						"street": "Тверская",
+						"house":  "1",
+						"floor":  "3",
+					},
+					"estimated_delivery": "2026-01-01T12:40:00Z",
+					"enqueued_at":        "2026-01-01T12:00:00Z",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Успешное получение пустой очереди",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetPendingAssignments(gomock.Any()).
+					Return([]entities.PendingAssignment{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []map[string]interface{}{},
+			wantErr:        false,
+		},
+		{
+			name: "Ошибка сервиса при получении очереди",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetPendingAssignments(gomock.Any()).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := delivery_pending_get.New(m.MockhandlerLogger, m.MockService)
+			req := httptest.NewRequest(http.MethodGet, "/delivery/pending", http.NoBody)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
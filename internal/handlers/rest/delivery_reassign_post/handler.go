@@ -0,0 +1,78 @@
+package delivery_reassign_post
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"service/internal/entities"
+	"service/internal/generated/dto"
+	"service/internal/service/delivery"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var deliveryReassignDTO dto.DeliveryReassignRequest
+	err := json.NewDecoder(r.Body).Decode(&deliveryReassignDTO)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	reassignment, err := h.service.DeliveryReassign(r.Context(), entities.DeliveryReassignParams{
+		OrderID:   deliveryReassignDTO.OrderID,
+		CourierID: deliveryReassignDTO.CourierID,
+		Reason:    deliveryReassignDTO.Reason,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, delivery.ErrInvalidOrderID),
+			errors.Is(err, delivery.ErrInvalidCourierID),
+			errors.Is(err, delivery.ErrInvalidReassignReason):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, delivery.ErrDeliveryNotFound),
+			errors.Is(err, delivery.ErrCourierNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, delivery.ErrNoAvailableCouriers),
+			errors.Is(err, delivery.ErrCourierNotAvailable),
+			errors.Is(err, delivery.ErrSameCourier),
+			errors.Is(err, delivery.ErrDeliveryCompleted):
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := dto.DeliveryReassignResponse{
+		OrderID:           reassignment.OrderID,
+		PreviousCourierID: reassignment.PreviousCourierID,
+		CourierID:         reassignment.CourierID,
+		TransportType:     reassignment.TransportType.String(),
+		DeliveryDeadline:  reassignment.Deadline,
+		Reason:            reassignment.Reason,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
@@ -0,0 +1,264 @@
+package delivery_reassign_post_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/delivery_reassign_post"
+	"service/internal/service/delivery"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestDeliveryReassignPostHandler(t *testing.T) {
+	t.Parallel()
+
+	reassignedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	deadline := reassignedAt.Add(20 * time.Minute)
+	deadlineStr := deadline.Format(time.RFC3339)
+	courierID := int64(2)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name: "Успешная передача заказа следующему подходящему курьеру",
+			requestBody: `{
+				"order_ID": "order-2026-001",
+				"reason": "курьер попал в ДТП"
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryReassign(gomock.Any(), entities.DeliveryReassignParams{
+						OrderID: "order-2026-001",
+						Reason:  "курьер попал в ДТП",
+					}).
+					Return(&entities.DeliveryReassignment{
+						ID:                1,
+						OrderID:           "order-2026-001",
+						PreviousCourierID: 1,
+						CourierID:         2,
+						TransportType:     entities.Scooter,
+						Reason:            "курьер попал в ДТП",
+						Deadline:          deadline,
+						ReassignedAt:      reassignedAt,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"order_ID":            "order-2026-001",
+				"previous_courier_ID": float64(1),
+				"courier_ID":          float64(2),
+				"transport_type":      "scooter",
+				"delivery_deadline":   deadlineStr,
+				"reason":              "курьер попал в ДТП",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Успешная передача заказа выбранному курьеру",
+			requestBody: `{
+				"order_ID": "order-2026-001",
+				"courier_ID": 2,
+				"reason": "перераспределение нагрузки"
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryReassign(gomock.Any(), entities.DeliveryReassignParams{
+						OrderID:   "order-2026-001",
+						CourierID: &courierID,
+						Reason:    "перераспределение нагрузки",
+					}).
+					Return(&entities.DeliveryReassignment{
+						ID:                1,
+						OrderID:           "order-2026-001",
+						PreviousCourierID: 1,
+						CourierID:         2,
+						TransportType:     entities.Car,
+						Reason:            "перераспределение нагрузки",
+						Deadline:          deadline,
+						ReassignedAt:      reassignedAt,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"order_ID":            "order-2026-001",
+				"previous_courier_ID": float64(1),
+				"courier_ID":          float64(2),
+				"transport_type":      "car",
+				"delivery_deadline":   deadlineStr,
+				"reason":              "перераспределение нагрузки",
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Невалидный JSON в теле запроса",
+			requestBody:    `{"order_ID": "order-2026-001",`,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Ошибка валидации причины передачи",
+			requestBody: `{"order_ID": "order-2026-001", "reason": ""}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryReassign(gomock.Any(), gomock.Any()).
+					Return(nil, delivery.ErrInvalidReassignReason)
+			},
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Ошибка валидации ID курьера",
+			requestBody: `{"order_ID": "order-2026-001", "courier_ID": -1, "reason": "причина"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryReassign(gomock.Any(), gomock.Any()).
+					Return(nil, delivery.ErrInvalidCourierID)
+			},
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Доставка не найдена",
+			requestBody: `{"order_ID": "order-2026-404", "reason": "причина"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryReassign(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("get delivery: %w", delivery.ErrDeliveryNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:        "Выбранный курьер не найден",
+			requestBody: `{"order_ID": "order-2026-001", "courier_ID": 404, "reason": "причина"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryReassign(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("get target courier: %w", delivery.ErrCourierNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:        "Выбранный курьер занят",
+			requestBody: `{"order_ID": "order-2026-001", "courier_ID": 3, "reason": "причина"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryReassign(gomock.Any(), gomock.Any()).
+					Return(nil, delivery.ErrCourierNotAvailable)
+			},
+			expectedStatus: http.StatusConflict,
+			wantErr:        true,
+		},
+		{
+			name:        "Заказ уже назначен на выбранного курьера",
+			requestBody: `{"order_ID": "order-2026-001", "courier_ID": 1, "reason": "причина"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryReassign(gomock.Any(), gomock.Any()).
+					Return(nil, delivery.ErrSameCourier)
+			},
+			expectedStatus: http.StatusConflict,
+			wantErr:        true,
+		},
+		{
+			name:        "Доставка уже выполнена",
+			requestBody: `{"order_ID": "order-2026-001", "reason": "причина"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryReassign(gomock.Any(), gomock.Any()).
+					Return(nil, delivery.ErrDeliveryCompleted)
+			},
+			expectedStatus: http.StatusConflict,
+			wantErr:        true,
+		},
+		{
+			name:        "Нет свободных курьеров для передачи",
+			requestBody: `{"order_ID": "order-2026-001", "reason": "причина"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryReassign(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("find courier for reassignment: %w", delivery.ErrNoAvailableCouriers))
+			},
+			expectedStatus: http.StatusConflict,
+			wantErr:        true,
+		},
+		{
+			name:        "Внутренняя ошибка сервиса",
+			requestBody: `{"order_ID": "order-2026-001", "reason": "причина"}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeliveryReassign(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database connection failed"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := delivery_reassign_post.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/delivery/reassign", bytes.NewReader([]byte(tt.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
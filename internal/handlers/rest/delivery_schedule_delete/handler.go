@@ -0,0 +1,42 @@
+package delivery_schedule_delete
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"service/internal/service/delivery"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	orderID := mux.Vars(r)["order_id"]
+
+	_, err := h.service.CancelScheduledDelivery(r.Context(), orderID)
+	if err != nil {
+		switch {
+		case errors.Is(err, delivery.ErrInvalidOrderID):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, delivery.ErrScheduledDeliveryNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,107 @@
+package delivery_schedule_delete_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/delivery_schedule_delete"
+	"service/internal/service/delivery"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestDeliveryScheduleDeleteHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		orderID        string
+		mockSetup      func(m *mock)
+		expectedStatus int
+	}{
+		{
+			name:    "Успешная отмена запланированной доставки",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					CancelScheduledDelivery(gomock.Any(), "order-2026-001").
+					Return(&entities.ScheduledDelivery{OrderID: "order-2026-001", Status: entities.ScheduledCancelled}, nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:    "Невалидный ID заказа",
+			orderID: "",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					CancelScheduledDelivery(gomock.Any(), "").
+					Return(nil, delivery.ErrInvalidOrderID)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "Запланированная доставка не найдена",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					CancelScheduledDelivery(gomock.Any(), "order-2026-001").
+					Return(nil, fmt.Errorf("cancel scheduled delivery: %w", delivery.ErrScheduledDeliveryNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:    "Внутренняя ошибка сервиса",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					CancelScheduledDelivery(gomock.Any(), "order-2026-001").
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			tt.mockSetup(m)
+
+			handler := delivery_schedule_delete.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/delivery/schedule/"+tt.orderID, nil)
+			req = mux.SetURLVars(req, map[string]string{"order_id": tt.orderID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+		})
+	}
+}
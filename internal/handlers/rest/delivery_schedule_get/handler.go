@@ -0,0 +1,112 @@
+package delivery_schedule_get
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"service/internal/entities"
+	"service/internal/generated/dto"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	scheduledEntities, err := h.service.GetScheduledDeliveries(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	scheduledDTOs := make([]dto.ScheduledDelivery, len(scheduledEntities))
+	for i := range scheduledEntities {
+		scheduledDTOs[i] = scheduledToDTO(&scheduledEntities[i])
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(scheduledDTOs)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
+
+func scheduledToDTO(scheduled *entities.ScheduledDelivery) dto.ScheduledDelivery {
+	scheduledDTO := dto.ScheduledDelivery{
+		OrderID:      scheduled.OrderID,
+		DeliverAt:    scheduled.DeliverAt,
+		AssignAt:     scheduled.AssignAt,
+		Address:      addressToDTO(scheduled.Address),
+		Requirements: requirementsToDTO(scheduled.Requirements),
+		Priority:     scheduled.Priority.String(),
+		Status:       scheduled.Status.String(),
+		CreatedAt:    scheduled.CreatedAt,
+	}
+	if scheduled.RestaurantID != "" {
+		scheduledDTO.RestaurantID = &scheduled.RestaurantID
+	}
+
+	return scheduledDTO
+}
+
+// requirementsToDTO заказ без требований возвращается без поля requirements
+func requirementsToDTO(requirements entities.OrderRequirements) *dto.OrderRequirements {
+	if len(requirements.Skills) == 0 && len(requirements.TransportTypes) == 0 {
+		return nil
+	}
+
+	requirementsDTO := &dto.OrderRequirements{}
+	if len(requirements.Skills) > 0 {
+		skills := make([]string, len(requirements.Skills))
+		for i, skill := range requirements.Skills {
+			skills[i] = skill.String()
+		}
+		requirementsDTO.Skills = &skills
+	}
+	if len(requirements.TransportTypes) > 0 {
+		transportTypes := make([]string, len(requirements.TransportTypes))
+		for i, transportType := range requirements.TransportTypes {
+			transportTypes[i] = transportType.String()
+		}
+		requirementsDTO.TransportTypes = &transportTypes
+	}
+
+	return requirementsDTO
+}
+
+// addressToDTO пустые необязательные поля адреса в ответ не попадают
+func addressToDTO(address *entities.Address) *dto.Address {
+	if address == nil {
+		return nil
+	}
+
+	addressDTO := &dto.Address{
+		Street: address.Street,
+		House:  address.House,
+	}
+	if address.Apartment != "" {
+		addressDTO.Apartment = &address.Apartment
+	}
+	if address.Floor != "" {
+		addressDTO.Floor = &address.Floor
+	}
+	if address.Comment != "" {
+		addressDTO.Comment = &address.Comment
+	}
+
+	return addressDTO
+}
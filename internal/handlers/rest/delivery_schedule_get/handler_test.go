@@ -0,0 +1,131 @@
+package delivery_schedule_get_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/delivery_schedule_get"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestDeliveryScheduleGetHandler(t *testing.T) {
+	t.Parallel()
+
+	deliverAt := time.Date(2026, 1, 1, 19, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   []map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name: "Список запланированных доставок",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetScheduledDeliveries(gomock.Any()).
+					Return([]entities.ScheduledDelivery{
+						{
+							ID:        1,
+							OrderID:   "order-2026-001",
+							DeliverAt: deliverAt,
+							AssignAt:  deliverAt.Add(-30 * time.Minute),
+							Address:   &entities.Address{Street: "Тверская", House: "1"},
+							Priority:  entities.PriorityHigh,
+							Status:    entities.ScheduledWaiting,
+							CreatedAt: createdAt,
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: []map[string]interface{}{
+				{
+					"order_ID":   "order-2026-001",
+					"deliver_at": "2026-01-01T19:00:00Z",
+					"assign_at":  "2026-01-01T18:30:00Z",
+					"address":    map[string]interface{}{"street": "Тверская", "house": "1"},
+					"priority":   "high",
+					"status":     "scheduled",
+					"created_at": "2026-01-01T12:00:00Z",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Нет запланированных доставок",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetScheduledDeliveries(gomock.Any()).
+					Return([]entities.ScheduledDelivery{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []map[string]interface{}{},
+			wantErr:        false,
+		},
+		{
+			name: "Внутренняя ошибка сервиса",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetScheduledDeliveries(gomock.Any()).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			tt.mockSetup(m)
+
+			handler := delivery_schedule_get.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/delivery/schedule", nil)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			expectedJSON, err := json.Marshal(tt.expectedBody)
+			require.NoError(t, err, "failed to marshal expected body")
+			assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package delivery_schedule_post
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"service/internal/entities"
+	"service/internal/generated/dto"
+	"service/internal/service/delivery"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var deliveryScheduleDTO dto.DeliveryScheduleRequest
+	err := json.NewDecoder(r.Body).Decode(&deliveryScheduleDTO)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// точки маршрута передаются только парой
+	if (deliveryScheduleDTO.Pickup == nil) != (deliveryScheduleDTO.Dropoff == nil) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	params := entities.DeliveryScheduleParams{
+		Order: entities.DeliveryAssignParams{
+			OrderID:      deliveryScheduleDTO.OrderID,
+			Address:      addressFromDTO(deliveryScheduleDTO.Address),
+			Requirements: requirementsFromDTO(deliveryScheduleDTO.Requirements),
+		},
+		DeliverAt: deliveryScheduleDTO.DeliverAt,
+	}
+	if deliveryScheduleDTO.RestaurantID != nil {
+		params.Order.RestaurantID = *deliveryScheduleDTO.RestaurantID
+	}
+	if deliveryScheduleDTO.Priority != nil {
+		params.Order.Priority = entities.OrderPriority(*deliveryScheduleDTO.Priority)
+	}
+	if deliveryScheduleDTO.Pickup != nil {
+		params.Order.Route = &entities.Route{
+			Pickup: entities.Location{
+				Latitude:  deliveryScheduleDTO.Pickup.Latitude,
+				Longitude: deliveryScheduleDTO.Pickup.Longitude,
+			},
+			Dropoff: entities.Location{
+				Latitude:  deliveryScheduleDTO.Dropoff.Latitude,
+				Longitude: deliveryScheduleDTO.Dropoff.Longitude,
+			},
+		}
+	}
+
+	scheduled, err := h.service.ScheduleDelivery(r.Context(), params)
+	if err != nil {
+		switch {
+		case errors.Is(err, delivery.ErrInvalidOrderID),
+			errors.Is(err, delivery.ErrInvalidRoute),
+			errors.Is(err, delivery.ErrInvalidRequirements),
+			errors.Is(err, delivery.ErrInvalidPriority),
+			errors.Is(err, delivery.ErrInvalidDeliverAt):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, delivery.ErrOrderAlreadyAssigned),
+			errors.Is(err, delivery.ErrOrderAlreadyScheduled):
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(scheduledToDTO(scheduled))
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
+
+func scheduledToDTO(scheduled *entities.ScheduledDelivery) dto.ScheduledDelivery {
+	scheduledDTO := dto.ScheduledDelivery{
+		OrderID:      scheduled.OrderID,
+		DeliverAt:    scheduled.DeliverAt,
+		AssignAt:     scheduled.AssignAt,
+		Address:      addressToDTO(scheduled.Address),
+		Requirements: requirementsToDTO(scheduled.Requirements),
+		Priority:     scheduled.Priority.String(),
+		Status:       scheduled.Status.String(),
+		CreatedAt:    scheduled.CreatedAt,
+	}
+	if scheduled.RestaurantID != "" {
+		scheduledDTO.RestaurantID = &scheduled.RestaurantID
+	}
+
+	return scheduledDTO
+}
+
+func requirementsFromDTO(requirementsDTO *dto.OrderRequirements) entities.OrderRequirements {
+	requirements := entities.OrderRequirements{}
+	if requirementsDTO == nil {
+		return requirements
+	}
+
+	if requirementsDTO.Skills != nil {
+		for _, skill := range *requirementsDTO.Skills {
+			requirements.Skills = append(requirements.Skills, entities.CourierSkill(skill))
+		}
+	}
+	if requirementsDTO.TransportTypes != nil {
+		for _, transportType := range *requirementsDTO.TransportTypes {
+			requirements.TransportTypes = append(requirements.TransportTypes, entities.CourierTransportType(transportType))
+		}
+	}
+
+	return requirements
+}
+
+// requirementsToDTO заказ без требований возвращается без поля requirements
+func requirementsToDTO(requirements entities.OrderRequirements) *dto.OrderRequirements {
+	if len(requirements.Skills) == 0 && len(requirements.TransportTypes) == 0 {
+		return nil
+	}
+
+	requirementsDTO := &dto.OrderRequirements{}
+	if len(requirements.Skills) > 0 {
+		skills := make([]string, len(requirements.Skills))
+		for i, skill := range requirements.Skills {
+			skills[i] = skill.String()
+		}
+		requirementsDTO.Skills = &skills
+	}
+	if len(requirements.TransportTypes) > 0 {
+		transportTypes := make([]string, len(requirements.TransportTypes))
+		for i, transportType := range requirements.TransportTypes {
+			transportTypes[i] = transportType.String()
+		}
+		requirementsDTO.TransportTypes = &transportTypes
+	}
+
+	return requirementsDTO
+}
+
+func addressFromDTO(addressDTO *dto.Address) *entities.Address {
+	if addressDTO == nil {
+		return nil
+	}
+
+	address := &entities.Address{
+		Street: addressDTO.Street,
+		House:  addressDTO.House,
+	}
+	if addressDTO.Apartment != nil {
+		address.Apartment = *addressDTO.Apartment
+	}
+	if addressDTO.Floor != nil {
+		address.Floor = *addressDTO.Floor
+	}
+	if addressDTO.Comment != nil {
+		address.Comment = *addressDTO.Comment
+	}
+
+	return address
+}
+
+// addressToDTO пустые необязательные поля адреса в ответ не попадают
+func addressToDTO(address *entities.Address) *dto.Address {
+	if address == nil {
+		return nil
+	}
+
+	addressDTO := &dto.Address{
+		Street: address.Street,
+		House:  address.House,
+	}
+	if address.Apartment != "" {
+		addressDTO.Apartment = &address.Apartment
+	}
+	if address.Floor != "" {
+		addressDTO.Floor = &address.Floor
+	}
+	if address.Comment != "" {
+		addressDTO.Comment = &address.Comment
+	}
+
+	return addressDTO
+}
@@ -0,0 +1,243 @@
+package delivery_schedule_post_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/delivery_schedule_post"
+	"service/internal/service/delivery"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestDeliverySchedulePostHandler(t *testing.T) {
+	t.Parallel()
+
+	deliverAt := time.Date(2026, 1, 1, 19, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name: "Успешное планирование доставки",
+			requestBody: `{
+				"order_ID": "order-2026-001",
+				"deliver_at": "2026-01-01T19:00:00Z"
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					ScheduleDelivery(gomock.Any(), entities.DeliveryScheduleParams{
+						Order:     entities.DeliveryAssignParams{OrderID: "order-2026-001"},
+						DeliverAt: deliverAt,
+					}).
+					Return(&entities.ScheduledDelivery{
+						ID:        1,
+						OrderID:   "order-2026-001",
+						DeliverAt: deliverAt,
+						AssignAt:  deliverAt.Add(-30 * time.Minute),
+						Priority:  entities.PriorityNormal,
+						Status:    entities.ScheduledWaiting,
+						CreatedAt: createdAt,
+					}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: map[string]interface{}{
+				"order_ID":   "order-2026-001",
+				"deliver_at": "2026-01-01T19:00:00Z",
+				"assign_at":  "2026-01-01T18:30:00Z",
+				"priority":   "normal",
+				"status":     "scheduled",
+				"created_at": "2026-01-01T12:00:00Z",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Планирование с маршрутом, рестораном и требованиями",
+			requestBody: `{
+				"order_ID": "order-2026-002",
+				"deliver_at": "2026-01-01T19:00:00Z",
+				"restaurant_ID": "restaurant-7",
+				"pickup": {"latitude": 55.7558, "longitude": 37.6173},
+				"dropoff": {"latitude": 55.7602, "longitude": 37.6186},
+				"requirements": {"transport_types": ["car"]}
+			}`,
+			mockSetup: func(m *mock) {
+				requirements := entities.OrderRequirements{
+					TransportTypes: []entities.CourierTransportType{entities.Car},
+				}
+				m.MockService.EXPECT().
+					ScheduleDelivery(gomock.Any(), entities.DeliveryScheduleParams{
+						Order: entities.DeliveryAssignParams{
+							OrderID:      "order-2026-002",
+							RestaurantID: "restaurant-7",
+							Route: &entities.Route{
+								Pickup:  entities.Location{Latitude: 55.7558, Longitude: 37.6173},
+								Dropoff: entities.Location{Latitude: 55.7602, Longitude: 37.6186},
+							},
+							Requirements: requirements,
+						},
+						DeliverAt: deliverAt,
+					}).
+					Return(&entities.ScheduledDelivery{
+						ID:           2,
+						OrderID:      "order-2026-002",
+						DeliverAt:    deliverAt,
+						AssignAt:     deliverAt.Add(-10 * time.Minute),
+						RestaurantID: "restaurant-7",
+						Requirements: requirements,
+						Priority:     entities.PriorityNormal,
+						Status:       entities.ScheduledWaiting,
+						CreatedAt:    createdAt,
+					}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: map[string]interface{}{
+				"order_ID":      "order-2026-002",
+				"deliver_at":    "2026-01-01T19:00:00Z",
+				"assign_at":     "2026-01-01T18:50:00Z",
+				"restaurant_ID": "restaurant-7",
+				"requirements":  map[string]interface{}{"transport_types": []string{"car"}},
+				"priority":      "normal",
+				"status":        "scheduled",
+				"created_at":    "2026-01-01T12:00:00Z",
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Невалидный JSON",
+			requestBody:    `{"order_ID": }`,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name: "Точка забора без точки доставки",
+			requestBody: `{
+				"order_ID": "order-2026-001",
+				"deliver_at": "2026-01-01T19:00:00Z",
+				"pickup": {"latitude": 55.7558, "longitude": 37.6173}
+			}`,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name: "Время доставки уже прошло",
+			requestBody: `{
+				"order_ID": "order-2026-001",
+				"deliver_at": "2020-01-01T19:00:00Z"
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					ScheduleDelivery(gomock.Any(), gomock.Any()).
+					Return(nil, delivery.ErrInvalidDeliverAt)
+			},
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name: "Заказу уже назначен курьер",
+			requestBody: `{
+				"order_ID": "order-2026-001",
+				"deliver_at": "2026-01-01T19:00:00Z"
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					ScheduleDelivery(gomock.Any(), gomock.Any()).
+					Return(nil, delivery.ErrOrderAlreadyAssigned)
+			},
+			expectedStatus: http.StatusConflict,
+			wantErr:        true,
+		},
+		{
+			name: "Заказ уже запланирован",
+			requestBody: `{
+				"order_ID": "order-2026-001",
+				"deliver_at": "2026-01-01T19:00:00Z"
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					ScheduleDelivery(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("create scheduled delivery: %w", delivery.ErrOrderAlreadyScheduled))
+			},
+			expectedStatus: http.StatusConflict,
+			wantErr:        true,
+		},
+		{
+			name: "Внутренняя ошибка сервиса",
+			requestBody: `{
+				"order_ID": "order-2026-001",
+				"deliver_at": "2026-01-01T19:00:00Z"
+			}`,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					ScheduleDelivery(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := delivery_schedule_post.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/delivery/schedule", strings.NewReader(tt.requestBody))
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package delivery_settings_get
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"service/internal/generated/dto"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.service.GetDeadlineSettings(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	response := dto.DeliverySettings{
+		TransportSpeeds: make([]dto.TransportSpeed, len(settings.TransportSpeeds)),
+		PeakHours:       make([]dto.PeakHour, len(settings.PeakHours)),
+	}
+	for i, transportSpeed := range settings.TransportSpeeds {
+		response.TransportSpeeds[i].TransportType = transportSpeed.TransportType.String()
+		response.TransportSpeeds[i].AverageSpeedKmh = transportSpeed.AverageSpeedKmh
+		response.TransportSpeeds[i].HandlingOverheadSeconds = int32(transportSpeed.HandlingOverhead / time.Second)
+	}
+	for i, peakHour := range settings.PeakHours {
+		response.PeakHours[i].StartHour = peakHour.StartHour
+		response.PeakHours[i].EndHour = peakHour.EndHour
+		response.PeakHours[i].Multiplier = peakHour.Multiplier
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
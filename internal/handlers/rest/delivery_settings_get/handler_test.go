@@ -0,0 +1,136 @@
+package delivery_settings_get_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/delivery_settings_get"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestDeliverySettingsGetHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name: "Успешное получение настроек дедлайнов",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetDeadlineSettings(gomock.Any()).
+					Return(&entities.DeadlineSettings{
+						TransportSpeeds: []entities.TransportSpeed{
+							{TransportType: entities.Car, AverageSpeedKmh: 25, HandlingOverhead: 5 * time.Minute},
+						},
+						PeakHours: []entities.PeakHour{
+							{StartHour: 17, EndHour: 20, Multiplier: 1.5},
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"transport_speeds": []map[string]interface{}{
+					{
+						"transport_type":            "car",
+						"average_speed_kmh":         float64(25),
+						"handling_overhead_seconds": float64(300),
+					},
+				},
+				"peak_hours": []map[string]interface{}{
+					{
+						"start_hour": float64(17),
+						"end_hour":   float64(20),
+						"multiplier": 1.5,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Пустые настройки возвращаются пустыми списками",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetDeadlineSettings(gomock.Any()).
+					Return(&entities.DeadlineSettings{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"transport_speeds": []map[string]interface{}{},
+				"peak_hours":       []map[string]interface{}{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ошибка сервиса при получении настроек",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetDeadlineSettings(gomock.Any()).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := delivery_settings_get.New(m.MockhandlerLogger, m.MockService)
+			req := httptest.NewRequest(http.MethodGet, "/admin/delivery-settings", http.NoBody)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package delivery_settings_put
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"service/internal/entities"
+	"service/internal/generated/dto"
+	"service/internal/service/delivery_settings"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var settingsDTO dto.DeliverySettings
+	err := json.NewDecoder(r.Body).Decode(&settingsDTO)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	settingsEntity := entities.DeadlineSettings{
+		TransportSpeeds: make([]entities.TransportSpeed, len(settingsDTO.TransportSpeeds)),
+		PeakHours:       make([]entities.PeakHour, len(settingsDTO.PeakHours)),
+	}
+	for i, transportSpeed := range settingsDTO.TransportSpeeds {
+		settingsEntity.TransportSpeeds[i] = entities.TransportSpeed{
+			TransportType:    entities.CourierTransportType(transportSpeed.TransportType),
+			AverageSpeedKmh:  transportSpeed.AverageSpeedKmh,
+			HandlingOverhead: time.Duration(transportSpeed.HandlingOverheadSeconds) * time.Second,
+		}
+	}
+	for i, peakHour := range settingsDTO.PeakHours {
+		settingsEntity.PeakHours[i] = entities.PeakHour{
+			StartHour:  peakHour.StartHour,
+			EndHour:    peakHour.EndHour,
+			Multiplier: peakHour.Multiplier,
+		}
+	}
+
+	res, err := h.service.UpdateDeadlineSettings(r.Context(), settingsEntity)
+	if err != nil {
+		switch {
+		case errors.Is(err, delivery_settings.ErrInvalidTransport),
+			errors.Is(err, delivery_settings.ErrDuplicateTransport),
+			errors.Is(err, delivery_settings.ErrInvalidSpeed),
+			errors.Is(err, delivery_settings.ErrInvalidHandlingOverhead),
+			errors.Is(err, delivery_settings.ErrInvalidPeakHours),
+			errors.Is(err, delivery_settings.ErrInvalidPeakMultiplier):
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := dto.DeliverySettings{
+		TransportSpeeds: make([]dto.TransportSpeed, len(res.TransportSpeeds)),
+		PeakHours:       make([]dto.PeakHour, len(res.PeakHours)),
+	}
+	for i, transportSpeed := range res.TransportSpeeds {
+		response.TransportSpeeds[i].TransportType = transportSpeed.TransportType.String()
+		response.TransportSpeeds[i].AverageSpeedKmh = transportSpeed.AverageSpeedKmh
+		response.TransportSpeeds[i].HandlingOverheadSeconds = int32(transportSpeed.HandlingOverhead / time.Second)
+	}
+	for i, peakHour := range res.PeakHours {
+		response.PeakHours[i].StartHour = peakHour.StartHour
+		response.PeakHours[i].EndHour = peakHour.EndHour
+		response.PeakHours[i].Multiplier = peakHour.Multiplier
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
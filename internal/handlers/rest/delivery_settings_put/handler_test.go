@@ -0,0 +1,172 @@
+package delivery_settings_put_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/delivery_settings_put"
+	"service/internal/service/delivery_settings"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestDeliverySettingsPutHandler(t *testing.T) {
+	t.Parallel()
+
+	settings := entities.DeadlineSettings{
+		TransportSpeeds: []entities.TransportSpeed{
+			{TransportType: entities.Scooter, AverageSpeedKmh: 18, HandlingOverhead: 4 * time.Minute},
+		},
+		PeakHours: []entities.PeakHour{
+			{StartHour: 8, EndHour: 10, Multiplier: 1.3},
+		},
+	}
+
+	validBody := `{
+		"transport_speeds": [
+			{"transport_type": "scooter", "average_speed_kmh": 18, "handling_overhead_seconds": 240}
+		],
+		"peak_hours": [
+			{"start_hour": 8, "end_hour": 10, "multiplier": 1.3}
+		]
+	}`
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name:        "Успешное обновление настроек дедлайнов",
+			requestBody: validBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateDeadlineSettings(gomock.Any(), settings).
+					Return(&settings, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"transport_speeds": []map[string]interface{}{
+					{
+						"transport_type":            "scooter",
+						"average_speed_kmh":         float64(18),
+						"handling_overhead_seconds": float64(240),
+					},
+				},
+				"peak_hours": []map[string]interface{}{
+					{
+						"start_hour": float64(8),
+						"end_hour":   float64(10),
+						"multiplier": 1.3,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Невалидный JSON в теле запроса",
+			requestBody:    "invalid json",
+			mockSetup:      nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+			wantErr:        true,
+		},
+		{
+			name:        "Ошибка валидации скорости",
+			requestBody: validBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateDeadlineSettings(gomock.Any(), gomock.Any()).
+					Return(nil, delivery_settings.ErrInvalidSpeed)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+			wantErr:        true,
+		},
+		{
+			name:        "Ошибка валидации часов пик",
+			requestBody: validBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateDeadlineSettings(gomock.Any(), gomock.Any()).
+					Return(nil, delivery_settings.ErrInvalidPeakHours)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+			wantErr:        true,
+		},
+		{
+			name:        "Ошибка сервиса при обновлении настроек",
+			requestBody: validBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateDeadlineSettings(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database connection error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := delivery_settings_put.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/delivery-settings", bytes.NewReader([]byte(tt.requestBody)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
@@ -38,8 +38,7 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		switch {
 		case errors.Is(err, delivery.ErrInvalidOrderID),
-			errors.Is(err, delivery.ErrMissingRequiredFields),
-			errors.Is(err, delivery.ErrCourierHasActiveDeliveries):
+			errors.Is(err, delivery.ErrMissingRequiredFields):
 			w.WriteHeader(http.StatusBadRequest)
 		case errors.Is(err, delivery.ErrDeliveryNotFound):
 			w.WriteHeader(http.StatusNotFound)
@@ -0,0 +1,13 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=livez_get_test
+package livez_get
+
+import (
+	"service/pkg/logger"
+)
+
+type handlerLogger interface {
+	Info(msg string, fields ...logger.Field)
+	Warn(msg string, fields ...logger.Field)
+	Error(msg string, fields ...logger.Field)
+	With(fields ...logger.Field) logger.Logger
+}
@@ -0,0 +1,37 @@
+package livez_get
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"service/internal/generated/dto"
+	"service/pkg/logger"
+)
+
+// Handler liveness не проверяет зависимости: недоступность Postgres не лечится перезапуском пода
+type Handler struct {
+	log handlerLogger
+}
+
+func New(log handlerLogger) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log: handlerLog,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	res := dto.HealthReport{
+		Status: dto.HealthReportStatusUp,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err := json.NewEncoder(w).Encode(res)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
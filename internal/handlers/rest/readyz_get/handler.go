@@ -0,0 +1,78 @@
+package readyz_get
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+
+	"service/internal/generated/dto"
+	"service/pkg/health"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log            handlerLogger
+	isShuttingDown *atomic.Bool
+	checker        Checker
+}
+
+func New(log handlerLogger, isShuttingDown *atomic.Bool, checker Checker) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:            handlerLog,
+		isShuttingDown: isShuttingDown,
+		checker:        checker,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// при остановке зависимости не проверяем: под должен уйти из балансировки сразу
+	if h.isShuttingDown.Load() {
+		h.writeReport(w, http.StatusServiceUnavailable, dto.HealthReport{
+			Status: dto.HealthReportStatusDown,
+		})
+		return
+	}
+
+	report := h.checker.Check(r.Context())
+
+	checks := make(map[string]dto.HealthCheck, len(report.Checks))
+	for _, result := range report.Checks {
+		check := dto.HealthCheck{
+			Status:     dto.HealthCheckStatus(result.Status),
+			DurationMs: result.Duration.Milliseconds(),
+			CheckedAt:  result.CheckedAt,
+		}
+		if result.Error != "" {
+			check.Error = &result.Error
+		}
+		checks[result.Name] = check
+	}
+
+	res := dto.HealthReport{
+		Status: dto.HealthReportStatus(report.Status),
+		Checks: &checks,
+	}
+
+	if report.Status != health.StatusUp {
+		h.log.With(
+			logger.NewField("checks", report.Checks),
+		).Warn("readiness check failed")
+		h.writeReport(w, http.StatusServiceUnavailable, res)
+		return
+	}
+
+	h.writeReport(w, http.StatusOK, res)
+}
+
+func (h *Handler) writeReport(w http.ResponseWriter, status int, res dto.HealthReport) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(res)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
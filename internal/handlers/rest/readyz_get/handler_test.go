@@ -0,0 +1,129 @@
+package readyz_get_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/handlers/rest/readyz_get"
+	"service/pkg/health"
+)
+
+type mock struct {
+	*MockChecker
+	*MockhandlerLogger
+	isShuttingDown atomic.Bool
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockChecker:       NewMockChecker(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestReadyzGetHandler(t *testing.T) {
+	t.Parallel()
+
+	checkedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	checkedAtStr := checkedAt.Format(time.RFC3339)
+
+	tests := []struct {
+		name           string
+		isShuttingDown bool
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{
+			name: "Все зависимости доступны, возвращает 200",
+			mockSetup: func(m *mock) {
+				m.MockChecker.EXPECT().
+					Check(gomock.Any()).
+					Return(health.Report{
+						Status: health.StatusUp,
+						Checks: []health.CheckResult{
+							{Name: "postgres", Status: health.StatusUp, Duration: 3 * time.Millisecond, CheckedAt: checkedAt},
+							{Name: "order-service", Status: health.StatusUp, Duration: time.Millisecond, CheckedAt: checkedAt},
+						},
+					})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"status": "up",
+				"checks": map[string]interface{}{
+					"postgres":      map[string]interface{}{"status": "up", "duration_ms": 3, "checked_at": checkedAtStr},
+					"order-service": map[string]interface{}{"status": "up", "duration_ms": 1, "checked_at": checkedAtStr},
+				},
+			},
+		},
+		{
+			name: "Недоступная зависимость, возвращает 503 с ошибкой проверки",
+			mockSetup: func(m *mock) {
+				m.MockChecker.EXPECT().
+					Check(gomock.Any()).
+					Return(health.Report{
+						Status: health.StatusDown,
+						Checks: []health.CheckResult{
+							{Name: "postgres", Status: health.StatusUp, Duration: 3 * time.Millisecond, CheckedAt: checkedAt},
+							{Name: "kafka", Status: health.StatusDown, Error: "check timed out", Duration: time.Second, CheckedAt: checkedAt},
+						},
+					})
+				m.MockhandlerLogger.EXPECT().Warn("readiness check failed", gomock.Any())
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: map[string]interface{}{
+				"status": "down",
+				"checks": map[string]interface{}{
+					"postgres": map[string]interface{}{"status": "up", "duration_ms": 3, "checked_at": checkedAtStr},
+					"kafka":    map[string]interface{}{"status": "down", "error": "check timed out", "duration_ms": 1000, "checked_at": checkedAtStr},
+				},
+			},
+		},
+		{
+			name:           "Сервис останавливается, возвращает 503 без проверки зависимостей",
+			isShuttingDown: true,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: map[string]interface{}{
+				"status": "down",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			m.isShuttingDown.Store(tt.isShuttingDown)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := readyz_get.New(m.MockhandlerLogger, &m.isShuttingDown, m.MockChecker)
+			req := httptest.NewRequest(http.MethodGet, "/readyz", http.NoBody)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			expectedJSON, err := json.Marshal(tt.expectedBody)
+			require.NoError(t, err, "failed to marshal expected body")
+			assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package zone_courier_delete
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"service/internal/service/zone"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["id"]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	courierIDStr := mux.Vars(r)["courier_id"]
+	courierID, err := strconv.ParseInt(courierIDStr, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.RemoveCourierFromZone(r.Context(), id, courierID)
+	if err != nil {
+		switch {
+		case errors.Is(err, zone.ErrInvalidZoneID),
+			errors.Is(err, zone.ErrInvalidCourierID):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, zone.ErrCourierNotInZone):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
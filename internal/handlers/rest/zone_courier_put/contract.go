@@ -0,0 +1,19 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=zone_courier_put_test
+package zone_courier_put
+
+import (
+	"context"
+
+	"service/pkg/logger"
+)
+
+type handlerLogger interface {
+	Info(msg string, fields ...logger.Field)
+	Warn(msg string, fields ...logger.Field)
+	Error(msg string, fields ...logger.Field)
+	With(fields ...logger.Field) logger.Logger
+}
+
+type Service interface {
+	AddCourierToZone(ctx context.Context, zoneID, courierID int64) error
+}
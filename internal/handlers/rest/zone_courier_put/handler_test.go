@@ -0,0 +1,148 @@
+package zone_courier_put_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"service/internal/handlers/rest/zone_courier_put"
+	"service/internal/service/zone"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestZoneCourierPutHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		zoneID         string
+		courierID      string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		wantErr        bool
+	}{
+		{
+			name:      "Успешное добавление курьера в зону",
+			zoneID:    "1",
+			courierID: "3",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					AddCourierToZone(gomock.Any(), int64(1), int64(3)).
+					Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "Невалидный ID зоны",
+			zoneID:         "abc",
+			courierID:      "3",
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:           "Невалидный ID курьера",
+			zoneID:         "1",
+			courierID:      "abc",
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:      "Отклонение сервисом невалидного ID курьера",
+			zoneID:    "1",
+			courierID: "0",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					AddCourierToZone(gomock.Any(), int64(1), int64(0)).
+					Return(zone.ErrInvalidCourierID)
+			},
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:      "Зона не найдена",
+			zoneID:    "2",
+			courierID: "3",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					AddCourierToZone(gomock.Any(), int64(2), int64(3)).
+					Return(zone.ErrZoneNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:      "Курьер не найден",
+			zoneID:    "1",
+			courierID: "99",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					AddCourierToZone(gomock.Any(), int64(1), int64(99)).
+					Return(zone.ErrCourierNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:      "Внутренняя ошибка сервиса",
+			zoneID:    "1",
+			courierID: "3",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					AddCourierToZone(gomock.Any(), int64(1), int64(3)).
+					Return(errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := zone_courier_put.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodPut, "/zone/"+tt.zoneID+"/couriers/"+tt.courierID, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.zoneID, "courier_id": tt.courierID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			assert.Empty(t, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,19 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=zone_couriers_get_test
+package zone_couriers_get
+
+import (
+	"context"
+
+	"service/pkg/logger"
+)
+
+type handlerLogger interface {
+	Info(msg string, fields ...logger.Field)
+	Warn(msg string, fields ...logger.Field)
+	Error(msg string, fields ...logger.Field)
+	With(fields ...logger.Field) logger.Logger
+}
+
+type Service interface {
+	GetZoneCourierIDs(ctx context.Context, zoneID int64) ([]int64, error)
+}
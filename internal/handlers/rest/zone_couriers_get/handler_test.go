@@ -0,0 +1,125 @@
+package zone_couriers_get_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"service/internal/handlers/rest/zone_couriers_get"
+	"service/internal/service/zone"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestZoneCouriersGetHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		zoneID         string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   string
+		wantErr        bool
+	}{
+		{
+			name:   "Успешное получение состава зоны",
+			zoneID: "1",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetZoneCourierIDs(gomock.Any(), int64(1)).
+					Return([]int64{3, 5}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"courier_IDs": [3, 5]}`,
+		},
+		{
+			name:   "В зоне нет курьеров",
+			zoneID: "1",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetZoneCourierIDs(gomock.Any(), int64(1)).
+					Return([]int64{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"courier_IDs": []}`,
+		},
+		{
+			name:           "Невалидный ID зоны",
+			zoneID:         "abc",
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:   "Зона не найдена",
+			zoneID: "2",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetZoneCourierIDs(gomock.Any(), int64(2)).
+					Return(nil, zone.ErrZoneNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:   "Внутренняя ошибка сервиса",
+			zoneID: "1",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetZoneCourierIDs(gomock.Any(), int64(1)).
+					Return(nil, errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := zone_couriers_get.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/zone/"+tt.zoneID+"/couriers", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.zoneID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			assert.JSONEq(t, tt.expectedBody, w.Body.String(), "unexpected response body")
+		})
+	}
+}
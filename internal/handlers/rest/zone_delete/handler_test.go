@@ -0,0 +1,112 @@
+package zone_delete_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"service/internal/handlers/rest/zone_delete"
+	"service/internal/service/zone"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestZoneDeleteHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		zoneID         string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		wantErr        bool
+	}{
+		{
+			name:   "Успешное удаление зоны",
+			zoneID: "1",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeleteZone(gomock.Any(), int64(1)).
+					Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "Невалидный ID зоны",
+			zoneID:         "abc",
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:   "Зона не найдена",
+			zoneID: "2",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeleteZone(gomock.Any(), int64(2)).
+					Return(zone.ErrZoneNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:   "Внутренняя ошибка сервиса",
+			zoneID: "1",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					DeleteZone(gomock.Any(), int64(1)).
+					Return(errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := zone_delete.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/zone/"+tt.zoneID, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.zoneID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			assert.Empty(t, w.Body.String())
+		})
+	}
+}
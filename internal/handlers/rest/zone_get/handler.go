@@ -0,0 +1,74 @@
+package zone_get
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"service/internal/generated/dto"
+	"service/internal/service/zone"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["id"]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	zoneEntity, err := h.service.GetZone(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, zone.ErrInvalidZoneID):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, zone.ErrZoneNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	polygon := make([]dto.Location, len(zoneEntity.Polygon))
+	for i, location := range zoneEntity.Polygon {
+		polygon[i] = dto.Location{
+			Latitude:  location.Latitude,
+			Longitude: location.Longitude,
+		}
+	}
+
+	response := dto.Zone{
+		ID:        zoneEntity.ID,
+		Name:      zoneEntity.Name,
+		Polygon:   polygon,
+		CreatedAt: zoneEntity.CreatedAt,
+		UpdatedAt: zoneEntity.UpdatedAt,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
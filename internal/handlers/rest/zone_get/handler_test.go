@@ -0,0 +1,145 @@
+package zone_get_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/zone_get"
+	"service/internal/service/zone"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestZoneGetHandler(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	polygon := []entities.Location{
+		{Latitude: 55.7, Longitude: 37.5},
+		{Latitude: 55.7, Longitude: 37.7},
+		{Latitude: 55.8, Longitude: 37.6},
+	}
+
+	tests := []struct {
+		name           string
+		zoneID         string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name:   "Успешное получение зоны",
+			zoneID: "1",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetZone(gomock.Any(), int64(1)).
+					Return(&entities.Zone{
+						ID:        1,
+						Name:      "Центр",
+						Polygon:   polygon,
+						CreatedAt: fixedTime,
+						UpdatedAt: fixedTime,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"ID":   float64(1),
+				"name": "Центр",
+				"polygon": []interface{}{
+					map[string]interface{}{"latitude": 55.7, "longitude": 37.5},
+					map[string]interface{}{"latitude": 55.7, "longitude": 37.7},
+					map[string]interface{}{"latitude": 55.8, "longitude": 37.6},
+				},
+				"created_at": "2026-01-01T12:00:00Z",
+				"updated_at": "2026-01-01T12:00:00Z",
+			},
+		},
+		{
+			name:           "Невалидный ID зоны",
+			zoneID:         "abc",
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:   "Зона не найдена",
+			zoneID: "2",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetZone(gomock.Any(), int64(2)).
+					Return(nil, zone.ErrZoneNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:   "Внутренняя ошибка сервиса",
+			zoneID: "1",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetZone(gomock.Any(), int64(1)).
+					Return(nil, errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := zone_get.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/zone/"+tt.zoneID, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.zoneID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
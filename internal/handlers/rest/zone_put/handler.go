@@ -0,0 +1,98 @@
+package zone_put
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"service/internal/entities"
+	"service/internal/generated/dto"
+	"service/internal/service/zone"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["id"]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var zoneModifyDTO dto.ZoneModify
+	err = json.NewDecoder(r.Body).Decode(&zoneModifyDTO)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	zoneModifyEntity := entities.ZoneModify{
+		ID:      &id,
+		Name:    &zoneModifyDTO.Name,
+		Polygon: make([]entities.Location, len(zoneModifyDTO.Polygon)),
+	}
+	for i, location := range zoneModifyDTO.Polygon {
+		zoneModifyEntity.Polygon[i] = entities.Location{
+			Latitude:  location.Latitude,
+			Longitude: location.Longitude,
+		}
+	}
+
+	zoneEntity, err := h.service.UpdateZone(r.Context(), zoneModifyEntity)
+	if err != nil {
+		switch {
+		case errors.Is(err, zone.ErrInvalidZoneID),
+			errors.Is(err, zone.ErrInvalidZoneName),
+			errors.Is(err, zone.ErrInvalidPolygon):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, zone.ErrZoneNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, zone.ErrZoneConflict):
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	polygon := make([]dto.Location, len(zoneEntity.Polygon))
+	for i, location := range zoneEntity.Polygon {
+		polygon[i] = dto.Location{
+			Latitude:  location.Latitude,
+			Longitude: location.Longitude,
+		}
+	}
+
+	response := dto.Zone{
+		ID:        zoneEntity.ID,
+		Name:      zoneEntity.Name,
+		Polygon:   polygon,
+		CreatedAt: zoneEntity.CreatedAt,
+		UpdatedAt: zoneEntity.UpdatedAt,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
@@ -0,0 +1,172 @@
+package zone_put_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/zone_put"
+	"service/internal/service/zone"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestZonePutHandler(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	polygon := []entities.Location{
+		{Latitude: 55.7, Longitude: 37.5},
+		{Latitude: 55.7, Longitude: 37.7},
+		{Latitude: 55.8, Longitude: 37.6},
+	}
+	validBody := `{"name": "Центр", "polygon": [{"latitude": 55.7, "longitude": 37.5}, {"latitude": 55.7, "longitude": 37.7}, {"latitude": 55.8, "longitude": 37.6}]}`
+
+	tests := []struct {
+		name           string
+		zoneID         string
+		requestBody    string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		wantErr        bool
+	}{
+		{
+			name:        "Успешное обновление зоны",
+			zoneID:      "1",
+			requestBody: validBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateZone(gomock.Any(), entities.ZoneModify{ID: pointer.To(int64(1)), Name: pointer.To("Центр"), Polygon: polygon}).
+					Return(&entities.Zone{
+						ID:        1,
+						Name:      "Центр",
+						Polygon:   polygon,
+						CreatedAt: fixedTime,
+						UpdatedAt: fixedTime,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"ID":   float64(1),
+				"name": "Центр",
+				"polygon": []interface{}{
+					map[string]interface{}{"latitude": 55.7, "longitude": 37.5},
+					map[string]interface{}{"latitude": 55.7, "longitude": 37.7},
+					map[string]interface{}{"latitude": 55.8, "longitude": 37.6},
+				},
+				"created_at": "2026-01-01T12:00:00Z",
+				"updated_at": "2026-01-01T12:00:00Z",
+			},
+		},
+		{
+			name:           "Невалидный ID зоны",
+			zoneID:         "abc",
+			requestBody:    validBody,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:           "Невалидный JSON",
+			zoneID:         "1",
+			requestBody:    `[]`,
+			expectedStatus: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name:        "Зона не найдена",
+			zoneID:      "2",
+			requestBody: validBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateZone(gomock.Any(), gomock.Any()).
+					Return(nil, zone.ErrZoneNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name:        "Имя занято другой зоной",
+			zoneID:      "1",
+			requestBody: validBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateZone(gomock.Any(), gomock.Any()).
+					Return(nil, zone.ErrZoneConflict)
+			},
+			expectedStatus: http.StatusConflict,
+			wantErr:        true,
+		},
+		{
+			name:        "Внутренняя ошибка сервиса",
+			zoneID:      "1",
+			requestBody: validBody,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					UpdateZone(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := zone_put.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodPut, "/zone/"+tt.zoneID, strings.NewReader(tt.requestBody))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.zoneID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.expectedBody != nil {
+				expectedJSON, err := json.Marshal(tt.expectedBody)
+				require.NoError(t, err, "failed to marshal expected body")
+				assert.JSONEq(t, string(expectedJSON), w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
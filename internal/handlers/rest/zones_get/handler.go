@@ -0,0 +1,59 @@
+package zones_get
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"service/internal/generated/dto"
+	"service/pkg/logger"
+)
+
+type Handler struct {
+	log     handlerLogger
+	service Service
+}
+
+func New(log handlerLogger, service Service) *Handler {
+	handlerLog := log.With()
+
+	return &Handler{
+		log:     handlerLog,
+		service: service,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	zoneEntities, err := h.service.GetZones(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]dto.Zone, len(zoneEntities))
+	for i, zoneEntity := range zoneEntities {
+		polygon := make([]dto.Location, len(zoneEntity.Polygon))
+		for j, location := range zoneEntity.Polygon {
+			polygon[j] = dto.Location{
+				Latitude:  location.Latitude,
+				Longitude: location.Longitude,
+			}
+		}
+
+		response[i] = dto.Zone{
+			ID:        zoneEntity.ID,
+			Name:      zoneEntity.Name,
+			Polygon:   polygon,
+			CreatedAt: zoneEntity.CreatedAt,
+			UpdatedAt: zoneEntity.UpdatedAt,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.log.With(
+			logger.NewField("error", err),
+		).Error("encode JSON response")
+	}
+}
@@ -0,0 +1,127 @@
+package zones_get_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/handlers/rest/zones_get"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+func TestZonesGetHandler(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		expectedStatus int
+		expectedBody   string
+		wantErr        bool
+	}{
+		{
+			name: "Успешное получение списка зон",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetZones(gomock.Any()).
+					Return([]entities.Zone{
+						{
+							ID:   1,
+							Name: "Центр",
+							Polygon: []entities.Location{
+								{Latitude: 55.7, Longitude: 37.5},
+								{Latitude: 55.7, Longitude: 37.7},
+								{Latitude: 55.8, Longitude: 37.6},
+							},
+							CreatedAt: fixedTime,
+							UpdatedAt: fixedTime,
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `[{
+				"ID": 1,
+				"name": "Центр",
+				"polygon": [
+					{"latitude": 55.7, "longitude": 37.5},
+					{"latitude": 55.7, "longitude": 37.7},
+					{"latitude": 55.8, "longitude": 37.6}
+				],
+				"created_at": "2026-01-01T12:00:00Z",
+				"updated_at": "2026-01-01T12:00:00Z"
+			}]`,
+		},
+		{
+			name: "Пустой список зон",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetZones(gomock.Any()).
+					Return([]entities.Zone{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[]`,
+		},
+		{
+			name: "Внутренняя ошибка сервиса",
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					GetZones(gomock.Any()).
+					Return(nil, errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+
+			m.MockhandlerLogger.EXPECT().
+				With(gomock.Any()).
+				Return(m.MockhandlerLogger).
+				AnyTimes()
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			handler := zones_get.New(m.MockhandlerLogger, m.MockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/zones", http.NoBody)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			if tt.wantErr {
+				return
+			}
+
+			assert.JSONEq(t, tt.expectedBody, w.Body.String(), "unexpected response body")
+		})
+	}
+}
@@ -4,11 +4,12 @@ import (
 	"context"
 	"time"
 
+	"service/internal/entities"
 	"service/pkg/logger"
 )
 
 type Service interface {
-	CleanupExpiredDeliveries(ctx context.Context) (int64, error)
+	ProcessOverdueDeliveries(ctx context.Context) (*entities.OverdueProcessing, error)
 }
 
 type DeliveryCleanup struct {
@@ -33,15 +34,26 @@ func (d *DeliveryCleanup) Do(ctx context.Context) error {
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, d.interval)
 	defer cancel()
 
-	rowsAffected, err := d.service.CleanupExpiredDeliveries(ctxWithTimeout)
+	result, err := d.service.ProcessOverdueDeliveries(ctxWithTimeout)
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range result.Overdue {
+		d.log.With(
+			logger.NewField("order_id", delivery.OrderID),
+			logger.NewField("courier_id", delivery.CourierID),
+			logger.NewField("deadline", delivery.Deadline),
+		).Warn("delivery overdue, escalated")
+	}
 
-	if rowsAffected > 0 {
+	if result.ReleasedCouriers > 0 {
 		d.log.With(
-			logger.NewField("expired_couriers", rowsAffected),
+			logger.NewField("released_couriers", result.ReleasedCouriers),
 		).Info("delivery cleanup")
 	}
 
-	return err
+	return nil
 }
 
 func (d *DeliveryCleanup) Info() string {
@@ -0,0 +1,53 @@
+package delivery_eta
+
+import (
+	"context"
+	"time"
+
+	"service/internal/entities"
+	"service/pkg/logger"
+)
+
+type Service interface {
+	RefreshModel(ctx context.Context) (*entities.ETAModelRefresh, error)
+}
+
+type DeliveryETA struct {
+	log      logger.Logger
+	service  Service
+	interval time.Duration
+}
+
+func NewDeliveryETA(log logger.Logger, service Service, interval time.Duration) *DeliveryETA {
+	return &DeliveryETA{
+		log:      log,
+		service:  service,
+		interval: interval,
+	}
+}
+
+func (d *DeliveryETA) TTL() time.Duration {
+	return d.interval
+}
+
+func (d *DeliveryETA) Do(ctx context.Context) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, d.interval)
+	defer cancel()
+
+	result, err := d.service.RefreshModel(ctxWithTimeout)
+	if err != nil {
+		return err
+	}
+
+	if result.Refreshed {
+		d.log.With(
+			logger.NewField("quantiles", result.Quantiles),
+		).Info("delivery eta model refreshed")
+	}
+
+	return nil
+}
+
+func (d *DeliveryETA) Info() string {
+	return "delivery eta model"
+}
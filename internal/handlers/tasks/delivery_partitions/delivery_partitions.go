@@ -0,0 +1,55 @@
+package delivery_partitions
+
+import (
+	"context"
+	"time"
+
+	"service/internal/entities"
+	"service/pkg/logger"
+)
+
+type Service interface {
+	MaintainPartitions(ctx context.Context) (*entities.DeliveryPartitionMaintenance, error)
+}
+
+type DeliveryPartitions struct {
+	log      logger.Logger
+	service  Service
+	interval time.Duration
+}
+
+func NewDeliveryPartitions(log logger.Logger, service Service, interval time.Duration) *DeliveryPartitions {
+	return &DeliveryPartitions{
+		log:      log,
+		service:  service,
+		interval: interval,
+	}
+}
+
+func (d *DeliveryPartitions) TTL() time.Duration {
+	return d.interval
+}
+
+func (d *DeliveryPartitions) Do(ctx context.Context) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, d.interval)
+	defer cancel()
+
+	result, err := d.service.MaintainPartitions(ctxWithTimeout)
+	if err != nil {
+		return err
+	}
+
+	if len(result.Created) > 0 || len(result.Archived) > 0 {
+		d.log.With(
+			logger.NewField("created", result.Created),
+			logger.NewField("archived", result.Archived),
+			logger.NewField("archived_rows", result.ArchivedRows),
+		).Info("delivery partitions")
+	}
+
+	return nil
+}
+
+func (d *DeliveryPartitions) Info() string {
+	return "delivery partitions"
+}
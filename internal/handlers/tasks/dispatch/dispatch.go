@@ -0,0 +1,50 @@
+package dispatch
+
+import (
+	"context"
+	"time"
+
+	"service/pkg/logger"
+)
+
+type Service interface {
+	DispatchPendingDeliveries(ctx context.Context) (int64, error)
+}
+
+type Dispatch struct {
+	log      logger.Logger
+	service  Service
+	interval time.Duration
+}
+
+// NewDispatch создает задачу пакетного распределения заказов из очереди ожидания.
+// Пока пакетное распределение выключено, задача ничего не назначает.
+func NewDispatch(log logger.Logger, service Service, interval time.Duration) *Dispatch {
+	return &Dispatch{
+		log:      log,
+		service:  service,
+		interval: interval,
+	}
+}
+
+func (d *Dispatch) TTL() time.Duration {
+	return d.interval
+}
+
+func (d *Dispatch) Do(ctx context.Context) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, d.interval)
+	defer cancel()
+
+	assignedCount, err := d.service.DispatchPendingDeliveries(ctxWithTimeout)
+	if assignedCount > 0 {
+		d.log.With(
+			logger.NewField("assigned_orders", assignedCount),
+		).Info("delivery dispatch")
+	}
+
+	return err
+}
+
+func (d *Dispatch) Info() string {
+	return "delivery dispatch"
+}
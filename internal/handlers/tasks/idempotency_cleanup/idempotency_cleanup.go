@@ -0,0 +1,48 @@
+package idempotency_cleanup
+
+import (
+	"context"
+	"time"
+
+	"service/pkg/logger"
+)
+
+type Service interface {
+	CleanupExpiredKeys(ctx context.Context) (int64, error)
+}
+
+type IdempotencyCleanup struct {
+	log      logger.Logger
+	service  Service
+	interval time.Duration
+}
+
+func NewIdempotencyCleanup(log logger.Logger, service Service, interval time.Duration) *IdempotencyCleanup {
+	return &IdempotencyCleanup{
+		log:      log,
+		service:  service,
+		interval: interval,
+	}
+}
+
+func (c *IdempotencyCleanup) TTL() time.Duration {
+	return c.interval
+}
+
+func (c *IdempotencyCleanup) Do(ctx context.Context) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, c.interval)
+	defer cancel()
+
+	rowsAffected, err := c.service.CleanupExpiredKeys(ctxWithTimeout)
+	if rowsAffected > 0 {
+		c.log.With(
+			logger.NewField("expired_keys", rowsAffected),
+		).Info("idempotency keys cleanup")
+	}
+
+	return err
+}
+
+func (c *IdempotencyCleanup) Info() string {
+	return "idempotency keys cleanup"
+}
@@ -0,0 +1,48 @@
+package offer_expiration
+
+import (
+	"context"
+	"time"
+
+	"service/pkg/logger"
+)
+
+type Service interface {
+	ExpireOffers(ctx context.Context) (int64, error)
+}
+
+type OfferExpiration struct {
+	log      logger.Logger
+	service  Service
+	interval time.Duration
+}
+
+func NewOfferExpiration(log logger.Logger, service Service, interval time.Duration) *OfferExpiration {
+	return &OfferExpiration{
+		log:      log,
+		service:  service,
+		interval: interval,
+	}
+}
+
+func (e *OfferExpiration) TTL() time.Duration {
+	return e.interval
+}
+
+func (e *OfferExpiration) Do(ctx context.Context) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, e.interval)
+	defer cancel()
+
+	expiredCount, err := e.service.ExpireOffers(ctxWithTimeout)
+	if expiredCount > 0 {
+		e.log.With(
+			logger.NewField("expired_offers", expiredCount),
+		).Info("delivery offers expiration")
+	}
+
+	return err
+}
+
+func (e *OfferExpiration) Info() string {
+	return "delivery offers expiration"
+}
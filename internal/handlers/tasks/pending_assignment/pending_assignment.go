@@ -0,0 +1,57 @@
+package pending_assignment
+
+import (
+	"context"
+	"time"
+
+	"service/pkg/logger"
+)
+
+type Service interface {
+	AssignPendingDeliveries(ctx context.Context) (int64, error)
+}
+
+type PendingAssignment struct {
+	log      logger.Logger
+	service  Service
+	interval time.Duration
+	trigger  <-chan struct{}
+}
+
+// NewPendingAssignment создает задачу разбора очереди ожидания.
+// Помимо запуска по интервалу задача запускается сразу по сигналу trigger, когда освобождается курьер.
+func NewPendingAssignment(log logger.Logger, service Service, interval time.Duration, trigger <-chan struct{}) *PendingAssignment {
+	return &PendingAssignment{
+		log:      log,
+		service:  service,
+		interval: interval,
+		trigger:  trigger,
+	}
+}
+
+func (p *PendingAssignment) TTL() time.Duration {
+	return p.interval
+}
+
+func (p *PendingAssignment) Trigger() <-chan struct{} {
+	return p.trigger
+}
+
+func (p *PendingAssignment) Do(ctx context.Context) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, p.interval)
+	defer cancel()
+
+	assignedCount, err := p.service.AssignPendingDeliveries(ctxWithTimeout)
+
+	if assignedCount > 0 {
+		p.log.With(
+			logger.NewField("assigned_orders", assignedCount),
+		).Info("pending assignment")
+	}
+
+	return err
+}
+
+func (p *PendingAssignment) Info() string {
+	return "pending assignment"
+}
@@ -0,0 +1,41 @@
+package pool_metrics
+
+import (
+	"context"
+	"time"
+
+	"service/pkg/logger"
+)
+
+type Service interface {
+	RefreshPoolMetrics(ctx context.Context) error
+}
+
+type PoolMetrics struct {
+	log      logger.Logger
+	service  Service
+	interval time.Duration
+}
+
+func NewPoolMetrics(log logger.Logger, service Service, interval time.Duration) *PoolMetrics {
+	return &PoolMetrics{
+		log:      log,
+		service:  service,
+		interval: interval,
+	}
+}
+
+func (p *PoolMetrics) TTL() time.Duration {
+	return p.interval
+}
+
+func (p *PoolMetrics) Do(ctx context.Context) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, p.interval)
+	defer cancel()
+
+	return p.service.RefreshPoolMetrics(ctxWithTimeout)
+}
+
+func (p *PoolMetrics) Info() string {
+	return "courier pool metrics refresh"
+}
@@ -0,0 +1,48 @@
+package scheduled_delivery
+
+import (
+	"context"
+	"time"
+
+	"service/pkg/logger"
+)
+
+type Service interface {
+	AssignDueScheduledDeliveries(ctx context.Context) (int64, error)
+}
+
+type ScheduledDelivery struct {
+	log      logger.Logger
+	service  Service
+	interval time.Duration
+}
+
+func NewScheduledDelivery(log logger.Logger, service Service, interval time.Duration) *ScheduledDelivery {
+	return &ScheduledDelivery{
+		log:      log,
+		service:  service,
+		interval: interval,
+	}
+}
+
+func (s *ScheduledDelivery) TTL() time.Duration {
+	return s.interval
+}
+
+func (s *ScheduledDelivery) Do(ctx context.Context) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, s.interval)
+	defer cancel()
+
+	assignedCount, err := s.service.AssignDueScheduledDeliveries(ctxWithTimeout)
+	if assignedCount > 0 {
+		s.log.With(
+			logger.NewField("assigned_deliveries", assignedCount),
+		).Info("scheduled deliveries assignment")
+	}
+
+	return err
+}
+
+func (s *ScheduledDelivery) Info() string {
+	return "scheduled deliveries assignment"
+}
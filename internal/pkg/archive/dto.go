@@ -0,0 +1,41 @@
+package archive
+
+import "time"
+
+type deliveryRecord struct {
+	ID                int64          `json:"id"`
+	CourierID         int64          `json:"courier_id"`
+	OrderID           string         `json:"order_id"`
+	RestaurantID      string         `json:"restaurant_id,omitempty"`
+	Address           *addressRecord `json:"address,omitempty"`
+	EstimatedDelivery *time.Time     `json:"estimated_delivery,omitempty"`
+	CreatedAt         time.Time      `json:"created_at"`
+	AssignedAt        time.Time      `json:"assigned_at"`
+	Deadline          time.Time      `json:"deadline"`
+	OverdueAt         *time.Time     `json:"overdue_at,omitempty"`
+	CourierReleasedAt *time.Time     `json:"courier_released_at,omitempty"`
+	CompletedAt       *time.Time     `json:"completed_at,omitempty"`
+	Route             *routeRecord   `json:"route,omitempty"`
+	Priority          string         `json:"priority,omitempty"`
+	RequiredSkills    []string       `json:"required_skills,omitempty"`
+	TransportTypes    []string       `json:"allowed_transport_types,omitempty"`
+	CashAmount        int64          `json:"cash_amount,omitempty"`
+}
+
+type routeRecord struct {
+	Pickup  locationRecord `json:"pickup"`
+	Dropoff locationRecord `json:"dropoff"`
+}
+
+type locationRecord struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type addressRecord struct {
+	Street    string `json:"street"`
+	House     string `json:"house"`
+	Apartment string `json:"apartment,omitempty"`
+	Floor     string `json:"floor,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+}
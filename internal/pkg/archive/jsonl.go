@@ -0,0 +1,135 @@
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"service/internal/entities"
+)
+
+const archiveFileExt = ".jsonl.gz"
+
+// JSONLStorage пишет архив партиции в <dir>/<name>.jsonl.gz, по доставке на строку.
+// Файл собирается во временном и переименовывается только после успешной записи,
+// поэтому недописанный архив не может оказаться на месте готового
+type JSONLStorage struct {
+	dir string
+}
+
+func NewJSONLStorage(dir string) *JSONLStorage {
+	return &JSONLStorage{
+		dir: dir,
+	}
+}
+
+func (s *JSONLStorage) Store(ctx context.Context, name string, write func(add func(delivery entities.Delivery) error) error) error {
+	err := os.MkdirAll(s.dir, 0o750)
+	if err != nil {
+		return fmt.Errorf("create archive dir: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(s.dir, name+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create archive file: %w", err)
+	}
+
+	err = writeArchive(ctx, tmpFile, write)
+	if err != nil {
+		removeErr := os.Remove(tmpFile.Name())
+		return errors.Join(err, removeErr)
+	}
+
+	err = os.Rename(tmpFile.Name(), filepath.Join(s.dir, name+archiveFileExt))
+	if err != nil {
+		removeErr := os.Remove(tmpFile.Name())
+		return errors.Join(fmt.Errorf("rename archive file: %w", err), removeErr)
+	}
+
+	return nil
+}
+
+func writeArchive(ctx context.Context, file *os.File, write func(add func(delivery entities.Delivery) error) error) error {
+	buffered := bufio.NewWriter(file)
+	gzipWriter := gzip.NewWriter(buffered)
+	encoder := json.NewEncoder(gzipWriter)
+
+	err := write(func(delivery entities.Delivery) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return encoder.Encode(toDeliveryRecord(delivery))
+	})
+	if err != nil {
+		return errors.Join(err, file.Close())
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		return errors.Join(fmt.Errorf("close gzip writer: %w", err), file.Close())
+	}
+
+	err = buffered.Flush()
+	if err != nil {
+		return errors.Join(fmt.Errorf("flush archive file: %w", err), file.Close())
+	}
+
+	// партиция удаляется сразу после записи, поэтому архив должен лежать на диске, а не в page cache
+	err = file.Sync()
+	if err != nil {
+		return errors.Join(fmt.Errorf("sync archive file: %w", err), file.Close())
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("close archive file: %w", err)
+	}
+
+	return nil
+}
+
+func toDeliveryRecord(delivery entities.Delivery) deliveryRecord {
+	record := deliveryRecord{
+		ID:                delivery.ID,
+		CourierID:         delivery.CourierID,
+		OrderID:           delivery.OrderID,
+		RestaurantID:      delivery.RestaurantID,
+		EstimatedDelivery: delivery.EstimatedDelivery,
+		CreatedAt:         delivery.CreatedAt,
+		AssignedAt:        delivery.AssignedAt,
+		Deadline:          delivery.Deadline,
+		OverdueAt:         delivery.OverdueAt,
+		CourierReleasedAt: delivery.CourierReleasedAt,
+		CompletedAt:       delivery.CompletedAt,
+		Priority:          string(delivery.Priority),
+		CashAmount:        delivery.CashAmount,
+	}
+	if delivery.Route != nil {
+		record.Route = &routeRecord{
+			Pickup:  locationRecord{Latitude: delivery.Route.Pickup.Latitude, Longitude: delivery.Route.Pickup.Longitude},
+			Dropoff: locationRecord{Latitude: delivery.Route.Dropoff.Latitude, Longitude: delivery.Route.Dropoff.Longitude},
+		}
+	}
+	for _, skill := range delivery.Requirements.Skills {
+		record.RequiredSkills = append(record.RequiredSkills, skill.String())
+	}
+	for _, transportType := range delivery.Requirements.TransportTypes {
+		record.TransportTypes = append(record.TransportTypes, transportType.String())
+	}
+	if delivery.Address != nil {
+		record.Address = &addressRecord{
+			Street:    delivery.Address.Street,
+			House:     delivery.Address.House,
+			Apartment: delivery.Address.Apartment,
+			Floor:     delivery.Address.Floor,
+			Comment:   delivery.Address.Comment,
+		}
+	}
+
+	return record
+}
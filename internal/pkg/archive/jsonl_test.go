@@ -0,0 +1,158 @@
+package archive_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"service/internal/entities"
+	"service/internal/pkg/archive"
+)
+
+func readArchive(t *testing.T, path string) []string {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	require.NoError(t, err)
+	defer gzipReader.Close()
+
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(gzipReader)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.NoError(t, scanner.Err())
+
+	return lines
+}
+
+func TestJSONLStorage_Store(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC)
+	completedAt := fixedTime.Add(25 * time.Minute)
+	deliveries := []entities.Delivery{
+		{
+			ID:           1,
+			CourierID:    7,
+			OrderID:      "order-1",
+			RestaurantID: "restaurant-7",
+			Address:      &entities.Address{Street: "Тверская", House: "1"},
+			CreatedAt:    fixedTime,
+			AssignedAt:   fixedTime.Add(time.Minute),
+			Deadline:     fixedTime.Add(30 * time.Minute),
+		},
+		{
+			ID:         2,
+			CourierID:  8,
+			OrderID:    "order-2",
+			CreatedAt:  fixedTime,
+			AssignedAt: fixedTime.Add(time.Minute),
+			Deadline:   fixedTime.Add(30 * time.Minute),
+		},
+		{
+			ID:          3,
+			CourierID:   9,
+			OrderID:     "order-3",
+			CreatedAt:   fixedTime,
+			AssignedAt:  fixedTime.Add(time.Minute),
+			Deadline:    fixedTime.Add(30 * time.Minute),
+			CompletedAt: &completedAt,
+			Route: &entities.Route{
+				Pickup:  entities.Location{Latitude: 55.75, Longitude: 37.61},
+				Dropoff: entities.Location{Latitude: 55.76, Longitude: 37.62},
+			},
+			Priority: entities.PriorityHigh,
+			Requirements: entities.OrderRequirements{
+				Skills:         []entities.CourierSkill{entities.SkillThermalBag},
+				TransportTypes: []entities.CourierTransportType{entities.Car},
+			},
+			CashAmount: 150000,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		write          func(add func(delivery entities.Delivery) error) error
+		expectedLines  []string
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Доставки записываются в gzip JSONL по одной на строку",
+			write: func(add func(delivery entities.Delivery) error) error {
+				for _, delivery := range deliveries {
+					err := add(delivery)
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			expectedLines: []string{
+				`{"id":1,"courier_id":7,"order_id":"order-1","restaurant_id":"restaurant-7","address":{"street":"Тверская","house":"1"},"created_at":"2025-01-15T11:00:00Z","assigned_at":"2025-01-15T11:01:00Z","deadline":"2025-01-15T11:30:00Z"}`,
+				`{"id":2,"courier_id":8,"order_id":"order-2","created_at":"2025-01-15T11:00:00Z","assigned_at":"2025-01-15T11:01:00Z","deadline":"2025-01-15T11:30:00Z"}`,
+				`{"id":3,"courier_id":9,"order_id":"order-3","created_at":"2025-01-15T11:00:00Z","assigned_at":"2025-01-15T11:01:00Z","deadline":"2025-01-15T11:30:00Z","completed_at":"2025-01-15T11:25:00Z","route":{"pickup":{"latitude":55.75,"longitude":37.61},"dropoff":{"latitude":55.76,"longitude":37.62}},"priority":"high","required_skills":["thermal_bag"],"allowed_transport_types":["car"],"cash_amount":150000}`,
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Пустая партиция дает пустой архив",
+			write: func(add func(delivery entities.Delivery) error) error {
+				return nil
+			},
+			expectedLines:  []string{},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Ошибка чтения партиции не оставляет файлов в каталоге",
+			write: func(add func(delivery entities.Delivery) error) error {
+				err := add(deliveries[0])
+				if err != nil {
+					return err
+				}
+				return errors.New("conn closed")
+			},
+			errorAssertion: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := filepath.Join(t.TempDir(), "archive")
+			storage := archive.NewJSONLStorage(dir)
+
+			err := storage.Store(context.Background(), "delivery_y2025m01", tt.write)
+			tt.errorAssertion(t, err, tt.name)
+
+			if tt.expectedLines == nil {
+				entries, err := os.ReadDir(dir)
+				require.NoError(t, err)
+				assert.Empty(t, entries)
+				return
+			}
+
+			lines := readArchive(t, filepath.Join(dir, "delivery_y2025m01.jsonl.gz"))
+			assert.Equal(t, len(tt.expectedLines), len(lines))
+			for i := range tt.expectedLines {
+				assert.JSONEq(t, tt.expectedLines[i], lines[i])
+			}
+
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Len(t, entries, 1, "temporary file must be renamed")
+		})
+	}
+}
@@ -5,22 +5,32 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 type (
 	Tasks struct {
-		CouriersStatusUpdateInterval time.Duration
-		OrdersAssingProcessInterval  time.Duration
+		CouriersStatusUpdateInterval   time.Duration
+		OrdersAssingProcessInterval    time.Duration
+		PendingAssignmentsInterval     time.Duration
+		IdempotencyKeysCleanupInterval time.Duration
+		PoolMetricsRefreshInterval     time.Duration
+		DeliveryPartitionsInterval     time.Duration
+		OfferExpirationInterval        time.Duration
+		DispatchInterval               time.Duration
+		ScheduledDeliveriesInterval    time.Duration
+		DeliveryETAInterval            time.Duration
 	}
 
 	HTTPServer struct {
-		Port             string
-		RequestTimeout   time.Duration // middleware timeout
-		RateLimiterQPS   int           // middleware  rate limiter capacity
-		RateLimiterBurst int           // middlewarerate limiter burst/refill
-		PprofEnabled     bool
-		PprofPort        string
+		Port              string
+		RequestTimeout    time.Duration // middleware timeout
+		RateLimiterQPS    int           // middleware  rate limiter capacity
+		RateLimiterBurst  int           // middlewarerate limiter burst/refill
+		PprofEnabled      bool
+		PprofPort         string
+		IdempotencyKeyTTL time.Duration // middleware idempotency: сколько хранится ответ по ключу
 	}
 
 	Database struct {
@@ -41,6 +51,7 @@ type (
 		Brokers         string
 		Topic           string
 		ConsumerGroup   string
+		OverdueTopic    string // топик эскалаций просроченных доставок
 		Sarama          Sarama
 		Handlers        KafkaHandlers
 	}
@@ -58,12 +69,128 @@ type (
 		ProcessTimeout time.Duration
 	}
 
+	// Healthcheck таймауты проверок /readyz задаются по отдельности:
+	// metadata запрос в Kafka заметно дольше ping в Postgres
+	Healthcheck struct {
+		CacheTTL            time.Duration
+		PostgresTimeout     time.Duration
+		OrderServiceTimeout time.Duration
+		KafkaTimeout        time.Duration
+	}
+
+	// Overdue политика для курьеров просроченных доставок:
+	// без AutoRelease курьер остается занятым, пока заказ не снимут вручную или по событию order-service
+	Overdue struct {
+		AutoRelease        bool
+		ReleaseGracePeriod time.Duration
+	}
+
+	// DeliveryPartitions обслуживание месячных партиций delivery:
+	// ArchiveMode "table" переносит старые партиции в delivery_archive, "jsonl" выгружает их в ArchiveDir
+	DeliveryPartitions struct {
+		PremakeMonths   int
+		RetentionMonths int
+		ArchiveMode     string
+		ArchiveDir      string
+	}
+
+	// Phone регион для номеров без кода страны
+	Phone struct {
+		DefaultRegion string
+	}
+
+	// Zones подбор курьера по зонам обслуживания: без CrossZoneFallback заказ ждет курьера своей зоны в очереди,
+	// с ним при отсутствии свободных в зоне получает любого свободного курьера
+	Zones struct {
+		CrossZoneFallback bool
+	}
+
+	// OrderRequirements правила, по которым из заказа order-service выводятся требования к курьеру.
+	// Ключевые слова ищутся в названиях позиций без учета регистра, нулевой порог отключает правило
+	OrderRequirements struct {
+		AgeRestrictedKeywords []string
+		ThermalKeywords       []string
+		LargeOrderItems       int
+		LargeOrderTotalPrice  int
+	}
+
+	// OrderPriority правила, по которым заказ order-service становится приоритетным: срочные позиции
+	// по ключевым словам или сумма от HighTotalPrice. Приоритетный заказ без свободного курьера забирает курьера
+	// у обычного заказа, назначенного не раньше PreemptWithin назад. Нулевые порог и PreemptWithin отключают правила
+	OrderPriority struct {
+		UrgentKeywords []string
+		HighTotalPrice int
+		PreemptWithin  time.Duration
+	}
+
+	// Offers заказ сначала предлагается курьеру и назначается только после принятия.
+	// Предложение без ответа дольше Timeout закрывается, и заказ предлагается следующему курьеру
+	// Курьеру, который отказался от заказа или пропустил предложение, заказ снова предлагается через DeclineCooldown
+	Offers struct {
+		Enabled         bool
+		Timeout         time.Duration
+		DeclineCooldown time.Duration
+	}
+
+	// Batching новый заказ ресторана ждет Window, чтобы уехать одному курьеру вместе с другими
+	// заказами ресторана, но не больше MaxOrders за раз. Нулевое окно отключает группировку
+	Batching struct {
+		Window        time.Duration
+		MaxOrders     int
+		ExtraStopTime time.Duration
+	}
+
+	// Dispatch пакетное распределение: заказы копятся в очереди ожидания, и раз в интервал задачи до MaxOrders
+	// заказов назначаются MaxCouriers свободным курьерам оптимально, а если решение не найдено за SolveBudget - жадно
+	Dispatch struct {
+		Enabled     bool
+		MaxOrders   int
+		MaxCouriers int
+		SolveBudget time.Duration
+	}
+
+	// ETA модель времени доставки обучается раз в сутки после RefreshHour (UTC) по доставкам за History.
+	// В модель попадают ячейки хотя бы с MinSamples доставками. С DeadlineP90 дедлайн без маршрута
+	// считается по p90 модели вместо фиксированного времени
+	ETA struct {
+		RefreshHour int
+		History     time.Duration
+		MinSamples  int
+		DeadlineP90 bool
+	}
+
+	// Rating средняя оценка курьера считается по оценкам доставок за Window. С PreferTopRated
+	// при подборе курьера более высокая средняя оценка важнее меньшей загрузки
+	Rating struct {
+		Window         time.Duration
+		PreferTopRated bool
+	}
+
+	// Cash наличные на руках у курьера в копейках. Курьер с остатком больше BalanceLimit не получает
+	// заказы с оплатой наличными, пока не сдаст наличные оператору. 0 - без лимита
+	Cash struct {
+		BalanceLimit int
+	}
+
 	Config struct {
 		Tasks        Tasks
 		Server       HTTPServer
 		Database     Database
 		OrderService OrderService
 		Kafka        Kafka
+		Healthcheck  Healthcheck
+		Overdue      Overdue
+		Partitions   DeliveryPartitions
+		Phone        Phone
+		Zones        Zones
+		Requirements OrderRequirements
+		Priority     OrderPriority
+		Offers       Offers
+		Batching     Batching
+		Dispatch     Dispatch
+		ETA          ETA
+		Rating       Rating
+		Cash         Cash
 	}
 )
 
@@ -90,6 +217,46 @@ func loadFromEnv() (*Config, error) {
 		return nil, fmt.Errorf("loading config: %w", err)
 	}
 
+	pendingInterval, err := osGetEnvDuration("BACKGROUND_PENDING_ASSIGNMENTS_PROCESS_INTERVAL")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	idempotencyCleanupInterval, err := osGetEnvDuration("BACKGROUND_IDEMPOTENCY_KEYS_CLEANUP_INTERVAL")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	poolMetricsInterval, err := osGetEnvDuration("BACKGROUND_POOL_METRICS_REFRESH_INTERVAL")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	deliveryPartitionsInterval, err := osGetEnvDuration("BACKGROUND_DELIVERY_PARTITIONS_INTERVAL")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	offerExpirationInterval, err := osGetEnvDuration("BACKGROUND_DELIVERY_OFFERS_EXPIRATION_INTERVAL")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	dispatchInterval, err := osGetEnvDuration("BACKGROUND_DELIVERY_DISPATCH_INTERVAL")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	scheduledDeliveriesInterval, err := osGetEnvDuration("BACKGROUND_SCHEDULED_DELIVERIES_INTERVAL")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	deliveryETAInterval, err := osGetEnvDuration("BACKGROUND_DELIVERY_ETA_INTERVAL")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
 	saramaOffsetsAutocommit, err := osGetBool("KAFKA_SARAMA_OFFSETS_AUTOCOMMIT")
 	if err != nil {
 		return nil, fmt.Errorf("loading config: %w", err)
@@ -120,18 +287,182 @@ func loadFromEnv() (*Config, error) {
 		return nil, fmt.Errorf("loading config: %w", err)
 	}
 
+	idempotencyKeyTTL, err := osGetEnvDuration("MIDDLEWARE_IDEMPOTENCY_KEY_TTL")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	healthcheckCacheTTL, err := osGetEnvDuration("HEALTHCHECK_CACHE_TTL")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	healthcheckPostgresTimeout, err := osGetEnvDuration("HEALTHCHECK_POSTGRES_TIMEOUT")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	healthcheckOrderServiceTimeout, err := osGetEnvDuration("HEALTHCHECK_ORDER_SERVICE_TIMEOUT")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	healthcheckKafkaTimeout, err := osGetEnvDuration("HEALTHCHECK_KAFKA_TIMEOUT")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	overdueAutoRelease, err := osGetBool("DELIVERY_OVERDUE_AUTO_RELEASE")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	zoneCrossZoneFallback, err := osGetBool("ZONE_CROSS_ZONE_FALLBACK")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	offersEnabled, err := osGetBool("DELIVERY_OFFER_ENABLED")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	offerTimeout, err := osGetEnvDuration("DELIVERY_OFFER_TIMEOUT")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	offerDeclineCooldown, err := osGetEnvDuration("DELIVERY_OFFER_DECLINE_COOLDOWN")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	batchWindow, err := osGetEnvDuration("DELIVERY_BATCH_WINDOW")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	batchMaxOrders, err := osGetInt("DELIVERY_BATCH_MAX_ORDERS")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	batchExtraStopTime, err := osGetEnvDuration("DELIVERY_BATCH_EXTRA_STOP_TIME")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	dispatchEnabled, err := osGetBool("DELIVERY_DISPATCH_ENABLED")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	dispatchMaxOrders, err := osGetInt("DELIVERY_DISPATCH_MAX_ORDERS")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	dispatchMaxCouriers, err := osGetInt("DELIVERY_DISPATCH_MAX_COURIERS")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	dispatchSolveBudget, err := osGetEnvDuration("DELIVERY_DISPATCH_SOLVE_BUDGET")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	requirementsLargeItems, err := osGetInt("ORDER_REQUIREMENTS_LARGE_ITEMS")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	requirementsLargeTotalPrice, err := osGetInt("ORDER_REQUIREMENTS_LARGE_TOTAL_PRICE")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	priorityHighTotalPrice, err := osGetInt("ORDER_PRIORITY_HIGH_TOTAL_PRICE")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	priorityPreemptWithin, err := osGetEnvDuration("DELIVERY_PRIORITY_PREEMPT_WITHIN")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	overdueReleaseGracePeriod, err := osGetEnvDuration("DELIVERY_OVERDUE_RELEASE_GRACE_PERIOD")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	partitionsPremakeMonths, err := osGetInt("DELIVERY_PARTITIONS_PREMAKE_MONTHS")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	partitionsRetentionMonths, err := osGetInt("DELIVERY_PARTITIONS_RETENTION_MONTHS")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	etaRefreshHour, err := osGetInt("DELIVERY_ETA_REFRESH_HOUR")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	etaHistory, err := osGetEnvDuration("DELIVERY_ETA_HISTORY")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	etaMinSamples, err := osGetInt("DELIVERY_ETA_MIN_SAMPLES")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	etaDeadlineP90, err := osGetBool("DELIVERY_ETA_DEADLINE_P90")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	ratingWindow, err := osGetEnvDuration("RATING_WINDOW")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	ratingPreferTopRated, err := osGetBool("RATING_PREFER_TOP_RATED")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	cashBalanceLimit, err := osGetInt("CASH_BALANCE_LIMIT")
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
 	return &Config{
 		Tasks: Tasks{
-			CouriersStatusUpdateInterval: courierInterval,
-			OrdersAssingProcessInterval:  orderInterval,
+			CouriersStatusUpdateInterval:   courierInterval,
+			OrdersAssingProcessInterval:    orderInterval,
+			PendingAssignmentsInterval:     pendingInterval,
+			IdempotencyKeysCleanupInterval: idempotencyCleanupInterval,
+			PoolMetricsRefreshInterval:     poolMetricsInterval,
+			DeliveryPartitionsInterval:     deliveryPartitionsInterval,
+			OfferExpirationInterval:        offerExpirationInterval,
+			DispatchInterval:               dispatchInterval,
+			ScheduledDeliveriesInterval:    scheduledDeliveriesInterval,
+			DeliveryETAInterval:            deliveryETAInterval,
 		},
 		Server: HTTPServer{
-			Port:             os.Getenv("PORT"),
-			RequestTimeout:   requestTimeout,
-			RateLimiterQPS:   rateLimiterQPS,
-			RateLimiterBurst: rateLimiterBurst,
-			PprofEnabled:     pprofEnabled,
-			PprofPort:        os.Getenv("PPROF_PORT"),
+			Port:              os.Getenv("PORT"),
+			RequestTimeout:    requestTimeout,
+			RateLimiterQPS:    rateLimiterQPS,
+			RateLimiterBurst:  rateLimiterBurst,
+			PprofEnabled:      pprofEnabled,
+			PprofPort:         os.Getenv("PPROF_PORT"),
+			IdempotencyKeyTTL: idempotencyKeyTTL,
 		},
 		Database: Database{
 			Host:     os.Getenv("POSTGRES_HOST"),
@@ -148,6 +479,7 @@ func loadFromEnv() (*Config, error) {
 			Brokers:         os.Getenv("KAFKA_BROKERS"),
 			Topic:           os.Getenv("KAFKA_TOPIC"),
 			ConsumerGroup:   os.Getenv("KAFKA_CONSUMER_GROUP"),
+			OverdueTopic:    os.Getenv("KAFKA_OVERDUE_TOPIC"),
 			PortHealthcheck: os.Getenv("KAFKA_HTTP_HEALTHCHECK_PORT"),
 			Sarama: Sarama{
 				Version:                   os.Getenv("KAFKA_SARAMA_VERSION"),
@@ -159,6 +491,68 @@ func loadFromEnv() (*Config, error) {
 				},
 			},
 		},
+		Healthcheck: Healthcheck{
+			CacheTTL:            healthcheckCacheTTL,
+			PostgresTimeout:     healthcheckPostgresTimeout,
+			OrderServiceTimeout: healthcheckOrderServiceTimeout,
+			KafkaTimeout:        healthcheckKafkaTimeout,
+		},
+		Overdue: Overdue{
+			AutoRelease:        overdueAutoRelease,
+			ReleaseGracePeriod: overdueReleaseGracePeriod,
+		},
+		Partitions: DeliveryPartitions{
+			PremakeMonths:   partitionsPremakeMonths,
+			RetentionMonths: partitionsRetentionMonths,
+			ArchiveMode:     os.Getenv("DELIVERY_PARTITIONS_ARCHIVE_MODE"),
+			ArchiveDir:      os.Getenv("DELIVERY_PARTITIONS_ARCHIVE_DIR"),
+		},
+		Phone: Phone{
+			DefaultRegion: os.Getenv("PHONE_DEFAULT_REGION"),
+		},
+		Zones: Zones{
+			CrossZoneFallback: zoneCrossZoneFallback,
+		},
+		Requirements: OrderRequirements{
+			AgeRestrictedKeywords: osGetList("ORDER_REQUIREMENTS_AGE_KEYWORDS"),
+			ThermalKeywords:       osGetList("ORDER_REQUIREMENTS_THERMAL_KEYWORDS"),
+			LargeOrderItems:       requirementsLargeItems,
+			LargeOrderTotalPrice:  requirementsLargeTotalPrice,
+		},
+		Priority: OrderPriority{
+			UrgentKeywords: osGetList("ORDER_PRIORITY_URGENT_KEYWORDS"),
+			HighTotalPrice: priorityHighTotalPrice,
+			PreemptWithin:  priorityPreemptWithin,
+		},
+		Offers: Offers{
+			Enabled:         offersEnabled,
+			Timeout:         offerTimeout,
+			DeclineCooldown: offerDeclineCooldown,
+		},
+		Batching: Batching{
+			Window:        batchWindow,
+			MaxOrders:     batchMaxOrders,
+			ExtraStopTime: batchExtraStopTime,
+		},
+		Dispatch: Dispatch{
+			Enabled:     dispatchEnabled,
+			MaxOrders:   dispatchMaxOrders,
+			MaxCouriers: dispatchMaxCouriers,
+			SolveBudget: dispatchSolveBudget,
+		},
+		ETA: ETA{
+			RefreshHour: etaRefreshHour,
+			History:     etaHistory,
+			MinSamples:  etaMinSamples,
+			DeadlineP90: etaDeadlineP90,
+		},
+		Rating: Rating{
+			Window:         ratingWindow,
+			PreferTopRated: ratingPreferTopRated,
+		},
+		Cash: Cash{
+			BalanceLimit: cashBalanceLimit,
+		},
 	}, nil
 }
 
@@ -175,6 +569,9 @@ func validateConfig(cfg *Config) error {
 	if cfg.Server.RateLimiterBurst == 0 {
 		return errors.New("MIDDLEWARE_RATE_LIMIT_BURST is required")
 	}
+	if cfg.Server.IdempotencyKeyTTL == time.Duration(0) {
+		return errors.New("MIDDLEWARE_IDEMPOTENCY_KEY_TTL is required")
+	}
 	if cfg.Server.PprofPort == "" && cfg.Server.PprofEnabled {
 		return errors.New("PprofPort is required (set via PPROF_PORT env variable)")
 	}
@@ -204,6 +601,31 @@ func validateConfig(cfg *Config) error {
 	if cfg.Tasks.OrdersAssingProcessInterval == time.Duration(0) {
 		return errors.New("BACKGROUND_ORDERS_ASSIGN_PROCESS_INTERVAL is required")
 	}
+	if cfg.Tasks.PendingAssignmentsInterval == time.Duration(0) {
+		return errors.New("BACKGROUND_PENDING_ASSIGNMENTS_PROCESS_INTERVAL is required")
+	}
+	if cfg.Tasks.IdempotencyKeysCleanupInterval == time.Duration(0) {
+		return errors.New("BACKGROUND_IDEMPOTENCY_KEYS_CLEANUP_INTERVAL is required")
+	}
+	if cfg.Tasks.PoolMetricsRefreshInterval == time.Duration(0) {
+		return errors.New("BACKGROUND_POOL_METRICS_REFRESH_INTERVAL is required")
+	}
+	if cfg.Tasks.DeliveryPartitionsInterval == time.Duration(0) {
+		return errors.New("BACKGROUND_DELIVERY_PARTITIONS_INTERVAL is required")
+	}
+	if cfg.Tasks.OfferExpirationInterval == time.Duration(0) {
+		return errors.New("BACKGROUND_DELIVERY_OFFERS_EXPIRATION_INTERVAL is required")
+	}
+
+	if cfg.Tasks.DispatchInterval == time.Duration(0) {
+		return errors.New("BACKGROUND_DELIVERY_DISPATCH_INTERVAL is required")
+	}
+	if cfg.Tasks.ScheduledDeliveriesInterval == time.Duration(0) {
+		return errors.New("BACKGROUND_SCHEDULED_DELIVERIES_INTERVAL is required")
+	}
+	if cfg.Tasks.DeliveryETAInterval == time.Duration(0) {
+		return errors.New("BACKGROUND_DELIVERY_ETA_INTERVAL is required")
+	}
 
 	if cfg.OrderService.GRPCHost == "" {
 		return errors.New("ORDER_SERVICE_GRPC_HOST is required")
@@ -218,6 +640,9 @@ func validateConfig(cfg *Config) error {
 	if cfg.Kafka.ConsumerGroup == "" {
 		return errors.New("KAFKA_CONSUMER_GROUP is required")
 	}
+	if cfg.Kafka.OverdueTopic == "" {
+		return errors.New("KAFKA_OVERDUE_TOPIC is required")
+	}
 	if cfg.Kafka.PortHealthcheck == "" {
 		return errors.New("KAFKA_HTTP_HEALTHCHECK_PORT is required")
 	}
@@ -230,6 +655,104 @@ func validateConfig(cfg *Config) error {
 		return errors.New("KAFKA_HANDLER_ORDER_STATUS_CHANGED_PROCESS_TIMEOUT is required")
 	}
 
+	if cfg.Healthcheck.CacheTTL == time.Duration(0) {
+		return errors.New("HEALTHCHECK_CACHE_TTL is required")
+	}
+	if cfg.Healthcheck.PostgresTimeout == time.Duration(0) {
+		return errors.New("HEALTHCHECK_POSTGRES_TIMEOUT is required")
+	}
+	if cfg.Healthcheck.OrderServiceTimeout == time.Duration(0) {
+		return errors.New("HEALTHCHECK_ORDER_SERVICE_TIMEOUT is required")
+	}
+	if cfg.Healthcheck.KafkaTimeout == time.Duration(0) {
+		return errors.New("HEALTHCHECK_KAFKA_TIMEOUT is required")
+	}
+
+	if cfg.Requirements.LargeOrderItems < 0 {
+		return errors.New("ORDER_REQUIREMENTS_LARGE_ITEMS must not be negative")
+	}
+	if cfg.Requirements.LargeOrderTotalPrice < 0 {
+		return errors.New("ORDER_REQUIREMENTS_LARGE_TOTAL_PRICE must not be negative")
+	}
+
+	if cfg.Priority.HighTotalPrice < 0 {
+		return errors.New("ORDER_PRIORITY_HIGH_TOTAL_PRICE must not be negative")
+	}
+	if cfg.Priority.PreemptWithin < 0 {
+		return errors.New("DELIVERY_PRIORITY_PREEMPT_WITHIN must not be negative")
+	}
+
+	if cfg.Offers.Enabled && cfg.Offers.Timeout <= 0 {
+		return errors.New("DELIVERY_OFFER_TIMEOUT is required when DELIVERY_OFFER_ENABLED is set")
+	}
+	if cfg.Offers.Enabled && cfg.Offers.DeclineCooldown <= 0 {
+		return errors.New("DELIVERY_OFFER_DECLINE_COOLDOWN is required when DELIVERY_OFFER_ENABLED is set")
+	}
+
+	if cfg.Batching.Window < 0 {
+		return errors.New("DELIVERY_BATCH_WINDOW must not be negative")
+	}
+	if cfg.Batching.Window > 0 && cfg.Batching.MaxOrders < 2 {
+		return errors.New("DELIVERY_BATCH_MAX_ORDERS must be at least 2 when DELIVERY_BATCH_WINDOW is set")
+	}
+	if cfg.Batching.ExtraStopTime < 0 {
+		return errors.New("DELIVERY_BATCH_EXTRA_STOP_TIME must not be negative")
+	}
+
+	if cfg.Dispatch.Enabled {
+		if cfg.Offers.Enabled {
+			return errors.New("DELIVERY_DISPATCH_ENABLED and DELIVERY_OFFER_ENABLED cannot be set together")
+		}
+		if cfg.Dispatch.MaxOrders < 1 || cfg.Dispatch.MaxCouriers < 1 {
+			return errors.New("DELIVERY_DISPATCH_MAX_ORDERS and DELIVERY_DISPATCH_MAX_COURIERS must be at least 1 when DELIVERY_DISPATCH_ENABLED is set")
+		}
+		if cfg.Dispatch.SolveBudget <= 0 {
+			return errors.New("DELIVERY_DISPATCH_SOLVE_BUDGET is required when DELIVERY_DISPATCH_ENABLED is set")
+		}
+	}
+
+	if cfg.Overdue.ReleaseGracePeriod < 0 {
+		return errors.New("DELIVERY_OVERDUE_RELEASE_GRACE_PERIOD must not be negative")
+	}
+
+	if cfg.Partitions.PremakeMonths < 1 {
+		return errors.New("DELIVERY_PARTITIONS_PREMAKE_MONTHS must be at least 1")
+	}
+	if cfg.Partitions.RetentionMonths < 1 {
+		return errors.New("DELIVERY_PARTITIONS_RETENTION_MONTHS must be at least 1")
+	}
+	switch cfg.Partitions.ArchiveMode {
+	case "table":
+	case "jsonl":
+		if cfg.Partitions.ArchiveDir == "" {
+			return errors.New("DELIVERY_PARTITIONS_ARCHIVE_DIR is required for jsonl archive mode")
+		}
+	default:
+		return fmt.Errorf("DELIVERY_PARTITIONS_ARCHIVE_MODE must be table or jsonl, got %q", cfg.Partitions.ArchiveMode)
+	}
+
+	if cfg.ETA.RefreshHour < 0 || cfg.ETA.RefreshHour > 23 {
+		return errors.New("DELIVERY_ETA_REFRESH_HOUR must be between 0 and 23")
+	}
+	if cfg.ETA.History <= 0 {
+		return errors.New("DELIVERY_ETA_HISTORY is required")
+	}
+	if cfg.ETA.MinSamples < 1 {
+		return errors.New("DELIVERY_ETA_MIN_SAMPLES must be at least 1")
+	}
+
+	if cfg.Rating.Window <= 0 {
+		return errors.New("RATING_WINDOW is required")
+	}
+
+	if cfg.Cash.BalanceLimit < 0 {
+		return errors.New("CASH_BALANCE_LIMIT must not be negative")
+	}
+
+	if cfg.Phone.DefaultRegion == "" {
+		return errors.New("PHONE_DEFAULT_REGION is required")
+	}
+
 	return nil
 }
 
@@ -271,3 +794,15 @@ func osGetBool(s string) (bool, error) {
 	}
 	return res, nil
 }
+
+// osGetList значения через запятую, пустые элементы отбрасываются
+func osGetList(s string) []string {
+	var res []string
+	for _, item := range strings.Split(os.Getenv(s), ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
@@ -0,0 +1,230 @@
+package courier_format_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"service/internal/entities"
+	"service/internal/pkg/courier_format"
+)
+
+func TestFromContentType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		contentType    string
+		expectedFormat courier_format.Format
+		expectedOK     bool
+	}{
+		{name: "csv", contentType: "text/csv", expectedFormat: courier_format.CSV, expectedOK: true},
+		{name: "csv с кодировкой", contentType: "text/csv; charset=utf-8", expectedFormat: courier_format.CSV, expectedOK: true},
+		{name: "ndjson", contentType: "application/x-ndjson", expectedFormat: courier_format.JSONL, expectedOK: true},
+		{name: "jsonl", contentType: "application/jsonl", expectedFormat: courier_format.JSONL, expectedOK: true},
+		{name: "json не поддерживается", contentType: "application/json", expectedOK: false},
+		{name: "пустой заголовок", contentType: "", expectedOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			format, ok := courier_format.FromContentType(tt.contentType)
+
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedFormat, format)
+		})
+	}
+}
+
+func TestFromAccept(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		accept         string
+		expectedFormat courier_format.Format
+		expectedOK     bool
+	}{
+		{name: "без заголовка - csv", accept: "", expectedFormat: courier_format.CSV, expectedOK: true},
+		{name: "любой тип - csv", accept: "*/*", expectedFormat: courier_format.CSV, expectedOK: true},
+		{name: "ndjson", accept: "application/x-ndjson", expectedFormat: courier_format.JSONL, expectedOK: true},
+		{name: "первый поддерживаемый из списка", accept: "application/json, application/x-ndjson;q=0.9, text/csv", expectedFormat: courier_format.JSONL, expectedOK: true},
+		{name: "неподдерживаемый тип", accept: "application/xml", expectedOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			format, ok := courier_format.FromAccept(tt.accept)
+
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedFormat, format)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		format        courier_format.Format
+		body          string
+		maxRows       int
+		expectedRows  []entities.CourierImportRow
+		expectedError error
+	}{
+		{
+			name:    "csv, колонки в произвольном порядке",
+			format:  courier_format.CSV,
+			body:    "\ufeffphone,Name,transport_type\n+79991234567, Иван ,car\n+79997654321,Петр,\n",
+			maxRows: 10,
+			expectedRows: []entities.CourierImportRow{
+				{Line: 2, Name: "Иван", Phone: "+79991234567", TransportType: "car"},
+				{Line: 3, Name: "Петр", Phone: "+79997654321"},
+			},
+		},
+		{
+			name:    "csv, строка с неверным числом колонок",
+			format:  courier_format.CSV,
+			body:    "name,phone\nИван,+79991234567\nПетр\n",
+			maxRows: 10,
+			expectedRows: []entities.CourierImportRow{
+				{Line: 2, Name: "Иван", Phone: "+79991234567"},
+				{Line: 3, ParseError: "expected 2 columns, got 1"},
+			},
+		},
+		{
+			name:         "csv, пустой файл",
+			format:       courier_format.CSV,
+			body:         "",
+			maxRows:      10,
+			expectedRows: []entities.CourierImportRow{},
+		},
+		{
+			name:          "csv, нет обязательной колонки",
+			format:        courier_format.CSV,
+			body:          "name,status\nИван,available\n",
+			maxRows:       10,
+			expectedError: courier_format.ErrNoHeader,
+		},
+		{
+			name:          "csv, незакрытая кавычка",
+			format:        courier_format.CSV,
+			body:          "name,phone\n\"Иван,+79991234567\n",
+			maxRows:       10,
+			expectedError: courier_format.ErrMalformed,
+		},
+		{
+			name:          "csv, превышен лимит строк",
+			format:        courier_format.CSV,
+			body:          "name,phone\nИван,+79991234567\nПетр,+79997654321\n",
+			maxRows:       1,
+			expectedError: courier_format.ErrTooManyRows,
+		},
+		{
+			name:    "jsonl, пустые строки пропускаются",
+			format:  courier_format.JSONL,
+			body:    "{\"name\":\"Иван\",\"phone\":\"+79991234567\",\"status\":\"busy\"}\n\n{bad\n",
+			maxRows: 10,
+			expectedRows: []entities.CourierImportRow{
+				{Line: 1, Name: "Иван", Phone: "+79991234567", Status: "busy"},
+				{Line: 3, ParseError: "invalid JSON"},
+			},
+		},
+		{
+			name:          "jsonl, превышен лимит строк",
+			format:        courier_format.JSONL,
+			body:          "{}\n{}\n",
+			maxRows:       1,
+			expectedError: courier_format.ErrTooManyRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rows, err := courier_format.Decode(tt.format, strings.NewReader(tt.body), tt.maxRows)
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRows, rows)
+		})
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC)
+	reason := "уволился, по собственному"
+	couriers := []entities.Courier{
+		{
+			ID:            1,
+			Name:          "Иван",
+			Phone:         "+79991234567",
+			Status:        entities.CourierAvailable,
+			TransportType: entities.Car,
+			CreatedAt:     fixedTime,
+			UpdatedAt:     fixedTime,
+		},
+		{
+			ID:                 2,
+			Name:               "Петр",
+			Phone:              "+79997654321",
+			Status:             entities.CourierAvailable,
+			TransportType:      entities.OnFoot,
+			CreatedAt:          fixedTime,
+			UpdatedAt:          fixedTime,
+			DeactivatedAt:      &fixedTime,
+			DeactivationReason: &reason,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		format         courier_format.Format
+		expectedOutput string
+	}{
+		{
+			name:   "csv",
+			format: courier_format.CSV,
+			expectedOutput: "id,name,phone,status,transport_type,created_at,updated_at,deactivated_at,deactivation_reason\n" +
+				"1,Иван,+79991234567,available,car,2025-01-15T11:00:00Z,2025-01-15T11:00:00Z,,\n" +
+				"2,Петр,+79997654321,available,on_foot,2025-01-15T11:00:00Z,2025-01-15T11:00:00Z,2025-01-15T11:00:00Z,\"уволился, по собственному\"\n",
+		},
+		{
+			name:   "jsonl",
+			format: courier_format.JSONL,
+			expectedOutput: `{"id":1,"name":"Иван","phone":"+79991234567","status":"available","transport_type":"car","created_at":"2025-01-15T11:00:00Z","updated_at":"2025-01-15T11:00:00Z"}` + "\n" +
+				`{"id":2,"name":"Петр","phone":"+79997654321","status":"available","transport_type":"on_foot","created_at":"2025-01-15T11:00:00Z","updated_at":"2025-01-15T11:00:00Z","deactivated_at":"2025-01-15T11:00:00Z","deactivation_reason":"уволился, по собственному"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			encoder, err := courier_format.NewEncoder(tt.format, &buf)
+			require.NoError(t, err)
+
+			for _, courier := range couriers {
+				require.NoError(t, encoder.Encode(courier))
+			}
+			require.NoError(t, encoder.Flush())
+
+			assert.Equal(t, tt.expectedOutput, buf.String())
+		})
+	}
+}
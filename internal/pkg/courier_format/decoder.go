@@ -0,0 +1,151 @@
+package courier_format
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"service/internal/entities"
+)
+
+const (
+	columnName          = "name"
+	columnPhone         = "phone"
+	columnStatus        = "status"
+	columnTransportType = "transport_type"
+)
+
+// maxJSONLLineSize строка курьера занимает сотню байт, мегабайта хватает с большим запасом
+const maxJSONLLineSize = 1 << 20
+
+// Decode разбирает файл импорта. Ошибка отдельной строки попадает в ее ParseError,
+// ошибка возвращается, только если файл нельзя читать дальше или строк больше maxRows
+func Decode(format Format, r io.Reader, maxRows int) ([]entities.CourierImportRow, error) {
+	if format == JSONL {
+		return decodeJSONL(r, maxRows)
+	}
+	return decodeCSV(r, maxRows)
+}
+
+// decodeCSV первая строка - заголовок, колонки ищутся по имени, status и transport_type необязательны
+func decodeCSV(r io.Reader, maxRows int) ([]entities.CourierImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return []entities.CourierImportRow{}, nil
+		}
+		return nil, fmt.Errorf("%w: read header: %w", ErrMalformed, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		// Excel сохраняет CSV в UTF-8 с BOM перед первой колонкой
+		column = strings.TrimPrefix(column, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	_, hasName := columns[columnName]
+	_, hasPhone := columns[columnPhone]
+	if !hasName || !hasPhone {
+		return nil, ErrNoHeader
+	}
+
+	rows := make([]entities.CourierImportRow, 0, 64)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, fmt.Errorf("%w: %w", ErrMalformed, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if len(rows) == maxRows {
+			return nil, fmt.Errorf("%w: limit is %d", ErrTooManyRows, maxRows)
+		}
+
+		if err != nil {
+			rows = append(rows, entities.CourierImportRow{
+				Line:       line,
+				ParseError: fmt.Sprintf("expected %d columns, got %d", len(header), len(record)),
+			})
+			continue
+		}
+
+		rows = append(rows, entities.CourierImportRow{
+			Line:          line,
+			Name:          csvField(record, columns, columnName),
+			Phone:         csvField(record, columns, columnPhone),
+			Status:        csvField(record, columns, columnStatus),
+			TransportType: csvField(record, columns, columnTransportType),
+		})
+	}
+
+	return rows, nil
+}
+
+func csvField(record []string, columns map[string]int, column string) string {
+	i, ok := columns[column]
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// decodeJSONL один JSON объект на строку, пустые строки пропускаются
+func decodeJSONL(r io.Reader, maxRows int) ([]entities.CourierImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLLineSize)
+
+	rows := make([]entities.CourierImportRow, 0, 64)
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		if len(rows) == maxRows {
+			return nil, fmt.Errorf("%w: limit is %d", ErrTooManyRows, maxRows)
+		}
+
+		var record importRecord
+		err := json.Unmarshal(data, &record)
+		if err != nil {
+			rows = append(rows, entities.CourierImportRow{
+				Line:       line,
+				ParseError: "invalid JSON",
+			})
+			continue
+		}
+
+		rows = append(rows, entities.CourierImportRow{
+			Line:          line,
+			Name:          strings.TrimSpace(record.Name),
+			Phone:         strings.TrimSpace(record.Phone),
+			Status:        strings.TrimSpace(record.Status),
+			TransportType: strings.TrimSpace(record.TransportType),
+		})
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("%w: line %d: %w", ErrMalformed, line+1, err)
+	}
+
+	return rows, nil
+}
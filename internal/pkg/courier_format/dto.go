@@ -0,0 +1,23 @@
+package courier_format
+
+import "time"
+
+// importRecord строка JSONL импорта, поля совпадают с колонками CSV
+type importRecord struct {
+	Name          string `json:"name"`
+	Phone         string `json:"phone"`
+	Status        string `json:"status"`
+	TransportType string `json:"transport_type"`
+}
+
+type exportRecord struct {
+	ID                 int64      `json:"id"`
+	Name               string     `json:"name"`
+	Phone              string     `json:"phone"`
+	Status             string     `json:"status"`
+	TransportType      string     `json:"transport_type"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	DeactivatedAt      *time.Time `json:"deactivated_at,omitempty"`
+	DeactivationReason *string    `json:"deactivation_reason,omitempty"`
+}
@@ -0,0 +1,91 @@
+package courier_format
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"service/internal/entities"
+)
+
+var exportHeader = []string{
+	"id", "name", "phone", "status", "transport_type",
+	"created_at", "updated_at", "deactivated_at", "deactivation_reason",
+}
+
+// Encoder пишет курьеров по одному, Flush отправляет накопленное в буфере
+type Encoder interface {
+	Encode(courier entities.Courier) error
+	Flush() error
+}
+
+// NewEncoder для CSV сразу пишет строку заголовка
+func NewEncoder(format Format, w io.Writer) (Encoder, error) {
+	if format == JSONL {
+		return &jsonlEncoder{encoder: json.NewEncoder(w)}, nil
+	}
+
+	writer := csv.NewWriter(w)
+	err := writer.Write(exportHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &csvEncoder{writer: writer}, nil
+}
+
+type csvEncoder struct {
+	writer *csv.Writer
+}
+
+func (e *csvEncoder) Encode(courier entities.Courier) error {
+	var deactivatedAt, deactivationReason string
+	if courier.DeactivatedAt != nil {
+		deactivatedAt = courier.DeactivatedAt.UTC().Format(time.RFC3339)
+	}
+	if courier.DeactivationReason != nil {
+		deactivationReason = *courier.DeactivationReason
+	}
+
+	return e.writer.Write([]string{
+		strconv.FormatInt(courier.ID, 10),
+		courier.Name,
+		courier.Phone,
+		courier.Status.String(),
+		courier.TransportType.String(),
+		courier.CreatedAt.UTC().Format(time.RFC3339),
+		courier.UpdatedAt.UTC().Format(time.RFC3339),
+		deactivatedAt,
+		deactivationReason,
+	})
+}
+
+func (e *csvEncoder) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type jsonlEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *jsonlEncoder) Encode(courier entities.Courier) error {
+	return e.encoder.Encode(exportRecord{
+		ID:                 courier.ID,
+		Name:               courier.Name,
+		Phone:              courier.Phone,
+		Status:             courier.Status.String(),
+		TransportType:      courier.TransportType.String(),
+		CreatedAt:          courier.CreatedAt.UTC(),
+		UpdatedAt:          courier.UpdatedAt.UTC(),
+		DeactivatedAt:      courier.DeactivatedAt,
+		DeactivationReason: courier.DeactivationReason,
+	})
+}
+
+// Flush json.Encoder пишет строку в writer сразу, буферизации нет
+func (e *jsonlEncoder) Flush() error {
+	return nil
+}
@@ -0,0 +1,87 @@
+package courier_format
+
+import (
+	"errors"
+	"mime"
+	"strings"
+)
+
+type Format string
+
+const (
+	CSV   Format = "csv"
+	JSONL Format = "jsonl"
+)
+
+const (
+	ContentTypeCSV   = "text/csv"
+	ContentTypeJSONL = "application/x-ndjson"
+)
+
+var (
+	ErrMalformed   = errors.New("malformed courier file")
+	ErrNoHeader    = errors.New("csv header is missing required columns")
+	ErrTooManyRows = errors.New("too many rows")
+)
+
+// jsonlMediaTypes у JSON Lines нет зарегистрированного MIME типа, поэтому принимаются распространенные варианты
+var jsonlMediaTypes = map[string]struct{}{
+	ContentTypeJSONL:          {},
+	"application/jsonl":       {},
+	"application/x-jsonlines": {},
+}
+
+func (f Format) ContentType() string {
+	if f == JSONL {
+		return ContentTypeJSONL
+	}
+	return ContentTypeCSV
+}
+
+// FromContentType формат тела запроса по заголовку Content-Type
+func FromContentType(contentType string) (Format, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+
+	return fromMediaType(mediaType)
+}
+
+// FromAccept формат ответа по заголовку Accept: первый поддерживаемый тип в порядке перечисления,
+// без заголовка или при */* отдается CSV. Веса q не учитываются
+func FromAccept(accept string) (Format, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return CSV, true
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		if mediaType == "*/*" || mediaType == "text/*" {
+			return CSV, true
+		}
+
+		format, ok := fromMediaType(mediaType)
+		if ok {
+			return format, true
+		}
+	}
+
+	return "", false
+}
+
+func fromMediaType(mediaType string) (Format, bool) {
+	mediaType = strings.ToLower(mediaType)
+	if mediaType == ContentTypeCSV {
+		return CSV, true
+	}
+	if _, ok := jsonlMediaTypes[mediaType]; ok {
+		return JSONL, true
+	}
+
+	return "", false
+}
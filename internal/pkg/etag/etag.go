@@ -0,0 +1,36 @@
+package etag
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrUnmatchable в If-Match тег, который сервис никогда не выдает (слабый, не число, список).
+// По RFC 9110 такое условие просто не выполняется, поэтому обработчики отвечают 412
+var ErrUnmatchable = errors.New("if-match cannot match any version")
+
+// Format сильный ETag из версии ресурса
+func Format(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ParseIfMatch возвращает версию из If-Match. ok == false, если условия нет: заголовок пустой или "*"
+func ParseIfMatch(header string) (version int64, ok bool, err error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, false, nil
+	}
+
+	// If-Match сравнивает только сильные теги
+	if !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) || len(header) < 2 {
+		return 0, false, ErrUnmatchable
+	}
+
+	version, err = strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false, ErrUnmatchable
+	}
+
+	return version, true, nil
+}
@@ -0,0 +1,54 @@
+package etag_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"service/internal/pkg/etag"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `"42"`, etag.Format(42))
+}
+
+func TestParseIfMatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		header          string
+		expectedVersion int64
+		expectedOK      bool
+		expectedError   error
+	}{
+		{name: "Сильный тег", header: `"42"`, expectedVersion: 42, expectedOK: true},
+		{name: "Пробелы вокруг тега", header: ` "7" `, expectedVersion: 7, expectedOK: true},
+		{name: "Без заголовка условия нет", header: ""},
+		{name: "Звездочка - любая версия", header: "*"},
+		{name: "Слабый тег не совпадает никогда", header: `W/"42"`, expectedError: etag.ErrUnmatchable},
+		{name: "Тег без кавычек", header: "42", expectedError: etag.ErrUnmatchable},
+		{name: "Не число", header: `"abc"`, expectedError: etag.ErrUnmatchable},
+		{name: "Нулевая версия", header: `"0"`, expectedError: etag.ErrUnmatchable},
+		{name: "Список тегов", header: `"1", "2"`, expectedError: etag.ErrUnmatchable},
+		{name: "Одна кавычка", header: `"`, expectedError: etag.ErrUnmatchable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			version, ok, err := etag.ParseIfMatch(tt.header)
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedVersion, version)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=delivery_deadline_test
+package delivery_deadline
+
+import (
+	"context"
+	"time"
+
+	"service/internal/entities"
+)
+
+type SettingsRepository interface {
+	GetDeadlineSettings(ctx context.Context) (*entities.DeadlineSettings, error)
+}
+
+type ETAPredictor interface {
+	Predict(ctx context.Context, transportType entities.CourierTransportType, assignedAt time.Time) (*entities.ETAPrediction, error)
+}
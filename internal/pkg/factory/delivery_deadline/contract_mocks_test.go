@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contract.go -destination=./contract_mocks_test.go -package=delivery_deadline_test
+//
+
+// Package delivery_deadline_test is a generated GoMock package.
+package delivery_deadline_test
+
+import (
+	context "context"
+	reflect "reflect"
+	entities "service/internal/entities"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSettingsRepository is a mock of SettingsRepository interface.
+type MockSettingsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettingsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSettingsRepositoryMockRecorder is the mock recorder for MockSettingsRepository.
+type MockSettingsRepositoryMockRecorder struct {
+	mock *MockSettingsRepository
+}
+
+// NewMockSettingsRepository creates a new mock instance.
+func NewMockSettingsRepository(ctrl *gomock.Controller) *MockSettingsRepository {
+	mock := &MockSettingsRepository{ctrl: ctrl}
+	mock.recorder = &MockSettingsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSettingsRepository) EXPECT() *MockSettingsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetDeadlineSettings mocks base method.
+func (m *MockSettingsRepository) GetDeadlineSettings(ctx context.Context) (*entities.DeadlineSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadlineSettings", ctx)
+	ret0, _ := ret[0].(*entities.DeadlineSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadlineSettings indicates an expected call of GetDeadlineSettings.
+func (mr *MockSettingsRepositoryMockRecorder) GetDeadlineSettings(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadlineSettings", reflect.TypeOf((*MockSettingsRepository)(nil).GetDeadlineSettings), ctx)
+}
+
+// MockETAPredictor is a mock of ETAPredictor interface.
+type MockETAPredictor struct {
+	ctrl     *gomock.Controller
+	recorder *MockETAPredictorMockRecorder
+	isgomock struct{}
+}
+
+// MockETAPredictorMockRecorder is the mock recorder for MockETAPredictor.
+type MockETAPredictorMockRecorder struct {
+	mock *MockETAPredictor
+}
+
+// NewMockETAPredictor creates a new mock instance.
+func NewMockETAPredictor(ctrl *gomock.Controller) *MockETAPredictor {
+	mock := &MockETAPredictor{ctrl: ctrl}
+	mock.recorder = &MockETAPredictorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockETAPredictor) EXPECT() *MockETAPredictorMockRecorder {
+	return m.recorder
+}
+
+// Predict mocks base method.
+func (m *MockETAPredictor) Predict(ctx context.Context, transportType entities.CourierTransportType, assignedAt time.Time) (*entities.ETAPrediction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Predict", ctx, transportType, assignedAt)
+	ret0, _ := ret[0].(*entities.ETAPrediction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Predict indicates an expected call of Predict.
+func (mr *MockETAPredictorMockRecorder) Predict(ctx, transportType, assignedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Predict", reflect.TypeOf((*MockETAPredictor)(nil).Predict), ctx, transportType, assignedAt)
+}
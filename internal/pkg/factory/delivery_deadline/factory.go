@@ -1,29 +1,121 @@
 package delivery_deadline
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"service/internal/entities"
+	"service/internal/service/delivery_eta"
+	"service/pkg/geo"
 )
 
-type DeliveryTimeFactory struct{}
+type Policy struct {
+	// UseETAP90 вместо фиксированного времени берется p90 длительности доставок из модели ETA,
+	// расчет по маршруту не меняется
+	UseETAP90 bool
+}
+
+type DeliveryTimeFactory struct {
+	settingsRepository SettingsRepository
+	etaPredictor       ETAPredictor
+	policy             Policy
+}
+
+func New(settingsRepository SettingsRepository, etaPredictor ETAPredictor, policy Policy) *DeliveryTimeFactory {
+	return &DeliveryTimeFactory{
+		settingsRepository: settingsRepository,
+		etaPredictor:       etaPredictor,
+		policy:             policy,
+	}
+}
+
+// CalculateDeadline считает дедлайн как время на передачу заказа плюс время в пути по маршруту
+// со средней скоростью транспорта, время в пути увеличивается в часы пик.
+// Если маршрут неизвестен или для транспорта нет настроек, используется фиксированное время
+// или p90 модели ETA, если это включено политикой.
+func (d *DeliveryTimeFactory) CalculateDeadline(
+	ctx context.Context,
+	transportType entities.CourierTransportType,
+	route *entities.Route,
+	baseTime time.Time,
+) (time.Time, error) {
+	if route == nil {
+		return d.fallbackDeadline(ctx, transportType, baseTime)
+	}
+
+	settings, err := d.settingsRepository.GetDeadlineSettings(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("get deadline settings: %w", err)
+	}
+
+	transportSpeed, ok := findTransportSpeed(settings.TransportSpeeds, transportType)
+	if !ok {
+		return d.fallbackDeadline(ctx, transportType, baseTime)
+	}
 
-func New() *DeliveryTimeFactory {
-	return &DeliveryTimeFactory{}
+	distanceKm := geo.DistanceKm(
+		geo.Point{Lat: route.Pickup.Latitude, Lon: route.Pickup.Longitude},
+		geo.Point{Lat: route.Dropoff.Latitude, Lon: route.Dropoff.Longitude},
+	)
+
+	travelHours := distanceKm / transportSpeed.AverageSpeedKmh * peakMultiplier(settings.PeakHours, baseTime)
+	travelDuration := time.Duration(travelHours * float64(time.Hour))
+
+	return baseTime.Add(transportSpeed.HandlingOverhead + travelDuration).Round(time.Second), nil
+}
+
+// fallbackDeadline пока у модели ETA нет квантилей для транспорта, используется фиксированное время
+func (d *DeliveryTimeFactory) fallbackDeadline(
+	ctx context.Context,
+	transportType entities.CourierTransportType,
+	baseTime time.Time,
+) (time.Time, error) {
+	if d.policy.UseETAP90 {
+		prediction, err := d.etaPredictor.Predict(ctx, transportType, baseTime)
+		if err == nil {
+			return baseTime.Add(prediction.P90), nil
+		}
+		if !errors.Is(err, delivery_eta.ErrETAModelNotFound) {
+			return time.Time{}, fmt.Errorf("predict eta: %w", err)
+		}
+	}
+
+	return baseTime.Add(fallbackDuration(transportType)), nil
 }
 
-func (d *DeliveryTimeFactory) CalculateDeadline(transportType entities.CourierTransportType, baseTime time.Time) time.Time {
-	resultTime := baseTime
+func fallbackDuration(transportType entities.CourierTransportType) time.Duration {
 	switch transportType {
 	case entities.OnFoot:
-		resultTime = resultTime.Add(time.Minute * 15)
+		return time.Minute * 15
 	case entities.Scooter:
-		resultTime = resultTime.Add(time.Minute * 10)
+		return time.Minute * 10
 	case entities.Car:
-		resultTime = resultTime.Add(time.Minute * 5)
+		return time.Minute * 5
 	default:
-		resultTime = resultTime.Add(time.Minute * 15)
+		return time.Minute * 15
 	}
+}
+
+func findTransportSpeed(transportSpeeds []entities.TransportSpeed, transportType entities.CourierTransportType) (entities.TransportSpeed, bool) {
+	for _, transportSpeed := range transportSpeeds {
+		if transportSpeed.TransportType == transportType {
+			return transportSpeed, true
+		}
+	}
+	return entities.TransportSpeed{}, false
+}
+
+// peakMultiplier при пересечении интервалов берется наибольший множитель
+func peakMultiplier(peakHours []entities.PeakHour, baseTime time.Time) float64 {
+	hour := baseTime.UTC().Hour()
 
-	return resultTime
+	multiplier := 1.0
+	for _, peakHour := range peakHours {
+		if hour >= peakHour.StartHour && hour < peakHour.EndHour && peakHour.Multiplier > multiplier {
+			multiplier = peakHour.Multiplier
+		}
+	}
+	return multiplier
 }
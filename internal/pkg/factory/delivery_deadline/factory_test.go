@@ -0,0 +1,220 @@
+package delivery_deadline_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/pkg/factory/delivery_deadline"
+	"service/internal/service/delivery_eta"
+)
+
+func TestDeliveryTimeFactory_CalculateDeadline(t *testing.T) {
+	t.Parallel()
+
+	// вне часов пик
+	baseTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	peakTime := time.Date(2026, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	// один градус по меридиану ~111.19 км
+	route := &entities.Route{
+		Pickup:  entities.Location{Latitude: 0, Longitude: 0},
+		Dropoff: entities.Location{Latitude: 1, Longitude: 0},
+	}
+
+	settings := &entities.DeadlineSettings{
+		TransportSpeeds: []entities.TransportSpeed{
+			{TransportType: entities.Car, AverageSpeedKmh: 111.19, HandlingOverhead: 5 * time.Minute},
+		},
+		PeakHours: []entities.PeakHour{
+			{StartHour: 17, EndHour: 20, Multiplier: 1.5},
+			{StartHour: 18, EndHour: 19, Multiplier: 2},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		transportType    entities.CourierTransportType
+		route            *entities.Route
+		baseTime         time.Time
+		mockSetup        func(m *MockSettingsRepository)
+		expectedDuration time.Duration
+		errorAssertion   require.ErrorAssertionFunc
+	}{
+		{
+			name:             "Без маршрута пешему курьеру дается фиксированное время",
+			transportType:    entities.OnFoot,
+			route:            nil,
+			baseTime:         baseTime,
+			expectedDuration: 15 * time.Minute,
+			errorAssertion:   require.NoError,
+		},
+		{
+			name:             "Без маршрута курьеру на скутере дается фиксированное время",
+			transportType:    entities.Scooter,
+			route:            nil,
+			baseTime:         baseTime,
+			expectedDuration: 10 * time.Minute,
+			errorAssertion:   require.NoError,
+		},
+		{
+			name:          "Время по расстоянию и скорости с учетом времени на передачу заказа",
+			transportType: entities.Car,
+			route:         route,
+			baseTime:      baseTime,
+			mockSetup: func(m *MockSettingsRepository) {
+				m.EXPECT().GetDeadlineSettings(gomock.Any()).Return(settings, nil)
+			},
+			expectedDuration: time.Hour + 5*time.Minute,
+			errorAssertion:   require.NoError,
+		},
+		{
+			name:          "В час пик берется наибольший из пересекающихся множителей",
+			transportType: entities.Car,
+			route:         route,
+			baseTime:      peakTime,
+			mockSetup: func(m *MockSettingsRepository) {
+				m.EXPECT().GetDeadlineSettings(gomock.Any()).Return(settings, nil)
+			},
+			expectedDuration: 2*time.Hour + 5*time.Minute,
+			errorAssertion:   require.NoError,
+		},
+		{
+			name:          "Нет настроек для транспорта - фиксированное время",
+			transportType: entities.Scooter,
+			route:         route,
+			baseTime:      baseTime,
+			mockSetup: func(m *MockSettingsRepository) {
+				m.EXPECT().GetDeadlineSettings(gomock.Any()).Return(settings, nil)
+			},
+			expectedDuration: 10 * time.Minute,
+			errorAssertion:   require.NoError,
+		},
+		{
+			name:          "Ошибка получения настроек",
+			transportType: entities.Car,
+			route:         route,
+			baseTime:      baseTime,
+			mockSetup: func(m *MockSettingsRepository) {
+				m.EXPECT().GetDeadlineSettings(gomock.Any()).Return(nil, errors.New("database connection lost"))
+			},
+			errorAssertion: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := NewMockSettingsRepository(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			factory := delivery_deadline.New(m, NewMockETAPredictor(ctrl), delivery_deadline.Policy{})
+
+			deadline, err := factory.CalculateDeadline(context.Background(), tt.transportType, tt.route, tt.baseTime)
+
+			tt.errorAssertion(t, err, tt.name)
+			if err != nil {
+				return
+			}
+			assert.WithinDuration(t, tt.baseTime.Add(tt.expectedDuration), deadline, time.Minute)
+		})
+	}
+}
+
+func TestDeliveryTimeFactory_CalculateDeadline_ETAP90(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	route := &entities.Route{
+		Pickup:  entities.Location{Latitude: 0, Longitude: 0},
+		Dropoff: entities.Location{Latitude: 1, Longitude: 0},
+	}
+
+	tests := []struct {
+		name             string
+		route            *entities.Route
+		mockSetup        func(settings *MockSettingsRepository, predictor *MockETAPredictor)
+		expectedDuration time.Duration
+		errorAssertion   require.ErrorAssertionFunc
+	}{
+		{
+			name:  "Без маршрута берется p90 модели ETA",
+			route: nil,
+			mockSetup: func(settings *MockSettingsRepository, predictor *MockETAPredictor) {
+				predictor.EXPECT().
+					Predict(gomock.Any(), entities.OnFoot, baseTime).
+					Return(&entities.ETAPrediction{P50: 20 * time.Minute, P90: 35 * time.Minute}, nil)
+			},
+			expectedDuration: 35 * time.Minute,
+			errorAssertion:   require.NoError,
+		},
+		{
+			name:  "Нет настроек для транспорта - p90 модели ETA",
+			route: route,
+			mockSetup: func(settings *MockSettingsRepository, predictor *MockETAPredictor) {
+				settings.EXPECT().
+					GetDeadlineSettings(gomock.Any()).
+					Return(&entities.DeadlineSettings{}, nil)
+				predictor.EXPECT().
+					Predict(gomock.Any(), entities.OnFoot, baseTime).
+					Return(&entities.ETAPrediction{P50: 20 * time.Minute, P90: 35 * time.Minute}, nil)
+			},
+			expectedDuration: 35 * time.Minute,
+			errorAssertion:   require.NoError,
+		},
+		{
+			name:  "Модель еще не обучена - фиксированное время",
+			route: nil,
+			mockSetup: func(settings *MockSettingsRepository, predictor *MockETAPredictor) {
+				predictor.EXPECT().
+					Predict(gomock.Any(), entities.OnFoot, baseTime).
+					Return(nil, fmt.Errorf("find eta quantile: %w", delivery_eta.ErrETAModelNotFound))
+			},
+			expectedDuration: 15 * time.Minute,
+			errorAssertion:   require.NoError,
+		},
+		{
+			name:  "Ошибка модели ETA",
+			route: nil,
+			mockSetup: func(settings *MockSettingsRepository, predictor *MockETAPredictor) {
+				predictor.EXPECT().
+					Predict(gomock.Any(), entities.OnFoot, baseTime).
+					Return(nil, errors.New("database connection lost"))
+			},
+			errorAssertion: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			settings := NewMockSettingsRepository(ctrl)
+			predictor := NewMockETAPredictor(ctrl)
+			tt.mockSetup(settings, predictor)
+
+			factory := delivery_deadline.New(settings, predictor, delivery_deadline.Policy{UseETAP90: true})
+
+			deadline, err := factory.CalculateDeadline(context.Background(), entities.OnFoot, tt.route, baseTime)
+
+			tt.errorAssertion(t, err, tt.name)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, baseTime.Add(tt.expectedDuration), deadline)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package order_handle
+
+import "service/internal/entities"
+
+// RequirementsFactory выводит требования к курьеру из состава заказа
+type RequirementsFactory interface {
+	Derive(order *entities.Order) entities.OrderRequirements
+}
+
+// PriorityFactory выводит класс срочности из состава заказа
+type PriorityFactory interface {
+	Derive(order *entities.Order) entities.OrderPriority
+}
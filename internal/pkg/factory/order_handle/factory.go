@@ -2,19 +2,29 @@ package order_handle
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"service/internal/entities"
+	"service/internal/service/delivery"
 	"service/internal/service/order"
 )
 
 type StatusHandlerFactory struct {
 	deliveryService order.DeliveryService
+	requirements    RequirementsFactory
+	priority        PriorityFactory
 }
 
-func NewStatusHandlerFactory(deliveryService order.DeliveryService) *StatusHandlerFactory {
+func NewStatusHandlerFactory(
+	deliveryService order.DeliveryService,
+	requirements RequirementsFactory,
+	priority PriorityFactory,
+) *StatusHandlerFactory {
 	return &StatusHandlerFactory{
 		deliveryService: deliveryService,
+		requirements:    requirements,
+		priority:        priority,
 	}
 }
 
@@ -31,26 +41,64 @@ func (f *StatusHandlerFactory) GetHandler(status entities.OrderStatusType) (orde
 	}
 }
 
-func (f *StatusHandlerFactory) createdHandler(ctx context.Context, orderID string) error {
-	_, err := f.deliveryService.DeliveryAssign(ctx, orderID)
+func (f *StatusHandlerFactory) createdHandler(ctx context.Context, orderEntity *entities.Order) error {
+	// доставку к назначенному времени назначит планировщик, сразу курьер не нужен
+	scheduled, err := f.deliveryService.IsDeliveryScheduled(ctx, orderEntity.ID)
 	if err != nil {
-		return fmt.Errorf("assign courier for created order %s: %w", orderID, err)
+		return fmt.Errorf("check scheduled delivery for created order %s: %w", orderEntity.ID, err)
+	}
+	if scheduled {
+		return nil
+	}
+
+	// order-service не передает координаты, дедлайн считается по типу транспорта
+	// или по обещанному клиенту времени доставки
+	params := entities.DeliveryAssignParams{
+		OrderID:           orderEntity.ID,
+		RestaurantID:      orderEntity.RestaurantID,
+		Address:           orderEntity.Address,
+		EstimatedDelivery: orderEntity.EstimatedDelivery,
+		Requirements:      f.requirements.Derive(orderEntity),
+		Priority:          f.priority.Derive(orderEntity),
+		CashAmount:        orderEntity.CashAmount(),
+	}
+	if !orderEntity.CreatedAt.IsZero() {
+		params.OrderCreatedAt = &orderEntity.CreatedAt
+	}
+	_, err = f.deliveryService.DeliveryAssignBatched(ctx, params)
+	// заказ принят в очередь ожидания и будет назначен, когда освободится курьер, закончится окно
+	// группировки заказов ресторана или курьер примет предложение
+	if err != nil && !errors.Is(err, delivery.ErrAssignmentPending) && !errors.Is(err, delivery.ErrOfferPending) {
+		return fmt.Errorf("assign courier for created order %s: %w", orderEntity.ID, err)
 	}
 	return nil
 }
 
-func (f *StatusHandlerFactory) cancelledHandler(ctx context.Context, orderID string) error {
+func (f *StatusHandlerFactory) cancelledHandler(ctx context.Context, orderEntity *entities.Order) error {
+	orderID := orderEntity.ID
 	_, err := f.deliveryService.DeliveryUnassign(ctx, orderID)
+	// курьер заказу еще не назначен - заказ может ждать в очереди или быть запланирован на потом
+	if errors.Is(err, delivery.ErrDeliveryNotFound) {
+		err = f.deliveryService.CancelPendingAssignment(ctx, orderID)
+		if err != nil && !errors.Is(err, delivery.ErrPendingAssignmentNotFound) {
+			return fmt.Errorf("cancel pending assignment for cancelled order %s: %w", orderID, err)
+		}
+		_, err = f.deliveryService.CancelScheduledDelivery(ctx, orderID)
+		if err != nil && !errors.Is(err, delivery.ErrScheduledDeliveryNotFound) {
+			return fmt.Errorf("cancel scheduled delivery for cancelled order %s: %w", orderID, err)
+		}
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("unassign courier for cancelled order %s: %w", orderID, err)
 	}
 	return nil
 }
 
-func (f *StatusHandlerFactory) completedHandler(ctx context.Context, orderID string) error {
-	err := f.deliveryService.FreeCourierByOrderID(ctx, orderID)
+func (f *StatusHandlerFactory) completedHandler(ctx context.Context, orderEntity *entities.Order) error {
+	err := f.deliveryService.FreeCourierByOrderID(ctx, orderEntity.ID)
 	if err != nil {
-		return fmt.Errorf("free courier for completed order %s: %w", orderID, err)
+		return fmt.Errorf("free courier for completed order %s: %w", orderEntity.ID, err)
 	}
 	return nil
 }
@@ -0,0 +1,49 @@
+package order_priority
+
+import (
+	"strings"
+
+	"service/internal/entities"
+)
+
+// Rules правила вывода класса срочности из заказа order-service.
+// Ключевые слова ищутся в названиях позиций без учета регистра, нулевой порог отключает правило
+type Rules struct {
+	UrgentKeywords []string
+	HighTotalPrice int64
+}
+
+type PriorityFactory struct {
+	rules Rules
+}
+
+func New(rules Rules) *PriorityFactory {
+	urgentKeywords := make([]string, 0, len(rules.UrgentKeywords))
+	for _, keyword := range rules.UrgentKeywords {
+		urgentKeywords = append(urgentKeywords, strings.ToLower(keyword))
+	}
+	rules.UrgentKeywords = urgentKeywords
+
+	return &PriorityFactory{
+		rules: rules,
+	}
+}
+
+// Derive класс срочности заказа: срочные позиции, например лекарства, и дорогие заказы VIP-клиентов -
+// приоритетные, остальные - обычные
+func (f *PriorityFactory) Derive(order *entities.Order) entities.OrderPriority {
+	if f.rules.HighTotalPrice > 0 && order.TotalPrice >= f.rules.HighTotalPrice {
+		return entities.PriorityHigh
+	}
+
+	for _, item := range order.Items {
+		name := strings.ToLower(item.Name)
+		for _, keyword := range f.rules.UrgentKeywords {
+			if strings.Contains(name, keyword) {
+				return entities.PriorityHigh
+			}
+		}
+	}
+
+	return entities.PriorityNormal
+}
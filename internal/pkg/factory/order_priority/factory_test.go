@@ -0,0 +1,75 @@
+package order_priority_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"service/internal/entities"
+	"service/internal/pkg/factory/order_priority"
+)
+
+func TestPriorityFactory_Derive(t *testing.T) {
+	t.Parallel()
+
+	rules := order_priority.Rules{
+		UrgentKeywords: []string{"Лекарств", "аптек"},
+		HighTotalPrice: 5000000,
+	}
+
+	tests := []struct {
+		name     string
+		rules    order_priority.Rules
+		order    *entities.Order
+		expected entities.OrderPriority
+	}{
+		{
+			name:  "Обычный заказ",
+			rules: rules,
+			order: &entities.Order{
+				Items:      []entities.OrderItem{{Name: "Салат", Price: 30000, Quantity: 1}},
+				TotalPrice: 30000,
+			},
+			expected: entities.PriorityNormal,
+		},
+		{
+			name:  "Лекарства - приоритетный заказ, ключевое слово без учета регистра",
+			rules: rules,
+			order: &entities.Order{
+				Items: []entities.OrderItem{
+					{Name: "Вода", Price: 5000, Quantity: 1},
+					{Name: "ЛЕКАРСТВО от кашля", Price: 45000, Quantity: 1},
+				},
+				TotalPrice: 50000,
+			},
+			expected: entities.PriorityHigh,
+		},
+		{
+			name:  "Дорогой заказ - приоритетный",
+			rules: rules,
+			order: &entities.Order{
+				Items:      []entities.OrderItem{{Name: "Сет роллов", Price: 2500000, Quantity: 2}},
+				TotalPrice: 5000000,
+			},
+			expected: entities.PriorityHigh,
+		},
+		{
+			name:  "Без правил заказ обычный",
+			rules: order_priority.Rules{},
+			order: &entities.Order{
+				Items:      []entities.OrderItem{{Name: "Лекарство", Price: 9000000, Quantity: 1}},
+				TotalPrice: 9000000,
+			},
+			expected: entities.PriorityNormal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			factory := order_priority.New(tt.rules)
+
+			assert.Equal(t, tt.expected, factory.Derive(tt.order))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package order_requirements
+
+import (
+	"slices"
+	"strings"
+
+	"service/internal/entities"
+)
+
+// Rules правила вывода требований из заказа order-service.
+// Ключевые слова ищутся в названиях позиций без учета регистра, нулевой порог отключает правило
+type Rules struct {
+	AgeRestrictedKeywords []string
+	ThermalKeywords       []string
+	LargeOrderItems       int64
+	LargeOrderTotalPrice  int64
+}
+
+// largeOrderTransport крупный заказ пешком не донести
+var largeOrderTransport = []entities.CourierTransportType{entities.Scooter, entities.Car}
+
+type RequirementsFactory struct {
+	rules Rules
+}
+
+func New(rules Rules) *RequirementsFactory {
+	rules.AgeRestrictedKeywords = lowerAll(rules.AgeRestrictedKeywords)
+	rules.ThermalKeywords = lowerAll(rules.ThermalKeywords)
+
+	return &RequirementsFactory{
+		rules: rules,
+	}
+}
+
+// Derive требования заказа к курьеру: навыки по позициям заказа и транспорт по его размеру
+func (f *RequirementsFactory) Derive(order *entities.Order) entities.OrderRequirements {
+	requirements := entities.OrderRequirements{}
+
+	var itemsCount int64
+	var thermal, ageRestricted bool
+	for _, item := range order.Items {
+		itemsCount += item.Quantity
+
+		name := strings.ToLower(item.Name)
+		thermal = thermal || containsAny(name, f.rules.ThermalKeywords)
+		ageRestricted = ageRestricted || containsAny(name, f.rules.AgeRestrictedKeywords)
+	}
+
+	if thermal {
+		requirements.Skills = append(requirements.Skills, entities.SkillThermalBag)
+	}
+	if ageRestricted {
+		requirements.Skills = append(requirements.Skills, entities.SkillAgeVerified)
+	}
+
+	largeByItems := f.rules.LargeOrderItems > 0 && itemsCount >= f.rules.LargeOrderItems
+	largeByPrice := f.rules.LargeOrderTotalPrice > 0 && order.TotalPrice >= f.rules.LargeOrderTotalPrice
+	if largeByItems || largeByPrice {
+		requirements.TransportTypes = slices.Clone(largeOrderTransport)
+	}
+
+	return requirements
+}
+
+func containsAny(name string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if strings.Contains(name, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func lowerAll(keywords []string) []string {
+	res := make([]string, 0, len(keywords))
+	for _, keyword := range keywords {
+		res = append(res, strings.ToLower(keyword))
+	}
+	return res
+}
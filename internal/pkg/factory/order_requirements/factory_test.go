@@ -0,0 +1,106 @@
+package order_requirements_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"service/internal/entities"
+	"service/internal/pkg/factory/order_requirements"
+)
+
+func TestRequirementsFactory_Derive(t *testing.T) {
+	t.Parallel()
+
+	rules := order_requirements.Rules{
+		AgeRestrictedKeywords: []string{"Вино", "пиво"},
+		ThermalKeywords:       []string{"пицца", "суп"},
+		LargeOrderItems:       10,
+		LargeOrderTotalPrice:  1000000,
+	}
+
+	tests := []struct {
+		name     string
+		rules    order_requirements.Rules
+		order    *entities.Order
+		expected entities.OrderRequirements
+	}{
+		{
+			name:  "Обычный заказ без требований",
+			rules: rules,
+			order: &entities.Order{
+				Items:      []entities.OrderItem{{Name: "Салат", Price: 30000, Quantity: 1}},
+				TotalPrice: 30000,
+			},
+			expected: entities.OrderRequirements{},
+		},
+		{
+			name:  "Горячее требует термосумку, ключевое слово без учета регистра",
+			rules: rules,
+			order: &entities.Order{
+				Items:      []entities.OrderItem{{Name: "ПИЦЦА Маргарита", Price: 59000, Quantity: 2}},
+				TotalPrice: 118000,
+			},
+			expected: entities.OrderRequirements{
+				Skills: []entities.CourierSkill{entities.SkillThermalBag},
+			},
+		},
+		{
+			name:  "Алкоголь и горячее в одном заказе",
+			rules: rules,
+			order: &entities.Order{
+				Items: []entities.OrderItem{
+					{Name: "Том ям суп", Price: 45000, Quantity: 1},
+					{Name: "Вино красное", Price: 120000, Quantity: 1},
+				},
+				TotalPrice: 165000,
+			},
+			expected: entities.OrderRequirements{
+				Skills: []entities.CourierSkill{entities.SkillThermalBag, entities.SkillAgeVerified},
+			},
+		},
+		{
+			name:  "Много позиций - пеший курьер не подходит",
+			rules: rules,
+			order: &entities.Order{
+				Items: []entities.OrderItem{
+					{Name: "Вода", Price: 5000, Quantity: 6},
+					{Name: "Сок", Price: 10000, Quantity: 4},
+				},
+				TotalPrice: 70000,
+			},
+			expected: entities.OrderRequirements{
+				TransportTypes: []entities.CourierTransportType{entities.Scooter, entities.Car},
+			},
+		},
+		{
+			name:  "Дорогой заказ - пеший курьер не подходит",
+			rules: rules,
+			order: &entities.Order{
+				Items:      []entities.OrderItem{{Name: "Торт", Price: 1200000, Quantity: 1}},
+				TotalPrice: 1200000,
+			},
+			expected: entities.OrderRequirements{
+				TransportTypes: []entities.CourierTransportType{entities.Scooter, entities.Car},
+			},
+		},
+		{
+			name:  "Без правил требований нет",
+			rules: order_requirements.Rules{},
+			order: &entities.Order{
+				Items:      []entities.OrderItem{{Name: "Пицца", Price: 59000, Quantity: 20}},
+				TotalPrice: 1180000,
+			},
+			expected: entities.OrderRequirements{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			factory := order_requirements.New(tt.rules)
+
+			assert.Equal(t, tt.expected, factory.Derive(tt.order))
+		})
+	}
+}
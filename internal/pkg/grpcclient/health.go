@@ -0,0 +1,33 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"service/pkg/health"
+)
+
+// ConnStateCheck проверяет состояние соединения без вызова бизнес-методов order-service.
+// Простаивающее соединение будится и проверка ждет, пока оно станет Ready или истечет таймаут.
+func ConnStateCheck(conn *grpc.ClientConn) health.CheckFunc {
+	return func(ctx context.Context) error {
+		for {
+			state := conn.GetState()
+			switch state {
+			case connectivity.Ready:
+				return nil
+			case connectivity.Shutdown:
+				return errors.New("gRPC connection is shut down")
+			case connectivity.Idle:
+				conn.Connect()
+			}
+
+			if !conn.WaitForStateChange(ctx, state) {
+				return fmt.Errorf("gRPC connection state %s: %w", state, ctx.Err())
+			}
+		}
+	}
+}
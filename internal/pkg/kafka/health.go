@@ -0,0 +1,57 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/IBM/sarama"
+	"service/internal/pkg/config"
+)
+
+// MetadataChecker запрашивает у брокеров метаданные топика.
+// Клиент отдельный от consumer group: у sarama.ConsumerGroup нет доступа к метаданным.
+type MetadataChecker struct {
+	client sarama.Client
+	topic  string
+}
+
+func NewMetadataChecker(cfg *config.Kafka, brokers []string) (*MetadataChecker, error) {
+	saramaConfig, err := NewSaramaConfig(
+		cfg.Sarama.Version,
+		cfg.Sarama.ConsumerOffsetsAutocommit,
+		sarama.OffsetOldest,
+		sarama.NewBalanceStrategyRoundRobin(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("build saramaConfig: %w", err)
+	}
+
+	client, err := sarama.NewClient(brokers, saramaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+
+	return &MetadataChecker{
+		client: client,
+		topic:  cfg.Topic,
+	}, nil
+}
+
+// Check sarama не принимает контекст, поэтому таймаут соблюдается на стороне health.Registry
+func (c *MetadataChecker) Check(_ context.Context) error {
+	err := c.client.RefreshMetadata(c.topic)
+	if err != nil {
+		return fmt.Errorf("refresh metadata: %w", err)
+	}
+
+	if len(c.client.Brokers()) == 0 {
+		return errors.New("no kafka brokers available")
+	}
+
+	return nil
+}
+
+func (c *MetadataChecker) Close() error {
+	return c.client.Close()
+}
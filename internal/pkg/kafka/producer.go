@@ -0,0 +1,31 @@
+package kafka
+
+import (
+	"fmt"
+
+	"github.com/IBM/sarama"
+	"service/internal/pkg/config"
+)
+
+// NewSyncProducer продюсер событий сервиса: SendMessages возвращается после подтверждения всеми in-sync репликами
+func NewSyncProducer(cfg *config.Kafka, brokers []string) (sarama.SyncProducer, error) {
+	saramaConfig, err := NewSaramaConfig(
+		cfg.Sarama.Version,
+		cfg.Sarama.ConsumerOffsetsAutocommit,
+		sarama.OffsetOldest,
+		sarama.NewBalanceStrategyRoundRobin(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("build saramaConfig: %w", err)
+	}
+
+	saramaConfig.Producer.Return.Successes = true
+	saramaConfig.Producer.RequiredAcks = sarama.WaitForAll
+
+	producer, err := sarama.NewSyncProducer(brokers, saramaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka producer: %w", err)
+	}
+
+	return producer, nil
+}
@@ -0,0 +1,22 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=idempotency_test
+package idempotency
+
+import (
+	"context"
+
+	"service/internal/entities"
+	"service/pkg/logger"
+)
+
+type Service interface {
+	Begin(ctx context.Context, key, scope, fingerprint string) (*entities.IdempotencyKey, error)
+	Complete(ctx context.Context, key, scope string, statusCode int, contentType string, body []byte) error
+	Release(ctx context.Context, key, scope string) error
+}
+
+type handlerLogger interface {
+	Info(msg string, fields ...logger.Field)
+	Warn(msg string, fields ...logger.Field)
+	Error(msg string, fields ...logger.Field)
+	With(fields ...logger.Field) logger.Logger
+}
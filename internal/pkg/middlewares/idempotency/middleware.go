@@ -0,0 +1,159 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"service/internal/service/idempotency"
+	"service/pkg/logger"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	HeaderReplayed       = "Idempotent-Replayed"
+
+	// ответ сохраняется и после отмены контекста запроса (таймаут, обрыв соединения),
+	// иначе ключ остался бы "в процессе" до истечения TTL
+	saveResponseTimeout = 5 * time.Second
+)
+
+// Middleware применяется к отдельным POST эндпоинтам. Запросы без заголовка Idempotency-Key
+// обрабатываются как обычно. Повтор с тем же ключом и телом получает сохраненный ответ,
+// с другим телом - 422. Ответы 5xx не сохраняются: ключ освобождается, и запрос можно повторить.
+func Middleware(log handlerLogger, service Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(HeaderIdempotencyKey)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			scope := requestScope(r)
+			stored, err := service.Begin(r.Context(), key, scope, fingerprint(body))
+			if err != nil {
+				switch {
+				case errors.Is(err, idempotency.ErrInvalidIdempotencyKey):
+					w.WriteHeader(http.StatusBadRequest)
+				case errors.Is(err, idempotency.ErrFingerprintMismatch):
+					w.WriteHeader(http.StatusUnprocessableEntity)
+				case errors.Is(err, idempotency.ErrRequestInProgress):
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(http.StatusConflict)
+				default:
+					log.With(
+						logger.NewField("error", err),
+						logger.NewField("route", scope),
+					).Error("begin idempotent request")
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+				return
+			}
+
+			if stored != nil {
+				replay(log, w, stored.StatusCode, stored.ContentType, stored.ResponseBody)
+				return
+			}
+
+			recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+			saveCtx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), saveResponseTimeout)
+			defer cancel()
+
+			defer func() {
+				if p := recover(); p != nil {
+					releaseKey(saveCtx, log, service, key, scope)
+					panic(p)
+				}
+			}()
+
+			next.ServeHTTP(recorder, r)
+
+			if recorder.statusCode >= http.StatusInternalServerError {
+				releaseKey(saveCtx, log, service, key, scope)
+				return
+			}
+
+			err = service.Complete(saveCtx, key, scope, recorder.statusCode, recorder.Header().Get("Content-Type"), recorder.body.Bytes())
+			if err != nil {
+				log.With(
+					logger.NewField("error", err),
+					logger.NewField("route", scope),
+				).Error("save idempotent response")
+			}
+		})
+	}
+}
+
+// requestScope ключ действует в пределах метода и пути запроса. Путь берется вместе с параметрами,
+// а не шаблон маршрута: тот же ключ и то же тело для другого курьера - другой запрос
+func requestScope(r *http.Request) string {
+	return r.Method + " " + r.URL.Path
+}
+
+// fingerprint отпечаток тела запроса. JSON приводится к компактному виду,
+// чтобы повтор с другими отступами не считался другим запросом
+func fingerprint(body []byte) string {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, body); err == nil {
+		body = compacted.Bytes()
+	}
+
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+func replay(log handlerLogger, w http.ResponseWriter, statusCode int, contentType string, body []byte) {
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set(HeaderReplayed, "true")
+	w.WriteHeader(statusCode)
+
+	_, err := w.Write(body)
+	if err != nil {
+		log.With(
+			logger.NewField("error", err),
+		).Error("write idempotent response")
+	}
+}
+
+func releaseKey(ctx context.Context, log handlerLogger, service Service, key, scope string) {
+	err := service.Release(ctx, key, scope)
+	if err != nil {
+		log.With(
+			logger.NewField("error", err),
+			logger.NewField("route", scope),
+		).Error("release idempotency key")
+	}
+}
+
+// responseRecorder передает ответ клиенту и одновременно запоминает его для повторов
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (rw *responseRecorder) WriteHeader(code int) {
+	rw.statusCode = code
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *responseRecorder) Write(b []byte) (int, error) {
+	rw.body.Write(b)
+	return rw.ResponseWriter.Write(b)
+}
@@ -0,0 +1,278 @@
+package idempotency_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/pkg/middlewares/idempotency"
+	service "service/internal/service/idempotency"
+)
+
+const (
+	testKey   = "key-2026-001"
+	testRoute = "/courier/{id}/cash/handover"
+)
+
+type mock struct {
+	*MockService
+	*MockhandlerLogger
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockService:       NewMockService(ctrl),
+		MockhandlerLogger: NewMockhandlerLogger(ctrl),
+	}
+}
+
+// newRouter оборачивает обработчик так же, как в main: middleware на маршруте с параметром пути
+func newRouter(m *mock, handler http.HandlerFunc) *mux.Router {
+	router := mux.NewRouter()
+	router.Handle(testRoute, idempotency.Middleware(m.MockhandlerLogger, m.MockService)(handler)).Methods(http.MethodPost)
+	return router
+}
+
+func newRequest(path, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(idempotency.HeaderIdempotencyKey, testKey)
+	return req
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	const (
+		path  = "/courier/1/cash/handover"
+		scope = "POST /courier/1/cash/handover"
+		body  = `{"amount": 100000}`
+	)
+
+	created := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"ID":5}`))
+	}
+
+	tests := []struct {
+		name             string
+		handler          http.HandlerFunc
+		mockSetup        func(m *mock)
+		expectedStatus   int
+		expectedBody     string
+		expectedReplayed bool
+		handlerCalled    bool
+	}{
+		{
+			name:    "Первый запрос выполняется, ответ сохраняется",
+			handler: created,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					Begin(gomock.Any(), testKey, scope, gomock.Any()).
+					Return(nil, nil)
+				m.MockService.EXPECT().
+					Complete(gomock.Any(), testKey, scope, http.StatusCreated, "application/json", []byte(`{"ID":5}`)).
+					Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"ID":5}`,
+			handlerCalled:  true,
+		},
+		{
+			name:    "Повтор получает сохраненный ответ без вызова обработчика",
+			handler: created,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					Begin(gomock.Any(), testKey, scope, gomock.Any()).
+					Return(&entities.IdempotencyKey{
+						Key:          testKey,
+						Scope:        scope,
+						StatusCode:   http.StatusCreated,
+						ContentType:  "application/json",
+						ResponseBody: []byte(`{"ID":5}`),
+					}, nil)
+			},
+			expectedStatus:   http.StatusCreated,
+			expectedBody:     `{"ID":5}`,
+			expectedReplayed: true,
+		},
+		{
+			name:    "Тот же ключ с другим телом",
+			handler: created,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					Begin(gomock.Any(), testKey, scope, gomock.Any()).
+					Return(nil, service.ErrFingerprintMismatch)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:    "Запрос с тем же ключом еще выполняется",
+			handler: created,
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					Begin(gomock.Any(), testKey, scope, gomock.Any()).
+					Return(nil, service.ErrRequestInProgress)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "Ответ 5xx не сохраняется, ключ освобождается",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			mockSetup: func(m *mock) {
+				m.MockService.EXPECT().
+					Begin(gomock.Any(), testKey, scope, gomock.Any()).
+					Return(nil, nil)
+				m.MockService.EXPECT().
+					Release(gomock.Any(), testKey, scope).
+					Return(nil)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			handlerCalled:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			tt.mockSetup(m)
+
+			handlerCalled := false
+			router := newRouter(m, func(w http.ResponseWriter, r *http.Request) {
+				handlerCalled = true
+				tt.handler(w, r)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newRequest(path, body))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.handlerCalled, handlerCalled)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			}
+			if tt.expectedReplayed {
+				assert.Equal(t, "true", w.Header().Get(idempotency.HeaderReplayed))
+			} else {
+				assert.Empty(t, w.Header().Get(idempotency.HeaderReplayed))
+			}
+		})
+	}
+}
+
+func TestMiddleware_ScopeByPath(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	m := newMock(ctrl)
+
+	// тот же ключ и то же тело для разных курьеров одного шаблона маршрута - разные запросы
+	body := `{"amount": 100000}`
+	for _, scope := range []string{"POST /courier/1/cash/handover", "POST /courier/2/cash/handover"} {
+		m.MockService.EXPECT().
+			Begin(gomock.Any(), testKey, scope, gomock.Any()).
+			Return(nil, nil)
+		m.MockService.EXPECT().
+			Complete(gomock.Any(), testKey, scope, http.StatusCreated, "", gomock.Any()).
+			Return(nil)
+	}
+
+	var handled []string
+	router := newRouter(m, func(w http.ResponseWriter, r *http.Request) {
+		handled = append(handled, mux.Vars(r)["id"])
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	for _, path := range []string{"/courier/1/cash/handover", "/courier/2/cash/handover"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest(path, body))
+		assert.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	assert.Equal(t, []string{"1", "2"}, handled)
+}
+
+func TestMiddleware_FingerprintMismatch(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	m := newMock(ctrl)
+
+	const scope = "POST /courier/1/cash/handover"
+	stored := &entities.IdempotencyKey{Key: testKey, Scope: scope}
+
+	// сервис сравнивает отпечаток повтора с отпечатком первого запроса
+	m.MockService.EXPECT().
+		Begin(gomock.Any(), testKey, scope, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key, scope, fingerprint string) (*entities.IdempotencyKey, error) {
+			if stored.Fingerprint == "" {
+				stored.Fingerprint = fingerprint
+				return nil, nil
+			}
+			if stored.Fingerprint != fingerprint {
+				return nil, service.ErrFingerprintMismatch
+			}
+			return stored, nil
+		}).
+		Times(3)
+	m.MockService.EXPECT().
+		Complete(gomock.Any(), testKey, scope, http.StatusCreated, "", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key, scope string, statusCode int, contentType string, body []byte) error {
+			stored.StatusCode = statusCode
+			return nil
+		})
+
+	router := newRouter(m, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	tests := []struct {
+		body           string
+		expectedStatus int
+	}{
+		{body: `{"amount": 100000}`, expectedStatus: http.StatusCreated},
+		// другие отступы - тот же запрос
+		{body: `{"amount":100000}`, expectedStatus: http.StatusCreated},
+		{body: `{"amount": 200000}`, expectedStatus: http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newRequest("/courier/1/cash/handover", tt.body))
+		assert.Equal(t, tt.expectedStatus, w.Code, tt.body)
+	}
+}
+
+func TestMiddleware_WithoutKey(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	m := newMock(ctrl)
+
+	var receivedBody string
+	router := newRouter(m, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		receivedBody = string(b)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	req := newRequest("/courier/1/cash/handover", `{"amount": 100000}`)
+	req.Header.Del(idempotency.HeaderIdempotencyKey)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, `{"amount": 100000}`, receivedBody)
+}
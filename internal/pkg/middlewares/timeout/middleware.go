@@ -6,9 +6,21 @@ import (
 	"time"
 )
 
-func Middleware(timout time.Duration) func(http.Handler) http.Handler {
+// Middleware ограничивает время обработки запроса. Потоковые ответы из skipPaths
+// (например, выгрузка всех курьеров) идут дольше обычного запроса и не ограничиваются
+func Middleware(timout time.Duration, skipPaths ...string) func(http.Handler) http.Handler {
+	skip := make(map[string]struct{}, len(skipPaths))
+	for _, path := range skipPaths {
+		skip[path] = struct{}{}
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := skip[r.URL.Path]; ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			// r.Context() = ongoingCtx (из BaseContext)
 			ctx, cancel := context.WithTimeout(r.Context(), timout)
 			defer cancel()
@@ -0,0 +1,131 @@
+package phone
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrInvalidNumber     = errors.New("invalid phone number")
+	ErrUnsupportedRegion = errors.New("unsupported phone region")
+)
+
+const (
+	// по E.164 в номере не больше 15 цифр вместе с кодом страны
+	maxE164Digits = 15
+	minE164Digits = 8
+)
+
+// region правила набора номера внутри страны
+type region struct {
+	callingCode string
+	// trunkPrefix набирается перед национальным номером внутри страны, например 8 в России
+	trunkPrefix string
+	// internationalPrefixes выход на международную линию вместо +
+	internationalPrefixes []string
+	nationalLengths       []int
+}
+
+var regions = map[string]region{
+	"RU": {callingCode: "7", trunkPrefix: "8", internationalPrefixes: []string{"810", "00"}, nationalLengths: []int{10}},
+	"KZ": {callingCode: "7", trunkPrefix: "8", internationalPrefixes: []string{"810", "00"}, nationalLengths: []int{10}},
+	"BY": {callingCode: "375", trunkPrefix: "80", internationalPrefixes: []string{"810", "00"}, nationalLengths: []int{9}},
+	"UA": {callingCode: "380", trunkPrefix: "0", internationalPrefixes: []string{"00"}, nationalLengths: []int{9}},
+	"UZ": {callingCode: "998", trunkPrefix: "", internationalPrefixes: []string{"00"}, nationalLengths: []int{9}},
+	"US": {callingCode: "1", trunkPrefix: "1", internationalPrefixes: []string{"011"}, nationalLengths: []int{10}},
+	"GB": {callingCode: "44", trunkPrefix: "0", internationalPrefixes: []string{"00"}, nationalLengths: []int{9, 10}},
+}
+
+// Normalizer приводит номера к E.164. Номер без кода страны считается номером региона по умолчанию
+type Normalizer struct {
+	defaultRegion region
+}
+
+func NewNormalizer(defaultRegion string) (*Normalizer, error) {
+	r, ok := regions[strings.ToUpper(defaultRegion)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedRegion, defaultRegion)
+	}
+
+	return &Normalizer{defaultRegion: r}, nil
+}
+
+// Normalize возвращает номер в E.164, например "8 (900) 000-00-00" -> "+79000000000" для RU.
+// Пробелы, дефисы, точки и скобки отбрасываются, любые другие символы делают номер невалидным
+func (n *Normalizer) Normalize(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	international := strings.HasPrefix(raw, "+")
+	if international {
+		raw = raw[1:]
+	}
+
+	digits, ok := stripFormatting(raw)
+	if !ok || digits == "" {
+		return "", ErrInvalidNumber
+	}
+
+	if international {
+		return normalizeInternational(digits)
+	}
+	return n.normalizeNational(digits)
+}
+
+func (n *Normalizer) normalizeNational(digits string) (string, error) {
+	r := n.defaultRegion
+
+	if r.trunkPrefix != "" {
+		if national, ok := strings.CutPrefix(digits, r.trunkPrefix); ok && slices.Contains(r.nationalLengths, len(national)) {
+			return "+" + r.callingCode + national, nil
+		}
+	}
+	// код страны без плюса: 79000000000
+	if national, ok := strings.CutPrefix(digits, r.callingCode); ok && slices.Contains(r.nationalLengths, len(national)) {
+		return "+" + r.callingCode + national, nil
+	}
+	if slices.Contains(r.nationalLengths, len(digits)) {
+		return "+" + r.callingCode + digits, nil
+	}
+	for _, prefix := range r.internationalPrefixes {
+		if number, ok := strings.CutPrefix(digits, prefix); ok {
+			return normalizeInternational(number)
+		}
+	}
+
+	return "", ErrInvalidNumber
+}
+
+// normalizeInternational проверяет номер с кодом страны. Длину национальной части
+// можно проверить только для известных регионов, для остальных проверяется длина E.164
+func normalizeInternational(digits string) (string, error) {
+	if len(digits) < minE164Digits || len(digits) > maxE164Digits || digits[0] == '0' {
+		return "", ErrInvalidNumber
+	}
+
+	for _, r := range regions {
+		national, ok := strings.CutPrefix(digits, r.callingCode)
+		if ok && !slices.Contains(r.nationalLengths, len(national)) {
+			return "", ErrInvalidNumber
+		}
+	}
+
+	return "+" + digits, nil
+}
+
+func stripFormatting(raw string) (string, bool) {
+	var b strings.Builder
+	b.Grow(len(raw))
+
+	for _, char := range raw {
+		switch {
+		case char >= '0' && char <= '9':
+			b.WriteRune(char)
+		case char == ' ', char == '-', char == '.', char == '(', char == ')':
+		default:
+			return "", false
+		}
+	}
+
+	return b.String(), true
+}
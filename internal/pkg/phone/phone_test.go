@@ -0,0 +1,73 @@
+package phone_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"service/internal/pkg/phone"
+)
+
+func TestNewNormalizer(t *testing.T) {
+	t.Parallel()
+
+	_, err := phone.NewNormalizer("ru")
+	require.NoError(t, err, "регион не зависит от регистра")
+
+	_, err = phone.NewNormalizer("XX")
+	require.ErrorIs(t, err, phone.ErrUnsupportedRegion)
+}
+
+func TestNormalizer_Normalize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		region        string
+		raw           string
+		expected      string
+		expectedError error
+	}{
+		{name: "Уже в E.164", region: "RU", raw: "+79000000000", expected: "+79000000000"},
+		{name: "С пробелами и дефисами", region: "RU", raw: "+7 900 000-00-00", expected: "+79000000000"},
+		{name: "Через 8", region: "RU", raw: "89000000000", expected: "+79000000000"},
+		{name: "Через 8 со скобками", region: "RU", raw: "8 (900) 000-00-00", expected: "+79000000000"},
+		{name: "Код страны без плюса", region: "RU", raw: "79000000000", expected: "+79000000000"},
+		{name: "Национальный номер без префикса", region: "RU", raw: "9000000000", expected: "+79000000000"},
+		{name: "Пробелы по краям", region: "RU", raw: "  +79000000000 ", expected: "+79000000000"},
+		{name: "Международный номер из России через 810", region: "RU", raw: "810 44 20 7946 0958", expected: "+442079460958"},
+		{name: "Международный номер через 00", region: "RU", raw: "00 375 29 123 45 67", expected: "+375291234567"},
+		{name: "Номер другой страны с плюсом", region: "RU", raw: "+44 20 7946 0958", expected: "+442079460958"},
+		{name: "Неизвестный код страны проверяется только по длине E.164", region: "RU", raw: "+33 1 23 45 67 89", expected: "+33123456789"},
+		{name: "Белорусский номер через 80", region: "BY", raw: "80 29 123-45-67", expected: "+375291234567"},
+		{name: "Британский номер через 0", region: "GB", raw: "020 7946 0958", expected: "+442079460958"},
+		{name: "Американский номер", region: "US", raw: "(202) 555-0123", expected: "+12025550123"},
+		{name: "Пустая строка", region: "RU", raw: "", expectedError: phone.ErrInvalidNumber},
+		{name: "Только плюс", region: "RU", raw: "+", expectedError: phone.ErrInvalidNumber},
+		{name: "Буквы", region: "RU", raw: "+7abc1234567", expectedError: phone.ErrInvalidNumber},
+		{name: "Плюс в середине", region: "RU", raw: "7+9000000000", expectedError: phone.ErrInvalidNumber},
+		{name: "Короткий российский номер", region: "RU", raw: "+7900000000", expectedError: phone.ErrInvalidNumber},
+		{name: "Длинный российский номер", region: "RU", raw: "+790000000000", expectedError: phone.ErrInvalidNumber},
+		{name: "Неверная длина без кода страны", region: "RU", raw: "123", expectedError: phone.ErrInvalidNumber},
+		{name: "Длиннее 15 цифр", region: "RU", raw: "+3312345678901234", expectedError: phone.ErrInvalidNumber},
+		{name: "Код страны не начинается с нуля", region: "RU", raw: "+0123456789", expectedError: phone.ErrInvalidNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			normalizer, err := phone.NewNormalizer(tt.region)
+			require.NoError(t, err)
+
+			normalized, err := normalizer.Normalize(tt.raw)
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, normalized)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package repository
+
+import "service/internal/entities"
+
+// AddressDB адрес хранится в JSONB колонке, pgx сериализует его через encoding/json
+type AddressDB struct {
+	Street    string `json:"street"`
+	House     string `json:"house"`
+	Apartment string `json:"apartment,omitempty"`
+	Floor     string `json:"floor,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+func AddressToDomain(a *AddressDB) *entities.Address {
+	if a == nil {
+		return nil
+	}
+
+	return &entities.Address{
+		Street:    a.Street,
+		House:     a.House,
+		Apartment: a.Apartment,
+		Floor:     a.Floor,
+		Comment:   a.Comment,
+	}
+}
+
+func AddressFromDomain(a *entities.Address) *AddressDB {
+	if a == nil {
+		return nil
+	}
+
+	return &AddressDB{
+		Street:    a.Street,
+		House:     a.House,
+		Apartment: a.Apartment,
+		Floor:     a.Floor,
+		Comment:   a.Comment,
+	}
+}
@@ -0,0 +1,211 @@
+package cash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"service/internal/entities"
+	"service/internal/service/cash"
+)
+
+type Repository struct {
+	querier Querier
+}
+
+func New(querier Querier) *Repository {
+	return &Repository{
+		querier: querier,
+	}
+}
+
+// GetCashDelivery последняя выполненная доставка заказа и наличные, полученные курьером от клиента
+func (r *Repository) GetCashDelivery(ctx context.Context, orderID string) (*entities.CashDelivery, error) {
+	query := `
+		SELECT order_id, courier_id, cash_amount
+		FROM delivery
+		WHERE order_id = $1 AND completed_at IS NOT NULL
+		ORDER BY assigned_at DESC
+		LIMIT 1
+	`
+
+	var deliveryDB CashDeliveryDB
+	err := r.querier.QueryRow(ctx, query, orderID).Scan(
+		&deliveryDB.OrderID,
+		&deliveryDB.CourierID,
+		&deliveryDB.CashAmount,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cash.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("unexpected cash repository get delivery error: %w", err)
+	}
+
+	return ToDomainCashDelivery(&deliveryDB), nil
+}
+
+// CreateCollection сохраняет получение наличных за заказ, если за заказ еще ничего не зачислено
+func (r *Repository) CreateCollection(
+	ctx context.Context,
+	cashDelivery entities.CashDelivery,
+	collectedAt time.Time,
+) (bool, error) {
+	query := `
+		INSERT INTO courier_cash_transactions (courier_id, kind, amount, order_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (order_id) WHERE order_id IS NOT NULL DO NOTHING
+	`
+
+	tag, err := r.querier.Exec(
+		ctx,
+		query,
+		cashDelivery.CourierID,
+		entities.CashCollected.String(),
+		cashDelivery.CashAmount,
+		cashDelivery.OrderID,
+		collectedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("unexpected cash repository create collection error: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *Repository) CreateHandover(
+	ctx context.Context,
+	params entities.CashHandoverParams,
+	handedOverAt time.Time,
+) (*entities.CashTransaction, error) {
+	query := `
+		INSERT INTO courier_cash_transactions (courier_id, kind, amount, operator, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, courier_id, kind, amount, order_id, operator, created_at
+	`
+
+	var transactionDB CashTransactionDB
+	err := r.querier.QueryRow(
+		ctx,
+		query,
+		params.CourierID,
+		entities.CashHandedOver.String(),
+		params.Amount,
+		params.Operator,
+		handedOverAt,
+	).Scan(
+		&transactionDB.ID,
+		&transactionDB.CourierID,
+		&transactionDB.Kind,
+		&transactionDB.Amount,
+		&transactionDB.OrderID,
+		&transactionDB.Operator,
+		&transactionDB.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected cash repository create handover error: %w", err)
+	}
+
+	return ToDomainTransaction(&transactionDB), nil
+}
+
+// CourierExists курьер есть, даже если деактивирован: наличные на руках остаются и после деактивации
+func (r *Repository) CourierExists(ctx context.Context, courierID int64) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM couriers WHERE id = $1)
+	`
+
+	var exists bool
+	err := r.querier.QueryRow(ctx, query, courierID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("unexpected cash repository courier exists error: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *Repository) GetBalanceForUpdate(ctx context.Context, courierID int64) (int64, error) {
+	query := `
+		SELECT balance
+		FROM courier_cash_balances
+		WHERE courier_id = $1
+		FOR UPDATE
+	`
+
+	var balance int64
+	err := r.querier.QueryRow(ctx, query, courierID).Scan(&balance)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("unexpected cash repository get balance error: %w", err)
+	}
+
+	return balance, nil
+}
+
+// AddBalance первое движение наличных курьера создает его остаток
+func (r *Repository) AddBalance(ctx context.Context, courierID int64, delta int64, updatedAt time.Time) (int64, error) {
+	query := `
+		INSERT INTO courier_cash_balances (courier_id, balance, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (courier_id) DO UPDATE
+		SET balance = courier_cash_balances.balance + EXCLUDED.balance,
+			updated_at = EXCLUDED.updated_at
+		RETURNING balance
+	`
+
+	var balance int64
+	err := r.querier.QueryRow(ctx, query, courierID, delta, updatedAt).Scan(&balance)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected cash repository add balance error: %w", err)
+	}
+
+	return balance, nil
+}
+
+// GetOutstandingBalances курьеры с несданными наличными, от большего остатка к меньшему
+func (r *Repository) GetOutstandingBalances(ctx context.Context) ([]entities.CourierCashBalance, error) {
+	query := `
+		SELECT b.courier_id, c.name, b.balance,
+			(SELECT MAX(t.created_at) FROM courier_cash_transactions t
+				WHERE t.courier_id = b.courier_id AND t.kind = 'collected'),
+			(SELECT MAX(t.created_at) FROM courier_cash_transactions t
+				WHERE t.courier_id = b.courier_id AND t.kind = 'handover')
+		FROM courier_cash_balances b
+		JOIN couriers c ON c.id = b.courier_id
+		WHERE b.balance > 0
+		ORDER BY b.balance DESC, b.courier_id
+	`
+
+	rows, err := r.querier.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected cash repository get outstanding balances error: %w", err)
+	}
+	defer rows.Close()
+
+	balanceModels := make([]CourierCashBalanceDB, 0)
+	for rows.Next() {
+		var balanceDB CourierCashBalanceDB
+		err := rows.Scan(
+			&balanceDB.CourierID,
+			&balanceDB.CourierName,
+			&balanceDB.Balance,
+			&balanceDB.LastCollectedAt,
+			&balanceDB.LastHandoverAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected cash repository get outstanding balances error: %w", err)
+		}
+		balanceModels = append(balanceModels, balanceDB)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected cash repository get outstanding balances error: %w", err)
+	}
+
+	return ToDomainBalanceList(balanceModels), nil
+}
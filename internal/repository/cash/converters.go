@@ -0,0 +1,56 @@
+package cash
+
+import "service/internal/entities"
+
+func ToDomainCashDelivery(d *CashDeliveryDB) *entities.CashDelivery {
+	if d == nil {
+		return nil
+	}
+
+	return &entities.CashDelivery{
+		OrderID:    d.OrderID,
+		CourierID:  d.CourierID,
+		CashAmount: d.CashAmount,
+	}
+}
+
+func ToDomainTransaction(t *CashTransactionDB) *entities.CashTransaction {
+	if t == nil {
+		return nil
+	}
+
+	transaction := &entities.CashTransaction{
+		ID:        t.ID,
+		CourierID: t.CourierID,
+		Kind:      entities.CashTransactionKind(t.Kind),
+		Amount:    t.Amount,
+		CreatedAt: t.CreatedAt,
+	}
+	if t.OrderID != nil {
+		transaction.OrderID = *t.OrderID
+	}
+	if t.Operator != nil {
+		transaction.Operator = *t.Operator
+	}
+
+	return transaction
+}
+
+func ToDomainBalanceList(balancesDB []CourierCashBalanceDB) []entities.CourierCashBalance {
+	if len(balancesDB) == 0 {
+		return []entities.CourierCashBalance{}
+	}
+
+	result := make([]entities.CourierCashBalance, len(balancesDB))
+	for i, b := range balancesDB {
+		result[i] = entities.CourierCashBalance{
+			CourierID:       b.CourierID,
+			CourierName:     b.CourierName,
+			Balance:         b.Balance,
+			LastCollectedAt: b.LastCollectedAt,
+			LastHandoverAt:  b.LastHandoverAt,
+		}
+	}
+
+	return result
+}
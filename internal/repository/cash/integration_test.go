@@ -0,0 +1,164 @@
+//go:build integration
+
+package cash_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"service/internal/entities"
+	"service/internal/repository/cash"
+	"service/internal/repository/integration_test"
+	service "service/internal/service/cash"
+)
+
+const cashSetupSql = `
+	INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+	VALUES
+		(1, 'Courier 1', '+79991112233', 'available', 'scooter', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+		(2, 'Courier 2', '+79991112244', 'available', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+		(3, 'Courier 3', '+79991112255', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+	INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline, completed_at, cash_amount)
+	VALUES
+		(1, 'order-1', '2025-01-15 12:00:00', '2025-01-15 12:00:00', '2025-01-15 12:30:00', '2025-01-15 12:20:00', 150000),
+		(2, 'order-2', '2025-01-15 12:10:00', '2025-01-15 12:10:00', '2025-01-15 12:40:00', '2025-01-15 12:35:00', 0),
+		(1, 'order-3', '2025-01-15 13:00:00', '2025-01-15 13:00:00', '2025-01-15 13:30:00', NULL, 90000);
+`
+
+func TestRepository_GetCashDelivery(t *testing.T) {
+	integration_test.SetupDB(t, cashSetupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := cash.New(q)
+	ctx := context.Background()
+
+	t.Run("Доставка с оплатой наличными", func(t *testing.T) {
+		actual, err := repo.GetCashDelivery(ctx, "order-1")
+		require.NoError(t, err)
+		assert.Equal(t, &entities.CashDelivery{OrderID: "order-1", CourierID: 1, CashAmount: 150000}, actual)
+	})
+
+	t.Run("Доставка без наличных", func(t *testing.T) {
+		actual, err := repo.GetCashDelivery(ctx, "order-2")
+		require.NoError(t, err)
+		assert.Zero(t, actual.CashAmount)
+	})
+
+	t.Run("Доставка еще не выполнена", func(t *testing.T) {
+		_, err := repo.GetCashDelivery(ctx, "order-3")
+		assert.ErrorIs(t, err, service.ErrDeliveryNotFound)
+	})
+}
+
+func TestRepository_Transactions(t *testing.T) {
+	integration_test.SetupDB(t, cashSetupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := cash.New(q)
+	ctx := context.Background()
+	collectedAt := time.Date(2025, 1, 15, 12, 20, 0, 0, time.UTC)
+
+	t.Run("Наличные за заказ зачисляются один раз", func(t *testing.T) {
+		cashDelivery := entities.CashDelivery{OrderID: "order-1", CourierID: 1, CashAmount: 150000}
+
+		created, err := repo.CreateCollection(ctx, cashDelivery, collectedAt)
+		require.NoError(t, err)
+		assert.True(t, created)
+
+		created, err = repo.CreateCollection(ctx, cashDelivery, collectedAt)
+		require.NoError(t, err)
+		assert.False(t, created)
+	})
+
+	t.Run("Остаток без движений", func(t *testing.T) {
+		balance, err := repo.GetBalanceForUpdate(ctx, 1)
+		require.NoError(t, err)
+		assert.Zero(t, balance)
+	})
+
+	t.Run("Изменение остатка", func(t *testing.T) {
+		balance, err := repo.AddBalance(ctx, 1, 150000, collectedAt)
+		require.NoError(t, err)
+		assert.Equal(t, int64(150000), balance)
+
+		balance, err = repo.AddBalance(ctx, 1, -50000, collectedAt.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(100000), balance)
+
+		balance, err = repo.GetBalanceForUpdate(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(100000), balance)
+	})
+
+	t.Run("Остаток не уходит в минус", func(t *testing.T) {
+		_, err := repo.AddBalance(ctx, 1, -200000, collectedAt.Add(time.Hour))
+		assert.Error(t, err)
+	})
+
+	t.Run("Сдача наличных", func(t *testing.T) {
+		handedOverAt := collectedAt.Add(time.Hour)
+
+		actual, err := repo.CreateHandover(ctx, entities.CashHandoverParams{
+			CourierID: 1,
+			Amount:    50000,
+			Operator:  "Кассир Петрова",
+		}, handedOverAt)
+		require.NoError(t, err)
+		assert.NotZero(t, actual.ID)
+		assert.Equal(t, entities.CashHandedOver, actual.Kind)
+		assert.Equal(t, int64(50000), actual.Amount)
+		assert.Equal(t, "Кассир Петрова", actual.Operator)
+		assert.Empty(t, actual.OrderID)
+		assert.Equal(t, handedOverAt, actual.CreatedAt.UTC())
+	})
+
+	t.Run("Курьер существует", func(t *testing.T) {
+		exists, err := repo.CourierExists(ctx, 1)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = repo.CourierExists(ctx, 999)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestRepository_GetOutstandingBalances(t *testing.T) {
+	integration_test.SetupDB(t, cashSetupSql+`
+		INSERT INTO courier_cash_transactions (courier_id, kind, amount, order_id, operator, created_at)
+		VALUES
+			(1, 'collected', 150000, 'order-1', NULL, '2025-01-15 12:20:00'),
+			(1, 'handover', 50000, NULL, 'Кассир Петрова', '2025-01-15 14:00:00'),
+			(2, 'collected', 300000, 'order-4', NULL, '2025-01-15 15:00:00'),
+			(3, 'collected', 40000, 'order-5', NULL, '2025-01-15 15:00:00'),
+			(3, 'handover', 40000, NULL, 'Кассир Петрова', '2025-01-15 16:00:00');
+
+		INSERT INTO courier_cash_balances (courier_id, balance)
+		VALUES (1, 100000), (2, 300000), (3, 0);
+	`)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := cash.New(q)
+
+	actual, err := repo.GetOutstandingBalances(context.Background())
+	require.NoError(t, err)
+	require.Len(t, actual, 2)
+
+	assert.Equal(t, int64(2), actual[0].CourierID)
+	assert.Equal(t, "Courier 2", actual[0].CourierName)
+	assert.Equal(t, int64(300000), actual[0].Balance)
+	require.NotNil(t, actual[0].LastCollectedAt)
+	assert.Nil(t, actual[0].LastHandoverAt)
+
+	assert.Equal(t, int64(1), actual[1].CourierID)
+	assert.Equal(t, int64(100000), actual[1].Balance)
+	require.NotNil(t, actual[1].LastHandoverAt)
+	assert.Equal(t, time.Date(2025, 1, 15, 14, 0, 0, 0, time.UTC), actual[1].LastHandoverAt.UTC())
+}
@@ -0,0 +1,27 @@
+package cash
+
+import "time"
+
+type CashDeliveryDB struct {
+	OrderID    string
+	CourierID  int64
+	CashAmount int64
+}
+
+type CashTransactionDB struct {
+	ID        int64
+	CourierID int64
+	Kind      string
+	Amount    int64
+	OrderID   *string
+	Operator  *string
+	CreatedAt time.Time
+}
+
+type CourierCashBalanceDB struct {
+	CourierID       int64
+	CourierName     string
+	Balance         int64
+	LastCollectedAt *time.Time
+	LastHandoverAt  *time.Time
+}
@@ -18,6 +18,10 @@ func ToDomain(c *CourierDB) *entities.Courier {
 		TransportType: entities.CourierTransportType(c.TransportType),
 		CreatedAt:     c.CreatedAt,
 		UpdatedAt:     c.UpdatedAt,
+
+		DeactivatedAt:      c.DeactivatedAt,
+		DeactivationReason: c.DeactivationReason,
+		Version:            c.Version,
 	}
 }
 
@@ -44,6 +48,9 @@ func FromDomainModify(courierModify *entities.CourierModify) *CourierModifyDB {
 		transportType := courierModify.TransportType.String()
 		courierDB.TransportType = &transportType
 	}
+	if courierModify.ExpectedVersion != nil {
+		courierDB.ExpectedVersion = courierModify.ExpectedVersion
+	}
 
 	return courierDB
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
@@ -69,11 +70,17 @@ func (r *Repository) Update(ctx context.Context, courierModifyEntity entities.Co
 		builder = builder.Set("transport_type", courierModifyModel.TransportType)
 	}
 
-	builder = builder.Set("updated_at", sq.Expr("NOW()"))
-
 	builder = builder.
-		Where(sq.Eq{"ID": courierModifyModel.ID}).
-		Suffix("RETURNING ID, name, phone, status, transport_type, created_at, updated_at")
+		Set("updated_at", sq.Expr("NOW()")).
+		Set("version", sq.Expr("version + 1")).
+		Where(sq.Eq{"ID": courierModifyModel.ID})
+
+	// оптимистичная блокировка: курьера успели изменить - строка не обновится
+	if courierModifyModel.ExpectedVersion != nil {
+		builder = builder.Where(sq.Eq{"version": courierModifyModel.ExpectedVersion})
+	}
+
+	builder = builder.Suffix("RETURNING ID, name, phone, status, transport_type, created_at, updated_at, deactivated_at, deactivation_reason, version")
 
 	query, args, err := builder.ToSql()
 	if err != nil {
@@ -90,9 +97,15 @@ func (r *Repository) Update(ctx context.Context, courierModifyEntity entities.Co
 			&courierModel.TransportType,
 			&courierModel.CreatedAt,
 			&courierModel.UpdatedAt,
+			&courierModel.DeactivatedAt,
+			&courierModel.DeactivationReason,
+			&courierModel.Version,
 		)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
+			if courierModifyModel.ExpectedVersion != nil {
+				return nil, r.versionMismatchOrNotFound(ctx, *courierModifyModel.ID)
+			}
 			return nil, courier.ErrCourierNotFound
 		}
 
@@ -106,8 +119,24 @@ func (r *Repository) Update(ctx context.Context, courierModifyEntity entities.Co
 	return ToDomain(&courierModel), nil
 }
 
+// versionMismatchOrNotFound Update с ожидаемой версией не нашел строку: курьера нет или его уже изменили
+func (r *Repository) versionMismatchOrNotFound(ctx context.Context, id int64) error {
+	query := `SELECT EXISTS (SELECT 1 FROM couriers WHERE id = $1)`
+
+	var exists bool
+	err := r.querier.QueryRow(ctx, query, id).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("unexpected courier repository update error: %w", err)
+	}
+
+	if exists {
+		return courier.ErrVersionMismatch
+	}
+	return courier.ErrCourierNotFound
+}
+
 func (r *Repository) GetByID(ctx context.Context, id int64) (*entities.Courier, error) {
-	query := `SELECT id, name, phone, status, transport_type, created_at, updated_at
+	query := `SELECT id, name, phone, status, transport_type, created_at, updated_at, deactivated_at, deactivation_reason, version
 		FROM couriers
 		WHERE id = $1`
 
@@ -121,6 +150,9 @@ func (r *Repository) GetByID(ctx context.Context, id int64) (*entities.Courier,
 			&courierModel.TransportType,
 			&courierModel.CreatedAt,
 			&courierModel.UpdatedAt,
+			&courierModel.DeactivatedAt,
+			&courierModel.DeactivationReason,
+			&courierModel.Version,
 		)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -133,13 +165,22 @@ func (r *Repository) GetByID(ctx context.Context, id int64) (*entities.Courier,
 	return ToDomain(&courierModel), nil
 }
 
-func (r *Repository) GetAll(ctx context.Context) ([]entities.Courier, error) {
-	query := `
-	SELECT id, name, phone, status, transport_type, created_at, updated_at
-	FROM couriers
-	ORDER BY id`
+func (r *Repository) GetAll(ctx context.Context, filter entities.CourierListFilter) ([]entities.Courier, error) {
+	builder := qb.
+		Select("id, name, phone, status, transport_type, created_at, updated_at, deactivated_at, deactivation_reason, version").
+		From("couriers").
+		OrderBy("id")
+
+	if !filter.IncludeDeactivated {
+		builder = builder.Where(sq.Eq{"deactivated_at": nil})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected courier repository getall error: %w", err)
+	}
 
-	rows, err := r.querier.Query(ctx, query)
+	rows, err := r.querier.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("unexpected courier repository getall error: %w", err)
 	}
@@ -158,6 +199,9 @@ func (r *Repository) GetAll(ctx context.Context) ([]entities.Courier, error) {
 			&courierModel.TransportType,
 			&courierModel.CreatedAt,
 			&courierModel.UpdatedAt,
+			&courierModel.DeactivatedAt,
+			&courierModel.DeactivationReason,
+			&courierModel.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("unexpected courier repository getall error: %w", err)
@@ -172,3 +216,306 @@ func (r *Repository) GetAll(ctx context.Context) ([]entities.Courier, error) {
 
 	return ToDomainList(CourierModels), nil
 }
+
+// GetByIDForUpdate блокирует курьера до конца транзакции, чтобы отключение не пересеклось с назначением
+func (r *Repository) GetByIDForUpdate(ctx context.Context, id int64) (*entities.Courier, error) {
+	query := `SELECT id, name, phone, status, transport_type, created_at, updated_at, deactivated_at, deactivation_reason, version
+		FROM couriers
+		WHERE id = $1
+		FOR UPDATE`
+
+	var courierModel CourierDB
+	err := r.querier.QueryRow(ctx, query, id).
+		Scan(
+			&courierModel.ID,
+			&courierModel.Name,
+			&courierModel.Phone,
+			&courierModel.Status,
+			&courierModel.TransportType,
+			&courierModel.CreatedAt,
+			&courierModel.UpdatedAt,
+			&courierModel.DeactivatedAt,
+			&courierModel.DeactivationReason,
+			&courierModel.Version,
+		)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, courier.ErrCourierNotFound
+		}
+
+		return nil, fmt.Errorf("unexpected courier repository getbyid for update error: %w", err)
+	}
+
+	return ToDomain(&courierModel), nil
+}
+
+// HasActiveDeliveries активной считается невыполненная доставка, от которой курьер не освобожден
+// политикой авто-освобождения. Просроченная доставка остается активной: заказ все еще у курьера
+func (r *Repository) HasActiveDeliveries(ctx context.Context, id int64) (bool, error) {
+	query := `SELECT EXISTS (
+			SELECT 1
+			FROM delivery
+			WHERE courier_id = $1 AND completed_at IS NULL AND courier_released_at IS NULL
+		)`
+
+	var exists bool
+	err := r.querier.QueryRow(ctx, query, id).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("unexpected courier repository has active deliveries error: %w", err)
+	}
+
+	return exists, nil
+}
+
+// CancelPendingOffers отменяет открытое предложение заказа курьеру, false - открытого предложения не было
+func (r *Repository) CancelPendingOffers(ctx context.Context, id int64, cancelledAt time.Time) (bool, error) {
+	query := `UPDATE delivery_offers
+		SET status = 'cancelled', responded_at = $2
+		WHERE courier_id = $1 AND status = 'pending'`
+
+	result, err := r.querier.Exec(ctx, query, id, cancelledAt)
+	if err != nil {
+		return false, fmt.Errorf("unexpected courier repository cancel pending offers error: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *Repository) Deactivate(ctx context.Context, id int64, reason string, deactivatedAt time.Time) (*entities.Courier, error) {
+	query := `UPDATE couriers
+		SET deactivated_at = $2,
+			deactivation_reason = $3,
+			updated_at = NOW(),
+			version = version + 1
+		WHERE id = $1
+		RETURNING id, name, phone, status, transport_type, created_at, updated_at, deactivated_at, deactivation_reason, version`
+
+	var courierModel CourierDB
+	err := r.querier.QueryRow(ctx, query, id, deactivatedAt, reason).
+		Scan(
+			&courierModel.ID,
+			&courierModel.Name,
+			&courierModel.Phone,
+			&courierModel.Status,
+			&courierModel.TransportType,
+			&courierModel.CreatedAt,
+			&courierModel.UpdatedAt,
+			&courierModel.DeactivatedAt,
+			&courierModel.DeactivationReason,
+			&courierModel.Version,
+		)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, courier.ErrCourierNotFound
+		}
+
+		return nil, fmt.Errorf("unexpected courier repository deactivate error: %w", err)
+	}
+
+	return ToDomain(&courierModel), nil
+}
+
+// Reactivate возвращает ErrConflict, если телефон курьера уже занят другим активным курьером
+func (r *Repository) Reactivate(ctx context.Context, id int64) (*entities.Courier, error) {
+	query := `UPDATE couriers
+		SET deactivated_at = NULL,
+			deactivation_reason = NULL,
+			updated_at = NOW(),
+			version = version + 1
+		WHERE id = $1
+		RETURNING id, name, phone, status, transport_type, created_at, updated_at, deactivated_at, deactivation_reason, version`
+
+	var courierModel CourierDB
+	err := r.querier.QueryRow(ctx, query, id).
+		Scan(
+			&courierModel.ID,
+			&courierModel.Name,
+			&courierModel.Phone,
+			&courierModel.Status,
+			&courierModel.TransportType,
+			&courierModel.CreatedAt,
+			&courierModel.UpdatedAt,
+			&courierModel.DeactivatedAt,
+			&courierModel.DeactivationReason,
+			&courierModel.Version,
+		)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, courier.ErrCourierNotFound
+		}
+
+		if repository.IsPgErrorWithCode(err, repository.PgErrUniqueViolation) {
+			return nil, courier.ErrConflict
+		}
+
+		return nil, fmt.Errorf("unexpected courier repository reactivate error: %w", err)
+	}
+
+	return ToDomain(&courierModel), nil
+}
+
+// GetActivePhones какие из переданных телефонов уже заняты активными курьерами
+func (r *Repository) GetActivePhones(ctx context.Context, phones []string) (map[string]struct{}, error) {
+	query := `SELECT phone
+		FROM couriers
+		WHERE deactivated_at IS NULL AND phone = ANY($1)`
+
+	rows, err := r.querier.Query(ctx, query, phones)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected courier repository get active phones error: %w", err)
+	}
+	defer rows.Close()
+
+	active := make(map[string]struct{})
+	for rows.Next() {
+		var phone string
+		err := rows.Scan(&phone)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected courier repository get active phones error: %w", err)
+		}
+		active[phone] = struct{}{}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected courier repository get active phones error: %w", err)
+	}
+
+	return active, nil
+}
+
+// StreamAll отдает курьеров по одному, не собирая всю таблицу в памяти.
+// Ошибка из fn прерывает чтение и возвращается как есть
+func (r *Repository) StreamAll(ctx context.Context, filter entities.CourierListFilter, fn func(courier entities.Courier) error) error {
+	builder := qb.
+		Select("id, name, phone, status, transport_type, created_at, updated_at, deactivated_at, deactivation_reason, version").
+		From("couriers").
+		OrderBy("id")
+
+	if !filter.IncludeDeactivated {
+		builder = builder.Where(sq.Eq{"deactivated_at": nil})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("unexpected courier repository stream all error: %w", err)
+	}
+
+	rows, err := r.querier.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("unexpected courier repository stream all error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var courierModel CourierDB
+		err := rows.Scan(
+			&courierModel.ID,
+			&courierModel.Name,
+			&courierModel.Phone,
+			&courierModel.Status,
+			&courierModel.TransportType,
+			&courierModel.CreatedAt,
+			&courierModel.UpdatedAt,
+			&courierModel.DeactivatedAt,
+			&courierModel.DeactivationReason,
+			&courierModel.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("unexpected courier repository stream all error: %w", err)
+		}
+
+		err = fn(*ToDomain(&courierModel))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("unexpected courier repository stream all error: %w", err)
+	}
+
+	return nil
+}
+
+// UpdatePhone меняет телефон, только если он не менялся с момента чтения.
+// false - строку успели изменить, телефон остался прежним
+func (r *Repository) UpdatePhone(ctx context.Context, id int64, oldPhone, newPhone string) (bool, error) {
+	query := `UPDATE couriers
+		SET phone = $3,
+			updated_at = NOW(),
+			version = version + 1
+		WHERE id = $1 AND phone = $2`
+
+	result, err := r.querier.Exec(ctx, query, id, oldPhone, newPhone)
+	if err != nil {
+		if repository.IsPgErrorWithCode(err, repository.PgErrUniqueViolation) {
+			return false, courier.ErrConflict
+		}
+		return false, fmt.Errorf("unexpected courier repository update phone error: %w", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+// GetSkills навыки курьера по алфавиту
+func (r *Repository) GetSkills(ctx context.Context, id int64) ([]entities.CourierSkill, error) {
+	query := `SELECT skill
+		FROM courier_skills
+		WHERE courier_id = $1
+		ORDER BY skill`
+
+	rows, err := r.querier.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected courier repository get skills error: %w", err)
+	}
+	defer rows.Close()
+
+	skills := make([]entities.CourierSkill, 0)
+	for rows.Next() {
+		var skill string
+		err := rows.Scan(&skill)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected courier repository get skills error: %w", err)
+		}
+		skills = append(skills, entities.CourierSkill(skill))
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected courier repository get skills error: %w", err)
+	}
+
+	return skills, nil
+}
+
+// ReplaceSkills заменяет набор навыков курьера целиком, вызывается в транзакции
+func (r *Repository) ReplaceSkills(ctx context.Context, id int64, skills []entities.CourierSkill) error {
+	_, err := r.querier.Exec(ctx, `DELETE FROM courier_skills WHERE courier_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("unexpected courier repository replace skills error: %w", err)
+	}
+	if len(skills) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		values = append(values, skill.String())
+	}
+
+	query := `INSERT INTO courier_skills (courier_id, skill)
+		SELECT $1, skill FROM UNNEST($2::TEXT[]) AS skill
+		ON CONFLICT DO NOTHING`
+
+	_, err = r.querier.Exec(ctx, query, id, values)
+	if err != nil {
+		if repository.IsPgErrorWithCode(err, repository.PgErrForeignKeyViolation) {
+			return courier.ErrCourierNotFound
+		}
+		return fmt.Errorf("unexpected courier repository replace skills error: %w", err)
+	}
+
+	return nil
+}
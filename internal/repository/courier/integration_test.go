@@ -4,6 +4,7 @@ package courier_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -279,7 +280,7 @@ func TestRepository_GetAll_Success(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("Успешное получение всех курьеров", func(t *testing.T) {
-		couriers, err := repo.GetAll(ctx)
+		couriers, err := repo.GetAll(ctx, entities.CourierListFilter{})
 		require.NoError(t, err)
 		require.Len(t, couriers, 3)
 
@@ -306,9 +307,452 @@ func TestRepository_GetAll_Empty(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("Успешное получение пустого списка курьеров", func(t *testing.T) {
-		couriers, err := repo.GetAll(ctx)
+		couriers, err := repo.GetAll(ctx, entities.CourierListFilter{})
 		require.NoError(t, err)
 		require.Empty(t, couriers)
 		assert.Len(t, couriers, 0)
 	})
 }
+
+func TestRepository_GetAll_Deactivated(t *testing.T) {
+	setupSql := `
+		INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at, deactivated_at, deactivation_reason)
+		VALUES
+			(1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00', NULL, NULL),
+			(2, 'Courier 2', '+79991112234', 'available', 'scooter', '2025-01-15 11:00:00', '2025-01-15 11:00:00', '2025-01-16 11:00:00', 'уволился');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := courier.New(q)
+	ctx := context.Background()
+
+	t.Run("Отключенный курьер не попадает в список по умолчанию", func(t *testing.T) {
+		couriers, err := repo.GetAll(ctx, entities.CourierListFilter{})
+		require.NoError(t, err)
+		require.Len(t, couriers, 1)
+
+		assert.Equal(t, int64(1), couriers[0].ID)
+		assert.Nil(t, couriers[0].DeactivatedAt)
+	})
+
+	t.Run("Отключенный курьер попадает в список по запросу", func(t *testing.T) {
+		couriers, err := repo.GetAll(ctx, entities.CourierListFilter{IncludeDeactivated: true})
+		require.NoError(t, err)
+		require.Len(t, couriers, 2)
+
+		assert.Equal(t, int64(2), couriers[1].ID)
+		require.NotNil(t, couriers[1].DeactivatedAt)
+		assert.Equal(t, time.Date(2025, 1, 16, 11, 0, 0, 0, time.UTC), *couriers[1].DeactivatedAt)
+		assert.Equal(t, pointer.To("уволился"), couriers[1].DeactivationReason)
+	})
+}
+
+func TestRepository_Deactivate_PhoneReuse(t *testing.T) {
+	setupSql := `
+		INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+		VALUES (1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := courier.New(q)
+	ctx := context.Background()
+	deactivatedAt := time.Date(2025, 1, 16, 11, 0, 0, 0, time.UTC)
+
+	t.Run("Курьер отключается с временем и причиной", func(t *testing.T) {
+		deactivated, err := repo.Deactivate(ctx, 1, "уволился", deactivatedAt)
+		require.NoError(t, err)
+		require.NotNil(t, deactivated.DeactivatedAt)
+
+		assert.Equal(t, deactivatedAt, *deactivated.DeactivatedAt)
+		assert.Equal(t, pointer.To("уволился"), deactivated.DeactivationReason)
+	})
+
+	t.Run("Телефон отключенного курьера можно выдать новому", func(t *testing.T) {
+		id, err := repo.Create(ctx, entities.CourierModify{
+			Name:          pointer.To("Courier 2"),
+			Phone:         pointer.To("+79991112233"),
+			Status:        pointer.To(entities.CourierAvailable),
+			TransportType: pointer.To(entities.Scooter),
+		})
+		require.NoError(t, err)
+		assert.Greater(t, id, int64(1))
+	})
+
+	t.Run("Курьера нельзя вернуть, пока его телефон занят активным", func(t *testing.T) {
+		reactivated, err := repo.Reactivate(ctx, 1)
+		require.Error(t, err)
+		require.Nil(t, reactivated)
+		assert.ErrorIs(t, err, service.ErrConflict)
+	})
+}
+
+func TestRepository_Reactivate_Success(t *testing.T) {
+	setupSql := `
+		INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at, deactivated_at, deactivation_reason)
+		VALUES (1, 'Courier 1', '+79991112233', 'paused', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00', '2025-01-16 11:00:00', 'уволился');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := courier.New(q)
+	ctx := context.Background()
+
+	t.Run("Курьер возвращается в работу с прежним статусом", func(t *testing.T) {
+		reactivated, err := repo.Reactivate(ctx, 1)
+		require.NoError(t, err)
+
+		assert.Nil(t, reactivated.DeactivatedAt)
+		assert.Nil(t, reactivated.DeactivationReason)
+		assert.Equal(t, entities.CourierPaused, reactivated.Status)
+	})
+
+	t.Run("Ошибка при возвращении несуществующего курьера", func(t *testing.T) {
+		reactivated, err := repo.Reactivate(ctx, 999)
+		require.Error(t, err)
+		require.Nil(t, reactivated)
+		assert.ErrorIs(t, err, service.ErrCourierNotFound)
+	})
+}
+
+func TestRepository_HasActiveDeliveries(t *testing.T) {
+	setupSql := `
+		INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+		VALUES
+			(1, 'Courier 1', '+79991112233', 'busy', 'on_foot', NOW(), NOW()),
+			(2, 'Courier 2', '+79991112234', 'busy', 'on_foot', NOW(), NOW()),
+			(3, 'Courier 3', '+79991112235', 'busy', 'on_foot', NOW(), NOW()),
+			(4, 'Courier 4', '+79991112236', 'available', 'on_foot', NOW(), NOW());
+
+		INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline, completed_at, courier_released_at)
+		VALUES
+			(1, 'order-active', NOW(), NOW(), NOW() + INTERVAL '1 hour', NULL, NULL),
+			(2, 'order-overdue', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NULL, NULL),
+			(3, 'order-released', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NULL, NOW()),
+			(4, 'order-completed', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '2 hours', NOW() + INTERVAL '1 hour', NOW(), NULL);
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := courier.New(q)
+	ctx := context.Background()
+
+	t.Run("Доставка с непрошедшим дедлайном активна", func(t *testing.T) {
+		hasActive, err := repo.HasActiveDeliveries(ctx, 1)
+		require.NoError(t, err)
+		assert.True(t, hasActive)
+	})
+
+	t.Run("Просроченная невыполненная доставка активна", func(t *testing.T) {
+		hasActive, err := repo.HasActiveDeliveries(ctx, 2)
+		require.NoError(t, err)
+		assert.True(t, hasActive)
+	})
+
+	t.Run("Доставка, от которой курьер освобожден, не активна", func(t *testing.T) {
+		hasActive, err := repo.HasActiveDeliveries(ctx, 3)
+		require.NoError(t, err)
+		assert.False(t, hasActive)
+	})
+
+	t.Run("Выполненная доставка не активна", func(t *testing.T) {
+		hasActive, err := repo.HasActiveDeliveries(ctx, 4)
+		require.NoError(t, err)
+		assert.False(t, hasActive)
+	})
+}
+
+func TestRepository_CancelPendingOffers(t *testing.T) {
+	setupSql := `
+		INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+		VALUES
+			(1, 'Courier 1', '+79991112233', 'available', 'on_foot', NOW(), NOW()),
+			(2, 'Courier 2', '+79991112234', 'available', 'on_foot', NOW(), NOW());
+
+		INSERT INTO delivery_offers (order_id, courier_id, status, offered_at, expires_at)
+		VALUES
+			('order-1', 1, 'pending', NOW(), NOW() + INTERVAL '1 minute'),
+			('order-2', 1, 'declined', NOW(), NOW() + INTERVAL '1 minute'),
+			('order-3', 2, 'pending', NOW(), NOW() + INTERVAL '1 minute');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := courier.New(q)
+	ctx := context.Background()
+
+	t.Run("Открытое предложение курьеру отменяется", func(t *testing.T) {
+		cancelled, err := repo.CancelPendingOffers(ctx, 1, time.Now().UTC())
+		require.NoError(t, err)
+		assert.True(t, cancelled)
+
+		var status string
+		err = q.QueryRow(ctx, `SELECT status FROM delivery_offers WHERE order_id = 'order-1'`).Scan(&status)
+		require.NoError(t, err)
+		assert.Equal(t, "cancelled", status)
+
+		// закрытые предложения остаются в истории как есть
+		err = q.QueryRow(ctx, `SELECT status FROM delivery_offers WHERE order_id = 'order-2'`).Scan(&status)
+		require.NoError(t, err)
+		assert.Equal(t, "declined", status)
+	})
+
+	t.Run("Предложения других курьеров не затрагиваются", func(t *testing.T) {
+		var status string
+		err := q.QueryRow(ctx, `SELECT status FROM delivery_offers WHERE order_id = 'order-3'`).Scan(&status)
+		require.NoError(t, err)
+		assert.Equal(t, "pending", status)
+	})
+
+	t.Run("Без открытого предложения", func(t *testing.T) {
+		cancelled, err := repo.CancelPendingOffers(ctx, 1, time.Now().UTC())
+		require.NoError(t, err)
+		assert.False(t, cancelled)
+	})
+}
+
+func TestRepository_GetActivePhones(t *testing.T) {
+	setupSql := `
+		INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at, deactivated_at, deactivation_reason)
+		VALUES
+			(1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00', NULL, NULL),
+			(2, 'Courier 2', '+79991112234', 'available', 'scooter', '2025-01-15 11:00:00', '2025-01-15 11:00:00', '2025-01-16 11:00:00', 'уволился');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := courier.New(q)
+	ctx := context.Background()
+
+	t.Run("Занятыми считаются только телефоны активных курьеров", func(t *testing.T) {
+		active, err := repo.GetActivePhones(ctx, []string{"+79991112233", "+79991112234", "+79990000000"})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]struct{}{"+79991112233": {}}, active)
+	})
+}
+
+func TestRepository_StreamAll(t *testing.T) {
+	setupSql := `
+		INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at, deactivated_at, deactivation_reason)
+		VALUES
+			(1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00', NULL, NULL),
+			(2, 'Courier 2', '+79991112234', 'available', 'scooter', '2025-01-15 11:00:00', '2025-01-15 11:00:00', '2025-01-16 11:00:00', 'уволился'),
+			(3, 'Courier 3', '+79991112235', 'busy', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00', NULL, NULL);
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := courier.New(q)
+	ctx := context.Background()
+
+	t.Run("Активные курьеры по порядку id", func(t *testing.T) {
+		ids := make([]int64, 0)
+		err := repo.StreamAll(ctx, entities.CourierListFilter{}, func(c entities.Courier) error {
+			ids = append(ids, c.ID)
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []int64{1, 3}, ids)
+	})
+
+	t.Run("С отключенными курьерами", func(t *testing.T) {
+		ids := make([]int64, 0)
+		err := repo.StreamAll(ctx, entities.CourierListFilter{IncludeDeactivated: true}, func(c entities.Courier) error {
+			ids = append(ids, c.ID)
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []int64{1, 2, 3}, ids)
+	})
+
+	t.Run("Ошибка из обработчика прерывает чтение", func(t *testing.T) {
+		stop := errors.New("stop")
+		calls := 0
+		err := repo.StreamAll(ctx, entities.CourierListFilter{}, func(c entities.Courier) error {
+			calls++
+			return stop
+		})
+
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestRepository_Update_Version(t *testing.T) {
+	setupSql := `
+		INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+		VALUES (1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := courier.New(q)
+	ctx := context.Background()
+
+	t.Run("Новый курьер получает первую версию", func(t *testing.T) {
+		found, err := repo.GetByID(ctx, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(1), found.Version)
+	})
+
+	t.Run("Обновление с совпавшей версией увеличивает ее", func(t *testing.T) {
+		updated, err := repo.Update(ctx, entities.CourierModify{
+			ID:              pointer.To(int64(1)),
+			Status:          pointer.To(entities.CourierPaused),
+			ExpectedVersion: pointer.To(int64(1)),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(2), updated.Version)
+		assert.Equal(t, entities.CourierPaused, updated.Status)
+	})
+
+	t.Run("Обновление с устаревшей версией не применяется", func(t *testing.T) {
+		updated, err := repo.Update(ctx, entities.CourierModify{
+			ID:              pointer.To(int64(1)),
+			Status:          pointer.To(entities.CourierBusy),
+			ExpectedVersion: pointer.To(int64(1)),
+		})
+		require.Error(t, err)
+		require.Nil(t, updated)
+		assert.ErrorIs(t, err, service.ErrVersionMismatch)
+
+		found, err := repo.GetByID(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, entities.CourierPaused, found.Status)
+		assert.Equal(t, int64(2), found.Version)
+	})
+
+	t.Run("Несуществующий курьер с ожидаемой версией", func(t *testing.T) {
+		updated, err := repo.Update(ctx, entities.CourierModify{
+			ID:              pointer.To(int64(999)),
+			Status:          pointer.To(entities.CourierBusy),
+			ExpectedVersion: pointer.To(int64(1)),
+		})
+		require.Error(t, err)
+		require.Nil(t, updated)
+		assert.ErrorIs(t, err, service.ErrCourierNotFound)
+	})
+
+	t.Run("Отключение и возвращение тоже меняют версию", func(t *testing.T) {
+		deactivated, err := repo.Deactivate(ctx, 1, "уволился", time.Date(2025, 1, 16, 11, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), deactivated.Version)
+
+		reactivated, err := repo.Reactivate(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), reactivated.Version)
+	})
+}
+
+func TestRepository_UpdatePhone(t *testing.T) {
+	setupSql := `
+		INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+		VALUES
+			(1, 'Courier 1', '8 999 111-22-33', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+			(2, 'Courier 2', '+79994445566', 'available', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := courier.New(q)
+	ctx := context.Background()
+
+	t.Run("Телефон меняется и версия увеличивается", func(t *testing.T) {
+		updated, err := repo.UpdatePhone(ctx, 1, "8 999 111-22-33", "+79991112233")
+		require.NoError(t, err)
+		assert.True(t, updated)
+
+		found, err := repo.GetByID(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "+79991112233", found.Phone)
+		assert.Equal(t, int64(2), found.Version)
+	})
+
+	t.Run("Телефон изменился после чтения - строка не трогается", func(t *testing.T) {
+		updated, err := repo.UpdatePhone(ctx, 1, "8 999 111-22-33", "+79990000000")
+		require.NoError(t, err)
+		assert.False(t, updated)
+
+		found, err := repo.GetByID(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "+79991112233", found.Phone)
+	})
+
+	t.Run("Номер занят другим активным курьером", func(t *testing.T) {
+		updated, err := repo.UpdatePhone(ctx, 1, "+79991112233", "+79994445566")
+		require.Error(t, err)
+		assert.False(t, updated)
+		assert.ErrorIs(t, err, service.ErrConflict)
+	})
+}
+
+func TestRepository_Skills(t *testing.T) {
+	setupSql := `
+		INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+		VALUES
+			(1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+		INSERT INTO courier_skills (courier_id, skill)
+		VALUES (1, 'thermal_bag');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := courier.New(q)
+	ctx := context.Background()
+
+	t.Run("Навыки курьера", func(t *testing.T) {
+		skills, err := repo.GetSkills(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []entities.CourierSkill{entities.SkillThermalBag}, skills)
+	})
+
+	t.Run("Набор навыков заменяется целиком", func(t *testing.T) {
+		err := repo.ReplaceSkills(ctx, 1, []entities.CourierSkill{entities.SkillAgeVerified})
+		require.NoError(t, err)
+
+		skills, err := repo.GetSkills(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []entities.CourierSkill{entities.SkillAgeVerified}, skills)
+	})
+
+	t.Run("Пустой набор снимает все навыки", func(t *testing.T) {
+		err := repo.ReplaceSkills(ctx, 1, nil)
+		require.NoError(t, err)
+
+		skills, err := repo.GetSkills(ctx, 1)
+		require.NoError(t, err)
+		assert.Empty(t, skills)
+	})
+
+	t.Run("Несуществующий курьер", func(t *testing.T) {
+		err := repo.ReplaceSkills(ctx, 999, []entities.CourierSkill{entities.SkillThermalBag})
+		assert.ErrorIs(t, err, service.ErrCourierNotFound)
+	})
+}
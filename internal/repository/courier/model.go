@@ -12,6 +12,10 @@ type CourierDB struct {
 	TransportType string
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+
+	DeactivatedAt      *time.Time
+	DeactivationReason *string
+	Version            int64
 }
 
 type CourierModifyDB struct {
@@ -20,4 +24,6 @@ type CourierModifyDB struct {
 	Phone         *string
 	Status        *string
 	TransportType *string
+
+	ExpectedVersion *int64
 }
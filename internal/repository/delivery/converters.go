@@ -1,19 +1,60 @@
 package delivery
 
-import "service/internal/entities"
+import (
+	"service/internal/entities"
+	"service/internal/repository"
+)
 
 func ToDomain(d *DeliveryDB) *entities.Delivery {
 	if d == nil {
 		return nil
 	}
-	return &entities.Delivery{
-		ID:         d.ID,
-		CourierID:  d.CourierID,
-		OrderID:    d.OrderID,
-		CreatedAt:  d.CreatedAt,
-		AssignedAt: d.AssignedAt,
-		Deadline:   d.Deadline,
+
+	deliveryEntity := &entities.Delivery{
+		ID:                d.ID,
+		CourierID:         d.CourierID,
+		OrderID:           d.OrderID,
+		Address:           repository.AddressToDomain(d.Address),
+		EstimatedDelivery: d.EstimatedDelivery,
+		CreatedAt:         d.CreatedAt,
+		AssignedAt:        d.AssignedAt,
+		Deadline:          d.Deadline,
+		OverdueAt:         d.OverdueAt,
+		CourierReleasedAt: d.CourierReleasedAt,
+		CompletedAt:       d.CompletedAt,
+		Priority:          entities.OrderPriority(d.Priority),
+		CashAmount:        d.CashAmount,
+	}
+	if d.RestaurantID != nil {
+		deliveryEntity.RestaurantID = *d.RestaurantID
+	}
+	if d.PickupLat != nil && d.PickupLon != nil && d.DropoffLat != nil && d.DropoffLon != nil {
+		deliveryEntity.Route = &entities.Route{
+			Pickup:  entities.Location{Latitude: *d.PickupLat, Longitude: *d.PickupLon},
+			Dropoff: entities.Location{Latitude: *d.DropoffLat, Longitude: *d.DropoffLon},
+		}
+	}
+	for _, skill := range d.RequiredSkills {
+		deliveryEntity.Requirements.Skills = append(deliveryEntity.Requirements.Skills, entities.CourierSkill(skill))
+	}
+	for _, transportType := range d.TransportTypes {
+		deliveryEntity.Requirements.TransportTypes = append(deliveryEntity.Requirements.TransportTypes, entities.CourierTransportType(transportType))
+	}
+
+	return deliveryEntity
+}
+
+func ToDomainList(deliveryDB []DeliveryDB) []entities.Delivery {
+	if len(deliveryDB) == 0 {
+		return []entities.Delivery{}
+	}
+
+	result := make([]entities.Delivery, len(deliveryDB))
+	for i, d := range deliveryDB {
+		result[i] = *ToDomain(&d)
 	}
+
+	return result
 }
 
 func FromDomainModify(d *entities.DeliveryModify) *DeliveryModifyDB {
@@ -31,6 +72,16 @@ func FromDomainModify(d *entities.DeliveryModify) *DeliveryModifyDB {
 	if d.OrderID != nil {
 		deliveryModifyDB.OrderID = d.OrderID
 	}
+	// пустой ресторан храним как NULL
+	if d.RestaurantID != nil && *d.RestaurantID != "" {
+		deliveryModifyDB.RestaurantID = d.RestaurantID
+	}
+	if d.Address != nil {
+		deliveryModifyDB.Address = repository.AddressFromDomain(d.Address)
+	}
+	if d.EstimatedDelivery != nil {
+		deliveryModifyDB.EstimatedDelivery = d.EstimatedDelivery
+	}
 	if d.CreatedAt != nil {
 		deliveryModifyDB.CreatedAt = d.CreatedAt
 	}
@@ -40,10 +91,45 @@ func FromDomainModify(d *entities.DeliveryModify) *DeliveryModifyDB {
 	if d.Deadline != nil {
 		deliveryModifyDB.Deadline = d.Deadline
 	}
+	if d.Priority != nil {
+		priority := d.Priority.String()
+		deliveryModifyDB.Priority = &priority
+	}
+	if d.Route != nil {
+		deliveryModifyDB.PickupLat = &d.Route.Pickup.Latitude
+		deliveryModifyDB.PickupLon = &d.Route.Pickup.Longitude
+		deliveryModifyDB.DropoffLat = &d.Route.Dropoff.Latitude
+		deliveryModifyDB.DropoffLon = &d.Route.Dropoff.Longitude
+	}
+	deliveryModifyDB.CashAmount = d.CashAmount
+
+	// в БД колонки NOT NULL, отсутствие требований - пустой массив
+	deliveryModifyDB.RequiredSkills = make([]string, len(d.Requirements.Skills))
+	for i, skill := range d.Requirements.Skills {
+		deliveryModifyDB.RequiredSkills[i] = skill.String()
+	}
+	deliveryModifyDB.TransportTypes = make([]string, len(d.Requirements.TransportTypes))
+	for i, transportType := range d.Requirements.TransportTypes {
+		deliveryModifyDB.TransportTypes[i] = transportType.String()
+	}
 
 	return deliveryModifyDB
 }
 
+func ToPreemptionCandidateDomain(c *PreemptionCandidateDB) *entities.PreemptionCandidate {
+	if c == nil {
+		return nil
+	}
+
+	delivery := ToDomain(&c.Delivery)
+	return &entities.PreemptionCandidate{
+		Delivery:     *delivery,
+		Courier:      *ToCourierDomain(&c.Courier),
+		Requirements: delivery.Requirements,
+		CashAmount:   delivery.CashAmount,
+	}
+}
+
 func ToCourierDomain(c *AvailableCourierDB) *entities.Courier {
 	if c == nil {
 		return nil
@@ -56,5 +142,82 @@ func ToCourierDomain(c *AvailableCourierDB) *entities.Courier {
 		TransportType: entities.CourierTransportType(c.TransportType),
 		CreatedAt:     c.CreatedAt,
 		UpdatedAt:     c.UpdatedAt,
+		DeactivatedAt: c.DeactivatedAt,
+		Version:       c.Version,
+	}
+}
+
+func ToDispatchCourierDomain(c *DispatchCourierDB) *entities.DispatchCourier {
+	if c == nil {
+		return nil
+	}
+
+	skills := make([]entities.CourierSkill, len(c.Skills))
+	for i, skill := range c.Skills {
+		skills[i] = entities.CourierSkill(skill)
+	}
+
+	return &entities.DispatchCourier{
+		Courier:          *ToCourierDomain(&c.AvailableCourierDB),
+		ActiveDeliveries: c.ActiveDeliveries,
+		Skills:           skills,
+		ZoneIDs:          c.ZoneIDs,
+		CashBalance:      c.CashBalance,
+	}
+}
+
+func ToReassignmentDomain(r *DeliveryReassignmentDB) *entities.DeliveryReassignment {
+	if r == nil {
+		return nil
+	}
+
+	return &entities.DeliveryReassignment{
+		ID:                r.ID,
+		OrderID:           r.OrderID,
+		PreviousCourierID: r.PreviousCourierID,
+		CourierID:         r.CourierID,
+		Reason:            r.Reason,
+		Deadline:          r.Deadline,
+		ReassignedAt:      r.ReassignedAt,
+	}
+}
+
+func FromReassignmentDomainModify(r *entities.DeliveryReassignmentModify) *DeliveryReassignmentModifyDB {
+	if r == nil {
+		return nil
+	}
+
+	reassignmentModifyDB := &DeliveryReassignmentModifyDB{}
+
+	if r.ID != nil {
+		reassignmentModifyDB.ID = r.ID
+	}
+	if r.OrderID != nil {
+		reassignmentModifyDB.OrderID = r.OrderID
+	}
+	if r.PreviousCourierID != nil {
+		reassignmentModifyDB.PreviousCourierID = r.PreviousCourierID
+	}
+	if r.CourierID != nil {
+		reassignmentModifyDB.CourierID = r.CourierID
+	}
+	if r.Reason != nil {
+		reassignmentModifyDB.Reason = r.Reason
+	}
+	if r.Deadline != nil {
+		reassignmentModifyDB.Deadline = r.Deadline
+	}
+	if r.ReassignedAt != nil {
+		reassignmentModifyDB.ReassignedAt = r.ReassignedAt
+	}
+
+	return reassignmentModifyDB
+}
+
+func ToCourierPoolCountDomain(c CourierPoolCountDB) entities.CourierPoolCount {
+	return entities.CourierPoolCount{
+		Status:        entities.CourierStatusType(c.Status),
+		TransportType: entities.CourierTransportType(c.TransportType),
+		Count:         c.Count,
 	}
 }
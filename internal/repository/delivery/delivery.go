@@ -4,14 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
 	"service/internal/entities"
 	"service/internal/repository"
 	"service/internal/service/delivery"
 )
 
+var qb sq.StatementBuilderType = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
 type Repository struct {
 	querier Querier
 }
@@ -26,9 +31,14 @@ func (r *Repository) Create(ctx context.Context, deliveryModify entities.Deliver
 	deliveryModifyDB := FromDomainModify(&deliveryModify)
 
 	query := `
-		INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, courier_id, order_id, created_at, assigned_at, deadline
+		INSERT INTO delivery (
+			courier_id, order_id, restaurant_id, address, estimated_delivery, created_at, assigned_at, deadline,
+			priority, required_skills, allowed_transport_types, pickup_lat, pickup_lon, dropoff_lat, dropoff_lon,
+			cash_amount
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9, 'normal'), $10, $11, $12, $13, $14, $15, $16)
+		RETURNING id, courier_id, order_id, restaurant_id, address, estimated_delivery, created_at, assigned_at, deadline, overdue_at, courier_released_at, completed_at,
+			pickup_lat, pickup_lon, dropoff_lat, dropoff_lon, priority, required_skills, allowed_transport_types, cash_amount
 	`
 
 	var deliveryDB DeliveryDB
@@ -37,16 +47,41 @@ func (r *Repository) Create(ctx context.Context, deliveryModify entities.Deliver
 		query,
 		deliveryModifyDB.CourierID,
 		deliveryModifyDB.OrderID,
+		deliveryModifyDB.RestaurantID,
+		deliveryModifyDB.Address,
+		deliveryModifyDB.EstimatedDelivery,
 		deliveryModifyDB.CreatedAt,
 		deliveryModifyDB.AssignedAt,
 		deliveryModifyDB.Deadline,
+		deliveryModifyDB.Priority,
+		deliveryModifyDB.RequiredSkills,
+		deliveryModifyDB.TransportTypes,
+		deliveryModifyDB.PickupLat,
+		deliveryModifyDB.PickupLon,
+		deliveryModifyDB.DropoffLat,
+		deliveryModifyDB.DropoffLon,
+		deliveryModifyDB.CashAmount,
 	).Scan(
 		&deliveryDB.ID,
 		&deliveryDB.CourierID,
 		&deliveryDB.OrderID,
+		&deliveryDB.RestaurantID,
+		&deliveryDB.Address,
+		&deliveryDB.EstimatedDelivery,
 		&deliveryDB.CreatedAt,
 		&deliveryDB.AssignedAt,
 		&deliveryDB.Deadline,
+		&deliveryDB.OverdueAt,
+		&deliveryDB.CourierReleasedAt,
+		&deliveryDB.CompletedAt,
+		&deliveryDB.PickupLat,
+		&deliveryDB.PickupLon,
+		&deliveryDB.DropoffLat,
+		&deliveryDB.DropoffLon,
+		&deliveryDB.Priority,
+		&deliveryDB.RequiredSkills,
+		&deliveryDB.TransportTypes,
+		&deliveryDB.CashAmount,
 	)
 	if err != nil {
 		if repository.IsPgErrorWithCode(err, repository.PgErrUniqueViolation) {
@@ -59,6 +94,178 @@ func (r *Repository) Create(ctx context.Context, deliveryModify entities.Deliver
 	return deliveryDomain, nil
 }
 
+func (r *Repository) GetByOrderID(ctx context.Context, orderID string) (*entities.Delivery, error) {
+	query := `
+		SELECT id, courier_id, order_id, restaurant_id, address, estimated_delivery, created_at, assigned_at, deadline, overdue_at, courier_released_at, completed_at,
+			pickup_lat, pickup_lon, dropoff_lat, dropoff_lon, priority, required_skills, allowed_transport_types, cash_amount
+		FROM delivery
+		WHERE order_id = $1
+	`
+
+	var deliveryDB DeliveryDB
+	err := r.querier.QueryRow(ctx, query, orderID).Scan(
+		&deliveryDB.ID,
+		&deliveryDB.CourierID,
+		&deliveryDB.OrderID,
+		&deliveryDB.RestaurantID,
+		&deliveryDB.Address,
+		&deliveryDB.EstimatedDelivery,
+		&deliveryDB.CreatedAt,
+		&deliveryDB.AssignedAt,
+		&deliveryDB.Deadline,
+		&deliveryDB.OverdueAt,
+		&deliveryDB.CourierReleasedAt,
+		&deliveryDB.CompletedAt,
+		&deliveryDB.PickupLat,
+		&deliveryDB.PickupLon,
+		&deliveryDB.DropoffLat,
+		&deliveryDB.DropoffLon,
+		&deliveryDB.Priority,
+		&deliveryDB.RequiredSkills,
+		&deliveryDB.TransportTypes,
+		&deliveryDB.CashAmount,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, delivery.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("unexpected delivery repository get by order id error: %w", err)
+	}
+
+	return ToDomain(&deliveryDB), nil
+}
+
+// GetByOrderIDForUpdate блокирует доставку до конца транзакции, чтобы заказ не передали дважды
+func (r *Repository) GetByOrderIDForUpdate(ctx context.Context, orderID string) (*entities.Delivery, error) {
+	query := `
+		SELECT id, courier_id, order_id, restaurant_id, address, estimated_delivery, created_at, assigned_at, deadline, overdue_at, courier_released_at, completed_at,
+			pickup_lat, pickup_lon, dropoff_lat, dropoff_lon, priority, required_skills, allowed_transport_types, cash_amount
+		FROM delivery
+		WHERE order_id = $1
+		FOR UPDATE
+	`
+
+	var deliveryDB DeliveryDB
+	err := r.querier.QueryRow(ctx, query, orderID).Scan(
+		&deliveryDB.ID,
+		&deliveryDB.CourierID,
+		&deliveryDB.OrderID,
+		&deliveryDB.RestaurantID,
+		&deliveryDB.Address,
+		&deliveryDB.EstimatedDelivery,
+		&deliveryDB.CreatedAt,
+		&deliveryDB.AssignedAt,
+		&deliveryDB.Deadline,
+		&deliveryDB.OverdueAt,
+		&deliveryDB.CourierReleasedAt,
+		&deliveryDB.CompletedAt,
+		&deliveryDB.PickupLat,
+		&deliveryDB.PickupLon,
+		&deliveryDB.DropoffLat,
+		&deliveryDB.DropoffLon,
+		&deliveryDB.Priority,
+		&deliveryDB.RequiredSkills,
+		&deliveryDB.TransportTypes,
+		&deliveryDB.CashAmount,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, delivery.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("unexpected delivery repository get by order id for update error: %w", err)
+	}
+
+	return ToDomain(&deliveryDB), nil
+}
+
+// Reassign переводит невыполненную доставку на другого курьера с новым временем назначения и дедлайном,
+// отметка о просрочке снимается: новый дедлайн еще не пройден
+func (r *Repository) Reassign(ctx context.Context, deliveryModify entities.DeliveryModify) (*entities.Delivery, error) {
+	deliveryModifyDB := FromDomainModify(&deliveryModify)
+
+	query := `
+		UPDATE delivery
+		SET courier_id = $2, assigned_at = $3, deadline = $4, overdue_at = NULL, courier_released_at = NULL
+		WHERE order_id = $1 AND completed_at IS NULL
+		RETURNING id, courier_id, order_id, restaurant_id, address, estimated_delivery, created_at, assigned_at, deadline, overdue_at, courier_released_at, completed_at,
+			pickup_lat, pickup_lon, dropoff_lat, dropoff_lon, priority, required_skills, allowed_transport_types, cash_amount
+	`
+
+	var deliveryDB DeliveryDB
+	err := r.querier.QueryRow(
+		ctx,
+		query,
+		deliveryModifyDB.OrderID,
+		deliveryModifyDB.CourierID,
+		deliveryModifyDB.AssignedAt,
+		deliveryModifyDB.Deadline,
+	).Scan(
+		&deliveryDB.ID,
+		&deliveryDB.CourierID,
+		&deliveryDB.OrderID,
+		&deliveryDB.RestaurantID,
+		&deliveryDB.Address,
+		&deliveryDB.EstimatedDelivery,
+		&deliveryDB.CreatedAt,
+		&deliveryDB.AssignedAt,
+		&deliveryDB.Deadline,
+		&deliveryDB.OverdueAt,
+		&deliveryDB.CourierReleasedAt,
+		&deliveryDB.CompletedAt,
+		&deliveryDB.PickupLat,
+		&deliveryDB.PickupLon,
+		&deliveryDB.DropoffLat,
+		&deliveryDB.DropoffLon,
+		&deliveryDB.Priority,
+		&deliveryDB.RequiredSkills,
+		&deliveryDB.TransportTypes,
+		&deliveryDB.CashAmount,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, delivery.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("unexpected delivery repository reassign error: %w", err)
+	}
+
+	return ToDomain(&deliveryDB), nil
+}
+
+func (r *Repository) CreateReassignment(ctx context.Context, reassignmentModify entities.DeliveryReassignmentModify) (*entities.DeliveryReassignment, error) {
+	reassignmentModifyDB := FromReassignmentDomainModify(&reassignmentModify)
+
+	query := `
+		INSERT INTO delivery_reassignments (order_id, previous_courier_id, courier_id, reason, deadline, reassigned_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, order_id, previous_courier_id, courier_id, reason, deadline, reassigned_at
+	`
+
+	var reassignmentDB DeliveryReassignmentDB
+	err := r.querier.QueryRow(
+		ctx,
+		query,
+		reassignmentModifyDB.OrderID,
+		reassignmentModifyDB.PreviousCourierID,
+		reassignmentModifyDB.CourierID,
+		reassignmentModifyDB.Reason,
+		reassignmentModifyDB.Deadline,
+		reassignmentModifyDB.ReassignedAt,
+	).Scan(
+		&reassignmentDB.ID,
+		&reassignmentDB.OrderID,
+		&reassignmentDB.PreviousCourierID,
+		&reassignmentDB.CourierID,
+		&reassignmentDB.Reason,
+		&reassignmentDB.Deadline,
+		&reassignmentDB.ReassignedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery repository create reassignment error: %w", err)
+	}
+
+	return ToReassignmentDomain(&reassignmentDB), nil
+}
+
 func (r *Repository) Delete(ctx context.Context, orderID string) error {
 	query := `
 		DELETE FROM delivery WHERE order_id = $1
@@ -76,11 +283,14 @@ func (r *Repository) Delete(ctx context.Context, orderID string) error {
 	return nil
 }
 
+// GetCourierIDAndDeliveryCountByOrderIDForAssing курьер доставки и число других его доставок, за которые
+// он еще отвечает: невыполненных и не снятых с него авто-освобождением. Просроченная доставка считается,
+// пока курьера не освободили по политике ReleasePolicy
 func (r *Repository) GetCourierIDAndDeliveryCountByOrderIDForAssing(ctx context.Context, orderID string) (courierID, activeDeliveriesCount int64, err error) {
 	query := `
         SELECT 
             d1.courier_id,
-            COUNT(d2.id) FILTER (WHERE d2.deadline >= NOW())
+            COUNT(d2.id) FILTER (WHERE d2.completed_at IS NULL AND d2.courier_released_at IS NULL)
         FROM delivery d1
         LEFT JOIN delivery d2 
             ON d2.courier_id = d1.courier_id 
@@ -99,20 +309,38 @@ func (r *Repository) GetCourierIDAndDeliveryCountByOrderIDForAssing(ctx context.
 	return courierID, activeDeliveriesCount, nil
 }
 
-func (r *Repository) GetCourierForAssignment(ctx context.Context) (*entities.Courier, error) {
-	query := `
-        SELECT 
-            c.id, c.name, c.phone, c.status, c.transport_type, c.created_at, c.updated_at
-        FROM couriers c
-        LEFT JOIN delivery d ON d.courier_id = c.id
-        WHERE c.status = 'available'
-        GROUP BY c.id
-        ORDER BY COUNT(d.id) FILTER (WHERE d.deadline >= NOW()) ASC, c.id ASC
-        LIMIT 1
-	`
+func (r *Repository) GetCourierForAssignment(ctx context.Context, filter entities.CourierSearchFilter) (*entities.Courier, error) {
+	builder := qb.
+		Select("c.id, c.name, c.phone, c.status, c.transport_type, c.created_at, c.updated_at, c.version").
+		From("couriers c").
+		LeftJoin("delivery d ON d.courier_id = c.id").
+		Where("c.status = 'available' AND c.deactivated_at IS NULL").
+		Where(courierWithoutPendingOffer).
+		GroupBy("c.id").
+		Limit(1)
+
+	if filter.PreferFastTransport {
+		builder = builder.OrderBy(fastTransportFirst)
+	}
+	if filter.TopRatedSince != nil {
+		builder = builder.OrderByClause(topRatedFirst, *filter.TopRatedSince)
+	}
+	builder = builder.OrderBy("COUNT(d.id) FILTER (WHERE d.deadline >= NOW() AND d.completed_at IS NULL) ASC", "c.id ASC")
+
+	for _, condition := range courierSearchConditions(filter) {
+		builder = builder.Where(condition.condition)
+	}
+	if len(filter.ExcludeCourierIDs) > 0 {
+		builder = builder.Where(sq.Expr("c.id <> ALL(?)", filter.ExcludeCourierIDs))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery repository find courier error: %w", err)
+	}
 
 	var courierDB AvailableCourierDB
-	err := r.querier.QueryRow(ctx, query).Scan(
+	err = r.querier.QueryRow(ctx, query, args...).Scan(
 		&courierDB.ID,
 		&courierDB.Name,
 		&courierDB.Phone,
@@ -120,6 +348,7 @@ func (r *Repository) GetCourierForAssignment(ctx context.Context) (*entities.Cou
 		&courierDB.TransportType,
 		&courierDB.CreatedAt,
 		&courierDB.UpdatedAt,
+		&courierDB.Version,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -132,23 +361,423 @@ func (r *Repository) GetCourierForAssignment(ctx context.Context) (*entities.Cou
 	return courierEntity, nil
 }
 
-func (r *Repository) UpdateCouriersAvailableWhereDeadlineExpired(ctx context.Context) (int64, error) {
+// GetPreemptionCandidateForUpdate блокирует самую свежую доставку обычного заказа, назначенную не раньше
+// assignedSince и еще не просроченную, курьер которой подходит под фильтр. Заблокированные другой
+// транзакцией доставки и курьеры пропускаются
+func (r *Repository) GetPreemptionCandidateForUpdate(
+	ctx context.Context,
+	filter entities.CourierSearchFilter,
+	assignedSince time.Time,
+) (*entities.PreemptionCandidate, error) {
+	builder := qb.
+		Select(
+			"d.id, d.courier_id, d.order_id, d.restaurant_id, d.address, d.estimated_delivery",
+			"d.created_at, d.assigned_at, d.deadline, d.overdue_at, d.courier_released_at, d.completed_at",
+			"d.pickup_lat, d.pickup_lon, d.dropoff_lat, d.dropoff_lon",
+			"d.priority, d.required_skills, d.allowed_transport_types, d.cash_amount",
+			"c.id, c.name, c.phone, c.status, c.transport_type, c.created_at, c.updated_at, c.version",
+		).
+		From("delivery d").
+		Join("couriers c ON c.id = d.courier_id").
+		Where("d.priority = 'normal' AND d.deadline >= NOW() AND d.overdue_at IS NULL AND d.completed_at IS NULL").
+		Where(sq.GtOrEq{"d.assigned_at": assignedSince}).
+		Where("c.deactivated_at IS NULL").
+		OrderBy("d.assigned_at DESC", "d.id DESC").
+		Limit(1).
+		Suffix("FOR UPDATE OF d, c SKIP LOCKED")
+
+	for _, condition := range courierSearchConditions(filter) {
+		builder = builder.Where(condition.condition)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery repository get preemption candidate error: %w", err)
+	}
+
+	var candidateDB PreemptionCandidateDB
+	err = r.querier.QueryRow(ctx, query, args...).Scan(
+		&candidateDB.Delivery.ID,
+		&candidateDB.Delivery.CourierID,
+		&candidateDB.Delivery.OrderID,
+		&candidateDB.Delivery.RestaurantID,
+		&candidateDB.Delivery.Address,
+		&candidateDB.Delivery.EstimatedDelivery,
+		&candidateDB.Delivery.CreatedAt,
+		&candidateDB.Delivery.AssignedAt,
+		&candidateDB.Delivery.Deadline,
+		&candidateDB.Delivery.OverdueAt,
+		&candidateDB.Delivery.CourierReleasedAt,
+		&candidateDB.Delivery.CompletedAt,
+		&candidateDB.Delivery.PickupLat,
+		&candidateDB.Delivery.PickupLon,
+		&candidateDB.Delivery.DropoffLat,
+		&candidateDB.Delivery.DropoffLon,
+		&candidateDB.Delivery.Priority,
+		&candidateDB.Delivery.RequiredSkills,
+		&candidateDB.Delivery.TransportTypes,
+		&candidateDB.Delivery.CashAmount,
+		&candidateDB.Courier.ID,
+		&candidateDB.Courier.Name,
+		&candidateDB.Courier.Phone,
+		&candidateDB.Courier.Status,
+		&candidateDB.Courier.TransportType,
+		&candidateDB.Courier.CreatedAt,
+		&candidateDB.Courier.UpdatedAt,
+		&candidateDB.Courier.Version,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, delivery.ErrNoPreemptionCandidate
+		}
+		return nil, fmt.Errorf("unexpected delivery repository get preemption candidate error: %w", err)
+	}
+
+	return ToPreemptionCandidateDomain(&candidateDB), nil
+}
+
+// GetCouriersForDispatch блокирует до limit свободных курьеров без открытого предложения вместе с их
+// текущей загрузкой, навыками, зонами и наличными на руках. Заблокированные другой транзакцией курьеры пропускаются
+func (r *Repository) GetCouriersForDispatch(ctx context.Context, limit int) ([]entities.DispatchCourier, error) {
 	query := `
-        UPDATE couriers 
-        SET status = 'available',
-            updated_at = NOW()
-        WHERE status = 'busy' 
-        AND EXISTS (
-            SELECT 1 
-            FROM delivery 
-            WHERE delivery.courier_id = couriers.id 
-              AND delivery.deadline < NOW()
+		SELECT
+			c.id, c.name, c.phone, c.status, c.transport_type, c.created_at, c.updated_at, c.version,
+			(SELECT COUNT(*) FROM delivery d WHERE d.courier_id = c.id AND d.deadline >= NOW() AND d.completed_at IS NULL),
+			COALESCE((SELECT array_agg(cs.skill ORDER BY cs.skill) FROM courier_skills cs WHERE cs.courier_id = c.id), '{}'),
+			COALESCE((SELECT array_agg(cz.zone_id ORDER BY cz.zone_id) FROM courier_zones cz WHERE cz.courier_id = c.id), '{}'),
+			COALESCE((SELECT b.balance FROM courier_cash_balances b WHERE b.courier_id = c.id), 0)
+		FROM couriers c
+		WHERE c.status = 'available' AND c.deactivated_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM delivery_offers o WHERE o.courier_id = c.id AND o.status = 'pending')
+		ORDER BY c.id
+		LIMIT $1
+		FOR UPDATE OF c SKIP LOCKED
+	`
+
+	rows, err := r.querier.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery repository get couriers for dispatch error: %w", err)
+	}
+	defer rows.Close()
+
+	couriers := make([]entities.DispatchCourier, 0, limit)
+	for rows.Next() {
+		var courierDB DispatchCourierDB
+		err := rows.Scan(
+			&courierDB.ID,
+			&courierDB.Name,
+			&courierDB.Phone,
+			&courierDB.Status,
+			&courierDB.TransportType,
+			&courierDB.CreatedAt,
+			&courierDB.UpdatedAt,
+			&courierDB.Version,
+			&courierDB.ActiveDeliveries,
+			&courierDB.Skills,
+			&courierDB.ZoneIDs,
+			&courierDB.CashBalance,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected delivery repository get couriers for dispatch error: %w", err)
+		}
+		couriers = append(couriers, *ToDispatchCourierDomain(&courierDB))
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery repository get couriers for dispatch error: %w", err)
+	}
+
+	return couriers, nil
+}
+
+// ExplainCourierMismatch считает свободных курьеров, подходящих под каждое требование фильтра отдельно,
+// чтобы было видно, какое из них никто не выполняет
+func (r *Repository) ExplainCourierMismatch(ctx context.Context, filter entities.CourierSearchFilter) (*entities.CourierMismatch, error) {
+	conditions := courierSearchConditions(filter)
+
+	builder := qb.
+		Select("COUNT(*)").
+		From("couriers c").
+		Where("c.status = 'available' AND c.deactivated_at IS NULL").
+		Where(courierWithoutPendingOffer)
+	for _, condition := range conditions {
+		builder = builder.Column(sq.ConcatExpr("COUNT(*) FILTER (WHERE ", condition.condition, ")"))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery repository explain courier mismatch error: %w", err)
+	}
+
+	mismatch := &entities.CourierMismatch{
+		Requirements: make([]entities.RequirementMatch, len(conditions)),
+	}
+	dest := make([]any, 0, len(conditions)+1)
+	dest = append(dest, &mismatch.AvailableCouriers)
+	for i, condition := range conditions {
+		mismatch.Requirements[i] = condition.requirement
+		dest = append(dest, &mismatch.Requirements[i].MatchingCouriers)
+	}
+
+	err = r.querier.QueryRow(ctx, query, args...).Scan(dest...)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery repository explain courier mismatch error: %w", err)
+	}
+
+	return mismatch, nil
+}
+
+// fastTransportFirst порядок транспорта от быстрого к медленному
+const fastTransportFirst = "CASE c.transport_type WHEN 'car' THEN 0 WHEN 'scooter' THEN 1 ELSE 2 END ASC"
+
+// topRatedFirst средняя оценка курьера за окно от высокой к низкой, курьеры без оценок в конце
+const topRatedFirst = "(SELECT AVG(r.score) FROM delivery_ratings r WHERE r.courier_id = c.id AND r.created_at >= ?) DESC NULLS LAST"
+
+// courierWithoutPendingOffer курьер, ждущий ответа на предложение заказа, других заказов не получает
+const courierWithoutPendingOffer = "NOT EXISTS (SELECT 1 FROM delivery_offers o WHERE o.courier_id = c.id AND o.status = 'pending')"
+
+// courierCashBalance наличные на руках у курьера, курьер без движений наличных ничего не должен
+const courierCashBalance = "COALESCE((SELECT b.balance FROM courier_cash_balances b WHERE b.courier_id = c.id), 0)"
+
+type courierSearchCondition struct {
+	requirement entities.RequirementMatch
+	condition   sq.Sqlizer
+}
+
+// courierSearchConditions условия фильтра подбора курьера, каждый навык - отдельное условие
+func courierSearchConditions(filter entities.CourierSearchFilter) []courierSearchCondition {
+	var conditions []courierSearchCondition
+
+	if len(filter.ZoneIDs) > 0 {
+		conditions = append(conditions, courierSearchCondition{
+			requirement: entities.RequirementMatch{Requirement: entities.RequirementZone},
+			condition: sq.Expr(
+				"EXISTS (SELECT 1 FROM courier_zones cz WHERE cz.courier_id = c.id AND cz.zone_id = ANY(?))",
+				filter.ZoneIDs,
+			),
+		})
+	}
+
+	if len(filter.TransportTypes) > 0 {
+		transportTypes := make([]string, len(filter.TransportTypes))
+		for i, transportType := range filter.TransportTypes {
+			transportTypes[i] = transportType.String()
+		}
+		conditions = append(conditions, courierSearchCondition{
+			requirement: entities.RequirementMatch{
+				Requirement: entities.RequirementTransportType,
+				Value:       strings.Join(transportTypes, ","),
+			},
+			condition: sq.Expr("c.transport_type = ANY(?)", transportTypes),
+		})
+	}
+
+	for _, skill := range filter.Skills {
+		conditions = append(conditions, courierSearchCondition{
+			requirement: entities.RequirementMatch{
+				Requirement: entities.RequirementSkill,
+				Value:       skill.String(),
+			},
+			condition: sq.Expr(
+				"EXISTS (SELECT 1 FROM courier_skills cs WHERE cs.courier_id = c.id AND cs.skill = ?)",
+				skill.String(),
+			),
+		})
+	}
+
+	if filter.MaxCashBalance != nil {
+		conditions = append(conditions, courierSearchCondition{
+			requirement: entities.RequirementMatch{
+				Requirement: entities.RequirementCashBalance,
+				Value:       strconv.FormatInt(*filter.MaxCashBalance, 10),
+			},
+			condition: sq.Expr(courierCashBalance+" <= ?", *filter.MaxCashBalance),
+		})
+	}
+
+	return conditions
+}
+
+// GetCourierByIDForUpdate блокирует выбранного курьера, чтобы его не заняли параллельным назначением
+func (r *Repository) GetCourierByIDForUpdate(ctx context.Context, courierID int64) (*entities.Courier, error) {
+	query := `
+        SELECT id, name, phone, status, transport_type, created_at, updated_at, deactivated_at, version
+        FROM couriers
+        WHERE id = $1
+        FOR UPDATE
+	`
+
+	var courierDB AvailableCourierDB
+	err := r.querier.QueryRow(ctx, query, courierID).Scan(
+		&courierDB.ID,
+		&courierDB.Name,
+		&courierDB.Phone,
+		&courierDB.Status,
+		&courierDB.TransportType,
+		&courierDB.CreatedAt,
+		&courierDB.UpdatedAt,
+		&courierDB.DeactivatedAt,
+		&courierDB.Version,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, delivery.ErrCourierNotFound
+		}
+		return nil, fmt.Errorf("unexpected delivery repository get courier for update error: %w", err)
+	}
+
+	return ToCourierDomain(&courierDB), nil
+}
+
+// CountActiveDeliveriesByCourierID доставки, за которые курьер еще отвечает: невыполненные и не снятые
+// с него авто-освобождением, в том числе просроченные
+func (r *Repository) CountActiveDeliveriesByCourierID(ctx context.Context, courierID int64) (int64, error) {
+	query := `
+        SELECT COUNT(*)
+        FROM delivery
+        WHERE courier_id = $1 AND completed_at IS NULL AND courier_released_at IS NULL
+	`
+
+	var activeDeliveriesCount int64
+	err := r.querier.QueryRow(ctx, query, courierID).Scan(&activeDeliveriesCount)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected delivery repository count active deliveries error: %w", err)
+	}
+
+	return activeDeliveriesCount, nil
+}
+
+func (r *Repository) CountActiveDeliveries(ctx context.Context) (int64, error) {
+	query := `
+        SELECT COUNT(*)
+        FROM delivery
+        WHERE deadline >= NOW() AND completed_at IS NULL
+	`
+
+	var activeDeliveriesCount int64
+	err := r.querier.QueryRow(ctx, query).Scan(&activeDeliveriesCount)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected delivery repository count all active deliveries error: %w", err)
+	}
+
+	return activeDeliveriesCount, nil
+}
+
+// CountCouriersByStatusAndTransportType отключенные курьеры в пул не входят
+func (r *Repository) CountCouriersByStatusAndTransportType(ctx context.Context) ([]entities.CourierPoolCount, error) {
+	query := `
+        SELECT status, transport_type, COUNT(*)
+        FROM couriers
+        WHERE deactivated_at IS NULL
+        GROUP BY status, transport_type
+	`
+
+	rows, err := r.querier.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery repository count couriers error: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make([]entities.CourierPoolCount, 0, 9)
+	for rows.Next() {
+		var countDB CourierPoolCountDB
+		err := rows.Scan(&countDB.Status, &countDB.TransportType, &countDB.Count)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected delivery repository count couriers error: %w", err)
+		}
+		counts = append(counts, ToCourierPoolCountDomain(countDB))
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery repository count couriers error: %w", err)
+	}
+
+	return counts, nil
+}
+
+// MarkOverdue отмечает невыполненные доставки с прошедшим дедлайном и возвращает только отмеченные сейчас,
+// чтобы эскалация по каждой доставке уходила один раз
+func (r *Repository) MarkOverdue(ctx context.Context, now time.Time) ([]entities.Delivery, error) {
+	query := `
+		UPDATE delivery
+		SET overdue_at = $1
+		WHERE overdue_at IS NULL AND completed_at IS NULL AND deadline < $1
+		RETURNING id, courier_id, order_id, restaurant_id, address, estimated_delivery, created_at, assigned_at, deadline, overdue_at, courier_released_at, completed_at,
+			pickup_lat, pickup_lon, dropoff_lat, dropoff_lon, priority, required_skills, allowed_transport_types, cash_amount
+	`
+
+	rows, err := r.querier.Query(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery repository mark overdue error: %w", err)
+	}
+	defer rows.Close()
+
+	deliveryModels, err := scanDeliveries(rows)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery repository mark overdue error: %w", err)
+	}
+
+	return ToDomainList(deliveryModels), nil
+}
+
+// GetOverdue просроченные доставки, которые еще не выполнены
+func (r *Repository) GetOverdue(ctx context.Context) ([]entities.Delivery, error) {
+	query := `
+		SELECT id, courier_id, order_id, restaurant_id, address, estimated_delivery, created_at, assigned_at, deadline, overdue_at, courier_released_at, completed_at,
+			pickup_lat, pickup_lon, dropoff_lat, dropoff_lon, priority, required_skills, allowed_transport_types, cash_amount
+		FROM delivery
+		WHERE overdue_at IS NOT NULL AND completed_at IS NULL
+		ORDER BY deadline ASC, id ASC
+	`
+
+	rows, err := r.querier.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery repository get overdue error: %w", err)
+	}
+	defer rows.Close()
+
+	deliveryModels, err := scanDeliveries(rows)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery repository get overdue error: %w", err)
+	}
+
+	return ToDomainList(deliveryModels), nil
+}
+
+// ReleaseCouriersForOverdueDeliveries освобождает занятых курьеров невыполненных просроченных доставок с дедлайном
+// раньше deadlineBefore. Доставка помечается courier_released_at, поэтому курьера не освободят повторно, когда он
+// возьмет новый заказ. Курьер, у которого есть другая невыполненная и не просроченная так же доставка, остается занятым
+func (r *Repository) ReleaseCouriersForOverdueDeliveries(ctx context.Context, deadlineBefore, releasedAt time.Time) (int64, error) {
+	query := `
+        WITH released AS (
+            UPDATE delivery
+            SET courier_released_at = $2
+            WHERE overdue_at IS NOT NULL
+              AND courier_released_at IS NULL
+              AND completed_at IS NULL
+              AND deadline < $1
+            RETURNING courier_id
         )
+        UPDATE couriers c
+        SET status = 'available',
+            updated_at = NOW(),
+            version = version + 1
+        WHERE c.status = 'busy'
+          AND c.id IN (SELECT courier_id FROM released)
+          AND NOT EXISTS (
+              SELECT 1 FROM delivery d
+              WHERE d.courier_id = c.id
+                AND d.completed_at IS NULL
+                AND d.courier_released_at IS NULL
+                AND NOT (d.overdue_at IS NOT NULL AND d.deadline < $1)
+          )
     `
 
-	result, err := r.querier.Exec(ctx, query)
+	result, err := r.querier.Exec(ctx, query, deadlineBefore, releasedAt)
 	if err != nil {
-		return 0, fmt.Errorf("unexpected delivery repository release expired couriers error: %w", err)
+		return 0, fmt.Errorf("unexpected delivery repository release overdue couriers error: %w", err)
 	}
 
 	return result.RowsAffected(), nil
@@ -191,3 +820,59 @@ func (r *Repository) GetCourierIDByOrderID(ctx context.Context, orderID string)
 
 	return courierID, nil
 }
+
+// MarkCompleted запоминает время выполнения заказа, повторное событие о выполнении его не меняет
+func (r *Repository) MarkCompleted(ctx context.Context, orderID string, completedAt time.Time) error {
+	query := `
+		UPDATE delivery
+		SET completed_at = $2
+		WHERE order_id = $1 AND completed_at IS NULL
+	`
+
+	_, err := r.querier.Exec(ctx, query, orderID, completedAt)
+	if err != nil {
+		return fmt.Errorf("unexpected delivery repository mark completed error: %w", err)
+	}
+
+	return nil
+}
+
+func scanDeliveries(rows pgx.Rows) ([]DeliveryDB, error) {
+	deliveryModels := make([]DeliveryDB, 0, 8)
+	for rows.Next() {
+		var deliveryDB DeliveryDB
+		err := rows.Scan(
+			&deliveryDB.ID,
+			&deliveryDB.CourierID,
+			&deliveryDB.OrderID,
+			&deliveryDB.RestaurantID,
+			&deliveryDB.Address,
+			&deliveryDB.EstimatedDelivery,
+			&deliveryDB.CreatedAt,
+			&deliveryDB.AssignedAt,
+			&deliveryDB.Deadline,
+			&deliveryDB.OverdueAt,
+			&deliveryDB.CourierReleasedAt,
+			&deliveryDB.CompletedAt,
+			&deliveryDB.PickupLat,
+			&deliveryDB.PickupLon,
+			&deliveryDB.DropoffLat,
+			&deliveryDB.DropoffLon,
+			&deliveryDB.Priority,
+			&deliveryDB.RequiredSkills,
+			&deliveryDB.TransportTypes,
+			&deliveryDB.CashAmount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		deliveryModels = append(deliveryModels, deliveryDB)
+	}
+
+	err := rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveryModels, nil
+}
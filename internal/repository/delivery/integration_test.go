@@ -141,6 +141,12 @@ func TestRepository_GetCourierIDAndDeliveryCountByOrderIDForAssing_Success(t *te
             (1, 'target-order', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NOW() + INTERVAL '1 hour'),
             (1, 'other-order-1', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NOW() + INTERVAL '1 hour'),
             (1, 'other-order-2', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NOW() + INTERVAL '1 hour');
+
+        INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline, completed_at, overdue_at, courier_released_at)
+        VALUES
+            (1, 'completed-order', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NOW() + INTERVAL '1 hour', NOW(), NULL, NULL),
+            (1, 'overdue-order', NOW() - INTERVAL '3 hours', NOW() - INTERVAL '3 hours', NOW() - INTERVAL '1 hour', NULL, NOW() - INTERVAL '1 hour', NULL),
+            (1, 'released-order', NOW() - INTERVAL '3 hours', NOW() - INTERVAL '3 hours', NOW() - INTERVAL '2 hours', NULL, NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour');
     `
 
 	integration_test.SetupDB(t, setupSql)
@@ -150,12 +156,12 @@ func TestRepository_GetCourierIDAndDeliveryCountByOrderIDForAssing_Success(t *te
 	repo := delivery.New(q)
 	ctx := context.Background()
 
-	t.Run("Успешное получение ID курьера и количества доставок", func(t *testing.T) {
+	t.Run("Выполненные и снятые авто-освобождением доставки не считаются, просроченная считается", func(t *testing.T) {
 		courierID, count, err := repo.GetCourierIDAndDeliveryCountByOrderIDForAssing(ctx, "target-order")
 		require.NoError(t, err)
 
 		assert.Equal(t, int64(1), courierID)
-		assert.Equal(t, int64(2), count)
+		assert.Equal(t, int64(3), count)
 	})
 }
 
@@ -205,7 +211,7 @@ func TestRepository_GetCourierForAssignment_Success(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("Успешный выбор курьера с минимальной нагрузкой", func(t *testing.T) {
-		courier, err := repo.GetCourierForAssignment(ctx)
+		courier, err := repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{})
 		require.NoError(t, err)
 		require.NotNil(t, courier)
 
@@ -215,6 +221,16 @@ func TestRepository_GetCourierForAssignment_Success(t *testing.T) {
 		assert.Equal(t, entities.CourierAvailable, courier.Status)
 		assert.Equal(t, entities.Scooter, courier.TransportType)
 	})
+
+	t.Run("Выполненные доставки не считаются нагрузкой", func(t *testing.T) {
+		_, err := q.Exec(ctx, `UPDATE delivery SET completed_at = NOW() WHERE courier_id = 1`)
+		require.NoError(t, err)
+
+		courier, err := repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{})
+		require.NoError(t, err)
+		require.NotNil(t, courier)
+		assert.Equal(t, int64(1), courier.ID)
+	})
 }
 
 func TestRepository_GetCourierForAssignment_NoAvailableCouriers(t *testing.T) {
@@ -233,28 +249,452 @@ func TestRepository_GetCourierForAssignment_NoAvailableCouriers(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("Ошибка при отсутствии доступных курьеров", func(t *testing.T) {
-		courier, err := repo.GetCourierForAssignment(ctx)
+		courier, err := repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{})
+		require.Error(t, err)
+		require.Nil(t, courier)
+		assert.ErrorIs(t, err, service.ErrNoAvailableCouriers)
+	})
+}
+
+func TestRepository_GetCourierForAssignment_ZoneFilter(t *testing.T) {
+	setupSql := `
+        INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+        VALUES
+            (1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (2, 'Courier 2', '+79991112234', 'available', 'scooter', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (3, 'Courier 3', '+79991112235', 'available', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+        INSERT INTO zones (id, name, polygon, min_lat, min_lon, max_lat, max_lon)
+        VALUES
+            (1, 'Центр', '[]', 0, 0, 0, 0),
+            (2, 'Север', '[]', 0, 0, 0, 0),
+            (3, 'Юг', '[]', 0, 0, 0, 0);
+
+        INSERT INTO courier_zones (zone_id, courier_id)
+        VALUES (1, 2), (2, 2), (2, 3);
+
+        INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline)
+        VALUES (2, 'order-1', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NOW() + INTERVAL '1 hour');
+    `
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Без фильтра выбирается любой курьер", func(t *testing.T) {
+		courier, err := repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), courier.ID)
+	})
+
+	t.Run("Выбирается только курьер из зоны", func(t *testing.T) {
+		courier, err := repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{ZoneIDs: []int64{1}})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), courier.ID)
+	})
+
+	t.Run("Из нескольких зон выбирается наименее загруженный", func(t *testing.T) {
+		courier, err := repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{ZoneIDs: []int64{1, 2}})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), courier.ID)
+	})
+
+	t.Run("В зоне нет курьеров", func(t *testing.T) {
+		courier, err := repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{ZoneIDs: []int64{3}})
+		require.Nil(t, courier)
+		assert.ErrorIs(t, err, service.ErrNoAvailableCouriers)
+	})
+}
+
+func TestRepository_GetCourierForAssignment_Requirements(t *testing.T) {
+	setupSql := `
+        INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+        VALUES
+            (1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (2, 'Courier 2', '+79991112234', 'available', 'scooter', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (3, 'Courier 3', '+79991112235', 'available', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (4, 'Courier 4', '+79991112236', 'busy', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+        INSERT INTO courier_skills (courier_id, skill)
+        VALUES
+            (1, 'thermal_bag'), (1, 'age_verified'),
+            (2, 'thermal_bag'),
+            (3, 'age_verified'),
+            (4, 'thermal_bag'), (4, 'age_verified');
+    `
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Курьер должен иметь все навыки", func(t *testing.T) {
+		courier, err := repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{
+			Skills: []entities.CourierSkill{entities.SkillThermalBag, entities.SkillAgeVerified},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), courier.ID)
+	})
+
+	t.Run("Навык и допустимый транспорт", func(t *testing.T) {
+		courier, err := repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{
+			Skills:         []entities.CourierSkill{entities.SkillThermalBag},
+			TransportTypes: []entities.CourierTransportType{entities.Scooter, entities.Car},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), courier.ID)
+	})
+
+	t.Run("Никто не подходит", func(t *testing.T) {
+		filter := entities.CourierSearchFilter{
+			Skills:         []entities.CourierSkill{entities.SkillThermalBag, entities.SkillAgeVerified},
+			TransportTypes: []entities.CourierTransportType{entities.Scooter, entities.Car},
+		}
+
+		courier, err := repo.GetCourierForAssignment(ctx, filter)
+		require.Nil(t, courier)
+		assert.ErrorIs(t, err, service.ErrNoAvailableCouriers)
+
+		mismatch, err := repo.ExplainCourierMismatch(ctx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, &entities.CourierMismatch{
+			AvailableCouriers: 3,
+			Requirements: []entities.RequirementMatch{
+				{Requirement: entities.RequirementTransportType, Value: "scooter,car", MatchingCouriers: 2},
+				{Requirement: entities.RequirementSkill, Value: "thermal_bag", MatchingCouriers: 2},
+				{Requirement: entities.RequirementSkill, Value: "age_verified", MatchingCouriers: 2},
+			},
+		}, mismatch)
+	})
+
+	t.Run("Объяснение без требований", func(t *testing.T) {
+		mismatch, err := repo.ExplainCourierMismatch(ctx, entities.CourierSearchFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), mismatch.AvailableCouriers)
+		assert.Empty(t, mismatch.Requirements)
+	})
+}
+
+func TestRepository_GetCourierForAssignment_CashBalance(t *testing.T) {
+	setupSql := `
+        INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+        VALUES
+            (1, 'Courier 1', '+79991112233', 'available', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (2, 'Courier 2', '+79991112234', 'available', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (3, 'Courier 3', '+79991112235', 'available', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+        INSERT INTO courier_cash_balances (courier_id, balance)
+        VALUES (1, 600000), (2, 500000);
+    `
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Курьер с наличными сверх лимита не подбирается", func(t *testing.T) {
+		courier, err := repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{
+			MaxCashBalance:    pointer.To(int64(500000)),
+			ExcludeCourierIDs: []int64{3},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), courier.ID)
+	})
+
+	t.Run("Объяснение с лимитом наличных", func(t *testing.T) {
+		filter := entities.CourierSearchFilter{MaxCashBalance: pointer.To(int64(100000))}
+		mismatch, err := repo.ExplainCourierMismatch(ctx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, &entities.CourierMismatch{
+			AvailableCouriers: 3,
+			Requirements: []entities.RequirementMatch{
+				{Requirement: entities.RequirementCashBalance, Value: "100000", MatchingCouriers: 1},
+			},
+		}, mismatch)
+	})
+}
+
+func TestRepository_GetCourierForAssignment_SkipsDeactivated(t *testing.T) {
+	setupSql := `
+        INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at, deactivated_at, deactivation_reason)
+        VALUES
+            (1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00', '2025-01-16 11:00:00', 'уволился');
+    `
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Отключенный курьер не получает заказы", func(t *testing.T) {
+		courier, err := repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{})
+		require.Error(t, err)
+		require.Nil(t, courier)
+		assert.ErrorIs(t, err, service.ErrNoAvailableCouriers)
+	})
+}
+
+func TestRepository_GetCourierForAssignment_Offers(t *testing.T) {
+	setupSql := `
+        INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+        VALUES
+            (1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (2, 'Courier 2', '+79991112234', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (3, 'Courier 3', '+79991112235', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+        INSERT INTO delivery_offers (order_id, courier_id, status, offered_at, expires_at)
+        VALUES
+            ('order-1', 1, 'pending', '2025-01-15 12:00:00', '2025-01-15 12:01:00'),
+            ('order-2', 2, 'declined', '2025-01-15 12:00:00', '2025-01-15 12:01:00');
+    `
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Курьер с открытым предложением не подбирается", func(t *testing.T) {
+		courier, err := repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{})
+		require.NoError(t, err)
+		assert.NotEqual(t, int64(1), courier.ID)
+	})
+
+	t.Run("Курьеры, которым заказ уже предлагали, исключаются", func(t *testing.T) {
+		courier, err := repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{ExcludeCourierIDs: []int64{2}})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), courier.ID)
+
+		courier, err = repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{ExcludeCourierIDs: []int64{2, 3}})
 		require.Error(t, err)
 		require.Nil(t, courier)
 		assert.ErrorIs(t, err, service.ErrNoAvailableCouriers)
 	})
 }
 
-func TestRepository_UpdateCouriersAvailableWhereDeadlineExpired_Success(t *testing.T) {
+func TestRepository_GetCourierForAssignment_PreferFastTransport(t *testing.T) {
+	setupSql := `
+        INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+        VALUES
+            (1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (2, 'Courier 2', '+79991112234', 'available', 'scooter', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (3, 'Courier 3', '+79991112235', 'available', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+        INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline)
+        VALUES (3, 'order-1', NOW(), NOW(), NOW() + INTERVAL '1 hour');
+    `
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Без предпочтения выбирается наименее загруженный", func(t *testing.T) {
+		courier, err := repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), courier.ID)
+	})
+
+	t.Run("С предпочтением быстрый транспорт важнее загрузки", func(t *testing.T) {
+		courier, err := repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{PreferFastTransport: true})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), courier.ID)
+	})
+}
+
+func TestRepository_GetCourierForAssignment_TopRated(t *testing.T) {
+	// у курьера 3 старая плохая оценка вне окна, в окне он оценен выше курьера 2
+	setupSql := `
+        INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+        VALUES
+            (1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (2, 'Courier 2', '+79991112234', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (3, 'Courier 3', '+79991112235', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+        INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline)
+        VALUES
+            (2, 'order-1', NOW(), NOW(), NOW() + INTERVAL '1 hour'),
+            (3, 'order-2', NOW(), NOW(), NOW() + INTERVAL '1 hour');
+
+        INSERT INTO delivery_ratings (order_id, courier_id, author, score, created_at)
+        VALUES
+            ('order-10', 2, 'customer', 4, NOW() - INTERVAL '1 day'),
+            ('order-11', 3, 'customer', 5, NOW() - INTERVAL '1 day'),
+            ('order-12', 3, 'customer', 1, NOW() - INTERVAL '60 days');
+    `
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Без предпочтения выбирается наименее загруженный", func(t *testing.T) {
+		courier, err := repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), courier.ID)
+	})
+
+	t.Run("С предпочтением оценка за окно важнее загрузки, курьер без оценок последний", func(t *testing.T) {
+		ratedSince := time.Now().UTC().Add(-30 * 24 * time.Hour)
+		courier, err := repo.GetCourierForAssignment(ctx, entities.CourierSearchFilter{TopRatedSince: &ratedSince})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), courier.ID)
+	})
+}
+
+func TestRepository_Create_PriorityAndRequirements(t *testing.T) {
+	setupSql := `
+        INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+        VALUES
+            (1, 'Test Courier', '+79991112233', 'busy', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+    `
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Класс срочности, требования, маршрут и наличные сохраняются в доставке", func(t *testing.T) {
+		now := time.Now().UTC()
+		requirements := entities.OrderRequirements{
+			Skills:         []entities.CourierSkill{entities.SkillThermalBag},
+			TransportTypes: []entities.CourierTransportType{entities.Car},
+		}
+		route := &entities.Route{
+			Pickup:  entities.Location{Latitude: 55.7558, Longitude: 37.6173},
+			Dropoff: entities.Location{Latitude: 55.7602, Longitude: 37.6186},
+		}
+		created, err := repo.Create(ctx, entities.DeliveryModify{
+			CourierID:    pointer.To(int64(1)),
+			OrderID:      pointer.To("normal-order"),
+			CreatedAt:    pointer.To(now),
+			AssignedAt:   pointer.To(now),
+			Deadline:     pointer.To(now.Add(time.Hour)),
+			Priority:     pointer.To(entities.PriorityNormal),
+			Route:        route,
+			Requirements: requirements,
+			CashAmount:   150000,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, route, created.Route)
+
+		locked, err := repo.GetByOrderIDForUpdate(ctx, "normal-order")
+		require.NoError(t, err)
+		assert.Equal(t, route, locked.Route)
+		// по ним подбирается курьер при переназначении
+		assert.Equal(t, entities.PriorityNormal, locked.Priority)
+		assert.Equal(t, requirements, locked.Requirements)
+		assert.Equal(t, int64(150000), locked.CashAmount)
+
+		candidate, err := repo.GetPreemptionCandidateForUpdate(ctx, entities.CourierSearchFilter{}, now.Add(-time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, "normal-order", candidate.Delivery.OrderID)
+		assert.Equal(t, route, candidate.Delivery.Route)
+		assert.Equal(t, requirements, candidate.Requirements)
+		assert.Equal(t, int64(150000), candidate.CashAmount)
+
+		// доставку приоритетного заказа не перехватывают
+		_, err = repo.Create(ctx, entities.DeliveryModify{
+			CourierID:  pointer.To(int64(1)),
+			OrderID:    pointer.To("high-order"),
+			CreatedAt:  pointer.To(now),
+			AssignedAt: pointer.To(now.Add(time.Second)),
+			Deadline:   pointer.To(now.Add(time.Hour)),
+			Priority:   pointer.To(entities.PriorityHigh),
+		})
+		require.NoError(t, err)
+
+		candidate, err = repo.GetPreemptionCandidateForUpdate(ctx, entities.CourierSearchFilter{}, now.Add(-time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, "normal-order", candidate.Delivery.OrderID)
+	})
+}
+
+func TestRepository_GetPreemptionCandidateForUpdate(t *testing.T) {
+	setupSql := `
+        INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at, version)
+        VALUES
+            (1, 'Courier 1', '+79991112233', 'busy', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00', 1),
+            (2, 'Courier 2', '+79991112234', 'busy', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00', 4),
+            (3, 'Courier 3', '+79991112235', 'busy', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00', 1),
+            (4, 'Courier 4', '+79991112236', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00', 1);
+
+        INSERT INTO courier_skills (courier_id, skill)
+        VALUES (2, 'thermal_bag');
+
+        INSERT INTO delivery (courier_id, order_id, restaurant_id, created_at, assigned_at, deadline, priority, completed_at)
+        VALUES
+            (1, 'order-fresh', 'restaurant-1', NOW() - INTERVAL '1 minute', NOW() - INTERVAL '1 minute', NOW() + INTERVAL '1 hour', 'normal', NULL),
+            (2, 'order-older', 'restaurant-1', NOW() - INTERVAL '2 minutes', NOW() - INTERVAL '2 minutes', NOW() + INTERVAL '1 hour', 'normal', NULL),
+            (3, 'order-stale', 'restaurant-1', NOW() - INTERVAL '1 hour', NOW() - INTERVAL '1 hour', NOW() + INTERVAL '1 hour', 'normal', NULL),
+            (4, 'order-completed', 'restaurant-1', NOW() - INTERVAL '30 seconds', NOW() - INTERVAL '30 seconds', NOW() + INTERVAL '1 hour', 'normal', NOW());
+    `
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+	assignedSince := time.Now().UTC().Add(-5 * time.Minute)
+
+	t.Run("Выбирается самая свежая невыполненная доставка", func(t *testing.T) {
+		candidate, err := repo.GetPreemptionCandidateForUpdate(ctx, entities.CourierSearchFilter{}, assignedSince)
+		require.NoError(t, err)
+		assert.Equal(t, "order-fresh", candidate.Delivery.OrderID)
+		assert.Equal(t, "restaurant-1", candidate.Delivery.RestaurantID)
+		assert.Equal(t, int64(1), candidate.Courier.ID)
+	})
+
+	t.Run("Курьер доставки должен подходить под требования", func(t *testing.T) {
+		candidate, err := repo.GetPreemptionCandidateForUpdate(ctx, entities.CourierSearchFilter{
+			Skills:         []entities.CourierSkill{entities.SkillThermalBag},
+			TransportTypes: []entities.CourierTransportType{entities.Car},
+		}, assignedSince)
+		require.NoError(t, err)
+		assert.Equal(t, "order-older", candidate.Delivery.OrderID)
+		assert.Equal(t, int64(4), candidate.Courier.Version)
+	})
+
+	t.Run("Давно назначенные доставки не перехватываются", func(t *testing.T) {
+		candidate, err := repo.GetPreemptionCandidateForUpdate(ctx, entities.CourierSearchFilter{
+			TransportTypes: []entities.CourierTransportType{entities.Car},
+		}, time.Now().UTC().Add(-90*time.Second))
+		require.Error(t, err)
+		require.Nil(t, candidate)
+		assert.ErrorIs(t, err, service.ErrNoPreemptionCandidate)
+	})
+}
+
+func TestRepository_MarkOverdue_Success(t *testing.T) {
 	setupSql := `
 		INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
 		VALUES
 		(1, 'Courier 1', '+79991112233', 'busy', 'on_foot', NOW(), NOW()),
-		(2, 'Courier 2', '+79991112234', 'busy', 'scooter', NOW(), NOW()),
-		(3, 'Courier 3', '+79991112235', 'available', 'car', NOW(), NOW()),
-		(4, 'Courier 4', '+79991112236', 'busy', 'car', NOW(), NOW());
+		(2, 'Courier 2', '+79991112234', 'busy', 'scooter', NOW(), NOW());
 
-		INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline)
+		INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline, overdue_at, completed_at)
 		VALUES
-		(1, 'expired-1', NOW() - INTERVAL '3 hours', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour'),
-		(2, 'expired-2', NOW() - INTERVAL '3 hours', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour'),
-		(2, 'active-1', NOW() - INTERVAL '1 hour', NOW() - INTERVAL '30 minutes', NOW() + INTERVAL '30 minutes'),
-		(4, 'expired-3', NOW() - INTERVAL '3 hours', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour');
+		(1, 'expired-1', NOW() - INTERVAL '3 hours', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NULL, NULL),
+		(2, 'expired-marked', NOW() - INTERVAL '3 hours', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NOW() - INTERVAL '50 minutes', NULL),
+		(2, 'active-1', NOW() - INTERVAL '1 hour', NOW() - INTERVAL '30 minutes', NOW() + INTERVAL '30 minutes', NULL, NULL),
+		(2, 'completed-1', NOW() - INTERVAL '3 hours', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NULL, NOW() - INTERVAL '90 minutes');
 	`
 
 	integration_test.SetupDB(t, setupSql)
@@ -264,28 +704,126 @@ func TestRepository_UpdateCouriersAvailableWhereDeadlineExpired_Success(t *testi
 	repo := delivery.New(q)
 	ctx := context.Background()
 
-	t.Run("Успешное обновление статуса курьеров с истекшими дедлайнами", func(t *testing.T) {
-		rowsAffected, err := repo.UpdateCouriersAvailableWhereDeadlineExpired(ctx)
+	t.Run("Отмечаются только новые просроченные невыполненные доставки", func(t *testing.T) {
+		now := time.Now().UTC()
+
+		overdue, err := repo.MarkOverdue(ctx, now)
 		require.NoError(t, err)
-		assert.Equal(t, int64(3), rowsAffected)
+		require.Len(t, overdue, 1)
+		assert.Equal(t, "expired-1", overdue[0].OrderID)
+		assert.Equal(t, int64(1), overdue[0].CourierID)
+		require.NotNil(t, overdue[0].OverdueAt)
+		assert.WithinDuration(t, now, *overdue[0].OverdueAt, time.Second)
+		assert.Nil(t, overdue[0].CourierReleasedAt)
+
+		overdue, err = repo.MarkOverdue(ctx, now)
+		require.NoError(t, err)
+		assert.Empty(t, overdue)
+
+		var status string
+		err = q.QueryRow(ctx, "SELECT status FROM couriers WHERE id = 1").Scan(&status)
+		require.NoError(t, err)
+		assert.Equal(t, "busy", status)
+	})
+}
+
+func TestRepository_GetOverdue_Success(t *testing.T) {
+	setupSql := `
+		INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+		VALUES
+		(1, 'Courier 1', '+79991112233', 'busy', 'on_foot', NOW(), NOW());
+
+		INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline, overdue_at, completed_at)
+		VALUES
+		(1, 'overdue-late', '2025-01-15 10:00:00', '2025-01-15 10:30:00', '2025-01-15 11:30:00', '2025-01-15 11:31:00', NULL),
+		(1, 'overdue-early', '2025-01-15 10:00:00', '2025-01-15 10:30:00', '2025-01-15 11:00:00', '2025-01-15 11:01:00', NULL),
+		(1, 'not-marked', '2025-01-15 10:00:00', '2025-01-15 10:30:00', '2025-01-15 11:00:00', NULL, NULL),
+		(1, 'overdue-completed', '2025-01-15 10:00:00', '2025-01-15 10:30:00', '2025-01-15 11:00:00', '2025-01-15 11:01:00', '2025-01-15 11:10:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
 
-		var status1, status2, status3, status4 string
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
 
-		err = q.QueryRow(ctx, "SELECT status FROM couriers WHERE id = 1").Scan(&status1)
+	t.Run("Просроченные доставки отсортированы по дедлайну", func(t *testing.T) {
+		overdue, err := repo.GetOverdue(ctx)
 		require.NoError(t, err)
-		assert.Equal(t, "available", status1)
+		require.Len(t, overdue, 2)
+		assert.Equal(t, "overdue-early", overdue[0].OrderID)
+		assert.Equal(t, "overdue-late", overdue[1].OrderID)
+		require.NotNil(t, overdue[0].OverdueAt)
+		assert.WithinDuration(t, time.Date(2025, 1, 15, 11, 1, 0, 0, time.UTC), *overdue[0].OverdueAt, time.Second)
+	})
+}
+
+func TestRepository_ReleaseCouriersForOverdueDeliveries_Success(t *testing.T) {
+	setupSql := `
+		INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+		VALUES
+		(1, 'Courier 1', '+79991112233', 'busy', 'on_foot', NOW(), NOW()),
+		(2, 'Courier 2', '+79991112234', 'busy', 'scooter', NOW(), NOW()),
+		(3, 'Courier 3', '+79991112235', 'busy', 'car', NOW(), NOW()),
+		(4, 'Courier 4', '+79991112236', 'busy', 'car', NOW(), NOW()),
+		(5, 'Courier 5', '+79991112237', 'busy', 'car', NOW(), NOW()),
+		(6, 'Courier 6', '+79991112238', 'busy', 'car', NOW(), NOW());
+
+		INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline, overdue_at, courier_released_at, completed_at)
+		VALUES
+		(1, 'overdue-grace-expired', NOW() - INTERVAL '3 hours', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NOW() - INTERVAL '50 minutes', NULL, NULL),
+		(1, 'overdue-grace-expired-2', NOW() - INTERVAL '3 hours', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NOW() - INTERVAL '50 minutes', NULL, NULL),
+		(2, 'overdue-in-grace', NOW() - INTERVAL '1 hour', NOW() - INTERVAL '30 minutes', NOW() - INTERVAL '5 minutes', NOW() - INTERVAL '4 minutes', NULL, NULL),
+		(3, 'overdue-already-released', NOW() - INTERVAL '3 hours', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NOW() - INTERVAL '50 minutes', NOW() - INTERVAL '40 minutes', NULL),
+		(4, 'expired-not-marked', NOW() - INTERVAL '3 hours', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NULL, NULL, NULL),
+		(5, 'overdue-completed', NOW() - INTERVAL '3 hours', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NOW() - INTERVAL '50 minutes', NULL, NOW() - INTERVAL '30 minutes'),
+		(5, 'newer-order', NOW() - INTERVAL '20 minutes', NOW() - INTERVAL '20 minutes', NOW() + INTERVAL '10 minutes', NULL, NULL, NULL),
+		(6, 'overdue-with-other', NOW() - INTERVAL '3 hours', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NOW() - INTERVAL '50 minutes', NULL, NULL),
+		(6, 'other-active', NOW() - INTERVAL '20 minutes', NOW() - INTERVAL '20 minutes', NOW() + INTERVAL '10 minutes', NULL, NULL, NULL);
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Освобождаются только курьеры с истекшим льготным периодом и без других доставок", func(t *testing.T) {
+		now := time.Now().UTC()
 
-		err = q.QueryRow(ctx, "SELECT status FROM couriers WHERE id = 2").Scan(&status2)
+		rowsAffected, err := repo.ReleaseCouriersForOverdueDeliveries(ctx, now.Add(-15*time.Minute), now)
 		require.NoError(t, err)
-		assert.Equal(t, "available", status2)
+		assert.Equal(t, int64(1), rowsAffected)
+
+		expectedStatuses := map[int64]string{
+			1: "available",
+			2: "busy",
+			3: "busy",
+			4: "busy",
+			5: "busy",
+			6: "busy",
+		}
+		for courierID, expectedStatus := range expectedStatuses {
+			var status string
+			err = q.QueryRow(ctx, "SELECT status FROM couriers WHERE id = $1", courierID).Scan(&status)
+			require.NoError(t, err)
+			assert.Equal(t, expectedStatus, status, "courier %d", courierID)
+		}
+
+		var releasedAt *time.Time
+		err = q.QueryRow(ctx, "SELECT courier_released_at FROM delivery WHERE order_id = 'overdue-grace-expired'").Scan(&releasedAt)
+		require.NoError(t, err)
+		require.NotNil(t, releasedAt)
 
-		err = q.QueryRow(ctx, "SELECT status FROM couriers WHERE id = 3").Scan(&status3)
+		err = q.QueryRow(ctx, "SELECT courier_released_at FROM delivery WHERE order_id = 'overdue-completed'").Scan(&releasedAt)
 		require.NoError(t, err)
-		assert.Equal(t, "available", status3)
+		assert.Nil(t, releasedAt)
 
-		err = q.QueryRow(ctx, "SELECT status FROM couriers WHERE id = 4").Scan(&status4)
+		rowsAffected, err = repo.ReleaseCouriersForOverdueDeliveries(ctx, now.Add(-15*time.Minute), now)
 		require.NoError(t, err)
-		assert.Equal(t, "available", status4)
+		assert.Equal(t, int64(0), rowsAffected)
 	})
 }
 
@@ -470,3 +1008,391 @@ func TestRepository_GetCourierIDByOrderID_NotFound(t *testing.T) {
 		assert.Equal(t, int64(0), courierID)
 	})
 }
+
+func TestRepository_Create_WithOrderDetails(t *testing.T) {
+	setupSql := `
+        INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+        VALUES
+            (1, 'Test Courier', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+    `
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	address := &entities.Address{Street: "Тверская", House: "1", Apartment: "15", Comment: "код 15К"}
+
+	t.Run("Адрес, ресторан и обещанное время сохраняются вместе с доставкой", func(t *testing.T) {
+		created, err := repo.Create(ctx, entities.DeliveryModify{
+			CourierID:         pointer.To(int64(1)),
+			OrderID:           pointer.To("order-with-details"),
+			RestaurantID:      pointer.To("restaurant-7"),
+			Address:           address,
+			EstimatedDelivery: pointer.To(time.Date(2025, 1, 15, 12, 40, 0, 0, time.UTC)),
+			CreatedAt:         pointer.To(time.Date(2025, 1, 15, 11, 30, 0, 0, time.UTC)),
+			AssignedAt:        pointer.To(time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)),
+			Deadline:          pointer.To(time.Date(2025, 1, 15, 12, 40, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, created)
+		assert.Equal(t, "restaurant-7", created.RestaurantID)
+
+		actual, err := repo.GetByOrderID(ctx, "order-with-details")
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, int64(1), actual.CourierID)
+		assert.Equal(t, "restaurant-7", actual.RestaurantID)
+		require.NotNil(t, actual.Address)
+		assert.Equal(t, *address, *actual.Address)
+		require.NotNil(t, actual.EstimatedDelivery)
+		assert.WithinDuration(t, time.Date(2025, 1, 15, 12, 40, 0, 0, time.UTC), *actual.EstimatedDelivery, time.Second)
+	})
+}
+
+func TestRepository_GetByOrderID_WithoutOrderDetails(t *testing.T) {
+	setupSql := `
+        INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+        VALUES
+            (1, 'Test Courier', '+79991112233', 'busy', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+        INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline)
+        VALUES (1, 'legacy-order', '2025-01-15 11:00:00', '2025-01-15 11:30:00', '2025-01-15 12:00:00');
+    `
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Доставка, созданная до появления данных заказа", func(t *testing.T) {
+		actual, err := repo.GetByOrderID(ctx, "legacy-order")
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, "legacy-order", actual.OrderID)
+		assert.Empty(t, actual.RestaurantID)
+		assert.Nil(t, actual.Address)
+		assert.Nil(t, actual.EstimatedDelivery)
+		assert.Nil(t, actual.Route)
+	})
+}
+
+func TestRepository_GetByOrderID_NotFound(t *testing.T) {
+	integration_test.SetupDB(t, "")
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Ошибка при получении несуществующей доставки", func(t *testing.T) {
+		actual, err := repo.GetByOrderID(ctx, "unknown-order")
+		require.Error(t, err)
+		require.Nil(t, actual)
+		assert.ErrorIs(t, err, service.ErrDeliveryNotFound)
+	})
+}
+
+func TestRepository_Reassign_Success(t *testing.T) {
+	setupSql := `
+        INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+        VALUES
+            (1, 'Test Courier 1', '+79991112233', 'busy', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (2, 'Test Courier 2', '+79991112234', 'available', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+        INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline, overdue_at, courier_released_at, completed_at)
+        VALUES
+            (1, 'order-1', '2025-01-15 11:00:00', '2025-01-15 11:30:00', '2025-01-15 12:00:00', '2025-01-15 12:01:00', '2025-01-15 12:20:00', NULL),
+            (1, 'order-completed', '2025-01-15 11:00:00', '2025-01-15 11:30:00', '2025-01-15 12:00:00', NULL, NULL, '2025-01-15 11:50:00');
+    `
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Заказ передается другому курьеру с новым дедлайном", func(t *testing.T) {
+		actual, err := repo.Reassign(ctx, entities.DeliveryModify{
+			OrderID:    pointer.To("order-1"),
+			CourierID:  pointer.To(int64(2)),
+			AssignedAt: pointer.To(time.Date(2025, 1, 15, 11, 45, 0, 0, time.UTC)),
+			Deadline:   pointer.To(time.Date(2025, 1, 15, 12, 5, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, int64(2), actual.CourierID)
+		assert.Equal(t, "order-1", actual.OrderID)
+		assert.WithinDuration(t, time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC), actual.CreatedAt, time.Second)
+		assert.WithinDuration(t, time.Date(2025, 1, 15, 11, 45, 0, 0, time.UTC), actual.AssignedAt, time.Second)
+		assert.WithinDuration(t, time.Date(2025, 1, 15, 12, 5, 0, 0, time.UTC), actual.Deadline, time.Second)
+		assert.Nil(t, actual.OverdueAt)
+		assert.Nil(t, actual.CourierReleasedAt)
+	})
+
+	t.Run("Выполненная доставка не передается", func(t *testing.T) {
+		actual, err := repo.Reassign(ctx, entities.DeliveryModify{
+			OrderID:    pointer.To("order-completed"),
+			CourierID:  pointer.To(int64(2)),
+			AssignedAt: pointer.To(time.Date(2025, 1, 15, 11, 45, 0, 0, time.UTC)),
+			Deadline:   pointer.To(time.Date(2025, 1, 15, 12, 5, 0, 0, time.UTC)),
+		})
+		require.Error(t, err)
+		require.Nil(t, actual)
+		assert.ErrorIs(t, err, service.ErrDeliveryNotFound)
+
+		completed, err := repo.GetByOrderIDForUpdate(ctx, "order-completed")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), completed.CourierID)
+		require.NotNil(t, completed.CompletedAt)
+	})
+
+	t.Run("Передача несуществующего заказа", func(t *testing.T) {
+		actual, err := repo.Reassign(ctx, entities.DeliveryModify{
+			OrderID:    pointer.To("order-404"),
+			CourierID:  pointer.To(int64(2)),
+			AssignedAt: pointer.To(time.Date(2025, 1, 15, 11, 45, 0, 0, time.UTC)),
+			Deadline:   pointer.To(time.Date(2025, 1, 15, 12, 5, 0, 0, time.UTC)),
+		})
+		require.Error(t, err)
+		require.Nil(t, actual)
+		assert.ErrorIs(t, err, service.ErrDeliveryNotFound)
+	})
+}
+
+func TestRepository_CreateReassignment_Success(t *testing.T) {
+	integration_test.SetupDB(t, "")
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Запись о передаче заказа сохраняется с причиной", func(t *testing.T) {
+		actual, err := repo.CreateReassignment(ctx, entities.DeliveryReassignmentModify{
+			OrderID:           pointer.To("order-1"),
+			PreviousCourierID: pointer.To(int64(1)),
+			CourierID:         pointer.To(int64(2)),
+			Reason:            pointer.To("курьер попал в ДТП"),
+			Deadline:          pointer.To(time.Date(2025, 1, 15, 12, 5, 0, 0, time.UTC)),
+			ReassignedAt:      pointer.To(time.Date(2025, 1, 15, 11, 45, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.NotZero(t, actual.ID)
+		assert.Equal(t, "order-1", actual.OrderID)
+		assert.Equal(t, int64(1), actual.PreviousCourierID)
+		assert.Equal(t, int64(2), actual.CourierID)
+		assert.Equal(t, "курьер попал в ДТП", actual.Reason)
+		assert.WithinDuration(t, time.Date(2025, 1, 15, 12, 5, 0, 0, time.UTC), actual.Deadline, time.Second)
+		assert.WithinDuration(t, time.Date(2025, 1, 15, 11, 45, 0, 0, time.UTC), actual.ReassignedAt, time.Second)
+	})
+}
+
+func TestRepository_GetCourierByIDForUpdate(t *testing.T) {
+	setupSql := `
+        INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+        VALUES
+            (1, 'Test Courier', '+79991112233', 'busy', 'scooter', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+    `
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Курьер найден вместе со статусом и транспортом", func(t *testing.T) {
+		actual, err := repo.GetCourierByIDForUpdate(ctx, 1)
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, entities.CourierBusy, actual.Status)
+		assert.Equal(t, entities.Scooter, actual.TransportType)
+	})
+
+	t.Run("Курьер не найден", func(t *testing.T) {
+		actual, err := repo.GetCourierByIDForUpdate(ctx, 404)
+		require.Error(t, err)
+		require.Nil(t, actual)
+		assert.ErrorIs(t, err, service.ErrCourierNotFound)
+	})
+}
+
+func TestRepository_CountActiveDeliveriesByCourierID(t *testing.T) {
+	setupSql := `
+        INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+        VALUES
+            (1, 'Test Courier', '+79991112233', 'busy', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (3, 'Test Courier 3', '+79991112235', 'busy', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (4, 'Test Courier 4', '+79991112236', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+        INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline, completed_at, overdue_at, courier_released_at)
+        VALUES
+            (1, 'order-active', NOW(), NOW(), NOW() + INTERVAL '30 minutes', NULL, NULL, NULL),
+            (1, 'order-completed', NOW(), NOW(), NOW() + INTERVAL '30 minutes', NOW(), NULL, NULL),
+            (3, 'order-overdue', '2025-01-15 11:00:00', '2025-01-15 11:30:00', '2025-01-15 12:00:00', NULL, '2025-01-15 12:01:00', NULL),
+            (4, 'order-released', '2025-01-15 11:00:00', '2025-01-15 11:30:00', '2025-01-15 12:00:00', NULL, '2025-01-15 12:01:00', '2025-01-15 12:20:00');
+    `
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Выполненные доставки не учитываются", func(t *testing.T) {
+		count, err := repo.CountActiveDeliveriesByCourierID(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Просроченная доставка держит курьера, пока авто-освобождение выключено", func(t *testing.T) {
+		count, err := repo.CountActiveDeliveriesByCourierID(ctx, 3)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Доставка, с которой курьера сняло авто-освобождение, не учитывается", func(t *testing.T) {
+		count, err := repo.CountActiveDeliveriesByCourierID(ctx, 4)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("У курьера без доставок ноль активных", func(t *testing.T) {
+		count, err := repo.CountActiveDeliveriesByCourierID(ctx, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+}
+
+func TestRepository_CountCouriersByStatusAndTransportType(t *testing.T) {
+	setupSql := `
+        INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+        VALUES
+            (1, 'Test Courier 1', '+79991112233', 'available', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (2, 'Test Courier 2', '+79991112234', 'available', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (3, 'Test Courier 3', '+79991112235', 'busy', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+    `
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Курьеры группируются по статусу и типу транспорта", func(t *testing.T) {
+		actual, err := repo.CountCouriersByStatusAndTransportType(ctx)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []entities.CourierPoolCount{
+			{Status: entities.CourierAvailable, TransportType: entities.Car, Count: 2},
+			{Status: entities.CourierBusy, TransportType: entities.OnFoot, Count: 1},
+		}, actual)
+	})
+}
+
+func TestRepository_CountActiveDeliveries(t *testing.T) {
+	setupSql := `
+        INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+        VALUES
+            (1, 'Test Courier 1', '+79991112233', 'busy', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (2, 'Test Courier 2', '+79991112234', 'busy', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+        INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline)
+        VALUES
+            (1, 'order-active-1', NOW(), NOW(), NOW() + INTERVAL '30 minutes'),
+            (2, 'order-active-2', NOW(), NOW(), NOW() + INTERVAL '10 minutes'),
+            (2, 'order-expired', '2025-01-15 11:00:00', '2025-01-15 11:30:00', '2025-01-15 12:00:00');
+    `
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Учитываются только доставки с непрошедшим дедлайном", func(t *testing.T) {
+		count, err := repo.CountActiveDeliveries(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+}
+
+func TestRepository_GetCouriersForDispatch(t *testing.T) {
+	setupSql := `
+        INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+        VALUES
+            (1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (2, 'Courier 2', '+79991112234', 'available', 'scooter', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (3, 'Courier 3', '+79991112235', 'busy', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+            (4, 'Courier 4', '+79991112236', 'available', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+        INSERT INTO zones (id, name, polygon, min_lat, min_lon, max_lat, max_lon)
+        VALUES
+            (1, 'Центр', '[]', 0, 0, 0, 0),
+            (2, 'Север', '[]', 0, 0, 0, 0);
+
+        INSERT INTO courier_zones (zone_id, courier_id)
+        VALUES (1, 1), (2, 1);
+
+        INSERT INTO courier_skills (courier_id, skill)
+        VALUES (1, 'thermal_bag');
+
+        INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline, completed_at)
+        VALUES
+            (1, 'order-1', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NOW() + INTERVAL '1 hour', NULL),
+            (1, 'order-2', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NOW() - INTERVAL '30 minutes', NULL),
+            (1, 'order-4', NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour', NOW() + INTERVAL '1 hour', NOW());
+
+        INSERT INTO delivery_offers (order_id, courier_id, status, offered_at, expires_at)
+        VALUES ('order-3', 4, 'pending', '2025-01-15 12:00:00', '2025-01-15 12:01:00');
+
+        INSERT INTO courier_cash_balances (courier_id, balance)
+        VALUES (1, 250000);
+    `
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Свободные курьеры без открытых предложений с загрузкой, навыками, зонами и наличными", func(t *testing.T) {
+		couriers, err := repo.GetCouriersForDispatch(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, couriers, 2)
+
+		assert.Equal(t, int64(1), couriers[0].Courier.ID)
+		assert.Equal(t, int64(1), couriers[0].ActiveDeliveries)
+		assert.Equal(t, []entities.CourierSkill{entities.SkillThermalBag}, couriers[0].Skills)
+		assert.Equal(t, []int64{1, 2}, couriers[0].ZoneIDs)
+		assert.Equal(t, int64(250000), couriers[0].CashBalance)
+
+		assert.Equal(t, int64(2), couriers[1].Courier.ID)
+		assert.Equal(t, entities.Scooter, couriers[1].Courier.TransportType)
+		assert.Zero(t, couriers[1].ActiveDeliveries)
+		assert.Empty(t, couriers[1].Skills)
+		assert.Empty(t, couriers[1].ZoneIDs)
+		assert.Zero(t, couriers[1].CashBalance)
+	})
+
+	t.Run("Количество курьеров ограничено", func(t *testing.T) {
+		couriers, err := repo.GetCouriersForDispatch(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, couriers, 1)
+		assert.Equal(t, int64(1), couriers[0].Courier.ID)
+	})
+}
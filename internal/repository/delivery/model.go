@@ -1,23 +1,57 @@
 package delivery
 
-import "time"
+import (
+	"time"
+
+	"service/internal/repository"
+)
 
 type DeliveryDB struct {
-	ID         int64
-	CourierID  int64
-	OrderID    string
-	CreatedAt  time.Time
-	AssignedAt time.Time
-	Deadline   time.Time
+	ID                int64
+	CourierID         int64
+	OrderID           string
+	RestaurantID      *string
+	Address           *repository.AddressDB
+	EstimatedDelivery *time.Time
+	CreatedAt         time.Time
+	AssignedAt        time.Time
+	Deadline          time.Time
+	OverdueAt         *time.Time
+	CourierReleasedAt *time.Time
+	CompletedAt       *time.Time
+	PickupLat         *float64
+	PickupLon         *float64
+	DropoffLat        *float64
+	DropoffLon        *float64
+	Priority          string
+	RequiredSkills    []string
+	TransportTypes    []string
+	CashAmount        int64
 }
 
 type DeliveryModifyDB struct {
-	ID         *int64
-	CourierID  *int64
-	OrderID    *string
-	CreatedAt  *time.Time
-	AssignedAt *time.Time
-	Deadline   *time.Time
+	ID                *int64
+	CourierID         *int64
+	OrderID           *string
+	RestaurantID      *string
+	Address           *repository.AddressDB
+	EstimatedDelivery *time.Time
+	CreatedAt         *time.Time
+	AssignedAt        *time.Time
+	Deadline          *time.Time
+	Priority          *string
+	RequiredSkills    []string
+	TransportTypes    []string
+	PickupLat         *float64
+	PickupLon         *float64
+	DropoffLat        *float64
+	DropoffLon        *float64
+	CashAmount        int64
+}
+
+type PreemptionCandidateDB struct {
+	Delivery DeliveryDB
+	Courier  AvailableCourierDB
 }
 
 type AvailableCourierDB struct {
@@ -28,4 +62,40 @@ type AvailableCourierDB struct {
 	TransportType string
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	DeactivatedAt *time.Time
+	Version       int64
+}
+
+type DispatchCourierDB struct {
+	AvailableCourierDB
+	ActiveDeliveries int64
+	Skills           []string
+	ZoneIDs          []int64
+	CashBalance      int64
+}
+
+type DeliveryReassignmentDB struct {
+	ID                int64
+	OrderID           string
+	PreviousCourierID int64
+	CourierID         int64
+	Reason            string
+	Deadline          time.Time
+	ReassignedAt      time.Time
+}
+
+type DeliveryReassignmentModifyDB struct {
+	ID                *int64
+	OrderID           *string
+	PreviousCourierID *int64
+	CourierID         *int64
+	Reason            *string
+	Deadline          *time.Time
+	ReassignedAt      *time.Time
+}
+
+type CourierPoolCountDB struct {
+	Status        string
+	TransportType string
+	Count         int64
 }
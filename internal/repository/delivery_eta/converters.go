@@ -0,0 +1,27 @@
+package delivery_eta
+
+import (
+	"time"
+
+	"service/internal/entities"
+)
+
+func ToDomain(q *ETAQuantileDB) *entities.ETAQuantile {
+	if q == nil {
+		return nil
+	}
+
+	return &entities.ETAQuantile{
+		TransportType: entities.CourierTransportType(q.TransportType),
+		Weekday:       int(q.Weekday),
+		Hour:          int(q.Hour),
+		SampleCount:   q.SampleCount,
+		P50:           secondsToDuration(q.P50Seconds),
+		P90:           secondsToDuration(q.P90Seconds),
+		TrainedAt:     q.TrainedAt,
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Second)
+}
@@ -0,0 +1,134 @@
+package delivery_eta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"service/internal/entities"
+	"service/internal/service/delivery_eta"
+)
+
+type Repository struct {
+	querier Querier
+}
+
+func New(querier Querier) *Repository {
+	return &Repository{
+		querier: querier,
+	}
+}
+
+// LockRefresh сериализует обновление модели между инстансами до конца транзакции
+func (r *Repository) LockRefresh(ctx context.Context) error {
+	query := `
+		SELECT pg_advisory_xact_lock(hashtext('delivery_eta_quantiles'))
+	`
+
+	_, err := r.querier.Exec(ctx, query)
+	if err != nil {
+		return fmt.Errorf("unexpected delivery eta repository lock error: %w", err)
+	}
+
+	return nil
+}
+
+// GetTrainedAt когда модель обучена последний раз, nil - модель еще не обучалась
+func (r *Repository) GetTrainedAt(ctx context.Context) (*time.Time, error) {
+	query := `
+		SELECT MAX(trained_at)
+		FROM delivery_eta_quantiles
+	`
+
+	var trainedAt *time.Time
+	err := r.querier.QueryRow(ctx, query).Scan(&trainedAt)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery eta repository get trained at error: %w", err)
+	}
+
+	return trainedAt, nil
+}
+
+// Replace заменяет модель квантилями, посчитанными по выполненным доставкам с assigned_at >= since.
+// Вид транспорта берется у курьера доставки. Доставки, с которых курьер снят авто-освобождением, не учитываются.
+// Ячейки, где доставок меньше minSamples, в модель не попадают. Вызывать внутри транзакции
+func (r *Repository) Replace(ctx context.Context, since time.Time, minSamples int, trainedAt time.Time) (int64, error) {
+	_, err := r.querier.Exec(ctx, `DELETE FROM delivery_eta_quantiles`)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected delivery eta repository clear error: %w", err)
+	}
+
+	query := `
+		WITH samples AS (
+			SELECT
+				c.transport_type,
+				EXTRACT(DOW FROM d.assigned_at)::SMALLINT AS weekday,
+				EXTRACT(HOUR FROM d.assigned_at)::SMALLINT AS hour,
+				EXTRACT(EPOCH FROM d.completed_at - d.assigned_at)::DOUBLE PRECISION AS duration_seconds
+			FROM delivery d
+			JOIN couriers c ON c.id = d.courier_id
+			WHERE d.completed_at IS NOT NULL
+				AND d.completed_at > d.assigned_at
+				AND d.courier_released_at IS NULL
+				AND d.assigned_at >= $1
+		)
+		INSERT INTO delivery_eta_quantiles (transport_type, weekday, hour, sample_count, p50_seconds, p90_seconds, trained_at)
+		SELECT
+			transport_type,
+			COALESCE(weekday, -1),
+			COALESCE(hour, -1),
+			COUNT(*),
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY duration_seconds),
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY duration_seconds),
+			$3
+		FROM samples
+		GROUP BY GROUPING SETS ((transport_type, weekday, hour), (transport_type))
+		HAVING COUNT(*) >= $2
+	`
+
+	result, err := r.querier.Exec(ctx, query, since, minSamples, trainedAt)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected delivery eta repository train error: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// FindQuantile квантили ячейки вида транспорта, дня недели и часа, а если в ячейке мало доставок -
+// квантили по всему виду транспорта
+func (r *Repository) FindQuantile(
+	ctx context.Context,
+	transportType entities.CourierTransportType,
+	weekday int,
+	hour int,
+) (*entities.ETAQuantile, error) {
+	query := `
+		SELECT transport_type, weekday, hour, sample_count, p50_seconds, p90_seconds, trained_at
+		FROM delivery_eta_quantiles
+		WHERE transport_type = $1
+			AND ((weekday = $2 AND hour = $3) OR (weekday = -1 AND hour = -1))
+		ORDER BY weekday DESC
+		LIMIT 1
+	`
+
+	var quantileDB ETAQuantileDB
+	err := r.querier.QueryRow(ctx, query, transportType.String(), weekday, hour).Scan(
+		&quantileDB.TransportType,
+		&quantileDB.Weekday,
+		&quantileDB.Hour,
+		&quantileDB.SampleCount,
+		&quantileDB.P50Seconds,
+		&quantileDB.P90Seconds,
+		&quantileDB.TrainedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, delivery_eta.ErrETAModelNotFound
+		}
+		return nil, fmt.Errorf("unexpected delivery eta repository find quantile error: %w", err)
+	}
+
+	return ToDomain(&quantileDB), nil
+}
@@ -0,0 +1,106 @@
+//go:build integration
+
+package delivery_eta_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"service/internal/entities"
+	"service/internal/repository/delivery_eta"
+	"service/internal/repository/integration_test"
+	service "service/internal/service/delivery_eta"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 2025-01-15 - среда. Курьер на машине за 12:00 отвез три заказа за 10, 20 и 30 минут,
+// в 15:00 - один заказ за 40 минут. Доставка без отметки о выполнении и доставка,
+// с которой курьер снят авто-освобождением, в модель не попадают
+const trainSetupSql = `
+	INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+	VALUES (1, 'Courier 1', '+79991112233', 'available', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+	INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline, completed_at, courier_released_at)
+	VALUES
+		(1, 'order-1', '2025-01-15 12:00:00', '2025-01-15 12:00:00', '2025-01-15 13:00:00', '2025-01-15 12:10:00', NULL),
+		(1, 'order-2', '2025-01-15 12:05:00', '2025-01-15 12:05:00', '2025-01-15 13:00:00', '2025-01-15 12:25:00', NULL),
+		(1, 'order-3', '2025-01-15 12:10:00', '2025-01-15 12:10:00', '2025-01-15 13:00:00', '2025-01-15 12:40:00', NULL),
+		(1, 'order-4', '2025-01-15 15:00:00', '2025-01-15 15:00:00', '2025-01-15 16:00:00', '2025-01-15 15:40:00', NULL),
+		(1, 'order-5', '2025-01-15 12:15:00', '2025-01-15 12:15:00', '2025-01-15 13:00:00', NULL, NULL),
+		(1, 'order-6', '2025-01-15 12:20:00', '2025-01-15 12:20:00', '2025-01-15 13:00:00', '2025-01-15 14:20:00', '2025-01-15 13:10:00');
+`
+
+func TestRepository_Replace(t *testing.T) {
+	integration_test.SetupDB(t, trainSetupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery_eta.New(q)
+	ctx := context.Background()
+
+	trainedAt := time.Date(2025, 1, 16, 3, 0, 0, 0, time.UTC)
+
+	t.Run("Ячейки с малым числом доставок не попадают в модель", func(t *testing.T) {
+		count, err := repo.Replace(ctx, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 3, trainedAt)
+		require.NoError(t, err)
+		// ячейка среды 12:00 и весь транспорт car, ячейка 15:00 с одной доставкой отброшена
+		assert.Equal(t, int64(2), count)
+
+		actual, err := repo.FindQuantile(ctx, entities.Car, int(time.Wednesday), 12)
+		require.NoError(t, err)
+		assert.Equal(t, int(time.Wednesday), actual.Weekday)
+		assert.Equal(t, 12, actual.Hour)
+		assert.Equal(t, int64(3), actual.SampleCount)
+		assert.Equal(t, 20*time.Minute, actual.P50)
+		assert.Equal(t, 28*time.Minute, actual.P90)
+
+		trained, err := repo.GetTrainedAt(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, trained)
+		assert.WithinDuration(t, trainedAt, *trained, time.Second)
+	})
+
+	t.Run("Без ячейки прогноз по всему виду транспорта", func(t *testing.T) {
+		actual, err := repo.FindQuantile(ctx, entities.Car, int(time.Wednesday), 15)
+		require.NoError(t, err)
+		assert.Equal(t, entities.ETAAnyTime, actual.Weekday)
+		assert.Equal(t, int64(4), actual.SampleCount)
+		assert.Equal(t, 25*time.Minute, actual.P50)
+	})
+
+	t.Run("Нет квантилей для вида транспорта", func(t *testing.T) {
+		_, err := repo.FindQuantile(ctx, entities.OnFoot, int(time.Wednesday), 12)
+		require.ErrorIs(t, err, service.ErrETAModelNotFound)
+	})
+
+	t.Run("Повторное обучение заменяет модель", func(t *testing.T) {
+		count, err := repo.Replace(ctx, time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC), 1, trainedAt.Add(24*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		_, err = repo.FindQuantile(ctx, entities.Car, int(time.Wednesday), 12)
+		require.NoError(t, err)
+
+		actual, err := repo.FindQuantile(ctx, entities.Car, int(time.Wednesday), 15)
+		require.NoError(t, err)
+		assert.Equal(t, 15, actual.Hour)
+		assert.Equal(t, 40*time.Minute, actual.P90)
+	})
+}
+
+func TestRepository_GetTrainedAt_Empty(t *testing.T) {
+	integration_test.SetupDB(t, "")
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery_eta.New(q)
+
+	t.Run("Модель еще не обучалась", func(t *testing.T) {
+		trained, err := repo.GetTrainedAt(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, trained)
+	})
+}
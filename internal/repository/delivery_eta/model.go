@@ -0,0 +1,13 @@
+package delivery_eta
+
+import "time"
+
+type ETAQuantileDB struct {
+	TransportType string
+	Weekday       int16
+	Hour          int16
+	SampleCount   int64
+	P50Seconds    float64
+	P90Seconds    float64
+	TrainedAt     time.Time
+}
@@ -0,0 +1,46 @@
+package delivery_offer
+
+import "service/internal/entities"
+
+func ToDomain(o *DeliveryOfferDB) *entities.DeliveryOffer {
+	if o == nil {
+		return nil
+	}
+
+	return &entities.DeliveryOffer{
+		ID:          o.ID,
+		OrderID:     o.OrderID,
+		CourierID:   o.CourierID,
+		Status:      entities.DeliveryOfferStatus(o.Status),
+		OfferedAt:   o.OfferedAt,
+		ExpiresAt:   o.ExpiresAt,
+		RespondedAt: o.RespondedAt,
+	}
+}
+
+func FromDomainModify(offerModify *entities.DeliveryOfferModify) *DeliveryOfferModifyDB {
+	if offerModify == nil {
+		return nil
+	}
+
+	return &DeliveryOfferModifyDB{
+		OrderID:   offerModify.OrderID,
+		CourierID: offerModify.CourierID,
+		OfferedAt: offerModify.OfferedAt,
+		ExpiresAt: offerModify.ExpiresAt,
+	}
+}
+
+func ToStatsDomain(s *CourierOfferStatsDB) *entities.CourierOfferStats {
+	if s == nil {
+		return nil
+	}
+
+	return &entities.CourierOfferStats{
+		CourierID: s.CourierID,
+		Offered:   s.Offered,
+		Accepted:  s.Accepted,
+		Declined:  s.Declined,
+		Expired:   s.Expired,
+	}
+}
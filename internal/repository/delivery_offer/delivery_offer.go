@@ -0,0 +1,240 @@
+package delivery_offer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"service/internal/entities"
+	"service/internal/repository"
+	"service/internal/service/delivery"
+)
+
+const (
+	constraintPendingOrder   = "idx_delivery_offers_pending_order"
+	constraintPendingCourier = "idx_delivery_offers_pending_courier"
+)
+
+type Repository struct {
+	querier Querier
+}
+
+func New(querier Querier) *Repository {
+	return &Repository{
+		querier: querier,
+	}
+}
+
+// Create сохраняет предложение. У заказа и у курьера может быть только одно открытое предложение
+func (r *Repository) Create(ctx context.Context, offerModify entities.DeliveryOfferModify) (*entities.DeliveryOffer, error) {
+	offerModifyDB := FromDomainModify(&offerModify)
+	query := `INSERT INTO delivery_offers (order_id, courier_id, offered_at, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, order_id, courier_id, status, offered_at, expires_at, responded_at`
+
+	var offerDB DeliveryOfferDB
+	err := r.querier.QueryRow(
+		ctx,
+		query,
+		offerModifyDB.OrderID,
+		offerModifyDB.CourierID,
+		offerModifyDB.OfferedAt,
+		offerModifyDB.ExpiresAt,
+	).Scan(
+		&offerDB.ID,
+		&offerDB.OrderID,
+		&offerDB.CourierID,
+		&offerDB.Status,
+		&offerDB.OfferedAt,
+		&offerDB.ExpiresAt,
+		&offerDB.RespondedAt,
+	)
+	if err != nil {
+		if repository.IsPgErrorWithCode(err, repository.PgErrUniqueViolation) {
+			switch repository.PgErrorConstraint(err) {
+			case constraintPendingOrder:
+				return nil, delivery.ErrOrderAlreadyOffered
+			case constraintPendingCourier:
+				return nil, delivery.ErrCourierNotAvailable
+			}
+		}
+		if repository.IsPgErrorWithCode(err, repository.PgErrForeignKeyViolation) {
+			return nil, delivery.ErrCourierNotFound
+		}
+		return nil, fmt.Errorf("unexpected delivery offer repository create error: %w", err)
+	}
+
+	return ToDomain(&offerDB), nil
+}
+
+func (r *Repository) GetByIDForUpdate(ctx context.Context, id int64) (*entities.DeliveryOffer, error) {
+	query := `SELECT id, order_id, courier_id, status, offered_at, expires_at, responded_at
+		FROM delivery_offers
+		WHERE id = $1
+		FOR UPDATE`
+
+	offer, err := r.scanOne(ctx, query, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, delivery.ErrOfferNotFound
+		}
+		return nil, fmt.Errorf("unexpected delivery offer repository get by id error: %w", err)
+	}
+
+	return offer, nil
+}
+
+func (r *Repository) GetPendingByOrderID(ctx context.Context, orderID string) (*entities.DeliveryOffer, error) {
+	query := `SELECT id, order_id, courier_id, status, offered_at, expires_at, responded_at
+		FROM delivery_offers
+		WHERE order_id = $1 AND status = 'pending'`
+
+	offer, err := r.scanOne(ctx, query, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, delivery.ErrOfferNotFound
+		}
+		return nil, fmt.Errorf("unexpected delivery offer repository get pending by order id error: %w", err)
+	}
+
+	return offer, nil
+}
+
+// GetNextExpiredForUpdate блокирует самое старое просроченное открытое предложение.
+// SKIP LOCKED позволяет нескольким инстансам разбирать просроченные предложения параллельно
+func (r *Repository) GetNextExpiredForUpdate(ctx context.Context, now time.Time) (*entities.DeliveryOffer, error) {
+	query := `SELECT id, order_id, courier_id, status, offered_at, expires_at, responded_at
+		FROM delivery_offers
+		WHERE status = 'pending' AND expires_at <= $1
+		ORDER BY expires_at ASC
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`
+
+	offer, err := r.scanOne(ctx, query, now)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, delivery.ErrNoExpiredOffers
+		}
+		return nil, fmt.Errorf("unexpected delivery offer repository get next expired error: %w", err)
+	}
+
+	return offer, nil
+}
+
+// Respond закрывает открытое предложение ответом курьера или истечением срока
+func (r *Repository) Respond(
+	ctx context.Context,
+	id int64,
+	status entities.DeliveryOfferStatus,
+	respondedAt time.Time,
+) (*entities.DeliveryOffer, error) {
+	query := `UPDATE delivery_offers
+		SET status = $2, responded_at = $3
+		WHERE id = $1 AND status = 'pending'
+		RETURNING id, order_id, courier_id, status, offered_at, expires_at, responded_at`
+
+	offer, err := r.scanOne(ctx, query, id, status.String(), respondedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, delivery.ErrOfferNotPending
+		}
+		return nil, fmt.Errorf("unexpected delivery offer repository respond error: %w", err)
+	}
+
+	return offer, nil
+}
+
+// CancelPendingByOrderID отменяет открытое предложение заказа, false - открытого предложения не было
+func (r *Repository) CancelPendingByOrderID(ctx context.Context, orderID string, cancelledAt time.Time) (bool, error) {
+	query := `UPDATE delivery_offers
+		SET status = 'cancelled', responded_at = $2
+		WHERE order_id = $1 AND status = 'pending'`
+
+	result, err := r.querier.Exec(ctx, query, orderID, cancelledAt)
+	if err != nil {
+		return false, fmt.Errorf("unexpected delivery offer repository cancel error: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// GetOfferedCourierIDs курьеры с открытым предложением заказа и курьеры, которые отказались
+// от заказа или пропустили предложение позже since
+func (r *Repository) GetOfferedCourierIDs(ctx context.Context, orderID string, since time.Time) ([]int64, error) {
+	query := `SELECT DISTINCT courier_id
+		FROM delivery_offers
+		WHERE order_id = $1 AND (status = 'pending' OR responded_at > $2)
+		ORDER BY courier_id`
+
+	rows, err := r.querier.Query(ctx, query, orderID, since)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery offer repository get offered couriers error: %w", err)
+	}
+	defer rows.Close()
+
+	courierIDs := make([]int64, 0)
+	for rows.Next() {
+		var courierID int64
+		err := rows.Scan(&courierID)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected delivery offer repository get offered couriers error: %w", err)
+		}
+		courierIDs = append(courierIDs, courierID)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery offer repository get offered couriers error: %w", err)
+	}
+
+	return courierIDs, nil
+}
+
+func (r *Repository) GetCourierStats(ctx context.Context, courierID int64) (*entities.CourierOfferStats, error) {
+	query := `SELECT c.id,
+			COUNT(o.id),
+			COUNT(o.id) FILTER (WHERE o.status = 'accepted'),
+			COUNT(o.id) FILTER (WHERE o.status = 'declined'),
+			COUNT(o.id) FILTER (WHERE o.status = 'expired')
+		FROM couriers c
+		LEFT JOIN delivery_offers o ON o.courier_id = c.id
+		WHERE c.id = $1
+		GROUP BY c.id`
+
+	var statsDB CourierOfferStatsDB
+	err := r.querier.QueryRow(ctx, query, courierID).Scan(
+		&statsDB.CourierID,
+		&statsDB.Offered,
+		&statsDB.Accepted,
+		&statsDB.Declined,
+		&statsDB.Expired,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, delivery.ErrCourierNotFound
+		}
+		return nil, fmt.Errorf("unexpected delivery offer repository get courier stats error: %w", err)
+	}
+
+	return ToStatsDomain(&statsDB), nil
+}
+
+func (r *Repository) scanOne(ctx context.Context, query string, args ...interface{}) (*entities.DeliveryOffer, error) {
+	var offerDB DeliveryOfferDB
+	err := r.querier.QueryRow(ctx, query, args...).Scan(
+		&offerDB.ID,
+		&offerDB.OrderID,
+		&offerDB.CourierID,
+		&offerDB.Status,
+		&offerDB.OfferedAt,
+		&offerDB.ExpiresAt,
+		&offerDB.RespondedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToDomain(&offerDB), nil
+}
@@ -0,0 +1,269 @@
+//go:build integration
+
+package delivery_offer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"service/internal/entities"
+	"service/internal/repository/delivery_offer"
+	"service/internal/repository/integration_test"
+	service "service/internal/service/delivery"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const offerCouriersSetup = `
+	INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+	VALUES
+		(1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+		(2, 'Courier 2', '+79991112234', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+`
+
+func TestRepository_Create_Success(t *testing.T) {
+	integration_test.SetupDB(t, offerCouriersSetup)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery_offer.New(q)
+	ctx := context.Background()
+
+	offeredAt := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Успешное создание предложения", func(t *testing.T) {
+		actual, err := repo.Create(ctx, entities.DeliveryOfferModify{
+			OrderID:   pointer.To("order-1"),
+			CourierID: pointer.To(int64(1)),
+			OfferedAt: pointer.To(offeredAt),
+			ExpiresAt: pointer.To(offeredAt.Add(time.Minute)),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, "order-1", actual.OrderID)
+		assert.Equal(t, int64(1), actual.CourierID)
+		assert.Equal(t, entities.OfferPending, actual.Status)
+		assert.WithinDuration(t, offeredAt.Add(time.Minute), actual.ExpiresAt, time.Second)
+		assert.Nil(t, actual.RespondedAt)
+	})
+
+	t.Run("У заказа может быть только одно открытое предложение", func(t *testing.T) {
+		actual, err := repo.Create(ctx, entities.DeliveryOfferModify{
+			OrderID:   pointer.To("order-1"),
+			CourierID: pointer.To(int64(2)),
+			OfferedAt: pointer.To(offeredAt),
+			ExpiresAt: pointer.To(offeredAt.Add(time.Minute)),
+		})
+		require.Error(t, err)
+		require.Nil(t, actual)
+		assert.ErrorIs(t, err, service.ErrOrderAlreadyOffered)
+	})
+
+	t.Run("У курьера может быть только одно открытое предложение", func(t *testing.T) {
+		actual, err := repo.Create(ctx, entities.DeliveryOfferModify{
+			OrderID:   pointer.To("order-2"),
+			CourierID: pointer.To(int64(1)),
+			OfferedAt: pointer.To(offeredAt),
+			ExpiresAt: pointer.To(offeredAt.Add(time.Minute)),
+		})
+		require.Error(t, err)
+		require.Nil(t, actual)
+		assert.ErrorIs(t, err, service.ErrCourierNotAvailable)
+	})
+
+	t.Run("Ошибка при предложении несуществующему курьеру", func(t *testing.T) {
+		actual, err := repo.Create(ctx, entities.DeliveryOfferModify{
+			OrderID:   pointer.To("order-3"),
+			CourierID: pointer.To(int64(999)),
+			OfferedAt: pointer.To(offeredAt),
+			ExpiresAt: pointer.To(offeredAt.Add(time.Minute)),
+		})
+		require.Error(t, err)
+		require.Nil(t, actual)
+		assert.ErrorIs(t, err, service.ErrCourierNotFound)
+	})
+}
+
+func TestRepository_Respond(t *testing.T) {
+	setupSql := offerCouriersSetup + `
+	INSERT INTO delivery_offers (id, order_id, courier_id, status, offered_at, expires_at)
+	VALUES
+		(1, 'order-1', 1, 'pending', '2025-01-15 12:00:00', '2025-01-15 12:01:00'),
+		(2, 'order-2', 2, 'declined', '2025-01-15 12:00:00', '2025-01-15 12:01:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery_offer.New(q)
+	ctx := context.Background()
+
+	respondedAt := time.Date(2025, 1, 15, 12, 0, 30, 0, time.UTC)
+
+	t.Run("Открытое предложение принимается", func(t *testing.T) {
+		actual, err := repo.Respond(ctx, 1, entities.OfferAccepted, respondedAt)
+		require.NoError(t, err)
+		assert.Equal(t, entities.OfferAccepted, actual.Status)
+		require.NotNil(t, actual.RespondedAt)
+		assert.WithinDuration(t, respondedAt, *actual.RespondedAt, time.Second)
+	})
+
+	t.Run("Закрытое предложение не меняется", func(t *testing.T) {
+		actual, err := repo.Respond(ctx, 2, entities.OfferAccepted, respondedAt)
+		require.Error(t, err)
+		require.Nil(t, actual)
+		assert.ErrorIs(t, err, service.ErrOfferNotPending)
+	})
+
+	t.Run("Предложение не найдено", func(t *testing.T) {
+		actual, err := repo.GetByIDForUpdate(ctx, 999)
+		require.Error(t, err)
+		require.Nil(t, actual)
+		assert.ErrorIs(t, err, service.ErrOfferNotFound)
+	})
+}
+
+func TestRepository_GetNextExpiredForUpdate(t *testing.T) {
+	setupSql := offerCouriersSetup + `
+	INSERT INTO delivery_offers (id, order_id, courier_id, status, offered_at, expires_at)
+	VALUES
+		(1, 'order-1', 1, 'pending', '2025-01-15 12:00:00', '2025-01-15 12:01:00'),
+		(2, 'order-2', 2, 'pending', '2025-01-15 12:00:00', '2025-01-15 12:10:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery_offer.New(q)
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 15, 12, 5, 0, 0, time.UTC)
+
+	t.Run("Выдается только просроченное предложение", func(t *testing.T) {
+		actual, err := repo.GetNextExpiredForUpdate(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), actual.ID)
+
+		_, err = repo.Respond(ctx, actual.ID, entities.OfferExpired, now)
+		require.NoError(t, err)
+
+		actual, err = repo.GetNextExpiredForUpdate(ctx, now)
+		require.Error(t, err)
+		require.Nil(t, actual)
+		assert.ErrorIs(t, err, service.ErrNoExpiredOffers)
+	})
+}
+
+func TestRepository_CancelPendingByOrderID(t *testing.T) {
+	setupSql := offerCouriersSetup + `
+	INSERT INTO delivery_offers (id, order_id, courier_id, status, offered_at, expires_at)
+	VALUES (1, 'order-1', 1, 'pending', '2025-01-15 12:00:00', '2025-01-15 12:01:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery_offer.New(q)
+	ctx := context.Background()
+
+	t.Run("Открытое предложение отменяется", func(t *testing.T) {
+		cancelled, err := repo.CancelPendingByOrderID(ctx, "order-1", time.Now().UTC())
+		require.NoError(t, err)
+		assert.True(t, cancelled)
+
+		_, err = repo.GetPendingByOrderID(ctx, "order-1")
+		assert.ErrorIs(t, err, service.ErrOfferNotFound)
+	})
+
+	t.Run("Открытого предложения нет", func(t *testing.T) {
+		cancelled, err := repo.CancelPendingByOrderID(ctx, "order-1", time.Now().UTC())
+		require.NoError(t, err)
+		assert.False(t, cancelled)
+	})
+}
+
+func TestRepository_GetCourierStats(t *testing.T) {
+	setupSql := offerCouriersSetup + `
+	INSERT INTO delivery_offers (order_id, courier_id, status, offered_at, expires_at)
+	VALUES
+		('order-1', 1, 'accepted', '2025-01-15 12:00:00', '2025-01-15 12:01:00'),
+		('order-2', 1, 'declined', '2025-01-15 12:00:00', '2025-01-15 12:01:00'),
+		('order-3', 1, 'expired', '2025-01-15 12:00:00', '2025-01-15 12:01:00'),
+		('order-4', 1, 'accepted', '2025-01-15 12:00:00', '2025-01-15 12:01:00'),
+		('order-2', 2, 'accepted', '2025-01-15 12:00:00', '2025-01-15 12:01:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery_offer.New(q)
+	ctx := context.Background()
+
+	t.Run("Статистика ответов курьера", func(t *testing.T) {
+		actual, err := repo.GetCourierStats(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, &entities.CourierOfferStats{
+			CourierID: 1,
+			Offered:   4,
+			Accepted:  2,
+			Declined:  1,
+			Expired:   1,
+		}, actual)
+		assert.InDelta(t, 0.5, actual.AcceptanceRate(), 0.0001)
+	})
+
+	t.Run("Курьер не найден", func(t *testing.T) {
+		actual, err := repo.GetCourierStats(ctx, 999)
+		require.Error(t, err)
+		require.Nil(t, actual)
+		assert.ErrorIs(t, err, service.ErrCourierNotFound)
+	})
+}
+
+func TestRepository_GetOfferedCourierIDs(t *testing.T) {
+	setupSql := offerCouriersSetup + `
+	INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+	VALUES
+		(3, 'Courier 3', '+79991112235', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+	INSERT INTO delivery_offers (order_id, courier_id, status, offered_at, expires_at, responded_at)
+	VALUES
+		('order-1', 1, 'declined', '2025-01-15 12:00:00', '2025-01-15 12:01:00', '2025-01-15 12:00:30'),
+		('order-1', 2, 'expired', '2025-01-15 12:10:00', '2025-01-15 12:11:00', '2025-01-15 12:11:00'),
+		('order-1', 3, 'pending', '2025-01-15 12:20:00', '2025-01-15 12:21:00', NULL),
+		('order-2', 1, 'declined', '2025-01-15 12:20:00', '2025-01-15 12:21:00', '2025-01-15 12:20:30');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery_offer.New(q)
+	ctx := context.Background()
+
+	t.Run("Курьеру, которому заказ предлагали, заказ больше не предлагается", func(t *testing.T) {
+		courierIDs, err := repo.GetOfferedCourierIDs(ctx, "order-1", time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2, 3}, courierIDs)
+	})
+
+	t.Run("Отказ раньше since не исключает курьера, открытое предложение исключает всегда", func(t *testing.T) {
+		courierIDs, err := repo.GetOfferedCourierIDs(ctx, "order-1", time.Date(2025, 1, 15, 12, 5, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2, 3}, courierIDs)
+	})
+
+	t.Run("Заказ никому не предлагали", func(t *testing.T) {
+		courierIDs, err := repo.GetOfferedCourierIDs(ctx, "order-3", time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Empty(t, courierIDs)
+	})
+}
@@ -0,0 +1,28 @@
+package delivery_offer
+
+import "time"
+
+type DeliveryOfferDB struct {
+	ID          int64
+	OrderID     string
+	CourierID   int64
+	Status      string
+	OfferedAt   time.Time
+	ExpiresAt   time.Time
+	RespondedAt *time.Time
+}
+
+type DeliveryOfferModifyDB struct {
+	OrderID   *string
+	CourierID *int64
+	OfferedAt *time.Time
+	ExpiresAt *time.Time
+}
+
+type CourierOfferStatsDB struct {
+	CourierID int64
+	Offered   int64
+	Accepted  int64
+	Declined  int64
+	Expired   int64
+}
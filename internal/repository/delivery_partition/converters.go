@@ -0,0 +1,81 @@
+package delivery_partition
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"service/internal/entities"
+	"service/internal/repository"
+)
+
+// partitionNamePattern delivery_y2026m01 - партиция за январь 2026
+var partitionNamePattern = regexp.MustCompile(`^delivery_y(\d{4})m(\d{2})$`)
+
+func PartitionName(month time.Time) string {
+	return fmt.Sprintf("delivery_y%04dm%02d", month.Year(), int(month.Month()))
+}
+
+func ToPartitionDomain(month time.Time) entities.DeliveryPartition {
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	return entities.DeliveryPartition{
+		Name: PartitionName(from),
+		From: from,
+		To:   from.AddDate(0, 1, 0),
+	}
+}
+
+// ToPartitionDomainFromName false для таблиц, названных не по схеме месячных партиций
+func ToPartitionDomainFromName(name string) (entities.DeliveryPartition, bool) {
+	match := partitionNamePattern.FindStringSubmatch(name)
+	if match == nil {
+		return entities.DeliveryPartition{}, false
+	}
+
+	month, err := time.Parse("200601", match[1]+match[2])
+	if err != nil {
+		return entities.DeliveryPartition{}, false
+	}
+
+	return ToPartitionDomain(month), true
+}
+
+func ToDomain(d *DeliveryDB) *entities.Delivery {
+	if d == nil {
+		return nil
+	}
+
+	deliveryEntity := &entities.Delivery{
+		ID:                d.ID,
+		CourierID:         d.CourierID,
+		OrderID:           d.OrderID,
+		Address:           repository.AddressToDomain(d.Address),
+		EstimatedDelivery: d.EstimatedDelivery,
+		CreatedAt:         d.CreatedAt,
+		AssignedAt:        d.AssignedAt,
+		Deadline:          d.Deadline,
+		OverdueAt:         d.OverdueAt,
+		CourierReleasedAt: d.CourierReleasedAt,
+		CompletedAt:       d.CompletedAt,
+		Priority:          entities.OrderPriority(d.Priority),
+		CashAmount:        d.CashAmount,
+	}
+	if d.RestaurantID != nil {
+		deliveryEntity.RestaurantID = *d.RestaurantID
+	}
+	if d.PickupLat != nil && d.PickupLon != nil && d.DropoffLat != nil && d.DropoffLon != nil {
+		deliveryEntity.Route = &entities.Route{
+			Pickup:  entities.Location{Latitude: *d.PickupLat, Longitude: *d.PickupLon},
+			Dropoff: entities.Location{Latitude: *d.DropoffLat, Longitude: *d.DropoffLon},
+		}
+	}
+	for _, skill := range d.RequiredSkills {
+		deliveryEntity.Requirements.Skills = append(deliveryEntity.Requirements.Skills, entities.CourierSkill(skill))
+	}
+	for _, transportType := range d.TransportTypes {
+		deliveryEntity.Requirements.TransportTypes = append(deliveryEntity.Requirements.TransportTypes, entities.CourierTransportType(transportType))
+	}
+
+	return deliveryEntity
+}
@@ -0,0 +1,263 @@
+package delivery_partition
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"service/internal/entities"
+)
+
+const partitionTimeLayout = "2006-01-02 15:04:05"
+
+type Repository struct {
+	querier Querier
+}
+
+func New(querier Querier) *Repository {
+	return &Repository{
+		querier: querier,
+	}
+}
+
+// LockMaintenance сериализует обслуживание партиций между инстансами до конца транзакции
+func (r *Repository) LockMaintenance(ctx context.Context) error {
+	query := `
+		SELECT pg_advisory_xact_lock(hashtext('delivery_partitions'))
+	`
+
+	_, err := r.querier.Exec(ctx, query)
+	if err != nil {
+		return fmt.Errorf("unexpected delivery partition repository lock error: %w", err)
+	}
+
+	return nil
+}
+
+// ListPartitions месячные партиции delivery по возрастанию месяца, партиция по умолчанию не входит
+func (r *Repository) ListPartitions(ctx context.Context) ([]entities.DeliveryPartition, error) {
+	query := `
+		SELECT child.relname
+		FROM pg_inherits
+		JOIN pg_class parent ON parent.oid = pg_inherits.inhparent
+		JOIN pg_class child ON child.oid = pg_inherits.inhrelid
+		WHERE parent.relname = 'delivery' AND pg_table_is_visible(parent.oid)
+		ORDER BY child.relname
+	`
+
+	rows, err := r.querier.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery partition repository list error: %w", err)
+	}
+	defer rows.Close()
+
+	partitions := make([]entities.DeliveryPartition, 0, 16)
+	for rows.Next() {
+		var name string
+		err := rows.Scan(&name)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected delivery partition repository list error: %w", err)
+		}
+
+		partition, ok := ToPartitionDomainFromName(name)
+		if !ok {
+			continue
+		}
+		partitions = append(partitions, partition)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery partition repository list error: %w", err)
+	}
+
+	return partitions, nil
+}
+
+// CreatePartition создает партицию за месяц, если ее еще нет. Доставки этого месяца, успевшие
+// попасть в delivery_default, переносятся в новую партицию: иначе ATTACH PARTITION не пройдет проверку.
+// Вызывать внутри транзакции
+func (r *Repository) CreatePartition(ctx context.Context, month time.Time) (bool, error) {
+	partition := ToPartitionDomain(month)
+
+	exists, err := r.partitionExists(ctx, partition.Name)
+	if err != nil {
+		return false, fmt.Errorf("unexpected delivery partition repository create error: %w", err)
+	}
+	if exists {
+		return false, nil
+	}
+
+	table := pgx.Identifier{partition.Name}.Sanitize()
+
+	_, err = r.querier.Exec(ctx, fmt.Sprintf(`
+		CREATE TABLE %s (LIKE delivery INCLUDING DEFAULTS INCLUDING CONSTRAINTS)
+	`, table))
+	if err != nil {
+		return false, fmt.Errorf("unexpected delivery partition repository create error: %w", err)
+	}
+
+	// удаление из delivery_default срабатывает триггером и на delivery_order_ids, поэтому ключи заказов возвращаются следом
+	_, err = r.querier.Exec(ctx, fmt.Sprintf(`
+		WITH moved AS (
+			DELETE FROM delivery_default
+			WHERE created_at >= $1 AND created_at < $2
+			RETURNING *
+		)
+		INSERT INTO %s SELECT * FROM moved
+	`, table), partition.From, partition.To)
+	if err != nil {
+		return false, fmt.Errorf("unexpected delivery partition repository move default rows error: %w", err)
+	}
+
+	_, err = r.querier.Exec(ctx, fmt.Sprintf(`
+		INSERT INTO delivery_order_ids (order_id, created_at)
+		SELECT order_id, created_at FROM %s
+		ON CONFLICT (order_id) DO NOTHING
+	`, table))
+	if err != nil {
+		return false, fmt.Errorf("unexpected delivery partition repository restore order ids error: %w", err)
+	}
+
+	// границы партиции в DDL нельзя передать параметрами, поэтому они форматируются из time.Time
+	_, err = r.querier.Exec(ctx, fmt.Sprintf(`
+		ALTER TABLE delivery ATTACH PARTITION %s FOR VALUES FROM ('%s') TO ('%s')
+	`, table, partition.From.Format(partitionTimeLayout), partition.To.Format(partitionTimeLayout)))
+	if err != nil {
+		return false, fmt.Errorf("unexpected delivery partition repository attach error: %w", err)
+	}
+
+	return true, nil
+}
+
+// ArchivePartition переносит строки партиции в delivery_archive и удаляет партицию.
+// Вызывать внутри транзакции, для уже удаленной партиции возвращает 0
+func (r *Repository) ArchivePartition(ctx context.Context, partition entities.DeliveryPartition) (int64, error) {
+	exists, err := r.partitionExists(ctx, partition.Name)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected delivery partition repository archive error: %w", err)
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	result, err := r.querier.Exec(ctx, fmt.Sprintf(`
+		INSERT INTO delivery_archive (
+			id, courier_id, order_id, created_at, assigned_at, deadline,
+			restaurant_id, address, estimated_delivery, overdue_at, courier_released_at,
+			priority, required_skills, allowed_transport_types, completed_at,
+			pickup_lat, pickup_lon, dropoff_lat, dropoff_lon, cash_amount
+		)
+		SELECT
+			id, courier_id, order_id, created_at, assigned_at, deadline,
+			restaurant_id, address, estimated_delivery, overdue_at, courier_released_at,
+			priority, required_skills, allowed_transport_types, completed_at,
+			pickup_lat, pickup_lon, dropoff_lat, dropoff_lon, cash_amount
+		FROM %s
+	`, pgx.Identifier{partition.Name}.Sanitize()))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected delivery partition repository archive error: %w", err)
+	}
+
+	err = r.DropPartition(ctx, partition)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// StreamPartition отдает строки партиции по одной, не загружая партицию в память целиком
+func (r *Repository) StreamPartition(ctx context.Context, partition entities.DeliveryPartition, fn func(delivery entities.Delivery) error) (int64, error) {
+	rows, err := r.querier.Query(ctx, fmt.Sprintf(`
+		SELECT id, courier_id, order_id, restaurant_id, address, estimated_delivery, created_at, assigned_at, deadline, overdue_at, courier_released_at,
+			priority, required_skills, allowed_transport_types, completed_at, pickup_lat, pickup_lon, dropoff_lat, dropoff_lon, cash_amount
+		FROM %s
+		ORDER BY id
+	`, pgx.Identifier{partition.Name}.Sanitize()))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected delivery partition repository stream error: %w", err)
+	}
+	defer rows.Close()
+
+	var count int64
+	for rows.Next() {
+		var deliveryDB DeliveryDB
+		err := rows.Scan(
+			&deliveryDB.ID,
+			&deliveryDB.CourierID,
+			&deliveryDB.OrderID,
+			&deliveryDB.RestaurantID,
+			&deliveryDB.Address,
+			&deliveryDB.EstimatedDelivery,
+			&deliveryDB.CreatedAt,
+			&deliveryDB.AssignedAt,
+			&deliveryDB.Deadline,
+			&deliveryDB.OverdueAt,
+			&deliveryDB.CourierReleasedAt,
+			&deliveryDB.Priority,
+			&deliveryDB.RequiredSkills,
+			&deliveryDB.TransportTypes,
+			&deliveryDB.CompletedAt,
+			&deliveryDB.PickupLat,
+			&deliveryDB.PickupLon,
+			&deliveryDB.DropoffLat,
+			&deliveryDB.DropoffLon,
+			&deliveryDB.CashAmount,
+		)
+		if err != nil {
+			return count, fmt.Errorf("unexpected delivery partition repository stream error: %w", err)
+		}
+
+		err = fn(*ToDomain(&deliveryDB))
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return count, fmt.Errorf("unexpected delivery partition repository stream error: %w", err)
+	}
+
+	return count, nil
+}
+
+// DropPartition удаляет партицию вместе с ключами ее заказов в delivery_order_ids:
+// DROP TABLE не вызывает триггер удаления строк
+func (r *Repository) DropPartition(ctx context.Context, partition entities.DeliveryPartition) error {
+	query := `
+		DELETE FROM delivery_order_ids
+		WHERE created_at >= $1 AND created_at < $2
+	`
+
+	_, err := r.querier.Exec(ctx, query, partition.From, partition.To)
+	if err != nil {
+		return fmt.Errorf("unexpected delivery partition repository drop order ids error: %w", err)
+	}
+
+	_, err = r.querier.Exec(ctx, fmt.Sprintf(`
+		DROP TABLE IF EXISTS %s
+	`, pgx.Identifier{partition.Name}.Sanitize()))
+	if err != nil {
+		return fmt.Errorf("unexpected delivery partition repository drop error: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) partitionExists(ctx context.Context, name string) (bool, error) {
+	query := `
+		SELECT to_regclass($1) IS NOT NULL
+	`
+
+	var exists bool
+	err := r.querier.QueryRow(ctx, query, name).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
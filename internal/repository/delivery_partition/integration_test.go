@@ -0,0 +1,175 @@
+//go:build integration
+
+package delivery_partition_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"service/internal/entities"
+	"service/internal/repository/delivery_partition"
+	"service/internal/repository/integration_test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// доставки за январь 2024 вне заранее созданных партиций и попадают в delivery_default
+const setupOldDeliveriesSql = `
+	INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+	VALUES
+		(1, 'Test Courier', '+79991112233', 'available', 'on_foot', '2024-01-01 10:00:00', '2024-01-01 10:00:00');
+
+	INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline)
+	VALUES
+		(1, 'order-1', '2024-01-10 11:00:00', '2024-01-10 11:00:00', '2024-01-10 11:30:00');
+
+	INSERT INTO delivery (
+		courier_id, order_id, created_at, assigned_at, deadline, priority, required_skills, allowed_transport_types,
+		completed_at, pickup_lat, pickup_lon, dropoff_lat, dropoff_lon, cash_amount
+	)
+	VALUES
+		(1, 'order-2', '2024-01-20 11:00:00', '2024-01-20 11:00:00', '2024-01-20 11:30:00', 'high', '{thermal_bag}', '{on_foot}',
+			'2024-01-20 11:25:00', 55.75, 37.61, 55.76, 37.62, 150000);
+`
+
+func countRows(t *testing.T, query string, args ...any) int {
+	var count int
+	err := integration_test.GetQuerier().QueryRow(context.Background(), query, args...).Scan(&count)
+	require.NoError(t, err)
+
+	return count
+}
+
+func TestRepository_CreatePartition(t *testing.T) {
+	integration_test.SetupDB(t, setupOldDeliveriesSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery_partition.New(q)
+	ctx := context.Background()
+	month := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	partition := entities.DeliveryPartition{
+		Name: "delivery_y2024m01",
+		From: month,
+		To:   month.AddDate(0, 1, 0),
+	}
+	defer func() {
+		require.NoError(t, repo.DropPartition(ctx, partition))
+	}()
+
+	t.Run("Партиция создается, строки из delivery_default переносятся в нее", func(t *testing.T) {
+		created, err := repo.CreatePartition(ctx, month)
+		require.NoError(t, err)
+		assert.True(t, created)
+
+		assert.Equal(t, 0, countRows(t, `SELECT COUNT(*) FROM delivery_default`))
+		assert.Equal(t, 2, countRows(t, `SELECT COUNT(*) FROM delivery_y2024m01`))
+		assert.Equal(t, 2, countRows(t, `SELECT COUNT(*) FROM delivery_order_ids`))
+	})
+
+	t.Run("Повторное создание ничего не делает", func(t *testing.T) {
+		created, err := repo.CreatePartition(ctx, month)
+		require.NoError(t, err)
+		assert.False(t, created)
+	})
+
+	t.Run("Партиция попадает в список", func(t *testing.T) {
+		partitions, err := repo.ListPartitions(ctx)
+		require.NoError(t, err)
+
+		assert.Contains(t, partitions, partition)
+	})
+}
+
+func TestRepository_ArchivePartition(t *testing.T) {
+	integration_test.SetupDB(t, setupOldDeliveriesSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery_partition.New(q)
+	ctx := context.Background()
+	month := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	partition := entities.DeliveryPartition{
+		Name: "delivery_y2024m01",
+		From: month,
+		To:   month.AddDate(0, 1, 0),
+	}
+
+	_, err := repo.CreatePartition(ctx, month)
+	require.NoError(t, err)
+
+	t.Run("Строки переносятся в delivery_archive, партиция удаляется", func(t *testing.T) {
+		rows, err := repo.ArchivePartition(ctx, partition)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), rows)
+
+		assert.Equal(t, 2, countRows(t, `SELECT COUNT(*) FROM delivery_archive`))
+		assert.Equal(t, 1, countRows(t, `
+			SELECT COUNT(*) FROM delivery_archive
+			WHERE order_id = 'order-2' AND priority = 'high' AND required_skills = '{thermal_bag}'
+				AND allowed_transport_types = '{on_foot}' AND completed_at = '2024-01-20 11:25:00'
+				AND pickup_lat = 55.75 AND dropoff_lon = 37.62 AND cash_amount = 150000
+		`))
+		assert.Equal(t, 0, countRows(t, `SELECT COUNT(*) FROM delivery`))
+		assert.Equal(t, 0, countRows(t, `SELECT COUNT(*) FROM delivery_order_ids`))
+		assert.Equal(t, 0, countRows(t, `SELECT COUNT(*) FROM pg_class WHERE relname = 'delivery_y2024m01'`))
+	})
+
+	t.Run("Повторный перенос уже удаленной партиции", func(t *testing.T) {
+		rows, err := repo.ArchivePartition(ctx, partition)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), rows)
+	})
+}
+
+func TestRepository_StreamPartition(t *testing.T) {
+	integration_test.SetupDB(t, setupOldDeliveriesSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery_partition.New(q)
+	ctx := context.Background()
+	month := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	partition := entities.DeliveryPartition{
+		Name: "delivery_y2024m01",
+		From: month,
+		To:   month.AddDate(0, 1, 0),
+	}
+
+	_, err := repo.CreatePartition(ctx, month)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, repo.DropPartition(ctx, partition))
+	}()
+
+	t.Run("Строки партиции отдаются по порядку id", func(t *testing.T) {
+		var deliveries []entities.Delivery
+		rows, err := repo.StreamPartition(ctx, partition, func(delivery entities.Delivery) error {
+			deliveries = append(deliveries, delivery)
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(2), rows)
+		require.Len(t, deliveries, 2)
+		assert.Equal(t, "order-1", deliveries[0].OrderID)
+		assert.Equal(t, entities.PriorityNormal, deliveries[0].Priority)
+		assert.Nil(t, deliveries[0].Route)
+		assert.Nil(t, deliveries[0].CompletedAt)
+
+		completed := deliveries[1]
+		assert.Equal(t, "order-2", completed.OrderID)
+		assert.Equal(t, entities.PriorityHigh, completed.Priority)
+		assert.Equal(t, []entities.CourierSkill{entities.SkillThermalBag}, completed.Requirements.Skills)
+		assert.Equal(t, []entities.CourierTransportType{entities.OnFoot}, completed.Requirements.TransportTypes)
+		require.NotNil(t, completed.CompletedAt)
+		assert.Equal(t, time.Date(2024, 1, 20, 11, 25, 0, 0, time.UTC), completed.CompletedAt.UTC())
+		assert.Equal(t, &entities.Route{
+			Pickup:  entities.Location{Latitude: 55.75, Longitude: 37.61},
+			Dropoff: entities.Location{Latitude: 55.76, Longitude: 37.62},
+		}, completed.Route)
+		assert.Equal(t, int64(150000), completed.CashAmount)
+	})
+}
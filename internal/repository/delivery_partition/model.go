@@ -0,0 +1,30 @@
+package delivery_partition
+
+import (
+	"time"
+
+	"service/internal/repository"
+)
+
+type DeliveryDB struct {
+	ID                int64
+	CourierID         int64
+	OrderID           string
+	RestaurantID      *string
+	Address           *repository.AddressDB
+	EstimatedDelivery *time.Time
+	CreatedAt         time.Time
+	AssignedAt        time.Time
+	Deadline          time.Time
+	OverdueAt         *time.Time
+	CourierReleasedAt *time.Time
+	Priority          string
+	RequiredSkills    []string
+	TransportTypes    []string
+	CompletedAt       *time.Time
+	PickupLat         *float64
+	PickupLon         *float64
+	DropoffLat        *float64
+	DropoffLon        *float64
+	CashAmount        int64
+}
@@ -0,0 +1,32 @@
+package delivery_rating
+
+import "service/internal/entities"
+
+func ToDomain(r *DeliveryRatingDB) *entities.DeliveryRating {
+	if r == nil {
+		return nil
+	}
+
+	return &entities.DeliveryRating{
+		ID:        r.ID,
+		OrderID:   r.OrderID,
+		CourierID: r.CourierID,
+		Author:    entities.RatingAuthor(r.Author),
+		Score:     int(r.Score),
+		Tags:      r.Tags,
+		Comment:   r.Comment,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func ToCourierRatingDomain(r *CourierRatingDB) *entities.CourierRating {
+	if r == nil {
+		return nil
+	}
+
+	return &entities.CourierRating{
+		CourierID: r.CourierID,
+		Average:   r.Average,
+		Count:     r.Count,
+	}
+}
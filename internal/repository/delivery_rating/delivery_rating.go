@@ -0,0 +1,106 @@
+package delivery_rating
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"service/internal/entities"
+	"service/internal/repository"
+	"service/internal/service/delivery_rating"
+)
+
+type Repository struct {
+	querier Querier
+}
+
+func New(querier Querier) *Repository {
+	return &Repository{
+		querier: querier,
+	}
+}
+
+// GetRatedDelivery курьер и время выполнения последней доставки заказа
+func (r *Repository) GetRatedDelivery(ctx context.Context, orderID string) (*entities.RatedDelivery, error) {
+	query := `
+		SELECT courier_id, completed_at
+		FROM delivery
+		WHERE order_id = $1
+		ORDER BY assigned_at DESC
+		LIMIT 1
+	`
+
+	var ratedDelivery entities.RatedDelivery
+	err := r.querier.QueryRow(ctx, query, orderID).Scan(&ratedDelivery.CourierID, &ratedDelivery.CompletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, delivery_rating.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("unexpected delivery rating repository get delivery error: %w", err)
+	}
+
+	return &ratedDelivery, nil
+}
+
+// Create сохраняет оценку, автор оценивает доставку только один раз
+func (r *Repository) Create(ctx context.Context, rating entities.DeliveryRating) (*entities.DeliveryRating, error) {
+	query := `
+		INSERT INTO delivery_ratings (order_id, courier_id, author, score, tags, comment, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, order_id, courier_id, author, score, tags, comment, created_at
+	`
+
+	tags := rating.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	var ratingDB DeliveryRatingDB
+	err := r.querier.QueryRow(
+		ctx,
+		query,
+		rating.OrderID,
+		rating.CourierID,
+		rating.Author.String(),
+		rating.Score,
+		tags,
+		rating.Comment,
+		rating.CreatedAt,
+	).Scan(
+		&ratingDB.ID,
+		&ratingDB.OrderID,
+		&ratingDB.CourierID,
+		&ratingDB.Author,
+		&ratingDB.Score,
+		&ratingDB.Tags,
+		&ratingDB.Comment,
+		&ratingDB.CreatedAt,
+	)
+	if err != nil {
+		if repository.IsPgErrorWithCode(err, repository.PgErrUniqueViolation) {
+			return nil, delivery_rating.ErrAlreadyRated
+		}
+		return nil, fmt.Errorf("unexpected delivery rating repository create error: %w", err)
+	}
+
+	return ToDomain(&ratingDB), nil
+}
+
+// GetCourierRating средняя оценка курьера и число оценок с момента since
+func (r *Repository) GetCourierRating(ctx context.Context, courierID int64, since time.Time) (*entities.CourierRating, error) {
+	query := `
+		SELECT COALESCE(AVG(score), 0)::DOUBLE PRECISION, COUNT(*)
+		FROM delivery_ratings
+		WHERE courier_id = $1 AND created_at >= $2
+	`
+
+	ratingDB := CourierRatingDB{CourierID: courierID}
+	err := r.querier.QueryRow(ctx, query, courierID, since).Scan(&ratingDB.Average, &ratingDB.Count)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery rating repository get courier rating error: %w", err)
+	}
+
+	return ToCourierRatingDomain(&ratingDB), nil
+}
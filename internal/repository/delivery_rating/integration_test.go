@@ -0,0 +1,142 @@
+//go:build integration
+
+package delivery_rating_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"service/internal/entities"
+	"service/internal/repository/delivery_rating"
+	"service/internal/repository/integration_test"
+	service "service/internal/service/delivery_rating"
+)
+
+const ratingSetupSql = `
+	INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+	VALUES (1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+	INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline, completed_at)
+	VALUES
+		(1, 'order-1', '2025-01-15 12:00:00', '2025-01-15 12:00:00', '2025-01-15 13:00:00', '2025-01-15 12:30:00'),
+		(1, 'order-2', '2025-01-15 12:10:00', '2025-01-15 12:10:00', '2025-01-15 13:00:00', NULL);
+`
+
+func TestRepository_GetRatedDelivery(t *testing.T) {
+	integration_test.SetupDB(t, ratingSetupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery_rating.New(q)
+	ctx := context.Background()
+
+	t.Run("Выполненная доставка", func(t *testing.T) {
+		actual, err := repo.GetRatedDelivery(ctx, "order-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), actual.CourierID)
+		require.NotNil(t, actual.CompletedAt)
+		assert.Equal(t, time.Date(2025, 1, 15, 12, 30, 0, 0, time.UTC), actual.CompletedAt.UTC())
+	})
+
+	t.Run("Доставка еще не выполнена", func(t *testing.T) {
+		actual, err := repo.GetRatedDelivery(ctx, "order-2")
+		require.NoError(t, err)
+		assert.Nil(t, actual.CompletedAt)
+	})
+
+	t.Run("Доставка не найдена", func(t *testing.T) {
+		_, err := repo.GetRatedDelivery(ctx, "order-404")
+		require.ErrorIs(t, err, service.ErrDeliveryNotFound)
+	})
+}
+
+func TestRepository_Create(t *testing.T) {
+	integration_test.SetupDB(t, ratingSetupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery_rating.New(q)
+	ctx := context.Background()
+
+	createdAt := time.Date(2025, 1, 15, 13, 0, 0, 0, time.UTC)
+
+	t.Run("Оценка клиента с тегами и комментарием", func(t *testing.T) {
+		actual, err := repo.Create(ctx, entities.DeliveryRating{
+			OrderID:   "order-1",
+			CourierID: 1,
+			Author:    entities.RatingAuthorCustomer,
+			Score:     5,
+			Tags:      []string{"polite", "fast"},
+			Comment:   pointer.To("Спасибо!"),
+			CreatedAt: createdAt,
+		})
+		require.NoError(t, err)
+		assert.NotZero(t, actual.ID)
+		assert.Equal(t, entities.RatingAuthorCustomer, actual.Author)
+		assert.Equal(t, 5, actual.Score)
+		assert.Equal(t, []string{"polite", "fast"}, actual.Tags)
+		assert.Equal(t, pointer.To("Спасибо!"), actual.Comment)
+		assert.Equal(t, createdAt, actual.CreatedAt.UTC())
+	})
+
+	t.Run("Ресторан оценивает ту же доставку без тегов", func(t *testing.T) {
+		actual, err := repo.Create(ctx, entities.DeliveryRating{
+			OrderID:   "order-1",
+			CourierID: 1,
+			Author:    entities.RatingAuthorRestaurant,
+			Score:     3,
+			CreatedAt: createdAt,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, actual.Tags)
+		assert.Nil(t, actual.Comment)
+	})
+
+	t.Run("Повторная оценка от того же автора", func(t *testing.T) {
+		_, err := repo.Create(ctx, entities.DeliveryRating{
+			OrderID:   "order-1",
+			CourierID: 1,
+			Author:    entities.RatingAuthorCustomer,
+			Score:     1,
+			CreatedAt: createdAt,
+		})
+		require.ErrorIs(t, err, service.ErrAlreadyRated)
+	})
+}
+
+func TestRepository_GetCourierRating(t *testing.T) {
+	setupSql := `
+		INSERT INTO delivery_ratings (order_id, courier_id, author, score, created_at)
+		VALUES
+			('order-1', 1, 'customer', 5, '2025-01-20 12:00:00'),
+			('order-1', 1, 'restaurant', 4, '2025-01-20 12:00:00'),
+			('order-2', 1, 'customer', 3, '2025-01-25 12:00:00'),
+			('order-3', 1, 'customer', 1, '2024-11-01 12:00:00'),
+			('order-4', 2, 'customer', 1, '2025-01-25 12:00:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery_rating.New(q)
+	ctx := context.Background()
+
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Средняя оценка за окно", func(t *testing.T) {
+		actual, err := repo.GetCourierRating(ctx, 1, since)
+		require.NoError(t, err)
+		assert.Equal(t, &entities.CourierRating{CourierID: 1, Average: 4, Count: 3}, actual)
+	})
+
+	t.Run("Курьер без оценок", func(t *testing.T) {
+		actual, err := repo.GetCourierRating(ctx, 3, since)
+		require.NoError(t, err)
+		assert.Equal(t, &entities.CourierRating{CourierID: 3}, actual)
+	})
+}
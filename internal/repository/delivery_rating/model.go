@@ -0,0 +1,20 @@
+package delivery_rating
+
+import "time"
+
+type DeliveryRatingDB struct {
+	ID        int64
+	OrderID   string
+	CourierID int64
+	Author    string
+	Score     int16
+	Tags      []string
+	Comment   *string
+	CreatedAt time.Time
+}
+
+type CourierRatingDB struct {
+	CourierID int64
+	Average   float64
+	Count     int64
+}
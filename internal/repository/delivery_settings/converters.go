@@ -0,0 +1,83 @@
+package delivery_settings
+
+import (
+	"time"
+
+	"service/internal/entities"
+)
+
+func ToDomainTransportSpeed(s *TransportSpeedDB) *entities.TransportSpeed {
+	if s == nil {
+		return nil
+	}
+
+	return &entities.TransportSpeed{
+		TransportType:    entities.CourierTransportType(s.TransportType),
+		AverageSpeedKmh:  s.AverageSpeedKmh,
+		HandlingOverhead: time.Duration(s.HandlingOverheadSeconds) * time.Second,
+		UpdatedAt:        s.UpdatedAt,
+	}
+}
+
+func FromDomainTransportSpeed(s *entities.TransportSpeed) *TransportSpeedDB {
+	if s == nil {
+		return nil
+	}
+
+	return &TransportSpeedDB{
+		TransportType:           s.TransportType.String(),
+		AverageSpeedKmh:         s.AverageSpeedKmh,
+		HandlingOverheadSeconds: int32(s.HandlingOverhead / time.Second),
+		UpdatedAt:               s.UpdatedAt,
+	}
+}
+
+func ToDomainPeakHour(p *PeakHourDB) *entities.PeakHour {
+	if p == nil {
+		return nil
+	}
+
+	return &entities.PeakHour{
+		StartHour:  int(p.StartHour),
+		EndHour:    int(p.EndHour),
+		Multiplier: p.Multiplier,
+	}
+}
+
+func FromDomainPeakHour(p *entities.PeakHour) *PeakHourDB {
+	if p == nil {
+		return nil
+	}
+
+	return &PeakHourDB{
+		StartHour:  int16(p.StartHour),
+		EndHour:    int16(p.EndHour),
+		Multiplier: p.Multiplier,
+	}
+}
+
+func ToDomainTransportSpeedList(speedsDB []TransportSpeedDB) []entities.TransportSpeed {
+	if len(speedsDB) == 0 {
+		return []entities.TransportSpeed{}
+	}
+
+	result := make([]entities.TransportSpeed, len(speedsDB))
+	for i, s := range speedsDB {
+		result[i] = *ToDomainTransportSpeed(&s)
+	}
+
+	return result
+}
+
+func ToDomainPeakHourList(peakHoursDB []PeakHourDB) []entities.PeakHour {
+	if len(peakHoursDB) == 0 {
+		return []entities.PeakHour{}
+	}
+
+	result := make([]entities.PeakHour, len(peakHoursDB))
+	for i, p := range peakHoursDB {
+		result[i] = *ToDomainPeakHour(&p)
+	}
+
+	return result
+}
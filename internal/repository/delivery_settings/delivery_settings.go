@@ -0,0 +1,166 @@
+package delivery_settings
+
+import (
+	"context"
+	"fmt"
+
+	"service/internal/entities"
+)
+
+type Repository struct {
+	querier Querier
+}
+
+func New(querier Querier) *Repository {
+	return &Repository{
+		querier: querier,
+	}
+}
+
+func (r *Repository) GetDeadlineSettings(ctx context.Context) (*entities.DeadlineSettings, error) {
+	transportSpeeds, err := r.getTransportSpeeds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	peakHours, err := r.getPeakHours(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.DeadlineSettings{
+		TransportSpeeds: transportSpeeds,
+		PeakHours:       peakHours,
+	}, nil
+}
+
+// ReplaceTransportSpeeds обновляет скорости для переданных типов транспорта и удаляет остальные,
+// для удаленных типов используется значение по умолчанию
+func (r *Repository) ReplaceTransportSpeeds(ctx context.Context, transportSpeeds []entities.TransportSpeed) error {
+	_, err := r.querier.Exec(ctx, `DELETE FROM delivery_transport_speeds`)
+	if err != nil {
+		return fmt.Errorf("unexpected delivery settings repository replace transport speeds error: %w", err)
+	}
+
+	query := `
+		INSERT INTO delivery_transport_speeds (transport_type, average_speed_kmh, handling_overhead_seconds, updated_at)
+		VALUES ($1, $2, $3, NOW())
+	`
+
+	for _, transportSpeed := range transportSpeeds {
+		transportSpeedDB := FromDomainTransportSpeed(&transportSpeed)
+
+		_, err = r.querier.Exec(
+			ctx,
+			query,
+			transportSpeedDB.TransportType,
+			transportSpeedDB.AverageSpeedKmh,
+			transportSpeedDB.HandlingOverheadSeconds,
+		)
+		if err != nil {
+			return fmt.Errorf("unexpected delivery settings repository replace transport speeds error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) ReplacePeakHours(ctx context.Context, peakHours []entities.PeakHour) error {
+	_, err := r.querier.Exec(ctx, `DELETE FROM delivery_peak_hours`)
+	if err != nil {
+		return fmt.Errorf("unexpected delivery settings repository replace peak hours error: %w", err)
+	}
+
+	query := `
+		INSERT INTO delivery_peak_hours (start_hour, end_hour, multiplier)
+		VALUES ($1, $2, $3)
+	`
+
+	for _, peakHour := range peakHours {
+		peakHourDB := FromDomainPeakHour(&peakHour)
+
+		_, err = r.querier.Exec(
+			ctx,
+			query,
+			peakHourDB.StartHour,
+			peakHourDB.EndHour,
+			peakHourDB.Multiplier,
+		)
+		if err != nil {
+			return fmt.Errorf("unexpected delivery settings repository replace peak hours error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) getTransportSpeeds(ctx context.Context) ([]entities.TransportSpeed, error) {
+	query := `
+		SELECT transport_type, average_speed_kmh, handling_overhead_seconds, updated_at
+		FROM delivery_transport_speeds
+		ORDER BY transport_type
+	`
+
+	rows, err := r.querier.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery settings repository get transport speeds error: %w", err)
+	}
+	defer rows.Close()
+
+	transportSpeedModels := make([]TransportSpeedDB, 0, 3)
+	for rows.Next() {
+		var transportSpeedDB TransportSpeedDB
+		err := rows.Scan(
+			&transportSpeedDB.TransportType,
+			&transportSpeedDB.AverageSpeedKmh,
+			&transportSpeedDB.HandlingOverheadSeconds,
+			&transportSpeedDB.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected delivery settings repository get transport speeds error: %w", err)
+		}
+		transportSpeedModels = append(transportSpeedModels, transportSpeedDB)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery settings repository get transport speeds error: %w", err)
+	}
+
+	return ToDomainTransportSpeedList(transportSpeedModels), nil
+}
+
+func (r *Repository) getPeakHours(ctx context.Context) ([]entities.PeakHour, error) {
+	query := `
+		SELECT start_hour, end_hour, multiplier
+		FROM delivery_peak_hours
+		ORDER BY start_hour, end_hour
+	`
+
+	rows, err := r.querier.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery settings repository get peak hours error: %w", err)
+	}
+	defer rows.Close()
+
+	peakHourModels := make([]PeakHourDB, 0, 4)
+	for rows.Next() {
+		var peakHourDB PeakHourDB
+		err := rows.Scan(
+			&peakHourDB.StartHour,
+			&peakHourDB.EndHour,
+			&peakHourDB.Multiplier,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected delivery settings repository get peak hours error: %w", err)
+		}
+		peakHourModels = append(peakHourModels, peakHourDB)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected delivery settings repository get peak hours error: %w", err)
+	}
+
+	return ToDomainPeakHourList(peakHourModels), nil
+}
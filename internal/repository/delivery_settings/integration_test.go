@@ -0,0 +1,113 @@
+//go:build integration
+
+package delivery_settings_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"service/internal/entities"
+	"service/internal/repository/delivery_settings"
+	"service/internal/repository/integration_test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_GetDeadlineSettings_Success(t *testing.T) {
+	setupSql := `
+		INSERT INTO delivery_transport_speeds (transport_type, average_speed_kmh, handling_overhead_seconds)
+		VALUES
+			('car', 30, 120),
+			('on_foot', 5, 300);
+
+		INSERT INTO delivery_peak_hours (start_hour, end_hour, multiplier)
+		VALUES
+			(17, 20, 1.5),
+			(8, 10, 1.3);
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery_settings.New(q)
+	ctx := context.Background()
+
+	t.Run("Успешное получение настроек дедлайнов", func(t *testing.T) {
+		actual, err := repo.GetDeadlineSettings(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		require.Len(t, actual.TransportSpeeds, 2)
+		assert.Equal(t, entities.Car, actual.TransportSpeeds[0].TransportType)
+		assert.InDelta(t, 30.0, actual.TransportSpeeds[0].AverageSpeedKmh, 1e-9)
+		assert.Equal(t, 2*time.Minute, actual.TransportSpeeds[0].HandlingOverhead)
+		assert.Equal(t, entities.OnFoot, actual.TransportSpeeds[1].TransportType)
+
+		require.Len(t, actual.PeakHours, 2)
+		assert.Equal(t, entities.PeakHour{StartHour: 8, EndHour: 10, Multiplier: 1.3}, actual.PeakHours[0])
+		assert.Equal(t, entities.PeakHour{StartHour: 17, EndHour: 20, Multiplier: 1.5}, actual.PeakHours[1])
+	})
+}
+
+func TestRepository_GetDeadlineSettings_Empty(t *testing.T) {
+	integration_test.SetupDB(t, "")
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery_settings.New(q)
+	ctx := context.Background()
+
+	t.Run("Пустые настройки возвращаются как пустые списки", func(t *testing.T) {
+		actual, err := repo.GetDeadlineSettings(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Empty(t, actual.TransportSpeeds)
+		assert.Empty(t, actual.PeakHours)
+	})
+}
+
+func TestRepository_Replace_Success(t *testing.T) {
+	setupSql := `
+		INSERT INTO delivery_transport_speeds (transport_type, average_speed_kmh, handling_overhead_seconds)
+		VALUES
+			('car', 30, 120),
+			('scooter', 15, 300);
+
+		INSERT INTO delivery_peak_hours (start_hour, end_hour, multiplier)
+		VALUES (17, 20, 1.5);
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := delivery_settings.New(q)
+	ctx := context.Background()
+
+	t.Run("Замена настроек удаляет старые записи", func(t *testing.T) {
+		err := repo.ReplaceTransportSpeeds(ctx, []entities.TransportSpeed{
+			{
+				TransportType:    entities.Car,
+				AverageSpeedKmh:  40,
+				HandlingOverhead: time.Minute,
+			},
+		})
+		require.NoError(t, err)
+
+		err = repo.ReplacePeakHours(ctx, []entities.PeakHour{})
+		require.NoError(t, err)
+
+		actual, err := repo.GetDeadlineSettings(ctx)
+		require.NoError(t, err)
+
+		require.Len(t, actual.TransportSpeeds, 1)
+		assert.Equal(t, entities.Car, actual.TransportSpeeds[0].TransportType)
+		assert.InDelta(t, 40.0, actual.TransportSpeeds[0].AverageSpeedKmh, 1e-9)
+		assert.Equal(t, time.Minute, actual.TransportSpeeds[0].HandlingOverhead)
+		assert.Empty(t, actual.PeakHours)
+	})
+}
@@ -0,0 +1,16 @@
+package delivery_settings
+
+import "time"
+
+type TransportSpeedDB struct {
+	TransportType           string
+	AverageSpeedKmh         float64
+	HandlingOverheadSeconds int32
+	UpdatedAt               time.Time
+}
+
+type PeakHourDB struct {
+	StartHour  int16
+	EndHour    int16
+	Multiplier float64
+}
@@ -0,0 +1,146 @@
+package earnings
+
+import "service/internal/entities"
+
+func ToDomainTariff(t *CourierTariffDB) *entities.CourierTariff {
+	if t == nil {
+		return nil
+	}
+
+	return &entities.CourierTariff{
+		TransportType: entities.CourierTransportType(t.TransportType),
+		ZoneID:        t.ZoneID,
+		BaseFee:       t.BaseFee,
+		PerKmRate:     t.PerKmRate,
+		PeakBonus:     t.PeakBonus,
+		LatePenalty:   t.LatePenalty,
+		UpdatedAt:     t.UpdatedAt,
+	}
+}
+
+func FromDomainTariff(t *entities.CourierTariff) *CourierTariffDB {
+	if t == nil {
+		return nil
+	}
+
+	return &CourierTariffDB{
+		TransportType: t.TransportType.String(),
+		ZoneID:        t.ZoneID,
+		BaseFee:       t.BaseFee,
+		PerKmRate:     t.PerKmRate,
+		PeakBonus:     t.PeakBonus,
+		LatePenalty:   t.LatePenalty,
+		UpdatedAt:     t.UpdatedAt,
+	}
+}
+
+func ToDomainTariffList(tariffsDB []CourierTariffDB) []entities.CourierTariff {
+	if len(tariffsDB) == 0 {
+		return []entities.CourierTariff{}
+	}
+
+	result := make([]entities.CourierTariff, len(tariffsDB))
+	for i, t := range tariffsDB {
+		result[i] = *ToDomainTariff(&t)
+	}
+
+	return result
+}
+
+// ToDomainEarningDelivery маршрут известен, только если сохранены обе точки
+func ToDomainEarningDelivery(d *EarningDeliveryDB) *entities.EarningDelivery {
+	if d == nil {
+		return nil
+	}
+
+	earningDelivery := &entities.EarningDelivery{
+		OrderID:       d.OrderID,
+		CourierID:     d.CourierID,
+		TransportType: entities.CourierTransportType(d.TransportType),
+		AssignedAt:    d.AssignedAt,
+		Deadline:      d.Deadline,
+		CompletedAt:   d.CompletedAt,
+	}
+	if d.PickupLat != nil && d.PickupLon != nil && d.DropoffLat != nil && d.DropoffLon != nil {
+		earningDelivery.Route = &entities.Route{
+			Pickup:  entities.Location{Latitude: *d.PickupLat, Longitude: *d.PickupLon},
+			Dropoff: entities.Location{Latitude: *d.DropoffLat, Longitude: *d.DropoffLon},
+		}
+	}
+
+	return earningDelivery
+}
+
+func ToDomainEntry(e *EarningEntryDB) *entities.EarningEntry {
+	if e == nil {
+		return nil
+	}
+
+	return &entities.EarningEntry{
+		ID:             e.ID,
+		OrderID:        e.OrderID,
+		CourierID:      e.CourierID,
+		TransportType:  entities.CourierTransportType(e.TransportType),
+		ZoneID:         e.ZoneID,
+		DistanceKm:     e.DistanceKm,
+		BaseFee:        e.BaseFee,
+		DistanceFee:    e.DistanceFee,
+		PeakBonus:      e.PeakBonus,
+		LatePenalty:    e.LatePenalty,
+		Total:          e.Total,
+		AssignedAt:     e.AssignedAt,
+		Deadline:       e.Deadline,
+		CompletedAt:    e.CompletedAt,
+		PayoutPeriodID: e.PayoutPeriodID,
+	}
+}
+
+func FromDomainEntry(e *entities.EarningEntry) *EarningEntryDB {
+	if e == nil {
+		return nil
+	}
+
+	return &EarningEntryDB{
+		ID:             e.ID,
+		OrderID:        e.OrderID,
+		CourierID:      e.CourierID,
+		TransportType:  e.TransportType.String(),
+		ZoneID:         e.ZoneID,
+		DistanceKm:     e.DistanceKm,
+		BaseFee:        e.BaseFee,
+		DistanceFee:    e.DistanceFee,
+		PeakBonus:      e.PeakBonus,
+		LatePenalty:    e.LatePenalty,
+		Total:          e.Total,
+		AssignedAt:     e.AssignedAt,
+		Deadline:       e.Deadline,
+		CompletedAt:    e.CompletedAt,
+		PayoutPeriodID: e.PayoutPeriodID,
+	}
+}
+
+func ToDomainEntryList(entriesDB []EarningEntryDB) []entities.EarningEntry {
+	if len(entriesDB) == 0 {
+		return []entities.EarningEntry{}
+	}
+
+	result := make([]entities.EarningEntry, len(entriesDB))
+	for i, e := range entriesDB {
+		result[i] = *ToDomainEntry(&e)
+	}
+
+	return result
+}
+
+func ToDomainPayoutPeriod(p *PayoutPeriodDB) *entities.PayoutPeriod {
+	if p == nil {
+		return nil
+	}
+
+	return &entities.PayoutPeriod{
+		ID:       p.ID,
+		Start:    p.PeriodStart,
+		End:      p.PeriodEnd,
+		ClosedAt: p.ClosedAt,
+	}
+}
@@ -0,0 +1,373 @@
+package earnings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"service/internal/entities"
+	"service/internal/repository"
+	"service/internal/service/earnings"
+)
+
+type Repository struct {
+	querier Querier
+}
+
+func New(querier Querier) *Repository {
+	return &Repository{
+		querier: querier,
+	}
+}
+
+const entryColumns = `id, order_id, courier_id, transport_type, zone_id, distance_km, base_fee, distance_fee,
+	peak_bonus, late_penalty, total, assigned_at, deadline, completed_at, payout_period_id`
+
+func (r *Repository) GetTariffs(ctx context.Context) ([]entities.CourierTariff, error) {
+	query := `
+		SELECT transport_type, zone_id, base_fee, per_km_rate, peak_bonus, late_penalty, updated_at
+		FROM courier_tariffs
+		ORDER BY transport_type, zone_id NULLS FIRST
+	`
+
+	rows, err := r.querier.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected earnings repository get tariffs error: %w", err)
+	}
+	defer rows.Close()
+
+	tariffModels := make([]CourierTariffDB, 0, 3)
+	for rows.Next() {
+		var tariffDB CourierTariffDB
+		err := rows.Scan(
+			&tariffDB.TransportType,
+			&tariffDB.ZoneID,
+			&tariffDB.BaseFee,
+			&tariffDB.PerKmRate,
+			&tariffDB.PeakBonus,
+			&tariffDB.LatePenalty,
+			&tariffDB.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected earnings repository get tariffs error: %w", err)
+		}
+		tariffModels = append(tariffModels, tariffDB)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected earnings repository get tariffs error: %w", err)
+	}
+
+	return ToDomainTariffList(tariffModels), nil
+}
+
+// ReplaceTariffs удаляет все тарифы и сохраняет переданные, вызывать внутри транзакции
+func (r *Repository) ReplaceTariffs(ctx context.Context, tariffs []entities.CourierTariff) error {
+	_, err := r.querier.Exec(ctx, `DELETE FROM courier_tariffs`)
+	if err != nil {
+		return fmt.Errorf("unexpected earnings repository replace tariffs error: %w", err)
+	}
+
+	query := `
+		INSERT INTO courier_tariffs (transport_type, zone_id, base_fee, per_km_rate, peak_bonus, late_penalty, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+	`
+
+	for _, tariff := range tariffs {
+		tariffDB := FromDomainTariff(&tariff)
+
+		_, err = r.querier.Exec(
+			ctx,
+			query,
+			tariffDB.TransportType,
+			tariffDB.ZoneID,
+			tariffDB.BaseFee,
+			tariffDB.PerKmRate,
+			tariffDB.PeakBonus,
+			tariffDB.LatePenalty,
+		)
+		if err != nil {
+			if repository.IsPgErrorWithCode(err, repository.PgErrForeignKeyViolation) {
+				return earnings.ErrZoneNotFound
+			}
+			return fmt.Errorf("unexpected earnings repository replace tariffs error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// FindTariff тариф транспорта в одной из зон, при нескольких зонах берется зона с меньшим ID.
+// Если у зон нет своего тарифа, возвращается тариф транспорта без зоны
+func (r *Repository) FindTariff(
+	ctx context.Context,
+	transportType entities.CourierTransportType,
+	zoneIDs []int64,
+) (*entities.CourierTariff, error) {
+	query := `
+		SELECT transport_type, zone_id, base_fee, per_km_rate, peak_bonus, late_penalty, updated_at
+		FROM courier_tariffs
+		WHERE transport_type = $1 AND (zone_id IS NULL OR zone_id = ANY($2))
+		ORDER BY zone_id NULLS LAST
+		LIMIT 1
+	`
+
+	if zoneIDs == nil {
+		zoneIDs = []int64{}
+	}
+
+	var tariffDB CourierTariffDB
+	err := r.querier.QueryRow(ctx, query, transportType.String(), zoneIDs).Scan(
+		&tariffDB.TransportType,
+		&tariffDB.ZoneID,
+		&tariffDB.BaseFee,
+		&tariffDB.PerKmRate,
+		&tariffDB.PeakBonus,
+		&tariffDB.LatePenalty,
+		&tariffDB.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, earnings.ErrTariffNotFound
+		}
+		return nil, fmt.Errorf("unexpected earnings repository find tariff error: %w", err)
+	}
+
+	return ToDomainTariff(&tariffDB), nil
+}
+
+// GetEarningDelivery последняя выполненная доставка заказа, вид транспорта берется у курьера доставки
+func (r *Repository) GetEarningDelivery(ctx context.Context, orderID string) (*entities.EarningDelivery, error) {
+	query := `
+		SELECT d.order_id, d.courier_id, c.transport_type, d.pickup_lat, d.pickup_lon, d.dropoff_lat, d.dropoff_lon,
+			d.assigned_at, d.deadline, d.completed_at
+		FROM delivery d
+		JOIN couriers c ON c.id = d.courier_id
+		WHERE d.order_id = $1 AND d.completed_at IS NOT NULL
+		ORDER BY d.assigned_at DESC
+		LIMIT 1
+	`
+
+	var deliveryDB EarningDeliveryDB
+	err := r.querier.QueryRow(ctx, query, orderID).Scan(
+		&deliveryDB.OrderID,
+		&deliveryDB.CourierID,
+		&deliveryDB.TransportType,
+		&deliveryDB.PickupLat,
+		&deliveryDB.PickupLon,
+		&deliveryDB.DropoffLat,
+		&deliveryDB.DropoffLon,
+		&deliveryDB.AssignedAt,
+		&deliveryDB.Deadline,
+		&deliveryDB.CompletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, earnings.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("unexpected earnings repository get delivery error: %w", err)
+	}
+
+	return ToDomainEarningDelivery(&deliveryDB), nil
+}
+
+// CreateEntry сохраняет начисление, если за заказ еще ничего не начислено
+func (r *Repository) CreateEntry(ctx context.Context, entry entities.EarningEntry) (bool, error) {
+	query := `
+		INSERT INTO courier_earnings (
+			order_id, courier_id, transport_type, zone_id, distance_km, base_fee, distance_fee,
+			peak_bonus, late_penalty, total, assigned_at, deadline, completed_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (order_id) DO NOTHING
+	`
+
+	entryDB := FromDomainEntry(&entry)
+	tag, err := r.querier.Exec(
+		ctx,
+		query,
+		entryDB.OrderID,
+		entryDB.CourierID,
+		entryDB.TransportType,
+		entryDB.ZoneID,
+		entryDB.DistanceKm,
+		entryDB.BaseFee,
+		entryDB.DistanceFee,
+		entryDB.PeakBonus,
+		entryDB.LatePenalty,
+		entryDB.Total,
+		entryDB.AssignedAt,
+		entryDB.Deadline,
+		entryDB.CompletedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("unexpected earnings repository create entry error: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// CourierExists курьер есть, даже если деактивирован: начисления за прошлые доставки остаются
+func (r *Repository) CourierExists(ctx context.Context, courierID int64) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM couriers WHERE id = $1)
+	`
+
+	var exists bool
+	err := r.querier.QueryRow(ctx, query, courierID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("unexpected earnings repository courier exists error: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetEntries начисления курьеру за доставки, выполненные в [from, to), по времени выполнения
+func (r *Repository) GetEntries(ctx context.Context, courierID int64, from, to time.Time) ([]entities.EarningEntry, error) {
+	query := `
+		SELECT ` + entryColumns + `
+		FROM courier_earnings
+		WHERE courier_id = $1 AND completed_at >= $2 AND completed_at < $3
+		ORDER BY completed_at, id
+	`
+
+	rows, err := r.querier.Query(ctx, query, courierID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected earnings repository get entries error: %w", err)
+	}
+	defer rows.Close()
+
+	entries, err := scanEntries(rows)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected earnings repository get entries error: %w", err)
+	}
+
+	return entries, nil
+}
+
+// LockPayouts сериализует закрытие расчетных периодов между запусками до конца транзакции
+func (r *Repository) LockPayouts(ctx context.Context) error {
+	query := `
+		SELECT pg_advisory_xact_lock(hashtext('payout_periods'))
+	`
+
+	_, err := r.querier.Exec(ctx, query)
+	if err != nil {
+		return fmt.Errorf("unexpected earnings repository lock payouts error: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetLastPayoutPeriod(ctx context.Context) (*entities.PayoutPeriod, error) {
+	query := `
+		SELECT id, period_start, period_end, closed_at
+		FROM payout_periods
+		ORDER BY period_end DESC
+		LIMIT 1
+	`
+
+	var periodDB PayoutPeriodDB
+	err := r.querier.QueryRow(ctx, query).Scan(
+		&periodDB.ID,
+		&periodDB.PeriodStart,
+		&periodDB.PeriodEnd,
+		&periodDB.ClosedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, earnings.ErrNoPayoutPeriod
+		}
+		return nil, fmt.Errorf("unexpected earnings repository get last payout period error: %w", err)
+	}
+
+	return ToDomainPayoutPeriod(&periodDB), nil
+}
+
+func (r *Repository) CreatePayoutPeriod(ctx context.Context, period entities.PayoutPeriod) (*entities.PayoutPeriod, error) {
+	query := `
+		INSERT INTO payout_periods (period_start, period_end, closed_at)
+		VALUES ($1, $2, $3)
+		RETURNING id, period_start, period_end, closed_at
+	`
+
+	var periodDB PayoutPeriodDB
+	err := r.querier.QueryRow(ctx, query, period.Start, period.End, period.ClosedAt).Scan(
+		&periodDB.ID,
+		&periodDB.PeriodStart,
+		&periodDB.PeriodEnd,
+		&periodDB.ClosedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected earnings repository create payout period error: %w", err)
+	}
+
+	return ToDomainPayoutPeriod(&periodDB), nil
+}
+
+// AssignEntriesToPeriod относит к периоду все невыплаченные начисления за доставки, выполненные до periodEnd,
+// и возвращает их по курьеру и времени выполнения
+func (r *Repository) AssignEntriesToPeriod(ctx context.Context, periodID int64, periodEnd time.Time) ([]entities.EarningEntry, error) {
+	query := `
+		WITH assigned AS (
+			UPDATE courier_earnings
+			SET payout_period_id = $1
+			WHERE payout_period_id IS NULL AND completed_at < $2
+			RETURNING ` + entryColumns + `
+		)
+		SELECT ` + entryColumns + `
+		FROM assigned
+		ORDER BY courier_id, completed_at, id
+	`
+
+	rows, err := r.querier.Query(ctx, query, periodID, periodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected earnings repository assign entries error: %w", err)
+	}
+	defer rows.Close()
+
+	entries, err := scanEntries(rows)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected earnings repository assign entries error: %w", err)
+	}
+
+	return entries, nil
+}
+
+func scanEntries(rows pgx.Rows) ([]entities.EarningEntry, error) {
+	entryModels := make([]EarningEntryDB, 0, 16)
+	for rows.Next() {
+		var entryDB EarningEntryDB
+		err := rows.Scan(
+			&entryDB.ID,
+			&entryDB.OrderID,
+			&entryDB.CourierID,
+			&entryDB.TransportType,
+			&entryDB.ZoneID,
+			&entryDB.DistanceKm,
+			&entryDB.BaseFee,
+			&entryDB.DistanceFee,
+			&entryDB.PeakBonus,
+			&entryDB.LatePenalty,
+			&entryDB.Total,
+			&entryDB.AssignedAt,
+			&entryDB.Deadline,
+			&entryDB.CompletedAt,
+			&entryDB.PayoutPeriodID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entryModels = append(entryModels, entryDB)
+	}
+
+	err := rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return ToDomainEntryList(entryModels), nil
+}
@@ -0,0 +1,248 @@
+//go:build integration
+
+package earnings_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"service/internal/entities"
+	"service/internal/repository/earnings"
+	"service/internal/repository/integration_test"
+	service "service/internal/service/earnings"
+)
+
+const earningsSetupSql = `
+	INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+	VALUES
+		(1, 'Courier 1', '+79991112233', 'available', 'scooter', '2025-01-15 11:00:00', '2025-01-15 11:00:00'),
+		(2, 'Courier 2', '+79991112244', 'available', 'car', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+	INSERT INTO zones (id, name, polygon, min_lat, min_lon, max_lat, max_lon)
+	VALUES
+		(1, 'Центр', '[]', 0, 0, 0, 0),
+		(2, 'Север', '[]', 0, 0, 0, 0);
+
+	INSERT INTO courier_tariffs (transport_type, zone_id, base_fee, per_km_rate, peak_bonus, late_penalty)
+	VALUES
+		('scooter', NULL, 12000, 1500, 5000, 5000),
+		('scooter', 2, 14000, 1500, 5000, 3000),
+		('car', NULL, 15000, 1200, 5000, 5000);
+
+	INSERT INTO delivery (courier_id, order_id, created_at, assigned_at, deadline, completed_at,
+		pickup_lat, pickup_lon, dropoff_lat, dropoff_lon)
+	VALUES
+		(1, 'order-1', '2025-01-15 12:00:00', '2025-01-15 12:00:00', '2025-01-15 12:30:00', '2025-01-15 12:20:00',
+			55.70, 37.60, 55.75, 37.60),
+		(2, 'order-2', '2025-01-15 12:10:00', '2025-01-15 12:10:00', '2025-01-15 12:40:00', '2025-01-15 12:50:00',
+			NULL, NULL, NULL, NULL),
+		(1, 'order-3', '2025-01-15 13:00:00', '2025-01-15 13:00:00', '2025-01-15 13:30:00', NULL,
+			NULL, NULL, NULL, NULL);
+`
+
+func entry(orderID string, courierID int64, total int64, completedAt time.Time) entities.EarningEntry {
+	return entities.EarningEntry{
+		OrderID:       orderID,
+		CourierID:     courierID,
+		TransportType: entities.Scooter,
+		BaseFee:       total,
+		Total:         total,
+		AssignedAt:    completedAt.Add(-30 * time.Minute),
+		Deadline:      completedAt.Add(10 * time.Minute),
+		CompletedAt:   completedAt,
+	}
+}
+
+func TestRepository_Tariffs(t *testing.T) {
+	integration_test.SetupDB(t, earningsSetupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := earnings.New(q)
+	ctx := context.Background()
+
+	t.Run("Тариф зоны точки забора", func(t *testing.T) {
+		actual, err := repo.FindTariff(ctx, entities.Scooter, []int64{1, 2})
+		require.NoError(t, err)
+		assert.Equal(t, pointer.To(int64(2)), actual.ZoneID)
+		assert.Equal(t, int64(14000), actual.BaseFee)
+	})
+
+	t.Run("У зоны нет своего тарифа", func(t *testing.T) {
+		actual, err := repo.FindTariff(ctx, entities.Car, []int64{2})
+		require.NoError(t, err)
+		assert.Nil(t, actual.ZoneID)
+		assert.Equal(t, int64(15000), actual.BaseFee)
+	})
+
+	t.Run("Зона точки забора неизвестна", func(t *testing.T) {
+		actual, err := repo.FindTariff(ctx, entities.Scooter, nil)
+		require.NoError(t, err)
+		assert.Nil(t, actual.ZoneID)
+	})
+
+	t.Run("Тарифа для транспорта нет", func(t *testing.T) {
+		_, err := repo.FindTariff(ctx, entities.OnFoot, nil)
+		require.ErrorIs(t, err, service.ErrTariffNotFound)
+	})
+
+	t.Run("Замена тарифов", func(t *testing.T) {
+		err := repo.ReplaceTariffs(ctx, []entities.CourierTariff{
+			{TransportType: entities.Car, BaseFee: 16000, PerKmRate: 1000},
+			{TransportType: entities.Car, ZoneID: pointer.To(int64(1)), BaseFee: 17000},
+		})
+		require.NoError(t, err)
+
+		actual, err := repo.GetTariffs(ctx)
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+		assert.Nil(t, actual[0].ZoneID)
+		assert.Equal(t, int64(16000), actual[0].BaseFee)
+		assert.Equal(t, pointer.To(int64(1)), actual[1].ZoneID)
+	})
+
+	t.Run("Тариф несуществующей зоны", func(t *testing.T) {
+		err := repo.ReplaceTariffs(ctx, []entities.CourierTariff{
+			{TransportType: entities.Car, ZoneID: pointer.To(int64(404))},
+		})
+		require.ErrorIs(t, err, service.ErrZoneNotFound)
+	})
+}
+
+func TestRepository_GetEarningDelivery(t *testing.T) {
+	integration_test.SetupDB(t, earningsSetupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := earnings.New(q)
+	ctx := context.Background()
+
+	t.Run("Доставка с маршрутом", func(t *testing.T) {
+		actual, err := repo.GetEarningDelivery(ctx, "order-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), actual.CourierID)
+		assert.Equal(t, entities.Scooter, actual.TransportType)
+		require.NotNil(t, actual.Route)
+		assert.Equal(t, entities.Location{Latitude: 55.70, Longitude: 37.60}, actual.Route.Pickup)
+		assert.Equal(t, time.Date(2025, 1, 15, 12, 20, 0, 0, time.UTC), actual.CompletedAt.UTC())
+	})
+
+	t.Run("Доставка без маршрута", func(t *testing.T) {
+		actual, err := repo.GetEarningDelivery(ctx, "order-2")
+		require.NoError(t, err)
+		assert.Equal(t, entities.Car, actual.TransportType)
+		assert.Nil(t, actual.Route)
+	})
+
+	t.Run("Доставка еще не выполнена", func(t *testing.T) {
+		_, err := repo.GetEarningDelivery(ctx, "order-3")
+		require.ErrorIs(t, err, service.ErrDeliveryNotFound)
+	})
+}
+
+func TestRepository_Entries(t *testing.T) {
+	integration_test.SetupDB(t, earningsSetupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := earnings.New(q)
+	ctx := context.Background()
+
+	day := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Начисление за заказ сохраняется один раз", func(t *testing.T) {
+		created, err := repo.CreateEntry(ctx, entry("order-1", 1, 17000, day.Add(12*time.Hour)))
+		require.NoError(t, err)
+		assert.True(t, created)
+
+		created, err = repo.CreateEntry(ctx, entry("order-1", 1, 99999, day.Add(12*time.Hour)))
+		require.NoError(t, err)
+		assert.False(t, created)
+	})
+
+	t.Run("Начисления курьера за период", func(t *testing.T) {
+		_, err := repo.CreateEntry(ctx, entry("order-4", 1, 8000, day.Add(36*time.Hour)))
+		require.NoError(t, err)
+		_, err = repo.CreateEntry(ctx, entry("order-5", 2, 9000, day.Add(13*time.Hour)))
+		require.NoError(t, err)
+
+		actual, err := repo.GetEntries(ctx, 1, day, day.Add(24*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Equal(t, "order-1", actual[0].OrderID)
+		assert.Equal(t, int64(17000), actual[0].Total)
+		assert.Nil(t, actual[0].PayoutPeriodID)
+	})
+
+	t.Run("Курьер существует", func(t *testing.T) {
+		exists, err := repo.CourierExists(ctx, 1)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = repo.CourierExists(ctx, 404)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestRepository_PayoutPeriods(t *testing.T) {
+	integration_test.SetupDB(t, earningsSetupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := earnings.New(q)
+	ctx := context.Background()
+
+	day := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	periodEnd := day.Add(24 * time.Hour)
+
+	for _, e := range []entities.EarningEntry{
+		entry("order-1", 1, 17000, day.Add(12*time.Hour)),
+		entry("order-4", 1, 8000, day.Add(36*time.Hour)),
+		entry("order-5", 2, 9000, day.Add(13*time.Hour)),
+	} {
+		_, err := repo.CreateEntry(ctx, e)
+		require.NoError(t, err)
+	}
+
+	t.Run("Закрытых периодов нет", func(t *testing.T) {
+		_, err := repo.GetLastPayoutPeriod(ctx)
+		require.ErrorIs(t, err, service.ErrNoPayoutPeriod)
+	})
+
+	t.Run("Закрытие периода относит к нему невыплаченные начисления до конца периода", func(t *testing.T) {
+		require.NoError(t, repo.LockPayouts(ctx))
+
+		period, err := repo.CreatePayoutPeriod(ctx, entities.PayoutPeriod{End: periodEnd, ClosedAt: periodEnd})
+		require.NoError(t, err)
+		assert.NotZero(t, period.ID)
+		assert.Nil(t, period.Start)
+
+		assigned, err := repo.AssignEntriesToPeriod(ctx, period.ID, period.End)
+		require.NoError(t, err)
+		require.Len(t, assigned, 2)
+		assert.Equal(t, "order-1", assigned[0].OrderID)
+		assert.Equal(t, "order-5", assigned[1].OrderID)
+		assert.Equal(t, &period.ID, assigned[0].PayoutPeriodID)
+
+		last, err := repo.GetLastPayoutPeriod(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, period.ID, last.ID)
+		assert.Equal(t, periodEnd, last.End.UTC())
+	})
+
+	t.Run("Выплаченные начисления не попадают в следующий период", func(t *testing.T) {
+		nextEnd := periodEnd.Add(48 * time.Hour)
+		period, err := repo.CreatePayoutPeriod(ctx, entities.PayoutPeriod{Start: &periodEnd, End: nextEnd, ClosedAt: nextEnd})
+		require.NoError(t, err)
+
+		assigned, err := repo.AssignEntriesToPeriod(ctx, period.ID, period.End)
+		require.NoError(t, err)
+		require.Len(t, assigned, 1)
+		assert.Equal(t, "order-4", assigned[0].OrderID)
+	})
+}
@@ -0,0 +1,51 @@
+package earnings
+
+import "time"
+
+type CourierTariffDB struct {
+	TransportType string
+	ZoneID        *int64
+	BaseFee       int64
+	PerKmRate     int64
+	PeakBonus     int64
+	LatePenalty   int64
+	UpdatedAt     time.Time
+}
+
+type EarningDeliveryDB struct {
+	OrderID       string
+	CourierID     int64
+	TransportType string
+	PickupLat     *float64
+	PickupLon     *float64
+	DropoffLat    *float64
+	DropoffLon    *float64
+	AssignedAt    time.Time
+	Deadline      time.Time
+	CompletedAt   time.Time
+}
+
+type EarningEntryDB struct {
+	ID             int64
+	OrderID        string
+	CourierID      int64
+	TransportType  string
+	ZoneID         *int64
+	DistanceKm     float64
+	BaseFee        int64
+	DistanceFee    int64
+	PeakBonus      int64
+	LatePenalty    int64
+	Total          int64
+	AssignedAt     time.Time
+	Deadline       time.Time
+	CompletedAt    time.Time
+	PayoutPeriodID *int64
+}
+
+type PayoutPeriodDB struct {
+	ID          int64
+	PeriodStart *time.Time
+	PeriodEnd   time.Time
+	ClosedAt    time.Time
+}
@@ -16,3 +16,15 @@ func IsPgErrorWithCode(err error, code string) bool {
 	}
 	return false
 }
+
+// https://www.postgresql.org/docs/current/errcodes-appendix.html#23503:~:text=restrict_violation-,23503,-foreign_key_violation
+const PgErrForeignKeyViolation = "23503"
+
+// PgErrorConstraint имя нарушенного ограничения, пусто если ошибка не от Postgres
+func PgErrorConstraint(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.ConstraintName
+	}
+	return ""
+}
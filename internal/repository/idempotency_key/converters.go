@@ -0,0 +1,61 @@
+package idempotency_key
+
+import "service/internal/entities"
+
+func ToDomain(k *IdempotencyKeyDB) *entities.IdempotencyKey {
+	if k == nil {
+		return nil
+	}
+
+	idempotencyKey := &entities.IdempotencyKey{
+		Key:          k.Key,
+		Scope:        k.Scope,
+		Fingerprint:  k.Fingerprint,
+		ResponseBody: k.ResponseBody,
+		CreatedAt:    k.CreatedAt,
+		ExpiresAt:    k.ExpiresAt,
+	}
+	if k.StatusCode != nil {
+		idempotencyKey.StatusCode = *k.StatusCode
+	}
+	if k.ContentType != nil {
+		idempotencyKey.ContentType = *k.ContentType
+	}
+
+	return idempotencyKey
+}
+
+func FromDomainModify(k *entities.IdempotencyKeyModify) *IdempotencyKeyModifyDB {
+	if k == nil {
+		return nil
+	}
+
+	keyModifyDB := &IdempotencyKeyModifyDB{}
+
+	if k.Key != nil {
+		keyModifyDB.Key = k.Key
+	}
+	if k.Scope != nil {
+		keyModifyDB.Scope = k.Scope
+	}
+	if k.Fingerprint != nil {
+		keyModifyDB.Fingerprint = k.Fingerprint
+	}
+	if k.StatusCode != nil {
+		keyModifyDB.StatusCode = k.StatusCode
+	}
+	if k.ContentType != nil {
+		keyModifyDB.ContentType = k.ContentType
+	}
+	if k.ResponseBody != nil {
+		keyModifyDB.ResponseBody = k.ResponseBody
+	}
+	if k.CreatedAt != nil {
+		keyModifyDB.CreatedAt = k.CreatedAt
+	}
+	if k.ExpiresAt != nil {
+		keyModifyDB.ExpiresAt = k.ExpiresAt
+	}
+
+	return keyModifyDB
+}
@@ -0,0 +1,158 @@
+package idempotency_key
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"service/internal/entities"
+	"service/internal/service/idempotency"
+)
+
+type Repository struct {
+	querier Querier
+}
+
+func New(querier Querier) *Repository {
+	return &Repository{
+		querier: querier,
+	}
+}
+
+// Create резервирует ключ. Истекший, но еще не удаленный задачей очистки ключ
+// перезаписывается, активный - возвращает ErrIdempotencyKeyExists.
+func (r *Repository) Create(ctx context.Context, keyModify entities.IdempotencyKeyModify) (*entities.IdempotencyKey, error) {
+	keyModifyDB := FromDomainModify(&keyModify)
+
+	query := `
+		INSERT INTO idempotency_keys (key, scope, fingerprint, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (key, scope) DO UPDATE
+			SET fingerprint = EXCLUDED.fingerprint,
+				status_code = NULL,
+				content_type = NULL,
+				response_body = NULL,
+				created_at = EXCLUDED.created_at,
+				expires_at = EXCLUDED.expires_at
+			WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+		RETURNING key, scope, fingerprint, status_code, content_type, response_body, created_at, expires_at
+	`
+
+	var keyDB IdempotencyKeyDB
+	err := r.querier.QueryRow(
+		ctx,
+		query,
+		keyModifyDB.Key,
+		keyModifyDB.Scope,
+		keyModifyDB.Fingerprint,
+		keyModifyDB.CreatedAt,
+		keyModifyDB.ExpiresAt,
+	).Scan(
+		&keyDB.Key,
+		&keyDB.Scope,
+		&keyDB.Fingerprint,
+		&keyDB.StatusCode,
+		&keyDB.ContentType,
+		&keyDB.ResponseBody,
+		&keyDB.CreatedAt,
+		&keyDB.ExpiresAt,
+	)
+	if err != nil {
+		// конфликт с активным ключом: условие WHERE не дало обновить строку
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, idempotency.ErrIdempotencyKeyExists
+		}
+		return nil, fmt.Errorf("unexpected idempotency key repository create error: %w", err)
+	}
+
+	return ToDomain(&keyDB), nil
+}
+
+func (r *Repository) Get(ctx context.Context, key, scope string) (*entities.IdempotencyKey, error) {
+	query := `
+		SELECT key, scope, fingerprint, status_code, content_type, response_body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE key = $1 AND scope = $2
+	`
+
+	var keyDB IdempotencyKeyDB
+	err := r.querier.QueryRow(ctx, query, key, scope).Scan(
+		&keyDB.Key,
+		&keyDB.Scope,
+		&keyDB.Fingerprint,
+		&keyDB.StatusCode,
+		&keyDB.ContentType,
+		&keyDB.ResponseBody,
+		&keyDB.CreatedAt,
+		&keyDB.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, idempotency.ErrIdempotencyKeyNotFound
+		}
+		return nil, fmt.Errorf("unexpected idempotency key repository get error: %w", err)
+	}
+
+	return ToDomain(&keyDB), nil
+}
+
+func (r *Repository) SaveResponse(ctx context.Context, keyModify entities.IdempotencyKeyModify) error {
+	keyModifyDB := FromDomainModify(&keyModify)
+
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $3, content_type = $4, response_body = $5
+		WHERE key = $1 AND scope = $2
+	`
+
+	result, err := r.querier.Exec(
+		ctx,
+		query,
+		keyModifyDB.Key,
+		keyModifyDB.Scope,
+		keyModifyDB.StatusCode,
+		keyModifyDB.ContentType,
+		keyModifyDB.ResponseBody,
+	)
+	if err != nil {
+		return fmt.Errorf("unexpected idempotency key repository save response error: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return idempotency.ErrIdempotencyKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) Delete(ctx context.Context, key, scope string) error {
+	query := `
+		DELETE FROM idempotency_keys WHERE key = $1 AND scope = $2
+	`
+
+	result, err := r.querier.Exec(ctx, query, key, scope)
+	if err != nil {
+		return fmt.Errorf("unexpected idempotency key repository delete error: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return idempotency.ErrIdempotencyKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	query := `
+		DELETE FROM idempotency_keys WHERE expires_at <= $1
+	`
+
+	result, err := r.querier.Exec(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected idempotency key repository delete expired error: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,206 @@
+//go:build integration
+
+package idempotency_key_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"service/internal/entities"
+	"service/internal/repository/idempotency_key"
+	"service/internal/repository/integration_test"
+	service "service/internal/service/idempotency"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Create_Success(t *testing.T) {
+	integration_test.SetupDB(t, "")
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := idempotency_key.New(q)
+	ctx := context.Background()
+
+	t.Run("Успешное резервирование ключа", func(t *testing.T) {
+		actual, err := repo.Create(ctx, entities.IdempotencyKeyModify{
+			Key:         pointer.To("key-1"),
+			Scope:       pointer.To("POST /courier"),
+			Fingerprint: pointer.To("fingerprint-1"),
+			CreatedAt:   pointer.To(time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)),
+			ExpiresAt:   pointer.To(time.Date(2025, 1, 16, 12, 0, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, "key-1", actual.Key)
+		assert.Equal(t, "POST /courier", actual.Scope)
+		assert.False(t, actual.IsCompleted())
+	})
+}
+
+func TestRepository_Create_ActiveKeyExists(t *testing.T) {
+	setupSql := `
+		INSERT INTO idempotency_keys (key, scope, fingerprint, created_at, expires_at)
+		VALUES ('key-1', 'POST /courier', 'fingerprint-1', NOW(), NOW() + INTERVAL '1 day');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := idempotency_key.New(q)
+	ctx := context.Background()
+
+	t.Run("Ошибка при повторном резервировании активного ключа", func(t *testing.T) {
+		now := time.Now().UTC()
+		actual, err := repo.Create(ctx, entities.IdempotencyKeyModify{
+			Key:         pointer.To("key-1"),
+			Scope:       pointer.To("POST /courier"),
+			Fingerprint: pointer.To("fingerprint-2"),
+			CreatedAt:   pointer.To(now),
+			ExpiresAt:   pointer.To(now.Add(24 * time.Hour)),
+		})
+		require.Error(t, err)
+		require.Nil(t, actual)
+		assert.ErrorIs(t, err, service.ErrIdempotencyKeyExists)
+	})
+
+	t.Run("Тот же ключ для другого эндпоинта резервируется независимо", func(t *testing.T) {
+		now := time.Now().UTC()
+		actual, err := repo.Create(ctx, entities.IdempotencyKeyModify{
+			Key:         pointer.To("key-1"),
+			Scope:       pointer.To("POST /delivery/assign"),
+			Fingerprint: pointer.To("fingerprint-2"),
+			CreatedAt:   pointer.To(now),
+			ExpiresAt:   pointer.To(now.Add(24 * time.Hour)),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+	})
+}
+
+func TestRepository_Create_ExpiredKeyOverwritten(t *testing.T) {
+	setupSql := `
+		INSERT INTO idempotency_keys (key, scope, fingerprint, status_code, content_type, response_body, created_at, expires_at)
+		VALUES ('key-1', 'POST /courier', 'fingerprint-1', 201, 'application/json', '{"ID":1}', '2025-01-14 12:00:00', '2025-01-15 12:00:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := idempotency_key.New(q)
+	ctx := context.Background()
+
+	t.Run("Истекший ключ перезаписывается новым запросом", func(t *testing.T) {
+		actual, err := repo.Create(ctx, entities.IdempotencyKeyModify{
+			Key:         pointer.To("key-1"),
+			Scope:       pointer.To("POST /courier"),
+			Fingerprint: pointer.To("fingerprint-2"),
+			CreatedAt:   pointer.To(time.Date(2025, 1, 15, 13, 0, 0, 0, time.UTC)),
+			ExpiresAt:   pointer.To(time.Date(2025, 1, 16, 13, 0, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, "fingerprint-2", actual.Fingerprint)
+		assert.False(t, actual.IsCompleted())
+		assert.Nil(t, actual.ResponseBody)
+	})
+}
+
+func TestRepository_SaveResponse_Success(t *testing.T) {
+	setupSql := `
+		INSERT INTO idempotency_keys (key, scope, fingerprint, created_at, expires_at)
+		VALUES ('key-1', 'POST /courier', 'fingerprint-1', NOW(), NOW() + INTERVAL '1 day');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := idempotency_key.New(q)
+	ctx := context.Background()
+
+	t.Run("Сохраненный ответ возвращается по ключу", func(t *testing.T) {
+		err := repo.SaveResponse(ctx, entities.IdempotencyKeyModify{
+			Key:          pointer.To("key-1"),
+			Scope:        pointer.To("POST /courier"),
+			StatusCode:   pointer.To(http.StatusCreated),
+			ContentType:  pointer.To("application/json"),
+			ResponseBody: []byte(`{"ID":1}`),
+		})
+		require.NoError(t, err)
+
+		actual, err := repo.Get(ctx, "key-1", "POST /courier")
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.True(t, actual.IsCompleted())
+		assert.Equal(t, http.StatusCreated, actual.StatusCode)
+		assert.Equal(t, "application/json", actual.ContentType)
+		assert.Equal(t, []byte(`{"ID":1}`), actual.ResponseBody)
+	})
+}
+
+func TestRepository_Get_NotFound(t *testing.T) {
+	integration_test.SetupDB(t, "")
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := idempotency_key.New(q)
+	ctx := context.Background()
+
+	t.Run("Ошибка при получении отсутствующего ключа", func(t *testing.T) {
+		actual, err := repo.Get(ctx, "unknown-key", "POST /courier")
+		require.Error(t, err)
+		require.Nil(t, actual)
+		assert.ErrorIs(t, err, service.ErrIdempotencyKeyNotFound)
+	})
+}
+
+func TestRepository_Delete_NotFound(t *testing.T) {
+	integration_test.SetupDB(t, "")
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := idempotency_key.New(q)
+	ctx := context.Background()
+
+	t.Run("Ошибка при удалении отсутствующего ключа", func(t *testing.T) {
+		err := repo.Delete(ctx, "unknown-key", "POST /courier")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, service.ErrIdempotencyKeyNotFound)
+	})
+}
+
+func TestRepository_DeleteExpired_Success(t *testing.T) {
+	setupSql := `
+		INSERT INTO idempotency_keys (key, scope, fingerprint, created_at, expires_at)
+		VALUES
+			('key-old-1', 'POST /courier', 'fingerprint-1', '2025-01-14 10:00:00', '2025-01-15 10:00:00'),
+			('key-old-2', 'POST /courier', 'fingerprint-2', '2025-01-14 11:00:00', '2025-01-15 11:00:00'),
+			('key-new', 'POST /courier', 'fingerprint-3', '2025-01-15 11:30:00', '2025-01-16 11:30:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := idempotency_key.New(q)
+	ctx := context.Background()
+
+	t.Run("Удаляются только истекшие ключи", func(t *testing.T) {
+		rowsAffected, err := repo.DeleteExpired(ctx, time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), rowsAffected)
+
+		_, err = repo.Get(ctx, "key-new", "POST /courier")
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,25 @@
+package idempotency_key
+
+import "time"
+
+type IdempotencyKeyDB struct {
+	Key          string
+	Scope        string
+	Fingerprint  string
+	StatusCode   *int
+	ContentType  *string
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+type IdempotencyKeyModifyDB struct {
+	Key          *string
+	Scope        *string
+	Fingerprint  *string
+	StatusCode   *int
+	ContentType  *string
+	ResponseBody []byte
+	CreatedAt    *time.Time
+	ExpiresAt    *time.Time
+}
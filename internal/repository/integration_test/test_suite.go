@@ -70,7 +70,7 @@ func TeardownDB(t *testing.T) {
 	defer cancel()
 
 	_, err := GetQuerier().Exec(ctx, `
-		TRUNCATE TABLE delivery, couriers RESTART IDENTITY CASCADE;
+		TRUNCATE TABLE delivery, delivery_order_ids, delivery_archive, couriers, pending_assignments, delivery_transport_speeds, delivery_peak_hours, idempotency_keys, delivery_reassignments, zones, courier_zones, courier_skills, delivery_offers, scheduled_deliveries, delivery_eta_quantiles, delivery_ratings, courier_tariffs, courier_earnings, payout_periods, courier_cash_transactions, courier_cash_balances RESTART IDENTITY CASCADE;
 	`)
 	require.NoError(t, err)
 }
@@ -0,0 +1,130 @@
+package pending_assignment
+
+import (
+	"service/internal/entities"
+	"service/internal/repository"
+)
+
+func ToDomain(p *PendingAssignmentDB) *entities.PendingAssignment {
+	if p == nil {
+		return nil
+	}
+
+	pending := &entities.PendingAssignment{
+		ID:                p.ID,
+		OrderID:           p.OrderID,
+		Priority:          p.Priority,
+		Route:             toDomainRoute(p),
+		Address:           repository.AddressToDomain(p.Address),
+		EstimatedDelivery: p.EstimatedDelivery,
+		OrderCreatedAt:    p.OrderCreatedAt,
+		Requirements:      toDomainRequirements(p),
+		CashAmount:        p.CashAmount,
+		EnqueuedAt:        p.EnqueuedAt,
+		BatchUntil:        p.BatchUntil,
+	}
+	if p.RestaurantID != nil {
+		pending.RestaurantID = *p.RestaurantID
+	}
+
+	return pending
+}
+
+func FromDomainModify(p *entities.PendingAssignmentModify) *PendingAssignmentModifyDB {
+	if p == nil {
+		return nil
+	}
+
+	pendingModifyDB := &PendingAssignmentModifyDB{}
+
+	if p.ID != nil {
+		pendingModifyDB.ID = p.ID
+	}
+	if p.OrderID != nil {
+		pendingModifyDB.OrderID = p.OrderID
+	}
+	if p.Priority != nil {
+		pendingModifyDB.Priority = p.Priority
+	}
+	if p.Route != nil {
+		pendingModifyDB.PickupLat = &p.Route.Pickup.Latitude
+		pendingModifyDB.PickupLon = &p.Route.Pickup.Longitude
+		pendingModifyDB.DropoffLat = &p.Route.Dropoff.Latitude
+		pendingModifyDB.DropoffLon = &p.Route.Dropoff.Longitude
+	}
+	// пустой ресторан храним как NULL
+	if p.RestaurantID != nil && *p.RestaurantID != "" {
+		pendingModifyDB.RestaurantID = p.RestaurantID
+	}
+	if p.Address != nil {
+		pendingModifyDB.Address = repository.AddressFromDomain(p.Address)
+	}
+	if p.EstimatedDelivery != nil {
+		pendingModifyDB.EstimatedDelivery = p.EstimatedDelivery
+	}
+	if p.OrderCreatedAt != nil {
+		pendingModifyDB.OrderCreatedAt = p.OrderCreatedAt
+	}
+	if p.EnqueuedAt != nil {
+		pendingModifyDB.EnqueuedAt = p.EnqueuedAt
+	}
+	if p.BatchUntil != nil {
+		pendingModifyDB.BatchUntil = p.BatchUntil
+	}
+	pendingModifyDB.CashAmount = p.CashAmount
+
+	// в БД колонки NOT NULL, отсутствие требований - пустой массив
+	pendingModifyDB.RequiredSkills = make([]string, len(p.Requirements.Skills))
+	for i, skill := range p.Requirements.Skills {
+		pendingModifyDB.RequiredSkills[i] = skill.String()
+	}
+	pendingModifyDB.TransportTypes = make([]string, len(p.Requirements.TransportTypes))
+	for i, transportType := range p.Requirements.TransportTypes {
+		pendingModifyDB.TransportTypes[i] = transportType.String()
+	}
+
+	return pendingModifyDB
+}
+
+func ToDomainList(pendingDB []PendingAssignmentDB) []entities.PendingAssignment {
+	if len(pendingDB) == 0 {
+		return []entities.PendingAssignment{}
+	}
+
+	result := make([]entities.PendingAssignment, len(pendingDB))
+	for i, p := range pendingDB {
+		result[i] = *ToDomain(&p)
+	}
+
+	return result
+}
+
+// toDomainRoute маршрут считается известным, только если заданы все координаты
+func toDomainRoute(p *PendingAssignmentDB) *entities.Route {
+	if p.PickupLat == nil || p.PickupLon == nil || p.DropoffLat == nil || p.DropoffLon == nil {
+		return nil
+	}
+
+	return &entities.Route{
+		Pickup: entities.Location{
+			Latitude:  *p.PickupLat,
+			Longitude: *p.PickupLon,
+		},
+		Dropoff: entities.Location{
+			Latitude:  *p.DropoffLat,
+			Longitude: *p.DropoffLon,
+		},
+	}
+}
+
+func toDomainRequirements(p *PendingAssignmentDB) entities.OrderRequirements {
+	requirements := entities.OrderRequirements{}
+	for _, skill := range p.RequiredSkills {
+		requirements.Skills = append(requirements.Skills, entities.CourierSkill(skill))
+	}
+	for _, transportType := range p.TransportTypes {
+		requirements.TransportTypes = append(requirements.TransportTypes, entities.CourierTransportType(transportType))
+	}
+
+	return requirements
+}
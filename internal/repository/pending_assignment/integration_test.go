@@ -0,0 +1,558 @@
+//go:build integration
+
+package pending_assignment_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"service/internal/entities"
+	"service/internal/repository/integration_test"
+	"service/internal/repository/pending_assignment"
+	service "service/internal/service/delivery"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Enqueue_Success(t *testing.T) {
+	integration_test.SetupDB(t, "")
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := pending_assignment.New(q)
+	ctx := context.Background()
+
+	t.Run("Успешная постановка заказа в очередь", func(t *testing.T) {
+		actual, err := repo.Enqueue(ctx, entities.PendingAssignmentModify{
+			OrderID:    pointer.To("order-1"),
+			Priority:   pointer.To(int32(0)),
+			EnqueuedAt: pointer.To(time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, "order-1", actual.OrderID)
+		assert.Equal(t, int32(0), actual.Priority)
+		assert.WithinDuration(t, time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC), actual.EnqueuedAt, time.Second)
+	})
+}
+
+func TestRepository_Enqueue_Repeated(t *testing.T) {
+	setupSql := `
+		INSERT INTO pending_assignments (order_id, priority, enqueued_at)
+		VALUES ('order-1', 0, '2025-01-15 12:00:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := pending_assignment.New(q)
+	ctx := context.Background()
+
+	t.Run("Повторная постановка сохраняет время ожидания и повышает приоритет", func(t *testing.T) {
+		actual, err := repo.Enqueue(ctx, entities.PendingAssignmentModify{
+			OrderID:    pointer.To("order-1"),
+			Priority:   pointer.To(int32(5)),
+			EnqueuedAt: pointer.To(time.Date(2025, 1, 15, 12, 30, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, int32(5), actual.Priority)
+		assert.WithinDuration(t, time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC), actual.EnqueuedAt, time.Second)
+
+		var count int
+		err = q.QueryRow(ctx, "SELECT COUNT(*) FROM pending_assignments").Scan(&count)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
+
+func TestRepository_Enqueue_RaisedPriorityStopsBatching(t *testing.T) {
+	setupSql := `
+		INSERT INTO pending_assignments (order_id, priority, restaurant_id, enqueued_at, batch_until)
+		VALUES
+			('order-1', 0, 'restaurant-1', '2025-01-15 12:00:00', '2025-01-15 12:03:00'),
+			('order-2', 0, 'restaurant-1', '2025-01-15 12:00:00', '2025-01-15 12:03:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := pending_assignment.New(q)
+	ctx := context.Background()
+
+	t.Run("Заказ с повышенным приоритетом больше не ждет группировки", func(t *testing.T) {
+		actual, err := repo.Enqueue(ctx, entities.PendingAssignmentModify{
+			OrderID:    pointer.To("order-1"),
+			Priority:   pointer.To(entities.HighPendingPriority),
+			EnqueuedAt: pointer.To(time.Date(2025, 1, 15, 12, 1, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entities.HighPendingPriority, actual.Priority)
+		assert.Nil(t, actual.BatchUntil)
+	})
+
+	t.Run("Повторная постановка с тем же приоритетом сохраняет окно группировки", func(t *testing.T) {
+		actual, err := repo.Enqueue(ctx, entities.PendingAssignmentModify{
+			OrderID:    pointer.To("order-2"),
+			Priority:   pointer.To(entities.DefaultPendingPriority),
+			EnqueuedAt: pointer.To(time.Date(2025, 1, 15, 12, 1, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, actual.BatchUntil)
+	})
+}
+
+func TestRepository_Enqueue_CashAmount(t *testing.T) {
+	setupSql := `
+		INSERT INTO pending_assignments (order_id, priority, cash_amount, enqueued_at)
+		VALUES ('order-1', 0, 150000, '2025-01-15 12:00:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := pending_assignment.New(q)
+	ctx := context.Background()
+
+	t.Run("Повторная постановка без наличных сохраняет сумму к получению", func(t *testing.T) {
+		actual, err := repo.Enqueue(ctx, entities.PendingAssignmentModify{
+			OrderID:    pointer.To("order-1"),
+			Priority:   pointer.To(entities.DefaultPendingPriority),
+			EnqueuedAt: pointer.To(time.Date(2025, 1, 15, 12, 1, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(150000), actual.CashAmount)
+	})
+
+	t.Run("Сумма к получению читается из очереди", func(t *testing.T) {
+		_, err := repo.Enqueue(ctx, entities.PendingAssignmentModify{
+			OrderID:    pointer.To("order-2"),
+			Priority:   pointer.To(entities.DefaultPendingPriority),
+			CashAmount: 90000,
+			EnqueuedAt: pointer.To(time.Date(2025, 1, 15, 12, 2, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+
+		actual, err := repo.GetByOrderIDForUpdate(ctx, "order-2")
+		require.NoError(t, err)
+		assert.Equal(t, int64(90000), actual.CashAmount)
+	})
+}
+
+func TestRepository_GetNextForUpdate_Order(t *testing.T) {
+	setupSql := `
+		INSERT INTO pending_assignments (order_id, priority, enqueued_at)
+		VALUES
+			('order-old', 0, '2025-01-15 11:00:00'),
+			('order-new', 0, '2025-01-15 12:00:00'),
+			('order-vip', 10, '2025-01-15 12:30:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := pending_assignment.New(q)
+	ctx := context.Background()
+
+	t.Run("Сначала выдается приоритетный заказ, затем самый старый", func(t *testing.T) {
+		actual, err := repo.GetNextForUpdate(ctx, time.Now().UTC(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "order-vip", actual.OrderID)
+
+		require.NoError(t, repo.Delete(ctx, "order-vip"))
+
+		actual, err = repo.GetNextForUpdate(ctx, time.Now().UTC(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "order-old", actual.OrderID)
+	})
+
+	t.Run("Пропущенные в проходе заказы не выдаются", func(t *testing.T) {
+		actual, err := repo.GetNextForUpdate(ctx, time.Now().UTC(), []string{"order-old"})
+		require.NoError(t, err)
+		assert.Equal(t, "order-new", actual.OrderID)
+
+		_, err = repo.GetNextForUpdate(ctx, time.Now().UTC(), []string{"order-old", "order-new"})
+		assert.ErrorIs(t, err, service.ErrPendingQueueEmpty)
+	})
+}
+
+func TestRepository_GetNextForUpdate_SkipsOffered(t *testing.T) {
+	setupSql := `
+		INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+		VALUES (1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+		INSERT INTO pending_assignments (order_id, priority, enqueued_at)
+		VALUES
+			('order-offered', 0, '2025-01-15 11:00:00'),
+			('order-waiting', 0, '2025-01-15 12:00:00');
+
+		INSERT INTO delivery_offers (order_id, courier_id, status, offered_at, expires_at)
+		VALUES ('order-offered', 1, 'pending', '2025-01-15 12:00:00', '2025-01-15 12:01:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := pending_assignment.New(q)
+	ctx := context.Background()
+
+	t.Run("Заказ, ожидающий ответа курьера, пропускается", func(t *testing.T) {
+		actual, err := repo.GetNextForUpdate(ctx, time.Now().UTC(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "order-waiting", actual.OrderID)
+	})
+
+	t.Run("Заказ с открытым предложением остается в очереди", func(t *testing.T) {
+		actual, err := repo.GetByOrderIDForUpdate(ctx, "order-offered")
+		require.NoError(t, err)
+		assert.Equal(t, "order-offered", actual.OrderID)
+	})
+
+	t.Run("Заказа нет в очереди", func(t *testing.T) {
+		actual, err := repo.GetByOrderIDForUpdate(ctx, "unknown-order")
+		require.Error(t, err)
+		require.Nil(t, actual)
+		assert.ErrorIs(t, err, service.ErrPendingAssignmentNotFound)
+	})
+}
+
+func TestRepository_GetNextForUpdate_SkipsBatching(t *testing.T) {
+	setupSql := `
+		INSERT INTO pending_assignments (order_id, priority, restaurant_id, enqueued_at, batch_until)
+		VALUES
+			('order-batching', 0, 'restaurant-1', '2025-01-15 11:00:00', '2025-01-15 11:05:00'),
+			('order-waiting', 0, NULL, '2025-01-15 11:01:00', NULL);
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := pending_assignment.New(q)
+	ctx := context.Background()
+
+	t.Run("Заказ в окне группировки пропускается", func(t *testing.T) {
+		actual, err := repo.GetNextForUpdate(ctx, time.Date(2025, 1, 15, 11, 2, 0, 0, time.UTC), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "order-waiting", actual.OrderID)
+	})
+
+	t.Run("После окна группировки заказ выдается в порядке очереди", func(t *testing.T) {
+		actual, err := repo.GetNextForUpdate(ctx, time.Date(2025, 1, 15, 11, 5, 0, 0, time.UTC), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "order-batching", actual.OrderID)
+		require.NotNil(t, actual.BatchUntil)
+		assert.WithinDuration(t, time.Date(2025, 1, 15, 11, 5, 0, 0, time.UTC), *actual.BatchUntil, time.Second)
+	})
+}
+
+func TestRepository_GetBatchForUpdate(t *testing.T) {
+	setupSql := `
+		INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+		VALUES (1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+		INSERT INTO pending_assignments (order_id, priority, restaurant_id, enqueued_at, batch_until)
+		VALUES
+			('order-head', 0, 'restaurant-1', '2025-01-15 11:00:00', '2025-01-15 11:05:00'),
+			('order-second', 0, 'restaurant-1', '2025-01-15 11:02:00', '2025-01-15 11:07:00'),
+			('order-first', 0, 'restaurant-1', '2025-01-15 11:01:00', '2025-01-15 11:06:00'),
+			('order-third', 0, 'restaurant-1', '2025-01-15 11:03:00', '2025-01-15 11:08:00'),
+			('order-offered', 0, 'restaurant-1', '2025-01-15 11:00:30', '2025-01-15 11:05:30'),
+			('order-not-batched', 0, 'restaurant-1', '2025-01-15 11:00:40', NULL),
+			('order-other', 0, 'restaurant-2', '2025-01-15 11:00:50', '2025-01-15 11:05:50');
+
+		INSERT INTO delivery_offers (order_id, courier_id, status, offered_at, expires_at)
+		VALUES ('order-offered', 1, 'pending', '2025-01-15 12:00:00', '2025-01-15 12:01:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := pending_assignment.New(q)
+	ctx := context.Background()
+
+	t.Run("Ожидающие группировки заказы ресторана в порядке постановки", func(t *testing.T) {
+		actual, err := repo.GetBatchForUpdate(ctx, "restaurant-1", "order-head", 2)
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+
+		assert.Equal(t, "order-first", actual[0].OrderID)
+		assert.Equal(t, "order-second", actual[1].OrderID)
+	})
+
+	t.Run("Других заказов ресторана нет", func(t *testing.T) {
+		actual, err := repo.GetBatchForUpdate(ctx, "restaurant-2", "order-other", 2)
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
+
+func TestRepository_GetReadyForDispatch(t *testing.T) {
+	setupSql := `
+		INSERT INTO couriers (id, name, phone, status, transport_type, created_at, updated_at)
+		VALUES (1, 'Courier 1', '+79991112233', 'available', 'on_foot', '2025-01-15 11:00:00', '2025-01-15 11:00:00');
+
+		INSERT INTO pending_assignments (order_id, priority, restaurant_id, enqueued_at, batch_until)
+		VALUES
+			('order-old', 0, NULL, '2025-01-15 11:00:00', NULL),
+			('order-new', 0, NULL, '2025-01-15 11:02:00', NULL),
+			('order-vip', 10, NULL, '2025-01-15 11:03:00', NULL),
+			('order-batching', 0, 'restaurant-1', '2025-01-15 10:00:00', '2025-01-15 11:10:00'),
+			('order-offered', 0, NULL, '2025-01-15 10:00:00', NULL);
+
+		INSERT INTO delivery_offers (order_id, courier_id, status, offered_at, expires_at)
+		VALUES ('order-offered', 1, 'pending', '2025-01-15 12:00:00', '2025-01-15 12:01:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := pending_assignment.New(q)
+	ctx := context.Background()
+
+	t.Run("Заказы, которые можно назначать, в порядке разбора очереди", func(t *testing.T) {
+		actual, err := repo.GetReadyForDispatch(ctx, time.Date(2025, 1, 15, 11, 5, 0, 0, time.UTC), 10)
+		require.NoError(t, err)
+		require.Len(t, actual, 3)
+
+		assert.Equal(t, "order-vip", actual[0].OrderID)
+		assert.Equal(t, "order-old", actual[1].OrderID)
+		assert.Equal(t, "order-new", actual[2].OrderID)
+	})
+
+	t.Run("Количество заказов ограничено", func(t *testing.T) {
+		actual, err := repo.GetReadyForDispatch(ctx, time.Date(2025, 1, 15, 11, 5, 0, 0, time.UTC), 1)
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Equal(t, "order-vip", actual[0].OrderID)
+	})
+}
+
+func TestRepository_GetNextForUpdate_Empty(t *testing.T) {
+	integration_test.SetupDB(t, "")
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := pending_assignment.New(q)
+	ctx := context.Background()
+
+	t.Run("Пустая очередь", func(t *testing.T) {
+		actual, err := repo.GetNextForUpdate(ctx, time.Now().UTC(), nil)
+		require.Error(t, err)
+		require.Nil(t, actual)
+		assert.ErrorIs(t, err, service.ErrPendingQueueEmpty)
+	})
+}
+
+func TestRepository_Delete_NotFound(t *testing.T) {
+	integration_test.SetupDB(t, "")
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := pending_assignment.New(q)
+	ctx := context.Background()
+
+	t.Run("Ошибка при удалении отсутствующего заказа из очереди", func(t *testing.T) {
+		err := repo.Delete(ctx, "unknown-order")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, service.ErrPendingAssignmentNotFound)
+	})
+}
+
+func TestRepository_GetAll_Success(t *testing.T) {
+	setupSql := `
+		INSERT INTO pending_assignments (order_id, priority, enqueued_at)
+		VALUES
+			('order-1', 0, '2025-01-15 11:00:00'),
+			('order-2', 1, '2025-01-15 12:00:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := pending_assignment.New(q)
+	ctx := context.Background()
+
+	t.Run("Успешное получение очереди в порядке разбора", func(t *testing.T) {
+		actual, err := repo.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+
+		assert.Equal(t, "order-2", actual[0].OrderID)
+		assert.Equal(t, "order-1", actual[1].OrderID)
+	})
+}
+
+func TestRepository_Enqueue_WithRoute(t *testing.T) {
+	integration_test.SetupDB(t, "")
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := pending_assignment.New(q)
+	ctx := context.Background()
+
+	route := &entities.Route{
+		Pickup:  entities.Location{Latitude: 55.7558, Longitude: 37.6173},
+		Dropoff: entities.Location{Latitude: 55.7602, Longitude: 37.6186},
+	}
+
+	t.Run("Маршрут сохраняется вместе с заказом и не теряется при повторной постановке", func(t *testing.T) {
+		_, err := repo.Enqueue(ctx, entities.PendingAssignmentModify{
+			OrderID:    pointer.To("order-1"),
+			Priority:   pointer.To(int32(0)),
+			Route:      route,
+			EnqueuedAt: pointer.To(time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+
+		actual, err := repo.Enqueue(ctx, entities.PendingAssignmentModify{
+			OrderID:    pointer.To("order-1"),
+			Priority:   pointer.To(int32(0)),
+			EnqueuedAt: pointer.To(time.Date(2025, 1, 15, 12, 30, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, actual.Route)
+		assert.Equal(t, *route, *actual.Route)
+
+		next, err := repo.GetNextForUpdate(ctx, time.Now().UTC(), nil)
+		require.NoError(t, err)
+		require.NotNil(t, next.Route)
+		assert.Equal(t, *route, *next.Route)
+	})
+}
+
+func TestRepository_Enqueue_WithOrderDetails(t *testing.T) {
+	integration_test.SetupDB(t, "")
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := pending_assignment.New(q)
+	ctx := context.Background()
+
+	address := &entities.Address{Street: "Тверская", House: "1", Floor: "3"}
+	estimatedDelivery := time.Date(2025, 1, 15, 12, 40, 0, 0, time.UTC)
+
+	t.Run("Данные заказа сохраняются в очереди и не теряются при повторной постановке", func(t *testing.T) {
+		_, err := repo.Enqueue(ctx, entities.PendingAssignmentModify{
+			OrderID:           pointer.To("order-1"),
+			Priority:          pointer.To(int32(0)),
+			RestaurantID:      pointer.To("restaurant-7"),
+			Address:           address,
+			EstimatedDelivery: &estimatedDelivery,
+			EnqueuedAt:        pointer.To(time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+
+		_, err = repo.Enqueue(ctx, entities.PendingAssignmentModify{
+			OrderID:    pointer.To("order-1"),
+			Priority:   pointer.To(int32(0)),
+			EnqueuedAt: pointer.To(time.Date(2025, 1, 15, 12, 30, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+
+		next, err := repo.GetNextForUpdate(ctx, time.Now().UTC(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "restaurant-7", next.RestaurantID)
+		require.NotNil(t, next.Address)
+		assert.Equal(t, *address, *next.Address)
+		require.NotNil(t, next.EstimatedDelivery)
+		assert.WithinDuration(t, estimatedDelivery, *next.EstimatedDelivery, time.Second)
+	})
+}
+
+func TestRepository_Enqueue_OrderCreatedAt(t *testing.T) {
+	integration_test.SetupDB(t, "")
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := pending_assignment.New(q)
+	ctx := context.Background()
+
+	orderCreatedAt := time.Date(2025, 1, 15, 11, 55, 0, 0, time.UTC)
+
+	t.Run("Время создания заказа сохраняется и не затирается повторной постановкой без него", func(t *testing.T) {
+		_, err := repo.Enqueue(ctx, entities.PendingAssignmentModify{
+			OrderID:        pointer.To("order-1"),
+			Priority:       pointer.To(int32(0)),
+			OrderCreatedAt: &orderCreatedAt,
+			EnqueuedAt:     pointer.To(time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+
+		actual, err := repo.Enqueue(ctx, entities.PendingAssignmentModify{
+			OrderID:    pointer.To("order-1"),
+			Priority:   pointer.To(int32(0)),
+			EnqueuedAt: pointer.To(time.Date(2025, 1, 15, 12, 5, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, actual.OrderCreatedAt)
+		assert.WithinDuration(t, orderCreatedAt, *actual.OrderCreatedAt, time.Second)
+
+		next, err := repo.GetNextForUpdate(ctx, time.Now().UTC(), nil)
+		require.NoError(t, err)
+		require.NotNil(t, next.OrderCreatedAt)
+		assert.WithinDuration(t, orderCreatedAt, *next.OrderCreatedAt, time.Second)
+	})
+}
+
+func TestRepository_Enqueue_Requirements(t *testing.T) {
+	integration_test.SetupDB(t, "")
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := pending_assignment.New(q)
+	ctx := context.Background()
+
+	requirements := entities.OrderRequirements{
+		Skills:         []entities.CourierSkill{entities.SkillAgeVerified, entities.SkillThermalBag},
+		TransportTypes: []entities.CourierTransportType{entities.Scooter, entities.Car},
+	}
+
+	t.Run("Требования заказа сохраняются в очереди", func(t *testing.T) {
+		actual, err := repo.Enqueue(ctx, entities.PendingAssignmentModify{
+			OrderID:      pointer.To("order-1"),
+			Priority:     pointer.To(int32(0)),
+			Requirements: requirements,
+			EnqueuedAt:   pointer.To(time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, requirements, actual.Requirements)
+
+		next, err := repo.GetNextForUpdate(ctx, time.Now().UTC(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, requirements, next.Requirements)
+	})
+
+	t.Run("Повторная постановка без требований их не стирает", func(t *testing.T) {
+		actual, err := repo.Enqueue(ctx, entities.PendingAssignmentModify{
+			OrderID:    pointer.To("order-1"),
+			Priority:   pointer.To(int32(0)),
+			EnqueuedAt: pointer.To(time.Date(2025, 1, 15, 12, 30, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, requirements, actual.Requirements)
+	})
+
+	t.Run("Заказ без требований", func(t *testing.T) {
+		actual, err := repo.Enqueue(ctx, entities.PendingAssignmentModify{
+			OrderID:    pointer.To("order-2"),
+			Priority:   pointer.To(int32(0)),
+			EnqueuedAt: pointer.To(time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+		assert.True(t, actual.Requirements.IsEmpty())
+	})
+}
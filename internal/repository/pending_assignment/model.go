@@ -0,0 +1,45 @@
+package pending_assignment
+
+import (
+	"time"
+
+	"service/internal/repository"
+)
+
+type PendingAssignmentDB struct {
+	ID                int64
+	OrderID           string
+	Priority          int32
+	PickupLat         *float64
+	PickupLon         *float64
+	DropoffLat        *float64
+	DropoffLon        *float64
+	RestaurantID      *string
+	Address           *repository.AddressDB
+	EstimatedDelivery *time.Time
+	OrderCreatedAt    *time.Time
+	RequiredSkills    []string
+	TransportTypes    []string
+	CashAmount        int64
+	EnqueuedAt        time.Time
+	BatchUntil        *time.Time
+}
+
+type PendingAssignmentModifyDB struct {
+	ID                *int64
+	OrderID           *string
+	Priority          *int32
+	PickupLat         *float64
+	PickupLon         *float64
+	DropoffLat        *float64
+	DropoffLon        *float64
+	RestaurantID      *string
+	Address           *repository.AddressDB
+	EstimatedDelivery *time.Time
+	OrderCreatedAt    *time.Time
+	RequiredSkills    []string
+	TransportTypes    []string
+	CashAmount        int64
+	EnqueuedAt        *time.Time
+	BatchUntil        *time.Time
+}
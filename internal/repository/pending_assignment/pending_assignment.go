@@ -0,0 +1,389 @@
+package pending_assignment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"service/internal/entities"
+	"service/internal/service/delivery"
+)
+
+type Repository struct {
+	querier Querier
+}
+
+func New(querier Querier) *Repository {
+	return &Repository{
+		querier: querier,
+	}
+}
+
+// Enqueue ставит заказ в очередь. Повторная постановка того же заказа не сбрасывает
+// время ожидания, а только повышает приоритет, если новый выше. Заказ с повышенным приоритетом
+// больше не ждет группировки. Наличные к получению при повторной постановке без них сохраняются.
+func (r *Repository) Enqueue(ctx context.Context, pendingModify entities.PendingAssignmentModify) (*entities.PendingAssignment, error) {
+	pendingModifyDB := FromDomainModify(&pendingModify)
+
+	query := `
+		INSERT INTO pending_assignments (
+			order_id, priority, pickup_lat, pickup_lon, dropoff_lat, dropoff_lon,
+			restaurant_id, address, estimated_delivery, order_created_at,
+			required_skills, allowed_transport_types, cash_amount, enqueued_at, batch_until
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (order_id) DO UPDATE
+			SET priority = GREATEST(pending_assignments.priority, EXCLUDED.priority),
+				pickup_lat = COALESCE(EXCLUDED.pickup_lat, pending_assignments.pickup_lat),
+				pickup_lon = COALESCE(EXCLUDED.pickup_lon, pending_assignments.pickup_lon),
+				dropoff_lat = COALESCE(EXCLUDED.dropoff_lat, pending_assignments.dropoff_lat),
+				dropoff_lon = COALESCE(EXCLUDED.dropoff_lon, pending_assignments.dropoff_lon),
+				restaurant_id = COALESCE(EXCLUDED.restaurant_id, pending_assignments.restaurant_id),
+				address = COALESCE(EXCLUDED.address, pending_assignments.address),
+				estimated_delivery = COALESCE(EXCLUDED.estimated_delivery, pending_assignments.estimated_delivery),
+				order_created_at = COALESCE(EXCLUDED.order_created_at, pending_assignments.order_created_at),
+				required_skills = CASE WHEN cardinality(EXCLUDED.required_skills) > 0
+					THEN EXCLUDED.required_skills ELSE pending_assignments.required_skills END,
+				allowed_transport_types = CASE WHEN cardinality(EXCLUDED.allowed_transport_types) > 0
+					THEN EXCLUDED.allowed_transport_types ELSE pending_assignments.allowed_transport_types END,
+				cash_amount = CASE WHEN EXCLUDED.cash_amount > 0
+					THEN EXCLUDED.cash_amount ELSE pending_assignments.cash_amount END,
+				batch_until = CASE WHEN EXCLUDED.priority > pending_assignments.priority
+					THEN NULL ELSE pending_assignments.batch_until END
+		RETURNING id, order_id, priority, pickup_lat, pickup_lon, dropoff_lat, dropoff_lon,
+			restaurant_id, address, estimated_delivery, order_created_at,
+			required_skills, allowed_transport_types, cash_amount, enqueued_at, batch_until
+	`
+
+	var pendingDB PendingAssignmentDB
+	err := r.querier.QueryRow(
+		ctx,
+		query,
+		pendingModifyDB.OrderID,
+		pendingModifyDB.Priority,
+		pendingModifyDB.PickupLat,
+		pendingModifyDB.PickupLon,
+		pendingModifyDB.DropoffLat,
+		pendingModifyDB.DropoffLon,
+		pendingModifyDB.RestaurantID,
+		pendingModifyDB.Address,
+		pendingModifyDB.EstimatedDelivery,
+		pendingModifyDB.OrderCreatedAt,
+		pendingModifyDB.RequiredSkills,
+		pendingModifyDB.TransportTypes,
+		pendingModifyDB.CashAmount,
+		pendingModifyDB.EnqueuedAt,
+		pendingModifyDB.BatchUntil,
+	).Scan(
+		&pendingDB.ID,
+		&pendingDB.OrderID,
+		&pendingDB.Priority,
+		&pendingDB.PickupLat,
+		&pendingDB.PickupLon,
+		&pendingDB.DropoffLat,
+		&pendingDB.DropoffLon,
+		&pendingDB.RestaurantID,
+		&pendingDB.Address,
+		&pendingDB.EstimatedDelivery,
+		&pendingDB.OrderCreatedAt,
+		&pendingDB.RequiredSkills,
+		&pendingDB.TransportTypes,
+		&pendingDB.CashAmount,
+		&pendingDB.EnqueuedAt,
+		&pendingDB.BatchUntil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected pending assignment repository enqueue error: %w", err)
+	}
+
+	return ToDomain(&pendingDB), nil
+}
+
+// GetNextForUpdate блокирует голову очереди до конца транзакции.
+// SKIP LOCKED позволяет нескольким инстансам разбирать очередь параллельно.
+// Заказы, которые сейчас предложены курьеру, пропускаются до его ответа,
+// заказы, ожидающие группировки, - до истечения batch_until, заказы из skipOrderIDs - всегда
+func (r *Repository) GetNextForUpdate(
+	ctx context.Context,
+	now time.Time,
+	skipOrderIDs []string,
+) (*entities.PendingAssignment, error) {
+	query := `
+		SELECT id, order_id, priority, pickup_lat, pickup_lon, dropoff_lat, dropoff_lon,
+			restaurant_id, address, estimated_delivery, order_created_at,
+			required_skills, allowed_transport_types, cash_amount, enqueued_at, batch_until
+		FROM pending_assignments pa
+		WHERE NOT EXISTS (
+			SELECT 1 FROM delivery_offers o WHERE o.order_id = pa.order_id AND o.status = 'pending'
+		)
+			AND (pa.batch_until IS NULL OR pa.batch_until <= $1)
+			AND pa.order_id <> ALL($2)
+		ORDER BY priority DESC, enqueued_at ASC
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	if skipOrderIDs == nil {
+		skipOrderIDs = []string{}
+	}
+
+	var pendingDB PendingAssignmentDB
+	err := r.querier.QueryRow(ctx, query, now, skipOrderIDs).Scan(
+		&pendingDB.ID,
+		&pendingDB.OrderID,
+		&pendingDB.Priority,
+		&pendingDB.PickupLat,
+		&pendingDB.PickupLon,
+		&pendingDB.DropoffLat,
+		&pendingDB.DropoffLon,
+		&pendingDB.RestaurantID,
+		&pendingDB.Address,
+		&pendingDB.EstimatedDelivery,
+		&pendingDB.OrderCreatedAt,
+		&pendingDB.RequiredSkills,
+		&pendingDB.TransportTypes,
+		&pendingDB.CashAmount,
+		&pendingDB.EnqueuedAt,
+		&pendingDB.BatchUntil,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, delivery.ErrPendingQueueEmpty
+		}
+		return nil, fmt.Errorf("unexpected pending assignment repository get next error: %w", err)
+	}
+
+	return ToDomain(&pendingDB), nil
+}
+
+// GetByOrderIDForUpdate блокирует запись заказа в очереди до конца транзакции
+func (r *Repository) GetByOrderIDForUpdate(ctx context.Context, orderID string) (*entities.PendingAssignment, error) {
+	query := `
+		SELECT id, order_id, priority, pickup_lat, pickup_lon, dropoff_lat, dropoff_lon,
+			restaurant_id, address, estimated_delivery, order_created_at,
+			required_skills, allowed_transport_types, cash_amount, enqueued_at, batch_until
+		FROM pending_assignments
+		WHERE order_id = $1
+		FOR UPDATE
+	`
+
+	var pendingDB PendingAssignmentDB
+	err := r.querier.QueryRow(ctx, query, orderID).Scan(
+		&pendingDB.ID,
+		&pendingDB.OrderID,
+		&pendingDB.Priority,
+		&pendingDB.PickupLat,
+		&pendingDB.PickupLon,
+		&pendingDB.DropoffLat,
+		&pendingDB.DropoffLon,
+		&pendingDB.RestaurantID,
+		&pendingDB.Address,
+		&pendingDB.EstimatedDelivery,
+		&pendingDB.OrderCreatedAt,
+		&pendingDB.RequiredSkills,
+		&pendingDB.TransportTypes,
+		&pendingDB.CashAmount,
+		&pendingDB.EnqueuedAt,
+		&pendingDB.BatchUntil,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, delivery.ErrPendingAssignmentNotFound
+		}
+		return nil, fmt.Errorf("unexpected pending assignment repository get by order id error: %w", err)
+	}
+
+	return ToDomain(&pendingDB), nil
+}
+
+// GetReadyForDispatch блокирует до limit записей, которые можно назначать прямо сейчас, в порядке разбора очереди:
+// без открытого предложения курьеру и вне окна группировки. Заблокированные другой транзакцией записи пропускаются
+func (r *Repository) GetReadyForDispatch(ctx context.Context, now time.Time, limit int) ([]entities.PendingAssignment, error) {
+	query := `
+		SELECT id, order_id, priority, pickup_lat, pickup_lon, dropoff_lat, dropoff_lon,
+			restaurant_id, address, estimated_delivery, order_created_at,
+			required_skills, allowed_transport_types, cash_amount, enqueued_at, batch_until
+		FROM pending_assignments pa
+		WHERE NOT EXISTS (
+			SELECT 1 FROM delivery_offers o WHERE o.order_id = pa.order_id AND o.status = 'pending'
+		)
+			AND (pa.batch_until IS NULL OR pa.batch_until <= $1)
+		ORDER BY priority DESC, enqueued_at ASC
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := r.querier.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected pending assignment repository get ready for dispatch error: %w", err)
+	}
+	defer rows.Close()
+
+	pendingModels := make([]PendingAssignmentDB, 0, limit)
+	for rows.Next() {
+		var pendingDB PendingAssignmentDB
+		err := rows.Scan(
+			&pendingDB.ID,
+			&pendingDB.OrderID,
+			&pendingDB.Priority,
+			&pendingDB.PickupLat,
+			&pendingDB.PickupLon,
+			&pendingDB.DropoffLat,
+			&pendingDB.DropoffLon,
+			&pendingDB.RestaurantID,
+			&pendingDB.Address,
+			&pendingDB.EstimatedDelivery,
+			&pendingDB.OrderCreatedAt,
+			&pendingDB.RequiredSkills,
+			&pendingDB.TransportTypes,
+			&pendingDB.CashAmount,
+			&pendingDB.EnqueuedAt,
+			&pendingDB.BatchUntil,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected pending assignment repository get ready for dispatch error: %w", err)
+		}
+		pendingModels = append(pendingModels, pendingDB)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected pending assignment repository get ready for dispatch error: %w", err)
+	}
+
+	return ToDomainList(pendingModels), nil
+}
+
+// GetBatchForUpdate блокирует ожидающие группировки заказы ресторана, кроме excludeOrderID, в порядке поступления.
+// Заказы, время ожидания которых еще не истекло, тоже выдаются: они присоединяются к группе раньше срока
+func (r *Repository) GetBatchForUpdate(
+	ctx context.Context,
+	restaurantID string,
+	excludeOrderID string,
+	limit int,
+) ([]entities.PendingAssignment, error) {
+	query := `
+		SELECT id, order_id, priority, pickup_lat, pickup_lon, dropoff_lat, dropoff_lon,
+			restaurant_id, address, estimated_delivery, order_created_at,
+			required_skills, allowed_transport_types, cash_amount, enqueued_at, batch_until
+		FROM pending_assignments pa
+		WHERE pa.restaurant_id = $1
+			AND pa.order_id <> $2
+			AND pa.batch_until IS NOT NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM delivery_offers o WHERE o.order_id = pa.order_id AND o.status = 'pending'
+			)
+		ORDER BY enqueued_at ASC
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := r.querier.Query(ctx, query, restaurantID, excludeOrderID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected pending assignment repository get batch error: %w", err)
+	}
+	defer rows.Close()
+
+	pendingModels := make([]PendingAssignmentDB, 0, limit)
+	for rows.Next() {
+		var pendingDB PendingAssignmentDB
+		err := rows.Scan(
+			&pendingDB.ID,
+			&pendingDB.OrderID,
+			&pendingDB.Priority,
+			&pendingDB.PickupLat,
+			&pendingDB.PickupLon,
+			&pendingDB.DropoffLat,
+			&pendingDB.DropoffLon,
+			&pendingDB.RestaurantID,
+			&pendingDB.Address,
+			&pendingDB.EstimatedDelivery,
+			&pendingDB.OrderCreatedAt,
+			&pendingDB.RequiredSkills,
+			&pendingDB.TransportTypes,
+			&pendingDB.CashAmount,
+			&pendingDB.EnqueuedAt,
+			&pendingDB.BatchUntil,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected pending assignment repository get batch error: %w", err)
+		}
+		pendingModels = append(pendingModels, pendingDB)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected pending assignment repository get batch error: %w", err)
+	}
+
+	return ToDomainList(pendingModels), nil
+}
+
+func (r *Repository) Delete(ctx context.Context, orderID string) error {
+	query := `
+		DELETE FROM pending_assignments WHERE order_id = $1
+	`
+
+	result, err := r.querier.Exec(ctx, query, orderID)
+	if err != nil {
+		return fmt.Errorf("unexpected pending assignment repository delete error: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return delivery.ErrPendingAssignmentNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) GetAll(ctx context.Context) ([]entities.PendingAssignment, error) {
+	query := `
+		SELECT id, order_id, priority, pickup_lat, pickup_lon, dropoff_lat, dropoff_lon,
+			restaurant_id, address, estimated_delivery, order_created_at,
+			required_skills, allowed_transport_types, cash_amount, enqueued_at, batch_until
+		FROM pending_assignments
+		ORDER BY priority DESC, enqueued_at ASC
+	`
+
+	rows, err := r.querier.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected pending assignment repository getall error: %w", err)
+	}
+	defer rows.Close()
+
+	pendingModels := make([]PendingAssignmentDB, 0, 8)
+	for rows.Next() {
+		var pendingDB PendingAssignmentDB
+		err := rows.Scan(
+			&pendingDB.ID,
+			&pendingDB.OrderID,
+			&pendingDB.Priority,
+			&pendingDB.PickupLat,
+			&pendingDB.PickupLon,
+			&pendingDB.DropoffLat,
+			&pendingDB.DropoffLon,
+			&pendingDB.RestaurantID,
+			&pendingDB.Address,
+			&pendingDB.EstimatedDelivery,
+			&pendingDB.OrderCreatedAt,
+			&pendingDB.RequiredSkills,
+			&pendingDB.TransportTypes,
+			&pendingDB.CashAmount,
+			&pendingDB.EnqueuedAt,
+			&pendingDB.BatchUntil,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected pending assignment repository getall error: %w", err)
+		}
+		pendingModels = append(pendingModels, pendingDB)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected pending assignment repository getall error: %w", err)
+	}
+
+	return ToDomainList(pendingModels), nil
+}
@@ -0,0 +1,117 @@
+package scheduled_delivery
+
+import (
+	"service/internal/entities"
+	"service/internal/repository"
+)
+
+func ToDomain(s *ScheduledDeliveryDB) *entities.ScheduledDelivery {
+	if s == nil {
+		return nil
+	}
+
+	scheduled := &entities.ScheduledDelivery{
+		ID:             s.ID,
+		OrderID:        s.OrderID,
+		DeliverAt:      s.DeliverAt,
+		AssignAt:       s.AssignAt,
+		Route:          toDomainRoute(s),
+		Address:        repository.AddressToDomain(s.Address),
+		OrderCreatedAt: s.OrderCreatedAt,
+		Requirements:   toDomainRequirements(s),
+		Priority:       entities.OrderPriority(s.Priority),
+		Status:         entities.ScheduledDeliveryStatus(s.Status),
+		CreatedAt:      s.CreatedAt,
+		AssignedAt:     s.AssignedAt,
+		CancelledAt:    s.CancelledAt,
+	}
+	if s.RestaurantID != nil {
+		scheduled.RestaurantID = *s.RestaurantID
+	}
+
+	return scheduled
+}
+
+func FromDomainModify(s *entities.ScheduledDeliveryModify) *ScheduledDeliveryModifyDB {
+	if s == nil {
+		return nil
+	}
+
+	scheduledModifyDB := &ScheduledDeliveryModifyDB{
+		OrderID:        s.OrderID,
+		DeliverAt:      s.DeliverAt,
+		AssignAt:       s.AssignAt,
+		Address:        repository.AddressFromDomain(s.Address),
+		OrderCreatedAt: s.OrderCreatedAt,
+		CreatedAt:      s.CreatedAt,
+	}
+	if s.Route != nil {
+		scheduledModifyDB.PickupLat = &s.Route.Pickup.Latitude
+		scheduledModifyDB.PickupLon = &s.Route.Pickup.Longitude
+		scheduledModifyDB.DropoffLat = &s.Route.Dropoff.Latitude
+		scheduledModifyDB.DropoffLon = &s.Route.Dropoff.Longitude
+	}
+	// пустой ресторан храним как NULL
+	if s.RestaurantID != nil && *s.RestaurantID != "" {
+		scheduledModifyDB.RestaurantID = s.RestaurantID
+	}
+	if s.Priority != nil {
+		priority := s.Priority.String()
+		scheduledModifyDB.Priority = &priority
+	}
+
+	// в БД колонки NOT NULL, отсутствие требований - пустой массив
+	scheduledModifyDB.RequiredSkills = make([]string, len(s.Requirements.Skills))
+	for i, skill := range s.Requirements.Skills {
+		scheduledModifyDB.RequiredSkills[i] = skill.String()
+	}
+	scheduledModifyDB.TransportTypes = make([]string, len(s.Requirements.TransportTypes))
+	for i, transportType := range s.Requirements.TransportTypes {
+		scheduledModifyDB.TransportTypes[i] = transportType.String()
+	}
+
+	return scheduledModifyDB
+}
+
+func ToDomainList(scheduledDB []ScheduledDeliveryDB) []entities.ScheduledDelivery {
+	if len(scheduledDB) == 0 {
+		return []entities.ScheduledDelivery{}
+	}
+
+	result := make([]entities.ScheduledDelivery, len(scheduledDB))
+	for i, s := range scheduledDB {
+		result[i] = *ToDomain(&s)
+	}
+
+	return result
+}
+
+// toDomainRoute маршрут считается известным, только если заданы все координаты
+func toDomainRoute(s *ScheduledDeliveryDB) *entities.Route {
+	if s.PickupLat == nil || s.PickupLon == nil || s.DropoffLat == nil || s.DropoffLon == nil {
+		return nil
+	}
+
+	return &entities.Route{
+		Pickup: entities.Location{
+			Latitude:  *s.PickupLat,
+			Longitude: *s.PickupLon,
+		},
+		Dropoff: entities.Location{
+			Latitude:  *s.DropoffLat,
+			Longitude: *s.DropoffLon,
+		},
+	}
+}
+
+func toDomainRequirements(s *ScheduledDeliveryDB) entities.OrderRequirements {
+	requirements := entities.OrderRequirements{}
+	for _, skill := range s.RequiredSkills {
+		requirements.Skills = append(requirements.Skills, entities.CourierSkill(skill))
+	}
+	for _, transportType := range s.TransportTypes {
+		requirements.TransportTypes = append(requirements.TransportTypes, entities.CourierTransportType(transportType))
+	}
+
+	return requirements
+}
@@ -0,0 +1,237 @@
+//go:build integration
+
+package scheduled_delivery_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"service/internal/entities"
+	"service/internal/repository/integration_test"
+	"service/internal/repository/scheduled_delivery"
+	service "service/internal/service/delivery"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Create(t *testing.T) {
+	integration_test.SetupDB(t, "")
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := scheduled_delivery.New(q)
+	ctx := context.Background()
+
+	modify := entities.ScheduledDeliveryModify{
+		OrderID:   pointer.To("order-1"),
+		DeliverAt: pointer.To(time.Date(2025, 1, 15, 19, 0, 0, 0, time.UTC)),
+		AssignAt:  pointer.To(time.Date(2025, 1, 15, 18, 30, 0, 0, time.UTC)),
+		Route: &entities.Route{
+			Pickup:  entities.Location{Latitude: 55.7558, Longitude: 37.6173},
+			Dropoff: entities.Location{Latitude: 55.7602, Longitude: 37.6186},
+		},
+		RestaurantID: pointer.To("restaurant-1"),
+		Address:      &entities.Address{Street: "Тверская", House: "1"},
+		Requirements: entities.OrderRequirements{
+			TransportTypes: []entities.CourierTransportType{entities.Car},
+		},
+		CreatedAt: pointer.To(time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)),
+	}
+
+	t.Run("Успешное сохранение запланированной доставки", func(t *testing.T) {
+		actual, err := repo.Create(ctx, modify)
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		assert.Equal(t, "order-1", actual.OrderID)
+		assert.WithinDuration(t, *modify.DeliverAt, actual.DeliverAt, time.Second)
+		assert.WithinDuration(t, *modify.AssignAt, actual.AssignAt, time.Second)
+		assert.Equal(t, modify.Route, actual.Route)
+		assert.Equal(t, "restaurant-1", actual.RestaurantID)
+		assert.Equal(t, modify.Address, actual.Address)
+		assert.Equal(t, modify.Requirements, actual.Requirements)
+		assert.Equal(t, entities.PriorityNormal, actual.Priority)
+		assert.Equal(t, entities.ScheduledWaiting, actual.Status)
+	})
+
+	t.Run("Заказ нельзя запланировать дважды", func(t *testing.T) {
+		actual, err := repo.Create(ctx, modify)
+		require.ErrorIs(t, err, service.ErrOrderAlreadyScheduled)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestRepository_GetDue(t *testing.T) {
+	setupSql := `
+		INSERT INTO scheduled_deliveries (order_id, deliver_at, assign_at, status, created_at)
+		VALUES
+			('order-late', '2025-01-15 19:00:00', '2025-01-15 18:40:00', 'scheduled', '2025-01-15 10:00:00'),
+			('order-early', '2025-01-15 19:00:00', '2025-01-15 18:30:00', 'scheduled', '2025-01-15 10:00:00'),
+			('order-future', '2025-01-15 21:00:00', '2025-01-15 20:30:00', 'scheduled', '2025-01-15 10:00:00'),
+			('order-cancelled', '2025-01-15 19:00:00', '2025-01-15 18:00:00', 'cancelled', '2025-01-15 10:00:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := scheduled_delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Выдаются только ожидающие доставки с наступившим временем поиска", func(t *testing.T) {
+		actual, err := repo.GetDue(ctx, time.Date(2025, 1, 15, 18, 45, 0, 0, time.UTC), time.Time{}, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+
+		assert.Equal(t, "order-early", actual[0].OrderID)
+		assert.Equal(t, "order-late", actual[1].OrderID)
+	})
+
+	t.Run("Количество ограничено", func(t *testing.T) {
+		actual, err := repo.GetDue(ctx, time.Date(2025, 1, 15, 18, 45, 0, 0, time.UTC), time.Time{}, 0, 1)
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Equal(t, "order-early", actual[0].OrderID)
+	})
+
+	t.Run("Следующая страница начинается после курсора", func(t *testing.T) {
+		first, err := repo.GetDue(ctx, time.Date(2025, 1, 15, 18, 45, 0, 0, time.UTC), time.Time{}, 0, 1)
+		require.NoError(t, err)
+		require.Len(t, first, 1)
+
+		actual, err := repo.GetDue(ctx, time.Date(2025, 1, 15, 18, 45, 0, 0, time.UTC), first[0].AssignAt, first[0].ID, 1)
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Equal(t, "order-late", actual[0].OrderID)
+
+		actual, err = repo.GetDue(ctx, time.Date(2025, 1, 15, 18, 45, 0, 0, time.UTC), actual[0].AssignAt, actual[0].ID, 1)
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
+
+func TestRepository_GetWaiting(t *testing.T) {
+	setupSql := `
+		INSERT INTO scheduled_deliveries (order_id, deliver_at, assign_at, status, created_at)
+		VALUES
+			('order-2', '2025-01-15 21:00:00', '2025-01-15 20:30:00', 'scheduled', '2025-01-15 10:00:00'),
+			('order-1', '2025-01-15 19:00:00', '2025-01-15 18:30:00', 'scheduled', '2025-01-15 10:00:00'),
+			('order-3', '2025-01-15 18:00:00', '2025-01-15 17:30:00', 'assigned', '2025-01-15 10:00:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := scheduled_delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Ожидающие доставки по времени доставки", func(t *testing.T) {
+		actual, err := repo.GetWaiting(ctx)
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+
+		assert.Equal(t, "order-1", actual[0].OrderID)
+		assert.Equal(t, "order-2", actual[1].OrderID)
+	})
+}
+
+func TestRepository_ExistsWaitingByOrderID(t *testing.T) {
+	setupSql := `
+		INSERT INTO scheduled_deliveries (order_id, deliver_at, assign_at, status, created_at)
+		VALUES
+			('order-waiting', '2025-01-15 19:00:00', '2025-01-15 18:30:00', 'scheduled', '2025-01-15 10:00:00'),
+			('order-assigned', '2025-01-15 18:00:00', '2025-01-15 17:30:00', 'assigned', '2025-01-15 10:00:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := scheduled_delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Ожидающая назначения доставка", func(t *testing.T) {
+		exists, err := repo.ExistsWaitingByOrderID(ctx, "order-waiting")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("Курьер уже назначен или доставки нет", func(t *testing.T) {
+		exists, err := repo.ExistsWaitingByOrderID(ctx, "order-assigned")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		exists, err = repo.ExistsWaitingByOrderID(ctx, "order-unknown")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestRepository_MarkAssigned(t *testing.T) {
+	setupSql := `
+		INSERT INTO scheduled_deliveries (order_id, deliver_at, assign_at, status, created_at)
+		VALUES ('order-1', '2025-01-15 19:00:00', '2025-01-15 18:30:00', 'scheduled', '2025-01-15 10:00:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := scheduled_delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Ожидающая доставка закрывается", func(t *testing.T) {
+		err := repo.MarkAssigned(ctx, "order-1", time.Date(2025, 1, 15, 18, 31, 0, 0, time.UTC))
+		require.NoError(t, err)
+
+		_, err = repo.GetWaitingByOrderIDForUpdate(ctx, "order-1")
+		require.ErrorIs(t, err, service.ErrScheduledDeliveryNotFound)
+	})
+
+	t.Run("Повторное закрытие", func(t *testing.T) {
+		err := repo.MarkAssigned(ctx, "order-1", time.Date(2025, 1, 15, 18, 32, 0, 0, time.UTC))
+		require.ErrorIs(t, err, service.ErrScheduledDeliveryNotFound)
+	})
+}
+
+func TestRepository_Cancel(t *testing.T) {
+	setupSql := `
+		INSERT INTO scheduled_deliveries (order_id, deliver_at, assign_at, status, created_at)
+		VALUES
+			('order-1', '2025-01-15 19:00:00', '2025-01-15 18:30:00', 'scheduled', '2025-01-15 10:00:00'),
+			('order-2', '2025-01-15 19:00:00', '2025-01-15 18:30:00', 'assigned', '2025-01-15 10:00:00');
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := scheduled_delivery.New(q)
+	ctx := context.Background()
+
+	t.Run("Успешная отмена", func(t *testing.T) {
+		actual, err := repo.Cancel(ctx, "order-1", time.Date(2025, 1, 15, 13, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, entities.ScheduledCancelled, actual.Status)
+		require.NotNil(t, actual.CancelledAt)
+	})
+
+	t.Run("После отмены заказ можно запланировать снова", func(t *testing.T) {
+		_, err := repo.Create(ctx, entities.ScheduledDeliveryModify{
+			OrderID:   pointer.To("order-1"),
+			DeliverAt: pointer.To(time.Date(2025, 1, 15, 20, 0, 0, 0, time.UTC)),
+			AssignAt:  pointer.To(time.Date(2025, 1, 15, 19, 30, 0, 0, time.UTC)),
+			CreatedAt: pointer.To(time.Date(2025, 1, 15, 13, 1, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("Доставку с назначенным курьером отменить нельзя", func(t *testing.T) {
+		_, err := repo.Cancel(ctx, "order-2", time.Date(2025, 1, 15, 13, 0, 0, 0, time.UTC))
+		require.ErrorIs(t, err, service.ErrScheduledDeliveryNotFound)
+	})
+}
@@ -0,0 +1,45 @@
+package scheduled_delivery
+
+import (
+	"time"
+
+	"service/internal/repository"
+)
+
+type ScheduledDeliveryDB struct {
+	ID             int64
+	OrderID        string
+	DeliverAt      time.Time
+	AssignAt       time.Time
+	PickupLat      *float64
+	PickupLon      *float64
+	DropoffLat     *float64
+	DropoffLon     *float64
+	RestaurantID   *string
+	Address        *repository.AddressDB
+	OrderCreatedAt *time.Time
+	RequiredSkills []string
+	TransportTypes []string
+	Priority       string
+	Status         string
+	CreatedAt      time.Time
+	AssignedAt     *time.Time
+	CancelledAt    *time.Time
+}
+
+type ScheduledDeliveryModifyDB struct {
+	OrderID        *string
+	DeliverAt      *time.Time
+	AssignAt       *time.Time
+	PickupLat      *float64
+	PickupLon      *float64
+	DropoffLat     *float64
+	DropoffLon     *float64
+	RestaurantID   *string
+	Address        *repository.AddressDB
+	OrderCreatedAt *time.Time
+	RequiredSkills []string
+	TransportTypes []string
+	Priority       *string
+	CreatedAt      *time.Time
+}
@@ -0,0 +1,243 @@
+package scheduled_delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"service/internal/entities"
+	"service/internal/repository"
+	"service/internal/service/delivery"
+)
+
+const constraintWaitingOrder = "idx_scheduled_deliveries_order"
+
+const scheduledColumns = `id, order_id, deliver_at, assign_at, pickup_lat, pickup_lon, dropoff_lat, dropoff_lon,
+	restaurant_id, address, order_created_at, required_skills, allowed_transport_types,
+	priority, status, created_at, assigned_at, cancelled_at`
+
+type Repository struct {
+	querier Querier
+}
+
+func New(querier Querier) *Repository {
+	return &Repository{
+		querier: querier,
+	}
+}
+
+// Create сохраняет запланированную доставку. У заказа может быть только одна ожидающая назначения доставка
+func (r *Repository) Create(ctx context.Context, scheduledModify entities.ScheduledDeliveryModify) (*entities.ScheduledDelivery, error) {
+	scheduledModifyDB := FromDomainModify(&scheduledModify)
+
+	query := `
+		INSERT INTO scheduled_deliveries (
+			order_id, deliver_at, assign_at, pickup_lat, pickup_lon, dropoff_lat, dropoff_lon,
+			restaurant_id, address, order_created_at, required_skills, allowed_transport_types,
+			priority, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, COALESCE($13, 'normal'), $14)
+		RETURNING ` + scheduledColumns
+
+	scheduled, err := scanScheduled(r.querier.QueryRow(
+		ctx,
+		query,
+		scheduledModifyDB.OrderID,
+		scheduledModifyDB.DeliverAt,
+		scheduledModifyDB.AssignAt,
+		scheduledModifyDB.PickupLat,
+		scheduledModifyDB.PickupLon,
+		scheduledModifyDB.DropoffLat,
+		scheduledModifyDB.DropoffLon,
+		scheduledModifyDB.RestaurantID,
+		scheduledModifyDB.Address,
+		scheduledModifyDB.OrderCreatedAt,
+		scheduledModifyDB.RequiredSkills,
+		scheduledModifyDB.TransportTypes,
+		scheduledModifyDB.Priority,
+		scheduledModifyDB.CreatedAt,
+	))
+	if err != nil {
+		if repository.IsPgErrorWithCode(err, repository.PgErrUniqueViolation) &&
+			repository.PgErrorConstraint(err) == constraintWaitingOrder {
+			return nil, delivery.ErrOrderAlreadyScheduled
+		}
+		return nil, fmt.Errorf("unexpected scheduled delivery repository create error: %w", err)
+	}
+
+	return ToDomain(scheduled), nil
+}
+
+// GetDue ожидающие назначения доставки, для которых уже наступил assign_at, в порядке (assign_at, id)
+// после доставки afterAssignAt, afterID. Нулевые afterAssignAt и afterID - с начала.
+// Записи не блокируются: каждая доставка назначается в своей транзакции через GetWaitingByOrderIDForUpdate
+func (r *Repository) GetDue(
+	ctx context.Context,
+	now time.Time,
+	afterAssignAt time.Time,
+	afterID int64,
+	limit int,
+) ([]entities.ScheduledDelivery, error) {
+	query := `
+		SELECT ` + scheduledColumns + `
+		FROM scheduled_deliveries
+		WHERE status = 'scheduled' AND assign_at <= $1 AND (assign_at, id) > ($2, $3)
+		ORDER BY assign_at ASC, id ASC
+		LIMIT $4
+	`
+
+	scheduled, err := r.scanList(ctx, query, now, afterAssignAt, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected scheduled delivery repository get due error: %w", err)
+	}
+
+	return scheduled, nil
+}
+
+// GetWaitingByOrderIDForUpdate блокирует ожидающую назначения доставку заказа до конца транзакции.
+// SKIP LOCKED позволяет нескольким инстансам назначать запланированные доставки параллельно:
+// доставку, которую назначает другой инстанс, эта транзакция не найдет
+func (r *Repository) GetWaitingByOrderIDForUpdate(ctx context.Context, orderID string) (*entities.ScheduledDelivery, error) {
+	query := `
+		SELECT ` + scheduledColumns + `
+		FROM scheduled_deliveries
+		WHERE order_id = $1 AND status = 'scheduled'
+		FOR UPDATE SKIP LOCKED
+	`
+
+	scheduled, err := scanScheduled(r.querier.QueryRow(ctx, query, orderID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, delivery.ErrScheduledDeliveryNotFound
+		}
+		return nil, fmt.Errorf("unexpected scheduled delivery repository get waiting by order id error: %w", err)
+	}
+
+	return ToDomain(scheduled), nil
+}
+
+// ExistsWaitingByOrderID у заказа есть запланированная доставка, которой курьер еще не назначен
+func (r *Repository) ExistsWaitingByOrderID(ctx context.Context, orderID string) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM scheduled_deliveries WHERE order_id = $1 AND status = 'scheduled')
+	`
+
+	var exists bool
+	err := r.querier.QueryRow(ctx, query, orderID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("unexpected scheduled delivery repository exists waiting error: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetWaiting ожидающие назначения доставки в порядке времени доставки
+func (r *Repository) GetWaiting(ctx context.Context) ([]entities.ScheduledDelivery, error) {
+	query := `
+		SELECT ` + scheduledColumns + `
+		FROM scheduled_deliveries
+		WHERE status = 'scheduled'
+		ORDER BY deliver_at ASC, id ASC
+	`
+
+	scheduled, err := r.scanList(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected scheduled delivery repository get waiting error: %w", err)
+	}
+
+	return scheduled, nil
+}
+
+// MarkAssigned закрывает ожидающую доставку заказа: курьер назначен
+func (r *Repository) MarkAssigned(ctx context.Context, orderID string, assignedAt time.Time) error {
+	query := `
+		UPDATE scheduled_deliveries
+		SET status = 'assigned', assigned_at = $2
+		WHERE order_id = $1 AND status = 'scheduled'
+	`
+
+	result, err := r.querier.Exec(ctx, query, orderID, assignedAt)
+	if err != nil {
+		return fmt.Errorf("unexpected scheduled delivery repository mark assigned error: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return delivery.ErrScheduledDeliveryNotFound
+	}
+
+	return nil
+}
+
+// Cancel отменяет ожидающую доставку заказа. Доставку, которой уже назначен курьер, отменить нельзя
+func (r *Repository) Cancel(ctx context.Context, orderID string, cancelledAt time.Time) (*entities.ScheduledDelivery, error) {
+	query := `
+		UPDATE scheduled_deliveries
+		SET status = 'cancelled', cancelled_at = $2
+		WHERE order_id = $1 AND status = 'scheduled'
+		RETURNING ` + scheduledColumns
+
+	scheduled, err := scanScheduled(r.querier.QueryRow(ctx, query, orderID, cancelledAt))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, delivery.ErrScheduledDeliveryNotFound
+		}
+		return nil, fmt.Errorf("unexpected scheduled delivery repository cancel error: %w", err)
+	}
+
+	return ToDomain(scheduled), nil
+}
+
+func (r *Repository) scanList(ctx context.Context, query string, args ...interface{}) ([]entities.ScheduledDelivery, error) {
+	rows, err := r.querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scheduledModels := make([]ScheduledDeliveryDB, 0, 8)
+	for rows.Next() {
+		scheduledDB, err := scanScheduled(rows)
+		if err != nil {
+			return nil, err
+		}
+		scheduledModels = append(scheduledModels, *scheduledDB)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return ToDomainList(scheduledModels), nil
+}
+
+func scanScheduled(row pgx.Row) (*ScheduledDeliveryDB, error) {
+	var scheduledDB ScheduledDeliveryDB
+	err := row.Scan(
+		&scheduledDB.ID,
+		&scheduledDB.OrderID,
+		&scheduledDB.DeliverAt,
+		&scheduledDB.AssignAt,
+		&scheduledDB.PickupLat,
+		&scheduledDB.PickupLon,
+		&scheduledDB.DropoffLat,
+		&scheduledDB.DropoffLon,
+		&scheduledDB.RestaurantID,
+		&scheduledDB.Address,
+		&scheduledDB.OrderCreatedAt,
+		&scheduledDB.RequiredSkills,
+		&scheduledDB.TransportTypes,
+		&scheduledDB.Priority,
+		&scheduledDB.Status,
+		&scheduledDB.CreatedAt,
+		&scheduledDB.AssignedAt,
+		&scheduledDB.CancelledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &scheduledDB, nil
+}
@@ -0,0 +1,49 @@
+package zone
+
+import (
+	"service/internal/entities"
+	"service/pkg/geo"
+)
+
+func ToDomain(z *ZoneDB) *entities.Zone {
+	if z == nil {
+		return nil
+	}
+
+	polygon := make([]entities.Location, len(z.Polygon))
+	for i, point := range z.Polygon {
+		polygon[i] = entities.Location{Latitude: point.Lat, Longitude: point.Lon}
+	}
+
+	return &entities.Zone{
+		ID:        z.ID,
+		Name:      z.Name,
+		Polygon:   polygon,
+		CreatedAt: z.CreatedAt,
+		UpdatedAt: z.UpdatedAt,
+	}
+}
+
+func FromDomainModify(zoneModify *entities.ZoneModify) *ZoneModifyDB {
+	if zoneModify == nil {
+		return nil
+	}
+
+	zoneDB := &ZoneModifyDB{
+		ID:      zoneModify.ID,
+		Name:    zoneModify.Name,
+		Polygon: make([]PointDB, len(zoneModify.Polygon)),
+	}
+
+	geoPolygon := make(geo.Polygon, len(zoneModify.Polygon))
+	for i, location := range zoneModify.Polygon {
+		zoneDB.Polygon[i] = PointDB{Lat: location.Latitude, Lon: location.Longitude}
+		geoPolygon[i] = geo.Point{Lat: location.Latitude, Lon: location.Longitude}
+	}
+
+	minPoint, maxPoint := geoPolygon.Bounds()
+	zoneDB.MinLat, zoneDB.MinLon = minPoint.Lat, minPoint.Lon
+	zoneDB.MaxLat, zoneDB.MaxLon = maxPoint.Lat, maxPoint.Lon
+
+	return zoneDB
+}
@@ -0,0 +1,227 @@
+//go:build integration
+
+package zone_test
+
+import (
+	"context"
+	"testing"
+
+	"service/internal/entities"
+	"service/internal/repository/integration_test"
+	"service/internal/repository/zone"
+	service "service/internal/service/zone"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func square(minLat, minLon, maxLat, maxLon float64) []entities.Location {
+	return []entities.Location{
+		{Latitude: minLat, Longitude: minLon},
+		{Latitude: minLat, Longitude: maxLon},
+		{Latitude: maxLat, Longitude: maxLon},
+		{Latitude: maxLat, Longitude: minLon},
+	}
+}
+
+func TestRepository_Create_Success(t *testing.T) {
+	integration_test.SetupDB(t, "")
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := zone.New(q)
+	ctx := context.Background()
+
+	name := "Центр"
+	polygon := square(55.7, 37.5, 55.8, 37.7)
+
+	actual, err := repo.Create(ctx, entities.ZoneModify{Name: &name, Polygon: polygon})
+	require.NoError(t, err)
+	require.NotNil(t, actual)
+	assert.Positive(t, actual.ID)
+	assert.Equal(t, name, actual.Name)
+	assert.Equal(t, polygon, actual.Polygon)
+
+	var minLat, minLon, maxLat, maxLon float64
+	err = q.QueryRow(ctx, "SELECT min_lat, min_lon, max_lat, max_lon FROM zones WHERE id = $1", actual.ID).
+		Scan(&minLat, &minLon, &maxLat, &maxLon)
+	require.NoError(t, err)
+	assert.InDelta(t, 55.7, minLat, 1e-9)
+	assert.InDelta(t, 37.5, minLon, 1e-9)
+	assert.InDelta(t, 55.8, maxLat, 1e-9)
+	assert.InDelta(t, 37.7, maxLon, 1e-9)
+}
+
+func TestRepository_Create_Conflict(t *testing.T) {
+	setupSql := `
+		INSERT INTO zones (name, polygon, min_lat, min_lon, max_lat, max_lon)
+		VALUES ('Центр', '[]', 0, 0, 0, 0);
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	repo := zone.New(integration_test.GetQuerier())
+
+	name := "Центр"
+	_, err := repo.Create(context.Background(), entities.ZoneModify{Name: &name, Polygon: square(1, 1, 2, 2)})
+	assert.ErrorIs(t, err, service.ErrZoneConflict)
+}
+
+func TestRepository_Update(t *testing.T) {
+	setupSql := `
+		INSERT INTO zones (id, name, polygon, min_lat, min_lon, max_lat, max_lon)
+		VALUES
+			(1, 'Центр', '[]', 0, 0, 0, 0),
+			(2, 'Север', '[]', 0, 0, 0, 0);
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	repo := zone.New(integration_test.GetQuerier())
+	ctx := context.Background()
+
+	t.Run("Успешное обновление", func(t *testing.T) {
+		id := int64(1)
+		name := "Центр-2"
+		polygon := square(10, 20, 11, 21)
+
+		actual, err := repo.Update(ctx, entities.ZoneModify{ID: &id, Name: &name, Polygon: polygon})
+		require.NoError(t, err)
+		assert.Equal(t, name, actual.Name)
+		assert.Equal(t, polygon, actual.Polygon)
+	})
+
+	t.Run("Зона не найдена", func(t *testing.T) {
+		id := int64(99)
+		name := "Нет"
+
+		_, err := repo.Update(ctx, entities.ZoneModify{ID: &id, Name: &name, Polygon: square(1, 1, 2, 2)})
+		assert.ErrorIs(t, err, service.ErrZoneNotFound)
+	})
+
+	t.Run("Имя занято другой зоной", func(t *testing.T) {
+		id := int64(1)
+		name := "Север"
+
+		_, err := repo.Update(ctx, entities.ZoneModify{ID: &id, Name: &name, Polygon: square(1, 1, 2, 2)})
+		assert.ErrorIs(t, err, service.ErrZoneConflict)
+	})
+}
+
+func TestRepository_GetByBoundsContaining(t *testing.T) {
+	setupSql := `
+		INSERT INTO zones (id, name, polygon, min_lat, min_lon, max_lat, max_lon)
+		VALUES
+			(1, 'Центр', '[]', 55.7, 37.5, 55.8, 37.7),
+			(2, 'Север', '[]', 55.8, 37.5, 55.9, 37.7),
+			(3, 'Далеко', '[]', 10, 10, 11, 11);
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	repo := zone.New(integration_test.GetQuerier())
+	ctx := context.Background()
+
+	t.Run("Точка внутри одного прямоугольника", func(t *testing.T) {
+		actual, err := repo.GetByBoundsContaining(ctx, entities.Location{Latitude: 55.75, Longitude: 37.6})
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Equal(t, int64(1), actual[0].ID)
+	})
+
+	t.Run("Точка на общей границе", func(t *testing.T) {
+		actual, err := repo.GetByBoundsContaining(ctx, entities.Location{Latitude: 55.8, Longitude: 37.6})
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+		assert.Equal(t, int64(1), actual[0].ID)
+		assert.Equal(t, int64(2), actual[1].ID)
+	})
+
+	t.Run("Точка вне всех зон", func(t *testing.T) {
+		actual, err := repo.GetByBoundsContaining(ctx, entities.Location{Latitude: 0, Longitude: 0})
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
+
+func TestRepository_Delete(t *testing.T) {
+	setupSql := `
+		INSERT INTO couriers (id, name, phone, status, transport_type)
+		VALUES (1, 'Courier', '+79991112233', 'available', 'on_foot');
+
+		INSERT INTO zones (id, name, polygon, min_lat, min_lon, max_lat, max_lon)
+		VALUES (1, 'Центр', '[]', 0, 0, 0, 0);
+
+		INSERT INTO courier_zones (zone_id, courier_id) VALUES (1, 1);
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	q := integration_test.GetQuerier()
+	repo := zone.New(q)
+	ctx := context.Background()
+
+	err := repo.Delete(ctx, 1)
+	require.NoError(t, err)
+
+	var memberships int
+	err = q.QueryRow(ctx, "SELECT COUNT(*) FROM courier_zones").Scan(&memberships)
+	require.NoError(t, err)
+	assert.Zero(t, memberships)
+
+	err = repo.Delete(ctx, 1)
+	assert.ErrorIs(t, err, service.ErrZoneNotFound)
+}
+
+func TestRepository_Couriers(t *testing.T) {
+	setupSql := `
+		INSERT INTO couriers (id, name, phone, status, transport_type)
+		VALUES
+			(1, 'Courier 1', '+79991112233', 'available', 'on_foot'),
+			(2, 'Courier 2', '+79991112234', 'available', 'car');
+
+		INSERT INTO zones (id, name, polygon, min_lat, min_lon, max_lat, max_lon)
+		VALUES (1, 'Центр', '[]', 0, 0, 0, 0);
+	`
+
+	integration_test.SetupDB(t, setupSql)
+	defer integration_test.TeardownDB(t)
+
+	repo := zone.New(integration_test.GetQuerier())
+	ctx := context.Background()
+
+	t.Run("Добавление идемпотентно", func(t *testing.T) {
+		require.NoError(t, repo.AddCourier(ctx, 1, 2))
+		require.NoError(t, repo.AddCourier(ctx, 1, 1))
+		require.NoError(t, repo.AddCourier(ctx, 1, 1))
+
+		actual, err := repo.GetCourierIDs(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, actual)
+	})
+
+	t.Run("Несуществующая зона", func(t *testing.T) {
+		err := repo.AddCourier(ctx, 99, 1)
+		assert.ErrorIs(t, err, service.ErrZoneNotFound)
+	})
+
+	t.Run("Несуществующий курьер", func(t *testing.T) {
+		err := repo.AddCourier(ctx, 1, 99)
+		assert.ErrorIs(t, err, service.ErrCourierNotFound)
+	})
+
+	t.Run("Удаление из зоны", func(t *testing.T) {
+		require.NoError(t, repo.RemoveCourier(ctx, 1, 1))
+
+		err := repo.RemoveCourier(ctx, 1, 1)
+		assert.ErrorIs(t, err, service.ErrCourierNotInZone)
+
+		actual, err := repo.GetCourierIDs(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2}, actual)
+	})
+}
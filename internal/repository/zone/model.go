@@ -0,0 +1,30 @@
+package zone
+
+import (
+	"time"
+)
+
+type ZoneDB struct {
+	ID        int64
+	Name      string
+	Polygon   []PointDB
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// PointDB вершина контура в JSONB колонке polygon
+type PointDB struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+type ZoneModifyDB struct {
+	ID      *int64
+	Name    *string
+	Polygon []PointDB
+
+	MinLat float64
+	MinLon float64
+	MaxLat float64
+	MaxLon float64
+}
@@ -0,0 +1,256 @@
+package zone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"service/internal/entities"
+	"service/internal/repository"
+	"service/internal/service/zone"
+)
+
+const (
+	constraintCourierZonesZone    = "courier_zones_zone_id_fkey"
+	constraintCourierZonesCourier = "courier_zones_courier_id_fkey"
+)
+
+type Repository struct {
+	querier Querier
+}
+
+func New(querier Querier) *Repository {
+	return &Repository{
+		querier: querier,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, zoneModifyEntity entities.ZoneModify) (*entities.Zone, error) {
+	zoneModifyModel := FromDomainModify(&zoneModifyEntity)
+	query := `INSERT INTO zones (name, polygon, min_lat, min_lon, max_lat, max_lon)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, name, polygon, created_at, updated_at`
+
+	var zoneModel ZoneDB
+	err := r.querier.QueryRow(
+		ctx,
+		query,
+		zoneModifyModel.Name,
+		zoneModifyModel.Polygon,
+		zoneModifyModel.MinLat,
+		zoneModifyModel.MinLon,
+		zoneModifyModel.MaxLat,
+		zoneModifyModel.MaxLon,
+	).Scan(
+		&zoneModel.ID,
+		&zoneModel.Name,
+		&zoneModel.Polygon,
+		&zoneModel.CreatedAt,
+		&zoneModel.UpdatedAt,
+	)
+	if err != nil {
+		if repository.IsPgErrorWithCode(err, repository.PgErrUniqueViolation) {
+			return nil, zone.ErrZoneConflict
+		}
+		return nil, fmt.Errorf("unexpected zone repository create error: %w", err)
+	}
+
+	return ToDomain(&zoneModel), nil
+}
+
+func (r *Repository) Update(ctx context.Context, zoneModifyEntity entities.ZoneModify) (*entities.Zone, error) {
+	zoneModifyModel := FromDomainModify(&zoneModifyEntity)
+	query := `UPDATE zones
+		SET name = $2, polygon = $3, min_lat = $4, min_lon = $5, max_lat = $6, max_lon = $7, updated_at = NOW()
+		WHERE id = $1
+		RETURNING id, name, polygon, created_at, updated_at`
+
+	var zoneModel ZoneDB
+	err := r.querier.QueryRow(
+		ctx,
+		query,
+		zoneModifyModel.ID,
+		zoneModifyModel.Name,
+		zoneModifyModel.Polygon,
+		zoneModifyModel.MinLat,
+		zoneModifyModel.MinLon,
+		zoneModifyModel.MaxLat,
+		zoneModifyModel.MaxLon,
+	).Scan(
+		&zoneModel.ID,
+		&zoneModel.Name,
+		&zoneModel.Polygon,
+		&zoneModel.CreatedAt,
+		&zoneModel.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, zone.ErrZoneNotFound
+		}
+		if repository.IsPgErrorWithCode(err, repository.PgErrUniqueViolation) {
+			return nil, zone.ErrZoneConflict
+		}
+		return nil, fmt.Errorf("unexpected zone repository update error: %w", err)
+	}
+
+	return ToDomain(&zoneModel), nil
+}
+
+func (r *Repository) GetByID(ctx context.Context, id int64) (*entities.Zone, error) {
+	query := `SELECT id, name, polygon, created_at, updated_at
+		FROM zones
+		WHERE id = $1`
+
+	var zoneModel ZoneDB
+	err := r.querier.QueryRow(ctx, query, id).
+		Scan(
+			&zoneModel.ID,
+			&zoneModel.Name,
+			&zoneModel.Polygon,
+			&zoneModel.CreatedAt,
+			&zoneModel.UpdatedAt,
+		)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, zone.ErrZoneNotFound
+		}
+		return nil, fmt.Errorf("unexpected zone repository getbyid error: %w", err)
+	}
+
+	return ToDomain(&zoneModel), nil
+}
+
+func (r *Repository) GetAll(ctx context.Context) ([]entities.Zone, error) {
+	query := `SELECT id, name, polygon, created_at, updated_at
+		FROM zones
+		ORDER BY id`
+
+	zones, err := r.queryZones(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected zone repository getall error: %w", err)
+	}
+
+	return zones, nil
+}
+
+// GetByBoundsContaining грубый отбор по описанному прямоугольнику, точную проверку делает сервис
+func (r *Repository) GetByBoundsContaining(ctx context.Context, point entities.Location) ([]entities.Zone, error) {
+	query := `SELECT id, name, polygon, created_at, updated_at
+		FROM zones
+		WHERE $1 BETWEEN min_lat AND max_lat
+			AND $2 BETWEEN min_lon AND max_lon
+		ORDER BY id`
+
+	zones, err := r.queryZones(ctx, query, point.Latitude, point.Longitude)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected zone repository getbyboundscontaining error: %w", err)
+	}
+
+	return zones, nil
+}
+
+func (r *Repository) queryZones(ctx context.Context, query string, args ...any) ([]entities.Zone, error) {
+	rows, err := r.querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	zones := make([]entities.Zone, 0, 8)
+	for rows.Next() {
+		var zoneModel ZoneDB
+		err := rows.Scan(
+			&zoneModel.ID,
+			&zoneModel.Name,
+			&zoneModel.Polygon,
+			&zoneModel.CreatedAt,
+			&zoneModel.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, *ToDomain(&zoneModel))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return zones, nil
+}
+
+func (r *Repository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM zones WHERE id = $1`
+
+	tag, err := r.querier.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("unexpected zone repository delete error: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return zone.ErrZoneNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) GetCourierIDs(ctx context.Context, zoneID int64) ([]int64, error) {
+	query := `SELECT courier_id
+		FROM courier_zones
+		WHERE zone_id = $1
+		ORDER BY courier_id`
+
+	rows, err := r.querier.Query(ctx, query, zoneID)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected zone repository getcourierids error: %w", err)
+	}
+	defer rows.Close()
+
+	courierIDs := make([]int64, 0, 8)
+	for rows.Next() {
+		var courierID int64
+		if err := rows.Scan(&courierID); err != nil {
+			return nil, fmt.Errorf("unexpected zone repository getcourierids error: %w", err)
+		}
+		courierIDs = append(courierIDs, courierID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unexpected zone repository getcourierids error: %w", err)
+	}
+
+	return courierIDs, nil
+}
+
+func (r *Repository) AddCourier(ctx context.Context, zoneID, courierID int64) error {
+	query := `INSERT INTO courier_zones (zone_id, courier_id)
+		VALUES ($1, $2)
+		ON CONFLICT (zone_id, courier_id) DO NOTHING`
+
+	_, err := r.querier.Exec(ctx, query, zoneID, courierID)
+	if err != nil {
+		if repository.IsPgErrorWithCode(err, repository.PgErrForeignKeyViolation) {
+			switch repository.PgErrorConstraint(err) {
+			case constraintCourierZonesZone:
+				return zone.ErrZoneNotFound
+			case constraintCourierZonesCourier:
+				return zone.ErrCourierNotFound
+			}
+		}
+		return fmt.Errorf("unexpected zone repository addcourier error: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) RemoveCourier(ctx context.Context, zoneID, courierID int64) error {
+	query := `DELETE FROM courier_zones WHERE zone_id = $1 AND courier_id = $2`
+
+	tag, err := r.querier.Exec(ctx, query, zoneID, courierID)
+	if err != nil {
+		return fmt.Errorf("unexpected zone repository removecourier error: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return zone.ErrCourierNotInZone
+	}
+
+	return nil
+}
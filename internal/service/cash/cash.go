@@ -0,0 +1,135 @@
+package cash
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"service/internal/entities"
+)
+
+// Policy BalanceLimit тот же лимит наличных, что и при назначении заказов, в сверке по нему отмечаются
+// курьеры, которым заказы с оплатой наличными не назначаются. 0 - без лимита
+type Policy struct {
+	BalanceLimit int64
+}
+
+type Cash struct {
+	repository Repository
+	txManager  TxManager
+	policy     Policy
+}
+
+func New(repository Repository, txManager TxManager, policy Policy) *Cash {
+	return &Cash{
+		repository: repository,
+		txManager:  txManager,
+		policy:     policy,
+	}
+}
+
+// RecordCollection зачисляет в кассу курьера наличные, полученные от клиента за выполненную доставку.
+// Доставка без наличных ничего не меняет, повторное выполнение заказа не зачисляет наличные второй раз
+func (c *Cash) RecordCollection(ctx context.Context, orderID string) error {
+	if orderID == "" {
+		return ErrInvalidOrderID
+	}
+
+	cashDelivery, err := c.repository.GetCashDelivery(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("get cash delivery: %w", err)
+	}
+	if cashDelivery.CashAmount <= 0 {
+		return nil
+	}
+
+	collectedAt := time.Now().UTC()
+	created, err := c.repository.CreateCollection(ctx, *cashDelivery, collectedAt)
+	if err != nil {
+		return fmt.Errorf("create cash collection: %w", err)
+	}
+	if !created {
+		return nil
+	}
+
+	_, err = c.repository.AddBalance(ctx, cashDelivery.CourierID, cashDelivery.CashAmount, collectedAt)
+	if err != nil {
+		return fmt.Errorf("add courier cash balance: %w", err)
+	}
+
+	CourierCashCollectedTotal.Add(float64(cashDelivery.CashAmount))
+	return nil
+}
+
+// HandOver курьер сдает оператору наличные. Сдать больше, чем на руках, нельзя:
+// остаток блокируется, чтобы параллельная сдача не увела его в минус
+func (c *Cash) HandOver(ctx context.Context, params entities.CashHandoverParams) (*entities.CashHandover, error) {
+	err := validateHandover(params)
+	if err != nil {
+		return nil, err
+	}
+	params.Operator = strings.TrimSpace(params.Operator)
+
+	var handover entities.CashHandover
+	err = c.txManager.Do(ctx, func(ctx context.Context) error {
+		exists, err := c.repository.CourierExists(ctx, params.CourierID)
+		if err != nil {
+			return fmt.Errorf("check courier exists: %w", err)
+		}
+		if !exists {
+			return ErrCourierNotFound
+		}
+
+		balance, err := c.repository.GetBalanceForUpdate(ctx, params.CourierID)
+		if err != nil {
+			return fmt.Errorf("get courier cash balance: %w", err)
+		}
+		if params.Amount > balance {
+			return ErrAmountExceedsBalance
+		}
+
+		handedOverAt := time.Now().UTC()
+		transaction, err := c.repository.CreateHandover(ctx, params, handedOverAt)
+		if err != nil {
+			return fmt.Errorf("create cash handover: %w", err)
+		}
+
+		balance, err = c.repository.AddBalance(ctx, params.CourierID, -params.Amount, handedOverAt)
+		if err != nil {
+			return fmt.Errorf("subtract courier cash balance: %w", err)
+		}
+
+		handover = entities.CashHandover{
+			Transaction: *transaction,
+			Balance:     balance,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	CourierCashHandedOverTotal.Add(float64(params.Amount))
+	return &handover, nil
+}
+
+// GetReconciliation сверка наличных: у кого из курьеров и сколько несданных наличных
+func (c *Cash) GetReconciliation(ctx context.Context) (*entities.CashReconciliation, error) {
+	balances, err := c.repository.GetOutstandingBalances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get outstanding cash balances: %w", err)
+	}
+
+	reconciliation := &entities.CashReconciliation{
+		GeneratedAt:  time.Now().UTC(),
+		BalanceLimit: c.policy.BalanceLimit,
+		Couriers:     balances,
+	}
+	for i := range balances {
+		balances[i].OverLimit = c.policy.BalanceLimit > 0 && balances[i].Balance > c.policy.BalanceLimit
+		reconciliation.Outstanding += balances[i].Balance
+	}
+
+	return reconciliation, nil
+}
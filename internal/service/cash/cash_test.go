@@ -0,0 +1,364 @@
+package cash_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/service/cash"
+)
+
+type mock struct {
+	*MockRepository
+	*MockTxManager
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockRepository: NewMockRepository(ctrl),
+		MockTxManager:  NewMockTxManager(ctrl),
+	}
+}
+
+func newService(m *mock) *cash.Cash {
+	return cash.New(m.MockRepository, m.MockTxManager, cash.Policy{BalanceLimit: 500000})
+}
+
+func errorAssertion(expectedError error, expectedErrMsg string) require.ErrorAssertionFunc {
+	return func(t require.TestingT, err error, msgAndArgs ...interface{}) {
+		require.Error(t, err, msgAndArgs...)
+
+		if expectedError != nil {
+			assert.ErrorIs(t, err, expectedError, msgAndArgs...)
+		}
+
+		if expectedErrMsg != "" {
+			assert.Contains(t, err.Error(), expectedErrMsg, msgAndArgs...)
+		}
+	}
+}
+
+func runInTx(m *mock) {
+	m.MockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+func TestCashService_RecordCollection(t *testing.T) {
+	t.Parallel()
+
+	cashDelivery := entities.CashDelivery{OrderID: "order-1", CourierID: 7, CashAmount: 150000}
+
+	tests := []struct {
+		name           string
+		orderID        string
+		mockSetup      func(m *mock)
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:    "Наличные за заказ зачисляются в кассу курьера",
+			orderID: "order-1",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetCashDelivery(gomock.Any(), "order-1").
+					Return(&cashDelivery, nil)
+				m.MockRepository.EXPECT().
+					CreateCollection(gomock.Any(), cashDelivery, gomock.Any()).
+					Return(true, nil)
+				m.MockRepository.EXPECT().
+					AddBalance(gomock.Any(), int64(7), int64(150000), gomock.Any()).
+					Return(int64(150000), nil)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:    "Заказ оплачен не наличными",
+			orderID: "order-1",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetCashDelivery(gomock.Any(), "order-1").
+					Return(&entities.CashDelivery{OrderID: "order-1", CourierID: 7}, nil)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:    "Повторное выполнение заказа не зачисляет наличные второй раз",
+			orderID: "order-1",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetCashDelivery(gomock.Any(), "order-1").
+					Return(&cashDelivery, nil)
+				m.MockRepository.EXPECT().
+					CreateCollection(gomock.Any(), cashDelivery, gomock.Any()).
+					Return(false, nil)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:           "Пустой ID заказа",
+			orderID:        "",
+			errorAssertion: errorAssertion(cash.ErrInvalidOrderID, ""),
+		},
+		{
+			name:    "Выполненная доставка не найдена",
+			orderID: "order-1",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetCashDelivery(gomock.Any(), "order-1").
+					Return(nil, cash.ErrDeliveryNotFound)
+			},
+			errorAssertion: errorAssertion(cash.ErrDeliveryNotFound, "get cash delivery"),
+		},
+		{
+			name:    "Ошибка изменения остатка",
+			orderID: "order-1",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetCashDelivery(gomock.Any(), "order-1").
+					Return(&cashDelivery, nil)
+				m.MockRepository.EXPECT().
+					CreateCollection(gomock.Any(), cashDelivery, gomock.Any()).
+					Return(true, nil)
+				m.MockRepository.EXPECT().
+					AddBalance(gomock.Any(), int64(7), int64(150000), gomock.Any()).
+					Return(int64(0), errors.New("database connection timeout"))
+			},
+			errorAssertion: errorAssertion(nil, "add courier cash balance: database connection timeout"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newMock(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			err := newService(m).RecordCollection(context.Background(), tt.orderID)
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
+
+func TestCashService_HandOver(t *testing.T) {
+	t.Parallel()
+
+	handedOverAt := time.Date(2026, 1, 1, 18, 0, 0, 0, time.UTC)
+	params := entities.CashHandoverParams{CourierID: 7, Amount: 100000, Operator: "Кассир Петрова"}
+
+	tests := []struct {
+		name           string
+		params         entities.CashHandoverParams
+		mockSetup      func(m *mock)
+		expected       *entities.CashHandover
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:   "Курьер сдает часть наличных",
+			params: entities.CashHandoverParams{CourierID: 7, Amount: 100000, Operator: "  Кассир Петрова "},
+			mockSetup: func(m *mock) {
+				runInTx(m)
+				m.MockRepository.EXPECT().
+					CourierExists(gomock.Any(), int64(7)).
+					Return(true, nil)
+				m.MockRepository.EXPECT().
+					GetBalanceForUpdate(gomock.Any(), int64(7)).
+					Return(int64(250000), nil)
+				m.MockRepository.EXPECT().
+					CreateHandover(gomock.Any(), params, gomock.Any()).
+					Return(&entities.CashTransaction{
+						ID:        3,
+						CourierID: 7,
+						Kind:      entities.CashHandedOver,
+						Amount:    100000,
+						Operator:  "Кассир Петрова",
+						CreatedAt: handedOverAt,
+					}, nil)
+				m.MockRepository.EXPECT().
+					AddBalance(gomock.Any(), int64(7), int64(-100000), gomock.Any()).
+					Return(int64(150000), nil)
+			},
+			expected: &entities.CashHandover{
+				Transaction: entities.CashTransaction{
+					ID:        3,
+					CourierID: 7,
+					Kind:      entities.CashHandedOver,
+					Amount:    100000,
+					Operator:  "Кассир Петрова",
+					CreatedAt: handedOverAt,
+				},
+				Balance: 150000,
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:   "Сумма больше остатка на руках",
+			params: params,
+			mockSetup: func(m *mock) {
+				runInTx(m)
+				m.MockRepository.EXPECT().
+					CourierExists(gomock.Any(), int64(7)).
+					Return(true, nil)
+				m.MockRepository.EXPECT().
+					GetBalanceForUpdate(gomock.Any(), int64(7)).
+					Return(int64(50000), nil)
+			},
+			errorAssertion: errorAssertion(cash.ErrAmountExceedsBalance, ""),
+		},
+		{
+			name:   "Курьер не найден",
+			params: params,
+			mockSetup: func(m *mock) {
+				runInTx(m)
+				m.MockRepository.EXPECT().
+					CourierExists(gomock.Any(), int64(7)).
+					Return(false, nil)
+			},
+			errorAssertion: errorAssertion(cash.ErrCourierNotFound, ""),
+		},
+		{
+			name:           "Некорректный ID курьера",
+			params:         entities.CashHandoverParams{Amount: 100000, Operator: "Кассир Петрова"},
+			errorAssertion: errorAssertion(cash.ErrInvalidCourierID, ""),
+		},
+		{
+			name:           "Нулевая сумма",
+			params:         entities.CashHandoverParams{CourierID: 7, Operator: "Кассир Петрова"},
+			errorAssertion: errorAssertion(cash.ErrInvalidAmount, ""),
+		},
+		{
+			name:           "Оператор не указан",
+			params:         entities.CashHandoverParams{CourierID: 7, Amount: 100000, Operator: "   "},
+			errorAssertion: errorAssertion(cash.ErrInvalidOperator, ""),
+		},
+		{
+			name:           "Слишком длинное имя оператора",
+			params:         entities.CashHandoverParams{CourierID: 7, Amount: 100000, Operator: strings.Repeat("я", 256)},
+			errorAssertion: errorAssertion(cash.ErrInvalidOperator, ""),
+		},
+		{
+			name:   "Ошибка сохранения сдачи",
+			params: params,
+			mockSetup: func(m *mock) {
+				runInTx(m)
+				m.MockRepository.EXPECT().
+					CourierExists(gomock.Any(), int64(7)).
+					Return(true, nil)
+				m.MockRepository.EXPECT().
+					GetBalanceForUpdate(gomock.Any(), int64(7)).
+					Return(int64(250000), nil)
+				m.MockRepository.EXPECT().
+					CreateHandover(gomock.Any(), params, gomock.Any()).
+					Return(nil, errors.New("database connection timeout"))
+			},
+			errorAssertion: errorAssertion(nil, "create cash handover: database connection timeout"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newMock(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			result, err := newService(m).HandOver(context.Background(), tt.params)
+			assert.Equal(t, tt.expected, result)
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
+
+func TestCashService_GetReconciliation(t *testing.T) {
+	t.Parallel()
+
+	collectedAt := time.Date(2026, 1, 1, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		check          func(t *testing.T, result *entities.CashReconciliation)
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Курьеры сверх лимита отмечаются, остатки суммируются",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetOutstandingBalances(gomock.Any()).
+					Return([]entities.CourierCashBalance{
+						{CourierID: 2, CourierName: "Courier 2", Balance: 600000, LastCollectedAt: pointer.To(collectedAt)},
+						{CourierID: 1, CourierName: "Courier 1", Balance: 500000, LastCollectedAt: pointer.To(collectedAt)},
+					}, nil)
+			},
+			check: func(t *testing.T, result *entities.CashReconciliation) {
+				assert.Equal(t, int64(500000), result.BalanceLimit)
+				assert.Equal(t, int64(1100000), result.Outstanding)
+				require.Len(t, result.Couriers, 2)
+				assert.True(t, result.Couriers[0].OverLimit)
+				assert.False(t, result.Couriers[1].OverLimit)
+				assert.False(t, result.GeneratedAt.IsZero())
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Все наличные сданы",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetOutstandingBalances(gomock.Any()).
+					Return([]entities.CourierCashBalance{}, nil)
+			},
+			check: func(t *testing.T, result *entities.CashReconciliation) {
+				assert.Zero(t, result.Outstanding)
+				assert.Empty(t, result.Couriers)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Ошибка получения остатков",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetOutstandingBalances(gomock.Any()).
+					Return(nil, errors.New("database connection timeout"))
+			},
+			check: func(t *testing.T, result *entities.CashReconciliation) {
+				assert.Nil(t, result)
+			},
+			errorAssertion: errorAssertion(nil, "get outstanding cash balances: database connection timeout"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newMock(ctrl)
+			tt.mockSetup(m)
+
+			result, err := newService(m).GetReconciliation(context.Background())
+			tt.check(t, result)
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
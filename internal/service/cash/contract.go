@@ -0,0 +1,26 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=cash_test
+package cash
+
+import (
+	"context"
+	"time"
+
+	"service/internal/entities"
+)
+
+type Repository interface {
+	GetCashDelivery(ctx context.Context, orderID string) (*entities.CashDelivery, error)
+	// CreateCollection false, если наличные за заказ уже зачислены
+	CreateCollection(ctx context.Context, cashDelivery entities.CashDelivery, collectedAt time.Time) (bool, error)
+	CreateHandover(ctx context.Context, params entities.CashHandoverParams, handedOverAt time.Time) (*entities.CashTransaction, error)
+	CourierExists(ctx context.Context, courierID int64) (bool, error)
+	// GetBalanceForUpdate блокирует остаток курьера до конца транзакции, курьер без движений наличных - 0
+	GetBalanceForUpdate(ctx context.Context, courierID int64) (int64, error)
+	// AddBalance меняет остаток на delta и возвращает новый остаток
+	AddBalance(ctx context.Context, courierID int64, delta int64, updatedAt time.Time) (int64, error)
+	GetOutstandingBalances(ctx context.Context) ([]entities.CourierCashBalance, error)
+}
+
+type TxManager interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,186 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contract.go -destination=./contract_mocks_test.go -package=cash_test
+//
+
+// Package cash_test is a generated GoMock package.
+package cash_test
+
+import (
+	context "context"
+	reflect "reflect"
+	entities "service/internal/entities"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddBalance mocks base method.
+func (m *MockRepository) AddBalance(ctx context.Context, courierID, delta int64, updatedAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBalance", ctx, courierID, delta, updatedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBalance indicates an expected call of AddBalance.
+func (mr *MockRepositoryMockRecorder) AddBalance(ctx, courierID, delta, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBalance", reflect.TypeOf((*MockRepository)(nil).AddBalance), ctx, courierID, delta, updatedAt)
+}
+
+// CourierExists mocks base method.
+func (m *MockRepository) CourierExists(ctx context.Context, courierID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CourierExists", ctx, courierID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CourierExists indicates an expected call of CourierExists.
+func (mr *MockRepositoryMockRecorder) CourierExists(ctx, courierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CourierExists", reflect.TypeOf((*MockRepository)(nil).CourierExists), ctx, courierID)
+}
+
+// CreateCollection mocks base method.
+func (m *MockRepository) CreateCollection(ctx context.Context, cashDelivery entities.CashDelivery, collectedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", ctx, cashDelivery, collectedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockRepositoryMockRecorder) CreateCollection(ctx, cashDelivery, collectedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockRepository)(nil).CreateCollection), ctx, cashDelivery, collectedAt)
+}
+
+// CreateHandover mocks base method.
+func (m *MockRepository) CreateHandover(ctx context.Context, params entities.CashHandoverParams, handedOverAt time.Time) (*entities.CashTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHandover", ctx, params, handedOverAt)
+	ret0, _ := ret[0].(*entities.CashTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHandover indicates an expected call of CreateHandover.
+func (mr *MockRepositoryMockRecorder) CreateHandover(ctx, params, handedOverAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHandover", reflect.TypeOf((*MockRepository)(nil).CreateHandover), ctx, params, handedOverAt)
+}
+
+// GetBalanceForUpdate mocks base method.
+func (m *MockRepository) GetBalanceForUpdate(ctx context.Context, courierID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceForUpdate", ctx, courierID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceForUpdate indicates an expected call of GetBalanceForUpdate.
+func (mr *MockRepositoryMockRecorder) GetBalanceForUpdate(ctx, courierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceForUpdate", reflect.TypeOf((*MockRepository)(nil).GetBalanceForUpdate), ctx, courierID)
+}
+
+// GetCashDelivery mocks base method.
+func (m *MockRepository) GetCashDelivery(ctx context.Context, orderID string) (*entities.CashDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCashDelivery", ctx, orderID)
+	ret0, _ := ret[0].(*entities.CashDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCashDelivery indicates an expected call of GetCashDelivery.
+func (mr *MockRepositoryMockRecorder) GetCashDelivery(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCashDelivery", reflect.TypeOf((*MockRepository)(nil).GetCashDelivery), ctx, orderID)
+}
+
+// GetOutstandingBalances mocks base method.
+func (m *MockRepository) GetOutstandingBalances(ctx context.Context) ([]entities.CourierCashBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutstandingBalances", ctx)
+	ret0, _ := ret[0].([]entities.CourierCashBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutstandingBalances indicates an expected call of GetOutstandingBalances.
+func (mr *MockRepositoryMockRecorder) GetOutstandingBalances(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutstandingBalances", reflect.TypeOf((*MockRepository)(nil).GetOutstandingBalances), ctx)
+}
+
+// MockTxManager is a mock of TxManager interface.
+type MockTxManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockTxManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockTxManagerMockRecorder is the mock recorder for MockTxManager.
+type MockTxManagerMockRecorder struct {
+	mock *MockTxManager
+}
+
+// NewMockTxManager creates a new mock instance.
+func NewMockTxManager(ctrl *gomock.Controller) *MockTxManager {
+	mock := &MockTxManager{ctrl: ctrl}
+	mock.recorder = &MockTxManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTxManager) EXPECT() *MockTxManagerMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockTxManager) Do(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockTxManagerMockRecorder) Do(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockTxManager)(nil).Do), ctx, fn)
+}
@@ -0,0 +1,14 @@
+package cash
+
+import "errors"
+
+var (
+	ErrInvalidOrderID   = errors.New("invalid order id")
+	ErrInvalidCourierID = errors.New("invalid courier id")
+	ErrInvalidAmount    = errors.New("cash amount must be positive")
+	ErrInvalidOperator  = errors.New("operator is required and must be at most 255 characters")
+
+	ErrAmountExceedsBalance = errors.New("handover amount exceeds courier cash balance")
+	ErrDeliveryNotFound     = errors.New("completed delivery not found")
+	ErrCourierNotFound      = errors.New("courier not found")
+)
@@ -0,0 +1,24 @@
+package cash
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// CourierCashCollectedTotal наличные, полученные курьерами от клиентов за выполненные доставки, в копейках
+	CourierCashCollectedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "courier_cash_collected_kopecks_total",
+			Help: "Total cash collected by couriers from customers for completed deliveries in kopecks",
+		},
+	)
+
+	// CourierCashHandedOverTotal наличные, сданные курьерами операторам, в копейках
+	CourierCashHandedOverTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "courier_cash_handed_over_kopecks_total",
+			Help: "Total cash handed over by couriers to operators in kopecks",
+		},
+	)
+)
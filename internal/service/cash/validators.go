@@ -0,0 +1,24 @@
+package cash
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"service/internal/entities"
+)
+
+const maxOperatorLength = 255
+
+func validateHandover(params entities.CashHandoverParams) error {
+	if params.CourierID <= 0 {
+		return ErrInvalidCourierID
+	}
+	if params.Amount <= 0 {
+		return ErrInvalidAmount
+	}
+	operator := strings.TrimSpace(params.Operator)
+	if operator == "" || utf8.RuneCountInString(operator) > maxOperatorLength {
+		return ErrInvalidOperator
+	}
+	return nil
+}
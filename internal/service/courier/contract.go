@@ -3,6 +3,7 @@ package courier
 
 import (
 	"context"
+	"time"
 
 	"service/internal/entities"
 )
@@ -10,10 +11,30 @@ import (
 type Repository interface {
 	Create(ctx context.Context, courierModifyEntity entities.CourierModify) (int64, error)
 	GetByID(ctx context.Context, id int64) (*entities.Courier, error)
-	GetAll(ctx context.Context) ([]entities.Courier, error)
+	GetByIDForUpdate(ctx context.Context, id int64) (*entities.Courier, error)
+	GetAll(ctx context.Context, filter entities.CourierListFilter) ([]entities.Courier, error)
 	Update(ctx context.Context, courierModifyEntity entities.CourierModify) (*entities.Courier, error)
+	HasActiveDeliveries(ctx context.Context, id int64) (bool, error)
+	CancelPendingOffers(ctx context.Context, id int64, cancelledAt time.Time) (bool, error)
+	Deactivate(ctx context.Context, id int64, reason string, deactivatedAt time.Time) (*entities.Courier, error)
+	Reactivate(ctx context.Context, id int64) (*entities.Courier, error)
+	GetActivePhones(ctx context.Context, phones []string) (map[string]struct{}, error)
+	StreamAll(ctx context.Context, filter entities.CourierListFilter, fn func(courier entities.Courier) error) error
+	UpdatePhone(ctx context.Context, id int64, oldPhone, newPhone string) (bool, error)
+	GetSkills(ctx context.Context, id int64) ([]entities.CourierSkill, error)
+	ReplaceSkills(ctx context.Context, id int64, skills []entities.CourierSkill) error
 }
 
 type TxManager interface {
 	Do(ctx context.Context, fn func(ctx context.Context) error) error
 }
+
+// PhoneNormalizer приводит телефон к E.164, чтобы один номер в разной записи не становился разными курьерами
+type PhoneNormalizer interface {
+	Normalize(phone string) (string, error)
+}
+
+// AvailabilityNotifier сообщает, что курьер стал доступен и очередь ожидания можно разбирать
+type AvailabilityNotifier interface {
+	Notify()
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 	entities "service/internal/entities"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,6 +42,21 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CancelPendingOffers mocks base method.
+func (m *MockRepository) CancelPendingOffers(ctx context.Context, id int64, cancelledAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPendingOffers", ctx, id, cancelledAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelPendingOffers indicates an expected call of CancelPendingOffers.
+func (mr *MockRepositoryMockRecorder) CancelPendingOffers(ctx, id, cancelledAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPendingOffers", reflect.TypeOf((*MockRepository)(nil).CancelPendingOffers), ctx, id, cancelledAt)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(ctx context.Context, courierModifyEntity entities.CourierModify) (int64, error) {
 	m.ctrl.T.Helper()
@@ -56,19 +72,49 @@ func (mr *MockRepositoryMockRecorder) Create(ctx, courierModifyEntity any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, courierModifyEntity)
 }
 
+// Deactivate mocks base method.
+func (m *MockRepository) Deactivate(ctx context.Context, id int64, reason string, deactivatedAt time.Time) (*entities.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deactivate", ctx, id, reason, deactivatedAt)
+	ret0, _ := ret[0].(*entities.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deactivate indicates an expected call of Deactivate.
+func (mr *MockRepositoryMockRecorder) Deactivate(ctx, id, reason, deactivatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deactivate", reflect.TypeOf((*MockRepository)(nil).Deactivate), ctx, id, reason, deactivatedAt)
+}
+
+// GetActivePhones mocks base method.
+func (m *MockRepository) GetActivePhones(ctx context.Context, phones []string) (map[string]struct{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivePhones", ctx, phones)
+	ret0, _ := ret[0].(map[string]struct{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivePhones indicates an expected call of GetActivePhones.
+func (mr *MockRepositoryMockRecorder) GetActivePhones(ctx, phones any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivePhones", reflect.TypeOf((*MockRepository)(nil).GetActivePhones), ctx, phones)
+}
+
 // GetAll mocks base method.
-func (m *MockRepository) GetAll(ctx context.Context) ([]entities.Courier, error) {
+func (m *MockRepository) GetAll(ctx context.Context, filter entities.CourierListFilter) ([]entities.Courier, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret := m.ctrl.Call(m, "GetAll", ctx, filter)
 	ret0, _ := ret[0].([]entities.Courier)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetAll(ctx, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRepository)(nil).GetAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRepository)(nil).GetAll), ctx, filter)
 }
 
 // GetByID mocks base method.
@@ -86,6 +132,94 @@ func (mr *MockRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByIDForUpdate mocks base method.
+func (m *MockRepository) GetByIDForUpdate(ctx context.Context, id int64) (*entities.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entities.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockRepositoryMockRecorder) GetByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockRepository)(nil).GetByIDForUpdate), ctx, id)
+}
+
+// GetSkills mocks base method.
+func (m *MockRepository) GetSkills(ctx context.Context, id int64) ([]entities.CourierSkill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSkills", ctx, id)
+	ret0, _ := ret[0].([]entities.CourierSkill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSkills indicates an expected call of GetSkills.
+func (mr *MockRepositoryMockRecorder) GetSkills(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSkills", reflect.TypeOf((*MockRepository)(nil).GetSkills), ctx, id)
+}
+
+// HasActiveDeliveries mocks base method.
+func (m *MockRepository) HasActiveDeliveries(ctx context.Context, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasActiveDeliveries", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasActiveDeliveries indicates an expected call of HasActiveDeliveries.
+func (mr *MockRepositoryMockRecorder) HasActiveDeliveries(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasActiveDeliveries", reflect.TypeOf((*MockRepository)(nil).HasActiveDeliveries), ctx, id)
+}
+
+// Reactivate mocks base method.
+func (m *MockRepository) Reactivate(ctx context.Context, id int64) (*entities.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reactivate", ctx, id)
+	ret0, _ := ret[0].(*entities.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reactivate indicates an expected call of Reactivate.
+func (mr *MockRepositoryMockRecorder) Reactivate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reactivate", reflect.TypeOf((*MockRepository)(nil).Reactivate), ctx, id)
+}
+
+// ReplaceSkills mocks base method.
+func (m *MockRepository) ReplaceSkills(ctx context.Context, id int64, skills []entities.CourierSkill) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceSkills", ctx, id, skills)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceSkills indicates an expected call of ReplaceSkills.
+func (mr *MockRepositoryMockRecorder) ReplaceSkills(ctx, id, skills any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceSkills", reflect.TypeOf((*MockRepository)(nil).ReplaceSkills), ctx, id, skills)
+}
+
+// StreamAll mocks base method.
+func (m *MockRepository) StreamAll(ctx context.Context, filter entities.CourierListFilter, fn func(entities.Courier) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamAll", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamAll indicates an expected call of StreamAll.
+func (mr *MockRepositoryMockRecorder) StreamAll(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamAll", reflect.TypeOf((*MockRepository)(nil).StreamAll), ctx, filter, fn)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(ctx context.Context, courierModifyEntity entities.CourierModify) (*entities.Courier, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +235,21 @@ func (mr *MockRepositoryMockRecorder) Update(ctx, courierModifyEntity any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, courierModifyEntity)
 }
 
+// UpdatePhone mocks base method.
+func (m *MockRepository) UpdatePhone(ctx context.Context, id int64, oldPhone, newPhone string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePhone", ctx, id, oldPhone, newPhone)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePhone indicates an expected call of UpdatePhone.
+func (mr *MockRepositoryMockRecorder) UpdatePhone(ctx, id, oldPhone, newPhone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePhone", reflect.TypeOf((*MockRepository)(nil).UpdatePhone), ctx, id, oldPhone, newPhone)
+}
+
 // MockTxManager is a mock of TxManager interface.
 type MockTxManager struct {
 	ctrl     *gomock.Controller
@@ -138,3 +287,78 @@ func (mr *MockTxManagerMockRecorder) Do(ctx, fn any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockTxManager)(nil).Do), ctx, fn)
 }
+
+// MockPhoneNormalizer is a mock of PhoneNormalizer interface.
+type MockPhoneNormalizer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPhoneNormalizerMockRecorder
+	isgomock struct{}
+}
+
+// MockPhoneNormalizerMockRecorder is the mock recorder for MockPhoneNormalizer.
+type MockPhoneNormalizerMockRecorder struct {
+	mock *MockPhoneNormalizer
+}
+
+// NewMockPhoneNormalizer creates a new mock instance.
+func NewMockPhoneNormalizer(ctrl *gomock.Controller) *MockPhoneNormalizer {
+	mock := &MockPhoneNormalizer{ctrl: ctrl}
+	mock.recorder = &MockPhoneNormalizerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPhoneNormalizer) EXPECT() *MockPhoneNormalizerMockRecorder {
+	return m.recorder
+}
+
+// Normalize mocks base method.
+func (m *MockPhoneNormalizer) Normalize(phone string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Normalize", phone)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Normalize indicates an expected call of Normalize.
+func (mr *MockPhoneNormalizerMockRecorder) Normalize(phone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Normalize", reflect.TypeOf((*MockPhoneNormalizer)(nil).Normalize), phone)
+}
+
+// MockAvailabilityNotifier is a mock of AvailabilityNotifier interface.
+type MockAvailabilityNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockAvailabilityNotifierMockRecorder
+	isgomock struct{}
+}
+
+// MockAvailabilityNotifierMockRecorder is the mock recorder for MockAvailabilityNotifier.
+type MockAvailabilityNotifierMockRecorder struct {
+	mock *MockAvailabilityNotifier
+}
+
+// NewMockAvailabilityNotifier creates a new mock instance.
+func NewMockAvailabilityNotifier(ctrl *gomock.Controller) *MockAvailabilityNotifier {
+	mock := &MockAvailabilityNotifier{ctrl: ctrl}
+	mock.recorder = &MockAvailabilityNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAvailabilityNotifier) EXPECT() *MockAvailabilityNotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockAvailabilityNotifier) Notify() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Notify")
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockAvailabilityNotifierMockRecorder) Notify() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockAvailabilityNotifier)(nil).Notify))
+}
@@ -3,6 +3,8 @@ package courier
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"service/internal/entities"
 )
@@ -10,12 +12,16 @@ import (
 type Courier struct {
 	repository Repository
 	txManager  TxManager
+	notifier   AvailabilityNotifier
+	phones     PhoneNormalizer
 }
 
-func New(repository Repository, txManager TxManager) *Courier {
+func New(repository Repository, txManager TxManager, notifier AvailabilityNotifier, phones PhoneNormalizer) *Courier {
 	return &Courier{
 		repository: repository,
 		txManager:  txManager,
+		notifier:   notifier,
+		phones:     phones,
 	}
 }
 
@@ -30,9 +36,11 @@ func (s *Courier) CreateCourier(ctx context.Context, courierModify entities.Cour
 	if !isValidName(*courierModify.Name) {
 		return 0, ErrInvalidName
 	}
-	if !isValidPhone(*courierModify.Phone) {
-		return 0, ErrInvalidPhone
+	phone, err := s.normalizePhone(*courierModify.Phone)
+	if err != nil {
+		return 0, err
 	}
+	courierModify.Phone = &phone
 	if !isValidStatus(courierModify.Status.String()) {
 		return 0, ErrInvalidStatus
 	}
@@ -59,8 +67,12 @@ func (s *Courier) UpdateCourier(ctx context.Context, courierModify entities.Cour
 	if courierModify.Name != nil && !isValidName(*courierModify.Name) {
 		return nil, ErrInvalidName
 	}
-	if courierModify.Phone != nil && !isValidPhone(*courierModify.Phone) {
-		return nil, ErrInvalidPhone
+	if courierModify.Phone != nil {
+		phone, err := s.normalizePhone(*courierModify.Phone)
+		if err != nil {
+			return nil, err
+		}
+		courierModify.Phone = &phone
 	}
 	if courierModify.Status != nil && !isValidStatus(courierModify.Status.String()) {
 		return nil, ErrInvalidStatus
@@ -68,11 +80,19 @@ func (s *Courier) UpdateCourier(ctx context.Context, courierModify entities.Cour
 	if courierModify.TransportType != nil && !isValidTransport(courierModify.TransportType.String()) {
 		return nil, ErrInvalidTransport
 	}
+	if courierModify.ExpectedVersion != nil && !isValidVersion(*courierModify.ExpectedVersion) {
+		return nil, ErrInvalidVersion
+	}
 
 	courier, err := s.repository.Update(ctx, courierModify)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update courier: %w", err)
 	}
+
+	// например, paused -> available: курьер может сразу забрать заказ из очереди ожидания
+	if courierModify.Status != nil && *courierModify.Status == entities.CourierAvailable {
+		s.notifier.Notify()
+	}
 	return courier, nil
 }
 
@@ -85,11 +105,110 @@ func (s *Courier) GetCourier(ctx context.Context, id int64) (*entities.Courier,
 	return courier, nil
 }
 
-func (s *Courier) GetCouriers(ctx context.Context) ([]entities.Courier, error) {
-	couriers, err := s.repository.GetAll(ctx)
+func (s *Courier) GetCouriers(ctx context.Context, filter entities.CourierListFilter) ([]entities.Courier, error) {
+	couriers, err := s.repository.GetAll(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get couriers: %w", err)
 	}
 
 	return couriers, nil
 }
+
+// DeactivateCourier мягкое удаление: курьер остается в БД ради истории доставок,
+// но больше не получает заказы. Курьера с активными доставками отключить нельзя.
+// Открытое предложение курьеру отменяется, и заказ предлагается другим курьерам
+func (s *Courier) DeactivateCourier(ctx context.Context, params entities.CourierDeactivateParams) (*entities.Courier, error) {
+	if params.ID <= 0 {
+		return nil, ErrInvalidCourierID
+	}
+	if !isValidDeactivationReason(params.Reason) {
+		return nil, ErrInvalidReason
+	}
+
+	var (
+		deactivated    *entities.Courier
+		offerCancelled bool
+	)
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		courier, err := s.repository.GetByIDForUpdate(ctx, params.ID)
+		if err != nil {
+			return fmt.Errorf("get courier: %w", err)
+		}
+		if courier.IsDeactivated() {
+			return ErrCourierDeactivated
+		}
+
+		hasActive, err := s.repository.HasActiveDeliveries(ctx, params.ID)
+		if err != nil {
+			return fmt.Errorf("check active deliveries: %w", err)
+		}
+		if hasActive {
+			return ErrCourierHasActiveDeliveries
+		}
+
+		deactivatedAt := time.Now().UTC()
+		offerCancelled, err = s.repository.CancelPendingOffers(ctx, params.ID, deactivatedAt)
+		if err != nil {
+			return fmt.Errorf("cancel pending offers: %w", err)
+		}
+
+		deactivated, err = s.repository.Deactivate(ctx, params.ID, strings.TrimSpace(params.Reason), deactivatedAt)
+		if err != nil {
+			return fmt.Errorf("deactivate courier: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// заказ из отмененного предложения ждет в очереди другого курьера
+	if offerCancelled {
+		s.notifier.Notify()
+	}
+	return deactivated, nil
+}
+
+// ReactivateCourier возвращает курьера в работу с прежним статусом.
+// Если его телефон за время отключения получил другой курьер, вернется ErrConflict
+func (s *Courier) ReactivateCourier(ctx context.Context, id int64) (*entities.Courier, error) {
+	if id <= 0 {
+		return nil, ErrInvalidCourierID
+	}
+
+	var reactivated *entities.Courier
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		courier, err := s.repository.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return fmt.Errorf("get courier: %w", err)
+		}
+		if !courier.IsDeactivated() {
+			return ErrCourierNotDeactivated
+		}
+
+		reactivated, err = s.repository.Reactivate(ctx, id)
+		if err != nil {
+			return fmt.Errorf("reactivate courier: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if reactivated.Status == entities.CourierAvailable {
+		s.notifier.Notify()
+	}
+	return reactivated, nil
+}
+
+// normalizePhone телефон хранится только в E.164: иначе "+7 900 000-00-00" и "89000000000"
+// обходят уникальный индекс как разные строки
+func (s *Courier) normalizePhone(phone string) (string, error) {
+	normalized, err := s.phones.Normalize(phone)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidPhone, err)
+	}
+
+	return normalized, nil
+}
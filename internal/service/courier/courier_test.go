@@ -11,18 +11,29 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"service/internal/entities"
+	"service/internal/pkg/phone"
 	"service/internal/service/courier"
 )
 
 type mock struct {
 	*MockRepository
 	*MockTxManager
+	*MockAvailabilityNotifier
+	// нормализация телефона - чистая функция, поэтому в тестах настоящая, а не мок
+	phones *phone.Normalizer
 }
 
 func newMock(ctrl *gomock.Controller) *mock {
+	phones, err := phone.NewNormalizer("RU")
+	if err != nil {
+		panic(err)
+	}
+
 	return &mock{
-		MockRepository: NewMockRepository(ctrl),
-		MockTxManager:  NewMockTxManager(ctrl),
+		MockRepository:           NewMockRepository(ctrl),
+		MockTxManager:            NewMockTxManager(ctrl),
+		MockAvailabilityNotifier: NewMockAvailabilityNotifier(ctrl),
+		phones:                   phones,
 	}
 }
 
@@ -97,15 +108,46 @@ func TestCourierService_CreateCourier(t *testing.T) {
 			assertion:  errorAssertion(courier.ErrInvalidName, ""),
 		},
 		{
-			name: "Отклонение создания курьера с номером телефона без кода страны",
+			name: "Телефон через 8 сохраняется в E.164",
 			modify: entities.CourierModify{
 				Name:          pointer.To("Test"),
-				Phone:         pointer.To("79161234567"),
+				Phone:         pointer.To("89161234567"),
 				Status:        pointer.To(entities.CourierAvailable),
 				TransportType: pointer.To(entities.Car),
 			},
-			expectedID: 0,
-			assertion:  errorAssertion(courier.ErrInvalidPhone, ""),
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), entities.CourierModify{
+						Name:          pointer.To("Test"),
+						Phone:         pointer.To("+79161234567"),
+						Status:        pointer.To(entities.CourierAvailable),
+						TransportType: pointer.To(entities.Car),
+					}).
+					Return(int64(2), nil)
+			},
+			expectedID: 2,
+			assertion:  require.NoError,
+		},
+		{
+			name: "Телефон с пробелами и дефисами сохраняется в E.164",
+			modify: entities.CourierModify{
+				Name:          pointer.To("Test"),
+				Phone:         pointer.To("+7 916 123-45-67"),
+				Status:        pointer.To(entities.CourierAvailable),
+				TransportType: pointer.To(entities.Car),
+			},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), entities.CourierModify{
+						Name:          pointer.To("Test"),
+						Phone:         pointer.To("+79161234567"),
+						Status:        pointer.To(entities.CourierAvailable),
+						TransportType: pointer.To(entities.Car),
+					}).
+					Return(int64(3), nil)
+			},
+			expectedID: 3,
+			assertion:  require.NoError,
 		},
 		{
 			name: "Отклонение создания курьера с номером телефона содержащим буквы",
@@ -119,10 +161,10 @@ func TestCourierService_CreateCourier(t *testing.T) {
 			assertion:  errorAssertion(courier.ErrInvalidPhone, ""),
 		},
 		{
-			name: "Отклонение создания курьера с номером телефона содержащим спецсимволы",
+			name: "Отклонение создания курьера с номером телефона неверной длины",
 			modify: entities.CourierModify{
 				Name:          pointer.To("Test"),
-				Phone:         pointer.To("+7916-123-45-67"),
+				Phone:         pointer.To("+7916123456"),
 				Status:        pointer.To(entities.CourierAvailable),
 				TransportType: pointer.To(entities.Car),
 			},
@@ -196,7 +238,7 @@ func TestCourierService_CreateCourier(t *testing.T) {
 				tt.mockSetup(m)
 			}
 
-			service := courier.New(m.MockRepository, m.MockTxManager)
+			service := courier.New(m.MockRepository, m.MockTxManager, m.MockAvailabilityNotifier, m.phones)
 			id, err := service.CreateCourier(context.Background(), tt.modify)
 
 			assert.Equal(t, tt.expectedID, id)
@@ -240,6 +282,50 @@ func TestCourierService_UpdateCourier(t *testing.T) {
 			expectedResult: existingCourier,
 			assertion:      require.NoError,
 		},
+		{
+			name: "Обновление с ожидаемой версией передается в репозиторий",
+			modify: entities.CourierModify{
+				ID:              pointer.To(int64(1)),
+				Status:          pointer.To(entities.CourierPaused),
+				ExpectedVersion: pointer.To(int64(2)),
+			},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Update(gomock.Any(), entities.CourierModify{
+						ID:              pointer.To(int64(1)),
+						Status:          pointer.To(entities.CourierPaused),
+						ExpectedVersion: pointer.To(int64(2)),
+					}).
+					Return(existingCourier, nil)
+			},
+			expectedResult: existingCourier,
+			assertion:      require.NoError,
+		},
+		{
+			name: "Курьера изменили параллельно",
+			modify: entities.CourierModify{
+				ID:              pointer.To(int64(1)),
+				Status:          pointer.To(entities.CourierPaused),
+				ExpectedVersion: pointer.To(int64(2)),
+			},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil, courier.ErrVersionMismatch)
+			},
+			expectedResult: nil,
+			assertion:      errorAssertion(courier.ErrVersionMismatch, "failed to update courier"),
+		},
+		{
+			name: "Отклонение обновления с невалидной ожидаемой версией",
+			modify: entities.CourierModify{
+				ID:              pointer.To(int64(1)),
+				Status:          pointer.To(entities.CourierPaused),
+				ExpectedVersion: pointer.To(int64(0)),
+			},
+			expectedResult: nil,
+			assertion:      errorAssertion(courier.ErrInvalidVersion, ""),
+		},
 		{
 			name: "Успешное обновление номера телефона курьера",
 			modify: entities.CourierModify{
@@ -268,6 +354,22 @@ func TestCourierService_UpdateCourier(t *testing.T) {
 			expectedResult: existingCourier,
 			assertion:      require.NoError,
 		},
+		{
+			name: "Успешное возвращение курьера с паузы уведомляет очередь ожидания",
+			modify: entities.CourierModify{
+				ID:     pointer.To(int64(1)),
+				Status: pointer.To(entities.CourierAvailable),
+			},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(existingCourier, nil)
+				m.MockAvailabilityNotifier.EXPECT().
+					Notify()
+			},
+			expectedResult: existingCourier,
+			assertion:      require.NoError,
+		},
 		{
 			name: "Успешное обновление типа транспорта курьера на 'самокат'",
 			modify: entities.CourierModify{
@@ -309,13 +411,21 @@ func TestCourierService_UpdateCourier(t *testing.T) {
 			assertion:      errorAssertion(courier.ErrInvalidName, ""),
 		},
 		{
-			name: "Отклонение обновления с номером телефона без кода страны",
+			name: "Телефон без плюса при обновлении сохраняется в E.164",
 			modify: entities.CourierModify{
 				ID:    pointer.To(int64(1)),
 				Phone: pointer.To("79264445566"),
 			},
-			expectedResult: nil,
-			assertion:      errorAssertion(courier.ErrInvalidPhone, ""),
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Update(gomock.Any(), entities.CourierModify{
+						ID:    pointer.To(int64(1)),
+						Phone: pointer.To("+79264445566"),
+					}).
+					Return(existingCourier, nil)
+			},
+			expectedResult: existingCourier,
+			assertion:      require.NoError,
 		},
 		{
 			name: "Отклонение обновления с номером телефона содержащим буквы",
@@ -389,7 +499,7 @@ func TestCourierService_UpdateCourier(t *testing.T) {
 
 			ctrl := gomock.NewController(t)
 			m := newMock(ctrl)
-			service := courier.New(m.MockRepository, m.MockTxManager)
+			service := courier.New(m.MockRepository, m.MockTxManager, m.MockAvailabilityNotifier, m.phones)
 
 			if tt.mockSetup != nil {
 				tt.mockSetup(m)
@@ -454,7 +564,7 @@ func TestCourierService_GetCourier(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			m := newMock(ctrl)
-			service := courier.New(m.MockRepository, m.MockTxManager)
+			service := courier.New(m.MockRepository, m.MockTxManager, m.MockAvailabilityNotifier, m.phones)
 
 			if tt.mockSetup != nil {
 				tt.mockSetup(m)
@@ -495,6 +605,7 @@ func TestCourierService_GetCouriers(t *testing.T) {
 
 	tests := []struct {
 		name           string
+		filter         entities.CourierListFilter
 		mockSetup      func(m *mock)
 		expectedResult []entities.Courier
 		assertion      require.ErrorAssertionFunc
@@ -503,7 +614,7 @@ func TestCourierService_GetCouriers(t *testing.T) {
 			name: "Успешное получение всех курьеров",
 			mockSetup: func(m *mock) {
 				m.MockRepository.EXPECT().
-					GetAll(gomock.Any()).
+					GetAll(gomock.Any(), entities.CourierListFilter{}).
 					Return(couriers, nil)
 			},
 			expectedResult: couriers,
@@ -513,7 +624,7 @@ func TestCourierService_GetCouriers(t *testing.T) {
 			name: "Покрытие обработки ошибок базы данных",
 			mockSetup: func(m *mock) {
 				m.MockRepository.EXPECT().
-					GetAll(gomock.Any()).
+					GetAll(gomock.Any(), entities.CourierListFilter{}).
 					Return(nil, errors.New("query execution failed"))
 			},
 			expectedResult: nil,
@@ -523,12 +634,348 @@ func TestCourierService_GetCouriers(t *testing.T) {
 			name: "Возврат пустого списка когда курьеры отсутствуют",
 			mockSetup: func(m *mock) {
 				m.MockRepository.EXPECT().
-					GetAll(gomock.Any()).
+					GetAll(gomock.Any(), entities.CourierListFilter{}).
 					Return([]entities.Courier{}, nil)
 			},
 			expectedResult: []entities.Courier{},
 			assertion:      require.NoError,
 		},
+		{
+			name:   "Фильтр передается в репозиторий",
+			filter: entities.CourierListFilter{IncludeDeactivated: true},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetAll(gomock.Any(), entities.CourierListFilter{IncludeDeactivated: true}).
+					Return(couriers, nil)
+			},
+			expectedResult: couriers,
+			assertion:      require.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+			service := courier.New(m.MockRepository, m.MockTxManager, m.MockAvailabilityNotifier, m.phones)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			result, err := service.GetCouriers(context.Background(), tt.filter)
+
+			assert.Equal(t, tt.expectedResult, result)
+			tt.assertion(t, err)
+		})
+	}
+}
+
+func TestCourierService_DeactivateCourier(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	activeCourier := &entities.Courier{
+		ID:            1,
+		Name:          "Barry Lyndon",
+		Phone:         "+79161234567",
+		Status:        entities.CourierAvailable,
+		TransportType: entities.Car,
+		CreatedAt:     fixedTime,
+		UpdatedAt:     fixedTime,
+	}
+	deactivatedCourier := &entities.Courier{
+		ID:                 1,
+		Name:               "Barry Lyndon",
+		Phone:              "+79161234567",
+		Status:             entities.CourierAvailable,
+		TransportType:      entities.Car,
+		CreatedAt:          fixedTime,
+		UpdatedAt:          fixedTime,
+		DeactivatedAt:      &fixedTime,
+		DeactivationReason: pointer.To("уволился"),
+	}
+
+	passthroughTx := func(m *mock) {
+		m.MockTxManager.EXPECT().
+			Do(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+
+	tests := []struct {
+		name           string
+		params         entities.CourierDeactivateParams
+		mockSetup      func(m *mock)
+		expectedResult *entities.Courier
+		assertion      require.ErrorAssertionFunc
+	}{
+		{
+			name:   "Успешное отключение курьера",
+			params: entities.CourierDeactivateParams{ID: 1, Reason: "  уволился "},
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(1)).
+					Return(activeCourier, nil)
+				m.MockRepository.EXPECT().
+					HasActiveDeliveries(gomock.Any(), int64(1)).
+					Return(false, nil)
+				m.MockRepository.EXPECT().
+					CancelPendingOffers(gomock.Any(), int64(1), gomock.Any()).
+					Return(false, nil)
+				m.MockRepository.EXPECT().
+					Deactivate(gomock.Any(), int64(1), "уволился", gomock.Any()).
+					Return(deactivatedCourier, nil)
+			},
+			expectedResult: deactivatedCourier,
+			assertion:      require.NoError,
+		},
+		{
+			name:   "Открытое предложение курьеру отменяется, заказ уходит другим курьерам",
+			params: entities.CourierDeactivateParams{ID: 1, Reason: "уволился"},
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(1)).
+					Return(activeCourier, nil)
+				m.MockRepository.EXPECT().
+					HasActiveDeliveries(gomock.Any(), int64(1)).
+					Return(false, nil)
+				m.MockRepository.EXPECT().
+					CancelPendingOffers(gomock.Any(), int64(1), gomock.Any()).
+					Return(true, nil)
+				m.MockRepository.EXPECT().
+					Deactivate(gomock.Any(), int64(1), "уволился", gomock.Any()).
+					Return(deactivatedCourier, nil)
+				m.MockAvailabilityNotifier.EXPECT().Notify()
+			},
+			expectedResult: deactivatedCourier,
+			assertion:      require.NoError,
+		},
+		{
+			name:   "Ошибка отмены открытого предложения",
+			params: entities.CourierDeactivateParams{ID: 1, Reason: "уволился"},
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(1)).
+					Return(activeCourier, nil)
+				m.MockRepository.EXPECT().
+					HasActiveDeliveries(gomock.Any(), int64(1)).
+					Return(false, nil)
+				m.MockRepository.EXPECT().
+					CancelPendingOffers(gomock.Any(), int64(1), gomock.Any()).
+					Return(false, errors.New("connection refused"))
+			},
+			expectedResult: nil,
+			assertion:      errorAssertion(nil, "cancel pending offers: connection refused"),
+		},
+		{
+			name:           "Невалидный ID курьера",
+			params:         entities.CourierDeactivateParams{ID: 0, Reason: "уволился"},
+			expectedResult: nil,
+			assertion:      errorAssertion(courier.ErrInvalidCourierID, ""),
+		},
+		{
+			name:           "Пустая причина отключения",
+			params:         entities.CourierDeactivateParams{ID: 1, Reason: "   "},
+			expectedResult: nil,
+			assertion:      errorAssertion(courier.ErrInvalidReason, ""),
+		},
+		{
+			name:   "Курьер не найден",
+			params: entities.CourierDeactivateParams{ID: 1, Reason: "уволился"},
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(1)).
+					Return(nil, courier.ErrCourierNotFound)
+			},
+			expectedResult: nil,
+			assertion:      errorAssertion(courier.ErrCourierNotFound, "get courier"),
+		},
+		{
+			name:   "Курьер уже отключен",
+			params: entities.CourierDeactivateParams{ID: 1, Reason: "уволился"},
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(1)).
+					Return(deactivatedCourier, nil)
+			},
+			expectedResult: nil,
+			assertion:      errorAssertion(courier.ErrCourierDeactivated, ""),
+		},
+		{
+			name:   "У курьера есть активные доставки",
+			params: entities.CourierDeactivateParams{ID: 1, Reason: "уволился"},
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(1)).
+					Return(activeCourier, nil)
+				m.MockRepository.EXPECT().
+					HasActiveDeliveries(gomock.Any(), int64(1)).
+					Return(true, nil)
+			},
+			expectedResult: nil,
+			assertion:      errorAssertion(courier.ErrCourierHasActiveDeliveries, ""),
+		},
+		{
+			name:   "Ошибка базы данных при отключении",
+			params: entities.CourierDeactivateParams{ID: 1, Reason: "уволился"},
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(1)).
+					Return(activeCourier, nil)
+				m.MockRepository.EXPECT().
+					HasActiveDeliveries(gomock.Any(), int64(1)).
+					Return(false, nil)
+				m.MockRepository.EXPECT().
+					CancelPendingOffers(gomock.Any(), int64(1), gomock.Any()).
+					Return(false, nil)
+				m.MockRepository.EXPECT().
+					Deactivate(gomock.Any(), int64(1), "уволился", gomock.Any()).
+					Return(nil, errors.New("connection refused"))
+			},
+			expectedResult: nil,
+			assertion:      errorAssertion(nil, "deactivate courier: connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+			service := courier.New(m.MockRepository, m.MockTxManager, m.MockAvailabilityNotifier, m.phones)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			result, err := service.DeactivateCourier(context.Background(), tt.params)
+
+			assert.Equal(t, tt.expectedResult, result)
+			tt.assertion(t, err)
+		})
+	}
+}
+
+func TestCourierService_ReactivateCourier(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	deactivatedCourier := func(status entities.CourierStatusType) *entities.Courier {
+		return &entities.Courier{
+			ID:                 1,
+			Name:               "Barry Lyndon",
+			Phone:              "+79161234567",
+			Status:             status,
+			TransportType:      entities.Car,
+			CreatedAt:          fixedTime,
+			UpdatedAt:          fixedTime,
+			DeactivatedAt:      &fixedTime,
+			DeactivationReason: pointer.To("уволился"),
+		}
+	}
+	activeCourier := func(status entities.CourierStatusType) *entities.Courier {
+		return &entities.Courier{
+			ID:            1,
+			Name:          "Barry Lyndon",
+			Phone:         "+79161234567",
+			Status:        status,
+			TransportType: entities.Car,
+			CreatedAt:     fixedTime,
+			UpdatedAt:     fixedTime,
+		}
+	}
+
+	passthroughTx := func(m *mock) {
+		m.MockTxManager.EXPECT().
+			Do(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+
+	tests := []struct {
+		name           string
+		id             int64
+		mockSetup      func(m *mock)
+		expectedResult *entities.Courier
+		assertion      require.ErrorAssertionFunc
+	}{
+		{
+			name: "Свободный курьер возвращается в работу и будит очередь ожидания",
+			id:   1,
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(1)).
+					Return(deactivatedCourier(entities.CourierAvailable), nil)
+				m.MockRepository.EXPECT().
+					Reactivate(gomock.Any(), int64(1)).
+					Return(activeCourier(entities.CourierAvailable), nil)
+				m.MockAvailabilityNotifier.EXPECT().Notify()
+			},
+			expectedResult: activeCourier(entities.CourierAvailable),
+			assertion:      require.NoError,
+		},
+		{
+			name: "Курьер на паузе возвращается без сигнала очереди",
+			id:   1,
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(1)).
+					Return(deactivatedCourier(entities.CourierPaused), nil)
+				m.MockRepository.EXPECT().
+					Reactivate(gomock.Any(), int64(1)).
+					Return(activeCourier(entities.CourierPaused), nil)
+			},
+			expectedResult: activeCourier(entities.CourierPaused),
+			assertion:      require.NoError,
+		},
+		{
+			name:           "Невалидный ID курьера",
+			id:             -1,
+			expectedResult: nil,
+			assertion:      errorAssertion(courier.ErrInvalidCourierID, ""),
+		},
+		{
+			name: "Курьер не отключен",
+			id:   1,
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(1)).
+					Return(activeCourier(entities.CourierAvailable), nil)
+			},
+			expectedResult: nil,
+			assertion:      errorAssertion(courier.ErrCourierNotDeactivated, ""),
+		},
+		{
+			name: "Телефон курьера занят другим активным курьером",
+			id:   1,
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(1)).
+					Return(deactivatedCourier(entities.CourierAvailable), nil)
+				m.MockRepository.EXPECT().
+					Reactivate(gomock.Any(), int64(1)).
+					Return(nil, courier.ErrConflict)
+			},
+			expectedResult: nil,
+			assertion:      errorAssertion(courier.ErrConflict, "reactivate courier"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -537,13 +984,13 @@ func TestCourierService_GetCouriers(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			m := newMock(ctrl)
-			service := courier.New(m.MockRepository, m.MockTxManager)
+			service := courier.New(m.MockRepository, m.MockTxManager, m.MockAvailabilityNotifier, m.phones)
 
 			if tt.mockSetup != nil {
 				tt.mockSetup(m)
 			}
 
-			result, err := service.GetCouriers(context.Background())
+			result, err := service.ReactivateCourier(context.Background(), tt.id)
 
 			assert.Equal(t, tt.expectedResult, result)
 			tt.assertion(t, err)
@@ -591,7 +1038,7 @@ func TestCourierService_ContextCancellation(t *testing.T) {
 				tt.mockSetup(ctx, m)
 			}
 
-			service := courier.New(m.MockRepository, m.MockTxManager)
+			service := courier.New(m.MockRepository, m.MockTxManager, m.MockAvailabilityNotifier, m.phones)
 			result, err := service.GetCourier(ctx, 1)
 
 			assert.Nil(t, result)
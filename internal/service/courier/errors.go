@@ -9,7 +9,21 @@ var (
 	ErrInvalidStatus         = errors.New("invalid status")
 	ErrInvalidPhone          = errors.New("invalid phone")
 	ErrInvalidTransport      = errors.New("invalid transport type")
+	ErrInvalidReason         = errors.New("invalid deactivation reason")
+	ErrInvalidImportMode     = errors.New("invalid import mode")
+	ErrInvalidVersion        = errors.New("invalid courier version")
+	ErrInvalidSkill          = errors.New("invalid courier skill")
+	ErrEmptyImport           = errors.New("import file has no rows")
 
 	ErrCourierNotFound = errors.New("courier not found")
 	ErrConflict        = errors.New("resource already exists")
+	ErrVersionMismatch = errors.New("courier was modified concurrently")
+
+	ErrCourierHasActiveDeliveries = errors.New("courier has active deliveries")
+	ErrCourierDeactivated         = errors.New("courier is deactivated")
+	ErrCourierNotDeactivated      = errors.New("courier is not deactivated")
+
+	// ошибки отдельных строк импорта, попадают в отчет
+	ErrDuplicatePhoneInFile = errors.New("phone is duplicated in the import file")
+	ErrPhoneInUse           = errors.New("phone is already used by an active courier")
 )
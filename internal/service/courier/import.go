@@ -0,0 +1,264 @@
+package courier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"service/internal/entities"
+)
+
+// importCandidate строка импорта, прошедшая проверки
+type importCandidate struct {
+	index  int
+	modify entities.CourierModify
+}
+
+// ImportCouriers создает курьеров из строк файла. Строки проверяются теми же правилами, что и при создании
+// одного курьера, плюс телефон не должен повторяться в файле и принадлежать активному курьеру.
+// В режиме all_or_nothing любая ошибочная строка отменяет весь импорт, в best_effort создаются все корректные строки.
+// При DryRun ничего не пишется, отчет показывает, что произошло бы
+func (s *Courier) ImportCouriers(
+	ctx context.Context,
+	rows []entities.CourierImportRow,
+	opts entities.CourierImportOptions,
+) (*entities.CourierImportReport, error) {
+	if opts.Mode == "" {
+		opts.Mode = entities.DefaultCourierImportMode
+	}
+	if !isValidImportMode(opts.Mode.String()) {
+		return nil, ErrInvalidImportMode
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmptyImport
+	}
+
+	report := &entities.CourierImportReport{
+		Mode:   opts.Mode,
+		DryRun: opts.DryRun,
+		Total:  len(rows),
+		Rows:   make([]entities.CourierImportRowResult, len(rows)),
+	}
+	for i, row := range rows {
+		report.Rows[i].Line = row.Line
+	}
+
+	candidates, err := s.validateImportRows(ctx, rows, report)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case opts.DryRun:
+		markImportRows(report, candidates, entities.CourierImportRowValid)
+	case opts.Mode == entities.CourierImportAllOrNothing && hasFailedRows(report):
+		markImportRows(report, candidates, entities.CourierImportRowSkipped)
+	case opts.Mode == entities.CourierImportAllOrNothing:
+		err = s.importAllOrNothing(ctx, candidates, report)
+	default:
+		err = s.importBestEffort(ctx, candidates, report)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	notify := false
+	for _, candidate := range candidates {
+		result := report.Rows[candidate.index]
+		if result.Status == entities.CourierImportRowCreated && *candidate.modify.Status == entities.CourierAvailable {
+			notify = true
+		}
+	}
+	for _, result := range report.Rows {
+		switch result.Status {
+		case entities.CourierImportRowCreated:
+			report.Created++
+		case entities.CourierImportRowFailed:
+			report.Failed++
+		}
+	}
+	report.Committed = report.Created > 0
+
+	if notify {
+		s.notifier.Notify()
+	}
+	return report, nil
+}
+
+// validateImportRows помечает ошибочные строки в отчете и возвращает остальные
+func (s *Courier) validateImportRows(
+	ctx context.Context,
+	rows []entities.CourierImportRow,
+	report *entities.CourierImportReport,
+) ([]importCandidate, error) {
+	candidates := make([]importCandidate, 0, len(rows))
+	// телефон -> строка, где он встретился первым
+	seenPhones := make(map[string]int, len(rows))
+
+	for i, row := range rows {
+		if row.ParseError != "" {
+			failImportRow(report, i, row.ParseError)
+			continue
+		}
+
+		status := entities.DefaultStatusType
+		if row.Status != "" {
+			status = entities.CourierStatusType(row.Status)
+		}
+		transportType := entities.DefaultTransportType
+		if row.TransportType != "" {
+			transportType = entities.CourierTransportType(row.TransportType)
+		}
+
+		// дубликаты ищутся по нормализованному телефону: "+7 900 000-00-00" и "89000000000" один номер
+		phone, phoneErr := s.phones.Normalize(row.Phone)
+
+		var err error
+		switch {
+		case !isValidName(row.Name):
+			err = ErrInvalidName
+		case phoneErr != nil:
+			err = ErrInvalidPhone
+		case !isValidStatus(status.String()):
+			err = ErrInvalidStatus
+		case !isValidTransport(transportType.String()):
+			err = ErrInvalidTransport
+		}
+		if err != nil {
+			failImportRow(report, i, err.Error())
+			continue
+		}
+
+		if firstLine, ok := seenPhones[phone]; ok {
+			failImportRow(report, i, fmt.Sprintf("%s: first seen on line %d", ErrDuplicatePhoneInFile, firstLine))
+			continue
+		}
+		seenPhones[phone] = row.Line
+
+		candidates = append(candidates, importCandidate{
+			index: i,
+			modify: entities.CourierModify{
+				Name:          &row.Name,
+				Phone:         &phone,
+				Status:        &status,
+				TransportType: &transportType,
+			},
+		})
+	}
+
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	phones := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		phones[i] = *candidate.modify.Phone
+	}
+
+	activePhones, err := s.repository.GetActivePhones(ctx, phones)
+	if err != nil {
+		return nil, fmt.Errorf("get active phones: %w", err)
+	}
+
+	free := candidates[:0]
+	for _, candidate := range candidates {
+		if _, ok := activePhones[*candidate.modify.Phone]; ok {
+			failImportRow(report, candidate.index, ErrPhoneInUse.Error())
+			continue
+		}
+		free = append(free, candidate)
+	}
+
+	return free, nil
+}
+
+// importAllOrNothing создает всех курьеров в одной транзакции. Телефон мог заняться после проверки,
+// тогда строка помечается ошибочной, а остальные пропущенными - транзакция откатывается целиком
+func (s *Courier) importAllOrNothing(ctx context.Context, candidates []importCandidate, report *entities.CourierImportReport) error {
+	ids := make([]int64, len(candidates))
+	conflictAt := -1
+
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		conflictAt = -1
+		for i, candidate := range candidates {
+			id, err := s.repository.Create(ctx, candidate.modify)
+			if err != nil {
+				if errors.Is(err, ErrConflict) {
+					conflictAt = i
+				}
+				return fmt.Errorf("create courier: %w", err)
+			}
+			ids[i] = id
+		}
+		return nil
+	})
+	if err != nil {
+		if conflictAt < 0 {
+			return err
+		}
+
+		for i, candidate := range candidates {
+			if i == conflictAt {
+				failImportRow(report, candidate.index, ErrPhoneInUse.Error())
+				continue
+			}
+			report.Rows[candidate.index].Status = entities.CourierImportRowSkipped
+		}
+		return nil
+	}
+
+	for i, candidate := range candidates {
+		report.Rows[candidate.index].Status = entities.CourierImportRowCreated
+		report.Rows[candidate.index].CourierID = ids[i]
+	}
+	return nil
+}
+
+// importBestEffort каждый курьер создается отдельно. Неожиданная ошибка прерывает импорт:
+// повторный запуск безопасен, уже созданные курьеры попадут в отчет как занятые телефоны
+func (s *Courier) importBestEffort(ctx context.Context, candidates []importCandidate, report *entities.CourierImportReport) error {
+	for _, candidate := range candidates {
+		id, err := s.repository.Create(ctx, candidate.modify)
+		if err != nil {
+			if errors.Is(err, ErrConflict) {
+				failImportRow(report, candidate.index, ErrPhoneInUse.Error())
+				continue
+			}
+			return fmt.Errorf("create courier: %w", err)
+		}
+
+		report.Rows[candidate.index].Status = entities.CourierImportRowCreated
+		report.Rows[candidate.index].CourierID = id
+	}
+	return nil
+}
+
+// ExportCouriers передает курьеров в fn по одному, для выгрузки без загрузки всей таблицы в память
+func (s *Courier) ExportCouriers(ctx context.Context, filter entities.CourierListFilter, fn func(courier entities.Courier) error) error {
+	err := s.repository.StreamAll(ctx, filter, fn)
+	if err != nil {
+		return fmt.Errorf("export couriers: %w", err)
+	}
+
+	return nil
+}
+
+func failImportRow(report *entities.CourierImportReport, index int, message string) {
+	report.Rows[index].Status = entities.CourierImportRowFailed
+	report.Rows[index].Error = message
+}
+
+func markImportRows(report *entities.CourierImportReport, candidates []importCandidate, status entities.CourierImportRowStatus) {
+	for _, candidate := range candidates {
+		report.Rows[candidate.index].Status = status
+	}
+}
+
+func hasFailedRows(report *entities.CourierImportReport) bool {
+	for _, result := range report.Rows {
+		if result.Status == entities.CourierImportRowFailed {
+			return true
+		}
+	}
+	return false
+}
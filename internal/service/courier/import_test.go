@@ -0,0 +1,340 @@
+package courier_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/service/courier"
+)
+
+func TestCourierService_ImportCouriers(t *testing.T) {
+	t.Parallel()
+
+	validRows := []entities.CourierImportRow{
+		{Line: 2, Name: "John Wick", Phone: "+79161234567", Status: "available", TransportType: "car"},
+		{Line: 3, Name: "Barry Lyndon", Phone: "+79161234568"},
+	}
+	johnModify := entities.CourierModify{
+		Name:          pointer.To("John Wick"),
+		Phone:         pointer.To("+79161234567"),
+		Status:        pointer.To(entities.CourierAvailable),
+		TransportType: pointer.To(entities.Car),
+	}
+	// пустые статус и транспорт заменяются значениями по умолчанию
+	barryModify := entities.CourierModify{
+		Name:          pointer.To("Barry Lyndon"),
+		Phone:         pointer.To("+79161234568"),
+		Status:        pointer.To(entities.DefaultStatusType),
+		TransportType: pointer.To(entities.DefaultTransportType),
+	}
+	invalidRows := []entities.CourierImportRow{
+		{Line: 2, Name: "John Wick", Phone: "+79161234567"},
+		{Line: 3, Name: "", Phone: "+79161234568"},
+		{Line: 4, Name: "Barry Lyndon", Phone: "+7916123456"},
+		{Line: 5, Name: "Jason Bourne", Phone: "+79161234570", Status: "sleeping"},
+		{Line: 6, Name: "Ethan Hunt", Phone: "+79161234571", TransportType: "plane"},
+		// тот же номер, что в строке 2, в другой записи
+		{Line: 7, Name: "James Bond", Phone: "8 916 123-45-67"},
+		{Line: 8, ParseError: "invalid JSON"},
+	}
+	invalidResults := []entities.CourierImportRowResult{
+		{Line: 3, Status: entities.CourierImportRowFailed, Error: courier.ErrInvalidName.Error()},
+		{Line: 4, Status: entities.CourierImportRowFailed, Error: courier.ErrInvalidPhone.Error()},
+		{Line: 5, Status: entities.CourierImportRowFailed, Error: courier.ErrInvalidStatus.Error()},
+		{Line: 6, Status: entities.CourierImportRowFailed, Error: courier.ErrInvalidTransport.Error()},
+		{Line: 7, Status: entities.CourierImportRowFailed, Error: courier.ErrDuplicatePhoneInFile.Error() + ": first seen on line 2"},
+		{Line: 8, Status: entities.CourierImportRowFailed, Error: "invalid JSON"},
+	}
+	validPhones := []string{"+79161234567", "+79161234568"}
+
+	passthroughTx := func(m *mock) {
+		m.MockTxManager.EXPECT().
+			Do(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+
+	tests := []struct {
+		name           string
+		rows           []entities.CourierImportRow
+		opts           entities.CourierImportOptions
+		mockSetup      func(m *mock)
+		expectedReport *entities.CourierImportReport
+		assertion      require.ErrorAssertionFunc
+	}{
+		{
+			name: "Все строки создаются в одной транзакции",
+			rows: validRows,
+			opts: entities.CourierImportOptions{},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetActivePhones(gomock.Any(), validPhones).
+					Return(map[string]struct{}{}, nil)
+				passthroughTx(m)
+				m.MockRepository.EXPECT().Create(gomock.Any(), johnModify).Return(int64(1), nil)
+				m.MockRepository.EXPECT().Create(gomock.Any(), barryModify).Return(int64(2), nil)
+				m.MockAvailabilityNotifier.EXPECT().Notify()
+			},
+			expectedReport: &entities.CourierImportReport{
+				Mode:      entities.CourierImportAllOrNothing,
+				Committed: true,
+				Total:     2,
+				Created:   2,
+				Rows: []entities.CourierImportRowResult{
+					{Line: 2, Status: entities.CourierImportRowCreated, CourierID: 1},
+					{Line: 3, Status: entities.CourierImportRowCreated, CourierID: 2},
+				},
+			},
+			assertion: require.NoError,
+		},
+		{
+			name: "Dry-run только проверяет строки",
+			rows: validRows,
+			opts: entities.CourierImportOptions{Mode: entities.CourierImportBestEffort, DryRun: true},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetActivePhones(gomock.Any(), validPhones).
+					Return(map[string]struct{}{"+79161234568": {}}, nil)
+			},
+			expectedReport: &entities.CourierImportReport{
+				Mode:   entities.CourierImportBestEffort,
+				DryRun: true,
+				Total:  2,
+				Failed: 1,
+				Rows: []entities.CourierImportRowResult{
+					{Line: 2, Status: entities.CourierImportRowValid},
+					{Line: 3, Status: entities.CourierImportRowFailed, Error: courier.ErrPhoneInUse.Error()},
+				},
+			},
+			assertion: require.NoError,
+		},
+		{
+			name: "all_or_nothing: ошибочные строки отменяют импорт",
+			rows: invalidRows,
+			opts: entities.CourierImportOptions{Mode: entities.CourierImportAllOrNothing},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetActivePhones(gomock.Any(), []string{"+79161234567"}).
+					Return(map[string]struct{}{}, nil)
+			},
+			expectedReport: &entities.CourierImportReport{
+				Mode:   entities.CourierImportAllOrNothing,
+				Total:  7,
+				Failed: 6,
+				Rows: append([]entities.CourierImportRowResult{
+					{Line: 2, Status: entities.CourierImportRowSkipped},
+				}, invalidResults...),
+			},
+			assertion: require.NoError,
+		},
+		{
+			name: "best_effort: корректные строки создаются",
+			rows: invalidRows,
+			opts: entities.CourierImportOptions{Mode: entities.CourierImportBestEffort},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetActivePhones(gomock.Any(), []string{"+79161234567"}).
+					Return(map[string]struct{}{}, nil)
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), entities.CourierModify{
+						Name:          pointer.To("John Wick"),
+						Phone:         pointer.To("+79161234567"),
+						Status:        pointer.To(entities.DefaultStatusType),
+						TransportType: pointer.To(entities.DefaultTransportType),
+					}).
+					Return(int64(1), nil)
+				m.MockAvailabilityNotifier.EXPECT().Notify()
+			},
+			expectedReport: &entities.CourierImportReport{
+				Mode:      entities.CourierImportBestEffort,
+				Committed: true,
+				Total:     7,
+				Created:   1,
+				Failed:    6,
+				Rows: append([]entities.CourierImportRowResult{
+					{Line: 2, Status: entities.CourierImportRowCreated, CourierID: 1},
+				}, invalidResults...),
+			},
+			assertion: require.NoError,
+		},
+		{
+			name: "all_or_nothing: телефон заняли после проверки, транзакция откатывается",
+			rows: validRows,
+			opts: entities.CourierImportOptions{Mode: entities.CourierImportAllOrNothing},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetActivePhones(gomock.Any(), validPhones).
+					Return(map[string]struct{}{}, nil)
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						err := fn(ctx)
+						require.Error(t, err)
+						return err
+					})
+				m.MockRepository.EXPECT().Create(gomock.Any(), johnModify).Return(int64(1), nil)
+				m.MockRepository.EXPECT().Create(gomock.Any(), barryModify).Return(int64(0), courier.ErrConflict)
+			},
+			expectedReport: &entities.CourierImportReport{
+				Mode:   entities.CourierImportAllOrNothing,
+				Total:  2,
+				Failed: 1,
+				Rows: []entities.CourierImportRowResult{
+					{Line: 2, Status: entities.CourierImportRowSkipped},
+					{Line: 3, Status: entities.CourierImportRowFailed, Error: courier.ErrPhoneInUse.Error()},
+				},
+			},
+			assertion: require.NoError,
+		},
+		{
+			name: "best_effort: курьер на паузе создается без сигнала очереди, конфликт попадает в отчет",
+			rows: []entities.CourierImportRow{
+				{Line: 1, Name: "John Wick", Phone: "+79161234567", Status: "paused"},
+				{Line: 2, Name: "Barry Lyndon", Phone: "+79161234568"},
+			},
+			opts: entities.CourierImportOptions{Mode: entities.CourierImportBestEffort},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetActivePhones(gomock.Any(), validPhones).
+					Return(map[string]struct{}{}, nil)
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), entities.CourierModify{
+						Name:          pointer.To("John Wick"),
+						Phone:         pointer.To("+79161234567"),
+						Status:        pointer.To(entities.CourierPaused),
+						TransportType: pointer.To(entities.DefaultTransportType),
+					}).
+					Return(int64(1), nil)
+				m.MockRepository.EXPECT().Create(gomock.Any(), barryModify).Return(int64(0), courier.ErrConflict)
+			},
+			expectedReport: &entities.CourierImportReport{
+				Mode:      entities.CourierImportBestEffort,
+				Committed: true,
+				Total:     2,
+				Created:   1,
+				Failed:    1,
+				Rows: []entities.CourierImportRowResult{
+					{Line: 1, Status: entities.CourierImportRowCreated, CourierID: 1},
+					{Line: 2, Status: entities.CourierImportRowFailed, Error: courier.ErrPhoneInUse.Error()},
+				},
+			},
+			assertion: require.NoError,
+		},
+		{
+			name: "best_effort: неожиданная ошибка прерывает импорт",
+			rows: validRows,
+			opts: entities.CourierImportOptions{Mode: entities.CourierImportBestEffort},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetActivePhones(gomock.Any(), validPhones).
+					Return(map[string]struct{}{}, nil)
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), johnModify).
+					Return(int64(0), errors.New("connection refused"))
+			},
+			expectedReport: nil,
+			assertion:      errorAssertion(nil, "create courier: connection refused"),
+		},
+		{
+			name:           "Неизвестный режим импорта",
+			rows:           validRows,
+			opts:           entities.CourierImportOptions{Mode: "sometimes"},
+			expectedReport: nil,
+			assertion:      errorAssertion(courier.ErrInvalidImportMode, ""),
+		},
+		{
+			name:           "Пустой файл",
+			rows:           []entities.CourierImportRow{},
+			opts:           entities.CourierImportOptions{},
+			expectedReport: nil,
+			assertion:      errorAssertion(courier.ErrEmptyImport, ""),
+		},
+		{
+			name: "Ошибка проверки занятых телефонов",
+			rows: validRows,
+			opts: entities.CourierImportOptions{},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetActivePhones(gomock.Any(), validPhones).
+					Return(nil, errors.New("connection refused"))
+			},
+			expectedReport: nil,
+			assertion:      errorAssertion(nil, "get active phones: connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+			service := courier.New(m.MockRepository, m.MockTxManager, m.MockAvailabilityNotifier, m.phones)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			report, err := service.ImportCouriers(context.Background(), tt.rows, tt.opts)
+
+			assert.Equal(t, tt.expectedReport, report)
+			tt.assertion(t, err)
+		})
+	}
+}
+
+func TestCourierService_ExportCouriers(t *testing.T) {
+	t.Parallel()
+
+	filter := entities.CourierListFilter{IncludeDeactivated: true}
+
+	tests := []struct {
+		name      string
+		mockSetup func(m *mock)
+		assertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Успешная выгрузка",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					StreamAll(gomock.Any(), filter, gomock.Any()).
+					Return(nil)
+			},
+			assertion: require.NoError,
+		},
+		{
+			name: "Ошибка репозитория",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					StreamAll(gomock.Any(), filter, gomock.Any()).
+					Return(errors.New("connection refused"))
+			},
+			assertion: errorAssertion(nil, "export couriers: connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			m := newMock(ctrl)
+			service := courier.New(m.MockRepository, m.MockTxManager, m.MockAvailabilityNotifier, m.phones)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			err := service.ExportCouriers(context.Background(), filter, func(entities.Courier) error { return nil })
+
+			tt.assertion(t, err)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package courier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"service/internal/entities"
+)
+
+type phoneChange struct {
+	courierID int64
+	oldPhone  string
+	newPhone  string
+}
+
+// NormalizePhones разовая миграция телефонов, сохраненных до нормализации.
+// Активные курьеры, чьи номера совпали после нормализации, только попадают в отчет.
+// Строки обновляются по одной без общей транзакции: сервис продолжает работать,
+// и конфликт с курьером, созданным во время миграции, не должен откатывать остальные
+func (s *Courier) NormalizePhones(ctx context.Context, dryRun bool) (*entities.PhoneNormalizationReport, error) {
+	report := &entities.PhoneNormalizationReport{DryRun: dryRun}
+
+	var changes []phoneChange
+	// E.164 -> активные курьеры с этим номером в любой записи
+	activeByPhone := make(map[string][]entities.CourierPhone)
+
+	err := s.repository.StreamAll(ctx, entities.CourierListFilter{IncludeDeactivated: true}, func(courier entities.Courier) error {
+		report.Total++
+		current := entities.CourierPhone{CourierID: courier.ID, Phone: courier.Phone}
+
+		normalized, err := s.phones.Normalize(courier.Phone)
+		if err != nil {
+			report.Invalid = append(report.Invalid, current)
+			return nil
+		}
+
+		// уникальный индекс только по активным курьерам, номер отключенного может повторяться
+		if !courier.IsDeactivated() {
+			activeByPhone[normalized] = append(activeByPhone[normalized], current)
+		}
+
+		if normalized == courier.Phone {
+			report.AlreadyNormalized++
+			return nil
+		}
+		changes = append(changes, phoneChange{courierID: courier.ID, oldPhone: courier.Phone, newPhone: normalized})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("stream couriers: %w", err)
+	}
+
+	colliding := make(map[int64]struct{})
+	for phone, couriers := range activeByPhone {
+		if len(couriers) < 2 {
+			continue
+		}
+		report.Collisions = append(report.Collisions, entities.PhoneCollision{Phone: phone, Couriers: couriers})
+		for _, courier := range couriers {
+			colliding[courier.CourierID] = struct{}{}
+		}
+	}
+
+	for _, change := range changes {
+		if _, ok := colliding[change.courierID]; ok {
+			continue
+		}
+		if dryRun {
+			report.Updated++
+			continue
+		}
+
+		updated, err := s.repository.UpdatePhone(ctx, change.courierID, change.oldPhone, change.newPhone)
+		switch {
+		case errors.Is(err, ErrConflict):
+			// номер занял курьер, созданный уже после чтения
+			report.Collisions = append(report.Collisions, entities.PhoneCollision{
+				Phone:    change.newPhone,
+				Couriers: []entities.CourierPhone{{CourierID: change.courierID, Phone: change.oldPhone}},
+			})
+		case err != nil:
+			return nil, fmt.Errorf("update phone of courier %d: %w", change.courierID, err)
+		case updated:
+			report.Updated++
+		default:
+			report.Skipped++
+		}
+	}
+
+	// порядок map случаен, а отчет читает человек
+	sort.Slice(report.Collisions, func(i, j int) bool {
+		return report.Collisions[i].Phone < report.Collisions[j].Phone
+	})
+	return report, nil
+}
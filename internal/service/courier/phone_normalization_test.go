@@ -0,0 +1,163 @@
+package courier_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/service/courier"
+)
+
+func TestCourierService_NormalizePhones(t *testing.T) {
+	t.Parallel()
+
+	deactivatedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	couriers := []entities.Courier{
+		{ID: 1, Phone: "+79161234567"},
+		{ID: 2, Phone: "8 916 123-45-68"},
+		// 3 и 4 после нормализации один номер
+		{ID: 3, Phone: "+7 916 123-45-69"},
+		{ID: 4, Phone: "89161234569"},
+		// у отключенного курьера номер может повторяться
+		{ID: 5, Phone: "89161234567", DeactivatedAt: pointer.To(deactivatedAt)},
+		{ID: 6, Phone: "12345"},
+	}
+	streamAll := func(m *mock) {
+		m.MockRepository.EXPECT().
+			StreamAll(gomock.Any(), entities.CourierListFilter{IncludeDeactivated: true}, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, filter entities.CourierListFilter, fn func(courier entities.Courier) error) error {
+				for _, c := range couriers {
+					err := fn(c)
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+	}
+	collisions := []entities.PhoneCollision{
+		{
+			Phone: "+79161234569",
+			Couriers: []entities.CourierPhone{
+				{CourierID: 3, Phone: "+7 916 123-45-69"},
+				{CourierID: 4, Phone: "89161234569"},
+			},
+		},
+	}
+	invalid := []entities.CourierPhone{{CourierID: 6, Phone: "12345"}}
+
+	tests := []struct {
+		name           string
+		dryRun         bool
+		mockSetup      func(m *mock)
+		expectedReport *entities.PhoneNormalizationReport
+		assertion      require.ErrorAssertionFunc
+	}{
+		{
+			name:   "Dry-run ничего не меняет",
+			dryRun: true,
+			mockSetup: func(m *mock) {
+				streamAll(m)
+			},
+			expectedReport: &entities.PhoneNormalizationReport{
+				DryRun:            true,
+				Total:             6,
+				AlreadyNormalized: 1,
+				Updated:           2,
+				Invalid:           invalid,
+				Collisions:        collisions,
+			},
+			assertion: require.NoError,
+		},
+		{
+			name: "Телефоны без коллизий переписываются",
+			mockSetup: func(m *mock) {
+				streamAll(m)
+				m.MockRepository.EXPECT().
+					UpdatePhone(gomock.Any(), int64(2), "8 916 123-45-68", "+79161234568").
+					Return(true, nil)
+				m.MockRepository.EXPECT().
+					UpdatePhone(gomock.Any(), int64(5), "89161234567", "+79161234567").
+					Return(true, nil)
+			},
+			expectedReport: &entities.PhoneNormalizationReport{
+				Total:             6,
+				AlreadyNormalized: 1,
+				Updated:           2,
+				Invalid:           invalid,
+				Collisions:        collisions,
+			},
+			assertion: require.NoError,
+		},
+		{
+			name: "Строку изменили во время миграции, номер заняли после чтения",
+			mockSetup: func(m *mock) {
+				streamAll(m)
+				m.MockRepository.EXPECT().
+					UpdatePhone(gomock.Any(), int64(2), "8 916 123-45-68", "+79161234568").
+					Return(false, courier.ErrConflict)
+				m.MockRepository.EXPECT().
+					UpdatePhone(gomock.Any(), int64(5), "89161234567", "+79161234567").
+					Return(false, nil)
+			},
+			expectedReport: &entities.PhoneNormalizationReport{
+				Total:             6,
+				AlreadyNormalized: 1,
+				Skipped:           1,
+				Invalid:           invalid,
+				Collisions: append([]entities.PhoneCollision{
+					{
+						Phone:    "+79161234568",
+						Couriers: []entities.CourierPhone{{CourierID: 2, Phone: "8 916 123-45-68"}},
+					},
+				}, collisions...),
+			},
+			assertion: require.NoError,
+		},
+		{
+			name: "Ошибка чтения курьеров",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					StreamAll(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			expectedReport: nil,
+			assertion:      errorAssertion(nil, "stream couriers"),
+		},
+		{
+			name: "Ошибка обновления телефона",
+			mockSetup: func(m *mock) {
+				streamAll(m)
+				m.MockRepository.EXPECT().
+					UpdatePhone(gomock.Any(), int64(2), gomock.Any(), gomock.Any()).
+					Return(false, errors.New("database error"))
+			},
+			expectedReport: nil,
+			assertion:      errorAssertion(nil, "update phone of courier 2"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := courier.New(m.MockRepository, m.MockTxManager, m.MockAvailabilityNotifier, m.phones)
+			report, err := service.NormalizePhones(context.Background(), tt.dryRun)
+
+			assert.Equal(t, tt.expectedReport, report)
+			tt.assertion(t, err)
+		})
+	}
+}
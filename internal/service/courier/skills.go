@@ -0,0 +1,65 @@
+package courier
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"service/internal/entities"
+)
+
+func (s *Courier) GetCourierSkills(ctx context.Context, id int64) ([]entities.CourierSkill, error) {
+	if id <= 0 {
+		return nil, ErrInvalidCourierID
+	}
+
+	_, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get courier: %w", err)
+	}
+
+	skills, err := s.repository.GetSkills(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get courier skills: %w", err)
+	}
+
+	return skills, nil
+}
+
+// SetCourierSkills заменяет набор навыков курьера целиком, повторы схлопываются.
+// Пустой набор снимает все навыки
+func (s *Courier) SetCourierSkills(ctx context.Context, id int64, skills []entities.CourierSkill) ([]entities.CourierSkill, error) {
+	if id <= 0 {
+		return nil, ErrInvalidCourierID
+	}
+	for _, skill := range skills {
+		if !isValidSkill(skill) {
+			return nil, ErrInvalidSkill
+		}
+	}
+
+	unique := slices.Clone(skills)
+	slices.Sort(unique)
+	unique = slices.Compact(unique)
+	if unique == nil {
+		unique = make([]entities.CourierSkill, 0)
+	}
+
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		_, err := s.repository.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return fmt.Errorf("get courier: %w", err)
+		}
+
+		err = s.repository.ReplaceSkills(ctx, id, unique)
+		if err != nil {
+			return fmt.Errorf("replace courier skills: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return unique, nil
+}
@@ -0,0 +1,201 @@
+package courier_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/service/courier"
+)
+
+func TestCourierService_GetCourierSkills(t *testing.T) {
+	t.Parallel()
+
+	existingCourier := &entities.Courier{ID: 1, Status: entities.CourierAvailable, TransportType: entities.Car}
+
+	tests := []struct {
+		name           string
+		id             int64
+		mockSetup      func(m *mock)
+		expectedResult []entities.CourierSkill
+		assertion      require.ErrorAssertionFunc
+	}{
+		{
+			name: "Навыки курьера",
+			id:   1,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetByID(gomock.Any(), int64(1)).
+					Return(existingCourier, nil)
+				m.MockRepository.EXPECT().
+					GetSkills(gomock.Any(), int64(1)).
+					Return([]entities.CourierSkill{entities.SkillAgeVerified}, nil)
+			},
+			expectedResult: []entities.CourierSkill{entities.SkillAgeVerified},
+			assertion:      require.NoError,
+		},
+		{
+			name:      "Невалидный ID курьера",
+			id:        0,
+			assertion: errorAssertion(courier.ErrInvalidCourierID, ""),
+		},
+		{
+			name: "Курьер не найден",
+			id:   1,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetByID(gomock.Any(), int64(1)).
+					Return(nil, courier.ErrCourierNotFound)
+			},
+			assertion: errorAssertion(courier.ErrCourierNotFound, "get courier"),
+		},
+		{
+			name: "Ошибка чтения навыков",
+			id:   1,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetByID(gomock.Any(), int64(1)).
+					Return(existingCourier, nil)
+				m.MockRepository.EXPECT().
+					GetSkills(gomock.Any(), int64(1)).
+					Return(nil, errors.New("db down"))
+			},
+			assertion: errorAssertion(nil, "get courier skills"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := courier.New(m.MockRepository, m.MockTxManager, m.MockAvailabilityNotifier, m.phones)
+			skills, err := service.GetCourierSkills(context.Background(), tt.id)
+
+			assert.Equal(t, tt.expectedResult, skills)
+			tt.assertion(t, err)
+		})
+	}
+}
+
+func TestCourierService_SetCourierSkills(t *testing.T) {
+	t.Parallel()
+
+	existingCourier := &entities.Courier{ID: 1, Status: entities.CourierAvailable, TransportType: entities.Car}
+
+	passthroughTx := func(m *mock) {
+		m.MockTxManager.EXPECT().
+			Do(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+
+	tests := []struct {
+		name           string
+		id             int64
+		skills         []entities.CourierSkill
+		mockSetup      func(m *mock)
+		expectedResult []entities.CourierSkill
+		assertion      require.ErrorAssertionFunc
+	}{
+		{
+			name:   "Навыки заменяются без повторов",
+			id:     1,
+			skills: []entities.CourierSkill{entities.SkillThermalBag, entities.SkillAgeVerified, entities.SkillThermalBag},
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(1)).
+					Return(existingCourier, nil)
+				m.MockRepository.EXPECT().
+					ReplaceSkills(gomock.Any(), int64(1), []entities.CourierSkill{entities.SkillAgeVerified, entities.SkillThermalBag}).
+					Return(nil)
+			},
+			expectedResult: []entities.CourierSkill{entities.SkillAgeVerified, entities.SkillThermalBag},
+			assertion:      require.NoError,
+		},
+		{
+			name:   "Пустой набор снимает все навыки",
+			id:     1,
+			skills: nil,
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(1)).
+					Return(existingCourier, nil)
+				m.MockRepository.EXPECT().
+					ReplaceSkills(gomock.Any(), int64(1), []entities.CourierSkill{}).
+					Return(nil)
+			},
+			expectedResult: []entities.CourierSkill{},
+			assertion:      require.NoError,
+		},
+		{
+			name:      "Невалидный ID курьера",
+			id:        0,
+			skills:    []entities.CourierSkill{entities.SkillThermalBag},
+			assertion: errorAssertion(courier.ErrInvalidCourierID, ""),
+		},
+		{
+			name:      "Неизвестный навык",
+			id:        1,
+			skills:    []entities.CourierSkill{entities.SkillThermalBag, "jetpack"},
+			assertion: errorAssertion(courier.ErrInvalidSkill, ""),
+		},
+		{
+			name:   "Курьер не найден",
+			id:     1,
+			skills: []entities.CourierSkill{entities.SkillThermalBag},
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(1)).
+					Return(nil, courier.ErrCourierNotFound)
+			},
+			assertion: errorAssertion(courier.ErrCourierNotFound, "get courier"),
+		},
+		{
+			name:   "Ошибка записи навыков",
+			id:     1,
+			skills: []entities.CourierSkill{entities.SkillThermalBag},
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(1)).
+					Return(existingCourier, nil)
+				m.MockRepository.EXPECT().
+					ReplaceSkills(gomock.Any(), int64(1), []entities.CourierSkill{entities.SkillThermalBag}).
+					Return(errors.New("db down"))
+			},
+			assertion: errorAssertion(nil, "replace courier skills"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := courier.New(m.MockRepository, m.MockTxManager, m.MockAvailabilityNotifier, m.phones)
+			skills, err := service.SetCourierSkills(context.Background(), tt.id, tt.skills)
+
+			assert.Equal(t, tt.expectedResult, skills)
+			tt.assertion(t, err)
+		})
+	}
+}
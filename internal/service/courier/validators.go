@@ -1,23 +1,15 @@
 package courier
 
-import "strings"
+import (
+	"strings"
 
-func isValidName(name string) bool {
-	return strings.TrimSpace(name) != ""
-}
+	"service/internal/entities"
+)
 
-func isValidPhone(phone string) bool {
-	phone = strings.TrimSpace((phone))
-	if !strings.HasPrefix(phone, "+") {
-		return false
-	}
+const maxDeactivationReasonLength = 500
 
-	for _, char := range phone[1:] {
-		if char < '0' || char > '9' {
-			return false
-		}
-	}
-	return true
+func isValidName(name string) bool {
+	return strings.TrimSpace(name) != ""
 }
 
 func isValidStatus(status string) bool {
@@ -37,3 +29,30 @@ func isValidTransport(status string) bool {
 		return false
 	}
 }
+
+func isValidDeactivationReason(reason string) bool {
+	return strings.TrimSpace(reason) != "" && len(reason) <= maxDeactivationReasonLength
+}
+
+// isValidVersion версии начинаются с 1
+func isValidVersion(version int64) bool {
+	return version > 0
+}
+
+func isValidImportMode(mode string) bool {
+	switch mode {
+	case "all_or_nothing", "best_effort":
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidSkill(skill entities.CourierSkill) bool {
+	switch skill {
+	case entities.SkillThermalBag, entities.SkillAgeVerified:
+		return true
+	default:
+		return false
+	}
+}
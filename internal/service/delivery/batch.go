@@ -0,0 +1,210 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"service/internal/entities"
+)
+
+type assignedOrder struct {
+	assignment     *entities.DeliveryAssignment
+	orderCreatedAt *time.Time
+	priority       entities.OrderPriority
+}
+
+// DeliveryAssignBatched назначает курьера новому заказу. С включенной группировкой заказ ресторана
+// не назначается сразу, а ждет в очереди до конца окна группировки, чтобы уехать одному курьеру
+// вместе с другими заказами ресторана. Приоритетный заказ группировки не ждет
+func (d *Delivery) DeliveryAssignBatched(ctx context.Context, params entities.DeliveryAssignParams) (*entities.DeliveryAssignment, error) {
+	if !d.batchPolicy.Enabled() || params.RestaurantID == "" || params.Priority.IsHigh() {
+		return d.DeliveryAssign(ctx, params)
+	}
+
+	start := time.Now()
+	err := d.holdForBatch(ctx, params)
+	observeAssignment(assignSourceRequest, params.Priority, nil, err, start)
+	return nil, err
+}
+
+func (d *Delivery) holdForBatch(ctx context.Context, params entities.DeliveryAssignParams) error {
+	if !isValidOrderID(params.OrderID) {
+		return ErrInvalidOrderID
+	}
+	if !isValidRoute(params.Route) {
+		return ErrInvalidRoute
+	}
+	if !isValidRequirements(params.Requirements) {
+		return ErrInvalidRequirements
+	}
+	if !isValidPriority(params.Priority) {
+		return ErrInvalidPriority
+	}
+
+	enqueuedAt := time.Now().UTC()
+	batchUntil := enqueuedAt.Add(d.batchPolicy.Window)
+	pendingModify := newPendingModify(params, enqueuedAt)
+	pendingModify.BatchUntil = &batchUntil
+
+	err := d.enqueueUnassigned(ctx, pendingModify)
+	if err != nil {
+		return err
+	}
+
+	DeliveryBatchOrdersTotal.WithLabelValues(batchOutcomeHeld).Inc()
+	return fmt.Errorf("%w: %w", ErrAssignmentPending, ErrHeldForBatch)
+}
+
+// assignBatch назначает одному курьеру заказ из головы очереди и ожидающие группировки заказы
+// того же ресторана. Заказ берется в группу, если курьер может выполнить требования всей группы
+// и успевает к обещанному клиенту времени с учетом остановок перед ним. Не попавшие в группу
+// заказы остаются в очереди. Вызывается в транзакции, вторым значением возвращает заказ,
+// который уже назначен в обход очереди
+func (d *Delivery) assignBatch(ctx context.Context, head *entities.PendingAssignment) ([]assignedOrder, string, error) {
+	candidates, err := d.pendingRepository.GetBatchForUpdate(ctx, head.RestaurantID, head.OrderID, d.batchPolicy.MaxOrders-1)
+	if err != nil {
+		return nil, "", fmt.Errorf("get pending batch: %w", err)
+	}
+
+	batch := []entities.PendingAssignment{*head}
+	requirements := head.Requirements
+	cashAmount := head.CashAmount
+	for _, candidate := range candidates {
+		merged, ok := mergeRequirements(requirements, candidate.Requirements)
+		if !ok {
+			DeliveryBatchOrdersTotal.WithLabelValues(batchOutcomeRequirementsExcluded).Inc()
+			continue
+		}
+		requirements = merged
+		cashAmount += candidate.CashAmount
+		batch = append(batch, candidate)
+	}
+
+	// лимит наличных действует, если наличными оплачен хотя бы один заказ группы
+	params := pendingToParams(head)
+	params.Requirements = requirements
+	params.CashAmount = cashAmount
+	courier, err := d.findCourierForAssignment(ctx, params, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	assignTime := time.Now().UTC()
+	deliveries := make([]*entities.Delivery, 0, len(batch))
+	included := make([]entities.PendingAssignment, 0, len(batch))
+	for _, pending := range batch {
+		deadline, ok, err := d.batchDeadline(ctx, courier.TransportType, &pending, len(deliveries), assignTime)
+		if err != nil {
+			return nil, "", err
+		}
+		if !ok {
+			DeliveryBatchOrdersTotal.WithLabelValues(batchOutcomeDeadlineExcluded).Inc()
+			continue
+		}
+
+		delivery, err := d.createDelivery(ctx, courier, pendingToParams(&pending), pending.EnqueuedAt, assignTime, deadline)
+		if err != nil {
+			if errors.Is(err, ErrOrderAlreadyAssigned) {
+				return nil, pending.OrderID, err
+			}
+			return nil, "", err
+		}
+
+		err = d.pendingRepository.Delete(ctx, pending.OrderID)
+		if err != nil {
+			return nil, "", fmt.Errorf("delete pending assignment: %w", err)
+		}
+
+		deliveries = append(deliveries, delivery)
+		included = append(included, pending)
+	}
+
+	updatedCourier, err := d.occupyCourier(ctx, courier)
+	if err != nil {
+		return nil, "", fmt.Errorf("update courier status: %w", err)
+	}
+
+	assigned := make([]assignedOrder, 0, len(deliveries))
+	for i, delivery := range deliveries {
+		assigned = append(assigned, assignedOrder{
+			assignment: &entities.DeliveryAssignment{
+				CourierID:     updatedCourier.ID,
+				OrderID:       delivery.OrderID,
+				AssignedAt:    delivery.AssignedAt,
+				Deadline:      delivery.Deadline,
+				TransportType: updatedCourier.TransportType,
+			},
+			orderCreatedAt: included[i].OrderCreatedAt,
+			priority:       entities.OrderPriorityFromPending(included[i].Priority),
+		})
+	}
+	return assigned, "", nil
+}
+
+// batchDeadline дедлайн заказа, перед которым курьер развозит stops других заказов группы.
+// Первый заказ группы получает обычный дедлайн. Для остальных обещанное клиенту время остается
+// дедлайном, только если курьер успевает к нему с учетом остановок, иначе заказ в группу не берется
+func (d *Delivery) batchDeadline(
+	ctx context.Context,
+	transportType entities.CourierTransportType,
+	pending *entities.PendingAssignment,
+	stops int,
+	assignTime time.Time,
+) (time.Time, bool, error) {
+	if stops == 0 {
+		deadline, err := d.calculateDeadline(ctx, transportType, pending.Route, pending.EstimatedDelivery, assignTime)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		return deadline, true, nil
+	}
+
+	deadline, err := d.timeFactory.CalculateDeadline(ctx, transportType, pending.Route, assignTime)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("calculate deadline: %w", err)
+	}
+	deadline = deadline.Add(time.Duration(stops) * d.batchPolicy.ExtraStopTime)
+
+	estimatedDelivery := pending.EstimatedDelivery
+	if estimatedDelivery != nil && estimatedDelivery.After(assignTime) {
+		if deadline.After(*estimatedDelivery) {
+			return time.Time{}, false, nil
+		}
+		return estimatedDelivery.UTC(), true, nil
+	}
+
+	return deadline, true, nil
+}
+
+// mergeRequirements требования к курьеру для двух заказов сразу: нужны навыки обоих заказов
+// и транспорт, допустимый для обоих. Если общего транспорта нет, заказы не группируются
+func mergeRequirements(a, b entities.OrderRequirements) (entities.OrderRequirements, bool) {
+	skills := slices.Clone(a.Skills)
+	for _, skill := range b.Skills {
+		if !slices.Contains(skills, skill) {
+			skills = append(skills, skill)
+		}
+	}
+
+	var transportTypes []entities.CourierTransportType
+	switch {
+	case len(a.TransportTypes) == 0:
+		transportTypes = slices.Clone(b.TransportTypes)
+	case len(b.TransportTypes) == 0:
+		transportTypes = slices.Clone(a.TransportTypes)
+	default:
+		for _, transportType := range a.TransportTypes {
+			if slices.Contains(b.TransportTypes, transportType) {
+				transportTypes = append(transportTypes, transportType)
+			}
+		}
+		if len(transportTypes) == 0 {
+			return entities.OrderRequirements{}, false
+		}
+	}
+
+	return entities.OrderRequirements{Skills: skills, TransportTypes: transportTypes}, true
+}
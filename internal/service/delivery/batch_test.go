@@ -0,0 +1,472 @@
+package delivery_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"service/internal/entities"
+	"service/internal/service/delivery"
+)
+
+var testBatchPolicy = delivery.BatchPolicy{
+	Window:        3 * time.Minute,
+	MaxOrders:     3,
+	ExtraStopTime: 10 * time.Minute,
+}
+
+func newBatchService(m *mock, cashPolicy delivery.CashPolicy) *delivery.Delivery {
+	return delivery.New(
+		m.MockRepository,
+		m.MockPendingRepository,
+		m.MockCourierService,
+		m.MockDeliveryTimeFactory,
+		m.MockTxManager,
+		m.MockAvailabilityNotifier,
+		m.MockZoneResolver,
+		delivery.ZonePolicy{},
+		m.MockOfferRepository,
+		delivery.OfferPolicy{},
+		testBatchPolicy,
+		delivery.DispatchPolicy{},
+		delivery.PriorityPolicy{},
+		m.MockScheduledRepository,
+		delivery.RatingPolicy{},
+		m.MockEarningsRecorder,
+		m.MockCashRecorder,
+		cashPolicy,
+	)
+}
+
+func TestDeliveryService_DeliveryAssignBatched(t *testing.T) {
+	t.Parallel()
+
+	availableCourier := &entities.Courier{
+		ID:            1,
+		Status:        entities.CourierAvailable,
+		TransportType: entities.Car,
+	}
+
+	tests := []struct {
+		name           string
+		params         entities.DeliveryAssignParams
+		mockSetup      func(m *mock)
+		expectAssigned bool
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Заказ ресторана ждет в очереди окно группировки",
+			params: entities.DeliveryAssignParams{
+				OrderID:      "order-2026-001",
+				RestaurantID: "restaurant-1",
+			},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderID(gomock.Any(), "order-2026-001").
+					Return(nil, delivery.ErrDeliveryNotFound)
+				m.MockPendingRepository.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, modify entities.PendingAssignmentModify) (*entities.PendingAssignment, error) {
+						assert.Equal(t, "restaurant-1", *modify.RestaurantID)
+						require.NotNil(t, modify.BatchUntil)
+						assert.Equal(t, testBatchPolicy.Window, modify.BatchUntil.Sub(*modify.EnqueuedAt))
+						return &entities.PendingAssignment{ID: 1, OrderID: "order-2026-001"}, nil
+					})
+			},
+			errorAssertion: errorAssertion(delivery.ErrHeldForBatch, "assignment pending: order held for batching"),
+		},
+		{
+			name: "Уже назначенный заказ не ставится в очередь",
+			params: entities.DeliveryAssignParams{
+				OrderID:      "order-2026-001",
+				RestaurantID: "restaurant-1",
+			},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderID(gomock.Any(), "order-2026-001").
+					Return(&entities.Delivery{ID: 1, OrderID: "order-2026-001"}, nil)
+			},
+			errorAssertion: errorAssertion(delivery.ErrOrderAlreadyAssigned, ""),
+		},
+		{
+			name: "Невалидный ID заказа",
+			params: entities.DeliveryAssignParams{
+				OrderID:      " ",
+				RestaurantID: "restaurant-1",
+			},
+			mockSetup:      func(m *mock) {},
+			errorAssertion: errorAssertion(delivery.ErrInvalidOrderID, ""),
+		},
+		{
+			name: "Заказ без ресторана назначается сразу",
+			params: entities.DeliveryAssignParams{
+				OrderID: "order-2026-001",
+			},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(availableCourier, nil)
+				m.MockDeliveryTimeFactory.EXPECT().
+					CalculateDeadline(gomock.Any(), entities.Car, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+						return baseTime.Add(5 * time.Minute), nil
+					})
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, modify entities.DeliveryModify) (*entities.Delivery, error) {
+						return &entities.Delivery{
+							ID:         1,
+							CourierID:  *modify.CourierID,
+							OrderID:    *modify.OrderID,
+							AssignedAt: *modify.AssignedAt,
+							Deadline:   *modify.Deadline,
+						}, nil
+					})
+				m.MockCourierService.EXPECT().
+					UpdateCourier(gomock.Any(), gomock.Any()).
+					Return(availableCourier, nil)
+			},
+			expectAssigned: true,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Приоритетный заказ ресторана не ждет группировки",
+			params: entities.DeliveryAssignParams{
+				OrderID:      "order-2026-001",
+				RestaurantID: "restaurant-1",
+				Priority:     entities.PriorityHigh,
+			},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{PreferFastTransport: true}).
+					Return(availableCourier, nil)
+				m.MockDeliveryTimeFactory.EXPECT().
+					CalculateDeadline(gomock.Any(), entities.Car, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+						return baseTime.Add(5 * time.Minute), nil
+					})
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, modify entities.DeliveryModify) (*entities.Delivery, error) {
+						return &entities.Delivery{
+							ID:         1,
+							CourierID:  *modify.CourierID,
+							OrderID:    *modify.OrderID,
+							AssignedAt: *modify.AssignedAt,
+							Deadline:   *modify.Deadline,
+						}, nil
+					})
+				m.MockCourierService.EXPECT().
+					UpdateCourier(gomock.Any(), gomock.Any()).
+					Return(availableCourier, nil)
+			},
+			expectAssigned: true,
+			errorAssertion: require.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			tt.mockSetup(m)
+
+			result, err := newBatchService(m, delivery.CashPolicy{}).DeliveryAssignBatched(context.Background(), tt.params)
+
+			tt.errorAssertion(t, err, tt.name)
+			if tt.expectAssigned {
+				require.NotNil(t, result)
+				assert.Equal(t, availableCourier.ID, result.CourierID)
+			} else {
+				assert.Nil(t, result)
+			}
+		})
+	}
+}
+
+func TestDeliveryService_AssignPendingDeliveries_Batch(t *testing.T) {
+	t.Parallel()
+
+	enqueuedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	batchUntil := enqueuedAt.Add(testBatchPolicy.Window)
+
+	availableCourier := &entities.Courier{
+		ID:            1,
+		Status:        entities.CourierAvailable,
+		TransportType: entities.Scooter,
+	}
+
+	head := &entities.PendingAssignment{
+		ID:           1,
+		OrderID:      "order-2026-001",
+		RestaurantID: "restaurant-1",
+		EnqueuedAt:   enqueuedAt,
+		BatchUntil:   &batchUntil,
+	}
+
+	newCandidate := func(orderID string, estimatedDelivery *time.Time, requirements entities.OrderRequirements) entities.PendingAssignment {
+		return entities.PendingAssignment{
+			ID:                2,
+			OrderID:           orderID,
+			RestaurantID:      "restaurant-1",
+			EstimatedDelivery: estimatedDelivery,
+			Requirements:      requirements,
+			EnqueuedAt:        enqueuedAt.Add(time.Minute),
+			BatchUntil:        &batchUntil,
+		}
+	}
+
+	expectQueue := func(m *mock, candidates []entities.PendingAssignment) {
+		gomock.InOrder(
+			m.MockPendingRepository.EXPECT().
+				GetNextForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(head, nil),
+			m.MockPendingRepository.EXPECT().
+				GetBatchForUpdate(gomock.Any(), "restaurant-1", head.OrderID, testBatchPolicy.MaxOrders-1).
+				Return(candidates, nil),
+		)
+		m.MockPendingRepository.EXPECT().
+			GetNextForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, delivery.ErrPendingQueueEmpty)
+	}
+
+	expectDeadline := func(m *mock, times int) {
+		m.MockDeliveryTimeFactory.EXPECT().
+			CalculateDeadline(gomock.Any(), entities.Scooter, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+				return baseTime.Add(20 * time.Minute), nil
+			}).
+			Times(times)
+	}
+
+	// expectCreate проверяет, что дедлайн каждого заказа группы отсчитывается от общего времени назначения
+	// с учетом остановок перед ним
+	expectCreate := func(t *testing.T, m *mock, deadlines map[string]func(assignedAt time.Time) time.Time) {
+		m.MockRepository.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, modify entities.DeliveryModify) (*entities.Delivery, error) {
+				expectedDeadline, ok := deadlines[*modify.OrderID]
+				require.True(t, ok, "unexpected order %s", *modify.OrderID)
+				assert.Equal(t, expectedDeadline(*modify.AssignedAt), *modify.Deadline)
+				assert.Equal(t, availableCourier.ID, *modify.CourierID)
+				return &entities.Delivery{
+					ID:         1,
+					CourierID:  *modify.CourierID,
+					OrderID:    *modify.OrderID,
+					AssignedAt: *modify.AssignedAt,
+					Deadline:   *modify.Deadline,
+				}, nil
+			}).
+			Times(len(deadlines))
+		for orderID := range deadlines {
+			m.MockPendingRepository.EXPECT().
+				Delete(gomock.Any(), orderID).
+				Return(nil)
+		}
+		m.MockCourierService.EXPECT().
+			UpdateCourier(gomock.Any(), gomock.Any()).
+			Return(availableCourier, nil)
+	}
+
+	headDeadline := func(assignedAt time.Time) time.Time {
+		return assignedAt.Add(20 * time.Minute)
+	}
+
+	tests := []struct {
+		name           string
+		cashPolicy     delivery.CashPolicy
+		mockSetup      func(t *testing.T, m *mock)
+		expectedCount  int64
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Заказы ресторана назначаются одному курьеру с учетом дополнительной остановки",
+			mockSetup: func(t *testing.T, m *mock) {
+				expectTx(m)
+				expectTx(m)
+				expectQueue(m, []entities.PendingAssignment{
+					newCandidate("order-2026-002", nil, entities.OrderRequirements{}),
+				})
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(availableCourier, nil)
+				expectDeadline(m, 2)
+				expectCreate(t, m, map[string]func(assignedAt time.Time) time.Time{
+					"order-2026-001": headDeadline,
+					"order-2026-002": func(assignedAt time.Time) time.Time {
+						return assignedAt.Add(20*time.Minute + testBatchPolicy.ExtraStopTime)
+					},
+				})
+			},
+			expectedCount:  2,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Обещанное клиенту время остается дедлайном, если курьер успевает с остановкой",
+			mockSetup: func(t *testing.T, m *mock) {
+				estimatedDelivery := time.Now().UTC().Add(2 * time.Hour)
+
+				expectTx(m)
+				expectTx(m)
+				expectQueue(m, []entities.PendingAssignment{
+					newCandidate("order-2026-002", &estimatedDelivery, entities.OrderRequirements{}),
+				})
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(availableCourier, nil)
+				expectDeadline(m, 2)
+				expectCreate(t, m, map[string]func(assignedAt time.Time) time.Time{
+					"order-2026-001": headDeadline,
+					"order-2026-002": func(time.Time) time.Time {
+						return estimatedDelivery
+					},
+				})
+			},
+			expectedCount:  2,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Заказ, к обещанному времени которого курьер не успеет с остановкой, остается в очереди",
+			mockSetup: func(t *testing.T, m *mock) {
+				estimatedDelivery := time.Now().UTC().Add(25 * time.Minute)
+
+				expectTx(m)
+				expectTx(m)
+				expectQueue(m, []entities.PendingAssignment{
+					newCandidate("order-2026-002", &estimatedDelivery, entities.OrderRequirements{}),
+				})
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(availableCourier, nil)
+				expectDeadline(m, 2)
+				expectCreate(t, m, map[string]func(assignedAt time.Time) time.Time{
+					"order-2026-001": headDeadline,
+				})
+			},
+			expectedCount:  1,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Курьер подбирается по требованиям всех заказов группы, несовместимый заказ остается в очереди",
+			mockSetup: func(t *testing.T, m *mock) {
+				expectTx(m)
+				expectTx(m)
+				expectQueue(m, []entities.PendingAssignment{
+					newCandidate("order-2026-002", nil, entities.OrderRequirements{
+						Skills:         []entities.CourierSkill{entities.SkillThermalBag},
+						TransportTypes: []entities.CourierTransportType{entities.Scooter, entities.Car},
+					}),
+					newCandidate("order-2026-003", nil, entities.OrderRequirements{
+						TransportTypes: []entities.CourierTransportType{entities.OnFoot},
+					}),
+				})
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{
+						Skills:         []entities.CourierSkill{entities.SkillThermalBag},
+						TransportTypes: []entities.CourierTransportType{entities.Scooter, entities.Car},
+					}).
+					Return(availableCourier, nil)
+				expectDeadline(m, 2)
+				expectCreate(t, m, map[string]func(assignedAt time.Time) time.Time{
+					"order-2026-001": headDeadline,
+					"order-2026-002": func(assignedAt time.Time) time.Time {
+						return assignedAt.Add(20*time.Minute + testBatchPolicy.ExtraStopTime)
+					},
+				})
+			},
+			expectedCount:  2,
+			errorAssertion: require.NoError,
+		},
+		{
+			name:       "Лимит наличных действует, если наличными оплачен заказ группы не из головы очереди",
+			cashPolicy: testCashPolicy,
+			mockSetup: func(t *testing.T, m *mock) {
+				cashCandidate := newCandidate("order-2026-002", nil, entities.OrderRequirements{})
+				cashCandidate.CashAmount = 150000
+
+				expectTx(m)
+				expectTx(m)
+				expectQueue(m, []entities.PendingAssignment{cashCandidate})
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{
+						MaxCashBalance: &testCashPolicy.BalanceLimit,
+					}).
+					Return(availableCourier, nil)
+				expectDeadline(m, 2)
+				expectCreate(t, m, map[string]func(assignedAt time.Time) time.Time{
+					"order-2026-001": headDeadline,
+					"order-2026-002": func(assignedAt time.Time) time.Time {
+						return assignedAt.Add(20*time.Minute + testBatchPolicy.ExtraStopTime)
+					},
+				})
+			},
+			expectedCount:  2,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Без свободных курьеров группа остается в очереди",
+			mockSetup: func(t *testing.T, m *mock) {
+				expectTx(m)
+				m.MockPendingRepository.EXPECT().
+					GetNextForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(head, nil)
+				m.MockPendingRepository.EXPECT().
+					GetBatchForUpdate(gomock.Any(), "restaurant-1", head.OrderID, testBatchPolicy.MaxOrders-1).
+					Return(nil, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					ExplainCourierMismatch(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(&entities.CourierMismatch{}, nil)
+			},
+			expectedCount:  0,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Уже назначенный в обход очереди заказ группы удаляется из очереди",
+			mockSetup: func(t *testing.T, m *mock) {
+				expectTx(m)
+				expectTx(m)
+				expectQueue(m, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(availableCourier, nil)
+				expectDeadline(m, 1)
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, delivery.ErrOrderAlreadyAssigned)
+				m.MockPendingRepository.EXPECT().
+					Delete(gomock.Any(), head.OrderID).
+					Return(nil)
+			},
+			expectedCount:  0,
+			errorAssertion: require.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			tt.mockSetup(t, m)
+
+			count, err := newBatchService(m, tt.cashPolicy).AssignPendingDeliveries(context.Background())
+
+			tt.errorAssertion(t, err, tt.name)
+			assert.Equal(t, tt.expectedCount, count)
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package delivery_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"service/internal/entities"
+	"service/internal/service/delivery"
+)
+
+var testCashPolicy = delivery.CashPolicy{BalanceLimit: 500000}
+
+func newCashService(m *mock, dispatchPolicy delivery.DispatchPolicy, policy delivery.CashPolicy) *delivery.Delivery {
+	return delivery.New(
+		m.MockRepository,
+		m.MockPendingRepository,
+		m.MockCourierService,
+		m.MockDeliveryTimeFactory,
+		m.MockTxManager,
+		m.MockAvailabilityNotifier,
+		m.MockZoneResolver,
+		delivery.ZonePolicy{},
+		m.MockOfferRepository,
+		delivery.OfferPolicy{},
+		delivery.BatchPolicy{},
+		dispatchPolicy,
+		delivery.PriorityPolicy{},
+		m.MockScheduledRepository,
+		delivery.RatingPolicy{},
+		m.MockEarningsRecorder,
+		m.MockCashRecorder,
+		policy,
+	)
+}
+
+func TestCashPolicy_Refuses(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		policy     delivery.CashPolicy
+		balance    int64
+		cashAmount int64
+		expected   bool
+	}{
+		{
+			name:       "Остаток больше лимита",
+			policy:     testCashPolicy,
+			balance:    500001,
+			cashAmount: 100000,
+			expected:   true,
+		},
+		{
+			name:       "Остаток ровно на лимите",
+			policy:     testCashPolicy,
+			balance:    500000,
+			cashAmount: 100000,
+			expected:   false,
+		},
+		{
+			name:       "Заказ оплачен не наличными",
+			policy:     testCashPolicy,
+			balance:    900000,
+			cashAmount: 0,
+			expected:   false,
+		},
+		{
+			name:       "Лимит отключен",
+			policy:     delivery.CashPolicy{},
+			balance:    900000,
+			cashAmount: 100000,
+			expected:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, tt.policy.Refuses(tt.balance, tt.cashAmount))
+		})
+	}
+}
+
+func TestDeliveryService_DeliveryAssign_Cash(t *testing.T) {
+	t.Parallel()
+
+	courier := &entities.Courier{ID: 1, Status: entities.CourierAvailable, TransportType: entities.Car, Version: 1}
+	cashFilter := entities.CourierSearchFilter{MaxCashBalance: &testCashPolicy.BalanceLimit}
+
+	expectAssign := func(m *mock, cashAmount int64) {
+		m.MockDeliveryTimeFactory.EXPECT().
+			CalculateDeadline(gomock.Any(), courier.TransportType, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+				return baseTime.Add(30 * time.Minute), nil
+			})
+		m.MockRepository.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, modify entities.DeliveryModify) (*entities.Delivery, error) {
+				assert.Equal(t, cashAmount, modify.CashAmount)
+				return &entities.Delivery{
+					ID:         1,
+					CourierID:  *modify.CourierID,
+					OrderID:    *modify.OrderID,
+					AssignedAt: *modify.AssignedAt,
+					Deadline:   *modify.Deadline,
+				}, nil
+			})
+		m.MockCourierService.EXPECT().
+			UpdateCourier(gomock.Any(), gomock.Any()).
+			Return(courier, nil)
+	}
+
+	tests := []struct {
+		name              string
+		cashAmount        int64
+		policy            delivery.CashPolicy
+		mockSetup         func(m *mock)
+		expectedCourierID int64
+		errorAssertion    require.ErrorAssertionFunc
+	}{
+		{
+			name:       "Заказ с оплатой наличными получает курьер с остатком не больше лимита",
+			cashAmount: 150000,
+			policy:     testCashPolicy,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), cashFilter).
+					Return(courier, nil)
+				expectAssign(m, 150000)
+			},
+			expectedCourierID: courier.ID,
+			errorAssertion:    require.NoError,
+		},
+		{
+			name:       "Заказ без наличных назначается без учета остатка",
+			cashAmount: 0,
+			policy:     testCashPolicy,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(courier, nil)
+				expectAssign(m, 0)
+			},
+			expectedCourierID: courier.ID,
+			errorAssertion:    require.NoError,
+		},
+		{
+			name:       "Лимит отключен",
+			cashAmount: 150000,
+			policy:     delivery.CashPolicy{},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(courier, nil)
+				expectAssign(m, 150000)
+			},
+			expectedCourierID: courier.ID,
+			errorAssertion:    require.NoError,
+		},
+		{
+			name:       "Заказ уходит в очередь с наличными, если все курьеры сверх лимита",
+			cashAmount: 150000,
+			policy:     testCashPolicy,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), cashFilter).
+					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					ExplainCourierMismatch(gomock.Any(), cashFilter).
+					Return(&entities.CourierMismatch{
+						AvailableCouriers: 2,
+						Requirements: []entities.RequirementMatch{
+							{Requirement: entities.RequirementCashBalance, Value: "500000", MatchingCouriers: 0},
+						},
+					}, nil)
+				m.MockPendingRepository.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, modify entities.PendingAssignmentModify) (*entities.PendingAssignment, error) {
+						assert.Equal(t, int64(150000), modify.CashAmount)
+						return &entities.PendingAssignment{ID: 1, OrderID: *modify.OrderID}, nil
+					})
+			},
+			errorAssertion: func(t require.TestingT, err error, msgAndArgs ...interface{}) {
+				errorAssertion(delivery.ErrAssignmentPending, "")(t, err, msgAndArgs...)
+
+				var noMatch *delivery.NoCourierMatchError
+				require.ErrorAs(t, err, &noMatch, msgAndArgs...)
+				assert.Equal(t, entities.RequirementCashBalance, noMatch.Mismatch.Requirements[0].Requirement, msgAndArgs...)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			tt.mockSetup(m)
+
+			result, err := newCashService(m, delivery.DispatchPolicy{}, tt.policy).DeliveryAssign(context.Background(), entities.DeliveryAssignParams{
+				OrderID:    "order-2026-001",
+				CashAmount: tt.cashAmount,
+			})
+
+			tt.errorAssertion(t, err)
+			if tt.expectedCourierID != 0 {
+				require.NotNil(t, result)
+				assert.Equal(t, tt.expectedCourierID, result.CourierID)
+			}
+		})
+	}
+}
+
+func TestDeliveryService_DispatchPendingDeliveries_Cash(t *testing.T) {
+	t.Parallel()
+
+	enqueuedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	// у курьера на машине наличных больше лимита, пеший курьер ехал бы дольше, но лимит не превышен
+	carCourier := entities.DispatchCourier{
+		Courier:     entities.Courier{ID: 1, Status: entities.CourierAvailable, TransportType: entities.Car},
+		CashBalance: 600000,
+	}
+	footCourier := entities.DispatchCourier{
+		Courier:     entities.Courier{ID: 2, Status: entities.CourierAvailable, TransportType: entities.OnFoot},
+		CashBalance: 100000,
+	}
+	cashOrder := entities.PendingAssignment{ID: 1, OrderID: "order-cash", CashAmount: 150000, EnqueuedAt: enqueuedAt}
+
+	travelTimes := map[entities.CourierTransportType]time.Duration{
+		entities.Car:    5 * time.Minute,
+		entities.OnFoot: 15 * time.Minute,
+	}
+
+	tests := []struct {
+		name              string
+		policy            delivery.CashPolicy
+		expectedCourierID int64
+	}{
+		{
+			name:              "Заказ с наличными не достается курьеру сверх лимита",
+			policy:            testCashPolicy,
+			expectedCourierID: footCourier.Courier.ID,
+		},
+		{
+			name:              "Без лимита заказ достается самому быстрому курьеру",
+			policy:            delivery.CashPolicy{},
+			expectedCourierID: carCourier.Courier.ID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			expectTx(m)
+			m.MockPendingRepository.EXPECT().
+				GetReadyForDispatch(gomock.Any(), gomock.Any(), testDispatchPolicy.MaxOrders).
+				Return([]entities.PendingAssignment{cashOrder}, nil)
+			m.MockRepository.EXPECT().
+				GetCouriersForDispatch(gomock.Any(), testDispatchPolicy.MaxCouriers).
+				Return([]entities.DispatchCourier{carCourier, footCourier}, nil)
+			m.MockDeliveryTimeFactory.EXPECT().
+				CalculateDeadline(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+					return baseTime.Add(travelTimes[transportType]), nil
+				}).
+				AnyTimes()
+			m.MockRepository.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, modify entities.DeliveryModify) (*entities.Delivery, error) {
+					assert.Equal(t, tt.expectedCourierID, *modify.CourierID)
+					assert.Equal(t, cashOrder.CashAmount, modify.CashAmount)
+					return &entities.Delivery{
+						ID:         1,
+						CourierID:  *modify.CourierID,
+						OrderID:    *modify.OrderID,
+						AssignedAt: *modify.AssignedAt,
+						Deadline:   *modify.Deadline,
+					}, nil
+				})
+			m.MockPendingRepository.EXPECT().
+				Delete(gomock.Any(), cashOrder.OrderID).
+				Return(nil)
+			m.MockCourierService.EXPECT().
+				UpdateCourier(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, modify entities.CourierModify) (*entities.Courier, error) {
+					return &entities.Courier{ID: *modify.ID, Status: entities.CourierBusy}, nil
+				})
+
+			count, err := newCashService(m, testDispatchPolicy, tt.policy).DispatchPendingDeliveries(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), count)
+		})
+	}
+}
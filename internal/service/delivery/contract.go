@@ -11,13 +11,55 @@ import (
 type Repository interface {
 	Create(ctx context.Context, DeliveryAssignmentEntity entities.DeliveryModify) (*entities.Delivery, error)
 	Delete(ctx context.Context, orderID string) error
+	GetByOrderID(ctx context.Context, orderID string) (*entities.Delivery, error)
+	GetByOrderIDForUpdate(ctx context.Context, orderID string) (*entities.Delivery, error)
+	Reassign(ctx context.Context, deliveryModify entities.DeliveryModify) (*entities.Delivery, error)
+	CreateReassignment(ctx context.Context, reassignmentModify entities.DeliveryReassignmentModify) (*entities.DeliveryReassignment, error)
 
 	GetCourierIDAndDeliveryCountByOrderIDForAssing(ctx context.Context, orderID string) (int64, int64, error)
-	GetCourierForAssignment(ctx context.Context) (*entities.Courier, error)
-	UpdateCouriersAvailableWhereDeadlineExpired(ctx context.Context) (int64, error)
+	GetCourierForAssignment(ctx context.Context, filter entities.CourierSearchFilter) (*entities.Courier, error)
+	GetCouriersForDispatch(ctx context.Context, limit int) ([]entities.DispatchCourier, error)
+	GetPreemptionCandidateForUpdate(ctx context.Context, filter entities.CourierSearchFilter, assignedSince time.Time) (*entities.PreemptionCandidate, error)
+	ExplainCourierMismatch(ctx context.Context, filter entities.CourierSearchFilter) (*entities.CourierMismatch, error)
+	GetCourierByIDForUpdate(ctx context.Context, courierID int64) (*entities.Courier, error)
+	CountActiveDeliveriesByCourierID(ctx context.Context, courierID int64) (int64, error)
+	CountActiveDeliveries(ctx context.Context) (int64, error)
+	CountCouriersByStatusAndTransportType(ctx context.Context) ([]entities.CourierPoolCount, error)
 
 	GetLastAssignedDeliveryTime(ctx context.Context) (time.Time, error)
 	GetCourierIDByOrderID(ctx context.Context, orderID string) (int64, error)
+	MarkCompleted(ctx context.Context, orderID string, completedAt time.Time) error
+}
+
+type PendingRepository interface {
+	Enqueue(ctx context.Context, pendingModify entities.PendingAssignmentModify) (*entities.PendingAssignment, error)
+	GetNextForUpdate(ctx context.Context, now time.Time, skipOrderIDs []string) (*entities.PendingAssignment, error)
+	GetBatchForUpdate(ctx context.Context, restaurantID string, excludeOrderID string, limit int) ([]entities.PendingAssignment, error)
+	GetReadyForDispatch(ctx context.Context, now time.Time, limit int) ([]entities.PendingAssignment, error)
+	GetByOrderIDForUpdate(ctx context.Context, orderID string) (*entities.PendingAssignment, error)
+	Delete(ctx context.Context, orderID string) error
+	GetAll(ctx context.Context) ([]entities.PendingAssignment, error)
+}
+
+type OfferRepository interface {
+	Create(ctx context.Context, offerModify entities.DeliveryOfferModify) (*entities.DeliveryOffer, error)
+	GetByIDForUpdate(ctx context.Context, id int64) (*entities.DeliveryOffer, error)
+	GetPendingByOrderID(ctx context.Context, orderID string) (*entities.DeliveryOffer, error)
+	GetNextExpiredForUpdate(ctx context.Context, now time.Time) (*entities.DeliveryOffer, error)
+	Respond(ctx context.Context, id int64, status entities.DeliveryOfferStatus, respondedAt time.Time) (*entities.DeliveryOffer, error)
+	CancelPendingByOrderID(ctx context.Context, orderID string, cancelledAt time.Time) (bool, error)
+	GetOfferedCourierIDs(ctx context.Context, orderID string, since time.Time) ([]int64, error)
+	GetCourierStats(ctx context.Context, courierID int64) (*entities.CourierOfferStats, error)
+}
+
+type ScheduledRepository interface {
+	Create(ctx context.Context, scheduledModify entities.ScheduledDeliveryModify) (*entities.ScheduledDelivery, error)
+	GetDue(ctx context.Context, now time.Time, afterAssignAt time.Time, afterID int64, limit int) ([]entities.ScheduledDelivery, error)
+	GetWaitingByOrderIDForUpdate(ctx context.Context, orderID string) (*entities.ScheduledDelivery, error)
+	GetWaiting(ctx context.Context) ([]entities.ScheduledDelivery, error)
+	ExistsWaitingByOrderID(ctx context.Context, orderID string) (bool, error)
+	MarkAssigned(ctx context.Context, orderID string, assignedAt time.Time) error
+	Cancel(ctx context.Context, orderID string, cancelledAt time.Time) (*entities.ScheduledDelivery, error)
 }
 
 type CourierService interface {
@@ -25,9 +67,29 @@ type CourierService interface {
 }
 
 type DeliveryTimeFactory interface {
-	CalculateDeadline(transportType entities.CourierTransportType, baseTime time.Time) time.Time
+	CalculateDeadline(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error)
 }
 
 type TxManager interface {
 	Do(ctx context.Context, fn func(ctx context.Context) error) error
 }
+
+// AvailabilityNotifier сообщает, что появился свободный курьер и очередь ожидания можно разбирать
+type AvailabilityNotifier interface {
+	Notify()
+}
+
+// ZoneResolver зоны обслуживания, в которые попадает точка
+type ZoneResolver interface {
+	FindZoneIDsByPoint(ctx context.Context, point entities.Location) ([]int64, error)
+}
+
+// EarningsRecorder начисляет курьеру оплату за выполненную доставку
+type EarningsRecorder interface {
+	RecordEarning(ctx context.Context, orderID string) error
+}
+
+// CashRecorder зачисляет в кассу курьера наличные, полученные от клиента за выполненную доставку
+type CashRecorder interface {
+	RecordCollection(ctx context.Context, orderID string) error
+}
@@ -42,6 +42,51 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountActiveDeliveries mocks base method.
+func (m *MockRepository) CountActiveDeliveries(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveDeliveries", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveDeliveries indicates an expected call of CountActiveDeliveries.
+func (mr *MockRepositoryMockRecorder) CountActiveDeliveries(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveDeliveries", reflect.TypeOf((*MockRepository)(nil).CountActiveDeliveries), ctx)
+}
+
+// CountActiveDeliveriesByCourierID mocks base method.
+func (m *MockRepository) CountActiveDeliveriesByCourierID(ctx context.Context, courierID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveDeliveriesByCourierID", ctx, courierID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveDeliveriesByCourierID indicates an expected call of CountActiveDeliveriesByCourierID.
+func (mr *MockRepositoryMockRecorder) CountActiveDeliveriesByCourierID(ctx, courierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveDeliveriesByCourierID", reflect.TypeOf((*MockRepository)(nil).CountActiveDeliveriesByCourierID), ctx, courierID)
+}
+
+// CountCouriersByStatusAndTransportType mocks base method.
+func (m *MockRepository) CountCouriersByStatusAndTransportType(ctx context.Context) ([]entities.CourierPoolCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCouriersByStatusAndTransportType", ctx)
+	ret0, _ := ret[0].([]entities.CourierPoolCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCouriersByStatusAndTransportType indicates an expected call of CountCouriersByStatusAndTransportType.
+func (mr *MockRepositoryMockRecorder) CountCouriersByStatusAndTransportType(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCouriersByStatusAndTransportType", reflect.TypeOf((*MockRepository)(nil).CountCouriersByStatusAndTransportType), ctx)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(ctx context.Context, DeliveryAssignmentEntity entities.DeliveryModify) (*entities.Delivery, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +102,21 @@ func (mr *MockRepositoryMockRecorder) Create(ctx, DeliveryAssignmentEntity any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, DeliveryAssignmentEntity)
 }
 
+// CreateReassignment mocks base method.
+func (m *MockRepository) CreateReassignment(ctx context.Context, reassignmentModify entities.DeliveryReassignmentModify) (*entities.DeliveryReassignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReassignment", ctx, reassignmentModify)
+	ret0, _ := ret[0].(*entities.DeliveryReassignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReassignment indicates an expected call of CreateReassignment.
+func (mr *MockRepositoryMockRecorder) CreateReassignment(ctx, reassignmentModify any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReassignment", reflect.TypeOf((*MockRepository)(nil).CreateReassignment), ctx, reassignmentModify)
+}
+
 // Delete mocks base method.
 func (m *MockRepository) Delete(ctx context.Context, orderID string) error {
 	m.ctrl.T.Helper()
@@ -71,19 +131,79 @@ func (mr *MockRepositoryMockRecorder) Delete(ctx, orderID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, orderID)
 }
 
+// ExplainCourierMismatch mocks base method.
+func (m *MockRepository) ExplainCourierMismatch(ctx context.Context, filter entities.CourierSearchFilter) (*entities.CourierMismatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainCourierMismatch", ctx, filter)
+	ret0, _ := ret[0].(*entities.CourierMismatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainCourierMismatch indicates an expected call of ExplainCourierMismatch.
+func (mr *MockRepositoryMockRecorder) ExplainCourierMismatch(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainCourierMismatch", reflect.TypeOf((*MockRepository)(nil).ExplainCourierMismatch), ctx, filter)
+}
+
+// GetByOrderID mocks base method.
+func (m *MockRepository) GetByOrderID(ctx context.Context, orderID string) (*entities.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrderID", ctx, orderID)
+	ret0, _ := ret[0].(*entities.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrderID indicates an expected call of GetByOrderID.
+func (mr *MockRepositoryMockRecorder) GetByOrderID(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrderID", reflect.TypeOf((*MockRepository)(nil).GetByOrderID), ctx, orderID)
+}
+
+// GetByOrderIDForUpdate mocks base method.
+func (m *MockRepository) GetByOrderIDForUpdate(ctx context.Context, orderID string) (*entities.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrderIDForUpdate", ctx, orderID)
+	ret0, _ := ret[0].(*entities.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrderIDForUpdate indicates an expected call of GetByOrderIDForUpdate.
+func (mr *MockRepositoryMockRecorder) GetByOrderIDForUpdate(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrderIDForUpdate", reflect.TypeOf((*MockRepository)(nil).GetByOrderIDForUpdate), ctx, orderID)
+}
+
+// GetCourierByIDForUpdate mocks base method.
+func (m *MockRepository) GetCourierByIDForUpdate(ctx context.Context, courierID int64) (*entities.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourierByIDForUpdate", ctx, courierID)
+	ret0, _ := ret[0].(*entities.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourierByIDForUpdate indicates an expected call of GetCourierByIDForUpdate.
+func (mr *MockRepositoryMockRecorder) GetCourierByIDForUpdate(ctx, courierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierByIDForUpdate", reflect.TypeOf((*MockRepository)(nil).GetCourierByIDForUpdate), ctx, courierID)
+}
+
 // GetCourierForAssignment mocks base method.
-func (m *MockRepository) GetCourierForAssignment(ctx context.Context) (*entities.Courier, error) {
+func (m *MockRepository) GetCourierForAssignment(ctx context.Context, filter entities.CourierSearchFilter) (*entities.Courier, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCourierForAssignment", ctx)
+	ret := m.ctrl.Call(m, "GetCourierForAssignment", ctx, filter)
 	ret0, _ := ret[0].(*entities.Courier)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCourierForAssignment indicates an expected call of GetCourierForAssignment.
-func (mr *MockRepositoryMockRecorder) GetCourierForAssignment(ctx any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetCourierForAssignment(ctx, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierForAssignment", reflect.TypeOf((*MockRepository)(nil).GetCourierForAssignment), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierForAssignment", reflect.TypeOf((*MockRepository)(nil).GetCourierForAssignment), ctx, filter)
 }
 
 // GetCourierIDAndDeliveryCountByOrderIDForAssing mocks base method.
@@ -117,6 +237,21 @@ func (mr *MockRepositoryMockRecorder) GetCourierIDByOrderID(ctx, orderID any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierIDByOrderID", reflect.TypeOf((*MockRepository)(nil).GetCourierIDByOrderID), ctx, orderID)
 }
 
+// GetCouriersForDispatch mocks base method.
+func (m *MockRepository) GetCouriersForDispatch(ctx context.Context, limit int) ([]entities.DispatchCourier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCouriersForDispatch", ctx, limit)
+	ret0, _ := ret[0].([]entities.DispatchCourier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCouriersForDispatch indicates an expected call of GetCouriersForDispatch.
+func (mr *MockRepositoryMockRecorder) GetCouriersForDispatch(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCouriersForDispatch", reflect.TypeOf((*MockRepository)(nil).GetCouriersForDispatch), ctx, limit)
+}
+
 // GetLastAssignedDeliveryTime mocks base method.
 func (m *MockRepository) GetLastAssignedDeliveryTime(ctx context.Context) (time.Time, error) {
 	m.ctrl.T.Helper()
@@ -132,19 +267,448 @@ func (mr *MockRepositoryMockRecorder) GetLastAssignedDeliveryTime(ctx any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastAssignedDeliveryTime", reflect.TypeOf((*MockRepository)(nil).GetLastAssignedDeliveryTime), ctx)
 }
 
-// UpdateCouriersAvailableWhereDeadlineExpired mocks base method.
-func (m *MockRepository) UpdateCouriersAvailableWhereDeadlineExpired(ctx context.Context) (int64, error) {
+// GetPreemptionCandidateForUpdate mocks base method.
+func (m *MockRepository) GetPreemptionCandidateForUpdate(ctx context.Context, filter entities.CourierSearchFilter, assignedSince time.Time) (*entities.PreemptionCandidate, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCouriersAvailableWhereDeadlineExpired", ctx)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "GetPreemptionCandidateForUpdate", ctx, filter, assignedSince)
+	ret0, _ := ret[0].(*entities.PreemptionCandidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreemptionCandidateForUpdate indicates an expected call of GetPreemptionCandidateForUpdate.
+func (mr *MockRepositoryMockRecorder) GetPreemptionCandidateForUpdate(ctx, filter, assignedSince any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreemptionCandidateForUpdate", reflect.TypeOf((*MockRepository)(nil).GetPreemptionCandidateForUpdate), ctx, filter, assignedSince)
+}
+
+// MarkCompleted mocks base method.
+func (m *MockRepository) MarkCompleted(ctx context.Context, orderID string, completedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkCompleted", ctx, orderID, completedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkCompleted indicates an expected call of MarkCompleted.
+func (mr *MockRepositoryMockRecorder) MarkCompleted(ctx, orderID, completedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCompleted", reflect.TypeOf((*MockRepository)(nil).MarkCompleted), ctx, orderID, completedAt)
+}
+
+// Reassign mocks base method.
+func (m *MockRepository) Reassign(ctx context.Context, deliveryModify entities.DeliveryModify) (*entities.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reassign", ctx, deliveryModify)
+	ret0, _ := ret[0].(*entities.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reassign indicates an expected call of Reassign.
+func (mr *MockRepositoryMockRecorder) Reassign(ctx, deliveryModify any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reassign", reflect.TypeOf((*MockRepository)(nil).Reassign), ctx, deliveryModify)
+}
+
+// MockPendingRepository is a mock of PendingRepository interface.
+type MockPendingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPendingRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPendingRepositoryMockRecorder is the mock recorder for MockPendingRepository.
+type MockPendingRepositoryMockRecorder struct {
+	mock *MockPendingRepository
+}
+
+// NewMockPendingRepository creates a new mock instance.
+func NewMockPendingRepository(ctrl *gomock.Controller) *MockPendingRepository {
+	mock := &MockPendingRepository{ctrl: ctrl}
+	mock.recorder = &MockPendingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPendingRepository) EXPECT() *MockPendingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockPendingRepository) Delete(ctx context.Context, orderID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPendingRepositoryMockRecorder) Delete(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPendingRepository)(nil).Delete), ctx, orderID)
+}
+
+// Enqueue mocks base method.
+func (m *MockPendingRepository) Enqueue(ctx context.Context, pendingModify entities.PendingAssignmentModify) (*entities.PendingAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, pendingModify)
+	ret0, _ := ret[0].(*entities.PendingAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockPendingRepositoryMockRecorder) Enqueue(ctx, pendingModify any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockPendingRepository)(nil).Enqueue), ctx, pendingModify)
+}
+
+// GetAll mocks base method.
+func (m *MockPendingRepository) GetAll(ctx context.Context) ([]entities.PendingAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]entities.PendingAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPendingRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPendingRepository)(nil).GetAll), ctx)
+}
+
+// GetBatchForUpdate mocks base method.
+func (m *MockPendingRepository) GetBatchForUpdate(ctx context.Context, restaurantID, excludeOrderID string, limit int) ([]entities.PendingAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatchForUpdate", ctx, restaurantID, excludeOrderID, limit)
+	ret0, _ := ret[0].([]entities.PendingAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatchForUpdate indicates an expected call of GetBatchForUpdate.
+func (mr *MockPendingRepositoryMockRecorder) GetBatchForUpdate(ctx, restaurantID, excludeOrderID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchForUpdate", reflect.TypeOf((*MockPendingRepository)(nil).GetBatchForUpdate), ctx, restaurantID, excludeOrderID, limit)
+}
+
+// GetByOrderIDForUpdate mocks base method.
+func (m *MockPendingRepository) GetByOrderIDForUpdate(ctx context.Context, orderID string) (*entities.PendingAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrderIDForUpdate", ctx, orderID)
+	ret0, _ := ret[0].(*entities.PendingAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrderIDForUpdate indicates an expected call of GetByOrderIDForUpdate.
+func (mr *MockPendingRepositoryMockRecorder) GetByOrderIDForUpdate(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrderIDForUpdate", reflect.TypeOf((*MockPendingRepository)(nil).GetByOrderIDForUpdate), ctx, orderID)
+}
+
+// GetNextForUpdate mocks base method.
+func (m *MockPendingRepository) GetNextForUpdate(ctx context.Context, now time.Time, skipOrderIDs []string) (*entities.PendingAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextForUpdate", ctx, now, skipOrderIDs)
+	ret0, _ := ret[0].(*entities.PendingAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextForUpdate indicates an expected call of GetNextForUpdate.
+func (mr *MockPendingRepositoryMockRecorder) GetNextForUpdate(ctx, now, skipOrderIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextForUpdate", reflect.TypeOf((*MockPendingRepository)(nil).GetNextForUpdate), ctx, now, skipOrderIDs)
+}
+
+// GetReadyForDispatch mocks base method.
+func (m *MockPendingRepository) GetReadyForDispatch(ctx context.Context, now time.Time, limit int) ([]entities.PendingAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReadyForDispatch", ctx, now, limit)
+	ret0, _ := ret[0].([]entities.PendingAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReadyForDispatch indicates an expected call of GetReadyForDispatch.
+func (mr *MockPendingRepositoryMockRecorder) GetReadyForDispatch(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReadyForDispatch", reflect.TypeOf((*MockPendingRepository)(nil).GetReadyForDispatch), ctx, now, limit)
+}
+
+// MockOfferRepository is a mock of OfferRepository interface.
+type MockOfferRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOfferRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOfferRepositoryMockRecorder is the mock recorder for MockOfferRepository.
+type MockOfferRepositoryMockRecorder struct {
+	mock *MockOfferRepository
+}
+
+// NewMockOfferRepository creates a new mock instance.
+func NewMockOfferRepository(ctrl *gomock.Controller) *MockOfferRepository {
+	mock := &MockOfferRepository{ctrl: ctrl}
+	mock.recorder = &MockOfferRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOfferRepository) EXPECT() *MockOfferRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CancelPendingByOrderID mocks base method.
+func (m *MockOfferRepository) CancelPendingByOrderID(ctx context.Context, orderID string, cancelledAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPendingByOrderID", ctx, orderID, cancelledAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelPendingByOrderID indicates an expected call of CancelPendingByOrderID.
+func (mr *MockOfferRepositoryMockRecorder) CancelPendingByOrderID(ctx, orderID, cancelledAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPendingByOrderID", reflect.TypeOf((*MockOfferRepository)(nil).CancelPendingByOrderID), ctx, orderID, cancelledAt)
+}
+
+// Create mocks base method.
+func (m *MockOfferRepository) Create(ctx context.Context, offerModify entities.DeliveryOfferModify) (*entities.DeliveryOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, offerModify)
+	ret0, _ := ret[0].(*entities.DeliveryOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOfferRepositoryMockRecorder) Create(ctx, offerModify any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOfferRepository)(nil).Create), ctx, offerModify)
+}
+
+// GetByIDForUpdate mocks base method.
+func (m *MockOfferRepository) GetByIDForUpdate(ctx context.Context, id int64) (*entities.DeliveryOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entities.DeliveryOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockOfferRepositoryMockRecorder) GetByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockOfferRepository)(nil).GetByIDForUpdate), ctx, id)
+}
+
+// GetCourierStats mocks base method.
+func (m *MockOfferRepository) GetCourierStats(ctx context.Context, courierID int64) (*entities.CourierOfferStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourierStats", ctx, courierID)
+	ret0, _ := ret[0].(*entities.CourierOfferStats)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateCouriersAvailableWhereDeadlineExpired indicates an expected call of UpdateCouriersAvailableWhereDeadlineExpired.
-func (mr *MockRepositoryMockRecorder) UpdateCouriersAvailableWhereDeadlineExpired(ctx any) *gomock.Call {
+// GetCourierStats indicates an expected call of GetCourierStats.
+func (mr *MockOfferRepositoryMockRecorder) GetCourierStats(ctx, courierID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCouriersAvailableWhereDeadlineExpired", reflect.TypeOf((*MockRepository)(nil).UpdateCouriersAvailableWhereDeadlineExpired), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierStats", reflect.TypeOf((*MockOfferRepository)(nil).GetCourierStats), ctx, courierID)
+}
+
+// GetNextExpiredForUpdate mocks base method.
+func (m *MockOfferRepository) GetNextExpiredForUpdate(ctx context.Context, now time.Time) (*entities.DeliveryOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextExpiredForUpdate", ctx, now)
+	ret0, _ := ret[0].(*entities.DeliveryOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextExpiredForUpdate indicates an expected call of GetNextExpiredForUpdate.
+func (mr *MockOfferRepositoryMockRecorder) GetNextExpiredForUpdate(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextExpiredForUpdate", reflect.TypeOf((*MockOfferRepository)(nil).GetNextExpiredForUpdate), ctx, now)
+}
+
+// GetOfferedCourierIDs mocks base method.
+func (m *MockOfferRepository) GetOfferedCourierIDs(ctx context.Context, orderID string, since time.Time) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOfferedCourierIDs", ctx, orderID, since)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOfferedCourierIDs indicates an expected call of GetOfferedCourierIDs.
+func (mr *MockOfferRepositoryMockRecorder) GetOfferedCourierIDs(ctx, orderID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOfferedCourierIDs", reflect.TypeOf((*MockOfferRepository)(nil).GetOfferedCourierIDs), ctx, orderID, since)
+}
+
+// GetPendingByOrderID mocks base method.
+func (m *MockOfferRepository) GetPendingByOrderID(ctx context.Context, orderID string) (*entities.DeliveryOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingByOrderID", ctx, orderID)
+	ret0, _ := ret[0].(*entities.DeliveryOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingByOrderID indicates an expected call of GetPendingByOrderID.
+func (mr *MockOfferRepositoryMockRecorder) GetPendingByOrderID(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingByOrderID", reflect.TypeOf((*MockOfferRepository)(nil).GetPendingByOrderID), ctx, orderID)
+}
+
+// Respond mocks base method.
+func (m *MockOfferRepository) Respond(ctx context.Context, id int64, status entities.DeliveryOfferStatus, respondedAt time.Time) (*entities.DeliveryOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Respond", ctx, id, status, respondedAt)
+	ret0, _ := ret[0].(*entities.DeliveryOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Respond indicates an expected call of Respond.
+func (mr *MockOfferRepositoryMockRecorder) Respond(ctx, id, status, respondedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Respond", reflect.TypeOf((*MockOfferRepository)(nil).Respond), ctx, id, status, respondedAt)
+}
+
+// MockScheduledRepository is a mock of ScheduledRepository interface.
+type MockScheduledRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduledRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockScheduledRepositoryMockRecorder is the mock recorder for MockScheduledRepository.
+type MockScheduledRepositoryMockRecorder struct {
+	mock *MockScheduledRepository
+}
+
+// NewMockScheduledRepository creates a new mock instance.
+func NewMockScheduledRepository(ctrl *gomock.Controller) *MockScheduledRepository {
+	mock := &MockScheduledRepository{ctrl: ctrl}
+	mock.recorder = &MockScheduledRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduledRepository) EXPECT() *MockScheduledRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockScheduledRepository) Cancel(ctx context.Context, orderID string, cancelledAt time.Time) (*entities.ScheduledDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, orderID, cancelledAt)
+	ret0, _ := ret[0].(*entities.ScheduledDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockScheduledRepositoryMockRecorder) Cancel(ctx, orderID, cancelledAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockScheduledRepository)(nil).Cancel), ctx, orderID, cancelledAt)
+}
+
+// Create mocks base method.
+func (m *MockScheduledRepository) Create(ctx context.Context, scheduledModify entities.ScheduledDeliveryModify) (*entities.ScheduledDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, scheduledModify)
+	ret0, _ := ret[0].(*entities.ScheduledDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockScheduledRepositoryMockRecorder) Create(ctx, scheduledModify any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockScheduledRepository)(nil).Create), ctx, scheduledModify)
+}
+
+// ExistsWaitingByOrderID mocks base method.
+func (m *MockScheduledRepository) ExistsWaitingByOrderID(ctx context.Context, orderID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsWaitingByOrderID", ctx, orderID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsWaitingByOrderID indicates an expected call of ExistsWaitingByOrderID.
+func (mr *MockScheduledRepositoryMockRecorder) ExistsWaitingByOrderID(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsWaitingByOrderID", reflect.TypeOf((*MockScheduledRepository)(nil).ExistsWaitingByOrderID), ctx, orderID)
+}
+
+// GetDue mocks base method.
+func (m *MockScheduledRepository) GetDue(ctx context.Context, now, afterAssignAt time.Time, afterID int64, limit int) ([]entities.ScheduledDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDue", ctx, now, afterAssignAt, afterID, limit)
+	ret0, _ := ret[0].([]entities.ScheduledDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDue indicates an expected call of GetDue.
+func (mr *MockScheduledRepositoryMockRecorder) GetDue(ctx, now, afterAssignAt, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDue", reflect.TypeOf((*MockScheduledRepository)(nil).GetDue), ctx, now, afterAssignAt, afterID, limit)
+}
+
+// GetWaiting mocks base method.
+func (m *MockScheduledRepository) GetWaiting(ctx context.Context) ([]entities.ScheduledDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWaiting", ctx)
+	ret0, _ := ret[0].([]entities.ScheduledDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWaiting indicates an expected call of GetWaiting.
+func (mr *MockScheduledRepositoryMockRecorder) GetWaiting(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWaiting", reflect.TypeOf((*MockScheduledRepository)(nil).GetWaiting), ctx)
+}
+
+// GetWaitingByOrderIDForUpdate mocks base method.
+func (m *MockScheduledRepository) GetWaitingByOrderIDForUpdate(ctx context.Context, orderID string) (*entities.ScheduledDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWaitingByOrderIDForUpdate", ctx, orderID)
+	ret0, _ := ret[0].(*entities.ScheduledDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWaitingByOrderIDForUpdate indicates an expected call of GetWaitingByOrderIDForUpdate.
+func (mr *MockScheduledRepositoryMockRecorder) GetWaitingByOrderIDForUpdate(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWaitingByOrderIDForUpdate", reflect.TypeOf((*MockScheduledRepository)(nil).GetWaitingByOrderIDForUpdate), ctx, orderID)
+}
+
+// MarkAssigned mocks base method.
+func (m *MockScheduledRepository) MarkAssigned(ctx context.Context, orderID string, assignedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAssigned", ctx, orderID, assignedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAssigned indicates an expected call of MarkAssigned.
+func (mr *MockScheduledRepositoryMockRecorder) MarkAssigned(ctx, orderID, assignedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAssigned", reflect.TypeOf((*MockScheduledRepository)(nil).MarkAssigned), ctx, orderID, assignedAt)
 }
 
 // MockCourierService is a mock of CourierService interface.
@@ -211,17 +775,18 @@ func (m *MockDeliveryTimeFactory) EXPECT() *MockDeliveryTimeFactoryMockRecorder
 }
 
 // CalculateDeadline mocks base method.
-func (m *MockDeliveryTimeFactory) CalculateDeadline(transportType entities.CourierTransportType, baseTime time.Time) time.Time {
+func (m *MockDeliveryTimeFactory) CalculateDeadline(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CalculateDeadline", transportType, baseTime)
+	ret := m.ctrl.Call(m, "CalculateDeadline", ctx, transportType, route, baseTime)
 	ret0, _ := ret[0].(time.Time)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // CalculateDeadline indicates an expected call of CalculateDeadline.
-func (mr *MockDeliveryTimeFactoryMockRecorder) CalculateDeadline(transportType, baseTime any) *gomock.Call {
+func (mr *MockDeliveryTimeFactoryMockRecorder) CalculateDeadline(ctx, transportType, route, baseTime any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateDeadline", reflect.TypeOf((*MockDeliveryTimeFactory)(nil).CalculateDeadline), transportType, baseTime)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateDeadline", reflect.TypeOf((*MockDeliveryTimeFactory)(nil).CalculateDeadline), ctx, transportType, route, baseTime)
 }
 
 // MockTxManager is a mock of TxManager interface.
@@ -261,3 +826,154 @@ func (mr *MockTxManagerMockRecorder) Do(ctx, fn any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockTxManager)(nil).Do), ctx, fn)
 }
+
+// MockAvailabilityNotifier is a mock of AvailabilityNotifier interface.
+type MockAvailabilityNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockAvailabilityNotifierMockRecorder
+	isgomock struct{}
+}
+
+// MockAvailabilityNotifierMockRecorder is the mock recorder for MockAvailabilityNotifier.
+type MockAvailabilityNotifierMockRecorder struct {
+	mock *MockAvailabilityNotifier
+}
+
+// NewMockAvailabilityNotifier creates a new mock instance.
+func NewMockAvailabilityNotifier(ctrl *gomock.Controller) *MockAvailabilityNotifier {
+	mock := &MockAvailabilityNotifier{ctrl: ctrl}
+	mock.recorder = &MockAvailabilityNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAvailabilityNotifier) EXPECT() *MockAvailabilityNotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockAvailabilityNotifier) Notify() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Notify")
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockAvailabilityNotifierMockRecorder) Notify() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockAvailabilityNotifier)(nil).Notify))
+}
+
+// MockZoneResolver is a mock of ZoneResolver interface.
+type MockZoneResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockZoneResolverMockRecorder
+	isgomock struct{}
+}
+
+// MockZoneResolverMockRecorder is the mock recorder for MockZoneResolver.
+type MockZoneResolverMockRecorder struct {
+	mock *MockZoneResolver
+}
+
+// NewMockZoneResolver creates a new mock instance.
+func NewMockZoneResolver(ctrl *gomock.Controller) *MockZoneResolver {
+	mock := &MockZoneResolver{ctrl: ctrl}
+	mock.recorder = &MockZoneResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockZoneResolver) EXPECT() *MockZoneResolverMockRecorder {
+	return m.recorder
+}
+
+// FindZoneIDsByPoint mocks base method.
+func (m *MockZoneResolver) FindZoneIDsByPoint(ctx context.Context, point entities.Location) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindZoneIDsByPoint", ctx, point)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindZoneIDsByPoint indicates an expected call of FindZoneIDsByPoint.
+func (mr *MockZoneResolverMockRecorder) FindZoneIDsByPoint(ctx, point any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindZoneIDsByPoint", reflect.TypeOf((*MockZoneResolver)(nil).FindZoneIDsByPoint), ctx, point)
+}
+
+// MockEarningsRecorder is a mock of EarningsRecorder interface.
+type MockEarningsRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockEarningsRecorderMockRecorder
+	isgomock struct{}
+}
+
+// MockEarningsRecorderMockRecorder is the mock recorder for MockEarningsRecorder.
+type MockEarningsRecorderMockRecorder struct {
+	mock *MockEarningsRecorder
+}
+
+// NewMockEarningsRecorder creates a new mock instance.
+func NewMockEarningsRecorder(ctrl *gomock.Controller) *MockEarningsRecorder {
+	mock := &MockEarningsRecorder{ctrl: ctrl}
+	mock.recorder = &MockEarningsRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEarningsRecorder) EXPECT() *MockEarningsRecorderMockRecorder {
+	return m.recorder
+}
+
+// RecordEarning mocks base method.
+func (m *MockEarningsRecorder) RecordEarning(ctx context.Context, orderID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordEarning", ctx, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordEarning indicates an expected call of RecordEarning.
+func (mr *MockEarningsRecorderMockRecorder) RecordEarning(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordEarning", reflect.TypeOf((*MockEarningsRecorder)(nil).RecordEarning), ctx, orderID)
+}
+
+// MockCashRecorder is a mock of CashRecorder interface.
+type MockCashRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockCashRecorderMockRecorder
+	isgomock struct{}
+}
+
+// MockCashRecorderMockRecorder is the mock recorder for MockCashRecorder.
+type MockCashRecorderMockRecorder struct {
+	mock *MockCashRecorder
+}
+
+// NewMockCashRecorder creates a new mock instance.
+func NewMockCashRecorder(ctrl *gomock.Controller) *MockCashRecorder {
+	mock := &MockCashRecorder{ctrl: ctrl}
+	mock.recorder = &MockCashRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCashRecorder) EXPECT() *MockCashRecorderMockRecorder {
+	return m.recorder
+}
+
+// RecordCollection mocks base method.
+func (m *MockCashRecorder) RecordCollection(ctx context.Context, orderID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordCollection", ctx, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordCollection indicates an expected call of RecordCollection.
+func (mr *MockCashRecorderMockRecorder) RecordCollection(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordCollection", reflect.TypeOf((*MockCashRecorder)(nil).RecordCollection), ctx, orderID)
+}
@@ -7,39 +7,215 @@ import (
 	"time"
 
 	"service/internal/entities"
+	courierService "service/internal/service/courier"
 )
 
 type Delivery struct {
-	repository     Repository
-	courierService CourierService
-	timeFactory    DeliveryTimeFactory
-	txManager      TxManager
+	repository          Repository
+	pendingRepository   PendingRepository
+	courierService      CourierService
+	timeFactory         DeliveryTimeFactory
+	txManager           TxManager
+	notifier            AvailabilityNotifier
+	zones               ZoneResolver
+	zonePolicy          ZonePolicy
+	offerRepository     OfferRepository
+	offerPolicy         OfferPolicy
+	batchPolicy         BatchPolicy
+	dispatchPolicy      DispatchPolicy
+	priorityPolicy      PriorityPolicy
+	scheduledRepository ScheduledRepository
+	ratingPolicy        RatingPolicy
+	earnings            EarningsRecorder
+	cash                CashRecorder
+	cashPolicy          CashPolicy
+}
+
+// ZonePolicy подбор курьера по зонам. Курьер ищется среди состоящих в зоне точки забора заказа,
+// с CrossZoneFallback при отсутствии свободных в зоне берется любой свободный курьер,
+// без него заказ уходит в очередь ожидания
+type ZonePolicy struct {
+	CrossZoneFallback bool
+}
+
+// OfferPolicy с Enabled заказ не назначается сразу, а предлагается курьеру: курьер принимает
+// или отклоняет предложение, без ответа за Timeout заказ предлагается следующему курьеру
+type OfferPolicy struct {
+	Enabled bool
+	Timeout time.Duration
+	// DeclineCooldown сколько курьеру не предлагается заказ, от которого он отказался или который пропустил
+	DeclineCooldown time.Duration
+}
+
+// BatchPolicy группировка заказов ресторана: новый заказ ждет до Window, чтобы уехать одному курьеру
+// вместе с другими заказами того же ресторана, но не больше MaxOrders за раз.
+// ExtraStopTime добавляется к дедлайну заказа за каждую остановку перед ним
+type BatchPolicy struct {
+	Window        time.Duration
+	MaxOrders     int
+	ExtraStopTime time.Duration
+}
+
+func (p BatchPolicy) Enabled() bool {
+	return p.Window > 0 && p.MaxOrders > 1
+}
+
+// DispatchPolicy с Enabled заказы не назначаются по одному: они копятся в очереди ожидания, и пакетное
+// распределение назначает до MaxOrders заказов MaxCouriers свободным курьерам с минимальной суммарной
+// стоимостью. Если оптимальное решение не найдено за SolveBudget, пары выбираются жадно
+type DispatchPolicy struct {
+	Enabled     bool
+	MaxOrders   int
+	MaxCouriers int
+	SolveBudget time.Duration
+}
+
+// PriorityPolicy приоритетный заказ, которому не нашлось свободного курьера, забирает курьера
+// у доставки обычного заказа, назначенной не раньше PreemptWithin назад: такой курьер, скорее всего,
+// еще не забрал заказ в ресторане. Нулевой PreemptWithin отключает перехват курьеров
+type PriorityPolicy struct {
+	PreemptWithin time.Duration
+}
+
+func (p PriorityPolicy) PreemptionEnabled() bool {
+	return p.PreemptWithin > 0
+}
+
+// RatingPolicy с PreferTopRated из подходящих курьеров сначала подбираются курьеры с более высокой
+// средней оценкой доставок за последние Window, и только затем менее загруженные
+type RatingPolicy struct {
+	PreferTopRated bool
+	Window         time.Duration
+}
+
+// CashPolicy заказ с оплатой наличными не назначается курьеру, у которого на руках больше BalanceLimit
+// несданных наличных. Нулевой BalanceLimit отключает лимит
+type CashPolicy struct {
+	BalanceLimit int64
+}
+
+func (p CashPolicy) Enabled() bool {
+	return p.BalanceLimit > 0
+}
+
+// Refuses курьер с balance наличных на руках не может получить заказ с cashAmount к оплате наличными
+func (p CashPolicy) Refuses(balance, cashAmount int64) bool {
+	return p.Enabled() && cashAmount > 0 && balance > p.BalanceLimit
 }
 
 func New(
 	repository Repository,
+	pendingRepository PendingRepository,
 	courierService CourierService,
 	timeFactory DeliveryTimeFactory,
 	txManager TxManager,
+	notifier AvailabilityNotifier,
+	zones ZoneResolver,
+	zonePolicy ZonePolicy,
+	offerRepository OfferRepository,
+	offerPolicy OfferPolicy,
+	batchPolicy BatchPolicy,
+	dispatchPolicy DispatchPolicy,
+	priorityPolicy PriorityPolicy,
+	scheduledRepository ScheduledRepository,
+	ratingPolicy RatingPolicy,
+	earnings EarningsRecorder,
+	cash CashRecorder,
+	cashPolicy CashPolicy,
 ) *Delivery {
 	return &Delivery{
-		repository:     repository,
-		courierService: courierService,
-		timeFactory:    timeFactory,
-		txManager:      txManager,
+		repository:          repository,
+		pendingRepository:   pendingRepository,
+		courierService:      courierService,
+		timeFactory:         timeFactory,
+		txManager:           txManager,
+		notifier:            notifier,
+		zones:               zones,
+		zonePolicy:          zonePolicy,
+		offerRepository:     offerRepository,
+		offerPolicy:         offerPolicy,
+		batchPolicy:         batchPolicy,
+		dispatchPolicy:      dispatchPolicy,
+		priorityPolicy:      priorityPolicy,
+		scheduledRepository: scheduledRepository,
+		ratingPolicy:        ratingPolicy,
+		earnings:            earnings,
+		cash:                cash,
+		cashPolicy:          cashPolicy,
 	}
 }
 
-func (d *Delivery) DeliveryAssign(ctx context.Context, orderID string) (*entities.DeliveryAssignment, error) {
-	if !isValidOrderID(orderID) {
+func (d *Delivery) DeliveryAssign(ctx context.Context, params entities.DeliveryAssignParams) (*entities.DeliveryAssignment, error) {
+	start := time.Now()
+	deliveryAssignment, err := d.deliveryAssign(ctx, params)
+	observeAssignment(assignSourceRequest, params.Priority, deliveryAssignment, err, start)
+	if err != nil {
+		return nil, err
+	}
+
+	observeTimeToAssign(assignSourceRequest, params.Priority, params.OrderCreatedAt, deliveryAssignment.AssignedAt)
+	return deliveryAssignment, nil
+}
+
+func (d *Delivery) deliveryAssign(ctx context.Context, params entities.DeliveryAssignParams) (*entities.DeliveryAssignment, error) {
+	if !isValidOrderID(params.OrderID) {
 		return nil, ErrInvalidOrderID
 	}
+	if !isValidRoute(params.Route) {
+		return nil, ErrInvalidRoute
+	}
+	if !isValidRequirements(params.Requirements) {
+		return nil, ErrInvalidRequirements
+	}
+	if !isValidPriority(params.Priority) {
+		return nil, ErrInvalidPriority
+	}
+	if d.offerPolicy.Enabled {
+		return nil, d.offerDelivery(ctx, params)
+	}
+	if d.dispatchPolicy.Enabled {
+		return nil, d.enqueueForDispatch(ctx, params)
+	}
 
 	deliveryCreatedAt := time.Now().UTC()
-	return d.internalDeliveryAssign(ctx, orderID, deliveryCreatedAt)
+	deliveryAssignment, err := d.internalDeliveryAssign(ctx, params, deliveryCreatedAt)
+	if err != nil {
+		// свободных курьеров нет - заказ не теряем, а ставим в очередь ожидания
+		if errors.Is(err, ErrNoAvailableCouriers) {
+			return nil, d.enqueuePendingAssignment(ctx, params, err)
+		}
+		return nil, err
+	}
+
+	return deliveryAssignment, nil
+}
+
+func (d *Delivery) GetDelivery(ctx context.Context, orderID string) (*entities.Delivery, error) {
+	if !isValidOrderID(orderID) {
+		return nil, ErrInvalidOrderID
+	}
+
+	delivery, err := d.repository.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("get delivery: %w", err)
+	}
+
+	return delivery, nil
 }
 
 func (d *Delivery) DeliveryUnassign(ctx context.Context, orderID string) (*entities.DeliveryUnassignment, error) {
+	deliveryUnassignment, err := d.deliveryUnassign(ctx, orderID)
+
+	transportType := transportUnknown
+	if deliveryUnassignment != nil {
+		transportType = deliveryUnassignment.TransportType.String()
+	}
+	DeliveryUnassignmentsTotal.WithLabelValues(unassignmentOutcome(err), transportType).Inc()
+
+	return deliveryUnassignment, err
+}
+
+func (d *Delivery) deliveryUnassign(ctx context.Context, orderID string) (*entities.DeliveryUnassignment, error) {
 	if !isValidOrderID(orderID) {
 		return nil, ErrInvalidOrderID
 	}
@@ -51,105 +227,682 @@ func (d *Delivery) DeliveryUnassign(ctx context.Context, orderID string) (*entit
 			return fmt.Errorf("get courier by order id: %w", err)
 		}
 
-		if activeDeliveriesCount > 0 {
-			return ErrCourierHasActiveDeliveries
-		}
-
 		err = d.repository.Delete(ctx, orderID)
 		if err != nil {
 			return fmt.Errorf("delete delivery: %w", err)
 		}
 
-		newStatus := entities.CourierAvailable
-		courierModify := entities.CourierModify{
-			ID:     &courierID,
-			Status: &newStatus,
-		}
+		// курьер везет и другие заказы группы, поэтому остается занят до последнего из них
+		var courier *entities.Courier
+		if activeDeliveriesCount > 0 {
+			courier, err = d.repository.GetCourierByIDForUpdate(ctx, courierID)
+			if err != nil {
+				return fmt.Errorf("get courier: %w", err)
+			}
+		} else {
+			newStatus := entities.CourierAvailable
+			courierModify := entities.CourierModify{
+				ID:     &courierID,
+				Status: &newStatus,
+			}
 
-		courier, err := d.courierService.UpdateCourier(ctx, courierModify)
-		if err != nil {
-			return fmt.Errorf("update courier status: %w", err)
+			courier, err = d.courierService.UpdateCourier(ctx, courierModify)
+			if err != nil {
+				return fmt.Errorf("update courier status: %w", err)
+			}
 		}
 
 		deliveryUnassignment = entities.DeliveryUnassignment{
-			CourierID: courier.ID,
-			OrderID:   orderID,
-			Status:    courier.Status.String(),
+			CourierID:     courier.ID,
+			OrderID:       orderID,
+			Status:        courier.Status.String(),
+			TransportType: courier.TransportType,
 		}
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
+
+	d.notifier.Notify()
 	return &deliveryUnassignment, nil
 }
 
-func (d *Delivery) CleanupExpiredDeliveries(ctx context.Context) (int64, error) {
-	rowsAffected, err := d.repository.UpdateCouriersAvailableWhereDeadlineExpired(ctx)
+// RefreshPoolMetrics обновляет gauge курьеров и активных доставок по данным БД:
+// статусы меняются и в других инстансах, поэтому считать их в памяти нельзя
+func (d *Delivery) RefreshPoolMetrics(ctx context.Context) error {
+	counts, err := d.repository.CountCouriersByStatusAndTransportType(ctx)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			return 0, fmt.Errorf("cleanup timed out: %w", err)
+		return fmt.Errorf("count couriers: %w", err)
+	}
+
+	activeDeliveriesCount, err := d.repository.CountActiveDeliveries(ctx)
+	if err != nil {
+		return fmt.Errorf("count active deliveries: %w", err)
+	}
+
+	setCouriersCount(counts)
+	DeliveriesActive.Set(float64(activeDeliveriesCount))
+	return nil
+}
+
+// AssignPendingDeliveries разбирает очередь ожидания, пока в ней есть заказы и есть свободные курьеры.
+// Заказ, которому не подошел ни один из свободных курьеров, пропускается до следующего прохода,
+// чтобы не задерживать заказы за ним. Возвращает количество назначенных заказов,
+// с политикой предложений - количество предложенных.
+func (d *Delivery) AssignPendingDeliveries(ctx context.Context) (int64, error) {
+	// очередь разбирает пакетное распределение, DispatchPendingDeliveries
+	if d.dispatchPolicy.Enabled {
+		return 0, nil
+	}
+
+	next := d.assignNextPending
+	if d.offerPolicy.Enabled {
+		next = d.offerNextPending
+	}
+
+	var (
+		assignedCount int64
+		skipOrderIDs  []string
+	)
+	for {
+		assigned, orderID, err := next(ctx, skipOrderIDs)
+		if err != nil {
+			var noMatch *NoCourierMatchError
+			if errors.As(err, &noMatch) && noMatch.Mismatch.AvailableCouriers > 0 && orderID != "" {
+				skipOrderIDs = append(skipOrderIDs, orderID)
+				continue
+			}
+			if errors.Is(err, ErrPendingQueueEmpty) || errors.Is(err, ErrNoAvailableCouriers) {
+				return assignedCount, nil
+			}
+			return assignedCount, err
 		}
-		return 0, fmt.Errorf("cleanup: %w", err)
+
+		assignedCount += assigned
 	}
+}
 
-	return rowsAffected, nil
+func (d *Delivery) GetPendingAssignments(ctx context.Context) ([]entities.PendingAssignment, error) {
+	pendingAssignments, err := d.pendingRepository.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get pending assignments: %w", err)
+	}
+
+	return pendingAssignments, nil
 }
 
-func (d *Delivery) internalDeliveryAssign(ctx context.Context, orderID string, deliveryCreatedAt time.Time) (*entities.DeliveryAssignment, error) {
+// CancelPendingAssignment убирает из очереди ожидания заказ, который больше не нужно назначать,
+// и отменяет его открытое предложение курьеру
+func (d *Delivery) CancelPendingAssignment(ctx context.Context, orderID string) error {
 	if !isValidOrderID(orderID) {
+		return ErrInvalidOrderID
+	}
+
+	var offerCancelled bool
+	err := d.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		offerCancelled, err = d.offerRepository.CancelPendingByOrderID(ctx, orderID, time.Now().UTC())
+		if err != nil {
+			return fmt.Errorf("cancel pending offer: %w", err)
+		}
+
+		err = d.pendingRepository.Delete(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("delete pending assignment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// курьер, которому предлагали заказ, снова свободен
+	if offerCancelled {
+		d.notifier.Notify()
+	}
+	return nil
+}
+
+func (d *Delivery) enqueuePendingAssignment(ctx context.Context, params entities.DeliveryAssignParams, cause error) error {
+	_, err := d.pendingRepository.Enqueue(ctx, newPendingModify(params, time.Now().UTC()))
+	if err != nil {
+		return fmt.Errorf("%w: enqueue pending assignment: %w", cause, err)
+	}
+
+	return fmt.Errorf("%w: %w", ErrAssignmentPending, cause)
+}
+
+// enqueueUnassigned ставит в очередь ожидания заказ, которому курьер еще не назначен
+func (d *Delivery) enqueueUnassigned(ctx context.Context, pendingModify entities.PendingAssignmentModify) error {
+	return d.txManager.Do(ctx, func(ctx context.Context) error {
+		_, err := d.repository.GetByOrderID(ctx, *pendingModify.OrderID)
+		if err == nil {
+			return ErrOrderAlreadyAssigned
+		}
+		if !errors.Is(err, ErrDeliveryNotFound) {
+			return fmt.Errorf("get delivery: %w", err)
+		}
+
+		_, err = d.pendingRepository.Enqueue(ctx, pendingModify)
+		if err != nil {
+			return fmt.Errorf("enqueue pending assignment: %w", err)
+		}
+		return nil
+	})
+}
+
+func newPendingModify(params entities.DeliveryAssignParams, enqueuedAt time.Time) entities.PendingAssignmentModify {
+	priority := params.Priority.PendingPriority()
+
+	return entities.PendingAssignmentModify{
+		OrderID:           &params.OrderID,
+		Priority:          &priority,
+		Route:             params.Route,
+		RestaurantID:      &params.RestaurantID,
+		Address:           params.Address,
+		EstimatedDelivery: params.EstimatedDelivery,
+		OrderCreatedAt:    params.OrderCreatedAt,
+		EnqueuedAt:        &enqueuedAt,
+		Requirements:      params.Requirements,
+		CashAmount:        params.CashAmount,
+	}
+}
+
+// assignNextPending назначает курьера заказу из головы очереди, а заказу, ожидавшему группировки, -
+// вместе с другими заказами того же ресторана. Возвращает количество назначенных заказов,
+// 0 без ошибки - запись в очереди оказалась устаревшей. Вторым значением возвращает заказ из головы очереди
+func (d *Delivery) assignNextPending(ctx context.Context, skipOrderIDs []string) (int64, string, error) {
+	var (
+		orderID      string
+		staleOrderID string
+		assigned     []assignedOrder
+		batched      bool
+		attempted    bool
+		priority     = priorityUnknown
+	)
+	start := time.Now()
+
+	err := d.txManager.Do(ctx, func(ctx context.Context) error {
+		pending, err := d.pendingRepository.GetNextForUpdate(ctx, time.Now().UTC(), skipOrderIDs)
+		if err != nil {
+			return fmt.Errorf("get next pending assignment: %w", err)
+		}
+
+		orderID = pending.OrderID
+		attempted = true
+		priority = entities.OrderPriorityFromPending(pending.Priority)
+		if d.batchPolicy.Enabled() && pending.BatchUntil != nil {
+			batched = true
+			assigned, staleOrderID, err = d.assignBatch(ctx, pending)
+			return err
+		}
+
+		deliveryAssignment, err := d.internalDeliveryAssign(ctx, pendingToParams(pending), pending.EnqueuedAt)
+		if err != nil {
+			if errors.Is(err, ErrOrderAlreadyAssigned) {
+				staleOrderID = pending.OrderID
+			}
+			return err
+		}
+
+		err = d.pendingRepository.Delete(ctx, pending.OrderID)
+		if err != nil {
+			return fmt.Errorf("delete pending assignment: %w", err)
+		}
+
+		assigned = []assignedOrder{{assignment: deliveryAssignment, orderCreatedAt: pending.OrderCreatedAt, priority: priority}}
+		return nil
+	})
+	if err != nil {
+		// пустая очередь не считается попыткой назначения, иначе транзакция откатилась и назначение не состоялось
+		if attempted {
+			observeAssignment(assignSourceQueue, priority, nil, err, start)
+		}
+		// заказ уже назначили в обход очереди (например, повторным POST /delivery/assign),
+		// транзакция откатилась, поэтому удаляем запись отдельно
+		if staleOrderID != "" {
+			err = d.pendingRepository.Delete(ctx, staleOrderID)
+			if err != nil && !errors.Is(err, ErrPendingAssignmentNotFound) {
+				return 0, orderID, fmt.Errorf("delete stale pending assignment: %w", err)
+			}
+			return 0, orderID, nil
+		}
+		return 0, orderID, err
+	}
+
+	for _, order := range assigned {
+		observeAssignment(assignSourceQueue, order.priority, order.assignment, nil, start)
+		observeTimeToAssign(assignSourceQueue, order.priority, order.orderCreatedAt, order.assignment.AssignedAt)
+	}
+	if batched {
+		observeBatch(len(assigned))
+	}
+	return int64(len(assigned)), orderID, nil
+}
+
+func pendingToParams(pending *entities.PendingAssignment) entities.DeliveryAssignParams {
+	return entities.DeliveryAssignParams{
+		OrderID:           pending.OrderID,
+		Route:             pending.Route,
+		RestaurantID:      pending.RestaurantID,
+		Address:           pending.Address,
+		EstimatedDelivery: pending.EstimatedDelivery,
+		OrderCreatedAt:    pending.OrderCreatedAt,
+		Requirements:      pending.Requirements,
+		Priority:          entities.OrderPriorityFromPending(pending.Priority),
+		CashAmount:        pending.CashAmount,
+	}
+}
+
+// deliveryToParams маршрут, требования, приоритет и наличные сохраненной доставки для повторного подбора курьера
+func deliveryToParams(delivery *entities.Delivery) entities.DeliveryAssignParams {
+	return entities.DeliveryAssignParams{
+		OrderID:           delivery.OrderID,
+		Route:             delivery.Route,
+		RestaurantID:      delivery.RestaurantID,
+		Address:           delivery.Address,
+		EstimatedDelivery: delivery.EstimatedDelivery,
+		Requirements:      delivery.Requirements,
+		Priority:          delivery.Priority,
+		CashAmount:        delivery.CashAmount,
+	}
+}
+
+func (d *Delivery) internalDeliveryAssign(
+	ctx context.Context,
+	params entities.DeliveryAssignParams,
+	deliveryCreatedAt time.Time,
+) (*entities.DeliveryAssignment, error) {
+	if !isValidOrderID(params.OrderID) {
 		return nil, ErrInvalidOrderID
 	}
 
-	deliveryAssignment := entities.DeliveryAssignment{}
+	var deliveryAssignment *entities.DeliveryAssignment
+	err := d.txManager.Do(ctx, func(ctx context.Context) error {
+		courier, err := d.findCourierForAssignment(ctx, params, nil)
+		if errors.Is(err, ErrNoAvailableCouriers) && params.Priority.IsHigh() && d.priorityPolicy.PreemptionEnabled() {
+			courier, err = d.preemptCourier(ctx, params, err)
+		}
+		if err != nil {
+			return err
+		}
+
+		deliveryAssignment, err = d.bindCourier(ctx, courier, params, deliveryCreatedAt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveryAssignment, nil
+}
+
+// bindCourier создает доставку и занимает курьера, вызывается в транзакции
+func (d *Delivery) bindCourier(
+	ctx context.Context,
+	courier *entities.Courier,
+	params entities.DeliveryAssignParams,
+	deliveryCreatedAt time.Time,
+) (*entities.DeliveryAssignment, error) {
+	// по идее Assign часть бизнес логики поэтому время задаем тут а не в БД
+	assignTime := time.Now().UTC()
+
+	deadline, err := d.calculateDeadline(ctx, courier.TransportType, params.Route, params.EstimatedDelivery, assignTime)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := d.createDelivery(ctx, courier, params, deliveryCreatedAt, assignTime, deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedCourier, err := d.occupyCourier(ctx, courier)
+	if err != nil {
+		return nil, fmt.Errorf("update courier status: %w", err)
+	}
+
+	return &entities.DeliveryAssignment{
+		CourierID:     updatedCourier.ID,
+		OrderID:       delivery.OrderID,
+		AssignedAt:    delivery.AssignedAt,
+		Deadline:      delivery.Deadline,
+		TransportType: updatedCourier.TransportType,
+	}, nil
+}
+
+func (d *Delivery) createDelivery(
+	ctx context.Context,
+	courier *entities.Courier,
+	params entities.DeliveryAssignParams,
+	deliveryCreatedAt time.Time,
+	assignTime time.Time,
+	deadline time.Time,
+) (*entities.Delivery, error) {
+	deliveryModify := entities.DeliveryModify{
+		CourierID:         &courier.ID,
+		OrderID:           &params.OrderID,
+		RestaurantID:      &params.RestaurantID,
+		Address:           params.Address,
+		EstimatedDelivery: params.EstimatedDelivery,
+		CreatedAt:         &deliveryCreatedAt,
+		AssignedAt:        &assignTime,
+		Deadline:          &deadline,
+		Route:             params.Route,
+		Priority:          &params.Priority,
+		Requirements:      params.Requirements,
+		CashAmount:        params.CashAmount,
+	}
+
+	delivery, err := d.repository.Create(ctx, deliveryModify)
+	if err != nil {
+		return nil, fmt.Errorf("create delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// DeliveryReassign передает заказ другому курьеру в одной транзакции: заказ ни в какой момент
+// не остается без курьера. Прежний курьер освобождается, если у него больше нет активных доставок.
+func (d *Delivery) DeliveryReassign(ctx context.Context, params entities.DeliveryReassignParams) (*entities.DeliveryReassignment, error) {
+	if !isValidOrderID(params.OrderID) {
+		return nil, ErrInvalidOrderID
+	}
+	if params.CourierID != nil && *params.CourierID <= 0 {
+		return nil, ErrInvalidCourierID
+	}
+	if !isValidReassignReason(params.Reason) {
+		return nil, ErrInvalidReassignReason
+	}
+
+	deliveryReassignment := entities.DeliveryReassignment{}
+	previousCourierReleased := false
 
 	err := d.txManager.Do(ctx, func(ctx context.Context) error {
-		courier, err := d.repository.GetCourierForAssignment(ctx)
+		currentDelivery, err := d.repository.GetByOrderIDForUpdate(ctx, params.OrderID)
+		if err != nil {
+			return fmt.Errorf("get delivery: %w", err)
+		}
+		// выполненную доставку не передают: новый курьер остался бы занят, освобождать его нечему
+		if currentDelivery.CompletedAt != nil {
+			return ErrDeliveryCompleted
+		}
+
+		courier, err := d.findCourierForReassignment(ctx, currentDelivery, params.CourierID)
 		if err != nil {
-			return fmt.Errorf("find courier for assignment: %w", err)
+			return err
 		}
 
-		deadline := d.timeFactory.CalculateDeadline(courier.TransportType, time.Now().UTC())
-		// по идее Assign часть бизнес логики поэтому время задаем тут а не в БД
-		assignTime := time.Now().UTC()
+		reassignTime := time.Now().UTC()
+
+		// дедлайн считается заново по сохраненному маршруту и типу транспорта нового курьера
+		deadline, err := d.calculateDeadline(ctx, courier.TransportType, currentDelivery.Route, currentDelivery.EstimatedDelivery, reassignTime)
+		if err != nil {
+			return err
+		}
 
 		deliveryModify := entities.DeliveryModify{
+			OrderID:    &params.OrderID,
 			CourierID:  &courier.ID,
-			OrderID:    &orderID,
-			CreatedAt:  &deliveryCreatedAt,
-			AssignedAt: &assignTime,
+			AssignedAt: &reassignTime,
 			Deadline:   &deadline,
 		}
 
-		delivery, err := d.repository.Create(ctx, deliveryModify)
+		_, err = d.repository.Reassign(ctx, deliveryModify)
 		if err != nil {
-			return fmt.Errorf("create delivery: %w", err)
+			return fmt.Errorf("reassign delivery: %w", err)
 		}
 
-		busyStatus := entities.CourierBusy
-		courierModify := entities.CourierModify{
-			ID:     &courier.ID,
-			Status: &busyStatus,
+		reassignmentModify := entities.DeliveryReassignmentModify{
+			OrderID:           &params.OrderID,
+			PreviousCourierID: &currentDelivery.CourierID,
+			CourierID:         &courier.ID,
+			Reason:            &params.Reason,
+			Deadline:          &deadline,
+			ReassignedAt:      &reassignTime,
 		}
 
-		updatedCourier, err := d.courierService.UpdateCourier(ctx, courierModify)
+		reassignment, err := d.repository.CreateReassignment(ctx, reassignmentModify)
 		if err != nil {
-			return fmt.Errorf("update courier status: %w", err)
+			return fmt.Errorf("create reassignment: %w", err)
 		}
 
-		deliveryAssignment = entities.DeliveryAssignment{
-			CourierID:     updatedCourier.ID,
-			OrderID:       delivery.OrderID,
-			AssignedAt:    delivery.AssignedAt,
-			Deadline:      delivery.Deadline,
-			TransportType: updatedCourier.TransportType,
+		_, err = d.occupyCourier(ctx, courier)
+		if err != nil {
+			return fmt.Errorf("update new courier status: %w", err)
 		}
+
+		activeDeliveriesCount, err := d.repository.CountActiveDeliveriesByCourierID(ctx, currentDelivery.CourierID)
+		if err != nil {
+			return fmt.Errorf("count previous courier deliveries: %w", err)
+		}
+
+		if activeDeliveriesCount == 0 {
+			availableStatus := entities.CourierAvailable
+			_, err = d.courierService.UpdateCourier(ctx, entities.CourierModify{
+				ID:     &currentDelivery.CourierID,
+				Status: &availableStatus,
+			})
+			if err != nil {
+				return fmt.Errorf("update previous courier status: %w", err)
+			}
+			previousCourierReleased = true
+		}
+
+		deliveryReassignment = *reassignment
+		deliveryReassignment.TransportType = courier.TransportType
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
-	return &deliveryAssignment, nil
+
+	if previousCourierReleased {
+		d.notifier.Notify()
+	}
+	return &deliveryReassignment, nil
+}
+
+// findCourierForAssignment подбирает курьера с навыками и транспортом по требованиям заказа
+// из зоны точки забора. Заказ без маршрута или с точкой вне всех зон получает курьера из любой зоны.
+// Приоритетному заказу достается курьер на самом быстром транспорте из подходящих,
+// заказ с оплатой наличными - только курьер, не превысивший лимит наличных.
+// Если подходящих курьеров нет, ошибка объясняет, какие требования не выполнены
+func (d *Delivery) findCourierForAssignment(
+	ctx context.Context,
+	params entities.DeliveryAssignParams,
+	excludeCourierIDs []int64,
+) (*entities.Courier, error) {
+	filter, err := d.courierSearchFilter(ctx, params, excludeCourierIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	courier, err := d.repository.GetCourierForAssignment(ctx, filter)
+	if err == nil {
+		return courier, nil
+	}
+	if !errors.Is(err, ErrNoAvailableCouriers) {
+		return nil, fmt.Errorf("find courier for assignment: %w", err)
+	}
+	if len(filter.ZoneIDs) == 0 || !d.zonePolicy.CrossZoneFallback {
+		return nil, d.explainNoCourier(ctx, filter)
+	}
+
+	// за пределами зоны требования заказа по-прежнему обязательны
+	filter.ZoneIDs = nil
+	courier, err = d.repository.GetCourierForAssignment(ctx, filter)
+	if err != nil {
+		if !errors.Is(err, ErrNoAvailableCouriers) {
+			return nil, fmt.Errorf("find courier outside pickup zones: %w", err)
+		}
+		return nil, d.explainNoCourier(ctx, filter)
+	}
+
+	DeliveryCrossZoneAssignmentsTotal.Inc()
+	return courier, nil
+}
+
+// courierSearchFilter фильтр подбора курьера по требованиям, приоритету и оплате заказа и зонам точки забора
+func (d *Delivery) courierSearchFilter(
+	ctx context.Context,
+	params entities.DeliveryAssignParams,
+	excludeCourierIDs []int64,
+) (entities.CourierSearchFilter, error) {
+	filter := entities.CourierSearchFilter{
+		Skills:              params.Requirements.Skills,
+		TransportTypes:      params.Requirements.TransportTypes,
+		ExcludeCourierIDs:   excludeCourierIDs,
+		PreferFastTransport: params.Priority.IsHigh(),
+	}
+	if d.ratingPolicy.PreferTopRated {
+		ratedSince := time.Now().UTC().Add(-d.ratingPolicy.Window)
+		filter.TopRatedSince = &ratedSince
+	}
+	if params.CashAmount > 0 && d.cashPolicy.Enabled() {
+		filter.MaxCashBalance = &d.cashPolicy.BalanceLimit
+	}
+	if params.Route != nil {
+		zoneIDs, err := d.zones.FindZoneIDsByPoint(ctx, params.Route.Pickup)
+		if err != nil {
+			return entities.CourierSearchFilter{}, fmt.Errorf("find pickup zones: %w", err)
+		}
+		filter.ZoneIDs = zoneIDs
+	}
+
+	return filter, nil
+}
+
+// preemptCourier забирает для приоритетного заказа курьера у самой свежей подходящей доставки обычного заказа
+// по PriorityPolicy. Доставка удаляется, а обычный заказ возвращается в очередь ожидания со временем
+// своей доставки, поэтому назначается раньше заказов, пришедших после него. Маршрут переносится в очередь,
+// чтобы при повторном назначении учесть зону и дедлайн. Вызывается в транзакции, без подходящей доставки возвращает cause
+func (d *Delivery) preemptCourier(
+	ctx context.Context,
+	params entities.DeliveryAssignParams,
+	cause error,
+) (*entities.Courier, error) {
+	filter, err := d.courierSearchFilter(ctx, params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	assignedSince := time.Now().UTC().Add(-d.priorityPolicy.PreemptWithin)
+	candidate, err := d.repository.GetPreemptionCandidateForUpdate(ctx, filter, assignedSince)
+	if errors.Is(err, ErrNoPreemptionCandidate) && len(filter.ZoneIDs) > 0 && d.zonePolicy.CrossZoneFallback {
+		filter.ZoneIDs = nil
+		candidate, err = d.repository.GetPreemptionCandidateForUpdate(ctx, filter, assignedSince)
+	}
+	if err != nil {
+		if errors.Is(err, ErrNoPreemptionCandidate) {
+			return nil, cause
+		}
+		return nil, fmt.Errorf("get preemption candidate: %w", err)
+	}
+
+	preempted := candidate.Delivery
+	err = d.repository.Delete(ctx, preempted.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("delete preempted delivery: %w", err)
+	}
+
+	_, err = d.pendingRepository.Enqueue(ctx, newPendingModify(entities.DeliveryAssignParams{
+		OrderID:           preempted.OrderID,
+		Route:             preempted.Route,
+		RestaurantID:      preempted.RestaurantID,
+		Address:           preempted.Address,
+		EstimatedDelivery: preempted.EstimatedDelivery,
+		Requirements:      candidate.Requirements,
+		Priority:          entities.PriorityNormal,
+		CashAmount:        candidate.CashAmount,
+	}, preempted.CreatedAt))
+	if err != nil {
+		return nil, fmt.Errorf("enqueue preempted order: %w", err)
+	}
+
+	DeliveryPreemptionsTotal.Inc()
+	return &candidate.Courier, nil
+}
+
+// explainNoCourier считает, скольким свободным курьерам подходит каждое требование фильтра
+func (d *Delivery) explainNoCourier(ctx context.Context, filter entities.CourierSearchFilter) error {
+	mismatch, err := d.repository.ExplainCourierMismatch(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("explain courier mismatch: %w", err)
+	}
+	return &NoCourierMatchError{Mismatch: *mismatch}
+}
+
+// findCourierForReassignment возвращает выбранного курьера, если он свободен,
+// или подбирает следующего подходящего по требованиям доставки из зоны точки забора, не предлагая текущего курьера.
+// Заказ с оплатой наличными передается только курьеру, не превысившему лимит наличных
+func (d *Delivery) findCourierForReassignment(ctx context.Context, current *entities.Delivery, targetCourierID *int64) (*entities.Courier, error) {
+	if targetCourierID == nil {
+		courier, err := d.findCourierForAssignment(ctx, deliveryToParams(current), []int64{current.CourierID})
+		if err != nil {
+			return nil, fmt.Errorf("find courier for reassignment: %w", err)
+		}
+		return courier, nil
+	}
+
+	if *targetCourierID == current.CourierID {
+		return nil, ErrSameCourier
+	}
+
+	courier, err := d.repository.GetCourierByIDForUpdate(ctx, *targetCourierID)
+	if err != nil {
+		return nil, fmt.Errorf("get target courier: %w", err)
+	}
+	if courier.Status != entities.CourierAvailable || courier.IsDeactivated() {
+		return nil, ErrCourierNotAvailable
+	}
+
+	return courier, nil
+}
+
+// occupyCourier переводит выбранного курьера в busy, только если его не изменили после выбора:
+// иначе параллельная ручная смена статуса (например, пауза) была бы молча перезаписана
+func (d *Delivery) occupyCourier(ctx context.Context, courier *entities.Courier) (*entities.Courier, error) {
+	busyStatus := entities.CourierBusy
+	updatedCourier, err := d.courierService.UpdateCourier(ctx, entities.CourierModify{
+		ID:              &courier.ID,
+		Status:          &busyStatus,
+		ExpectedVersion: &courier.Version,
+	})
+	if err != nil {
+		if errors.Is(err, courierService.ErrVersionMismatch) {
+			return nil, fmt.Errorf("%w: %w", ErrCourierNotAvailable, err)
+		}
+		return nil, err
+	}
+
+	return updatedCourier, nil
+}
+
+// calculateDeadline время, обещанное клиенту в order-service, важнее расчетного,
+// но только если оно еще не прошло - иначе курьер сразу оказался бы просрочен
+func (d *Delivery) calculateDeadline(
+	ctx context.Context,
+	transportType entities.CourierTransportType,
+	route *entities.Route,
+	estimatedDelivery *time.Time,
+	assignTime time.Time,
+) (time.Time, error) {
+	deadline, err := d.timeFactory.CalculateDeadline(ctx, transportType, route, assignTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("calculate deadline: %w", err)
+	}
+
+	return promisedDeadline(deadline, estimatedDelivery, assignTime), nil
+}
+
+// promisedDeadline обещанное клиенту время, пока оно не прошло, заменяет расчетный дедлайн
+func promisedDeadline(deadline time.Time, estimatedDelivery *time.Time, assignTime time.Time) time.Time {
+	if estimatedDelivery != nil && estimatedDelivery.After(assignTime) {
+		return estimatedDelivery.UTC()
+	}
+
+	return deadline
 }
 
 func (d *Delivery) FreeCourierByOrderID(ctx context.Context, orderID string) error {
@@ -163,6 +916,32 @@ func (d *Delivery) FreeCourierByOrderID(ctx context.Context, orderID string) err
 			return fmt.Errorf("get courier by order id: %w", err)
 		}
 
+		// по времени выполнения обучается модель ETA
+		err = d.repository.MarkCompleted(ctx, orderID, time.Now().UTC())
+		if err != nil {
+			return fmt.Errorf("mark delivery completed: %w", err)
+		}
+
+		// оплата начисляется вместе с выполнением, чтобы повтор события не оставил доставку без начисления
+		err = d.earnings.RecordEarning(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("record courier earning: %w", err)
+		}
+
+		err = d.cash.RecordCollection(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("record courier cash collection: %w", err)
+		}
+
+		// курьер с группой заказов освобождается, когда доставит последний из них
+		activeDeliveriesCount, err := d.repository.CountActiveDeliveriesByCourierID(ctx, courierID)
+		if err != nil {
+			return fmt.Errorf("count courier active deliveries: %w", err)
+		}
+		if activeDeliveriesCount > 0 {
+			return nil
+		}
+
 		newStatus := entities.CourierAvailable
 		courierModify := entities.CourierModify{
 			ID:     &courierID,
@@ -175,6 +954,10 @@ func (d *Delivery) FreeCourierByOrderID(ctx context.Context, orderID string) err
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	d.notifier.Notify()
+	return nil
 }
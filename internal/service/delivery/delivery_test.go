@@ -3,29 +3,46 @@ package delivery_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"service/internal/entities"
+	courierService "service/internal/service/courier"
 	"service/internal/service/delivery"
 )
 
 type mock struct {
 	*MockRepository
+	*MockPendingRepository
 	*MockCourierService
 	*MockTxManager
 	*MockDeliveryTimeFactory
+	*MockAvailabilityNotifier
+	*MockZoneResolver
+	*MockOfferRepository
+	*MockScheduledRepository
+	*MockEarningsRecorder
+	*MockCashRecorder
 }
 
 func newMock(ctrl *gomock.Controller) *mock {
 	return &mock{
-		MockRepository:          NewMockRepository(ctrl),
-		MockCourierService:      NewMockCourierService(ctrl),
-		MockTxManager:           NewMockTxManager(ctrl),
-		MockDeliveryTimeFactory: NewMockDeliveryTimeFactory(ctrl),
+		MockRepository:           NewMockRepository(ctrl),
+		MockPendingRepository:    NewMockPendingRepository(ctrl),
+		MockCourierService:       NewMockCourierService(ctrl),
+		MockTxManager:            NewMockTxManager(ctrl),
+		MockDeliveryTimeFactory:  NewMockDeliveryTimeFactory(ctrl),
+		MockAvailabilityNotifier: NewMockAvailabilityNotifier(ctrl),
+		MockZoneResolver:         NewMockZoneResolver(ctrl),
+		MockOfferRepository:      NewMockOfferRepository(ctrl),
+		MockScheduledRepository:  NewMockScheduledRepository(ctrl),
+		MockEarningsRecorder:     NewMockEarningsRecorder(ctrl),
+		MockCashRecorder:         NewMockCashRecorder(ctrl),
 	}
 }
 
@@ -56,16 +73,31 @@ func TestDeliveryService_DeliveryAssign(t *testing.T) {
 		TransportType: entities.Car,
 		CreatedAt:     fixedTime,
 		UpdatedAt:     fixedTime,
+		Version:       3,
 	}
+	busyStatus := entities.CourierBusy
+
+	route := &entities.Route{
+		Pickup:  entities.Location{Latitude: 55.7558, Longitude: 37.6173},
+		Dropoff: entities.Location{Latitude: 55.7602, Longitude: 37.6186},
+	}
+
+	promisedAt := time.Now().UTC().Add(2 * time.Hour).Truncate(time.Second)
+	expiredPromise := time.Now().UTC().Add(-time.Hour)
+	address := &entities.Address{Street: "Тверская", House: "1", Apartment: "15"}
 
 	tests := []struct {
-		name           string
-		orderID        string
-		deadlineOffset time.Duration
-		mockSetup      func(m *mock)
-		expectedResult *entities.DeliveryAssignment
-		resultChecker  func(t *testing.T, result *entities.DeliveryAssignment, before, after time.Time)
-		errorAssertion require.ErrorAssertionFunc
+		name              string
+		orderID           string
+		route             *entities.Route
+		restaurantID      string
+		address           *entities.Address
+		estimatedDelivery *time.Time
+		deadlineOffset    time.Duration
+		mockSetup         func(m *mock)
+		expectedResult    *entities.DeliveryAssignment
+		resultChecker     func(t *testing.T, result *entities.DeliveryAssignment, before, after time.Time)
+		errorAssertion    require.ErrorAssertionFunc
 	}{
 		{
 			name:           "Успешное назначение доставки доступному курьеру с валидным ID заказа",
@@ -78,13 +110,13 @@ func TestDeliveryService_DeliveryAssign(t *testing.T) {
 						return fn(ctx)
 					})
 				m.MockRepository.EXPECT().
-					GetCourierForAssignment(gomock.Any()).
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
 					Return(availableCourier, nil)
 
 				m.MockDeliveryTimeFactory.EXPECT().
-					CalculateDeadline(availableCourier.TransportType, gomock.Any()).
-					DoAndReturn(func(transportType entities.CourierTransportType, baseTime time.Time) time.Time {
-						return baseTime.Add(30 * time.Minute)
+					CalculateDeadline(gomock.Any(), availableCourier.TransportType, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+						return baseTime.Add(30 * time.Minute), nil
 					})
 				m.MockRepository.EXPECT().
 					Create(gomock.Any(), gomock.Any()).
@@ -98,7 +130,11 @@ func TestDeliveryService_DeliveryAssign(t *testing.T) {
 						}, nil
 					})
 				m.MockCourierService.EXPECT().
-					UpdateCourier(gomock.Any(), gomock.Any()).
+					UpdateCourier(gomock.Any(), entities.CourierModify{
+						ID:              &availableCourier.ID,
+						Status:          &busyStatus,
+						ExpectedVersion: &availableCourier.Version,
+					}).
 					Return(availableCourier, nil)
 			},
 			expectedResult: &entities.DeliveryAssignment{
@@ -129,6 +165,205 @@ func TestDeliveryService_DeliveryAssign(t *testing.T) {
 			},
 			errorAssertion: errorAssertion(delivery.ErrInvalidOrderID, ""),
 		},
+		{
+			name:           "Курьера изменили после выбора - назначение отменяется",
+			orderID:        "order-2026-001",
+			deadlineOffset: 30 * time.Minute,
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(availableCourier, nil)
+				m.MockDeliveryTimeFactory.EXPECT().
+					CalculateDeadline(gomock.Any(), availableCourier.TransportType, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+						return baseTime.Add(30 * time.Minute), nil
+					})
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(&entities.Delivery{ID: 1, CourierID: 1, OrderID: "order-2026-001"}, nil)
+				m.MockCourierService.EXPECT().
+					UpdateCourier(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("failed to update courier: %w", courierService.ErrVersionMismatch))
+			},
+			expectedResult: nil,
+			resultChecker: func(t *testing.T, result *entities.DeliveryAssignment, before, after time.Time) {
+				assert.Nil(t, result)
+			},
+			errorAssertion: errorAssertion(delivery.ErrCourierNotAvailable, "courier was modified concurrently"),
+		},
+		{
+			name:           "Отклонение назначения доставки с некорректными координатами маршрута",
+			orderID:        "order-2026-001",
+			route:          &entities.Route{Pickup: entities.Location{Latitude: 91, Longitude: 0}},
+			deadlineOffset: 30 * time.Minute,
+			expectedResult: nil,
+			resultChecker: func(t *testing.T, result *entities.DeliveryAssignment, before, after time.Time) {
+				assert.Nil(t, result)
+			},
+			errorAssertion: errorAssertion(delivery.ErrInvalidRoute, ""),
+		},
+		{
+			name:           "Маршрут передается в расчет дедлайна",
+			orderID:        "order-2026-001",
+			route:          route,
+			deadlineOffset: 12 * time.Minute,
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				// точка забора вне всех зон - любой свободный курьер
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(nil, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(availableCourier, nil)
+				m.MockDeliveryTimeFactory.EXPECT().
+					CalculateDeadline(gomock.Any(), availableCourier.TransportType, route, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+						return baseTime.Add(12 * time.Minute), nil
+					})
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, modify entities.DeliveryModify) (*entities.Delivery, error) {
+						return &entities.Delivery{
+							ID:         1,
+							CourierID:  *modify.CourierID,
+							OrderID:    *modify.OrderID,
+							AssignedAt: *modify.AssignedAt,
+							Deadline:   *modify.Deadline,
+						}, nil
+					})
+				m.MockCourierService.EXPECT().
+					UpdateCourier(gomock.Any(), gomock.Any()).
+					Return(availableCourier, nil)
+			},
+			resultChecker: func(t *testing.T, result *entities.DeliveryAssignment, before, after time.Time) {
+				require.NotNil(t, result)
+				assert.WithinDuration(t, result.AssignedAt.Add(12*time.Minute), result.Deadline, time.Second)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:              "Дедлайн берется из обещанного клиенту времени доставки",
+			orderID:           "order-2026-001",
+			restaurantID:      "restaurant-7",
+			address:           address,
+			estimatedDelivery: &promisedAt,
+			deadlineOffset:    30 * time.Minute,
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(availableCourier, nil)
+				m.MockDeliveryTimeFactory.EXPECT().
+					CalculateDeadline(gomock.Any(), availableCourier.TransportType, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+						return baseTime.Add(30 * time.Minute), nil
+					})
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, modify entities.DeliveryModify) (*entities.Delivery, error) {
+						assert.Equal(t, "restaurant-7", *modify.RestaurantID)
+						assert.Equal(t, address, modify.Address)
+						assert.Equal(t, &promisedAt, modify.EstimatedDelivery)
+						return &entities.Delivery{
+							ID:         1,
+							CourierID:  *modify.CourierID,
+							OrderID:    *modify.OrderID,
+							AssignedAt: *modify.AssignedAt,
+							Deadline:   *modify.Deadline,
+						}, nil
+					})
+				m.MockCourierService.EXPECT().
+					UpdateCourier(gomock.Any(), gomock.Any()).
+					Return(availableCourier, nil)
+			},
+			resultChecker: func(t *testing.T, result *entities.DeliveryAssignment, before, after time.Time) {
+				require.NotNil(t, result)
+				assert.Equal(t, promisedAt, result.Deadline)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:              "Прошедшее обещанное время не заменяет расчетный дедлайн",
+			orderID:           "order-2026-001",
+			estimatedDelivery: &expiredPromise,
+			deadlineOffset:    30 * time.Minute,
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(availableCourier, nil)
+				m.MockDeliveryTimeFactory.EXPECT().
+					CalculateDeadline(gomock.Any(), availableCourier.TransportType, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+						return baseTime.Add(30 * time.Minute), nil
+					})
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, modify entities.DeliveryModify) (*entities.Delivery, error) {
+						return &entities.Delivery{
+							ID:         1,
+							CourierID:  *modify.CourierID,
+							OrderID:    *modify.OrderID,
+							AssignedAt: *modify.AssignedAt,
+							Deadline:   *modify.Deadline,
+						}, nil
+					})
+				m.MockCourierService.EXPECT().
+					UpdateCourier(gomock.Any(), gomock.Any()).
+					Return(availableCourier, nil)
+			},
+			resultChecker: func(t *testing.T, result *entities.DeliveryAssignment, before, after time.Time) {
+				require.NotNil(t, result)
+				assert.WithinDuration(t, result.AssignedAt.Add(30*time.Minute), result.Deadline, time.Second)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:           "Отклонение назначения при ошибке расчета дедлайна",
+			orderID:        "order-2026-001",
+			route:          route,
+			deadlineOffset: 30 * time.Minute,
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				// точка забора вне всех зон - любой свободный курьер
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(nil, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(availableCourier, nil)
+				m.MockDeliveryTimeFactory.EXPECT().
+					CalculateDeadline(gomock.Any(), availableCourier.TransportType, route, gomock.Any()).
+					Return(time.Time{}, errors.New("database connection lost"))
+			},
+			expectedResult: nil,
+			resultChecker: func(t *testing.T, result *entities.DeliveryAssignment, before, after time.Time) {
+				assert.Nil(t, result)
+			},
+			errorAssertion: errorAssertion(nil, "calculate deadline: database connection lost"),
+		},
 		{
 			name:           "Отклонение назначения когда нет доступных курьеров в системе",
 			orderID:        "order-2026-001",
@@ -140,7 +375,7 @@ func TestDeliveryService_DeliveryAssign(t *testing.T) {
 						return fn(ctx)
 					})
 				m.MockRepository.EXPECT().
-					GetCourierForAssignment(gomock.Any()).
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
 					Return(nil, errors.New("no active couriers found"))
 			},
 			expectedResult: nil,
@@ -150,7 +385,51 @@ func TestDeliveryService_DeliveryAssign(t *testing.T) {
 			errorAssertion: errorAssertion(nil, "find courier for assignment: no active couriers found"),
 		},
 		{
-			name:           "Отклонение назначения когда все курьеры заняты",
+			name:           "Постановка заказа в очередь ожидания когда все курьеры заняты",
+			orderID:        "order-2026-001",
+			route:          route,
+			deadlineOffset: 30 * time.Minute,
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				// точка забора вне всех зон - любой свободный курьер
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(nil, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					ExplainCourierMismatch(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(&entities.CourierMismatch{}, nil)
+				m.MockPendingRepository.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, modify entities.PendingAssignmentModify) (*entities.PendingAssignment, error) {
+						assert.Equal(t, "order-2026-001", *modify.OrderID)
+						assert.Equal(t, entities.DefaultPendingPriority, *modify.Priority)
+						assert.Equal(t, route, modify.Route)
+						return &entities.PendingAssignment{
+							ID:         1,
+							OrderID:    *modify.OrderID,
+							Priority:   *modify.Priority,
+							EnqueuedAt: *modify.EnqueuedAt,
+						}, nil
+					})
+			},
+			expectedResult: nil,
+			resultChecker: func(t *testing.T, result *entities.DeliveryAssignment, before, after time.Time) {
+				assert.Nil(t, result)
+			},
+			errorAssertion: func(t require.TestingT, err error, msgAndArgs ...interface{}) {
+				errorAssertion(delivery.ErrAssignmentPending, "")(t, err, msgAndArgs...)
+				assert.ErrorIs(t, err, delivery.ErrNoAvailableCouriers, msgAndArgs...)
+			},
+		},
+		{
+			name:           "Отклонение назначения когда все курьеры заняты и очередь недоступна",
 			orderID:        "order-2026-001",
 			deadlineOffset: 30 * time.Minute,
 			mockSetup: func(m *mock) {
@@ -160,14 +439,23 @@ func TestDeliveryService_DeliveryAssign(t *testing.T) {
 						return fn(ctx)
 					})
 				m.MockRepository.EXPECT().
-					GetCourierForAssignment(gomock.Any()).
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
 					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					ExplainCourierMismatch(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(&entities.CourierMismatch{}, nil)
+				m.MockPendingRepository.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database connection lost"))
 			},
 			expectedResult: nil,
 			resultChecker: func(t *testing.T, result *entities.DeliveryAssignment, before, after time.Time) {
 				assert.Nil(t, result)
 			},
-			errorAssertion: errorAssertion(delivery.ErrNoAvailableCouriers, ""),
+			errorAssertion: func(t require.TestingT, err error, msgAndArgs ...interface{}) {
+				errorAssertion(delivery.ErrNoAvailableCouriers, "enqueue pending assignment: database connection lost")(t, err, msgAndArgs...)
+				assert.NotErrorIs(t, err, delivery.ErrAssignmentPending, msgAndArgs...)
+			},
 		},
 		{
 			name:           "Отклонение назначения при нарушении ограничений базы данных",
@@ -180,12 +468,12 @@ func TestDeliveryService_DeliveryAssign(t *testing.T) {
 						return fn(ctx)
 					})
 				m.MockRepository.EXPECT().
-					GetCourierForAssignment(gomock.Any()).
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
 					Return(availableCourier, nil)
 				m.MockDeliveryTimeFactory.EXPECT().
-					CalculateDeadline(availableCourier.TransportType, gomock.Any()).
-					DoAndReturn(func(transportType entities.CourierTransportType, baseTime time.Time) time.Time {
-						return baseTime.Add(30 * time.Minute)
+					CalculateDeadline(gomock.Any(), availableCourier.TransportType, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+						return baseTime.Add(30 * time.Minute), nil
 					})
 				m.MockRepository.EXPECT().
 					Create(gomock.Any(), gomock.Any()).
@@ -208,12 +496,12 @@ func TestDeliveryService_DeliveryAssign(t *testing.T) {
 						return fn(ctx)
 					})
 				m.MockRepository.EXPECT().
-					GetCourierForAssignment(gomock.Any()).
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
 					Return(availableCourier, nil)
 				m.MockDeliveryTimeFactory.EXPECT().
-					CalculateDeadline(availableCourier.TransportType, gomock.Any()).
-					DoAndReturn(func(transportType entities.CourierTransportType, baseTime time.Time) time.Time {
-						return baseTime.Add(30 * time.Minute)
+					CalculateDeadline(gomock.Any(), availableCourier.TransportType, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+						return baseTime.Add(30 * time.Minute), nil
 					})
 				m.MockRepository.EXPECT().
 					Create(gomock.Any(), gomock.Any()).
@@ -236,12 +524,12 @@ func TestDeliveryService_DeliveryAssign(t *testing.T) {
 						return fn(ctx)
 					})
 				m.MockRepository.EXPECT().
-					GetCourierForAssignment(gomock.Any()).
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
 					Return(availableCourier, nil)
 				m.MockDeliveryTimeFactory.EXPECT().
-					CalculateDeadline(availableCourier.TransportType, gomock.Any()).
-					DoAndReturn(func(transportType entities.CourierTransportType, baseTime time.Time) time.Time {
-						return baseTime.Add(30 * time.Minute)
+					CalculateDeadline(gomock.Any(), availableCourier.TransportType, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+						return baseTime.Add(30 * time.Minute), nil
 					})
 				m.MockRepository.EXPECT().
 					Create(gomock.Any(), gomock.Any()).
@@ -294,13 +582,33 @@ func TestDeliveryService_DeliveryAssign(t *testing.T) {
 
 			service := delivery.New(
 				m.MockRepository,
+				m.MockPendingRepository,
 				m.MockCourierService,
 				m.MockDeliveryTimeFactory,
 				m.MockTxManager,
+				m.MockAvailabilityNotifier,
+				m.MockZoneResolver,
+				delivery.ZonePolicy{},
+				m.MockOfferRepository,
+				delivery.OfferPolicy{},
+				delivery.BatchPolicy{},
+				delivery.DispatchPolicy{},
+				delivery.PriorityPolicy{},
+				m.MockScheduledRepository,
+				delivery.RatingPolicy{},
+				m.MockEarningsRecorder,
+				m.MockCashRecorder,
+				delivery.CashPolicy{},
 			)
 
 			beforeCall := time.Now().UTC()
-			result, err := service.DeliveryAssign(context.Background(), tt.orderID)
+			result, err := service.DeliveryAssign(context.Background(), entities.DeliveryAssignParams{
+				OrderID:           tt.orderID,
+				Route:             tt.route,
+				RestaurantID:      tt.restaurantID,
+				Address:           tt.address,
+				EstimatedDelivery: tt.estimatedDelivery,
+			})
 			afterCall := time.Now().UTC()
 
 			tt.resultChecker(t, result, beforeCall, afterCall)
@@ -323,6 +631,11 @@ func TestDeliveryService_DeliveryUnassign(t *testing.T) {
 		CreatedAt:     fixedTime,
 		UpdatedAt:     fixedTime,
 	}
+	busyCourier := &entities.Courier{
+		ID:            1,
+		Status:        entities.CourierBusy,
+		TransportType: entities.Car,
+	}
 
 	tests := []struct {
 		name           string
@@ -349,11 +662,14 @@ func TestDeliveryService_DeliveryUnassign(t *testing.T) {
 				m.MockCourierService.EXPECT().
 					UpdateCourier(gomock.Any(), gomock.Any()).
 					Return(updatedCourier, nil)
+				m.MockAvailabilityNotifier.EXPECT().
+					Notify()
 			},
 			expectedResult: &entities.DeliveryUnassignment{
-				CourierID: updatedCourier.ID,
-				OrderID:   "order-2026-001",
-				Status:    updatedCourier.Status.String(),
+				CourierID:     updatedCourier.ID,
+				OrderID:       "order-2026-001",
+				Status:        updatedCourier.Status.String(),
+				TransportType: updatedCourier.TransportType,
 			},
 			errorAssertion: require.NoError,
 		},
@@ -380,7 +696,7 @@ func TestDeliveryService_DeliveryUnassign(t *testing.T) {
 			errorAssertion: errorAssertion(delivery.ErrDeliveryNotFound, ""),
 		},
 		{
-			name:    "Отклонение снятия когда у курьера есть другие активные доставки",
+			name:    "Снятие одной из доставок курьера с другими активными доставками, курьер остается занят",
 			orderID: "order-2026-001",
 			mockSetup: func(m *mock) {
 				m.MockTxManager.EXPECT().
@@ -391,9 +707,22 @@ func TestDeliveryService_DeliveryUnassign(t *testing.T) {
 				m.MockRepository.EXPECT().
 					GetCourierIDAndDeliveryCountByOrderIDForAssing(gomock.Any(), "order-2026-001").
 					Return(int64(1), int64(2), nil)
+				m.MockRepository.EXPECT().
+					Delete(gomock.Any(), "order-2026-001").
+					Return(nil)
+				m.MockRepository.EXPECT().
+					GetCourierByIDForUpdate(gomock.Any(), int64(1)).
+					Return(busyCourier, nil)
+				m.MockAvailabilityNotifier.EXPECT().
+					Notify()
 			},
-			expectedResult: nil,
-			errorAssertion: errorAssertion(delivery.ErrCourierHasActiveDeliveries, ""),
+			expectedResult: &entities.DeliveryUnassignment{
+				CourierID:     busyCourier.ID,
+				OrderID:       "order-2026-001",
+				Status:        entities.CourierBusy.String(),
+				TransportType: busyCourier.TransportType,
+			},
+			errorAssertion: require.NoError,
 		},
 		{
 			name:    "Отклонение снятия при ошибке удаления доставки",
@@ -461,9 +790,23 @@ func TestDeliveryService_DeliveryUnassign(t *testing.T) {
 
 			service := delivery.New(
 				m.MockRepository,
+				m.MockPendingRepository,
 				m.MockCourierService,
 				m.MockDeliveryTimeFactory,
 				m.MockTxManager,
+				m.MockAvailabilityNotifier,
+				m.MockZoneResolver,
+				delivery.ZonePolicy{},
+				m.MockOfferRepository,
+				delivery.OfferPolicy{},
+				delivery.BatchPolicy{},
+				delivery.DispatchPolicy{},
+				delivery.PriorityPolicy{},
+				m.MockScheduledRepository,
+				delivery.RatingPolicy{},
+				m.MockEarningsRecorder,
+				m.MockCashRecorder,
+				delivery.CashPolicy{},
 			)
 
 			result, err := service.DeliveryUnassign(context.Background(), tt.orderID)
@@ -474,78 +817,6 @@ func TestDeliveryService_DeliveryUnassign(t *testing.T) {
 	}
 }
 
-func TestDeliveryService_CleanupExpiredDeliveries(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name           string
-		mockSetup      func(m *mock)
-		errorAssertion require.ErrorAssertionFunc
-	}{
-		{
-			name: "Успешная очистка истекших доставок с освобождением 3 курьеров",
-			mockSetup: func(m *mock) {
-				m.MockRepository.EXPECT().
-					UpdateCouriersAvailableWhereDeadlineExpired(gomock.Any()).
-					Return(int64(3), nil)
-			},
-			errorAssertion: require.NoError,
-		},
-		{
-			name: "Успешная очистка когда нет истекших доставок",
-			mockSetup: func(m *mock) {
-				m.MockRepository.EXPECT().
-					UpdateCouriersAvailableWhereDeadlineExpired(gomock.Any()).
-					Return(int64(0), nil)
-			},
-			errorAssertion: require.NoError,
-		},
-		{
-			name: "Очистка возвращает ошибку от репозитория",
-			mockSetup: func(m *mock) {
-				m.MockRepository.EXPECT().
-					UpdateCouriersAvailableWhereDeadlineExpired(gomock.Any()).
-					Return(int64(0), errors.New("cleanup query execution failed"))
-			},
-			errorAssertion: errorAssertion(nil, "cleanup: cleanup query execution failed"),
-		},
-		{
-			name: "Таймаут контекста при выполнении очистки",
-			mockSetup: func(m *mock) {
-				m.MockRepository.EXPECT().
-					UpdateCouriersAvailableWhereDeadlineExpired(gomock.Any()).
-					Return(int64(0), context.DeadlineExceeded)
-			},
-			errorAssertion: errorAssertion(nil, "cleanup timed out"),
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			ctrl := gomock.NewController(t)
-			m := newMock(ctrl)
-
-			if tt.mockSetup != nil {
-				tt.mockSetup(m)
-			}
-
-			service := delivery.New(
-				m.MockRepository,
-				m.MockCourierService,
-				m.MockDeliveryTimeFactory,
-				m.MockTxManager,
-			)
-
-			ctx := context.Background()
-			_, err := service.CleanupExpiredDeliveries(ctx)
-
-			tt.errorAssertion(t, err, tt.name)
-		})
-	}
-}
-
 func TestDeliveryService_FreeCourierByOrderID(t *testing.T) {
 	t.Parallel()
 
@@ -577,9 +848,23 @@ func TestDeliveryService_FreeCourierByOrderID(t *testing.T) {
 				m.MockRepository.EXPECT().
 					GetCourierIDByOrderID(gomock.Any(), "order-2026-001").
 					Return(int64(1), nil)
+				m.MockRepository.EXPECT().
+					MarkCompleted(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return(nil)
+				m.MockEarningsRecorder.EXPECT().
+					RecordEarning(gomock.Any(), "order-2026-001").
+					Return(nil)
+				m.MockCashRecorder.EXPECT().
+					RecordCollection(gomock.Any(), "order-2026-001").
+					Return(nil)
+				m.MockRepository.EXPECT().
+					CountActiveDeliveriesByCourierID(gomock.Any(), int64(1)).
+					Return(int64(0), nil)
 				m.MockCourierService.EXPECT().
 					UpdateCourier(gomock.Any(), gomock.Any()).
 					Return(updatedCourier, nil)
+				m.MockAvailabilityNotifier.EXPECT().
+					Notify()
 			},
 			errorAssertion: require.NoError,
 		},
@@ -619,7 +904,7 @@ func TestDeliveryService_FreeCourierByOrderID(t *testing.T) {
 			errorAssertion: errorAssertion(nil, "get courier by order id: database connection timeout"),
 		},
 		{
-			name:    "Отклонение освобождения при ошибке обновления статуса курьера",
+			name:    "Отклонение освобождения при ошибке сохранения времени выполнения",
 			orderID: "order-2026-001",
 			mockSetup: func(m *mock) {
 				m.MockTxManager.EXPECT().
@@ -630,9 +915,140 @@ func TestDeliveryService_FreeCourierByOrderID(t *testing.T) {
 				m.MockRepository.EXPECT().
 					GetCourierIDByOrderID(gomock.Any(), "order-2026-001").
 					Return(int64(1), nil)
-				m.MockCourierService.EXPECT().
-					UpdateCourier(gomock.Any(), gomock.Any()).
-					Return(nil, errors.New("courier service unavailable"))
+				m.MockRepository.EXPECT().
+					MarkCompleted(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return(errors.New("database connection timeout"))
+			},
+			errorAssertion: errorAssertion(nil, "mark delivery completed: database connection timeout"),
+		},
+		{
+			name:    "Отклонение освобождения при ошибке начисления оплаты курьеру",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				m.MockRepository.EXPECT().
+					GetCourierIDByOrderID(gomock.Any(), "order-2026-001").
+					Return(int64(1), nil)
+				m.MockRepository.EXPECT().
+					MarkCompleted(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return(nil)
+				m.MockEarningsRecorder.EXPECT().
+					RecordEarning(gomock.Any(), "order-2026-001").
+					Return(errors.New("database connection timeout"))
+			},
+			errorAssertion: errorAssertion(nil, "record courier earning: database connection timeout"),
+		},
+		{
+			name:    "Отклонение освобождения при ошибке зачисления наличных в кассу курьера",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				m.MockRepository.EXPECT().
+					GetCourierIDByOrderID(gomock.Any(), "order-2026-001").
+					Return(int64(1), nil)
+				m.MockRepository.EXPECT().
+					MarkCompleted(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return(nil)
+				m.MockEarningsRecorder.EXPECT().
+					RecordEarning(gomock.Any(), "order-2026-001").
+					Return(nil)
+				m.MockCashRecorder.EXPECT().
+					RecordCollection(gomock.Any(), "order-2026-001").
+					Return(errors.New("database connection timeout"))
+			},
+			errorAssertion: errorAssertion(nil, "record courier cash collection: database connection timeout"),
+		},
+		{
+			name:    "Курьер с другими доставками группы остается занят",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				m.MockRepository.EXPECT().
+					GetCourierIDByOrderID(gomock.Any(), "order-2026-001").
+					Return(int64(1), nil)
+				m.MockRepository.EXPECT().
+					MarkCompleted(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return(nil)
+				m.MockEarningsRecorder.EXPECT().
+					RecordEarning(gomock.Any(), "order-2026-001").
+					Return(nil)
+				m.MockCashRecorder.EXPECT().
+					RecordCollection(gomock.Any(), "order-2026-001").
+					Return(nil)
+				m.MockRepository.EXPECT().
+					CountActiveDeliveriesByCourierID(gomock.Any(), int64(1)).
+					Return(int64(2), nil)
+				m.MockAvailabilityNotifier.EXPECT().
+					Notify()
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:    "Отклонение освобождения при ошибке подсчета активных доставок курьера",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				m.MockRepository.EXPECT().
+					GetCourierIDByOrderID(gomock.Any(), "order-2026-001").
+					Return(int64(1), nil)
+				m.MockRepository.EXPECT().
+					MarkCompleted(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return(nil)
+				m.MockEarningsRecorder.EXPECT().
+					RecordEarning(gomock.Any(), "order-2026-001").
+					Return(nil)
+				m.MockCashRecorder.EXPECT().
+					RecordCollection(gomock.Any(), "order-2026-001").
+					Return(nil)
+				m.MockRepository.EXPECT().
+					CountActiveDeliveriesByCourierID(gomock.Any(), int64(1)).
+					Return(int64(0), errors.New("database connection timeout"))
+			},
+			errorAssertion: errorAssertion(nil, "count courier active deliveries: database connection timeout"),
+		},
+		{
+			name:    "Отклонение освобождения при ошибке обновления статуса курьера",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				m.MockRepository.EXPECT().
+					GetCourierIDByOrderID(gomock.Any(), "order-2026-001").
+					Return(int64(1), nil)
+				m.MockRepository.EXPECT().
+					MarkCompleted(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return(nil)
+				m.MockEarningsRecorder.EXPECT().
+					RecordEarning(gomock.Any(), "order-2026-001").
+					Return(nil)
+				m.MockCashRecorder.EXPECT().
+					RecordCollection(gomock.Any(), "order-2026-001").
+					Return(nil)
+				m.MockRepository.EXPECT().
+					CountActiveDeliveriesByCourierID(gomock.Any(), int64(1)).
+					Return(int64(0), nil)
+				m.MockCourierService.EXPECT().
+					UpdateCourier(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("courier service unavailable"))
 			},
 			errorAssertion: errorAssertion(nil, "update courier status: courier service unavailable"),
 		},
@@ -648,6 +1064,18 @@ func TestDeliveryService_FreeCourierByOrderID(t *testing.T) {
 				m.MockRepository.EXPECT().
 					GetCourierIDByOrderID(gomock.Any(), "order-2026-001").
 					Return(int64(1), nil)
+				m.MockRepository.EXPECT().
+					MarkCompleted(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return(nil)
+				m.MockEarningsRecorder.EXPECT().
+					RecordEarning(gomock.Any(), "order-2026-001").
+					Return(nil)
+				m.MockCashRecorder.EXPECT().
+					RecordCollection(gomock.Any(), "order-2026-001").
+					Return(nil)
+				m.MockRepository.EXPECT().
+					CountActiveDeliveriesByCourierID(gomock.Any(), int64(1)).
+					Return(int64(0), nil)
 				unchangedCourier := &entities.Courier{
 					ID:     1,
 					Status: entities.CourierBusy,
@@ -683,9 +1111,23 @@ func TestDeliveryService_FreeCourierByOrderID(t *testing.T) {
 
 			service := delivery.New(
 				m.MockRepository,
+				m.MockPendingRepository,
 				m.MockCourierService,
 				m.MockDeliveryTimeFactory,
 				m.MockTxManager,
+				m.MockAvailabilityNotifier,
+				m.MockZoneResolver,
+				delivery.ZonePolicy{},
+				m.MockOfferRepository,
+				delivery.OfferPolicy{},
+				delivery.BatchPolicy{},
+				delivery.DispatchPolicy{},
+				delivery.PriorityPolicy{},
+				m.MockScheduledRepository,
+				delivery.RatingPolicy{},
+				m.MockEarningsRecorder,
+				m.MockCashRecorder,
+				delivery.CashPolicy{},
 			)
 
 			err := service.FreeCourierByOrderID(context.Background(), tt.orderID)
@@ -695,52 +1137,311 @@ func TestDeliveryService_FreeCourierByOrderID(t *testing.T) {
 	}
 }
 
-func TestDeliveryService_CleanupExpiredDeliveries_FullCoverage(t *testing.T) {
+func TestDeliveryService_AssignPendingDeliveries(t *testing.T) {
 	t.Parallel()
 
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	availableCourier := &entities.Courier{
+		ID:            1,
+		Name:          "Snake Plissken",
+		Phone:         "+79161234567",
+		Status:        entities.CourierAvailable,
+		TransportType: entities.Car,
+		CreatedAt:     fixedTime,
+		UpdatedAt:     fixedTime,
+	}
+
+	pending := &entities.PendingAssignment{
+		ID:         1,
+		OrderID:    "order-2026-001",
+		Priority:   entities.DefaultPendingPriority,
+		EnqueuedAt: fixedTime,
+	}
+
+	txPassThrough := func(m *mock) {
+		m.MockTxManager.EXPECT().
+			Do(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			}).
+			AnyTimes()
+	}
+
+	expectAssign := func(m *mock) {
+		m.MockRepository.EXPECT().
+			GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+			Return(availableCourier, nil)
+		m.MockDeliveryTimeFactory.EXPECT().
+			CalculateDeadline(gomock.Any(), availableCourier.TransportType, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+				return baseTime.Add(5 * time.Minute), nil
+			})
+		m.MockRepository.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, modify entities.DeliveryModify) (*entities.Delivery, error) {
+				assert.Equal(t, pending.EnqueuedAt, *modify.CreatedAt)
+				return &entities.Delivery{
+					ID:         1,
+					CourierID:  *modify.CourierID,
+					OrderID:    *modify.OrderID,
+					CreatedAt:  *modify.CreatedAt,
+					AssignedAt: *modify.AssignedAt,
+					Deadline:   *modify.Deadline,
+				}, nil
+			})
+		m.MockCourierService.EXPECT().
+			UpdateCourier(gomock.Any(), gomock.Any()).
+			Return(availableCourier, nil)
+	}
+
 	tests := []struct {
 		name           string
 		mockSetup      func(m *mock)
-		rowsAffected   int64
+		expectedCount  int64
 		errorAssertion require.ErrorAssertionFunc
 	}{
 		{
-			name:         "Успешная очистка с освобождением курьеров - логируется",
-			rowsAffected: 3,
+			name: "Пустая очередь ожидания",
 			mockSetup: func(m *mock) {
-				m.MockRepository.EXPECT().
-					UpdateCouriersAvailableWhereDeadlineExpired(gomock.Any()).
-					Return(int64(3), nil)
+				txPassThrough(m)
+				m.MockPendingRepository.EXPECT().
+					GetNextForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, delivery.ErrPendingQueueEmpty)
+			},
+			expectedCount:  0,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Назначение заказа из очереди и остановка на пустой очереди",
+			mockSetup: func(m *mock) {
+				txPassThrough(m)
+				gomock.InOrder(
+					m.MockPendingRepository.EXPECT().
+						GetNextForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(pending, nil),
+					m.MockPendingRepository.EXPECT().
+						Delete(gomock.Any(), pending.OrderID).
+						Return(nil),
+					m.MockPendingRepository.EXPECT().
+						GetNextForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, delivery.ErrPendingQueueEmpty),
+				)
+				expectAssign(m)
 			},
+			expectedCount:  1,
 			errorAssertion: require.NoError,
 		},
 		{
-			name:         "Успешная очистка без освобождения курьеров - лог не вызывается",
-			rowsAffected: 0,
+			name: "Остановка разбора когда свободные курьеры закончились",
 			mockSetup: func(m *mock) {
+				txPassThrough(m)
+				m.MockPendingRepository.EXPECT().
+					GetNextForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(pending, nil)
 				m.MockRepository.EXPECT().
-					UpdateCouriersAvailableWhereDeadlineExpired(gomock.Any()).
-					Return(int64(0), nil)
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					ExplainCourierMismatch(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(&entities.CourierMismatch{}, nil)
 			},
+			expectedCount:  0,
 			errorAssertion: require.NoError,
 		},
 		{
-			name: "Обработка таймаута контекста",
+			name: "Заказ, которому не подошел ни один свободный курьер, не задерживает заказы за ним",
 			mockSetup: func(m *mock) {
+				thermal := entities.OrderRequirements{Skills: []entities.CourierSkill{entities.SkillThermalBag}}
+				thermalFilter := entities.CourierSearchFilter{Skills: thermal.Skills}
+				thermalPending := &entities.PendingAssignment{
+					ID:           2,
+					OrderID:      "order-2026-thermal",
+					Priority:     entities.DefaultPendingPriority,
+					Requirements: thermal,
+					EnqueuedAt:   fixedTime,
+				}
+
+				txPassThrough(m)
+				gomock.InOrder(
+					m.MockPendingRepository.EXPECT().
+						GetNextForUpdate(gomock.Any(), gomock.Any(), []string(nil)).
+						Return(thermalPending, nil),
+					m.MockPendingRepository.EXPECT().
+						GetNextForUpdate(gomock.Any(), gomock.Any(), []string{thermalPending.OrderID}).
+						Return(pending, nil),
+					m.MockPendingRepository.EXPECT().
+						Delete(gomock.Any(), pending.OrderID).
+						Return(nil),
+					m.MockPendingRepository.EXPECT().
+						GetNextForUpdate(gomock.Any(), gomock.Any(), []string{thermalPending.OrderID}).
+						Return(nil, delivery.ErrPendingQueueEmpty),
+				)
 				m.MockRepository.EXPECT().
-					UpdateCouriersAvailableWhereDeadlineExpired(gomock.Any()).
-					Return(int64(0), context.DeadlineExceeded)
+					GetCourierForAssignment(gomock.Any(), thermalFilter).
+					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					ExplainCourierMismatch(gomock.Any(), thermalFilter).
+					Return(&entities.CourierMismatch{
+						AvailableCouriers: 1,
+						Requirements: []entities.RequirementMatch{
+							{Requirement: entities.RequirementSkill, Value: "thermal_bag", MatchingCouriers: 0},
+						},
+					}, nil)
+				expectAssign(m)
 			},
-			errorAssertion: errorAssertion(nil, "cleanup timed out"),
+			expectedCount:  1,
+			errorAssertion: require.NoError,
 		},
 		{
-			name: "Обработка произвольной ошибки репозитория",
+			name: "Удаление устаревшей записи для уже назначенного заказа",
 			mockSetup: func(m *mock) {
+				txPassThrough(m)
+				gomock.InOrder(
+					m.MockPendingRepository.EXPECT().
+						GetNextForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(pending, nil),
+					m.MockPendingRepository.EXPECT().
+						Delete(gomock.Any(), pending.OrderID).
+						Return(nil),
+					m.MockPendingRepository.EXPECT().
+						GetNextForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, delivery.ErrPendingQueueEmpty),
+				)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(availableCourier, nil)
+				m.MockDeliveryTimeFactory.EXPECT().
+					CalculateDeadline(gomock.Any(), availableCourier.TransportType, gomock.Any(), gomock.Any()).
+					Return(fixedTime.Add(5*time.Minute), nil)
 				m.MockRepository.EXPECT().
-					UpdateCouriersAvailableWhereDeadlineExpired(gomock.Any()).
-					Return(int64(0), errors.New("database deadlock"))
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, delivery.ErrOrderAlreadyAssigned)
+			},
+			expectedCount:  0,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Ошибка репозитория при чтении очереди",
+			mockSetup: func(m *mock) {
+				txPassThrough(m)
+				m.MockPendingRepository.EXPECT().
+					GetNextForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database connection lost"))
+			},
+			expectedCount:  0,
+			errorAssertion: errorAssertion(nil, "get next pending assignment: database connection lost"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := delivery.New(
+				m.MockRepository,
+				m.MockPendingRepository,
+				m.MockCourierService,
+				m.MockDeliveryTimeFactory,
+				m.MockTxManager,
+				m.MockAvailabilityNotifier,
+				m.MockZoneResolver,
+				delivery.ZonePolicy{},
+				m.MockOfferRepository,
+				delivery.OfferPolicy{},
+				delivery.BatchPolicy{},
+				delivery.DispatchPolicy{},
+				delivery.PriorityPolicy{},
+				m.MockScheduledRepository,
+				delivery.RatingPolicy{},
+				m.MockEarningsRecorder,
+				m.MockCashRecorder,
+				delivery.CashPolicy{},
+			)
+
+			count, err := service.AssignPendingDeliveries(context.Background())
+
+			assert.Equal(t, tt.expectedCount, count)
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
+
+func TestDeliveryService_CancelPendingAssignment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		orderID        string
+		mockSetup      func(m *mock)
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:    "Успешное удаление заказа из очереди ожидания",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				m.MockOfferRepository.EXPECT().
+					CancelPendingByOrderID(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return(false, nil)
+				m.MockPendingRepository.EXPECT().
+					Delete(gomock.Any(), "order-2026-001").
+					Return(nil)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:           "Отклонение удаления с пустым ID заказа",
+			orderID:        "",
+			errorAssertion: errorAssertion(delivery.ErrInvalidOrderID, ""),
+		},
+		{
+			name:    "Заказа нет в очереди ожидания",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				m.MockOfferRepository.EXPECT().
+					CancelPendingByOrderID(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return(false, nil)
+				m.MockPendingRepository.EXPECT().
+					Delete(gomock.Any(), "order-2026-001").
+					Return(delivery.ErrPendingAssignmentNotFound)
+			},
+			errorAssertion: errorAssertion(delivery.ErrPendingAssignmentNotFound, ""),
+		},
+		{
+			name:    "Отмена открытого предложения освобождает курьера",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+				m.MockOfferRepository.EXPECT().
+					CancelPendingByOrderID(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return(true, nil)
+				m.MockPendingRepository.EXPECT().
+					Delete(gomock.Any(), "order-2026-001").
+					Return(nil)
+				m.MockAvailabilityNotifier.EXPECT().Notify()
 			},
-			errorAssertion: errorAssertion(nil, "cleanup: database deadlock"),
+			errorAssertion: require.NoError,
 		},
 	}
 
@@ -757,15 +1458,1092 @@ func TestDeliveryService_CleanupExpiredDeliveries_FullCoverage(t *testing.T) {
 
 			service := delivery.New(
 				m.MockRepository,
+				m.MockPendingRepository,
 				m.MockCourierService,
 				m.MockDeliveryTimeFactory,
 				m.MockTxManager,
+				m.MockAvailabilityNotifier,
+				m.MockZoneResolver,
+				delivery.ZonePolicy{},
+				m.MockOfferRepository,
+				delivery.OfferPolicy{},
+				delivery.BatchPolicy{},
+				delivery.DispatchPolicy{},
+				delivery.PriorityPolicy{},
+				m.MockScheduledRepository,
+				delivery.RatingPolicy{},
+				m.MockEarningsRecorder,
+				m.MockCashRecorder,
+				delivery.CashPolicy{},
 			)
 
-			ctx := context.Background()
-			_, err := service.CleanupExpiredDeliveries(ctx)
+			err := service.CancelPendingAssignment(context.Background(), tt.orderID)
 
 			tt.errorAssertion(t, err, tt.name)
 		})
 	}
 }
+
+func TestDeliveryService_GetDelivery(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	existingDelivery := &entities.Delivery{
+		ID:           1,
+		CourierID:    1,
+		OrderID:      "order-2026-001",
+		RestaurantID: "restaurant-7",
+		Address:      &entities.Address{Street: "Тверская", House: "1"},
+		AssignedAt:   fixedTime,
+		Deadline:     fixedTime.Add(30 * time.Minute),
+	}
+
+	tests := []struct {
+		name           string
+		orderID        string
+		mockSetup      func(m *mock)
+		expectedResult *entities.Delivery
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:    "Успешное получение доставки по ID заказа",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetByOrderID(gomock.Any(), "order-2026-001").
+					Return(existingDelivery, nil)
+			},
+			expectedResult: existingDelivery,
+			errorAssertion: require.NoError,
+		},
+		{
+			name:           "Отклонение получения с пустым ID заказа",
+			orderID:        "",
+			errorAssertion: errorAssertion(delivery.ErrInvalidOrderID, ""),
+		},
+		{
+			name:    "Доставка не найдена",
+			orderID: "order-2026-404",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetByOrderID(gomock.Any(), "order-2026-404").
+					Return(nil, delivery.ErrDeliveryNotFound)
+			},
+			errorAssertion: errorAssertion(delivery.ErrDeliveryNotFound, "get delivery"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := delivery.New(
+				m.MockRepository,
+				m.MockPendingRepository,
+				m.MockCourierService,
+				m.MockDeliveryTimeFactory,
+				m.MockTxManager,
+				m.MockAvailabilityNotifier,
+				m.MockZoneResolver,
+				delivery.ZonePolicy{},
+				m.MockOfferRepository,
+				delivery.OfferPolicy{},
+				delivery.BatchPolicy{},
+				delivery.DispatchPolicy{},
+				delivery.PriorityPolicy{},
+				m.MockScheduledRepository,
+				delivery.RatingPolicy{},
+				m.MockEarningsRecorder,
+				m.MockCashRecorder,
+				delivery.CashPolicy{},
+			)
+
+			result, err := service.GetDelivery(context.Background(), tt.orderID)
+
+			assert.Equal(t, tt.expectedResult, result)
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
+
+func TestDeliveryService_DeliveryReassign(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	route := &entities.Route{
+		Pickup:  entities.Location{Latitude: 55.7558, Longitude: 37.6173},
+		Dropoff: entities.Location{Latitude: 55.7602, Longitude: 37.6186},
+	}
+
+	currentDelivery := &entities.Delivery{
+		ID:         1,
+		CourierID:  1,
+		OrderID:    "order-2026-001",
+		AssignedAt: fixedTime,
+		Deadline:   fixedTime.Add(30 * time.Minute),
+		Route:      route,
+	}
+
+	nextCourier := &entities.Courier{
+		ID:            2,
+		Name:          "Kurt Russell",
+		Phone:         "+79161234568",
+		Status:        entities.CourierAvailable,
+		TransportType: entities.Scooter,
+		Version:       5,
+	}
+
+	busyCourier := &entities.Courier{
+		ID:            3,
+		Name:          "Lee Van Cleef",
+		Phone:         "+79161234569",
+		Status:        entities.CourierBusy,
+		TransportType: entities.Car,
+	}
+
+	deactivatedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	deactivatedCourier := &entities.Courier{
+		ID:            4,
+		Name:          "Gian Maria Volonte",
+		Phone:         "+79161234570",
+		Status:        entities.CourierAvailable,
+		TransportType: entities.Car,
+		DeactivatedAt: &deactivatedAt,
+	}
+
+	// текущий курьер не подбирается повторно
+	reassignFilter := entities.CourierSearchFilter{ExcludeCourierIDs: []int64{1}}
+
+	requirementsDelivery := *currentDelivery
+	requirementsDelivery.Priority = entities.PriorityHigh
+	requirementsDelivery.Requirements = entities.OrderRequirements{
+		Skills:         []entities.CourierSkill{entities.SkillThermalBag},
+		TransportTypes: []entities.CourierTransportType{entities.Scooter, entities.Car},
+	}
+
+	cashDelivery := *currentDelivery
+	cashDelivery.CashAmount = 150000
+	cashBalanceLimit := int64(500000)
+
+	targetCourierID := int64(2)
+	busyCourierID := int64(3)
+	deactivatedCourierID := int64(4)
+	sameCourierID := int64(1)
+	invalidCourierID := int64(0)
+	availableStatus := entities.CourierAvailable
+
+	expectTx := func(m *mock) {
+		m.MockTxManager.EXPECT().
+			Do(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+
+	expectReassign := func(m *mock) {
+		m.MockDeliveryTimeFactory.EXPECT().
+			CalculateDeadline(gomock.Any(), nextCourier.TransportType, route, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+				return baseTime.Add(20 * time.Minute), nil
+			})
+		m.MockRepository.EXPECT().
+			Reassign(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, modify entities.DeliveryModify) (*entities.Delivery, error) {
+				return &entities.Delivery{
+					ID:         1,
+					CourierID:  *modify.CourierID,
+					OrderID:    *modify.OrderID,
+					AssignedAt: *modify.AssignedAt,
+					Deadline:   *modify.Deadline,
+				}, nil
+			})
+		m.MockRepository.EXPECT().
+			CreateReassignment(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, modify entities.DeliveryReassignmentModify) (*entities.DeliveryReassignment, error) {
+				return &entities.DeliveryReassignment{
+					ID:                1,
+					OrderID:           *modify.OrderID,
+					PreviousCourierID: *modify.PreviousCourierID,
+					CourierID:         *modify.CourierID,
+					Reason:            *modify.Reason,
+					Deadline:          *modify.Deadline,
+					ReassignedAt:      *modify.ReassignedAt,
+				}, nil
+			})
+		m.MockCourierService.EXPECT().
+			UpdateCourier(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, modify entities.CourierModify) (*entities.Courier, error) {
+				assert.Equal(t, nextCourier.ID, *modify.ID)
+				assert.Equal(t, entities.CourierBusy, *modify.Status)
+				assert.Equal(t, &nextCourier.Version, modify.ExpectedVersion)
+				return nextCourier, nil
+			})
+	}
+
+	tests := []struct {
+		name           string
+		params         entities.DeliveryReassignParams
+		zonePolicy     delivery.ZonePolicy
+		cashPolicy     delivery.CashPolicy
+		mockSetup      func(m *mock)
+		resultChecker  func(t *testing.T, result *entities.DeliveryReassignment)
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:   "Успешная передача заказа следующему подходящему курьеру с освобождением прежнего",
+			params: entities.DeliveryReassignParams{OrderID: "order-2026-001", Reason: "курьер попал в ДТП"},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(currentDelivery, nil)
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(nil, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), reassignFilter).
+					Return(nextCourier, nil)
+				expectReassign(m)
+				m.MockRepository.EXPECT().
+					CountActiveDeliveriesByCourierID(gomock.Any(), currentDelivery.CourierID).
+					Return(int64(0), nil)
+				m.MockCourierService.EXPECT().
+					UpdateCourier(gomock.Any(), entities.CourierModify{
+						ID:     &currentDelivery.CourierID,
+						Status: &availableStatus,
+					}).
+					Return(&entities.Courier{ID: 1}, nil)
+				m.MockAvailabilityNotifier.EXPECT().Notify()
+			},
+			resultChecker: func(t *testing.T, result *entities.DeliveryReassignment) {
+				require.NotNil(t, result)
+				assert.Equal(t, "order-2026-001", result.OrderID)
+				assert.Equal(t, currentDelivery.CourierID, result.PreviousCourierID)
+				assert.Equal(t, nextCourier.ID, result.CourierID)
+				assert.Equal(t, nextCourier.TransportType, result.TransportType)
+				assert.Equal(t, "курьер попал в ДТП", result.Reason)
+				assert.WithinDuration(t, result.ReassignedAt.Add(20*time.Minute), result.Deadline, time.Second)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:   "Следующий курьер подбирается по требованиям и приоритету доставки",
+			params: entities.DeliveryReassignParams{OrderID: "order-2026-001", Reason: "курьер попал в ДТП"},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(&requirementsDelivery, nil)
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(nil, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{
+						Skills:              []entities.CourierSkill{entities.SkillThermalBag},
+						TransportTypes:      []entities.CourierTransportType{entities.Scooter, entities.Car},
+						ExcludeCourierIDs:   []int64{1},
+						PreferFastTransport: true,
+					}).
+					Return(nextCourier, nil)
+				expectReassign(m)
+				m.MockRepository.EXPECT().
+					CountActiveDeliveriesByCourierID(gomock.Any(), currentDelivery.CourierID).
+					Return(int64(1), nil)
+			},
+			resultChecker: func(t *testing.T, result *entities.DeliveryReassignment) {
+				require.NotNil(t, result)
+				assert.Equal(t, nextCourier.ID, result.CourierID)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:       "Следующий курьер подбирается вне зоны точки забора, если в зоне свободных нет",
+			params:     entities.DeliveryReassignParams{OrderID: "order-2026-001", Reason: "курьер попал в ДТП"},
+			zonePolicy: delivery.ZonePolicy{CrossZoneFallback: true},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(currentDelivery, nil)
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return([]int64{7}, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{
+						ExcludeCourierIDs: []int64{1},
+						ZoneIDs:           []int64{7},
+					}).
+					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), reassignFilter).
+					Return(nextCourier, nil)
+				expectReassign(m)
+				m.MockRepository.EXPECT().
+					CountActiveDeliveriesByCourierID(gomock.Any(), currentDelivery.CourierID).
+					Return(int64(1), nil)
+			},
+			resultChecker: func(t *testing.T, result *entities.DeliveryReassignment) {
+				require.NotNil(t, result)
+				assert.Equal(t, nextCourier.ID, result.CourierID)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:   "Без резерва из других зон заказ не передается курьеру вне зоны точки забора",
+			params: entities.DeliveryReassignParams{OrderID: "order-2026-001", Reason: "курьер попал в ДТП"},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(currentDelivery, nil)
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return([]int64{7}, nil)
+				zoneFilter := entities.CourierSearchFilter{
+					ExcludeCourierIDs: []int64{1},
+					ZoneIDs:           []int64{7},
+				}
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), zoneFilter).
+					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					ExplainCourierMismatch(gomock.Any(), zoneFilter).
+					Return(&entities.CourierMismatch{AvailableCouriers: 1}, nil)
+			},
+			resultChecker:  func(t *testing.T, result *entities.DeliveryReassignment) { assert.Nil(t, result) },
+			errorAssertion: errorAssertion(delivery.ErrNoAvailableCouriers, "find courier for reassignment"),
+		},
+		{
+			name:       "Заказ с оплатой наличными передается курьеру в пределах лимита наличных",
+			params:     entities.DeliveryReassignParams{OrderID: "order-2026-001", Reason: "курьер попал в ДТП"},
+			cashPolicy: delivery.CashPolicy{BalanceLimit: cashBalanceLimit},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(&cashDelivery, nil)
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(nil, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{
+						ExcludeCourierIDs: []int64{1},
+						MaxCashBalance:    &cashBalanceLimit,
+					}).
+					Return(nextCourier, nil)
+				expectReassign(m)
+				m.MockRepository.EXPECT().
+					CountActiveDeliveriesByCourierID(gomock.Any(), currentDelivery.CourierID).
+					Return(int64(1), nil)
+			},
+			resultChecker: func(t *testing.T, result *entities.DeliveryReassignment) {
+				require.NotNil(t, result)
+				assert.Equal(t, nextCourier.ID, result.CourierID)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Передача заказа выбранному курьеру без освобождения прежнего, у которого есть другие доставки",
+			params: entities.DeliveryReassignParams{
+				OrderID:   "order-2026-001",
+				CourierID: &targetCourierID,
+				Reason:    "перераспределение нагрузки",
+			},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(currentDelivery, nil)
+				m.MockRepository.EXPECT().
+					GetCourierByIDForUpdate(gomock.Any(), targetCourierID).
+					Return(nextCourier, nil)
+				expectReassign(m)
+				m.MockRepository.EXPECT().
+					CountActiveDeliveriesByCourierID(gomock.Any(), currentDelivery.CourierID).
+					Return(int64(1), nil)
+			},
+			resultChecker: func(t *testing.T, result *entities.DeliveryReassignment) {
+				require.NotNil(t, result)
+				assert.Equal(t, nextCourier.ID, result.CourierID)
+				assert.Equal(t, currentDelivery.CourierID, result.PreviousCourierID)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:           "Отклонение передачи с пустым ID заказа",
+			params:         entities.DeliveryReassignParams{OrderID: "", Reason: "причина"},
+			resultChecker:  func(t *testing.T, result *entities.DeliveryReassignment) { assert.Nil(t, result) },
+			errorAssertion: errorAssertion(delivery.ErrInvalidOrderID, ""),
+		},
+		{
+			name:           "Отклонение передачи с некорректным ID курьера",
+			params:         entities.DeliveryReassignParams{OrderID: "order-2026-001", CourierID: &invalidCourierID, Reason: "причина"},
+			resultChecker:  func(t *testing.T, result *entities.DeliveryReassignment) { assert.Nil(t, result) },
+			errorAssertion: errorAssertion(delivery.ErrInvalidCourierID, ""),
+		},
+		{
+			name:           "Отклонение передачи без причины",
+			params:         entities.DeliveryReassignParams{OrderID: "order-2026-001", Reason: "   "},
+			resultChecker:  func(t *testing.T, result *entities.DeliveryReassignment) { assert.Nil(t, result) },
+			errorAssertion: errorAssertion(delivery.ErrInvalidReassignReason, ""),
+		},
+		{
+			name:   "Доставка не найдена",
+			params: entities.DeliveryReassignParams{OrderID: "order-2026-404", Reason: "причина"},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-404").
+					Return(nil, delivery.ErrDeliveryNotFound)
+			},
+			resultChecker:  func(t *testing.T, result *entities.DeliveryReassignment) { assert.Nil(t, result) },
+			errorAssertion: errorAssertion(delivery.ErrDeliveryNotFound, "get delivery"),
+		},
+		{
+			name:   "Отклонение передачи тому же курьеру",
+			params: entities.DeliveryReassignParams{OrderID: "order-2026-001", CourierID: &sameCourierID, Reason: "причина"},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(currentDelivery, nil)
+			},
+			resultChecker:  func(t *testing.T, result *entities.DeliveryReassignment) { assert.Nil(t, result) },
+			errorAssertion: errorAssertion(delivery.ErrSameCourier, ""),
+		},
+		{
+			name:   "Отклонение передачи занятому курьеру",
+			params: entities.DeliveryReassignParams{OrderID: "order-2026-001", CourierID: &busyCourierID, Reason: "причина"},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(currentDelivery, nil)
+				m.MockRepository.EXPECT().
+					GetCourierByIDForUpdate(gomock.Any(), busyCourierID).
+					Return(busyCourier, nil)
+			},
+			resultChecker:  func(t *testing.T, result *entities.DeliveryReassignment) { assert.Nil(t, result) },
+			errorAssertion: errorAssertion(delivery.ErrCourierNotAvailable, ""),
+		},
+		{
+			name:   "Отклонение передачи отключенному курьеру",
+			params: entities.DeliveryReassignParams{OrderID: "order-2026-001", CourierID: &deactivatedCourierID, Reason: "причина"},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(currentDelivery, nil)
+				m.MockRepository.EXPECT().
+					GetCourierByIDForUpdate(gomock.Any(), deactivatedCourierID).
+					Return(deactivatedCourier, nil)
+			},
+			resultChecker:  func(t *testing.T, result *entities.DeliveryReassignment) { assert.Nil(t, result) },
+			errorAssertion: errorAssertion(delivery.ErrCourierNotAvailable, ""),
+		},
+		{
+			name:   "Выбранный курьер не найден",
+			params: entities.DeliveryReassignParams{OrderID: "order-2026-001", CourierID: &targetCourierID, Reason: "причина"},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(currentDelivery, nil)
+				m.MockRepository.EXPECT().
+					GetCourierByIDForUpdate(gomock.Any(), targetCourierID).
+					Return(nil, delivery.ErrCourierNotFound)
+			},
+			resultChecker:  func(t *testing.T, result *entities.DeliveryReassignment) { assert.Nil(t, result) },
+			errorAssertion: errorAssertion(delivery.ErrCourierNotFound, "get target courier"),
+		},
+		{
+			name:   "Выполненную доставку передать нельзя",
+			params: entities.DeliveryReassignParams{OrderID: "order-2026-001", Reason: "причина"},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				completedAt := fixedTime.Add(20 * time.Minute)
+				completedDelivery := *currentDelivery
+				completedDelivery.CompletedAt = &completedAt
+				m.MockRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(&completedDelivery, nil)
+			},
+			resultChecker:  func(t *testing.T, result *entities.DeliveryReassignment) { assert.Nil(t, result) },
+			errorAssertion: errorAssertion(delivery.ErrDeliveryCompleted, ""),
+		},
+		{
+			name:   "Нет свободных курьеров для передачи",
+			params: entities.DeliveryReassignParams{OrderID: "order-2026-001", Reason: "причина"},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(currentDelivery, nil)
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(nil, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), reassignFilter).
+					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					ExplainCourierMismatch(gomock.Any(), reassignFilter).
+					Return(&entities.CourierMismatch{AvailableCouriers: 1}, nil)
+			},
+			resultChecker:  func(t *testing.T, result *entities.DeliveryReassignment) { assert.Nil(t, result) },
+			errorAssertion: errorAssertion(delivery.ErrNoAvailableCouriers, "find courier for reassignment"),
+		},
+		{
+			name:   "Ошибка сохранения записи о передаче",
+			params: entities.DeliveryReassignParams{OrderID: "order-2026-001", Reason: "причина"},
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(currentDelivery, nil)
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(nil, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), reassignFilter).
+					Return(nextCourier, nil)
+				m.MockDeliveryTimeFactory.EXPECT().
+					CalculateDeadline(gomock.Any(), nextCourier.TransportType, route, gomock.Any()).
+					Return(fixedTime.Add(20*time.Minute), nil)
+				m.MockRepository.EXPECT().
+					Reassign(gomock.Any(), gomock.Any()).
+					Return(currentDelivery, nil)
+				m.MockRepository.EXPECT().
+					CreateReassignment(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			resultChecker:  func(t *testing.T, result *entities.DeliveryReassignment) { assert.Nil(t, result) },
+			errorAssertion: errorAssertion(nil, "create reassignment: database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := delivery.New(
+				m.MockRepository,
+				m.MockPendingRepository,
+				m.MockCourierService,
+				m.MockDeliveryTimeFactory,
+				m.MockTxManager,
+				m.MockAvailabilityNotifier,
+				m.MockZoneResolver,
+				tt.zonePolicy,
+				m.MockOfferRepository,
+				delivery.OfferPolicy{},
+				delivery.BatchPolicy{},
+				delivery.DispatchPolicy{},
+				delivery.PriorityPolicy{},
+				m.MockScheduledRepository,
+				delivery.RatingPolicy{},
+				m.MockEarningsRecorder,
+				m.MockCashRecorder,
+				tt.cashPolicy,
+			)
+
+			result, err := service.DeliveryReassign(context.Background(), tt.params)
+
+			tt.resultChecker(t, result)
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
+
+func TestDeliveryService_RefreshPoolMetrics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		metricsChecker func(t *testing.T)
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Gauge курьеров и активных доставок обновляются из БД",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					CountCouriersByStatusAndTransportType(gomock.Any()).
+					Return([]entities.CourierPoolCount{
+						{Status: entities.CourierAvailable, TransportType: entities.Car, Count: 3},
+						{Status: entities.CourierBusy, TransportType: entities.OnFoot, Count: 2},
+					}, nil)
+				m.MockRepository.EXPECT().
+					CountActiveDeliveries(gomock.Any()).
+					Return(int64(2), nil)
+			},
+			metricsChecker: func(t *testing.T) {
+				assert.InDelta(t, 3, testutil.ToFloat64(delivery.CouriersCount.WithLabelValues("available", "car")), 0)
+				assert.InDelta(t, 2, testutil.ToFloat64(delivery.CouriersCount.WithLabelValues("busy", "on_foot")), 0)
+				assert.InDelta(t, 0, testutil.ToFloat64(delivery.CouriersCount.WithLabelValues("paused", "scooter")), 0)
+				assert.InDelta(t, 2, testutil.ToFloat64(delivery.DeliveriesActive), 0)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Ошибка подсчета курьеров",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					CountCouriersByStatusAndTransportType(gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			errorAssertion: errorAssertion(nil, "count couriers: database error"),
+		},
+		{
+			name: "Ошибка подсчета активных доставок",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					CountCouriersByStatusAndTransportType(gomock.Any()).
+					Return([]entities.CourierPoolCount{}, nil)
+				m.MockRepository.EXPECT().
+					CountActiveDeliveries(gomock.Any()).
+					Return(int64(0), errors.New("database error"))
+			},
+			errorAssertion: errorAssertion(nil, "count active deliveries: database error"),
+		},
+	}
+
+	// без t.Parallel в подтестах: gauge глобальные
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := delivery.New(
+				m.MockRepository,
+				m.MockPendingRepository,
+				m.MockCourierService,
+				m.MockDeliveryTimeFactory,
+				m.MockTxManager,
+				m.MockAvailabilityNotifier,
+				m.MockZoneResolver,
+				delivery.ZonePolicy{},
+				m.MockOfferRepository,
+				delivery.OfferPolicy{},
+				delivery.BatchPolicy{},
+				delivery.DispatchPolicy{},
+				delivery.PriorityPolicy{},
+				m.MockScheduledRepository,
+				delivery.RatingPolicy{},
+				m.MockEarningsRecorder,
+				m.MockCashRecorder,
+				delivery.CashPolicy{},
+			)
+
+			err := service.RefreshPoolMetrics(context.Background())
+
+			tt.errorAssertion(t, err, tt.name)
+			if tt.metricsChecker != nil {
+				tt.metricsChecker(t)
+			}
+		})
+	}
+}
+
+func TestDeliveryService_DeliveryAssign_Zones(t *testing.T) {
+	t.Parallel()
+
+	zoneCourier := &entities.Courier{ID: 1, Status: entities.CourierAvailable, TransportType: entities.Car, Version: 1}
+	otherCourier := &entities.Courier{ID: 2, Status: entities.CourierAvailable, TransportType: entities.OnFoot, Version: 1}
+
+	route := &entities.Route{
+		Pickup:  entities.Location{Latitude: 55.7558, Longitude: 37.6173},
+		Dropoff: entities.Location{Latitude: 55.7602, Longitude: 37.6186},
+	}
+	pickupZones := entities.CourierSearchFilter{ZoneIDs: []int64{3, 7}}
+
+	expectAssign := func(m *mock, courier *entities.Courier) {
+		m.MockDeliveryTimeFactory.EXPECT().
+			CalculateDeadline(gomock.Any(), courier.TransportType, route, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+				return baseTime.Add(30 * time.Minute), nil
+			})
+		m.MockRepository.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, modify entities.DeliveryModify) (*entities.Delivery, error) {
+				return &entities.Delivery{
+					ID:         1,
+					CourierID:  *modify.CourierID,
+					OrderID:    *modify.OrderID,
+					AssignedAt: *modify.AssignedAt,
+					Deadline:   *modify.Deadline,
+				}, nil
+			})
+		m.MockCourierService.EXPECT().
+			UpdateCourier(gomock.Any(), gomock.Any()).
+			Return(courier, nil)
+	}
+
+	tests := []struct {
+		name              string
+		policy            delivery.ZonePolicy
+		mockSetup         func(m *mock)
+		expectedCourierID int64
+		errorAssertion    require.ErrorAssertionFunc
+	}{
+		{
+			name: "Курьер подбирается среди курьеров зон точки забора",
+			mockSetup: func(m *mock) {
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(pickupZones.ZoneIDs, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), pickupZones).
+					Return(zoneCourier, nil)
+				expectAssign(m, zoneCourier)
+			},
+			expectedCourierID: zoneCourier.ID,
+			errorAssertion:    require.NoError,
+		},
+		{
+			name: "Без политики фолбэка заказ уходит в очередь, если в зоне нет свободных",
+			mockSetup: func(m *mock) {
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(pickupZones.ZoneIDs, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), pickupZones).
+					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					ExplainCourierMismatch(gomock.Any(), pickupZones).
+					Return(&entities.CourierMismatch{}, nil)
+				m.MockPendingRepository.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					Return(&entities.PendingAssignment{ID: 1, OrderID: "order-2026-001"}, nil)
+			},
+			errorAssertion: errorAssertion(delivery.ErrAssignmentPending, ""),
+		},
+		{
+			name:   "С политикой фолбэка берется курьер из другой зоны",
+			policy: delivery.ZonePolicy{CrossZoneFallback: true},
+			mockSetup: func(m *mock) {
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(pickupZones.ZoneIDs, nil)
+				gomock.InOrder(
+					m.MockRepository.EXPECT().
+						GetCourierForAssignment(gomock.Any(), pickupZones).
+						Return(nil, delivery.ErrNoAvailableCouriers),
+					m.MockRepository.EXPECT().
+						GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+						Return(otherCourier, nil),
+				)
+				expectAssign(m, otherCourier)
+			},
+			expectedCourierID: otherCourier.ID,
+			errorAssertion:    require.NoError,
+		},
+		{
+			name:   "Фолбэк не повторяет поиск, если точка вне всех зон",
+			policy: delivery.ZonePolicy{CrossZoneFallback: true},
+			mockSetup: func(m *mock) {
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(nil, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					ExplainCourierMismatch(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(&entities.CourierMismatch{}, nil)
+				m.MockPendingRepository.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					Return(&entities.PendingAssignment{ID: 1, OrderID: "order-2026-001"}, nil)
+			},
+			errorAssertion: errorAssertion(delivery.ErrAssignmentPending, ""),
+		},
+		{
+			name: "Ошибка определения зоны",
+			mockSetup: func(m *mock) {
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(nil, errors.New("db down"))
+			},
+			errorAssertion: errorAssertion(nil, "find pickup zones"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			m.MockTxManager.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+			tt.mockSetup(m)
+
+			service := delivery.New(
+				m.MockRepository,
+				m.MockPendingRepository,
+				m.MockCourierService,
+				m.MockDeliveryTimeFactory,
+				m.MockTxManager,
+				m.MockAvailabilityNotifier,
+				m.MockZoneResolver,
+				tt.policy,
+				m.MockOfferRepository,
+				delivery.OfferPolicy{},
+				delivery.BatchPolicy{},
+				delivery.DispatchPolicy{},
+				delivery.PriorityPolicy{},
+				m.MockScheduledRepository,
+				delivery.RatingPolicy{},
+				m.MockEarningsRecorder,
+				m.MockCashRecorder,
+				delivery.CashPolicy{},
+			)
+
+			result, err := service.DeliveryAssign(context.Background(), entities.DeliveryAssignParams{
+				OrderID: "order-2026-001",
+				Route:   route,
+			})
+
+			tt.errorAssertion(t, err)
+			if tt.expectedCourierID != 0 {
+				require.NotNil(t, result)
+				assert.Equal(t, tt.expectedCourierID, result.CourierID)
+			}
+		})
+	}
+}
+
+func TestDeliveryService_DeliveryAssign_Requirements(t *testing.T) {
+	t.Parallel()
+
+	courier := &entities.Courier{ID: 1, Status: entities.CourierAvailable, TransportType: entities.Scooter, Version: 1}
+
+	route := &entities.Route{
+		Pickup:  entities.Location{Latitude: 55.7558, Longitude: 37.6173},
+		Dropoff: entities.Location{Latitude: 55.7602, Longitude: 37.6186},
+	}
+	requirements := entities.OrderRequirements{
+		Skills:         []entities.CourierSkill{entities.SkillThermalBag},
+		TransportTypes: []entities.CourierTransportType{entities.Scooter, entities.Car},
+	}
+	requirementsFilter := entities.CourierSearchFilter{
+		Skills:         requirements.Skills,
+		TransportTypes: requirements.TransportTypes,
+	}
+	zoneFilter := entities.CourierSearchFilter{
+		ZoneIDs:        []int64{3},
+		Skills:         requirements.Skills,
+		TransportTypes: requirements.TransportTypes,
+	}
+	mismatch := &entities.CourierMismatch{
+		AvailableCouriers: 4,
+		Requirements: []entities.RequirementMatch{
+			{Requirement: entities.RequirementTransportType, Value: "scooter,car", MatchingCouriers: 2},
+			{Requirement: entities.RequirementSkill, Value: "thermal_bag", MatchingCouriers: 0},
+		},
+	}
+
+	txPassThrough := func(m *mock) {
+		m.MockTxManager.EXPECT().
+			Do(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+	expectAssign := func(m *mock) {
+		m.MockDeliveryTimeFactory.EXPECT().
+			CalculateDeadline(gomock.Any(), courier.TransportType, route, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+				return baseTime.Add(30 * time.Minute), nil
+			})
+		m.MockRepository.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, modify entities.DeliveryModify) (*entities.Delivery, error) {
+				return &entities.Delivery{
+					ID:         1,
+					CourierID:  *modify.CourierID,
+					OrderID:    *modify.OrderID,
+					AssignedAt: *modify.AssignedAt,
+					Deadline:   *modify.Deadline,
+				}, nil
+			})
+		m.MockCourierService.EXPECT().
+			UpdateCourier(gomock.Any(), gomock.Any()).
+			Return(courier, nil)
+	}
+
+	tests := []struct {
+		name              string
+		requirements      entities.OrderRequirements
+		policy            delivery.ZonePolicy
+		mockSetup         func(m *mock)
+		expectedCourierID int64
+		errorAssertion    require.ErrorAssertionFunc
+	}{
+		{
+			name:         "Курьер подбирается по навыкам и транспорту заказа",
+			requirements: requirements,
+			mockSetup: func(m *mock) {
+				txPassThrough(m)
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(nil, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), requirementsFilter).
+					Return(courier, nil)
+				expectAssign(m)
+			},
+			expectedCourierID: courier.ID,
+			errorAssertion:    require.NoError,
+		},
+		{
+			name:         "Заказ уходит в очередь с требованиями и объяснением, если никто не подходит",
+			requirements: requirements,
+			mockSetup: func(m *mock) {
+				txPassThrough(m)
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(nil, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), requirementsFilter).
+					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					ExplainCourierMismatch(gomock.Any(), requirementsFilter).
+					Return(mismatch, nil)
+				m.MockPendingRepository.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, modify entities.PendingAssignmentModify) (*entities.PendingAssignment, error) {
+						assert.Equal(t, requirements, modify.Requirements)
+						return &entities.PendingAssignment{ID: 1, OrderID: *modify.OrderID}, nil
+					})
+			},
+			errorAssertion: func(t require.TestingT, err error, msgAndArgs ...interface{}) {
+				errorAssertion(delivery.ErrAssignmentPending, "")(t, err, msgAndArgs...)
+				assert.ErrorIs(t, err, delivery.ErrNoAvailableCouriers, msgAndArgs...)
+
+				var noMatch *delivery.NoCourierMatchError
+				require.ErrorAs(t, err, &noMatch, msgAndArgs...)
+				assert.Equal(t, *mismatch, noMatch.Mismatch, msgAndArgs...)
+			},
+		},
+		{
+			name:         "Фолбэк за пределы зоны сохраняет требования заказа",
+			requirements: requirements,
+			policy:       delivery.ZonePolicy{CrossZoneFallback: true},
+			mockSetup: func(m *mock) {
+				txPassThrough(m)
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(zoneFilter.ZoneIDs, nil)
+				gomock.InOrder(
+					m.MockRepository.EXPECT().
+						GetCourierForAssignment(gomock.Any(), zoneFilter).
+						Return(nil, delivery.ErrNoAvailableCouriers),
+					m.MockRepository.EXPECT().
+						GetCourierForAssignment(gomock.Any(), requirementsFilter).
+						Return(courier, nil),
+				)
+				expectAssign(m)
+			},
+			expectedCourierID: courier.ID,
+			errorAssertion:    require.NoError,
+		},
+		{
+			name:         "Ошибка объяснения подбора",
+			requirements: requirements,
+			mockSetup: func(m *mock) {
+				txPassThrough(m)
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(nil, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), requirementsFilter).
+					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					ExplainCourierMismatch(gomock.Any(), requirementsFilter).
+					Return(nil, errors.New("db down"))
+			},
+			errorAssertion: errorAssertion(nil, "explain courier mismatch"),
+		},
+		{
+			name: "Отклонение неизвестного навыка",
+			requirements: entities.OrderRequirements{
+				Skills: []entities.CourierSkill{"jetpack"},
+			},
+			mockSetup:      func(m *mock) {},
+			errorAssertion: errorAssertion(delivery.ErrInvalidRequirements, ""),
+		},
+		{
+			name: "Отклонение неизвестного транспорта",
+			requirements: entities.OrderRequirements{
+				TransportTypes: []entities.CourierTransportType{"bicycle"},
+			},
+			mockSetup:      func(m *mock) {},
+			errorAssertion: errorAssertion(delivery.ErrInvalidRequirements, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			tt.mockSetup(m)
+
+			service := delivery.New(
+				m.MockRepository,
+				m.MockPendingRepository,
+				m.MockCourierService,
+				m.MockDeliveryTimeFactory,
+				m.MockTxManager,
+				m.MockAvailabilityNotifier,
+				m.MockZoneResolver,
+				tt.policy,
+				m.MockOfferRepository,
+				delivery.OfferPolicy{},
+				delivery.BatchPolicy{},
+				delivery.DispatchPolicy{},
+				delivery.PriorityPolicy{},
+				m.MockScheduledRepository,
+				delivery.RatingPolicy{},
+				m.MockEarningsRecorder,
+				m.MockCashRecorder,
+				delivery.CashPolicy{},
+			)
+
+			result, err := service.DeliveryAssign(context.Background(), entities.DeliveryAssignParams{
+				OrderID:      "order-2026-001",
+				Route:        route,
+				Requirements: tt.requirements,
+			})
+
+			tt.errorAssertion(t, err)
+			if tt.expectedCourierID != 0 {
+				require.NotNil(t, result)
+				assert.Equal(t, tt.expectedCourierID, result.CourierID)
+			}
+		})
+	}
+}
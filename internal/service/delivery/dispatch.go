@@ -0,0 +1,240 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"service/internal/entities"
+	"service/pkg/hungarian"
+)
+
+const (
+	// dispatchLoadPenalty за каждую активную доставку курьера: загруженный курьер доберется до заказа позже
+	dispatchLoadPenalty = 10 * time.Minute
+	// dispatchCrossZonePenalty курьер не состоит в зоне точки забора и, скорее всего, находится далеко от нее
+	dispatchCrossZonePenalty = 15 * time.Minute
+	// dispatchLatenessWeight во сколько раз опоздание к обещанному клиенту времени дороже времени в пути
+	dispatchLatenessWeight = 3
+	// dispatchPriorityWeight во сколько раз время доставки приоритетного заказа дороже обычного:
+	// приоритетному заказу достается более быстрый курьер
+	dispatchPriorityWeight = 3
+	// dispatchPriorityBonus вычитается из стоимости приоритетного заказа, чтобы при нехватке курьеров
+	// они доставались сначала приоритетным заказам
+	dispatchPriorityBonus = 24 * time.Hour
+)
+
+func (d *Delivery) enqueueForDispatch(ctx context.Context, params entities.DeliveryAssignParams) error {
+	err := d.enqueueUnassigned(ctx, newPendingModify(params, time.Now().UTC()))
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", ErrAssignmentPending, ErrQueuedForDispatch)
+}
+
+// DispatchPendingDeliveries назначает заказы из очереди ожидания свободным курьерам так, чтобы суммарная
+// стоимость назначений была минимальной. Все назначения фиксируются в одной транзакции, заказы,
+// которым курьер не достался, остаются в очереди до следующего запуска. Возвращает количество назначенных заказов
+func (d *Delivery) DispatchPendingDeliveries(ctx context.Context) (int64, error) {
+	if !d.dispatchPolicy.Enabled {
+		return 0, nil
+	}
+
+	var (
+		staleOrderID string
+		assigned     []assignedOrder
+		attempted    bool
+	)
+	start := time.Now()
+
+	err := d.txManager.Do(ctx, func(ctx context.Context) error {
+		assignTime := time.Now().UTC()
+
+		pendings, err := d.pendingRepository.GetReadyForDispatch(ctx, assignTime, d.dispatchPolicy.MaxOrders)
+		if err != nil {
+			return fmt.Errorf("get pending assignments for dispatch: %w", err)
+		}
+		if len(pendings) == 0 {
+			return nil
+		}
+
+		couriers, err := d.repository.GetCouriersForDispatch(ctx, d.dispatchPolicy.MaxCouriers)
+		if err != nil {
+			return fmt.Errorf("get couriers for dispatch: %w", err)
+		}
+		if len(couriers) == 0 {
+			return nil
+		}
+
+		attempted = true
+		cost, deadlines, err := d.dispatchCost(ctx, pendings, couriers, assignTime)
+		if err != nil {
+			return err
+		}
+
+		matching := d.solveDispatch(ctx, cost)
+		for i, j := range matching {
+			if j < 0 {
+				continue
+			}
+
+			pending := pendings[i]
+			courier := couriers[j].Courier
+			deadline := promisedDeadline(deadlines[i][courier.TransportType], pending.EstimatedDelivery, assignTime)
+
+			delivery, err := d.createDelivery(ctx, &courier, pendingToParams(&pending), pending.EnqueuedAt, assignTime, deadline)
+			if err != nil {
+				if errors.Is(err, ErrOrderAlreadyAssigned) {
+					staleOrderID = pending.OrderID
+				}
+				return err
+			}
+
+			err = d.pendingRepository.Delete(ctx, pending.OrderID)
+			if err != nil {
+				return fmt.Errorf("delete pending assignment: %w", err)
+			}
+
+			updatedCourier, err := d.occupyCourier(ctx, &courier)
+			if err != nil {
+				return fmt.Errorf("update courier status: %w", err)
+			}
+
+			assigned = append(assigned, assignedOrder{
+				assignment: &entities.DeliveryAssignment{
+					CourierID:     updatedCourier.ID,
+					OrderID:       delivery.OrderID,
+					AssignedAt:    delivery.AssignedAt,
+					Deadline:      delivery.Deadline,
+					TransportType: updatedCourier.TransportType,
+				},
+				orderCreatedAt: pending.OrderCreatedAt,
+				priority:       entities.OrderPriorityFromPending(pending.Priority),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		if attempted {
+			observeAssignment(assignSourceDispatch, priorityUnknown, nil, err, start)
+		}
+		// заказ уже назначили в обход очереди, из-за него откатились все назначения запуска:
+		// удаляем запись отдельно, остальные заказы назначит следующий запуск
+		if staleOrderID != "" {
+			err = d.pendingRepository.Delete(ctx, staleOrderID)
+			if err != nil && !errors.Is(err, ErrPendingAssignmentNotFound) {
+				return 0, fmt.Errorf("delete stale pending assignment: %w", err)
+			}
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	for _, order := range assigned {
+		observeAssignment(assignSourceDispatch, order.priority, order.assignment, nil, start)
+		observeTimeToAssign(assignSourceDispatch, order.priority, order.orderCreatedAt, order.assignment.AssignedAt)
+	}
+	return int64(len(assigned)), nil
+}
+
+// solveDispatch ищет оптимальное назначение, а если бюджет времени на решение исчерпан - жадное
+func (d *Delivery) solveDispatch(ctx context.Context, cost [][]float64) []int {
+	solveCtx, cancel := context.WithTimeout(ctx, d.dispatchPolicy.SolveBudget)
+	defer cancel()
+
+	start := time.Now()
+	matching, err := hungarian.Solve(solveCtx, cost)
+	if err == nil {
+		observeDispatchSolve(dispatchMethodOptimal, start)
+		return matching
+	}
+
+	start = time.Now()
+	matching = hungarian.Greedy(cost)
+	observeDispatchSolve(dispatchMethodGreedy, start)
+	return matching
+}
+
+// dispatchCost стоимость пары заказ-курьер - ожидаемое время в секундах, за которое курьер доставит заказ:
+// время в пути по маршруту на транспорте курьера и штрафы за загрузку курьера и за то, что курьер не из зоны
+// точки забора. Положение курьера неизвестно, и зона - лучшее доступное приближение расстояния до ресторана.
+// Опоздание к обещанному клиенту времени добавляется с весом dispatchLatenessWeight.
+// Стоимость приоритетного заказа умножается на dispatchPriorityWeight и уменьшается на dispatchPriorityBonus.
+// Пара, в которой курьер не выполняет требования заказа или превысил лимит наличных для заказа с оплатой наличными, запрещена.
+// Вместе со стоимостью возвращает расчетные дедлайны заказов по типу транспорта
+func (d *Delivery) dispatchCost(
+	ctx context.Context,
+	pendings []entities.PendingAssignment,
+	couriers []entities.DispatchCourier,
+	assignTime time.Time,
+) ([][]float64, []map[entities.CourierTransportType]time.Time, error) {
+	cost := make([][]float64, len(pendings))
+	deadlines := make([]map[entities.CourierTransportType]time.Time, len(pendings))
+
+	for i, pending := range pendings {
+		var pickupZoneIDs []int64
+		if pending.Route != nil {
+			zoneIDs, err := d.zones.FindZoneIDsByPoint(ctx, pending.Route.Pickup)
+			if err != nil {
+				return nil, nil, fmt.Errorf("find pickup zones: %w", err)
+			}
+			pickupZoneIDs = zoneIDs
+		}
+
+		cost[i] = make([]float64, len(couriers))
+		deadlines[i] = make(map[entities.CourierTransportType]time.Time)
+		for j, courier := range couriers {
+			if !meetsRequirements(courier, pending.Requirements) || d.cashPolicy.Refuses(courier.CashBalance, pending.CashAmount) {
+				cost[i][j] = hungarian.Forbidden
+				continue
+			}
+
+			inPickupZone := len(pickupZoneIDs) == 0 || slices.ContainsFunc(courier.ZoneIDs, func(zoneID int64) bool {
+				return slices.Contains(pickupZoneIDs, zoneID)
+			})
+			if !inPickupZone && !d.zonePolicy.CrossZoneFallback {
+				cost[i][j] = hungarian.Forbidden
+				continue
+			}
+
+			transportType := courier.Courier.TransportType
+			deadline, ok := deadlines[i][transportType]
+			if !ok {
+				var err error
+				deadline, err = d.timeFactory.CalculateDeadline(ctx, transportType, pending.Route, assignTime)
+				if err != nil {
+					return nil, nil, fmt.Errorf("calculate deadline: %w", err)
+				}
+				deadlines[i][transportType] = deadline
+			}
+
+			arrival := deadline.Add(time.Duration(courier.ActiveDeliveries) * dispatchLoadPenalty)
+			if !inPickupZone {
+				arrival = arrival.Add(dispatchCrossZonePenalty)
+			}
+
+			cost[i][j] = arrival.Sub(assignTime).Seconds()
+			if pending.EstimatedDelivery != nil && pending.EstimatedDelivery.After(assignTime) && arrival.After(*pending.EstimatedDelivery) {
+				cost[i][j] += dispatchLatenessWeight * arrival.Sub(*pending.EstimatedDelivery).Seconds()
+			}
+			if entities.OrderPriorityFromPending(pending.Priority).IsHigh() {
+				cost[i][j] = dispatchPriorityWeight*cost[i][j] - dispatchPriorityBonus.Seconds()
+			}
+		}
+	}
+
+	return cost, deadlines, nil
+}
+
+func meetsRequirements(courier entities.DispatchCourier, requirements entities.OrderRequirements) bool {
+	for _, skill := range requirements.Skills {
+		if !slices.Contains(courier.Skills, skill) {
+			return false
+		}
+	}
+
+	return len(requirements.TransportTypes) == 0 || slices.Contains(requirements.TransportTypes, courier.Courier.TransportType)
+}
@@ -0,0 +1,397 @@
+package delivery_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"service/internal/entities"
+	"service/internal/service/delivery"
+)
+
+var testDispatchPolicy = delivery.DispatchPolicy{
+	Enabled:     true,
+	MaxOrders:   10,
+	MaxCouriers: 10,
+	SolveBudget: time.Second,
+}
+
+func newDispatchService(m *mock, policy delivery.DispatchPolicy) *delivery.Delivery {
+	return delivery.New(
+		m.MockRepository,
+		m.MockPendingRepository,
+		m.MockCourierService,
+		m.MockDeliveryTimeFactory,
+		m.MockTxManager,
+		m.MockAvailabilityNotifier,
+		m.MockZoneResolver,
+		delivery.ZonePolicy{},
+		m.MockOfferRepository,
+		delivery.OfferPolicy{},
+		delivery.BatchPolicy{},
+		policy,
+		delivery.PriorityPolicy{},
+		m.MockScheduledRepository,
+		delivery.RatingPolicy{},
+		m.MockEarningsRecorder,
+		m.MockCashRecorder,
+		delivery.CashPolicy{},
+	)
+}
+
+func TestDeliveryService_DeliveryAssign_Dispatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Заказ ставится в очередь пакетного распределения",
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderID(gomock.Any(), "order-2026-001").
+					Return(nil, delivery.ErrDeliveryNotFound)
+				m.MockPendingRepository.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, modify entities.PendingAssignmentModify) (*entities.PendingAssignment, error) {
+						assert.Equal(t, "order-2026-001", *modify.OrderID)
+						assert.Nil(t, modify.BatchUntil)
+						return &entities.PendingAssignment{ID: 1, OrderID: "order-2026-001"}, nil
+					})
+			},
+			errorAssertion: errorAssertion(delivery.ErrQueuedForDispatch, "assignment pending: order queued for dispatch"),
+		},
+		{
+			name: "Уже назначенный заказ не ставится в очередь",
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderID(gomock.Any(), "order-2026-001").
+					Return(&entities.Delivery{ID: 1, OrderID: "order-2026-001"}, nil)
+			},
+			errorAssertion: errorAssertion(delivery.ErrOrderAlreadyAssigned, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			tt.mockSetup(m)
+
+			result, err := newDispatchService(m, testDispatchPolicy).DeliveryAssign(context.Background(), entities.DeliveryAssignParams{
+				OrderID: "order-2026-001",
+			})
+
+			assert.Nil(t, result)
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
+
+func TestDeliveryService_AssignPendingDeliveries_Dispatch(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	m := newMock(ctrl)
+
+	// очередь разбирает пакетное распределение, поэтому к репозиториям обращений нет
+	count, err := newDispatchService(m, testDispatchPolicy).AssignPendingDeliveries(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestDeliveryService_DispatchPendingDeliveries(t *testing.T) {
+	t.Parallel()
+
+	enqueuedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	longRoute := &entities.Route{
+		Pickup:  entities.Location{Latitude: 55.75, Longitude: 37.61},
+		Dropoff: entities.Location{Latitude: 55.85, Longitude: 37.71},
+	}
+
+	carCourier := entities.DispatchCourier{
+		Courier: entities.Courier{ID: 1, Status: entities.CourierAvailable, TransportType: entities.Car},
+	}
+	footCourier := entities.DispatchCourier{
+		Courier: entities.Courier{ID: 2, Status: entities.CourierAvailable, TransportType: entities.OnFoot},
+		Skills:  []entities.CourierSkill{entities.SkillThermalBag},
+	}
+	couriers := []entities.DispatchCourier{carCourier, footCourier}
+
+	// заказ с длинным маршрутом пешком везти намного дольше, чем на машине
+	farOrder := entities.PendingAssignment{ID: 1, OrderID: "order-far", Route: longRoute, EnqueuedAt: enqueuedAt}
+	nearOrder := entities.PendingAssignment{ID: 2, OrderID: "order-near", EnqueuedAt: enqueuedAt}
+
+	travelTimes := map[bool]map[entities.CourierTransportType]time.Duration{
+		true:  {entities.Car: 10 * time.Minute, entities.OnFoot: 60 * time.Minute},
+		false: {entities.Car: 5 * time.Minute, entities.OnFoot: 15 * time.Minute},
+	}
+
+	expectDeadlines := func(m *mock) {
+		m.MockDeliveryTimeFactory.EXPECT().
+			CalculateDeadline(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+				return baseTime.Add(travelTimes[route != nil][transportType]), nil
+			}).
+			AnyTimes()
+		m.MockZoneResolver.EXPECT().
+			FindZoneIDsByPoint(gomock.Any(), longRoute.Pickup).
+			Return(nil, nil).
+			AnyTimes()
+	}
+
+	// expectAssignments проверяет, какому курьеру достался каждый заказ и с каким дедлайном
+	expectAssignments := func(t *testing.T, m *mock, expected map[string]entities.DispatchCourier) {
+		m.MockRepository.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, modify entities.DeliveryModify) (*entities.Delivery, error) {
+				courier, ok := expected[*modify.OrderID]
+				require.True(t, ok, "unexpected order %s", *modify.OrderID)
+				assert.Equal(t, courier.Courier.ID, *modify.CourierID)
+				travelTime := travelTimes[*modify.OrderID == farOrder.OrderID][courier.Courier.TransportType]
+				assert.Equal(t, modify.AssignedAt.Add(travelTime), *modify.Deadline)
+				assert.Equal(t, enqueuedAt, *modify.CreatedAt)
+				return &entities.Delivery{
+					ID:         1,
+					CourierID:  *modify.CourierID,
+					OrderID:    *modify.OrderID,
+					AssignedAt: *modify.AssignedAt,
+					Deadline:   *modify.Deadline,
+				}, nil
+			}).
+			Times(len(expected))
+		for orderID := range expected {
+			m.MockPendingRepository.EXPECT().
+				Delete(gomock.Any(), orderID).
+				Return(nil)
+		}
+		m.MockCourierService.EXPECT().
+			UpdateCourier(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, modify entities.CourierModify) (*entities.Courier, error) {
+				assert.Equal(t, entities.CourierBusy, *modify.Status)
+				for _, courier := range couriers {
+					if courier.Courier.ID == *modify.ID {
+						return &courier.Courier, nil
+					}
+				}
+				return nil, delivery.ErrCourierNotAvailable
+			}).
+			Times(len(expected))
+	}
+
+	tests := []struct {
+		name           string
+		policy         delivery.DispatchPolicy
+		mockSetup      func(t *testing.T, m *mock)
+		expectedCount  int64
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:           "Пакетное распределение выключено",
+			policy:         delivery.DispatchPolicy{},
+			mockSetup:      func(t *testing.T, m *mock) {},
+			expectedCount:  0,
+			errorAssertion: require.NoError,
+		},
+		{
+			name:   "Пустая очередь ожидания",
+			policy: testDispatchPolicy,
+			mockSetup: func(t *testing.T, m *mock) {
+				expectTx(m)
+				m.MockPendingRepository.EXPECT().
+					GetReadyForDispatch(gomock.Any(), gomock.Any(), testDispatchPolicy.MaxOrders).
+					Return([]entities.PendingAssignment{}, nil)
+			},
+			expectedCount:  0,
+			errorAssertion: require.NoError,
+		},
+		{
+			name:   "Нет свободных курьеров",
+			policy: testDispatchPolicy,
+			mockSetup: func(t *testing.T, m *mock) {
+				expectTx(m)
+				m.MockPendingRepository.EXPECT().
+					GetReadyForDispatch(gomock.Any(), gomock.Any(), testDispatchPolicy.MaxOrders).
+					Return([]entities.PendingAssignment{farOrder}, nil)
+				m.MockRepository.EXPECT().
+					GetCouriersForDispatch(gomock.Any(), testDispatchPolicy.MaxCouriers).
+					Return([]entities.DispatchCourier{}, nil)
+			},
+			expectedCount:  0,
+			errorAssertion: require.NoError,
+		},
+		{
+			name:   "Оптимальное назначение вместо самой дешевой пары",
+			policy: testDispatchPolicy,
+			mockSetup: func(t *testing.T, m *mock) {
+				expectTx(m)
+				m.MockPendingRepository.EXPECT().
+					GetReadyForDispatch(gomock.Any(), gomock.Any(), testDispatchPolicy.MaxOrders).
+					Return([]entities.PendingAssignment{farOrder, nearOrder}, nil)
+				m.MockRepository.EXPECT().
+					GetCouriersForDispatch(gomock.Any(), testDispatchPolicy.MaxCouriers).
+					Return(couriers, nil)
+				expectDeadlines(m)
+				expectAssignments(t, m, map[string]entities.DispatchCourier{
+					farOrder.OrderID:  carCourier,
+					nearOrder.OrderID: footCourier,
+				})
+			},
+			expectedCount:  2,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Жадное назначение, если бюджет времени на решение исчерпан",
+			policy: delivery.DispatchPolicy{
+				Enabled:     true,
+				MaxOrders:   testDispatchPolicy.MaxOrders,
+				MaxCouriers: testDispatchPolicy.MaxCouriers,
+				SolveBudget: -time.Second,
+			},
+			mockSetup: func(t *testing.T, m *mock) {
+				expectTx(m)
+				m.MockPendingRepository.EXPECT().
+					GetReadyForDispatch(gomock.Any(), gomock.Any(), testDispatchPolicy.MaxOrders).
+					Return([]entities.PendingAssignment{farOrder, nearOrder}, nil)
+				m.MockRepository.EXPECT().
+					GetCouriersForDispatch(gomock.Any(), testDispatchPolicy.MaxCouriers).
+					Return(couriers, nil)
+				expectDeadlines(m)
+				expectAssignments(t, m, map[string]entities.DispatchCourier{
+					farOrder.OrderID:  footCourier,
+					nearOrder.OrderID: carCourier,
+				})
+			},
+			expectedCount:  2,
+			errorAssertion: require.NoError,
+		},
+		{
+			name:   "Приоритетный заказ получает курьера первым, когда курьеров не хватает",
+			policy: testDispatchPolicy,
+			mockSetup: func(t *testing.T, m *mock) {
+				urgentOrder := farOrder
+				urgentOrder.Priority = entities.HighPendingPriority
+
+				expectTx(m)
+				m.MockPendingRepository.EXPECT().
+					GetReadyForDispatch(gomock.Any(), gomock.Any(), testDispatchPolicy.MaxOrders).
+					Return([]entities.PendingAssignment{nearOrder, urgentOrder}, nil)
+				m.MockRepository.EXPECT().
+					GetCouriersForDispatch(gomock.Any(), testDispatchPolicy.MaxCouriers).
+					Return([]entities.DispatchCourier{carCourier}, nil)
+				expectDeadlines(m)
+				expectAssignments(t, m, map[string]entities.DispatchCourier{
+					farOrder.OrderID: carCourier,
+				})
+			},
+			expectedCount:  1,
+			errorAssertion: require.NoError,
+		},
+		{
+			name:   "Заказ достается курьеру, который выполняет его требования",
+			policy: testDispatchPolicy,
+			mockSetup: func(t *testing.T, m *mock) {
+				thermalOrder := nearOrder
+				thermalOrder.Requirements = entities.OrderRequirements{
+					Skills: []entities.CourierSkill{entities.SkillThermalBag},
+				}
+
+				expectTx(m)
+				m.MockPendingRepository.EXPECT().
+					GetReadyForDispatch(gomock.Any(), gomock.Any(), testDispatchPolicy.MaxOrders).
+					Return([]entities.PendingAssignment{thermalOrder}, nil)
+				m.MockRepository.EXPECT().
+					GetCouriersForDispatch(gomock.Any(), testDispatchPolicy.MaxCouriers).
+					Return(couriers, nil)
+				expectDeadlines(m)
+				expectAssignments(t, m, map[string]entities.DispatchCourier{
+					nearOrder.OrderID: footCourier,
+				})
+			},
+			expectedCount:  1,
+			errorAssertion: require.NoError,
+		},
+		{
+			name:   "Заказ без подходящего курьера остается в очереди",
+			policy: testDispatchPolicy,
+			mockSetup: func(t *testing.T, m *mock) {
+				scooterOrder := nearOrder
+				scooterOrder.Requirements = entities.OrderRequirements{
+					TransportTypes: []entities.CourierTransportType{entities.Scooter},
+				}
+
+				expectTx(m)
+				m.MockPendingRepository.EXPECT().
+					GetReadyForDispatch(gomock.Any(), gomock.Any(), testDispatchPolicy.MaxOrders).
+					Return([]entities.PendingAssignment{scooterOrder}, nil)
+				m.MockRepository.EXPECT().
+					GetCouriersForDispatch(gomock.Any(), testDispatchPolicy.MaxCouriers).
+					Return(couriers, nil)
+			},
+			expectedCount:  0,
+			errorAssertion: require.NoError,
+		},
+		{
+			name:   "Уже назначенный в обход очереди заказ удаляется из очереди",
+			policy: testDispatchPolicy,
+			mockSetup: func(t *testing.T, m *mock) {
+				expectTx(m)
+				m.MockPendingRepository.EXPECT().
+					GetReadyForDispatch(gomock.Any(), gomock.Any(), testDispatchPolicy.MaxOrders).
+					Return([]entities.PendingAssignment{nearOrder}, nil)
+				m.MockRepository.EXPECT().
+					GetCouriersForDispatch(gomock.Any(), testDispatchPolicy.MaxCouriers).
+					Return(couriers, nil)
+				expectDeadlines(m)
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, delivery.ErrOrderAlreadyAssigned)
+				m.MockPendingRepository.EXPECT().
+					Delete(gomock.Any(), nearOrder.OrderID).
+					Return(nil)
+			},
+			expectedCount:  0,
+			errorAssertion: require.NoError,
+		},
+		{
+			name:   "Ошибка получения курьеров",
+			policy: testDispatchPolicy,
+			mockSetup: func(t *testing.T, m *mock) {
+				expectTx(m)
+				m.MockPendingRepository.EXPECT().
+					GetReadyForDispatch(gomock.Any(), gomock.Any(), testDispatchPolicy.MaxOrders).
+					Return([]entities.PendingAssignment{nearOrder}, nil)
+				m.MockRepository.EXPECT().
+					GetCouriersForDispatch(gomock.Any(), testDispatchPolicy.MaxCouriers).
+					Return(nil, assert.AnError)
+			},
+			expectedCount:  0,
+			errorAssertion: errorAssertion(assert.AnError, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			tt.mockSetup(t, m)
+
+			count, err := newDispatchService(m, tt.policy).DispatchPendingDeliveries(context.Background())
+
+			tt.errorAssertion(t, err, tt.name)
+			assert.Equal(t, tt.expectedCount, count)
+		})
+	}
+}
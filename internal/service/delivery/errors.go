@@ -1,14 +1,71 @@
 package delivery
 
-import "errors"
+import (
+	"errors"
+
+	"service/internal/entities"
+)
 
 var (
 	ErrMissingRequiredFields = errors.New("missing required fields")
 	ErrInvalidOrderID        = errors.New("invalid order id")
 	ErrInvalidCourierID      = errors.New("invalid courier id")
+	ErrInvalidRoute          = errors.New("invalid route coordinates")
+	ErrInvalidReassignReason = errors.New("invalid reassign reason")
+	ErrInvalidRequirements   = errors.New("invalid order requirements")
+	ErrInvalidOfferID        = errors.New("invalid offer id")
+	ErrInvalidPriority       = errors.New("invalid order priority")
+	ErrInvalidDeliverAt      = errors.New("delivery time must be in the future")
+
+	ErrNoAvailableCouriers   = errors.New("no available couriers")
+	ErrDeliveryNotFound      = errors.New("delivery not found")
+	ErrOrderAlreadyAssigned  = errors.New("order already assigned")
+	ErrCourierNotFound       = errors.New("courier not found")
+	ErrCourierNotAvailable   = errors.New("courier not available")
+	ErrSameCourier           = errors.New("order is already assigned to this courier")
+	ErrDeliveryCompleted     = errors.New("delivery is already completed")
+	ErrNoPreemptionCandidate = errors.New("no delivery to preempt")
 
-	ErrNoAvailableCouriers        = errors.New("no available couriers")
-	ErrDeliveryNotFound           = errors.New("delivery not found")
-	ErrOrderAlreadyAssigned       = errors.New("order already assigned")
-	ErrCourierHasActiveDeliveries = errors.New("courier has active deliveries")
+	ErrAssignmentPending         = errors.New("assignment pending")
+	ErrPendingQueueEmpty         = errors.New("pending assignment queue is empty")
+	ErrPendingAssignmentNotFound = errors.New("pending assignment not found")
+	ErrHeldForBatch              = errors.New("order held for batching")
+	ErrQueuedForDispatch         = errors.New("order queued for dispatch")
+
+	ErrOfferPending        = errors.New("order offered to courier")
+	ErrOfferNotFound       = errors.New("offer not found")
+	ErrOfferNotPending     = errors.New("offer is already answered")
+	ErrOfferExpired        = errors.New("offer expired")
+	ErrOrderAlreadyOffered = errors.New("order is already offered to another courier")
+	ErrNoExpiredOffers     = errors.New("no expired offers")
+
+	ErrOrderAlreadyScheduled     = errors.New("order is already scheduled")
+	ErrScheduledDeliveryNotFound = errors.New("scheduled delivery not found")
 )
+
+// NoCourierMatchError свободного курьера под требования заказа нет.
+// Mismatch объясняет, каким требованиям сколько курьеров удовлетворяет
+type NoCourierMatchError struct {
+	Mismatch entities.CourierMismatch
+}
+
+func (e *NoCourierMatchError) Error() string {
+	return ErrNoAvailableCouriers.Error()
+}
+
+func (e *NoCourierMatchError) Unwrap() error {
+	return ErrNoAvailableCouriers
+}
+
+// OfferPendingError заказ предложен курьеру и будет назначен, когда тот примет предложение
+type OfferPendingError struct {
+	Offer entities.DeliveryOffer
+}
+
+func (e *OfferPendingError) Error() string {
+	return ErrOfferPending.Error()
+}
+
+func (e *OfferPendingError) Unwrap() error {
+	return ErrOfferPending
+}
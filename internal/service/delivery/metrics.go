@@ -0,0 +1,246 @@
+package delivery
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"service/internal/entities"
+)
+
+const (
+	assignSourceRequest  = "request"
+	assignSourceQueue    = "queue"
+	assignSourceOffer    = "offer"
+	assignSourceDispatch = "dispatch"
+	assignSourceSchedule = "schedule"
+
+	offerOutcomeOffered  = "offered"
+	offerOutcomeAccepted = "accepted"
+	offerOutcomeDeclined = "declined"
+	offerOutcomeExpired  = "expired"
+
+	dispatchMethodOptimal = "optimal"
+	dispatchMethodGreedy  = "greedy"
+
+	batchOutcomeHeld                 = "held"
+	batchOutcomeBatched              = "batched"
+	batchOutcomeSingle               = "single"
+	batchOutcomeDeadlineExcluded     = "deadline_excluded"
+	batchOutcomeRequirementsExcluded = "requirements_excluded"
+
+	// transportUnknown курьер не был выбран, например при ошибке до подбора
+	transportUnknown = "unknown"
+)
+
+// priorityUnknown заказ не определен, например при ошибке пакетного распределения или пустой очереди
+const priorityUnknown entities.OrderPriority = "unknown"
+
+var (
+	DeliveryAssignmentsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "delivery_assignments_total",
+			Help: "Total number of courier assignment attempts by source, outcome, transport type and order priority",
+		},
+		[]string{"source", "outcome", "transport_type", "priority"},
+	)
+
+	DeliveryAssignmentDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "delivery_assignment_duration_seconds",
+			Help:    "Duration of courier assignment including the database transaction",
+			Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		},
+		[]string{"source", "outcome"},
+	)
+
+	// DeliveryCrossZoneAssignmentsTotal курьер взят вне зоны забора заказа по политике CrossZoneFallback,
+	// считается при подборе, даже если транзакция назначения потом откатится
+	DeliveryCrossZoneAssignmentsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "delivery_cross_zone_assignments_total",
+			Help: "Total number of couriers picked outside the pickup zone by the cross-zone fallback policy",
+		},
+	)
+
+	// DeliveryPreemptionsTotal курьер забран у обычного заказа для приоритетного, обычный заказ вернулся в очередь.
+	// Считается при подборе, даже если транзакция назначения потом откатится
+	DeliveryPreemptionsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "delivery_preemptions_total",
+			Help: "Total number of normal deliveries whose courier was taken by a high priority order",
+		},
+	)
+
+	// DeliveryOffersTotal предложения заказов курьерам и ответы на них. Предложение считается при создании,
+	// даже если транзакция потом откатится. Доля принятых по курьеру - GET /courier/{id}/offer-stats
+	DeliveryOffersTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "delivery_offers_total",
+			Help: "Total number of delivery offers to couriers by outcome",
+		},
+		[]string{"outcome"},
+	)
+
+	// DeliveryBatchOrdersTotal заказы, прошедшие через группировку: held - заказ ждет окно группировки,
+	// batched и single - назначен в группе или один. Исключенные из группы по дедлайну или требованиям
+	// считаются при подборе, даже если транзакция назначения потом откатится
+	DeliveryBatchOrdersTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "delivery_batch_orders_total",
+			Help: "Total number of orders passed through restaurant batching by outcome",
+		},
+		[]string{"outcome"},
+	)
+
+	DeliveryBatchSize = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "delivery_batch_size",
+			Help:    "Number of orders assigned to one courier by restaurant batching",
+			Buckets: prometheus.LinearBuckets(1, 1, 10),
+		},
+	)
+
+	// DeliveryDispatchSolveDuration время решения задачи о назначениях пакетным распределением:
+	// optimal - венгерский алгоритм уложился в бюджет, greedy - запасное жадное назначение
+	DeliveryDispatchSolveDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "delivery_dispatch_solve_duration_seconds",
+			Help:    "Duration of solving the batch dispatch assignment problem by method",
+			Buckets: []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		},
+		[]string{"method"},
+	)
+
+	DeliveryUnassignmentsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "delivery_unassignments_total",
+			Help: "Total number of courier unassignment attempts by outcome and transport type",
+		},
+		[]string{"outcome", "transport_type"},
+	)
+
+	DeliveryTimeToAssign = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "delivery_time_to_assign_seconds",
+			Help:    "Time from order creation in order-service to courier assignment by source and order priority",
+			Buckets: []float64{1, 5, 15, 30, 60, 120, 300, 600, 1200, 1800, 3600},
+		},
+		[]string{"source", "priority"},
+	)
+
+	CouriersCount = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "couriers_count",
+			Help: "Number of couriers by status and transport type, refreshed from the database",
+		},
+		[]string{"status", "transport_type"},
+	)
+
+	DeliveriesActive = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "deliveries_active",
+			Help: "Number of deliveries whose deadline has not passed yet, refreshed from the database",
+		},
+	)
+)
+
+func assignmentOutcome(err error) string {
+	switch {
+	case err == nil:
+		return "assigned"
+	case errors.Is(err, ErrOfferPending):
+		return "offered"
+	// проверяются до ErrAssignmentPending: заказ ждет в очереди группировки или пакетного распределения
+	case errors.Is(err, ErrHeldForBatch):
+		return "held_for_batch"
+	case errors.Is(err, ErrQueuedForDispatch):
+		return "queued_for_dispatch"
+	// проверяется до ErrNoAvailableCouriers: заказ в очереди оборачивает обе ошибки
+	case errors.Is(err, ErrAssignmentPending):
+		return "queued"
+	case errors.Is(err, ErrNoAvailableCouriers):
+		return "no_couriers"
+	case errors.Is(err, ErrOrderAlreadyAssigned):
+		return "already_assigned"
+	case errors.Is(err, ErrOfferNotPending),
+		errors.Is(err, ErrOfferExpired):
+		return "offer_closed"
+	case errors.Is(err, ErrInvalidOrderID),
+		errors.Is(err, ErrInvalidRoute),
+		errors.Is(err, ErrInvalidRequirements),
+		errors.Is(err, ErrInvalidPriority),
+		errors.Is(err, ErrInvalidOfferID):
+		return "invalid"
+	default:
+		return "error"
+	}
+}
+
+func unassignmentOutcome(err error) string {
+	switch {
+	case err == nil:
+		return "unassigned"
+	case errors.Is(err, ErrDeliveryNotFound):
+		return "not_found"
+	case errors.Is(err, ErrInvalidOrderID):
+		return "invalid"
+	default:
+		return "error"
+	}
+}
+
+func observeAssignment(
+	source string,
+	priority entities.OrderPriority,
+	assignment *entities.DeliveryAssignment,
+	err error,
+	start time.Time,
+) {
+	outcome := assignmentOutcome(err)
+	transportType := transportUnknown
+	if assignment != nil {
+		transportType = assignment.TransportType.String()
+	}
+
+	DeliveryAssignmentsTotal.WithLabelValues(source, outcome, transportType, priority.String()).Inc()
+	DeliveryAssignmentDuration.WithLabelValues(source, outcome).Observe(time.Since(start).Seconds())
+}
+
+func observeTimeToAssign(source string, priority entities.OrderPriority, orderCreatedAt *time.Time, assignedAt time.Time) {
+	if orderCreatedAt == nil || orderCreatedAt.IsZero() {
+		return
+	}
+	DeliveryTimeToAssign.WithLabelValues(source, priority.String()).Observe(assignedAt.Sub(*orderCreatedAt).Seconds())
+}
+
+func observeBatch(size int) {
+	DeliveryBatchSize.Observe(float64(size))
+	if size > 1 {
+		DeliveryBatchOrdersTotal.WithLabelValues(batchOutcomeBatched).Add(float64(size))
+		return
+	}
+	DeliveryBatchOrdersTotal.WithLabelValues(batchOutcomeSingle).Inc()
+}
+
+func observeDispatchSolve(method string, start time.Time) {
+	DeliveryDispatchSolveDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// setCouriersCount обнуляет сочетания, которых нет в выборке: иначе gauge
+// хранил бы последнее ненулевое значение для статуса, в котором курьеров не осталось
+func setCouriersCount(counts []entities.CourierPoolCount) {
+	statuses := []entities.CourierStatusType{entities.CourierAvailable, entities.CourierBusy, entities.CourierPaused}
+	transportTypes := []entities.CourierTransportType{entities.OnFoot, entities.Scooter, entities.Car}
+
+	for _, status := range statuses {
+		for _, transportType := range transportTypes {
+			CouriersCount.WithLabelValues(status.String(), transportType.String()).Set(0)
+		}
+	}
+
+	for _, count := range counts {
+		CouriersCount.WithLabelValues(count.Status.String(), count.TransportType.String()).Set(float64(count.Count))
+	}
+}
@@ -0,0 +1,287 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"service/internal/entities"
+)
+
+// offerDelivery ставит заказ в очередь ожидания и предлагает его свободному курьеру.
+// Заказ остается в очереди, пока курьер не примет предложение, чтобы при отказе
+// или истечении срока его можно было предложить следующему. Без свободных курьеров заказ ждет в очереди
+func (d *Delivery) offerDelivery(ctx context.Context, params entities.DeliveryAssignParams) error {
+	var (
+		offer    *entities.DeliveryOffer
+		offerErr error
+	)
+	err := d.txManager.Do(ctx, func(ctx context.Context) error {
+		_, err := d.repository.GetByOrderID(ctx, params.OrderID)
+		if err == nil {
+			return ErrOrderAlreadyAssigned
+		}
+		if !errors.Is(err, ErrDeliveryNotFound) {
+			return fmt.Errorf("get delivery: %w", err)
+		}
+
+		// повторный запрос по заказу, который ждет ответа курьера, возвращает то же предложение
+		offer, err = d.offerRepository.GetPendingByOrderID(ctx, params.OrderID)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, ErrOfferNotFound) {
+			return fmt.Errorf("get pending offer: %w", err)
+		}
+
+		_, err = d.pendingRepository.Enqueue(ctx, newPendingModify(params, time.Now().UTC()))
+		if err != nil {
+			return fmt.Errorf("enqueue pending assignment: %w", err)
+		}
+
+		offer, offerErr = d.offerNext(ctx, params)
+		// заказ остается в очереди, поэтому транзакцию фиксируем
+		if errors.Is(offerErr, ErrNoAvailableCouriers) {
+			return nil
+		}
+		return offerErr
+	})
+	if err != nil {
+		return err
+	}
+
+	if offer == nil {
+		return fmt.Errorf("%w: %w", ErrAssignmentPending, offerErr)
+	}
+	return &OfferPendingError{Offer: *offer}
+}
+
+// offerNext предлагает заказ свободному курьеру, который не отказывался от него в течение DeclineCooldown.
+// Когда срок отказа истекает, курьеру можно предложить заказ снова: иначе заказ, от которого отказались
+// все свободные курьеры, навсегда остался бы в очереди. Вызывается в транзакции
+func (d *Delivery) offerNext(ctx context.Context, params entities.DeliveryAssignParams) (*entities.DeliveryOffer, error) {
+	offeredAt := time.Now().UTC()
+	offeredCourierIDs, err := d.offerRepository.GetOfferedCourierIDs(ctx, params.OrderID, offeredAt.Add(-d.offerPolicy.DeclineCooldown))
+	if err != nil {
+		return nil, fmt.Errorf("get offered couriers: %w", err)
+	}
+
+	courier, err := d.findCourierForAssignment(ctx, params, offeredCourierIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := offeredAt.Add(d.offerPolicy.Timeout)
+	offer, err := d.offerRepository.Create(ctx, entities.DeliveryOfferModify{
+		OrderID:   &params.OrderID,
+		CourierID: &courier.ID,
+		OfferedAt: &offeredAt,
+		ExpiresAt: &expiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create offer: %w", err)
+	}
+
+	DeliveryOffersTotal.WithLabelValues(offerOutcomeOffered).Inc()
+	return offer, nil
+}
+
+// offerNextPending предлагает курьеру заказ из головы очереди. Заказ остается в очереди до принятия предложения.
+// Вторым значением возвращает заказ из головы очереди
+func (d *Delivery) offerNextPending(ctx context.Context, skipOrderIDs []string) (int64, string, error) {
+	var orderID string
+	err := d.txManager.Do(ctx, func(ctx context.Context) error {
+		pending, err := d.pendingRepository.GetNextForUpdate(ctx, time.Now().UTC(), skipOrderIDs)
+		if err != nil {
+			return fmt.Errorf("get next pending assignment: %w", err)
+		}
+
+		orderID = pending.OrderID
+		_, err = d.offerNext(ctx, pendingToParams(pending))
+		return err
+	})
+	if err != nil {
+		return 0, orderID, err
+	}
+
+	return 1, orderID, nil
+}
+
+// AcceptOffer курьер принимает предложение: заказ назначается ему так же, как при немедленном назначении
+func (d *Delivery) AcceptOffer(ctx context.Context, offerID int64) (*entities.DeliveryAssignment, error) {
+	if offerID <= 0 {
+		return nil, ErrInvalidOfferID
+	}
+
+	var (
+		deliveryAssignment *entities.DeliveryAssignment
+		orderCreatedAt     *time.Time
+		priority           = priorityUnknown
+	)
+	start := time.Now()
+
+	err := d.txManager.Do(ctx, func(ctx context.Context) error {
+		offer, err := d.offerRepository.GetByIDForUpdate(ctx, offerID)
+		if err != nil {
+			return fmt.Errorf("get offer: %w", err)
+		}
+		if offer.Status != entities.OfferPending {
+			return ErrOfferNotPending
+		}
+		acceptedAt := time.Now().UTC()
+		if !acceptedAt.Before(offer.ExpiresAt) {
+			return ErrOfferExpired
+		}
+
+		pending, err := d.pendingRepository.GetByOrderIDForUpdate(ctx, offer.OrderID)
+		if err != nil {
+			return fmt.Errorf("get pending assignment: %w", err)
+		}
+
+		courier, err := d.repository.GetCourierByIDForUpdate(ctx, offer.CourierID)
+		if err != nil {
+			return fmt.Errorf("get courier: %w", err)
+		}
+		if courier.Status != entities.CourierAvailable || courier.IsDeactivated() {
+			return ErrCourierNotAvailable
+		}
+
+		params := pendingToParams(pending)
+		orderCreatedAt = params.OrderCreatedAt
+		priority = params.Priority
+		deliveryAssignment, err = d.bindCourier(ctx, courier, params, pending.EnqueuedAt)
+		if err != nil {
+			return err
+		}
+
+		err = d.pendingRepository.Delete(ctx, offer.OrderID)
+		if err != nil {
+			return fmt.Errorf("delete pending assignment: %w", err)
+		}
+
+		_, err = d.offerRepository.Respond(ctx, offer.ID, entities.OfferAccepted, acceptedAt)
+		if err != nil {
+			return fmt.Errorf("accept offer: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		// транзакция откатилась, назначение не состоялось
+		observeAssignment(assignSourceOffer, priority, nil, err, start)
+		return nil, err
+	}
+
+	observeAssignment(assignSourceOffer, priority, deliveryAssignment, nil, start)
+	observeTimeToAssign(assignSourceOffer, priority, orderCreatedAt, deliveryAssignment.AssignedAt)
+	DeliveryOffersTotal.WithLabelValues(offerOutcomeAccepted).Inc()
+	return deliveryAssignment, nil
+}
+
+// DeclineOffer курьер отказывается от заказа, заказ сразу предлагается следующему курьеру
+func (d *Delivery) DeclineOffer(ctx context.Context, offerID int64) error {
+	if offerID <= 0 {
+		return ErrInvalidOfferID
+	}
+
+	err := d.txManager.Do(ctx, func(ctx context.Context) error {
+		offer, err := d.offerRepository.GetByIDForUpdate(ctx, offerID)
+		if err != nil {
+			return fmt.Errorf("get offer: %w", err)
+		}
+		if offer.Status != entities.OfferPending {
+			return ErrOfferNotPending
+		}
+
+		_, err = d.offerRepository.Respond(ctx, offer.ID, entities.OfferDeclined, time.Now().UTC())
+		if err != nil {
+			return fmt.Errorf("decline offer: %w", err)
+		}
+
+		return d.reoffer(ctx, offer.OrderID)
+	})
+	if err != nil {
+		return err
+	}
+
+	DeliveryOffersTotal.WithLabelValues(offerOutcomeDeclined).Inc()
+	// отказавшийся курьер снова свободен и может взять другой заказ из очереди
+	d.notifier.Notify()
+	return nil
+}
+
+// ExpireOffers закрывает предложения, на которые курьеры не ответили вовремя,
+// и предлагает заказы следующим курьерам. Возвращает количество просроченных предложений
+func (d *Delivery) ExpireOffers(ctx context.Context) (int64, error) {
+	var expiredCount int64
+	for {
+		err := d.expireNextOffer(ctx)
+		if err != nil {
+			if errors.Is(err, ErrNoExpiredOffers) {
+				break
+			}
+			return expiredCount, err
+		}
+		expiredCount++
+	}
+
+	if expiredCount > 0 {
+		d.notifier.Notify()
+	}
+	return expiredCount, nil
+}
+
+func (d *Delivery) expireNextOffer(ctx context.Context) error {
+	err := d.txManager.Do(ctx, func(ctx context.Context) error {
+		now := time.Now().UTC()
+		offer, err := d.offerRepository.GetNextExpiredForUpdate(ctx, now)
+		if err != nil {
+			return fmt.Errorf("get next expired offer: %w", err)
+		}
+
+		_, err = d.offerRepository.Respond(ctx, offer.ID, entities.OfferExpired, now)
+		if err != nil {
+			return fmt.Errorf("expire offer: %w", err)
+		}
+
+		return d.reoffer(ctx, offer.OrderID)
+	})
+	if err != nil {
+		return err
+	}
+
+	DeliveryOffersTotal.WithLabelValues(offerOutcomeExpired).Inc()
+	return nil
+}
+
+// reoffer предлагает заказ следующему курьеру после отказа или истечения срока.
+// Если подходящих курьеров нет, заказ ждет в очереди ожидания. Вызывается в транзакции
+func (d *Delivery) reoffer(ctx context.Context, orderID string) error {
+	pending, err := d.pendingRepository.GetByOrderIDForUpdate(ctx, orderID)
+	if err != nil {
+		// заказ успели отменить
+		if errors.Is(err, ErrPendingAssignmentNotFound) {
+			return nil
+		}
+		return fmt.Errorf("get pending assignment: %w", err)
+	}
+
+	_, err = d.offerNext(ctx, pendingToParams(pending))
+	if err != nil && !errors.Is(err, ErrNoAvailableCouriers) {
+		return fmt.Errorf("offer to next courier: %w", err)
+	}
+	return nil
+}
+
+func (d *Delivery) GetCourierOfferStats(ctx context.Context, courierID int64) (*entities.CourierOfferStats, error) {
+	if courierID <= 0 {
+		return nil, ErrInvalidCourierID
+	}
+
+	stats, err := d.offerRepository.GetCourierStats(ctx, courierID)
+	if err != nil {
+		return nil, fmt.Errorf("get courier offer stats: %w", err)
+	}
+
+	return stats, nil
+}
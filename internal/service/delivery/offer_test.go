@@ -0,0 +1,736 @@
+package delivery_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"service/internal/entities"
+	"service/internal/service/delivery"
+)
+
+const testDeclineCooldown = 5 * time.Minute
+
+func newOfferService(m *mock) *delivery.Delivery {
+	return delivery.New(
+		m.MockRepository,
+		m.MockPendingRepository,
+		m.MockCourierService,
+		m.MockDeliveryTimeFactory,
+		m.MockTxManager,
+		m.MockAvailabilityNotifier,
+		m.MockZoneResolver,
+		delivery.ZonePolicy{},
+		m.MockOfferRepository,
+		delivery.OfferPolicy{Enabled: true, Timeout: time.Minute, DeclineCooldown: testDeclineCooldown},
+		delivery.BatchPolicy{},
+		delivery.DispatchPolicy{},
+		delivery.PriorityPolicy{},
+		m.MockScheduledRepository,
+		delivery.RatingPolicy{},
+		m.MockEarningsRecorder,
+		m.MockCashRecorder,
+		delivery.CashPolicy{},
+	)
+}
+
+func expectTx(m *mock) {
+	m.MockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+func TestDeliveryService_DeliveryAssign_Offer(t *testing.T) {
+	t.Parallel()
+
+	availableCourier := &entities.Courier{
+		ID:            1,
+		Status:        entities.CourierAvailable,
+		TransportType: entities.Car,
+	}
+	existingOffer := &entities.DeliveryOffer{
+		ID:        7,
+		OrderID:   "order-2026-001",
+		CourierID: 1,
+		Status:    entities.OfferPending,
+	}
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		offerChecker   func(t *testing.T, err error)
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Заказ предлагается свободному курьеру вместо немедленного назначения",
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderID(gomock.Any(), "order-2026-001").
+					Return(nil, delivery.ErrDeliveryNotFound)
+				m.MockOfferRepository.EXPECT().
+					GetPendingByOrderID(gomock.Any(), "order-2026-001").
+					Return(nil, delivery.ErrOfferNotFound)
+				m.MockPendingRepository.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					Return(&entities.PendingAssignment{ID: 1, OrderID: "order-2026-001"}, nil)
+				m.MockOfferRepository.EXPECT().
+					GetOfferedCourierIDs(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return(nil, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(availableCourier, nil)
+				m.MockOfferRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, modify entities.DeliveryOfferModify) (*entities.DeliveryOffer, error) {
+						assert.Equal(t, availableCourier.ID, *modify.CourierID)
+						assert.Equal(t, time.Minute, modify.ExpiresAt.Sub(*modify.OfferedAt))
+						return existingOffer, nil
+					})
+			},
+			offerChecker: func(t *testing.T, err error) {
+				var pendingErr *delivery.OfferPendingError
+				require.True(t, errors.As(err, &pendingErr))
+				assert.Equal(t, *existingOffer, pendingErr.Offer)
+			},
+			errorAssertion: errorAssertion(delivery.ErrOfferPending, ""),
+		},
+		{
+			name: "Повторный запрос возвращает открытое предложение",
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderID(gomock.Any(), "order-2026-001").
+					Return(nil, delivery.ErrDeliveryNotFound)
+				m.MockOfferRepository.EXPECT().
+					GetPendingByOrderID(gomock.Any(), "order-2026-001").
+					Return(existingOffer, nil)
+			},
+			offerChecker: func(t *testing.T, err error) {
+				var pendingErr *delivery.OfferPendingError
+				require.True(t, errors.As(err, &pendingErr))
+				assert.Equal(t, existingOffer.ID, pendingErr.Offer.ID)
+			},
+			errorAssertion: errorAssertion(delivery.ErrOfferPending, ""),
+		},
+		{
+			name: "Без свободных курьеров заказ остается в очереди ожидания",
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderID(gomock.Any(), "order-2026-001").
+					Return(nil, delivery.ErrDeliveryNotFound)
+				m.MockOfferRepository.EXPECT().
+					GetPendingByOrderID(gomock.Any(), "order-2026-001").
+					Return(nil, delivery.ErrOfferNotFound)
+				m.MockPendingRepository.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					Return(&entities.PendingAssignment{ID: 1, OrderID: "order-2026-001"}, nil)
+				m.MockOfferRepository.EXPECT().
+					GetOfferedCourierIDs(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return(nil, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					ExplainCourierMismatch(gomock.Any(), entities.CourierSearchFilter{}).
+					Return(&entities.CourierMismatch{}, nil)
+			},
+			errorAssertion: errorAssertion(delivery.ErrAssignmentPending, ""),
+		},
+		{
+			name: "Отклонение предложения уже назначенного заказа",
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderID(gomock.Any(), "order-2026-001").
+					Return(&entities.Delivery{ID: 1, OrderID: "order-2026-001"}, nil)
+			},
+			errorAssertion: errorAssertion(delivery.ErrOrderAlreadyAssigned, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			tt.mockSetup(m)
+
+			result, err := newOfferService(m).DeliveryAssign(context.Background(), entities.DeliveryAssignParams{
+				OrderID: "order-2026-001",
+			})
+
+			assert.Nil(t, result)
+			tt.errorAssertion(t, err, tt.name)
+			if tt.offerChecker != nil {
+				tt.offerChecker(t, err)
+			}
+		})
+	}
+}
+
+func TestDeliveryService_AcceptOffer(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	pendingOffer := &entities.DeliveryOffer{
+		ID:        7,
+		OrderID:   "order-2026-001",
+		CourierID: 1,
+		Status:    entities.OfferPending,
+		OfferedAt: now,
+		ExpiresAt: now.Add(time.Minute),
+	}
+	expiredOffer := &entities.DeliveryOffer{
+		ID:        7,
+		OrderID:   "order-2026-001",
+		CourierID: 1,
+		Status:    entities.OfferPending,
+		OfferedAt: now.Add(-2 * time.Minute),
+		ExpiresAt: now.Add(-time.Minute),
+	}
+	declinedOffer := &entities.DeliveryOffer{
+		ID:        7,
+		OrderID:   "order-2026-001",
+		CourierID: 1,
+		Status:    entities.OfferDeclined,
+	}
+	availableCourier := &entities.Courier{
+		ID:            1,
+		Status:        entities.CourierAvailable,
+		TransportType: entities.Car,
+		Version:       2,
+	}
+	busyCourier := &entities.Courier{
+		ID:            1,
+		Status:        entities.CourierBusy,
+		TransportType: entities.Car,
+	}
+	pending := &entities.PendingAssignment{
+		ID:         3,
+		OrderID:    "order-2026-001",
+		EnqueuedAt: now.Add(-time.Minute),
+	}
+	busyStatus := entities.CourierBusy
+
+	tests := []struct {
+		name           string
+		offerID        int64
+		mockSetup      func(m *mock)
+		expectedResult *entities.DeliveryAssignment
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:    "Курьер принимает предложение и получает заказ",
+			offerID: 7,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockOfferRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(7)).
+					Return(pendingOffer, nil)
+				m.MockPendingRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(pending, nil)
+				m.MockRepository.EXPECT().
+					GetCourierByIDForUpdate(gomock.Any(), int64(1)).
+					Return(availableCourier, nil)
+				m.MockDeliveryTimeFactory.EXPECT().
+					CalculateDeadline(gomock.Any(), entities.Car, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+						return baseTime.Add(30 * time.Minute), nil
+					})
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, modify entities.DeliveryModify) (*entities.Delivery, error) {
+						assert.Equal(t, pending.EnqueuedAt, *modify.CreatedAt)
+						return &entities.Delivery{ID: 1, CourierID: 1, OrderID: "order-2026-001"}, nil
+					})
+				m.MockCourierService.EXPECT().
+					UpdateCourier(gomock.Any(), entities.CourierModify{
+						ID:              &availableCourier.ID,
+						Status:          &busyStatus,
+						ExpectedVersion: &availableCourier.Version,
+					}).
+					Return(availableCourier, nil)
+				m.MockPendingRepository.EXPECT().
+					Delete(gomock.Any(), "order-2026-001").
+					Return(nil)
+				m.MockOfferRepository.EXPECT().
+					Respond(gomock.Any(), int64(7), entities.OfferAccepted, gomock.Any()).
+					Return(pendingOffer, nil)
+			},
+			expectedResult: &entities.DeliveryAssignment{
+				CourierID:     1,
+				OrderID:       "order-2026-001",
+				TransportType: entities.Car,
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:           "Отклонение принятия с некорректным ID предложения",
+			offerID:        0,
+			errorAssertion: errorAssertion(delivery.ErrInvalidOfferID, ""),
+		},
+		{
+			name:    "Предложение не найдено",
+			offerID: 7,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockOfferRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(7)).
+					Return(nil, delivery.ErrOfferNotFound)
+			},
+			errorAssertion: errorAssertion(delivery.ErrOfferNotFound, ""),
+		},
+		{
+			name:    "Нельзя принять уже отклоненное предложение",
+			offerID: 7,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockOfferRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(7)).
+					Return(declinedOffer, nil)
+			},
+			errorAssertion: errorAssertion(delivery.ErrOfferNotPending, ""),
+		},
+		{
+			name:    "Нельзя принять просроченное предложение",
+			offerID: 7,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockOfferRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(7)).
+					Return(expiredOffer, nil)
+			},
+			errorAssertion: errorAssertion(delivery.ErrOfferExpired, ""),
+		},
+		{
+			name:    "Курьер стал недоступен после предложения",
+			offerID: 7,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockOfferRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(7)).
+					Return(pendingOffer, nil)
+				m.MockPendingRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(pending, nil)
+				m.MockRepository.EXPECT().
+					GetCourierByIDForUpdate(gomock.Any(), int64(1)).
+					Return(busyCourier, nil)
+			},
+			errorAssertion: errorAssertion(delivery.ErrCourierNotAvailable, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			result, err := newOfferService(m).AcceptOffer(context.Background(), tt.offerID)
+
+			tt.errorAssertion(t, err, tt.name)
+			if tt.expectedResult == nil {
+				assert.Nil(t, result)
+				return
+			}
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expectedResult.CourierID, result.CourierID)
+			assert.Equal(t, tt.expectedResult.OrderID, result.OrderID)
+			assert.Equal(t, tt.expectedResult.TransportType, result.TransportType)
+		})
+	}
+}
+
+func TestDeliveryService_DeclineOffer(t *testing.T) {
+	t.Parallel()
+
+	pendingOffer := &entities.DeliveryOffer{
+		ID:        7,
+		OrderID:   "order-2026-001",
+		CourierID: 1,
+		Status:    entities.OfferPending,
+	}
+	expiredOffer := &entities.DeliveryOffer{
+		ID:        7,
+		OrderID:   "order-2026-001",
+		CourierID: 1,
+		Status:    entities.OfferExpired,
+	}
+	pending := &entities.PendingAssignment{ID: 3, OrderID: "order-2026-001"}
+	nextCourier := &entities.Courier{ID: 2, Status: entities.CourierAvailable}
+
+	tests := []struct {
+		name           string
+		offerID        int64
+		mockSetup      func(m *mock)
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:    "После отказа заказ предлагается следующему курьеру",
+			offerID: 7,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockOfferRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(7)).
+					Return(pendingOffer, nil)
+				m.MockOfferRepository.EXPECT().
+					Respond(gomock.Any(), int64(7), entities.OfferDeclined, gomock.Any()).
+					Return(pendingOffer, nil)
+				m.MockPendingRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(pending, nil)
+				m.MockOfferRepository.EXPECT().
+					GetOfferedCourierIDs(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return([]int64{1}, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{ExcludeCourierIDs: []int64{1}}).
+					Return(nextCourier, nil)
+				m.MockOfferRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, modify entities.DeliveryOfferModify) (*entities.DeliveryOffer, error) {
+						assert.Equal(t, nextCourier.ID, *modify.CourierID)
+						return &entities.DeliveryOffer{ID: 8, OrderID: "order-2026-001", CourierID: 2}, nil
+					})
+				m.MockAvailabilityNotifier.EXPECT().Notify()
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:    "Без других курьеров заказ остается в очереди ожидания",
+			offerID: 7,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockOfferRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(7)).
+					Return(pendingOffer, nil)
+				m.MockOfferRepository.EXPECT().
+					Respond(gomock.Any(), int64(7), entities.OfferDeclined, gomock.Any()).
+					Return(pendingOffer, nil)
+				m.MockPendingRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(pending, nil)
+				m.MockOfferRepository.EXPECT().
+					GetOfferedCourierIDs(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return([]int64{1}, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{ExcludeCourierIDs: []int64{1}}).
+					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					ExplainCourierMismatch(gomock.Any(), entities.CourierSearchFilter{ExcludeCourierIDs: []int64{1}}).
+					Return(&entities.CourierMismatch{}, nil)
+				m.MockAvailabilityNotifier.EXPECT().Notify()
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:    "Заказ успели убрать из очереди ожидания",
+			offerID: 7,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockOfferRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(7)).
+					Return(pendingOffer, nil)
+				m.MockOfferRepository.EXPECT().
+					Respond(gomock.Any(), int64(7), entities.OfferDeclined, gomock.Any()).
+					Return(pendingOffer, nil)
+				m.MockPendingRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(nil, delivery.ErrPendingAssignmentNotFound)
+				m.MockAvailabilityNotifier.EXPECT().Notify()
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:           "Отклонение отказа с некорректным ID предложения",
+			offerID:        -1,
+			errorAssertion: errorAssertion(delivery.ErrInvalidOfferID, ""),
+		},
+		{
+			name:    "Нельзя отказаться от просроченного предложения",
+			offerID: 7,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockOfferRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), int64(7)).
+					Return(expiredOffer, nil)
+			},
+			errorAssertion: errorAssertion(delivery.ErrOfferNotPending, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			err := newOfferService(m).DeclineOffer(context.Background(), tt.offerID)
+
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
+
+func TestDeliveryService_ExpireOffers(t *testing.T) {
+	t.Parallel()
+
+	expiredOffer := &entities.DeliveryOffer{
+		ID:        7,
+		OrderID:   "order-2026-001",
+		CourierID: 1,
+		Status:    entities.OfferPending,
+	}
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		expectedCount  int64
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Просроченное предложение закрывается, заказ ждет следующего курьера",
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(2)
+				gomock.InOrder(
+					m.MockOfferRepository.EXPECT().
+						GetNextExpiredForUpdate(gomock.Any(), gomock.Any()).
+						Return(expiredOffer, nil),
+					m.MockOfferRepository.EXPECT().
+						GetNextExpiredForUpdate(gomock.Any(), gomock.Any()).
+						Return(nil, delivery.ErrNoExpiredOffers),
+				)
+				m.MockOfferRepository.EXPECT().
+					Respond(gomock.Any(), int64(7), entities.OfferExpired, gomock.Any()).
+					Return(expiredOffer, nil)
+				m.MockPendingRepository.EXPECT().
+					GetByOrderIDForUpdate(gomock.Any(), "order-2026-001").
+					Return(&entities.PendingAssignment{ID: 3, OrderID: "order-2026-001"}, nil)
+				m.MockOfferRepository.EXPECT().
+					GetOfferedCourierIDs(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return([]int64{1}, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{ExcludeCourierIDs: []int64{1}}).
+					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					ExplainCourierMismatch(gomock.Any(), gomock.Any()).
+					Return(&entities.CourierMismatch{}, nil)
+				m.MockAvailabilityNotifier.EXPECT().Notify()
+			},
+			expectedCount:  1,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Просроченных предложений нет",
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockOfferRepository.EXPECT().
+					GetNextExpiredForUpdate(gomock.Any(), gomock.Any()).
+					Return(nil, delivery.ErrNoExpiredOffers)
+			},
+			expectedCount:  0,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Ошибка репозитория прерывает обработку",
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockOfferRepository.EXPECT().
+					GetNextExpiredForUpdate(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("connection refused"))
+			},
+			expectedCount:  0,
+			errorAssertion: errorAssertion(nil, "connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			tt.mockSetup(m)
+
+			count, err := newOfferService(m).ExpireOffers(context.Background())
+
+			assert.Equal(t, tt.expectedCount, count)
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
+
+func TestDeliveryService_AssignPendingDeliveries_Offer(t *testing.T) {
+	t.Parallel()
+
+	pending := &entities.PendingAssignment{ID: 3, OrderID: "order-2026-001"}
+	courier := &entities.Courier{ID: 1, Status: entities.CourierAvailable}
+
+	tests := []struct {
+		name          string
+		mockSetup     func(m *mock)
+		expectedCount int64
+	}{
+		{
+			name: "Курьеру, который отказался от заказа раньше срока отказа, заказ предлагается снова",
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(2)
+				gomock.InOrder(
+					m.MockPendingRepository.EXPECT().
+						GetNextForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(pending, nil),
+					m.MockPendingRepository.EXPECT().
+						GetNextForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, delivery.ErrPendingQueueEmpty),
+				)
+				m.MockOfferRepository.EXPECT().
+					GetOfferedCourierIDs(gomock.Any(), "order-2026-001", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, orderID string, since time.Time) ([]int64, error) {
+						assert.WithinDuration(t, time.Now().UTC().Add(-testDeclineCooldown), since, time.Minute)
+						return []int64{}, nil
+					})
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{ExcludeCourierIDs: []int64{}}).
+					Return(courier, nil)
+				m.MockOfferRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, modify entities.DeliveryOfferModify) (*entities.DeliveryOffer, error) {
+						assert.Equal(t, courier.ID, *modify.CourierID)
+						return &entities.DeliveryOffer{ID: 9, OrderID: "order-2026-001", CourierID: courier.ID}, nil
+					})
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "Все свободные курьеры недавно отказались: заказ ждет, очередь разбирается дальше",
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(2)
+				gomock.InOrder(
+					m.MockPendingRepository.EXPECT().
+						GetNextForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(pending, nil),
+					m.MockPendingRepository.EXPECT().
+						GetNextForUpdate(gomock.Any(), gomock.Any(), []string{"order-2026-001"}).
+						Return(nil, delivery.ErrPendingQueueEmpty),
+				)
+				m.MockOfferRepository.EXPECT().
+					GetOfferedCourierIDs(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return([]int64{1}, nil)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{ExcludeCourierIDs: []int64{1}}).
+					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					ExplainCourierMismatch(gomock.Any(), entities.CourierSearchFilter{ExcludeCourierIDs: []int64{1}}).
+					Return(&entities.CourierMismatch{AvailableCouriers: 1}, nil)
+			},
+			expectedCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			tt.mockSetup(m)
+
+			count, err := newOfferService(m).AssignPendingDeliveries(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCount, count)
+		})
+	}
+}
+
+func TestDeliveryService_GetCourierOfferStats(t *testing.T) {
+	t.Parallel()
+
+	stats := &entities.CourierOfferStats{CourierID: 1, Offered: 4, Accepted: 3, Declined: 1}
+
+	tests := []struct {
+		name           string
+		courierID      int64
+		mockSetup      func(m *mock)
+		expectedResult *entities.CourierOfferStats
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:      "Успешное получение статистики предложений курьера",
+			courierID: 1,
+			mockSetup: func(m *mock) {
+				m.MockOfferRepository.EXPECT().
+					GetCourierStats(gomock.Any(), int64(1)).
+					Return(stats, nil)
+			},
+			expectedResult: stats,
+			errorAssertion: require.NoError,
+		},
+		{
+			name:           "Отклонение запроса с некорректным ID курьера",
+			courierID:      0,
+			errorAssertion: errorAssertion(delivery.ErrInvalidCourierID, ""),
+		},
+		{
+			name:      "Курьер не найден",
+			courierID: 1,
+			mockSetup: func(m *mock) {
+				m.MockOfferRepository.EXPECT().
+					GetCourierStats(gomock.Any(), int64(1)).
+					Return(nil, delivery.ErrCourierNotFound)
+			},
+			errorAssertion: errorAssertion(delivery.ErrCourierNotFound, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			result, err := newOfferService(m).GetCourierOfferStats(context.Background(), tt.courierID)
+
+			assert.Equal(t, tt.expectedResult, result)
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
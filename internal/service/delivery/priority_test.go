@@ -0,0 +1,251 @@
+package delivery_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"service/internal/entities"
+	"service/internal/service/delivery"
+)
+
+var testPriorityPolicy = delivery.PriorityPolicy{PreemptWithin: 3 * time.Minute}
+
+func newPriorityService(m *mock, policy delivery.PriorityPolicy) *delivery.Delivery {
+	return delivery.New(
+		m.MockRepository,
+		m.MockPendingRepository,
+		m.MockCourierService,
+		m.MockDeliveryTimeFactory,
+		m.MockTxManager,
+		m.MockAvailabilityNotifier,
+		m.MockZoneResolver,
+		delivery.ZonePolicy{},
+		m.MockOfferRepository,
+		delivery.OfferPolicy{},
+		delivery.BatchPolicy{},
+		delivery.DispatchPolicy{},
+		policy,
+		m.MockScheduledRepository,
+		delivery.RatingPolicy{},
+		m.MockEarningsRecorder,
+		m.MockCashRecorder,
+		delivery.CashPolicy{},
+	)
+}
+
+func TestDeliveryService_DeliveryAssign_Priority(t *testing.T) {
+	t.Parallel()
+
+	carCourier := &entities.Courier{ID: 1, Status: entities.CourierAvailable, TransportType: entities.Car, Version: 2}
+	busyCourier := entities.Courier{ID: 7, Status: entities.CourierBusy, TransportType: entities.Scooter, Version: 5}
+	thermal := entities.OrderRequirements{Skills: []entities.CourierSkill{entities.SkillThermalBag}}
+
+	preemptedCreatedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	preemptedRoute := &entities.Route{
+		Pickup:  entities.Location{Latitude: 55.7558, Longitude: 37.6173},
+		Dropoff: entities.Location{Latitude: 55.7602, Longitude: 37.6186},
+	}
+	candidate := &entities.PreemptionCandidate{
+		Delivery: entities.Delivery{
+			ID:           10,
+			CourierID:    busyCourier.ID,
+			OrderID:      "order-normal",
+			RestaurantID: "restaurant-1",
+			CreatedAt:    preemptedCreatedAt,
+			AssignedAt:   preemptedCreatedAt.Add(time.Minute),
+			Route:        preemptedRoute,
+		},
+		Courier:      busyCourier,
+		Requirements: thermal,
+	}
+
+	highFilter := entities.CourierSearchFilter{PreferFastTransport: true}
+	mismatch := &entities.CourierMismatch{AvailableCouriers: 0}
+
+	expectNoCourier := func(m *mock, filter entities.CourierSearchFilter) {
+		m.MockRepository.EXPECT().
+			GetCourierForAssignment(gomock.Any(), filter).
+			Return(nil, delivery.ErrNoAvailableCouriers)
+		m.MockRepository.EXPECT().
+			ExplainCourierMismatch(gomock.Any(), filter).
+			Return(mismatch, nil)
+	}
+	expectAssign := func(m *mock, courier entities.Courier) {
+		m.MockDeliveryTimeFactory.EXPECT().
+			CalculateDeadline(gomock.Any(), courier.TransportType, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+				return baseTime.Add(10 * time.Minute), nil
+			})
+		m.MockRepository.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, modify entities.DeliveryModify) (*entities.Delivery, error) {
+				assert.Equal(t, entities.PriorityHigh, *modify.Priority)
+				return &entities.Delivery{
+					ID:         11,
+					CourierID:  *modify.CourierID,
+					OrderID:    *modify.OrderID,
+					AssignedAt: *modify.AssignedAt,
+					Deadline:   *modify.Deadline,
+				}, nil
+			})
+		m.MockCourierService.EXPECT().
+			UpdateCourier(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, modify entities.CourierModify) (*entities.Courier, error) {
+				assert.Equal(t, courier.ID, *modify.ID)
+				assert.Equal(t, courier.Version, *modify.ExpectedVersion)
+				return &courier, nil
+			})
+	}
+	expectEnqueue := func(m *mock, orderID string, priority int32) {
+		m.MockPendingRepository.EXPECT().
+			Enqueue(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, modify entities.PendingAssignmentModify) (*entities.PendingAssignment, error) {
+				assert.Equal(t, orderID, *modify.OrderID)
+				assert.Equal(t, priority, *modify.Priority)
+				return &entities.PendingAssignment{ID: 1, OrderID: orderID}, nil
+			})
+	}
+
+	tests := []struct {
+		name              string
+		priority          entities.OrderPriority
+		policy            delivery.PriorityPolicy
+		mockSetup         func(m *mock)
+		expectedCourierID int64
+		errorAssertion    require.ErrorAssertionFunc
+	}{
+		{
+			name:     "Приоритетному заказу подбирается курьер на самом быстром транспорте",
+			priority: entities.PriorityHigh,
+			policy:   testPriorityPolicy,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), highFilter).
+					Return(carCourier, nil)
+				expectAssign(m, *carCourier)
+			},
+			expectedCourierID: carCourier.ID,
+			errorAssertion:    require.NoError,
+		},
+		{
+			name:     "Приоритетный заказ забирает курьера у недавно назначенного обычного заказа",
+			priority: entities.PriorityHigh,
+			policy:   testPriorityPolicy,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				expectNoCourier(m, highFilter)
+				m.MockRepository.EXPECT().
+					GetPreemptionCandidateForUpdate(gomock.Any(), highFilter, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, filter entities.CourierSearchFilter, assignedSince time.Time) (*entities.PreemptionCandidate, error) {
+						assert.WithinDuration(t, time.Now().Add(-testPriorityPolicy.PreemptWithin), assignedSince, time.Minute)
+						return candidate, nil
+					})
+				m.MockRepository.EXPECT().
+					Delete(gomock.Any(), candidate.Delivery.OrderID).
+					Return(nil)
+				m.MockPendingRepository.EXPECT().
+					Enqueue(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, modify entities.PendingAssignmentModify) (*entities.PendingAssignment, error) {
+						// обычный заказ возвращается в очередь со временем своей доставки, маршрутом и требованиями
+						assert.Equal(t, candidate.Delivery.OrderID, *modify.OrderID)
+						assert.Equal(t, entities.DefaultPendingPriority, *modify.Priority)
+						assert.Equal(t, preemptedCreatedAt, *modify.EnqueuedAt)
+						assert.Equal(t, "restaurant-1", *modify.RestaurantID)
+						assert.Equal(t, thermal, modify.Requirements)
+						assert.Equal(t, preemptedRoute, modify.Route)
+						return &entities.PendingAssignment{ID: 1, OrderID: *modify.OrderID}, nil
+					})
+				expectAssign(m, busyCourier)
+			},
+			expectedCourierID: busyCourier.ID,
+			errorAssertion:    require.NoError,
+		},
+		{
+			name:     "Без доставки для перехвата приоритетный заказ встает в начало очереди",
+			priority: entities.PriorityHigh,
+			policy:   testPriorityPolicy,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				expectNoCourier(m, highFilter)
+				m.MockRepository.EXPECT().
+					GetPreemptionCandidateForUpdate(gomock.Any(), highFilter, gomock.Any()).
+					Return(nil, delivery.ErrNoPreemptionCandidate)
+				expectEnqueue(m, "order-2026-001", entities.HighPendingPriority)
+			},
+			errorAssertion: errorAssertion(delivery.ErrAssignmentPending, "no available couriers"),
+		},
+		{
+			name:     "Без политики перехвата приоритетный заказ ждет в очереди",
+			priority: entities.PriorityHigh,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				expectNoCourier(m, highFilter)
+				expectEnqueue(m, "order-2026-001", entities.HighPendingPriority)
+			},
+			errorAssertion: errorAssertion(delivery.ErrAssignmentPending, ""),
+		},
+		{
+			name:     "Обычный заказ не забирает чужих курьеров",
+			priority: entities.PriorityNormal,
+			policy:   testPriorityPolicy,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				expectNoCourier(m, entities.CourierSearchFilter{})
+				expectEnqueue(m, "order-2026-001", entities.DefaultPendingPriority)
+			},
+			errorAssertion: errorAssertion(delivery.ErrAssignmentPending, ""),
+		},
+		{
+			name:     "Ошибка удаления перехваченной доставки",
+			priority: entities.PriorityHigh,
+			policy:   testPriorityPolicy,
+			mockSetup: func(m *mock) {
+				expectTx(m)
+				expectNoCourier(m, highFilter)
+				m.MockRepository.EXPECT().
+					GetPreemptionCandidateForUpdate(gomock.Any(), highFilter, gomock.Any()).
+					Return(candidate, nil)
+				m.MockRepository.EXPECT().
+					Delete(gomock.Any(), candidate.Delivery.OrderID).
+					Return(assert.AnError)
+			},
+			errorAssertion: errorAssertion(assert.AnError, "delete preempted delivery"),
+		},
+		{
+			name:           "Отклонение неизвестного класса срочности",
+			priority:       entities.OrderPriority("urgent"),
+			policy:         testPriorityPolicy,
+			mockSetup:      func(m *mock) {},
+			errorAssertion: errorAssertion(delivery.ErrInvalidPriority, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			tt.mockSetup(m)
+
+			result, err := newPriorityService(m, tt.policy).DeliveryAssign(context.Background(), entities.DeliveryAssignParams{
+				OrderID:  "order-2026-001",
+				Priority: tt.priority,
+			})
+
+			tt.errorAssertion(t, err, tt.name)
+			if tt.expectedCourierID != 0 {
+				require.NotNil(t, result)
+				assert.Equal(t, tt.expectedCourierID, result.CourierID)
+			} else {
+				assert.Nil(t, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"service/internal/entities"
+)
+
+// scheduledAssignBatchSize сколько запланированных доставок читается за один запрос
+const scheduledAssignBatchSize = 100
+
+// scheduleTransportTypes виды транспорта заказа без ограничений по транспорту
+var scheduleTransportTypes = []entities.CourierTransportType{entities.OnFoot, entities.Scooter, entities.Car}
+
+// ScheduleDelivery планирует доставку заказа к DeliverAt. Курьер не назначается сразу и не простаивает
+// в ожидании: планировщик ищет курьера на каждом виде транспорта, только когда до DeliverAt остается
+// время в пути на нем. Поиск начинается в AssignAt - момент для самого медленного из подходящих видов транспорта
+func (d *Delivery) ScheduleDelivery(ctx context.Context, params entities.DeliveryScheduleParams) (*entities.ScheduledDelivery, error) {
+	order := params.Order
+	if !isValidOrderID(order.OrderID) {
+		return nil, ErrInvalidOrderID
+	}
+	if !isValidRoute(order.Route) {
+		return nil, ErrInvalidRoute
+	}
+	if !isValidRequirements(order.Requirements) {
+		return nil, ErrInvalidRequirements
+	}
+	if !isValidPriority(order.Priority) {
+		return nil, ErrInvalidPriority
+	}
+
+	now := time.Now().UTC()
+	deliverAt := params.DeliverAt.UTC()
+	if !deliverAt.After(now) {
+		return nil, ErrInvalidDeliverAt
+	}
+
+	travelTimes, err := d.scheduleTravelTimes(ctx, order, deliverAt)
+	if err != nil {
+		return nil, err
+	}
+
+	assignAt := deliverAt
+	for _, travelTime := range travelTimes {
+		if start := deliverAt.Add(-travelTime); start.Before(assignAt) {
+			assignAt = start
+		}
+	}
+
+	var scheduled *entities.ScheduledDelivery
+	err = d.txManager.Do(ctx, func(ctx context.Context) error {
+		_, err := d.repository.GetByOrderID(ctx, order.OrderID)
+		if err == nil {
+			return ErrOrderAlreadyAssigned
+		}
+		if !errors.Is(err, ErrDeliveryNotFound) {
+			return fmt.Errorf("get delivery: %w", err)
+		}
+
+		scheduled, err = d.scheduledRepository.Create(ctx, entities.ScheduledDeliveryModify{
+			OrderID:        &order.OrderID,
+			DeliverAt:      &deliverAt,
+			AssignAt:       &assignAt,
+			Route:          order.Route,
+			RestaurantID:   &order.RestaurantID,
+			Address:        order.Address,
+			OrderCreatedAt: order.OrderCreatedAt,
+			Requirements:   order.Requirements,
+			Priority:       &order.Priority,
+			CreatedAt:      &now,
+		})
+		if err != nil {
+			return fmt.Errorf("create scheduled delivery: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduled, nil
+}
+
+// GetScheduledDeliveries запланированные доставки, которым курьер еще не назначен, по времени доставки
+func (d *Delivery) GetScheduledDeliveries(ctx context.Context) ([]entities.ScheduledDelivery, error) {
+	scheduled, err := d.scheduledRepository.GetWaiting(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get scheduled deliveries: %w", err)
+	}
+
+	return scheduled, nil
+}
+
+// IsDeliveryScheduled заказ ждет назначения по расписанию: курьера ему назначит планировщик
+func (d *Delivery) IsDeliveryScheduled(ctx context.Context, orderID string) (bool, error) {
+	if !isValidOrderID(orderID) {
+		return false, ErrInvalidOrderID
+	}
+
+	scheduled, err := d.scheduledRepository.ExistsWaitingByOrderID(ctx, orderID)
+	if err != nil {
+		return false, fmt.Errorf("check scheduled delivery: %w", err)
+	}
+
+	return scheduled, nil
+}
+
+// CancelScheduledDelivery отменяет запланированную доставку, пока курьер ей не назначен
+func (d *Delivery) CancelScheduledDelivery(ctx context.Context, orderID string) (*entities.ScheduledDelivery, error) {
+	if !isValidOrderID(orderID) {
+		return nil, ErrInvalidOrderID
+	}
+
+	scheduled, err := d.scheduledRepository.Cancel(ctx, orderID, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("cancel scheduled delivery: %w", err)
+	}
+
+	return scheduled, nil
+}
+
+// AssignDueScheduledDeliveries назначает курьеров запланированным доставкам, для которых наступило время поиска.
+// Доставка без подходящего свободного курьера остается запланированной до следующего запуска.
+// Доставки читаются страницами по курсору, поэтому те, которым курьер не нашелся, не закрывают
+// следующие за ними. Возвращает количество назначенных доставок
+func (d *Delivery) AssignDueScheduledDeliveries(ctx context.Context) (int64, error) {
+	now := time.Now().UTC()
+
+	var (
+		assignedCount int64
+		afterAssignAt time.Time
+		afterID       int64
+	)
+	for {
+		due, err := d.scheduledRepository.GetDue(ctx, now, afterAssignAt, afterID, scheduledAssignBatchSize)
+		if err != nil {
+			return assignedCount, fmt.Errorf("get due scheduled deliveries: %w", err)
+		}
+
+		for _, scheduled := range due {
+			assigned, err := d.assignScheduled(ctx, scheduled.OrderID, now)
+			if err != nil {
+				return assignedCount, err
+			}
+			if assigned {
+				assignedCount++
+			}
+		}
+
+		if len(due) < scheduledAssignBatchSize {
+			return assignedCount, nil
+		}
+		last := due[len(due)-1]
+		afterAssignAt, afterID = last.AssignAt, last.ID
+	}
+}
+
+// assignScheduled назначает запланированной доставке курьера на виде транспорта, которому пора выезжать.
+// false без ошибки - курьер не назначен, доставка ждет следующего запуска или уже обработана
+func (d *Delivery) assignScheduled(ctx context.Context, orderID string, now time.Time) (bool, error) {
+	var (
+		deliveryAssignment *entities.DeliveryAssignment
+		alreadyAssigned    bool
+		attempted          bool
+		priority           = priorityUnknown
+	)
+	start := time.Now()
+
+	err := d.txManager.Do(ctx, func(ctx context.Context) error {
+		scheduled, err := d.scheduledRepository.GetWaitingByOrderIDForUpdate(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("get scheduled delivery: %w", err)
+		}
+
+		params := scheduledToParams(scheduled)
+		priority = params.Priority
+		params.Requirements.TransportTypes, err = d.dueTransportTypes(ctx, params, scheduled.DeliverAt, now)
+		if err != nil {
+			return err
+		}
+		if len(params.Requirements.TransportTypes) == 0 {
+			return ErrNoAvailableCouriers
+		}
+
+		attempted = true
+		deliveryAssignment, err = d.internalDeliveryAssign(ctx, params, scheduled.CreatedAt)
+		if err != nil {
+			alreadyAssigned = errors.Is(err, ErrOrderAlreadyAssigned)
+			return err
+		}
+
+		err = d.scheduledRepository.MarkAssigned(ctx, orderID, deliveryAssignment.AssignedAt)
+		if err != nil {
+			return fmt.Errorf("mark scheduled delivery assigned: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if attempted {
+			observeAssignment(assignSourceSchedule, priority, nil, err, start)
+		}
+
+		switch {
+		// заказ уже назначили в обход планировщика, транзакция откатилась, поэтому закрываем запись отдельно
+		case alreadyAssigned:
+			err = d.scheduledRepository.MarkAssigned(ctx, orderID, time.Now().UTC())
+			if err != nil && !errors.Is(err, ErrScheduledDeliveryNotFound) {
+				return false, fmt.Errorf("mark stale scheduled delivery assigned: %w", err)
+			}
+			return false, nil
+		// доставку отменили или назначает другой инстанс, либо курьера пока нет - пробуем в следующий запуск
+		case errors.Is(err, ErrScheduledDeliveryNotFound),
+			errors.Is(err, ErrNoAvailableCouriers),
+			errors.Is(err, ErrCourierNotAvailable):
+			return false, nil
+		}
+		return false, err
+	}
+
+	// время до назначения у запланированной доставки задано клиентом, поэтому time-to-assign не считается
+	observeAssignment(assignSourceSchedule, priority, deliveryAssignment, nil, start)
+	return true, nil
+}
+
+// dueTransportTypes виды транспорта, курьерам на которых уже пора выезжать, чтобы успеть к deliverAt
+func (d *Delivery) dueTransportTypes(
+	ctx context.Context,
+	params entities.DeliveryAssignParams,
+	deliverAt time.Time,
+	now time.Time,
+) ([]entities.CourierTransportType, error) {
+	travelTimes, err := d.scheduleTravelTimes(ctx, params, deliverAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var due []entities.CourierTransportType
+	for _, transportType := range scheduleCandidates(params.Requirements) {
+		if !now.Before(deliverAt.Add(-travelTimes[transportType])) {
+			due = append(due, transportType)
+		}
+	}
+	return due, nil
+}
+
+// scheduleTravelTimes время в пути до клиента на каждом подходящем заказу виде транспорта.
+// Считается от deliverAt, поэтому при планировании и назначении получается одинаковым
+func (d *Delivery) scheduleTravelTimes(
+	ctx context.Context,
+	params entities.DeliveryAssignParams,
+	deliverAt time.Time,
+) (map[entities.CourierTransportType]time.Duration, error) {
+	candidates := scheduleCandidates(params.Requirements)
+
+	travelTimes := make(map[entities.CourierTransportType]time.Duration, len(candidates))
+	for _, transportType := range candidates {
+		arrival, err := d.timeFactory.CalculateDeadline(ctx, transportType, params.Route, deliverAt)
+		if err != nil {
+			return nil, fmt.Errorf("calculate travel time: %w", err)
+		}
+		travelTimes[transportType] = arrival.Sub(deliverAt)
+	}
+	return travelTimes, nil
+}
+
+// scheduleCandidates виды транспорта, разрешенные заказу
+func scheduleCandidates(requirements entities.OrderRequirements) []entities.CourierTransportType {
+	if len(requirements.TransportTypes) > 0 {
+		return requirements.TransportTypes
+	}
+	return scheduleTransportTypes
+}
+
+// scheduledToParams обещанное клиенту время запланированной доставки - DeliverAt
+func scheduledToParams(scheduled *entities.ScheduledDelivery) entities.DeliveryAssignParams {
+	deliverAt := scheduled.DeliverAt
+	return entities.DeliveryAssignParams{
+		OrderID:           scheduled.OrderID,
+		Route:             scheduled.Route,
+		RestaurantID:      scheduled.RestaurantID,
+		Address:           scheduled.Address,
+		EstimatedDelivery: &deliverAt,
+		OrderCreatedAt:    scheduled.OrderCreatedAt,
+		Requirements:      scheduled.Requirements,
+		Priority:          scheduled.Priority,
+	}
+}
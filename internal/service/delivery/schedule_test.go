@@ -0,0 +1,539 @@
+package delivery_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/service/delivery"
+)
+
+func newScheduleService(m *mock) *delivery.Delivery {
+	return delivery.New(
+		m.MockRepository,
+		m.MockPendingRepository,
+		m.MockCourierService,
+		m.MockDeliveryTimeFactory,
+		m.MockTxManager,
+		m.MockAvailabilityNotifier,
+		m.MockZoneResolver,
+		delivery.ZonePolicy{},
+		m.MockOfferRepository,
+		delivery.OfferPolicy{},
+		delivery.BatchPolicy{},
+		delivery.DispatchPolicy{},
+		delivery.PriorityPolicy{},
+		m.MockScheduledRepository,
+		delivery.RatingPolicy{},
+		m.MockEarningsRecorder,
+		m.MockCashRecorder,
+		delivery.CashPolicy{},
+	)
+}
+
+// testTravelTimes время в пути без маршрута: пешком дольше всего, на машине быстрее всего
+var testTravelTimes = map[entities.CourierTransportType]time.Duration{
+	entities.OnFoot:  30 * time.Minute,
+	entities.Scooter: 20 * time.Minute,
+	entities.Car:     10 * time.Minute,
+}
+
+func expectTravelTimes(m *mock) {
+	m.MockDeliveryTimeFactory.EXPECT().
+		CalculateDeadline(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, transportType entities.CourierTransportType, route *entities.Route, baseTime time.Time) (time.Time, error) {
+			return baseTime.Add(testTravelTimes[transportType]), nil
+		}).
+		AnyTimes()
+}
+
+func TestDeliveryService_ScheduleDelivery(t *testing.T) {
+	t.Parallel()
+
+	deliverAt := time.Now().UTC().Add(2 * time.Hour).Truncate(time.Second)
+	scooterOrCar := entities.OrderRequirements{
+		TransportTypes: []entities.CourierTransportType{entities.Scooter, entities.Car},
+	}
+
+	tests := []struct {
+		name             string
+		orderID          string
+		deliverAt        time.Time
+		requirements     entities.OrderRequirements
+		mockSetup        func(m *mock)
+		expectedAssignAt time.Time
+		errorAssertion   require.ErrorAssertionFunc
+	}{
+		{
+			name:      "Поиск курьера начинается за время в пути самого медленного транспорта",
+			orderID:   "order-2026-001",
+			deliverAt: deliverAt,
+			mockSetup: func(m *mock) {
+				expectTravelTimes(m)
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderID(gomock.Any(), "order-2026-001").
+					Return(nil, delivery.ErrDeliveryNotFound)
+				m.MockScheduledRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, modify entities.ScheduledDeliveryModify) (*entities.ScheduledDelivery, error) {
+						assert.Equal(t, deliverAt, *modify.DeliverAt)
+						return &entities.ScheduledDelivery{
+							ID:        1,
+							OrderID:   *modify.OrderID,
+							DeliverAt: *modify.DeliverAt,
+							AssignAt:  *modify.AssignAt,
+							Status:    entities.ScheduledWaiting,
+						}, nil
+					})
+			},
+			expectedAssignAt: deliverAt.Add(-30 * time.Minute),
+			errorAssertion:   require.NoError,
+		},
+		{
+			name:         "Учитывается только транспорт, разрешенный заказу",
+			orderID:      "order-2026-001",
+			deliverAt:    deliverAt,
+			requirements: scooterOrCar,
+			mockSetup: func(m *mock) {
+				expectTravelTimes(m)
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderID(gomock.Any(), "order-2026-001").
+					Return(nil, delivery.ErrDeliveryNotFound)
+				m.MockScheduledRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, modify entities.ScheduledDeliveryModify) (*entities.ScheduledDelivery, error) {
+						assert.Equal(t, scooterOrCar, modify.Requirements)
+						return &entities.ScheduledDelivery{
+							ID:        1,
+							OrderID:   *modify.OrderID,
+							DeliverAt: *modify.DeliverAt,
+							AssignAt:  *modify.AssignAt,
+							Status:    entities.ScheduledWaiting,
+						}, nil
+					})
+			},
+			expectedAssignAt: deliverAt.Add(-20 * time.Minute),
+			errorAssertion:   require.NoError,
+		},
+		{
+			name:      "Заказу уже назначен курьер",
+			orderID:   "order-2026-001",
+			deliverAt: deliverAt,
+			mockSetup: func(m *mock) {
+				expectTravelTimes(m)
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderID(gomock.Any(), "order-2026-001").
+					Return(&entities.Delivery{OrderID: "order-2026-001"}, nil)
+			},
+			errorAssertion: errorAssertion(delivery.ErrOrderAlreadyAssigned, ""),
+		},
+		{
+			name:      "Заказ уже запланирован",
+			orderID:   "order-2026-001",
+			deliverAt: deliverAt,
+			mockSetup: func(m *mock) {
+				expectTravelTimes(m)
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetByOrderID(gomock.Any(), "order-2026-001").
+					Return(nil, delivery.ErrDeliveryNotFound)
+				m.MockScheduledRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, delivery.ErrOrderAlreadyScheduled)
+			},
+			errorAssertion: errorAssertion(delivery.ErrOrderAlreadyScheduled, "create scheduled delivery"),
+		},
+		{
+			name:           "Время доставки уже прошло",
+			orderID:        "order-2026-001",
+			deliverAt:      time.Now().Add(-time.Minute),
+			mockSetup:      func(m *mock) {},
+			errorAssertion: errorAssertion(delivery.ErrInvalidDeliverAt, ""),
+		},
+		{
+			name:           "Невалидный ID заказа",
+			orderID:        " ",
+			deliverAt:      deliverAt,
+			mockSetup:      func(m *mock) {},
+			errorAssertion: errorAssertion(delivery.ErrInvalidOrderID, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			tt.mockSetup(m)
+
+			result, err := newScheduleService(m).ScheduleDelivery(context.Background(), entities.DeliveryScheduleParams{
+				Order: entities.DeliveryAssignParams{
+					OrderID:      tt.orderID,
+					Requirements: tt.requirements,
+				},
+				DeliverAt: tt.deliverAt,
+			})
+
+			tt.errorAssertion(t, err, tt.name)
+			if tt.expectedAssignAt.IsZero() {
+				assert.Nil(t, result)
+				return
+			}
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expectedAssignAt, result.AssignAt)
+		})
+	}
+}
+
+func TestDeliveryService_AssignDueScheduledDeliveries(t *testing.T) {
+	t.Parallel()
+
+	// пешему курьеру уже пора выезжать, на скутере и машине - еще нет
+	footDue := entities.ScheduledDelivery{
+		ID:        1,
+		OrderID:   "order-foot",
+		DeliverAt: time.Now().UTC().Add(25 * time.Minute),
+		CreatedAt: time.Now().UTC().Add(-time.Hour),
+		Status:    entities.ScheduledWaiting,
+	}
+	// до доставки меньше времени в пути на любом транспорте
+	allDue := entities.ScheduledDelivery{
+		ID:        2,
+		OrderID:   "order-all",
+		DeliverAt: time.Now().UTC().Add(5 * time.Minute),
+		CreatedAt: time.Now().UTC().Add(-time.Hour),
+		Status:    entities.ScheduledWaiting,
+	}
+	footCourier := entities.Courier{ID: 3, Status: entities.CourierAvailable, TransportType: entities.OnFoot, Version: 1}
+
+	expectLocked := func(m *mock, scheduled entities.ScheduledDelivery) {
+		m.MockScheduledRepository.EXPECT().
+			GetWaitingByOrderIDForUpdate(gomock.Any(), scheduled.OrderID).
+			Return(&scheduled, nil)
+	}
+	expectAssign := func(m *mock, scheduled entities.ScheduledDelivery, courier entities.Courier) {
+		m.MockRepository.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, modify entities.DeliveryModify) (*entities.Delivery, error) {
+				// обещанное клиенту время - время запланированной доставки
+				assert.Equal(t, scheduled.DeliverAt, *modify.Deadline)
+				assert.Equal(t, scheduled.CreatedAt, *modify.CreatedAt)
+				return &entities.Delivery{
+					ID:         10,
+					CourierID:  *modify.CourierID,
+					OrderID:    *modify.OrderID,
+					AssignedAt: *modify.AssignedAt,
+					Deadline:   *modify.Deadline,
+				}, nil
+			})
+		m.MockCourierService.EXPECT().
+			UpdateCourier(gomock.Any(), gomock.Any()).
+			Return(&courier, nil)
+		m.MockScheduledRepository.EXPECT().
+			MarkAssigned(gomock.Any(), scheduled.OrderID, gomock.Any()).
+			Return(nil)
+	}
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		expectedCount  int64
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Курьер ищется только на транспорте, которому пора выезжать",
+			mockSetup: func(m *mock) {
+				expectTravelTimes(m)
+				m.MockScheduledRepository.EXPECT().
+					GetDue(gomock.Any(), gomock.Any(), time.Time{}, int64(0), 100).
+					Return([]entities.ScheduledDelivery{footDue}, nil)
+				expectTx(m)
+				expectLocked(m, footDue)
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{
+						TransportTypes: []entities.CourierTransportType{entities.OnFoot},
+					}).
+					Return(&footCourier, nil)
+				expectAssign(m, footDue, footCourier)
+			},
+			expectedCount:  1,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Когда время поджимает, подходит любой транспорт",
+			mockSetup: func(m *mock) {
+				expectTravelTimes(m)
+				m.MockScheduledRepository.EXPECT().
+					GetDue(gomock.Any(), gomock.Any(), time.Time{}, int64(0), 100).
+					Return([]entities.ScheduledDelivery{allDue}, nil)
+				expectTx(m)
+				expectLocked(m, allDue)
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), entities.CourierSearchFilter{
+						TransportTypes: []entities.CourierTransportType{entities.OnFoot, entities.Scooter, entities.Car},
+					}).
+					Return(&footCourier, nil)
+				expectAssign(m, allDue, footCourier)
+			},
+			expectedCount:  1,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Без свободного курьера доставка ждет следующего запуска, остальные назначаются",
+			mockSetup: func(m *mock) {
+				expectTravelTimes(m)
+				m.MockScheduledRepository.EXPECT().
+					GetDue(gomock.Any(), gomock.Any(), time.Time{}, int64(0), 100).
+					Return([]entities.ScheduledDelivery{footDue, allDue}, nil)
+				expectTx(m)
+				expectLocked(m, footDue)
+				expectTx(m)
+				footFilter := entities.CourierSearchFilter{
+					TransportTypes: []entities.CourierTransportType{entities.OnFoot},
+				}
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), footFilter).
+					Return(nil, delivery.ErrNoAvailableCouriers)
+				m.MockRepository.EXPECT().
+					ExplainCourierMismatch(gomock.Any(), footFilter).
+					Return(&entities.CourierMismatch{}, nil)
+
+				expectTx(m)
+				expectLocked(m, allDue)
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), gomock.Any()).
+					Return(&footCourier, nil)
+				expectAssign(m, allDue, footCourier)
+			},
+			expectedCount:  1,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Полная страница доставок без курьера не закрывает следующие доставки",
+			mockSetup: func(m *mock) {
+				expectTravelTimes(m)
+				firstPage := make([]entities.ScheduledDelivery, 100)
+				assignAt := time.Now().UTC().Add(-time.Hour)
+				for i := range firstPage {
+					firstPage[i] = entities.ScheduledDelivery{
+						ID:       int64(100 + i),
+						OrderID:  fmt.Sprintf("order-taken-%d", i),
+						AssignAt: assignAt,
+					}
+					expectTx(m)
+					m.MockScheduledRepository.EXPECT().
+						GetWaitingByOrderIDForUpdate(gomock.Any(), firstPage[i].OrderID).
+						Return(nil, delivery.ErrScheduledDeliveryNotFound)
+				}
+				gomock.InOrder(
+					m.MockScheduledRepository.EXPECT().
+						GetDue(gomock.Any(), gomock.Any(), time.Time{}, int64(0), 100).
+						Return(firstPage, nil),
+					m.MockScheduledRepository.EXPECT().
+						GetDue(gomock.Any(), gomock.Any(), assignAt, int64(199), 100).
+						Return([]entities.ScheduledDelivery{footDue}, nil),
+				)
+				expectTx(m)
+				expectLocked(m, footDue)
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), gomock.Any()).
+					Return(&footCourier, nil)
+				expectAssign(m, footDue, footCourier)
+			},
+			expectedCount:  1,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Доставку отменили или назначает другой инстанс",
+			mockSetup: func(m *mock) {
+				m.MockScheduledRepository.EXPECT().
+					GetDue(gomock.Any(), gomock.Any(), time.Time{}, int64(0), 100).
+					Return([]entities.ScheduledDelivery{footDue}, nil)
+				expectTx(m)
+				m.MockScheduledRepository.EXPECT().
+					GetWaitingByOrderIDForUpdate(gomock.Any(), footDue.OrderID).
+					Return(nil, delivery.ErrScheduledDeliveryNotFound)
+			},
+			expectedCount:  0,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Заказ назначили в обход планировщика - запланированная доставка закрывается",
+			mockSetup: func(m *mock) {
+				expectTravelTimes(m)
+				m.MockScheduledRepository.EXPECT().
+					GetDue(gomock.Any(), gomock.Any(), time.Time{}, int64(0), 100).
+					Return([]entities.ScheduledDelivery{footDue}, nil)
+				expectTx(m)
+				expectLocked(m, footDue)
+				expectTx(m)
+				m.MockRepository.EXPECT().
+					GetCourierForAssignment(gomock.Any(), gomock.Any()).
+					Return(&footCourier, nil)
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, delivery.ErrOrderAlreadyAssigned)
+				m.MockScheduledRepository.EXPECT().
+					MarkAssigned(gomock.Any(), footDue.OrderID, gomock.Any()).
+					Return(nil)
+			},
+			expectedCount:  0,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Ошибка получения запланированных доставок",
+			mockSetup: func(m *mock) {
+				m.MockScheduledRepository.EXPECT().
+					GetDue(gomock.Any(), gomock.Any(), time.Time{}, int64(0), 100).
+					Return(nil, assert.AnError)
+			},
+			expectedCount:  0,
+			errorAssertion: errorAssertion(assert.AnError, "get due scheduled deliveries"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			tt.mockSetup(m)
+
+			assignedCount, err := newScheduleService(m).AssignDueScheduledDeliveries(context.Background())
+
+			tt.errorAssertion(t, err, tt.name)
+			assert.Equal(t, tt.expectedCount, assignedCount)
+		})
+	}
+}
+
+func TestDeliveryService_IsDeliveryScheduled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		orderID        string
+		mockSetup      func(m *mock)
+		expected       bool
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:    "Заказ ждет назначения по расписанию",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockScheduledRepository.EXPECT().
+					ExistsWaitingByOrderID(gomock.Any(), "order-2026-001").
+					Return(true, nil)
+			},
+			expected:       true,
+			errorAssertion: require.NoError,
+		},
+		{
+			name:    "Заказ без расписания",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockScheduledRepository.EXPECT().
+					ExistsWaitingByOrderID(gomock.Any(), "order-2026-001").
+					Return(false, nil)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:    "Ошибка репозитория",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockScheduledRepository.EXPECT().
+					ExistsWaitingByOrderID(gomock.Any(), "order-2026-001").
+					Return(false, assert.AnError)
+			},
+			errorAssertion: errorAssertion(assert.AnError, "check scheduled delivery"),
+		},
+		{
+			name:           "Невалидный ID заказа",
+			orderID:        "",
+			mockSetup:      func(m *mock) {},
+			errorAssertion: errorAssertion(delivery.ErrInvalidOrderID, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			tt.mockSetup(m)
+
+			scheduled, err := newScheduleService(m).IsDeliveryScheduled(context.Background(), tt.orderID)
+
+			tt.errorAssertion(t, err, tt.name)
+			assert.Equal(t, tt.expected, scheduled)
+		})
+	}
+}
+
+func TestDeliveryService_CancelScheduledDelivery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		orderID        string
+		mockSetup      func(m *mock)
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:    "Успешная отмена запланированной доставки",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockScheduledRepository.EXPECT().
+					Cancel(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return(&entities.ScheduledDelivery{OrderID: "order-2026-001", Status: entities.ScheduledCancelled}, nil)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:    "Запланированная доставка не найдена или курьер уже назначен",
+			orderID: "order-2026-001",
+			mockSetup: func(m *mock) {
+				m.MockScheduledRepository.EXPECT().
+					Cancel(gomock.Any(), "order-2026-001", gomock.Any()).
+					Return(nil, delivery.ErrScheduledDeliveryNotFound)
+			},
+			errorAssertion: errorAssertion(delivery.ErrScheduledDeliveryNotFound, ""),
+		},
+		{
+			name:           "Невалидный ID заказа",
+			orderID:        "",
+			mockSetup:      func(m *mock) {},
+			errorAssertion: errorAssertion(delivery.ErrInvalidOrderID, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			tt.mockSetup(m)
+
+			_, err := newScheduleService(m).CancelScheduledDelivery(context.Background(), tt.orderID)
+
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
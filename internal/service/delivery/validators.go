@@ -1,7 +1,58 @@
 package delivery
 
-import "strings"
+import (
+	"strings"
+
+	"service/internal/entities"
+	"service/pkg/geo"
+)
+
+const maxReassignReasonLength = 500
+
+func isValidReassignReason(reason string) bool {
+	trimmed := strings.TrimSpace(reason)
+	return trimmed != "" && len(reason) <= maxReassignReasonLength
+}
 
 func isValidOrderID(orderID string) bool {
 	return strings.TrimSpace(orderID) != ""
 }
+
+// isValidRoute маршрут необязателен, но если передан - координаты должны быть корректными
+func isValidRoute(route *entities.Route) bool {
+	if route == nil {
+		return true
+	}
+
+	pickup := geo.Point{Lat: route.Pickup.Latitude, Lon: route.Pickup.Longitude}
+	dropoff := geo.Point{Lat: route.Dropoff.Latitude, Lon: route.Dropoff.Longitude}
+	return pickup.IsValid() && dropoff.IsValid()
+}
+
+// isValidPriority пустой класс - обычный заказ
+func isValidPriority(priority entities.OrderPriority) bool {
+	switch priority {
+	case "", entities.PriorityNormal, entities.PriorityHigh:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidRequirements(requirements entities.OrderRequirements) bool {
+	for _, skill := range requirements.Skills {
+		switch skill {
+		case entities.SkillThermalBag, entities.SkillAgeVerified:
+		default:
+			return false
+		}
+	}
+	for _, transportType := range requirements.TransportTypes {
+		switch transportType {
+		case entities.OnFoot, entities.Scooter, entities.Car:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,20 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=delivery_eta_test
+package delivery_eta
+
+import (
+	"context"
+	"time"
+
+	"service/internal/entities"
+)
+
+type Repository interface {
+	LockRefresh(ctx context.Context) error
+	GetTrainedAt(ctx context.Context) (*time.Time, error)
+	Replace(ctx context.Context, since time.Time, minSamples int, trainedAt time.Time) (int64, error)
+	FindQuantile(ctx context.Context, transportType entities.CourierTransportType, weekday int, hour int) (*entities.ETAQuantile, error)
+}
+
+type TxManager interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contract.go -destination=./contract_mocks_test.go -package=delivery_eta_test
+//
+
+// Package delivery_eta_test is a generated GoMock package.
+package delivery_eta_test
+
+import (
+	context "context"
+	reflect "reflect"
+	entities "service/internal/entities"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindQuantile mocks base method.
+func (m *MockRepository) FindQuantile(ctx context.Context, transportType entities.CourierTransportType, weekday, hour int) (*entities.ETAQuantile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindQuantile", ctx, transportType, weekday, hour)
+	ret0, _ := ret[0].(*entities.ETAQuantile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindQuantile indicates an expected call of FindQuantile.
+func (mr *MockRepositoryMockRecorder) FindQuantile(ctx, transportType, weekday, hour any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindQuantile", reflect.TypeOf((*MockRepository)(nil).FindQuantile), ctx, transportType, weekday, hour)
+}
+
+// GetTrainedAt mocks base method.
+func (m *MockRepository) GetTrainedAt(ctx context.Context) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrainedAt", ctx)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrainedAt indicates an expected call of GetTrainedAt.
+func (mr *MockRepositoryMockRecorder) GetTrainedAt(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrainedAt", reflect.TypeOf((*MockRepository)(nil).GetTrainedAt), ctx)
+}
+
+// LockRefresh mocks base method.
+func (m *MockRepository) LockRefresh(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockRefresh", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockRefresh indicates an expected call of LockRefresh.
+func (mr *MockRepositoryMockRecorder) LockRefresh(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockRefresh", reflect.TypeOf((*MockRepository)(nil).LockRefresh), ctx)
+}
+
+// Replace mocks base method.
+func (m *MockRepository) Replace(ctx context.Context, since time.Time, minSamples int, trainedAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, since, minSamples, trainedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockRepositoryMockRecorder) Replace(ctx, since, minSamples, trainedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockRepository)(nil).Replace), ctx, since, minSamples, trainedAt)
+}
+
+// MockTxManager is a mock of TxManager interface.
+type MockTxManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockTxManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockTxManagerMockRecorder is the mock recorder for MockTxManager.
+type MockTxManagerMockRecorder struct {
+	mock *MockTxManager
+}
+
+// NewMockTxManager creates a new mock instance.
+func NewMockTxManager(ctrl *gomock.Controller) *MockTxManager {
+	mock := &MockTxManager{ctrl: ctrl}
+	mock.recorder = &MockTxManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTxManager) EXPECT() *MockTxManagerMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockTxManager) Do(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockTxManagerMockRecorder) Do(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockTxManager)(nil).Do), ctx, fn)
+}
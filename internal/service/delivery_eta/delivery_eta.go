@@ -0,0 +1,101 @@
+package delivery_eta
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"service/internal/entities"
+)
+
+type Policy struct {
+	// RefreshHour час UTC, после которого модель обучается заново раз в сутки
+	RefreshHour int
+	// History за какой период берутся доставки для обучения
+	History time.Duration
+	// MinSamples сколько доставок нужно ячейке, чтобы попасть в модель
+	MinSamples int
+}
+
+type DeliveryETA struct {
+	repository Repository
+	txManager  TxManager
+	policy     Policy
+}
+
+func New(repository Repository, txManager TxManager, policy Policy) *DeliveryETA {
+	return &DeliveryETA{
+		repository: repository,
+		txManager:  txManager,
+		policy:     policy,
+	}
+}
+
+// RefreshModel обучает модель заново, если она еще не обучалась или обучена до последнего RefreshHour.
+// Задача запускается чаще раза в сутки, поэтому пропущенное из-за рестарта обучение нагоняется при следующем запуске
+func (d *DeliveryETA) RefreshModel(ctx context.Context) (*entities.ETAModelRefresh, error) {
+	now := time.Now().UTC()
+	result := &entities.ETAModelRefresh{}
+
+	err := d.txManager.Do(ctx, func(ctx context.Context) error {
+		err := d.repository.LockRefresh(ctx)
+		if err != nil {
+			return err
+		}
+
+		trainedAt, err := d.repository.GetTrainedAt(ctx)
+		if err != nil {
+			return err
+		}
+		if trainedAt != nil && !trainedAt.Before(d.lastRefreshAt(now)) {
+			return nil
+		}
+
+		result.Quantiles, err = d.repository.Replace(ctx, now.Add(-d.policy.History), d.policy.MinSamples, now)
+		if err != nil {
+			return err
+		}
+		result.Refreshed = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("refresh eta model: %w", err)
+	}
+
+	return result, nil
+}
+
+// Predict прогноз времени доставки курьером на transportType, назначенным в assignedAt
+func (d *DeliveryETA) Predict(
+	ctx context.Context,
+	transportType entities.CourierTransportType,
+	assignedAt time.Time,
+) (*entities.ETAPrediction, error) {
+	assignedAt = assignedAt.UTC()
+	quantile, err := d.repository.FindQuantile(ctx, transportType, int(assignedAt.Weekday()), assignedAt.Hour())
+	if err != nil {
+		return nil, fmt.Errorf("find eta quantile: %w", err)
+	}
+
+	confidence := entities.ETAConfidenceHigh
+	if quantile.Weekday == entities.ETAAnyTime {
+		confidence = entities.ETAConfidenceLow
+	}
+
+	return &entities.ETAPrediction{
+		ETA:         assignedAt.Add(quantile.P50),
+		P50:         quantile.P50,
+		P90:         quantile.P90,
+		SampleCount: quantile.SampleCount,
+		Confidence:  confidence,
+	}, nil
+}
+
+// lastRefreshAt последний наступивший RefreshHour: сегодня, если час уже прошел, иначе вчера
+func (d *DeliveryETA) lastRefreshAt(now time.Time) time.Time {
+	refreshAt := time.Date(now.Year(), now.Month(), now.Day(), d.policy.RefreshHour, 0, 0, 0, time.UTC)
+	if now.Before(refreshAt) {
+		refreshAt = refreshAt.AddDate(0, 0, -1)
+	}
+	return refreshAt
+}
@@ -0,0 +1,234 @@
+package delivery_eta_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/service/delivery_eta"
+)
+
+type mock struct {
+	*MockRepository
+	*MockTxManager
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockRepository: NewMockRepository(ctrl),
+		MockTxManager:  NewMockTxManager(ctrl),
+	}
+}
+
+func errorAssertion(expectedError error, expectedErrMsg string) require.ErrorAssertionFunc {
+	return func(t require.TestingT, err error, msgAndArgs ...interface{}) {
+		require.Error(t, err, msgAndArgs...)
+
+		if expectedError != nil {
+			assert.ErrorIs(t, err, expectedError, msgAndArgs...)
+		}
+
+		if expectedErrMsg != "" {
+			assert.Contains(t, err.Error(), expectedErrMsg, msgAndArgs...)
+		}
+	}
+}
+
+func TestDeliveryETAService_RefreshModel(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	// час обучения, который уже наступил сегодня
+	refreshHour := now.Hour()
+	lastRefresh := time.Date(now.Year(), now.Month(), now.Day(), refreshHour, 0, 0, 0, time.UTC)
+	policy := delivery_eta.Policy{
+		RefreshHour: refreshHour,
+		History:     28 * 24 * time.Hour,
+		MinSamples:  20,
+	}
+
+	expectLocked := func(m *mock) {
+		m.MockTxManager.EXPECT().
+			Do(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+		m.MockRepository.EXPECT().
+			LockRefresh(gomock.Any()).
+			Return(nil)
+	}
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		expected       *entities.ETAModelRefresh
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Модель еще не обучалась",
+			mockSetup: func(m *mock) {
+				expectLocked(m)
+				m.MockRepository.EXPECT().
+					GetTrainedAt(gomock.Any()).
+					Return(nil, nil)
+				m.MockRepository.EXPECT().
+					Replace(gomock.Any(), gomock.Any(), 20, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, since time.Time, minSamples int, trainedAt time.Time) (int64, error) {
+						assert.Equal(t, policy.History, trainedAt.Sub(since))
+						return 12, nil
+					})
+			},
+			expected:       &entities.ETAModelRefresh{Refreshed: true, Quantiles: 12},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Модель обучена до последнего часа обучения",
+			mockSetup: func(m *mock) {
+				expectLocked(m)
+				trainedAt := lastRefresh.Add(-time.Minute)
+				m.MockRepository.EXPECT().
+					GetTrainedAt(gomock.Any()).
+					Return(&trainedAt, nil)
+				m.MockRepository.EXPECT().
+					Replace(gomock.Any(), gomock.Any(), 20, gomock.Any()).
+					Return(int64(3), nil)
+			},
+			expected:       &entities.ETAModelRefresh{Refreshed: true, Quantiles: 3},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Модель уже обучена после последнего часа обучения",
+			mockSetup: func(m *mock) {
+				expectLocked(m)
+				trainedAt := lastRefresh
+				m.MockRepository.EXPECT().
+					GetTrainedAt(gomock.Any()).
+					Return(&trainedAt, nil)
+			},
+			expected:       &entities.ETAModelRefresh{},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Ошибка обучения модели",
+			mockSetup: func(m *mock) {
+				expectLocked(m)
+				m.MockRepository.EXPECT().
+					GetTrainedAt(gomock.Any()).
+					Return(nil, nil)
+				m.MockRepository.EXPECT().
+					Replace(gomock.Any(), gomock.Any(), 20, gomock.Any()).
+					Return(int64(0), errors.New("database connection lost"))
+			},
+			errorAssertion: errorAssertion(nil, "refresh eta model: database connection lost"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			tt.mockSetup(m)
+
+			service := delivery_eta.New(m.MockRepository, m.MockTxManager, policy)
+
+			actual, err := service.RefreshModel(context.Background())
+
+			tt.errorAssertion(t, err, tt.name)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestDeliveryETAService_Predict(t *testing.T) {
+	t.Parallel()
+
+	// четверг, 12:10 UTC
+	assignedAt := time.Date(2026, 1, 1, 12, 10, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		expected       *entities.ETAPrediction
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Прогноз по ячейке дня недели и часа",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					FindQuantile(gomock.Any(), entities.Car, int(time.Thursday), 12).
+					Return(&entities.ETAQuantile{
+						TransportType: entities.Car,
+						Weekday:       int(time.Thursday),
+						Hour:          12,
+						SampleCount:   140,
+						P50:           18 * time.Minute,
+						P90:           31 * time.Minute,
+					}, nil)
+			},
+			expected: &entities.ETAPrediction{
+				ETA:         assignedAt.Add(18 * time.Minute),
+				P50:         18 * time.Minute,
+				P90:         31 * time.Minute,
+				SampleCount: 140,
+				Confidence:  entities.ETAConfidenceHigh,
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "В ячейке мало доставок - прогноз по всему виду транспорта",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					FindQuantile(gomock.Any(), entities.Car, int(time.Thursday), 12).
+					Return(&entities.ETAQuantile{
+						TransportType: entities.Car,
+						Weekday:       entities.ETAAnyTime,
+						Hour:          entities.ETAAnyTime,
+						SampleCount:   900,
+						P50:           22 * time.Minute,
+						P90:           40 * time.Minute,
+					}, nil)
+			},
+			expected: &entities.ETAPrediction{
+				ETA:         assignedAt.Add(22 * time.Minute),
+				P50:         22 * time.Minute,
+				P90:         40 * time.Minute,
+				SampleCount: 900,
+				Confidence:  entities.ETAConfidenceLow,
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Модель не обучена для вида транспорта",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					FindQuantile(gomock.Any(), entities.Car, int(time.Thursday), 12).
+					Return(nil, delivery_eta.ErrETAModelNotFound)
+			},
+			errorAssertion: errorAssertion(delivery_eta.ErrETAModelNotFound, "find eta quantile"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			tt.mockSetup(m)
+
+			service := delivery_eta.New(m.MockRepository, m.MockTxManager, delivery_eta.Policy{})
+
+			actual, err := service.Predict(context.Background(), entities.Car, assignedAt)
+
+			tt.errorAssertion(t, err, tt.name)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,5 @@
+package delivery_eta
+
+import "errors"
+
+var ErrETAModelNotFound = errors.New("eta model has no quantiles for transport type")
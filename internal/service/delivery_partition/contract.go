@@ -0,0 +1,27 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=delivery_partition_test
+package delivery_partition
+
+import (
+	"context"
+	"time"
+
+	"service/internal/entities"
+)
+
+type Repository interface {
+	LockMaintenance(ctx context.Context) error
+	ListPartitions(ctx context.Context) ([]entities.DeliveryPartition, error)
+	CreatePartition(ctx context.Context, month time.Time) (bool, error)
+	ArchivePartition(ctx context.Context, partition entities.DeliveryPartition) (int64, error)
+	StreamPartition(ctx context.Context, partition entities.DeliveryPartition, fn func(delivery entities.Delivery) error) (int64, error)
+	DropPartition(ctx context.Context, partition entities.DeliveryPartition) error
+}
+
+// ArchiveStorage сохраняет строки партиции вне БД; write вызывает add для каждой строки
+type ArchiveStorage interface {
+	Store(ctx context.Context, name string, write func(add func(delivery entities.Delivery) error) error) error
+}
+
+type TxManager interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,207 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contract.go -destination=./contract_mocks_test.go -package=delivery_partition_test
+//
+
+// Package delivery_partition_test is a generated GoMock package.
+package delivery_partition_test
+
+import (
+	context "context"
+	reflect "reflect"
+	entities "service/internal/entities"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ArchivePartition mocks base method.
+func (m *MockRepository) ArchivePartition(ctx context.Context, partition entities.DeliveryPartition) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchivePartition", ctx, partition)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchivePartition indicates an expected call of ArchivePartition.
+func (mr *MockRepositoryMockRecorder) ArchivePartition(ctx, partition any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchivePartition", reflect.TypeOf((*MockRepository)(nil).ArchivePartition), ctx, partition)
+}
+
+// CreatePartition mocks base method.
+func (m *MockRepository) CreatePartition(ctx context.Context, month time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePartition", ctx, month)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePartition indicates an expected call of CreatePartition.
+func (mr *MockRepositoryMockRecorder) CreatePartition(ctx, month any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePartition", reflect.TypeOf((*MockRepository)(nil).CreatePartition), ctx, month)
+}
+
+// DropPartition mocks base method.
+func (m *MockRepository) DropPartition(ctx context.Context, partition entities.DeliveryPartition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropPartition", ctx, partition)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropPartition indicates an expected call of DropPartition.
+func (mr *MockRepositoryMockRecorder) DropPartition(ctx, partition any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropPartition", reflect.TypeOf((*MockRepository)(nil).DropPartition), ctx, partition)
+}
+
+// ListPartitions mocks base method.
+func (m *MockRepository) ListPartitions(ctx context.Context) ([]entities.DeliveryPartition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPartitions", ctx)
+	ret0, _ := ret[0].([]entities.DeliveryPartition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPartitions indicates an expected call of ListPartitions.
+func (mr *MockRepositoryMockRecorder) ListPartitions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPartitions", reflect.TypeOf((*MockRepository)(nil).ListPartitions), ctx)
+}
+
+// LockMaintenance mocks base method.
+func (m *MockRepository) LockMaintenance(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockMaintenance", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockMaintenance indicates an expected call of LockMaintenance.
+func (mr *MockRepositoryMockRecorder) LockMaintenance(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockMaintenance", reflect.TypeOf((*MockRepository)(nil).LockMaintenance), ctx)
+}
+
+// StreamPartition mocks base method.
+func (m *MockRepository) StreamPartition(ctx context.Context, partition entities.DeliveryPartition, fn func(entities.Delivery) error) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamPartition", ctx, partition, fn)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamPartition indicates an expected call of StreamPartition.
+func (mr *MockRepositoryMockRecorder) StreamPartition(ctx, partition, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamPartition", reflect.TypeOf((*MockRepository)(nil).StreamPartition), ctx, partition, fn)
+}
+
+// MockArchiveStorage is a mock of ArchiveStorage interface.
+type MockArchiveStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockArchiveStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockArchiveStorageMockRecorder is the mock recorder for MockArchiveStorage.
+type MockArchiveStorageMockRecorder struct {
+	mock *MockArchiveStorage
+}
+
+// NewMockArchiveStorage creates a new mock instance.
+func NewMockArchiveStorage(ctrl *gomock.Controller) *MockArchiveStorage {
+	mock := &MockArchiveStorage{ctrl: ctrl}
+	mock.recorder = &MockArchiveStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArchiveStorage) EXPECT() *MockArchiveStorageMockRecorder {
+	return m.recorder
+}
+
+// Store mocks base method.
+func (m *MockArchiveStorage) Store(ctx context.Context, name string, write func(func(entities.Delivery) error) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", ctx, name, write)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Store indicates an expected call of Store.
+func (mr *MockArchiveStorageMockRecorder) Store(ctx, name, write any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockArchiveStorage)(nil).Store), ctx, name, write)
+}
+
+// MockTxManager is a mock of TxManager interface.
+type MockTxManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockTxManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockTxManagerMockRecorder is the mock recorder for MockTxManager.
+type MockTxManagerMockRecorder struct {
+	mock *MockTxManager
+}
+
+// NewMockTxManager creates a new mock instance.
+func NewMockTxManager(ctrl *gomock.Controller) *MockTxManager {
+	mock := &MockTxManager{ctrl: ctrl}
+	mock.recorder = &MockTxManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTxManager) EXPECT() *MockTxManagerMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockTxManager) Do(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockTxManagerMockRecorder) Do(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockTxManager)(nil).Do), ctx, fn)
+}
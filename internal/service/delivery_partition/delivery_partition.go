@@ -0,0 +1,144 @@
+package delivery_partition
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"service/internal/entities"
+)
+
+type ArchiveMode string
+
+const (
+	// ArchiveModeTable строки партиции переносятся в delivery_archive
+	ArchiveModeTable ArchiveMode = "table"
+	// ArchiveModeJSONL строки партиции выгружаются в сжатый JSONL файл
+	ArchiveModeJSONL ArchiveMode = "jsonl"
+)
+
+type Policy struct {
+	// PremakeMonths на сколько месяцев вперед, считая текущий, партиции создаются заранее
+	PremakeMonths int
+	// RetentionMonths сколько полных месяцев до текущего остаются в delivery
+	RetentionMonths int
+	ArchiveMode     ArchiveMode
+}
+
+type DeliveryPartition struct {
+	repository Repository
+	storage    ArchiveStorage
+	txManager  TxManager
+	policy     Policy
+}
+
+func New(repository Repository, storage ArchiveStorage, txManager TxManager, policy Policy) *DeliveryPartition {
+	return &DeliveryPartition{
+		repository: repository,
+		storage:    storage,
+		txManager:  txManager,
+		policy:     policy,
+	}
+}
+
+// MaintainPartitions создает партиции на PremakeMonths вперед и убирает в архив партиции старше срока хранения
+func (d *DeliveryPartition) MaintainPartitions(ctx context.Context) (*entities.DeliveryPartitionMaintenance, error) {
+	now := time.Now().UTC()
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	result := &entities.DeliveryPartitionMaintenance{}
+
+	for i := range d.policy.PremakeMonths {
+		month := currentMonth.AddDate(0, i, 0)
+
+		var created bool
+		err := d.txManager.Do(ctx, func(ctx context.Context) error {
+			err := d.repository.LockMaintenance(ctx)
+			if err != nil {
+				return err
+			}
+
+			created, err = d.repository.CreatePartition(ctx, month)
+			return err
+		})
+		if err != nil {
+			return result, fmt.Errorf("create partition %s: %w", month.Format("2006-01"), err)
+		}
+
+		if created {
+			result.Created = append(result.Created, month.Format("2006-01"))
+		}
+	}
+
+	partitions, err := d.repository.ListPartitions(ctx)
+	if err != nil {
+		return result, fmt.Errorf("list partitions: %w", err)
+	}
+
+	retainFrom := currentMonth.AddDate(0, -d.policy.RetentionMonths, 0)
+	for _, partition := range partitions {
+		if partition.To.After(retainFrom) {
+			continue
+		}
+
+		rows, err := d.archivePartition(ctx, partition)
+		if err != nil {
+			return result, fmt.Errorf("archive partition %s: %w", partition.Name, err)
+		}
+
+		result.Archived = append(result.Archived, partition.Name)
+		result.ArchivedRows += rows
+	}
+
+	return result, nil
+}
+
+func (d *DeliveryPartition) archivePartition(ctx context.Context, partition entities.DeliveryPartition) (int64, error) {
+	if d.policy.ArchiveMode == ArchiveModeJSONL {
+		return d.exportPartition(ctx, partition)
+	}
+
+	var rows int64
+	err := d.txManager.Do(ctx, func(ctx context.Context) error {
+		err := d.repository.LockMaintenance(ctx)
+		if err != nil {
+			return err
+		}
+
+		rows, err = d.repository.ArchivePartition(ctx, partition)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return rows, nil
+}
+
+// exportPartition партиция удаляется только после того, как файл записан целиком.
+// Если удаление не прошло, следующий запуск перезапишет файл тем же содержимым
+func (d *DeliveryPartition) exportPartition(ctx context.Context, partition entities.DeliveryPartition) (int64, error) {
+	var rows int64
+	err := d.storage.Store(ctx, partition.Name, func(add func(delivery entities.Delivery) error) error {
+		var err error
+		rows, err = d.repository.StreamPartition(ctx, partition, add)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("export: %w", err)
+	}
+
+	err = d.txManager.Do(ctx, func(ctx context.Context) error {
+		err := d.repository.LockMaintenance(ctx)
+		if err != nil {
+			return err
+		}
+
+		return d.repository.DropPartition(ctx, partition)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("drop: %w", err)
+	}
+
+	return rows, nil
+}
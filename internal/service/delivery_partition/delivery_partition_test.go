@@ -0,0 +1,247 @@
+package delivery_partition_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/service/delivery_partition"
+)
+
+type mock struct {
+	*MockRepository
+	*MockArchiveStorage
+	*MockTxManager
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockRepository:     NewMockRepository(ctrl),
+		MockArchiveStorage: NewMockArchiveStorage(ctrl),
+		MockTxManager:      NewMockTxManager(ctrl),
+	}
+}
+
+func errorAssertion(expectedError error, expectedErrMsg string) require.ErrorAssertionFunc {
+	return func(t require.TestingT, err error, msgAndArgs ...interface{}) {
+		require.Error(t, err, msgAndArgs...)
+
+		if expectedError != nil {
+			assert.ErrorIs(t, err, expectedError, msgAndArgs...)
+		}
+
+		if expectedErrMsg != "" {
+			assert.Contains(t, err.Error(), expectedErrMsg, msgAndArgs...)
+		}
+	}
+}
+
+func partitionAt(month time.Time) entities.DeliveryPartition {
+	return entities.DeliveryPartition{
+		Name: fmt.Sprintf("delivery_y%04dm%02d", month.Year(), int(month.Month())),
+		From: month,
+		To:   month.AddDate(0, 1, 0),
+	}
+}
+
+func TestDeliveryPartitionService_MaintainPartitions(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	// при сроке хранения 2 месяца партиция третьего месяца назад уходит в архив, второго - остается
+	expired := partitionAt(currentMonth.AddDate(0, -3, 0))
+	retained := partitionAt(currentMonth.AddDate(0, -2, 0))
+	current := partitionAt(currentMonth)
+
+	deliveries := []entities.Delivery{
+		{ID: 1, CourierID: 7, OrderID: "order-1", CreatedAt: expired.From},
+		{ID: 2, CourierID: 8, OrderID: "order-2", CreatedAt: expired.From},
+	}
+
+	passthroughTx := func(m *mock, times int) {
+		m.MockTxManager.EXPECT().
+			Do(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			}).
+			Times(times)
+		m.MockRepository.EXPECT().
+			LockMaintenance(gomock.Any()).
+			Return(nil).
+			Times(times)
+	}
+
+	tests := []struct {
+		name           string
+		policy         delivery_partition.Policy
+		mockSetup      func(m *mock)
+		expectedResult *entities.DeliveryPartitionMaintenance
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Создаются недостающие партиции, старая переносится в архивную таблицу",
+			policy: delivery_partition.Policy{
+				PremakeMonths:   2,
+				RetentionMonths: 2,
+				ArchiveMode:     delivery_partition.ArchiveModeTable,
+			},
+			mockSetup: func(m *mock) {
+				passthroughTx(m, 3)
+				m.MockRepository.EXPECT().
+					CreatePartition(gomock.Any(), currentMonth).
+					Return(false, nil)
+				m.MockRepository.EXPECT().
+					CreatePartition(gomock.Any(), currentMonth.AddDate(0, 1, 0)).
+					Return(true, nil)
+				m.MockRepository.EXPECT().
+					ListPartitions(gomock.Any()).
+					Return([]entities.DeliveryPartition{expired, retained, current}, nil)
+				m.MockRepository.EXPECT().
+					ArchivePartition(gomock.Any(), expired).
+					Return(int64(42), nil)
+			},
+			expectedResult: &entities.DeliveryPartitionMaintenance{
+				Created:      []string{currentMonth.AddDate(0, 1, 0).Format("2006-01")},
+				Archived:     []string{expired.Name},
+				ArchivedRows: 42,
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Старая партиция выгружается в JSONL и удаляется после записи файла",
+			policy: delivery_partition.Policy{
+				PremakeMonths:   1,
+				RetentionMonths: 2,
+				ArchiveMode:     delivery_partition.ArchiveModeJSONL,
+			},
+			mockSetup: func(m *mock) {
+				passthroughTx(m, 2)
+				m.MockRepository.EXPECT().
+					CreatePartition(gomock.Any(), currentMonth).
+					Return(false, nil)
+				m.MockRepository.EXPECT().
+					ListPartitions(gomock.Any()).
+					Return([]entities.DeliveryPartition{expired, retained, current}, nil)
+
+				var stored []entities.Delivery
+				storeCall := m.MockArchiveStorage.EXPECT().
+					Store(gomock.Any(), expired.Name, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, name string, write func(add func(delivery entities.Delivery) error) error) error {
+						err := write(func(delivery entities.Delivery) error {
+							stored = append(stored, delivery)
+							return nil
+						})
+						assert.Equal(t, deliveries, stored)
+						return err
+					})
+				m.MockRepository.EXPECT().
+					StreamPartition(gomock.Any(), expired, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, partition entities.DeliveryPartition, fn func(delivery entities.Delivery) error) (int64, error) {
+						for _, delivery := range deliveries {
+							err := fn(delivery)
+							if err != nil {
+								return 0, err
+							}
+						}
+						return int64(len(deliveries)), nil
+					})
+				m.MockRepository.EXPECT().
+					DropPartition(gomock.Any(), expired).
+					Return(nil).
+					After(storeCall)
+			},
+			expectedResult: &entities.DeliveryPartitionMaintenance{
+				Archived:     []string{expired.Name},
+				ArchivedRows: 2,
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Ошибка выгрузки - партиция не удаляется",
+			policy: delivery_partition.Policy{
+				PremakeMonths:   1,
+				RetentionMonths: 2,
+				ArchiveMode:     delivery_partition.ArchiveModeJSONL,
+			},
+			mockSetup: func(m *mock) {
+				passthroughTx(m, 1)
+				m.MockRepository.EXPECT().
+					CreatePartition(gomock.Any(), currentMonth).
+					Return(false, nil)
+				m.MockRepository.EXPECT().
+					ListPartitions(gomock.Any()).
+					Return([]entities.DeliveryPartition{expired}, nil)
+				m.MockArchiveStorage.EXPECT().
+					Store(gomock.Any(), expired.Name, gomock.Any()).
+					Return(errors.New("no space left on device"))
+			},
+			expectedResult: &entities.DeliveryPartitionMaintenance{},
+			errorAssertion: errorAssertion(nil, "archive partition "+expired.Name+": export: no space left on device"),
+		},
+		{
+			name: "Ошибка создания партиции",
+			policy: delivery_partition.Policy{
+				PremakeMonths:   1,
+				RetentionMonths: 2,
+				ArchiveMode:     delivery_partition.ArchiveModeTable,
+			},
+			mockSetup: func(m *mock) {
+				passthroughTx(m, 1)
+				m.MockRepository.EXPECT().
+					CreatePartition(gomock.Any(), currentMonth).
+					Return(false, errors.New("lock timeout"))
+			},
+			expectedResult: &entities.DeliveryPartitionMaintenance{},
+			errorAssertion: errorAssertion(nil, "create partition "+currentMonth.Format("2006-01")+": lock timeout"),
+		},
+		{
+			name: "Ошибка получения списка партиций",
+			policy: delivery_partition.Policy{
+				PremakeMonths:   1,
+				RetentionMonths: 2,
+				ArchiveMode:     delivery_partition.ArchiveModeTable,
+			},
+			mockSetup: func(m *mock) {
+				passthroughTx(m, 1)
+				m.MockRepository.EXPECT().
+					CreatePartition(gomock.Any(), currentMonth).
+					Return(true, nil)
+				m.MockRepository.EXPECT().
+					ListPartitions(gomock.Any()).
+					Return(nil, errors.New("connection refused"))
+			},
+			expectedResult: &entities.DeliveryPartitionMaintenance{
+				Created: []string{currentMonth.Format("2006-01")},
+			},
+			errorAssertion: errorAssertion(nil, "list partitions: connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := delivery_partition.New(m.MockRepository, m.MockArchiveStorage, m.MockTxManager, tt.policy)
+
+			result, err := service.MaintainPartitions(context.Background())
+
+			assert.Equal(t, tt.expectedResult, result)
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=delivery_rating_test
+package delivery_rating
+
+import (
+	"context"
+	"time"
+
+	"service/internal/entities"
+)
+
+type Repository interface {
+	GetRatedDelivery(ctx context.Context, orderID string) (*entities.RatedDelivery, error)
+	Create(ctx context.Context, rating entities.DeliveryRating) (*entities.DeliveryRating, error)
+	GetCourierRating(ctx context.Context, courierID int64, since time.Time) (*entities.CourierRating, error)
+}
@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contract.go -destination=./contract_mocks_test.go -package=delivery_rating_test
+//
+
+// Package delivery_rating_test is a generated GoMock package.
+package delivery_rating_test
+
+import (
+	context "context"
+	reflect "reflect"
+	entities "service/internal/entities"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, rating entities.DeliveryRating) (*entities.DeliveryRating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, rating)
+	ret0, _ := ret[0].(*entities.DeliveryRating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, rating any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, rating)
+}
+
+// GetCourierRating mocks base method.
+func (m *MockRepository) GetCourierRating(ctx context.Context, courierID int64, since time.Time) (*entities.CourierRating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourierRating", ctx, courierID, since)
+	ret0, _ := ret[0].(*entities.CourierRating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourierRating indicates an expected call of GetCourierRating.
+func (mr *MockRepositoryMockRecorder) GetCourierRating(ctx, courierID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierRating", reflect.TypeOf((*MockRepository)(nil).GetCourierRating), ctx, courierID, since)
+}
+
+// GetRatedDelivery mocks base method.
+func (m *MockRepository) GetRatedDelivery(ctx context.Context, orderID string) (*entities.RatedDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatedDelivery", ctx, orderID)
+	ret0, _ := ret[0].(*entities.RatedDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatedDelivery indicates an expected call of GetRatedDelivery.
+func (mr *MockRepositoryMockRecorder) GetRatedDelivery(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatedDelivery", reflect.TypeOf((*MockRepository)(nil).GetRatedDelivery), ctx, orderID)
+}
@@ -0,0 +1,86 @@
+package delivery_rating
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"service/internal/entities"
+)
+
+type Policy struct {
+	// Window за какой период считается средняя оценка курьера
+	Window time.Duration
+}
+
+type DeliveryRating struct {
+	repository Repository
+	policy     Policy
+}
+
+func New(repository Repository, policy Policy) *DeliveryRating {
+	return &DeliveryRating{
+		repository: repository,
+		policy:     policy,
+	}
+}
+
+// RateDelivery сохраняет оценку выполненной доставки. Оценка привязывается к курьеру, который вез заказ,
+// повторная оценка от того же автора отклоняется
+func (d *DeliveryRating) RateDelivery(ctx context.Context, params entities.DeliveryRatingParams) (*entities.DeliveryRating, error) {
+	err := validateRatingParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := normalizeTags(params.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	// пустой комментарий не сохраняется
+	comment := params.Comment
+	if comment != nil && strings.TrimSpace(*comment) == "" {
+		comment = nil
+	}
+
+	ratedDelivery, err := d.repository.GetRatedDelivery(ctx, params.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("get rated delivery: %w", err)
+	}
+	if ratedDelivery.CompletedAt == nil {
+		return nil, ErrDeliveryNotCompleted
+	}
+
+	rating, err := d.repository.Create(ctx, entities.DeliveryRating{
+		OrderID:   params.OrderID,
+		CourierID: ratedDelivery.CourierID,
+		Author:    params.Author,
+		Score:     params.Score,
+		Tags:      tags,
+		Comment:   comment,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create delivery rating: %w", err)
+	}
+
+	return rating, nil
+}
+
+// GetCourierRating средняя оценка курьера за последние Window
+func (d *DeliveryRating) GetCourierRating(ctx context.Context, courierID int64) (*entities.CourierRating, error) {
+	if courierID <= 0 {
+		return nil, ErrInvalidCourierID
+	}
+
+	since := time.Now().UTC().Add(-d.policy.Window)
+	rating, err := d.repository.GetCourierRating(ctx, courierID, since)
+	if err != nil {
+		return nil, fmt.Errorf("get courier rating: %w", err)
+	}
+	rating.Window = d.policy.Window
+
+	return rating, nil
+}
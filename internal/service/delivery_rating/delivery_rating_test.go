@@ -0,0 +1,282 @@
+package delivery_rating_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/service/delivery_rating"
+)
+
+type mock struct {
+	*MockRepository
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockRepository: NewMockRepository(ctrl),
+	}
+}
+
+func errorAssertion(expectedError error, expectedErrMsg string) require.ErrorAssertionFunc {
+	return func(t require.TestingT, err error, msgAndArgs ...interface{}) {
+		require.Error(t, err, msgAndArgs...)
+
+		if expectedError != nil {
+			assert.ErrorIs(t, err, expectedError, msgAndArgs...)
+		}
+
+		if expectedErrMsg != "" {
+			assert.Contains(t, err.Error(), expectedErrMsg, msgAndArgs...)
+		}
+	}
+}
+
+func TestDeliveryRatingService_RateDelivery(t *testing.T) {
+	t.Parallel()
+
+	completedAt := time.Date(2026, 1, 1, 12, 40, 0, 0, time.UTC)
+	createdAt := time.Date(2026, 1, 1, 13, 0, 0, 0, time.UTC)
+
+	validParams := entities.DeliveryRatingParams{
+		OrderID: "order-1",
+		Author:  entities.RatingAuthorCustomer,
+		Score:   5,
+		Tags:    []string{" Polite ", "fast", "polite"},
+		Comment: pointer.To("Спасибо!"),
+	}
+
+	tests := []struct {
+		name           string
+		params         entities.DeliveryRatingParams
+		mockSetup      func(m *mock)
+		expected       *entities.DeliveryRating
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:   "Оценка выполненной доставки",
+			params: validParams,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetRatedDelivery(gomock.Any(), "order-1").
+					Return(&entities.RatedDelivery{CourierID: 7, CompletedAt: &completedAt}, nil)
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, rating entities.DeliveryRating) (*entities.DeliveryRating, error) {
+						assert.Equal(t, int64(7), rating.CourierID)
+						assert.Equal(t, []string{"polite", "fast"}, rating.Tags)
+						assert.WithinDuration(t, time.Now().UTC(), rating.CreatedAt, time.Minute)
+						rating.ID = 1
+						rating.CreatedAt = createdAt
+						return &rating, nil
+					})
+			},
+			expected: &entities.DeliveryRating{
+				ID:        1,
+				OrderID:   "order-1",
+				CourierID: 7,
+				Author:    entities.RatingAuthorCustomer,
+				Score:     5,
+				Tags:      []string{"polite", "fast"},
+				Comment:   pointer.To("Спасибо!"),
+				CreatedAt: createdAt,
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Оценка ресторана без тегов и с пустым комментарием",
+			params: entities.DeliveryRatingParams{
+				OrderID: "order-1",
+				Author:  entities.RatingAuthorRestaurant,
+				Score:   2,
+				Comment: pointer.To("  "),
+			},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetRatedDelivery(gomock.Any(), "order-1").
+					Return(&entities.RatedDelivery{CourierID: 7, CompletedAt: &completedAt}, nil)
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, rating entities.DeliveryRating) (*entities.DeliveryRating, error) {
+						assert.Nil(t, rating.Comment)
+						assert.Empty(t, rating.Tags)
+						rating.ID = 2
+						rating.CreatedAt = createdAt
+						return &rating, nil
+					})
+			},
+			expected: &entities.DeliveryRating{
+				ID:        2,
+				OrderID:   "order-1",
+				CourierID: 7,
+				Author:    entities.RatingAuthorRestaurant,
+				Score:     2,
+				Tags:      []string{},
+				CreatedAt: createdAt,
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:           "Пустой ID заказа",
+			params:         entities.DeliveryRatingParams{OrderID: " ", Author: entities.RatingAuthorCustomer, Score: 5},
+			errorAssertion: errorAssertion(delivery_rating.ErrInvalidOrderID, ""),
+		},
+		{
+			name:           "Неизвестный автор оценки",
+			params:         entities.DeliveryRatingParams{OrderID: "order-1", Author: "courier", Score: 5},
+			errorAssertion: errorAssertion(delivery_rating.ErrInvalidAuthor, ""),
+		},
+		{
+			name:           "Оценка меньше 1",
+			params:         entities.DeliveryRatingParams{OrderID: "order-1", Author: entities.RatingAuthorCustomer, Score: 0},
+			errorAssertion: errorAssertion(delivery_rating.ErrInvalidScore, ""),
+		},
+		{
+			name:           "Оценка больше 5",
+			params:         entities.DeliveryRatingParams{OrderID: "order-1", Author: entities.RatingAuthorCustomer, Score: 6},
+			errorAssertion: errorAssertion(delivery_rating.ErrInvalidScore, ""),
+		},
+		{
+			name: "Пустой тег",
+			params: entities.DeliveryRatingParams{
+				OrderID: "order-1",
+				Author:  entities.RatingAuthorCustomer,
+				Score:   4,
+				Tags:    []string{"fast", " "},
+			},
+			errorAssertion: errorAssertion(delivery_rating.ErrInvalidTags, ""),
+		},
+		{
+			name: "Слишком длинный комментарий",
+			params: entities.DeliveryRatingParams{
+				OrderID: "order-1",
+				Author:  entities.RatingAuthorCustomer,
+				Score:   4,
+				Comment: pointer.To(strings.Repeat("а", 1001)),
+			},
+			errorAssertion: errorAssertion(delivery_rating.ErrInvalidComment, ""),
+		},
+		{
+			name:   "Доставка не найдена",
+			params: validParams,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetRatedDelivery(gomock.Any(), "order-1").
+					Return(nil, delivery_rating.ErrDeliveryNotFound)
+			},
+			errorAssertion: errorAssertion(delivery_rating.ErrDeliveryNotFound, "get rated delivery"),
+		},
+		{
+			name:   "Доставка еще не выполнена",
+			params: validParams,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetRatedDelivery(gomock.Any(), "order-1").
+					Return(&entities.RatedDelivery{CourierID: 7}, nil)
+			},
+			errorAssertion: errorAssertion(delivery_rating.ErrDeliveryNotCompleted, ""),
+		},
+		{
+			name:   "Автор уже оценил доставку",
+			params: validParams,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetRatedDelivery(gomock.Any(), "order-1").
+					Return(&entities.RatedDelivery{CourierID: 7, CompletedAt: &completedAt}, nil)
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, delivery_rating.ErrAlreadyRated)
+			},
+			errorAssertion: errorAssertion(delivery_rating.ErrAlreadyRated, "create delivery rating"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := delivery_rating.New(m.MockRepository, delivery_rating.Policy{Window: 30 * 24 * time.Hour})
+
+			actual, err := service.RateDelivery(context.Background(), tt.params)
+
+			tt.errorAssertion(t, err, tt.name)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestDeliveryRatingService_GetCourierRating(t *testing.T) {
+	t.Parallel()
+
+	window := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name           string
+		courierID      int64
+		mockSetup      func(m *mock)
+		expected       *entities.CourierRating
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:      "Средняя оценка за окно",
+			courierID: 7,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetCourierRating(gomock.Any(), int64(7), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, courierID int64, since time.Time) (*entities.CourierRating, error) {
+						assert.WithinDuration(t, time.Now().UTC().Add(-window), since, time.Minute)
+						return &entities.CourierRating{CourierID: 7, Average: 4.5, Count: 12}, nil
+					})
+			},
+			expected:       &entities.CourierRating{CourierID: 7, Average: 4.5, Count: 12, Window: window},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:           "Некорректный ID курьера",
+			courierID:      0,
+			errorAssertion: errorAssertion(delivery_rating.ErrInvalidCourierID, ""),
+		},
+		{
+			name:      "Ошибка репозитория",
+			courierID: 7,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetCourierRating(gomock.Any(), int64(7), gomock.Any()).
+					Return(nil, errors.New("database connection lost"))
+			},
+			errorAssertion: errorAssertion(nil, "get courier rating: database connection lost"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := delivery_rating.New(m.MockRepository, delivery_rating.Policy{Window: window})
+
+			actual, err := service.GetCourierRating(context.Background(), tt.courierID)
+
+			tt.errorAssertion(t, err, tt.name)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
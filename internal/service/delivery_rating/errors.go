@@ -0,0 +1,16 @@
+package delivery_rating
+
+import "errors"
+
+var (
+	ErrInvalidOrderID   = errors.New("invalid order id")
+	ErrInvalidCourierID = errors.New("invalid courier id")
+	ErrInvalidAuthor    = errors.New("invalid rating author")
+	ErrInvalidScore     = errors.New("rating score must be from 1 to 5")
+	ErrInvalidTags      = errors.New("invalid rating tags")
+	ErrInvalidComment   = errors.New("rating comment is too long")
+
+	ErrDeliveryNotFound     = errors.New("delivery not found")
+	ErrDeliveryNotCompleted = errors.New("delivery is not completed")
+	ErrAlreadyRated         = errors.New("delivery already rated by this author")
+)
@@ -0,0 +1,54 @@
+package delivery_rating
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"service/internal/entities"
+)
+
+const (
+	maxTags          = 10
+	maxTagLength     = 32
+	maxCommentLength = 1000
+)
+
+// normalizeTags теги приводятся к нижнему регистру без пробелов по краям, повторы убираются
+func normalizeTags(tags []string) ([]string, error) {
+	if len(tags) > maxTags {
+		return nil, ErrInvalidTags
+	}
+
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, ErrInvalidTags
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+
+	return normalized, nil
+}
+
+func validateRatingParams(params entities.DeliveryRatingParams) error {
+	if strings.TrimSpace(params.OrderID) == "" {
+		return ErrInvalidOrderID
+	}
+	if !params.Author.IsValid() {
+		return ErrInvalidAuthor
+	}
+	if params.Score < entities.MinRatingScore || params.Score > entities.MaxRatingScore {
+		return ErrInvalidScore
+	}
+	if params.Comment != nil && utf8.RuneCountInString(*params.Comment) > maxCommentLength {
+		return ErrInvalidComment
+	}
+
+	return nil
+}
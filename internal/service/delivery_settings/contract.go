@@ -0,0 +1,18 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=delivery_settings_test
+package delivery_settings
+
+import (
+	"context"
+
+	"service/internal/entities"
+)
+
+type Repository interface {
+	GetDeadlineSettings(ctx context.Context) (*entities.DeadlineSettings, error)
+	ReplaceTransportSpeeds(ctx context.Context, transportSpeeds []entities.TransportSpeed) error
+	ReplacePeakHours(ctx context.Context, peakHours []entities.PeakHour) error
+}
+
+type TxManager interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contract.go -destination=./contract_mocks_test.go -package=delivery_settings_test
+//
+
+// Package delivery_settings_test is a generated GoMock package.
+package delivery_settings_test
+
+import (
+	context "context"
+	reflect "reflect"
+	entities "service/internal/entities"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetDeadlineSettings mocks base method.
+func (m *MockRepository) GetDeadlineSettings(ctx context.Context) (*entities.DeadlineSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadlineSettings", ctx)
+	ret0, _ := ret[0].(*entities.DeadlineSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadlineSettings indicates an expected call of GetDeadlineSettings.
+func (mr *MockRepositoryMockRecorder) GetDeadlineSettings(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadlineSettings", reflect.TypeOf((*MockRepository)(nil).GetDeadlineSettings), ctx)
+}
+
+// ReplacePeakHours mocks base method.
+func (m *MockRepository) ReplacePeakHours(ctx context.Context, peakHours []entities.PeakHour) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplacePeakHours", ctx, peakHours)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplacePeakHours indicates an expected call of ReplacePeakHours.
+func (mr *MockRepositoryMockRecorder) ReplacePeakHours(ctx, peakHours any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplacePeakHours", reflect.TypeOf((*MockRepository)(nil).ReplacePeakHours), ctx, peakHours)
+}
+
+// ReplaceTransportSpeeds mocks base method.
+func (m *MockRepository) ReplaceTransportSpeeds(ctx context.Context, transportSpeeds []entities.TransportSpeed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTransportSpeeds", ctx, transportSpeeds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTransportSpeeds indicates an expected call of ReplaceTransportSpeeds.
+func (mr *MockRepositoryMockRecorder) ReplaceTransportSpeeds(ctx, transportSpeeds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTransportSpeeds", reflect.TypeOf((*MockRepository)(nil).ReplaceTransportSpeeds), ctx, transportSpeeds)
+}
+
+// MockTxManager is a mock of TxManager interface.
+type MockTxManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockTxManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockTxManagerMockRecorder is the mock recorder for MockTxManager.
+type MockTxManagerMockRecorder struct {
+	mock *MockTxManager
+}
+
+// NewMockTxManager creates a new mock instance.
+func NewMockTxManager(ctrl *gomock.Controller) *MockTxManager {
+	mock := &MockTxManager{ctrl: ctrl}
+	mock.recorder = &MockTxManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTxManager) EXPECT() *MockTxManagerMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockTxManager) Do(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockTxManagerMockRecorder) Do(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockTxManager)(nil).Do), ctx, fn)
+}
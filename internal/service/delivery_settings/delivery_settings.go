@@ -0,0 +1,93 @@
+package delivery_settings
+
+import (
+	"context"
+	"fmt"
+
+	"service/internal/entities"
+)
+
+type DeliverySettings struct {
+	repository Repository
+	txManager  TxManager
+}
+
+func New(repository Repository, txManager TxManager) *DeliverySettings {
+	return &DeliverySettings{
+		repository: repository,
+		txManager:  txManager,
+	}
+}
+
+func (s *DeliverySettings) GetDeadlineSettings(ctx context.Context) (*entities.DeadlineSettings, error) {
+	settings, err := s.repository.GetDeadlineSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get deadline settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// UpdateDeadlineSettings полностью заменяет настройки: типы транспорта, которых нет в запросе,
+// считаются по значениям по умолчанию
+func (s *DeliverySettings) UpdateDeadlineSettings(ctx context.Context, settings entities.DeadlineSettings) (*entities.DeadlineSettings, error) {
+	err := validateDeadlineSettings(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedSettings *entities.DeadlineSettings
+	err = s.txManager.Do(ctx, func(ctx context.Context) error {
+		err := s.repository.ReplaceTransportSpeeds(ctx, settings.TransportSpeeds)
+		if err != nil {
+			return fmt.Errorf("replace transport speeds: %w", err)
+		}
+
+		err = s.repository.ReplacePeakHours(ctx, settings.PeakHours)
+		if err != nil {
+			return fmt.Errorf("replace peak hours: %w", err)
+		}
+
+		updatedSettings, err = s.repository.GetDeadlineSettings(ctx)
+		if err != nil {
+			return fmt.Errorf("get deadline settings: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedSettings, nil
+}
+
+func validateDeadlineSettings(settings entities.DeadlineSettings) error {
+	seen := make(map[entities.CourierTransportType]struct{}, len(settings.TransportSpeeds))
+	for _, transportSpeed := range settings.TransportSpeeds {
+		if !isValidTransport(transportSpeed.TransportType) {
+			return ErrInvalidTransport
+		}
+		if _, ok := seen[transportSpeed.TransportType]; ok {
+			return ErrDuplicateTransport
+		}
+		seen[transportSpeed.TransportType] = struct{}{}
+
+		if !isValidSpeed(transportSpeed.AverageSpeedKmh) {
+			return ErrInvalidSpeed
+		}
+		if !isValidHandlingOverhead(transportSpeed.HandlingOverhead) {
+			return ErrInvalidHandlingOverhead
+		}
+	}
+
+	for _, peakHour := range settings.PeakHours {
+		if !isValidPeakHours(peakHour.StartHour, peakHour.EndHour) {
+			return ErrInvalidPeakHours
+		}
+		if !isValidPeakMultiplier(peakHour.Multiplier) {
+			return ErrInvalidPeakMultiplier
+		}
+	}
+
+	return nil
+}
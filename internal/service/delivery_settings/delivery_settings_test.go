@@ -0,0 +1,197 @@
+package delivery_settings_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/service/delivery_settings"
+)
+
+type mock struct {
+	*MockRepository
+	*MockTxManager
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockRepository: NewMockRepository(ctrl),
+		MockTxManager:  NewMockTxManager(ctrl),
+	}
+}
+
+func errorAssertion(expectedError error, expectedErrMsg string) require.ErrorAssertionFunc {
+	return func(t require.TestingT, err error, msgAndArgs ...interface{}) {
+		require.Error(t, err, msgAndArgs...)
+
+		if expectedError != nil {
+			assert.ErrorIs(t, err, expectedError, msgAndArgs...)
+		}
+
+		if expectedErrMsg != "" {
+			assert.Contains(t, err.Error(), expectedErrMsg, msgAndArgs...)
+		}
+	}
+}
+
+func TestDeliverySettingsService_UpdateDeadlineSettings(t *testing.T) {
+	t.Parallel()
+
+	validSettings := entities.DeadlineSettings{
+		TransportSpeeds: []entities.TransportSpeed{
+			{TransportType: entities.Car, AverageSpeedKmh: 30, HandlingOverhead: 2 * time.Minute},
+			{TransportType: entities.OnFoot, AverageSpeedKmh: 5, HandlingOverhead: 5 * time.Minute},
+		},
+		PeakHours: []entities.PeakHour{
+			{StartHour: 17, EndHour: 20, Multiplier: 1.5},
+		},
+	}
+
+	txPassThrough := func(m *mock) {
+		m.MockTxManager.EXPECT().
+			Do(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+
+	tests := []struct {
+		name           string
+		settings       entities.DeadlineSettings
+		mockSetup      func(m *mock)
+		expectedResult *entities.DeadlineSettings
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:     "Успешное обновление настроек",
+			settings: validSettings,
+			mockSetup: func(m *mock) {
+				txPassThrough(m)
+				m.MockRepository.EXPECT().
+					ReplaceTransportSpeeds(gomock.Any(), validSettings.TransportSpeeds).
+					Return(nil)
+				m.MockRepository.EXPECT().
+					ReplacePeakHours(gomock.Any(), validSettings.PeakHours).
+					Return(nil)
+				m.MockRepository.EXPECT().
+					GetDeadlineSettings(gomock.Any()).
+					Return(&validSettings, nil)
+			},
+			expectedResult: &validSettings,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Отклонение неизвестного типа транспорта",
+			settings: entities.DeadlineSettings{
+				TransportSpeeds: []entities.TransportSpeed{
+					{TransportType: "bicycle", AverageSpeedKmh: 12},
+				},
+			},
+			errorAssertion: errorAssertion(delivery_settings.ErrInvalidTransport, ""),
+		},
+		{
+			name: "Отклонение повторяющегося типа транспорта",
+			settings: entities.DeadlineSettings{
+				TransportSpeeds: []entities.TransportSpeed{
+					{TransportType: entities.Car, AverageSpeedKmh: 30},
+					{TransportType: entities.Car, AverageSpeedKmh: 40},
+				},
+			},
+			errorAssertion: errorAssertion(delivery_settings.ErrDuplicateTransport, ""),
+		},
+		{
+			name: "Отклонение нулевой скорости",
+			settings: entities.DeadlineSettings{
+				TransportSpeeds: []entities.TransportSpeed{
+					{TransportType: entities.Car, AverageSpeedKmh: 0},
+				},
+			},
+			errorAssertion: errorAssertion(delivery_settings.ErrInvalidSpeed, ""),
+		},
+		{
+			name: "Отклонение отрицательного времени на передачу заказа",
+			settings: entities.DeadlineSettings{
+				TransportSpeeds: []entities.TransportSpeed{
+					{TransportType: entities.Car, AverageSpeedKmh: 30, HandlingOverhead: -time.Minute},
+				},
+			},
+			errorAssertion: errorAssertion(delivery_settings.ErrInvalidHandlingOverhead, ""),
+		},
+		{
+			name: "Отклонение пустого интервала часов пик",
+			settings: entities.DeadlineSettings{
+				PeakHours: []entities.PeakHour{
+					{StartHour: 20, EndHour: 17, Multiplier: 1.5},
+				},
+			},
+			errorAssertion: errorAssertion(delivery_settings.ErrInvalidPeakHours, ""),
+		},
+		{
+			name: "Отклонение множителя меньше единицы",
+			settings: entities.DeadlineSettings{
+				PeakHours: []entities.PeakHour{
+					{StartHour: 17, EndHour: 20, Multiplier: 0.5},
+				},
+			},
+			errorAssertion: errorAssertion(delivery_settings.ErrInvalidPeakMultiplier, ""),
+		},
+		{
+			name:     "Ошибка репозитория при замене скоростей",
+			settings: validSettings,
+			mockSetup: func(m *mock) {
+				txPassThrough(m)
+				m.MockRepository.EXPECT().
+					ReplaceTransportSpeeds(gomock.Any(), validSettings.TransportSpeeds).
+					Return(errors.New("database connection lost"))
+			},
+			errorAssertion: errorAssertion(nil, "replace transport speeds: database connection lost"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := delivery_settings.New(m.MockRepository, m.MockTxManager)
+
+			result, err := service.UpdateDeadlineSettings(context.Background(), tt.settings)
+
+			tt.errorAssertion(t, err, tt.name)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestDeliverySettingsService_GetDeadlineSettings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Ошибка репозитория при получении настроек", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		m := newMock(ctrl)
+
+		m.MockRepository.EXPECT().
+			GetDeadlineSettings(gomock.Any()).
+			Return(nil, errors.New("database connection lost"))
+
+		service := delivery_settings.New(m.MockRepository, m.MockTxManager)
+
+		result, err := service.GetDeadlineSettings(context.Background())
+
+		errorAssertion(nil, "get deadline settings: database connection lost")(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,12 @@
+package delivery_settings
+
+import "errors"
+
+var (
+	ErrInvalidTransport        = errors.New("invalid transport type")
+	ErrDuplicateTransport      = errors.New("duplicate transport type")
+	ErrInvalidSpeed            = errors.New("invalid average speed")
+	ErrInvalidHandlingOverhead = errors.New("invalid handling overhead")
+	ErrInvalidPeakHours        = errors.New("invalid peak hours interval")
+	ErrInvalidPeakMultiplier   = errors.New("invalid peak multiplier")
+)
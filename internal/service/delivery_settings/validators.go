@@ -0,0 +1,32 @@
+package delivery_settings
+
+import (
+	"time"
+
+	"service/internal/entities"
+)
+
+func isValidTransport(transportType entities.CourierTransportType) bool {
+	switch transportType {
+	case entities.OnFoot, entities.Scooter, entities.Car:
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidSpeed(speedKmh float64) bool {
+	return speedKmh > 0
+}
+
+func isValidHandlingOverhead(overhead time.Duration) bool {
+	return overhead >= 0
+}
+
+func isValidPeakHours(startHour, endHour int) bool {
+	return startHour >= 0 && endHour <= 24 && startHour < endHour
+}
+
+func isValidPeakMultiplier(multiplier float64) bool {
+	return multiplier >= 1
+}
@@ -0,0 +1,36 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=earnings_test
+package earnings
+
+import (
+	"context"
+	"time"
+
+	"service/internal/entities"
+)
+
+type Repository interface {
+	GetTariffs(ctx context.Context) ([]entities.CourierTariff, error)
+	ReplaceTariffs(ctx context.Context, tariffs []entities.CourierTariff) error
+	FindTariff(ctx context.Context, transportType entities.CourierTransportType, zoneIDs []int64) (*entities.CourierTariff, error)
+	GetEarningDelivery(ctx context.Context, orderID string) (*entities.EarningDelivery, error)
+	// CreateEntry false, если начисление за заказ уже есть
+	CreateEntry(ctx context.Context, entry entities.EarningEntry) (bool, error)
+	CourierExists(ctx context.Context, courierID int64) (bool, error)
+	GetEntries(ctx context.Context, courierID int64, from, to time.Time) ([]entities.EarningEntry, error)
+	LockPayouts(ctx context.Context) error
+	GetLastPayoutPeriod(ctx context.Context) (*entities.PayoutPeriod, error)
+	CreatePayoutPeriod(ctx context.Context, period entities.PayoutPeriod) (*entities.PayoutPeriod, error)
+	AssignEntriesToPeriod(ctx context.Context, periodID int64, periodEnd time.Time) ([]entities.EarningEntry, error)
+}
+
+type SettingsRepository interface {
+	GetDeadlineSettings(ctx context.Context) (*entities.DeadlineSettings, error)
+}
+
+type ZoneResolver interface {
+	FindZoneIDsByPoint(ctx context.Context, point entities.Location) ([]int64, error)
+}
+
+type TxManager interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,322 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contract.go -destination=./contract_mocks_test.go -package=earnings_test
+//
+
+// Package earnings_test is a generated GoMock package.
+package earnings_test
+
+import (
+	context "context"
+	reflect "reflect"
+	entities "service/internal/entities"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AssignEntriesToPeriod mocks base method.
+func (m *MockRepository) AssignEntriesToPeriod(ctx context.Context, periodID int64, periodEnd time.Time) ([]entities.EarningEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignEntriesToPeriod", ctx, periodID, periodEnd)
+	ret0, _ := ret[0].([]entities.EarningEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignEntriesToPeriod indicates an expected call of AssignEntriesToPeriod.
+func (mr *MockRepositoryMockRecorder) AssignEntriesToPeriod(ctx, periodID, periodEnd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignEntriesToPeriod", reflect.TypeOf((*MockRepository)(nil).AssignEntriesToPeriod), ctx, periodID, periodEnd)
+}
+
+// CourierExists mocks base method.
+func (m *MockRepository) CourierExists(ctx context.Context, courierID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CourierExists", ctx, courierID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CourierExists indicates an expected call of CourierExists.
+func (mr *MockRepositoryMockRecorder) CourierExists(ctx, courierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CourierExists", reflect.TypeOf((*MockRepository)(nil).CourierExists), ctx, courierID)
+}
+
+// CreateEntry mocks base method.
+func (m *MockRepository) CreateEntry(ctx context.Context, entry entities.EarningEntry) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEntry", ctx, entry)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEntry indicates an expected call of CreateEntry.
+func (mr *MockRepositoryMockRecorder) CreateEntry(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockRepository)(nil).CreateEntry), ctx, entry)
+}
+
+// CreatePayoutPeriod mocks base method.
+func (m *MockRepository) CreatePayoutPeriod(ctx context.Context, period entities.PayoutPeriod) (*entities.PayoutPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayoutPeriod", ctx, period)
+	ret0, _ := ret[0].(*entities.PayoutPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayoutPeriod indicates an expected call of CreatePayoutPeriod.
+func (mr *MockRepositoryMockRecorder) CreatePayoutPeriod(ctx, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayoutPeriod", reflect.TypeOf((*MockRepository)(nil).CreatePayoutPeriod), ctx, period)
+}
+
+// FindTariff mocks base method.
+func (m *MockRepository) FindTariff(ctx context.Context, transportType entities.CourierTransportType, zoneIDs []int64) (*entities.CourierTariff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTariff", ctx, transportType, zoneIDs)
+	ret0, _ := ret[0].(*entities.CourierTariff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTariff indicates an expected call of FindTariff.
+func (mr *MockRepositoryMockRecorder) FindTariff(ctx, transportType, zoneIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTariff", reflect.TypeOf((*MockRepository)(nil).FindTariff), ctx, transportType, zoneIDs)
+}
+
+// GetEarningDelivery mocks base method.
+func (m *MockRepository) GetEarningDelivery(ctx context.Context, orderID string) (*entities.EarningDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEarningDelivery", ctx, orderID)
+	ret0, _ := ret[0].(*entities.EarningDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEarningDelivery indicates an expected call of GetEarningDelivery.
+func (mr *MockRepositoryMockRecorder) GetEarningDelivery(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEarningDelivery", reflect.TypeOf((*MockRepository)(nil).GetEarningDelivery), ctx, orderID)
+}
+
+// GetEntries mocks base method.
+func (m *MockRepository) GetEntries(ctx context.Context, courierID int64, from, to time.Time) ([]entities.EarningEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntries", ctx, courierID, from, to)
+	ret0, _ := ret[0].([]entities.EarningEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntries indicates an expected call of GetEntries.
+func (mr *MockRepositoryMockRecorder) GetEntries(ctx, courierID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntries", reflect.TypeOf((*MockRepository)(nil).GetEntries), ctx, courierID, from, to)
+}
+
+// GetLastPayoutPeriod mocks base method.
+func (m *MockRepository) GetLastPayoutPeriod(ctx context.Context) (*entities.PayoutPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastPayoutPeriod", ctx)
+	ret0, _ := ret[0].(*entities.PayoutPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastPayoutPeriod indicates an expected call of GetLastPayoutPeriod.
+func (mr *MockRepositoryMockRecorder) GetLastPayoutPeriod(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastPayoutPeriod", reflect.TypeOf((*MockRepository)(nil).GetLastPayoutPeriod), ctx)
+}
+
+// GetTariffs mocks base method.
+func (m *MockRepository) GetTariffs(ctx context.Context) ([]entities.CourierTariff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTariffs", ctx)
+	ret0, _ := ret[0].([]entities.CourierTariff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTariffs indicates an expected call of GetTariffs.
+func (mr *MockRepositoryMockRecorder) GetTariffs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTariffs", reflect.TypeOf((*MockRepository)(nil).GetTariffs), ctx)
+}
+
+// LockPayouts mocks base method.
+func (m *MockRepository) LockPayouts(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockPayouts", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockPayouts indicates an expected call of LockPayouts.
+func (mr *MockRepositoryMockRecorder) LockPayouts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockPayouts", reflect.TypeOf((*MockRepository)(nil).LockPayouts), ctx)
+}
+
+// ReplaceTariffs mocks base method.
+func (m *MockRepository) ReplaceTariffs(ctx context.Context, tariffs []entities.CourierTariff) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTariffs", ctx, tariffs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTariffs indicates an expected call of ReplaceTariffs.
+func (mr *MockRepositoryMockRecorder) ReplaceTariffs(ctx, tariffs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTariffs", reflect.TypeOf((*MockRepository)(nil).ReplaceTariffs), ctx, tariffs)
+}
+
+// MockSettingsRepository is a mock of SettingsRepository interface.
+type MockSettingsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettingsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSettingsRepositoryMockRecorder is the mock recorder for MockSettingsRepository.
+type MockSettingsRepositoryMockRecorder struct {
+	mock *MockSettingsRepository
+}
+
+// NewMockSettingsRepository creates a new mock instance.
+func NewMockSettingsRepository(ctrl *gomock.Controller) *MockSettingsRepository {
+	mock := &MockSettingsRepository{ctrl: ctrl}
+	mock.recorder = &MockSettingsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSettingsRepository) EXPECT() *MockSettingsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetDeadlineSettings mocks base method.
+func (m *MockSettingsRepository) GetDeadlineSettings(ctx context.Context) (*entities.DeadlineSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadlineSettings", ctx)
+	ret0, _ := ret[0].(*entities.DeadlineSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadlineSettings indicates an expected call of GetDeadlineSettings.
+func (mr *MockSettingsRepositoryMockRecorder) GetDeadlineSettings(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadlineSettings", reflect.TypeOf((*MockSettingsRepository)(nil).GetDeadlineSettings), ctx)
+}
+
+// MockZoneResolver is a mock of ZoneResolver interface.
+type MockZoneResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockZoneResolverMockRecorder
+	isgomock struct{}
+}
+
+// MockZoneResolverMockRecorder is the mock recorder for MockZoneResolver.
+type MockZoneResolverMockRecorder struct {
+	mock *MockZoneResolver
+}
+
+// NewMockZoneResolver creates a new mock instance.
+func NewMockZoneResolver(ctrl *gomock.Controller) *MockZoneResolver {
+	mock := &MockZoneResolver{ctrl: ctrl}
+	mock.recorder = &MockZoneResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockZoneResolver) EXPECT() *MockZoneResolverMockRecorder {
+	return m.recorder
+}
+
+// FindZoneIDsByPoint mocks base method.
+func (m *MockZoneResolver) FindZoneIDsByPoint(ctx context.Context, point entities.Location) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindZoneIDsByPoint", ctx, point)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindZoneIDsByPoint indicates an expected call of FindZoneIDsByPoint.
+func (mr *MockZoneResolverMockRecorder) FindZoneIDsByPoint(ctx, point any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindZoneIDsByPoint", reflect.TypeOf((*MockZoneResolver)(nil).FindZoneIDsByPoint), ctx, point)
+}
+
+// MockTxManager is a mock of TxManager interface.
+type MockTxManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockTxManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockTxManagerMockRecorder is the mock recorder for MockTxManager.
+type MockTxManagerMockRecorder struct {
+	mock *MockTxManager
+}
+
+// NewMockTxManager creates a new mock instance.
+func NewMockTxManager(ctrl *gomock.Controller) *MockTxManager {
+	mock := &MockTxManager{ctrl: ctrl}
+	mock.recorder = &MockTxManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTxManager) EXPECT() *MockTxManagerMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockTxManager) Do(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockTxManagerMockRecorder) Do(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockTxManager)(nil).Do), ctx, fn)
+}
@@ -0,0 +1,280 @@
+package earnings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"service/internal/entities"
+	"service/pkg/geo"
+)
+
+type Earnings struct {
+	repository         Repository
+	settingsRepository SettingsRepository
+	zones              ZoneResolver
+	txManager          TxManager
+}
+
+func New(
+	repository Repository,
+	settingsRepository SettingsRepository,
+	zones ZoneResolver,
+	txManager TxManager,
+) *Earnings {
+	return &Earnings{
+		repository:         repository,
+		settingsRepository: settingsRepository,
+		zones:              zones,
+		txManager:          txManager,
+	}
+}
+
+// RecordEarning начисляет курьеру оплату за выполненную доставку по тарифу его транспорта в зоне
+// точки забора. Повторное выполнение заказа начисление не меняет. Если тарифа нет, доставка
+// остается без начисления: это видно по метрике, ошибка не откатывает выполнение заказа
+func (e *Earnings) RecordEarning(ctx context.Context, orderID string) error {
+	if orderID == "" {
+		return ErrInvalidOrderID
+	}
+
+	earningDelivery, err := e.repository.GetEarningDelivery(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("get earning delivery: %w", err)
+	}
+
+	var zoneIDs []int64
+	if earningDelivery.Route != nil {
+		zoneIDs, err = e.zones.FindZoneIDsByPoint(ctx, earningDelivery.Route.Pickup)
+		if err != nil {
+			return fmt.Errorf("find pickup zones: %w", err)
+		}
+	}
+
+	tariff, err := e.repository.FindTariff(ctx, earningDelivery.TransportType, zoneIDs)
+	if err != nil {
+		if errors.Is(err, ErrTariffNotFound) {
+			CourierEarningsMissingTariffTotal.WithLabelValues(earningDelivery.TransportType.String()).Inc()
+			return nil
+		}
+		return fmt.Errorf("find tariff: %w", err)
+	}
+
+	settings, err := e.settingsRepository.GetDeadlineSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("get deadline settings: %w", err)
+	}
+
+	entry := calculateEntry(*earningDelivery, *tariff, settings.PeakHours)
+
+	created, err := e.repository.CreateEntry(ctx, entry)
+	if err != nil {
+		return fmt.Errorf("create earning entry: %w", err)
+	}
+	if !created {
+		return nil
+	}
+
+	CourierEarningsTotal.WithLabelValues(entry.TransportType.String()).Add(float64(entry.Total))
+	return nil
+}
+
+// GetCourierEarnings начисления курьеру за доставки, выполненные в [from, to)
+func (e *Earnings) GetCourierEarnings(ctx context.Context, courierID int64, from, to time.Time) (*entities.CourierEarnings, error) {
+	if courierID <= 0 {
+		return nil, ErrInvalidCourierID
+	}
+	if !from.Before(to) {
+		return nil, ErrInvalidPeriod
+	}
+
+	exists, err := e.repository.CourierExists(ctx, courierID)
+	if err != nil {
+		return nil, fmt.Errorf("check courier exists: %w", err)
+	}
+	if !exists {
+		return nil, ErrCourierNotFound
+	}
+
+	entries, err := e.repository.GetEntries(ctx, courierID, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("get earning entries: %w", err)
+	}
+
+	return &entities.CourierEarnings{
+		CourierID: courierID,
+		From:      from.UTC(),
+		To:        to.UTC(),
+		Total:     sumEntries(entries),
+		Entries:   entries,
+	}, nil
+}
+
+func (e *Earnings) GetTariffs(ctx context.Context) ([]entities.CourierTariff, error) {
+	tariffs, err := e.repository.GetTariffs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get tariffs: %w", err)
+	}
+
+	return tariffs, nil
+}
+
+// UpdateTariffs полностью заменяет тарифы. Уже начисленная оплата не пересчитывается
+func (e *Earnings) UpdateTariffs(ctx context.Context, tariffs []entities.CourierTariff) ([]entities.CourierTariff, error) {
+	err := validateTariffs(tariffs)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedTariffs []entities.CourierTariff
+	err = e.txManager.Do(ctx, func(ctx context.Context) error {
+		err := e.repository.ReplaceTariffs(ctx, tariffs)
+		if err != nil {
+			return fmt.Errorf("replace tariffs: %w", err)
+		}
+
+		updatedTariffs, err = e.repository.GetTariffs(ctx)
+		if err != nil {
+			return fmt.Errorf("get tariffs: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedTariffs, nil
+}
+
+// ClosePayoutPeriod закрывает расчетный период с конца предыдущего по end: в ведомость попадают
+// все еще не выплаченные начисления за доставки, выполненные до end, в том числе начисленные
+// после закрытия предыдущего периода за более ранние доставки
+func (e *Earnings) ClosePayoutPeriod(ctx context.Context, end time.Time) (*entities.PayoutStatement, error) {
+	end = end.UTC()
+	if end.After(time.Now().UTC()) {
+		return nil, ErrInvalidPeriodEnd
+	}
+
+	var statement *entities.PayoutStatement
+	err := e.txManager.Do(ctx, func(ctx context.Context) error {
+		err := e.repository.LockPayouts(ctx)
+		if err != nil {
+			return fmt.Errorf("lock payouts: %w", err)
+		}
+
+		var start *time.Time
+		lastPeriod, err := e.repository.GetLastPayoutPeriod(ctx)
+		switch {
+		case errors.Is(err, ErrNoPayoutPeriod):
+		case err != nil:
+			return fmt.Errorf("get last payout period: %w", err)
+		case !end.After(lastPeriod.End):
+			return ErrPeriodAlreadyClosed
+		default:
+			start = &lastPeriod.End
+		}
+
+		period, err := e.repository.CreatePayoutPeriod(ctx, entities.PayoutPeriod{
+			Start:    start,
+			End:      end,
+			ClosedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return fmt.Errorf("create payout period: %w", err)
+		}
+
+		entries, err := e.repository.AssignEntriesToPeriod(ctx, period.ID, period.End)
+		if err != nil {
+			return fmt.Errorf("assign entries to payout period: %w", err)
+		}
+
+		statement = &entities.PayoutStatement{
+			Period:   *period,
+			Couriers: groupPayouts(entries),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return statement, nil
+}
+
+// calculateEntry оплата за расстояние от точки забора до точки доставки округляется до копейки,
+// штраф за опоздание не больше остальной оплаты, чтобы начисление не было отрицательным
+func calculateEntry(earningDelivery entities.EarningDelivery, tariff entities.CourierTariff, peakHours []entities.PeakHour) entities.EarningEntry {
+	entry := entities.EarningEntry{
+		OrderID:       earningDelivery.OrderID,
+		CourierID:     earningDelivery.CourierID,
+		TransportType: earningDelivery.TransportType,
+		ZoneID:        tariff.ZoneID,
+		BaseFee:       tariff.BaseFee,
+		AssignedAt:    earningDelivery.AssignedAt,
+		Deadline:      earningDelivery.Deadline,
+		CompletedAt:   earningDelivery.CompletedAt,
+	}
+
+	if earningDelivery.Route != nil {
+		entry.DistanceKm = geo.DistanceKm(
+			geo.Point{Lat: earningDelivery.Route.Pickup.Latitude, Lon: earningDelivery.Route.Pickup.Longitude},
+			geo.Point{Lat: earningDelivery.Route.Dropoff.Latitude, Lon: earningDelivery.Route.Dropoff.Longitude},
+		)
+		entry.DistanceFee = int64(math.Round(entry.DistanceKm * float64(tariff.PerKmRate)))
+	}
+
+	if isPeakHour(peakHours, earningDelivery.AssignedAt) {
+		entry.PeakBonus = tariff.PeakBonus
+	}
+
+	earned := entry.BaseFee + entry.DistanceFee + entry.PeakBonus
+	if earningDelivery.CompletedAt.After(earningDelivery.Deadline) {
+		entry.LatePenalty = min(tariff.LatePenalty, earned)
+	}
+
+	entry.Total = earned - entry.LatePenalty
+	return entry
+}
+
+// isPeakHour час пик определяется по настройкам дедлайнов, по времени назначения курьера
+func isPeakHour(peakHours []entities.PeakHour, assignedAt time.Time) bool {
+	hour := assignedAt.UTC().Hour()
+	for _, peakHour := range peakHours {
+		if hour >= peakHour.StartHour && hour < peakHour.EndHour {
+			return true
+		}
+	}
+	return false
+}
+
+func sumEntries(entries []entities.EarningEntry) int64 {
+	var total int64
+	for _, entry := range entries {
+		total += entry.Total
+	}
+	return total
+}
+
+func groupPayouts(entries []entities.EarningEntry) []entities.CourierPayout {
+	entriesByCourier := make(map[int64][]entities.EarningEntry)
+	for _, entry := range entries {
+		entriesByCourier[entry.CourierID] = append(entriesByCourier[entry.CourierID], entry)
+	}
+
+	payouts := make([]entities.CourierPayout, 0, len(entriesByCourier))
+	for courierID, courierEntries := range entriesByCourier {
+		payouts = append(payouts, entities.CourierPayout{
+			CourierID: courierID,
+			Total:     sumEntries(courierEntries),
+			Entries:   courierEntries,
+		})
+	}
+
+	sort.Slice(payouts, func(i, j int) bool {
+		return payouts[i].CourierID < payouts[j].CourierID
+	})
+	return payouts
+}
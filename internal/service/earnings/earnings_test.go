@@ -0,0 +1,624 @@
+package earnings_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/service/earnings"
+)
+
+type mock struct {
+	*MockRepository
+	*MockSettingsRepository
+	*MockZoneResolver
+	*MockTxManager
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockRepository:         NewMockRepository(ctrl),
+		MockSettingsRepository: NewMockSettingsRepository(ctrl),
+		MockZoneResolver:       NewMockZoneResolver(ctrl),
+		MockTxManager:          NewMockTxManager(ctrl),
+	}
+}
+
+func newService(m *mock) *earnings.Earnings {
+	return earnings.New(m.MockRepository, m.MockSettingsRepository, m.MockZoneResolver, m.MockTxManager)
+}
+
+func errorAssertion(expectedError error, expectedErrMsg string) require.ErrorAssertionFunc {
+	return func(t require.TestingT, err error, msgAndArgs ...interface{}) {
+		require.Error(t, err, msgAndArgs...)
+
+		if expectedError != nil {
+			assert.ErrorIs(t, err, expectedError, msgAndArgs...)
+		}
+
+		if expectedErrMsg != "" {
+			assert.Contains(t, err.Error(), expectedErrMsg, msgAndArgs...)
+		}
+	}
+}
+
+func runInTx(m *mock) {
+	m.MockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+func TestEarningsService_RecordEarning(t *testing.T) {
+	t.Parallel()
+
+	assignedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	deadline := time.Date(2026, 1, 1, 12, 30, 0, 0, time.UTC)
+
+	// около 5.56 км по меридиану
+	route := &entities.Route{
+		Pickup:  entities.Location{Latitude: 55.70, Longitude: 37.60},
+		Dropoff: entities.Location{Latitude: 55.75, Longitude: 37.60},
+	}
+
+	onTimeDelivery := entities.EarningDelivery{
+		OrderID:       "order-1",
+		CourierID:     7,
+		TransportType: entities.Scooter,
+		Route:         route,
+		AssignedAt:    assignedAt,
+		Deadline:      deadline,
+		CompletedAt:   deadline.Add(-5 * time.Minute),
+	}
+
+	zoneTariff := entities.CourierTariff{
+		TransportType: entities.Scooter,
+		ZoneID:        pointer.To(int64(3)),
+		BaseFee:       12000,
+		PerKmRate:     1000,
+		PeakBonus:     5000,
+		LatePenalty:   4000,
+	}
+
+	settings := &entities.DeadlineSettings{
+		PeakHours: []entities.PeakHour{{StartHour: 11, EndHour: 14, Multiplier: 1.2}},
+	}
+
+	tests := []struct {
+		name           string
+		orderID        string
+		mockSetup      func(m *mock)
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:    "Начисление по тарифу зоны в час пик",
+			orderID: "order-1",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetEarningDelivery(gomock.Any(), "order-1").
+					Return(&onTimeDelivery, nil)
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return([]int64{3}, nil)
+				m.MockRepository.EXPECT().
+					FindTariff(gomock.Any(), entities.Scooter, []int64{3}).
+					Return(&zoneTariff, nil)
+				m.MockSettingsRepository.EXPECT().
+					GetDeadlineSettings(gomock.Any()).
+					Return(settings, nil)
+				m.MockRepository.EXPECT().
+					CreateEntry(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, entry entities.EarningEntry) (bool, error) {
+						assert.Equal(t, "order-1", entry.OrderID)
+						assert.Equal(t, int64(7), entry.CourierID)
+						assert.Equal(t, pointer.To(int64(3)), entry.ZoneID)
+						assert.InDelta(t, 5.56, entry.DistanceKm, 0.01)
+						assert.Equal(t, int64(12000), entry.BaseFee)
+						assert.InDelta(t, 5560, entry.DistanceFee, 10)
+						assert.Equal(t, int64(5000), entry.PeakBonus)
+						assert.Equal(t, int64(0), entry.LatePenalty)
+						assert.Equal(t, entry.BaseFee+entry.DistanceFee+entry.PeakBonus, entry.Total)
+						return true, nil
+					})
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:    "Опоздание без маршрута вне часа пик",
+			orderID: "order-1",
+			mockSetup: func(m *mock) {
+				lateDelivery := onTimeDelivery
+				lateDelivery.Route = nil
+				lateDelivery.AssignedAt = assignedAt.Add(5 * time.Hour)
+				lateDelivery.Deadline = lateDelivery.AssignedAt.Add(30 * time.Minute)
+				lateDelivery.CompletedAt = lateDelivery.Deadline.Add(time.Minute)
+
+				m.MockRepository.EXPECT().
+					GetEarningDelivery(gomock.Any(), "order-1").
+					Return(&lateDelivery, nil)
+				m.MockRepository.EXPECT().
+					FindTariff(gomock.Any(), entities.Scooter, nil).
+					Return(&entities.CourierTariff{TransportType: entities.Scooter, BaseFee: 12000, PerKmRate: 1000, PeakBonus: 5000, LatePenalty: 4000}, nil)
+				m.MockSettingsRepository.EXPECT().
+					GetDeadlineSettings(gomock.Any()).
+					Return(settings, nil)
+				m.MockRepository.EXPECT().
+					CreateEntry(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, entry entities.EarningEntry) (bool, error) {
+						assert.Nil(t, entry.ZoneID)
+						assert.Zero(t, entry.DistanceKm)
+						assert.Zero(t, entry.DistanceFee)
+						assert.Zero(t, entry.PeakBonus)
+						assert.Equal(t, int64(4000), entry.LatePenalty)
+						assert.Equal(t, int64(8000), entry.Total)
+						return true, nil
+					})
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:    "Штраф за опоздание не делает начисление отрицательным",
+			orderID: "order-1",
+			mockSetup: func(m *mock) {
+				lateDelivery := onTimeDelivery
+				lateDelivery.Route = nil
+				lateDelivery.CompletedAt = deadline.Add(time.Minute)
+
+				m.MockRepository.EXPECT().
+					GetEarningDelivery(gomock.Any(), "order-1").
+					Return(&lateDelivery, nil)
+				m.MockRepository.EXPECT().
+					FindTariff(gomock.Any(), entities.Scooter, nil).
+					Return(&entities.CourierTariff{TransportType: entities.Scooter, BaseFee: 3000, LatePenalty: 10000}, nil)
+				m.MockSettingsRepository.EXPECT().
+					GetDeadlineSettings(gomock.Any()).
+					Return(&entities.DeadlineSettings{}, nil)
+				m.MockRepository.EXPECT().
+					CreateEntry(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, entry entities.EarningEntry) (bool, error) {
+						assert.Equal(t, int64(3000), entry.LatePenalty)
+						assert.Zero(t, entry.Total)
+						return true, nil
+					})
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:    "Повторное выполнение заказа не меняет начисление",
+			orderID: "order-1",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetEarningDelivery(gomock.Any(), "order-1").
+					Return(&onTimeDelivery, nil)
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return([]int64{3}, nil)
+				m.MockRepository.EXPECT().
+					FindTariff(gomock.Any(), entities.Scooter, []int64{3}).
+					Return(&zoneTariff, nil)
+				m.MockSettingsRepository.EXPECT().
+					GetDeadlineSettings(gomock.Any()).
+					Return(settings, nil)
+				m.MockRepository.EXPECT().
+					CreateEntry(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:    "Без тарифа доставка остается без начисления",
+			orderID: "order-1",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetEarningDelivery(gomock.Any(), "order-1").
+					Return(&onTimeDelivery, nil)
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return([]int64{}, nil)
+				m.MockRepository.EXPECT().
+					FindTariff(gomock.Any(), entities.Scooter, []int64{}).
+					Return(nil, earnings.ErrTariffNotFound)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:           "Пустой ID заказа",
+			orderID:        "",
+			errorAssertion: errorAssertion(earnings.ErrInvalidOrderID, ""),
+		},
+		{
+			name:    "Выполненная доставка не найдена",
+			orderID: "order-1",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetEarningDelivery(gomock.Any(), "order-1").
+					Return(nil, earnings.ErrDeliveryNotFound)
+			},
+			errorAssertion: errorAssertion(earnings.ErrDeliveryNotFound, "get earning delivery"),
+		},
+		{
+			name:    "Ошибка поиска зон точки забора",
+			orderID: "order-1",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetEarningDelivery(gomock.Any(), "order-1").
+					Return(&onTimeDelivery, nil)
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return(nil, errors.New("database connection timeout"))
+			},
+			errorAssertion: errorAssertion(nil, "find pickup zones: database connection timeout"),
+		},
+		{
+			name:    "Ошибка сохранения начисления",
+			orderID: "order-1",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetEarningDelivery(gomock.Any(), "order-1").
+					Return(&onTimeDelivery, nil)
+				m.MockZoneResolver.EXPECT().
+					FindZoneIDsByPoint(gomock.Any(), route.Pickup).
+					Return([]int64{3}, nil)
+				m.MockRepository.EXPECT().
+					FindTariff(gomock.Any(), entities.Scooter, []int64{3}).
+					Return(&zoneTariff, nil)
+				m.MockSettingsRepository.EXPECT().
+					GetDeadlineSettings(gomock.Any()).
+					Return(settings, nil)
+				m.MockRepository.EXPECT().
+					CreateEntry(gomock.Any(), gomock.Any()).
+					Return(false, errors.New("database connection timeout"))
+			},
+			errorAssertion: errorAssertion(nil, "create earning entry: database connection timeout"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			err := newService(m).RecordEarning(context.Background(), tt.orderID)
+
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
+
+func TestEarningsService_GetCourierEarnings(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	entries := []entities.EarningEntry{
+		{ID: 1, OrderID: "order-1", CourierID: 7, Total: 17000},
+		{ID: 2, OrderID: "order-2", CourierID: 7, Total: 8000},
+	}
+
+	tests := []struct {
+		name           string
+		courierID      int64
+		from           time.Time
+		to             time.Time
+		mockSetup      func(m *mock)
+		expected       *entities.CourierEarnings
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:      "Начисления за период с итогом",
+			courierID: 7,
+			from:      from,
+			to:        to,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					CourierExists(gomock.Any(), int64(7)).
+					Return(true, nil)
+				m.MockRepository.EXPECT().
+					GetEntries(gomock.Any(), int64(7), from, to).
+					Return(entries, nil)
+			},
+			expected: &entities.CourierEarnings{
+				CourierID: 7,
+				From:      from,
+				To:        to,
+				Total:     25000,
+				Entries:   entries,
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:           "Невалидный ID курьера",
+			courierID:      0,
+			from:           from,
+			to:             to,
+			errorAssertion: errorAssertion(earnings.ErrInvalidCourierID, ""),
+		},
+		{
+			name:           "Начало периода совпадает с концом",
+			courierID:      7,
+			from:           from,
+			to:             from,
+			errorAssertion: errorAssertion(earnings.ErrInvalidPeriod, ""),
+		},
+		{
+			name:      "Курьер не найден",
+			courierID: 7,
+			from:      from,
+			to:        to,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					CourierExists(gomock.Any(), int64(7)).
+					Return(false, nil)
+			},
+			errorAssertion: errorAssertion(earnings.ErrCourierNotFound, ""),
+		},
+		{
+			name:      "Ошибка получения начислений",
+			courierID: 7,
+			from:      from,
+			to:        to,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					CourierExists(gomock.Any(), int64(7)).
+					Return(true, nil)
+				m.MockRepository.EXPECT().
+					GetEntries(gomock.Any(), int64(7), from, to).
+					Return(nil, errors.New("database connection timeout"))
+			},
+			errorAssertion: errorAssertion(nil, "get earning entries: database connection timeout"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			result, err := newService(m).GetCourierEarnings(context.Background(), tt.courierID, tt.from, tt.to)
+
+			tt.errorAssertion(t, err, tt.name)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestEarningsService_UpdateTariffs(t *testing.T) {
+	t.Parallel()
+
+	defaultTariffs := []entities.CourierTariff{
+		{TransportType: entities.OnFoot, BaseFee: 10000, PerKmRate: 2000, PeakBonus: 5000, LatePenalty: 5000},
+		{TransportType: entities.Scooter, BaseFee: 12000, PerKmRate: 1500, PeakBonus: 5000, LatePenalty: 5000},
+		{TransportType: entities.Car, BaseFee: 15000, PerKmRate: 1200, PeakBonus: 5000, LatePenalty: 5000},
+	}
+
+	withZone := func(extra ...entities.CourierTariff) []entities.CourierTariff {
+		return append(append([]entities.CourierTariff{}, defaultTariffs...), extra...)
+	}
+
+	tests := []struct {
+		name           string
+		tariffs        []entities.CourierTariff
+		mockSetup      func(m *mock)
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:    "Тарифы транспорта и тариф зоны",
+			tariffs: withZone(entities.CourierTariff{TransportType: entities.Car, ZoneID: pointer.To(int64(2)), BaseFee: 18000}),
+			mockSetup: func(m *mock) {
+				runInTx(m)
+				m.MockRepository.EXPECT().
+					ReplaceTariffs(gomock.Any(), gomock.Len(4)).
+					Return(nil)
+				m.MockRepository.EXPECT().
+					GetTariffs(gomock.Any()).
+					Return(defaultTariffs, nil)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:           "Нет тарифа без зоны для транспорта",
+			tariffs:        defaultTariffs[:2],
+			errorAssertion: errorAssertion(earnings.ErrMissingDefaultTariff, ""),
+		},
+		{
+			name:           "Неизвестный транспорт",
+			tariffs:        withZone(entities.CourierTariff{TransportType: "bicycle"}),
+			errorAssertion: errorAssertion(earnings.ErrInvalidTransport, ""),
+		},
+		{
+			name:           "Повторный тариф транспорта в зоне",
+			tariffs:        withZone(entities.CourierTariff{TransportType: entities.Car, ZoneID: pointer.To(int64(2))}, entities.CourierTariff{TransportType: entities.Car, ZoneID: pointer.To(int64(2))}),
+			errorAssertion: errorAssertion(earnings.ErrDuplicateTariff, ""),
+		},
+		{
+			name:           "Невалидный ID зоны",
+			tariffs:        withZone(entities.CourierTariff{TransportType: entities.Car, ZoneID: pointer.To(int64(0))}),
+			errorAssertion: errorAssertion(earnings.ErrInvalidZoneID, ""),
+		},
+		{
+			name:           "Отрицательный штраф",
+			tariffs:        withZone(entities.CourierTariff{TransportType: entities.Car, ZoneID: pointer.To(int64(2)), LatePenalty: -1}),
+			errorAssertion: errorAssertion(earnings.ErrInvalidTariffFee, ""),
+		},
+		{
+			name:    "Зона тарифа не найдена",
+			tariffs: withZone(entities.CourierTariff{TransportType: entities.Car, ZoneID: pointer.To(int64(99))}),
+			mockSetup: func(m *mock) {
+				runInTx(m)
+				m.MockRepository.EXPECT().
+					ReplaceTariffs(gomock.Any(), gomock.Any()).
+					Return(earnings.ErrZoneNotFound)
+			},
+			errorAssertion: errorAssertion(earnings.ErrZoneNotFound, "replace tariffs"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			_, err := newService(m).UpdateTariffs(context.Background(), tt.tariffs)
+
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
+
+func TestEarningsService_ClosePayoutPeriod(t *testing.T) {
+	t.Parallel()
+
+	lastEnd := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC)
+	closedAt := time.Date(2026, 1, 8, 9, 0, 0, 0, time.UTC)
+
+	entries := []entities.EarningEntry{
+		{ID: 1, OrderID: "order-1", CourierID: 3, Total: 17000},
+		{ID: 2, OrderID: "order-2", CourierID: 9, Total: 8000},
+		{ID: 3, OrderID: "order-3", CourierID: 3, Total: 12000},
+	}
+
+	tests := []struct {
+		name           string
+		end            time.Time
+		mockSetup      func(m *mock)
+		expected       *entities.PayoutStatement
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Период начинается с конца предыдущего, ведомость по курьерам",
+			end:  end,
+			mockSetup: func(m *mock) {
+				runInTx(m)
+				m.MockRepository.EXPECT().
+					LockPayouts(gomock.Any()).
+					Return(nil)
+				m.MockRepository.EXPECT().
+					GetLastPayoutPeriod(gomock.Any()).
+					Return(&entities.PayoutPeriod{ID: 1, End: lastEnd}, nil)
+				m.MockRepository.EXPECT().
+					CreatePayoutPeriod(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, period entities.PayoutPeriod) (*entities.PayoutPeriod, error) {
+						assert.Equal(t, &lastEnd, period.Start)
+						assert.Equal(t, end, period.End)
+						return &entities.PayoutPeriod{ID: 2, Start: period.Start, End: period.End, ClosedAt: closedAt}, nil
+					})
+				m.MockRepository.EXPECT().
+					AssignEntriesToPeriod(gomock.Any(), int64(2), end).
+					Return(entries, nil)
+			},
+			expected: &entities.PayoutStatement{
+				Period: entities.PayoutPeriod{ID: 2, Start: &lastEnd, End: end, ClosedAt: closedAt},
+				Couriers: []entities.CourierPayout{
+					{CourierID: 3, Total: 29000, Entries: []entities.EarningEntry{entries[0], entries[2]}},
+					{CourierID: 9, Total: 8000, Entries: []entities.EarningEntry{entries[1]}},
+				},
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Первый период без начала",
+			end:  end,
+			mockSetup: func(m *mock) {
+				runInTx(m)
+				m.MockRepository.EXPECT().
+					LockPayouts(gomock.Any()).
+					Return(nil)
+				m.MockRepository.EXPECT().
+					GetLastPayoutPeriod(gomock.Any()).
+					Return(nil, earnings.ErrNoPayoutPeriod)
+				m.MockRepository.EXPECT().
+					CreatePayoutPeriod(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, period entities.PayoutPeriod) (*entities.PayoutPeriod, error) {
+						assert.Nil(t, period.Start)
+						return &entities.PayoutPeriod{ID: 1, End: period.End, ClosedAt: closedAt}, nil
+					})
+				m.MockRepository.EXPECT().
+					AssignEntriesToPeriod(gomock.Any(), int64(1), end).
+					Return([]entities.EarningEntry{}, nil)
+			},
+			expected: &entities.PayoutStatement{
+				Period:   entities.PayoutPeriod{ID: 1, End: end, ClosedAt: closedAt},
+				Couriers: []entities.CourierPayout{},
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:           "Конец периода в будущем",
+			end:            time.Now().UTC().Add(time.Hour),
+			errorAssertion: errorAssertion(earnings.ErrInvalidPeriodEnd, ""),
+		},
+		{
+			name: "Конец периода не позже уже закрытого",
+			end:  lastEnd,
+			mockSetup: func(m *mock) {
+				runInTx(m)
+				m.MockRepository.EXPECT().
+					LockPayouts(gomock.Any()).
+					Return(nil)
+				m.MockRepository.EXPECT().
+					GetLastPayoutPeriod(gomock.Any()).
+					Return(&entities.PayoutPeriod{ID: 1, End: lastEnd}, nil)
+			},
+			errorAssertion: errorAssertion(earnings.ErrPeriodAlreadyClosed, ""),
+		},
+		{
+			name: "Ошибка получения последнего периода",
+			end:  end,
+			mockSetup: func(m *mock) {
+				runInTx(m)
+				m.MockRepository.EXPECT().
+					LockPayouts(gomock.Any()).
+					Return(nil)
+				m.MockRepository.EXPECT().
+					GetLastPayoutPeriod(gomock.Any()).
+					Return(nil, errors.New("database connection timeout"))
+			},
+			errorAssertion: errorAssertion(nil, "get last payout period: database connection timeout"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			result, err := newService(m).ClosePayoutPeriod(context.Background(), tt.end)
+
+			tt.errorAssertion(t, err, tt.name)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package earnings
+
+import "errors"
+
+var (
+	ErrInvalidOrderID       = errors.New("invalid order id")
+	ErrInvalidCourierID     = errors.New("invalid courier id")
+	ErrInvalidPeriod        = errors.New("period start must be before its end")
+	ErrInvalidTransport     = errors.New("invalid transport type")
+	ErrInvalidZoneID        = errors.New("invalid zone id")
+	ErrDuplicateTariff      = errors.New("duplicate tariff for transport type and zone")
+	ErrInvalidTariffFee     = errors.New("tariff fees must not be negative")
+	ErrMissingDefaultTariff = errors.New("every transport type must have a tariff without zone")
+	ErrInvalidPeriodEnd     = errors.New("payout period end must not be in the future")
+	ErrPeriodAlreadyClosed  = errors.New("payout period end must be after the last closed period end")
+
+	ErrTariffNotFound   = errors.New("tariff not found")
+	ErrDeliveryNotFound = errors.New("completed delivery not found")
+	ErrCourierNotFound  = errors.New("courier not found")
+	ErrZoneNotFound     = errors.New("zone not found")
+	ErrNoPayoutPeriod   = errors.New("no closed payout period")
+)
@@ -0,0 +1,27 @@
+package earnings
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// CourierEarningsTotal начисления за выполненные доставки в копейках по типу транспорта
+	CourierEarningsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "courier_earnings_kopecks_total",
+			Help: "Total amount earned by couriers for completed deliveries in kopecks by transport type",
+		},
+		[]string{"transport_type"},
+	)
+
+	// CourierEarningsMissingTariffTotal доставка выполнена, но для транспорта курьера нет тарифа:
+	// начисление не создано, его нужно сделать вручную
+	CourierEarningsMissingTariffTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "courier_earnings_missing_tariff_total",
+			Help: "Total number of completed deliveries left without earnings because no tariff matched",
+		},
+		[]string{"transport_type"},
+	)
+)
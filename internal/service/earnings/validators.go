@@ -0,0 +1,54 @@
+package earnings
+
+import "service/internal/entities"
+
+var transportTypes = []entities.CourierTransportType{entities.OnFoot, entities.Scooter, entities.Car}
+
+func isValidTransport(transportType entities.CourierTransportType) bool {
+	for _, t := range transportTypes {
+		if t == transportType {
+			return true
+		}
+	}
+	return false
+}
+
+type tariffKey struct {
+	transportType entities.CourierTransportType
+	zoneID        int64
+}
+
+// validateTariffs у каждого транспорта должен быть тариф без зоны, иначе доставка вне зон
+// с собственным тарифом останется без начисления
+func validateTariffs(tariffs []entities.CourierTariff) error {
+	seen := make(map[tariffKey]struct{}, len(tariffs))
+	for _, tariff := range tariffs {
+		if !isValidTransport(tariff.TransportType) {
+			return ErrInvalidTransport
+		}
+		// тариф без зоны хранится под нулевым ключом, ID зон начинаются с 1
+		key := tariffKey{transportType: tariff.TransportType}
+		if tariff.ZoneID != nil {
+			if *tariff.ZoneID <= 0 {
+				return ErrInvalidZoneID
+			}
+			key.zoneID = *tariff.ZoneID
+		}
+		if _, ok := seen[key]; ok {
+			return ErrDuplicateTariff
+		}
+		seen[key] = struct{}{}
+
+		if tariff.BaseFee < 0 || tariff.PerKmRate < 0 || tariff.PeakBonus < 0 || tariff.LatePenalty < 0 {
+			return ErrInvalidTariffFee
+		}
+	}
+
+	for _, transportType := range transportTypes {
+		if _, ok := seen[tariffKey{transportType: transportType}]; !ok {
+			return ErrMissingDefaultTariff
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=idempotency_test
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"service/internal/entities"
+)
+
+type Repository interface {
+	Create(ctx context.Context, keyModify entities.IdempotencyKeyModify) (*entities.IdempotencyKey, error)
+	Get(ctx context.Context, key, scope string) (*entities.IdempotencyKey, error)
+	SaveResponse(ctx context.Context, keyModify entities.IdempotencyKeyModify) error
+	Delete(ctx context.Context, key, scope string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
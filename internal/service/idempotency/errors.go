@@ -0,0 +1,11 @@
+package idempotency
+
+import "errors"
+
+var (
+	ErrInvalidIdempotencyKey  = errors.New("invalid idempotency key")
+	ErrIdempotencyKeyExists   = errors.New("idempotency key already exists")
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+	ErrFingerprintMismatch    = errors.New("idempotency key reused with different request")
+	ErrRequestInProgress      = errors.New("request with this idempotency key is in progress")
+)
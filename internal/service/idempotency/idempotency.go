@@ -0,0 +1,107 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"service/internal/entities"
+)
+
+type Idempotency struct {
+	repository Repository
+	ttl        time.Duration
+}
+
+func New(repository Repository, ttl time.Duration) *Idempotency {
+	return &Idempotency{
+		repository: repository,
+		ttl:        ttl,
+	}
+}
+
+// Begin резервирует ключ за запросом. Возвращает nil, если запрос нужно выполнить,
+// и сохраненный ответ, если такой же запрос по этому ключу уже был выполнен.
+func (s *Idempotency) Begin(ctx context.Context, key, scope, fingerprint string) (*entities.IdempotencyKey, error) {
+	if !isValidKey(key) {
+		return nil, ErrInvalidIdempotencyKey
+	}
+
+	createdAt := time.Now().UTC()
+	expiresAt := createdAt.Add(s.ttl)
+	keyModify := entities.IdempotencyKeyModify{
+		Key:         &key,
+		Scope:       &scope,
+		Fingerprint: &fingerprint,
+		CreatedAt:   &createdAt,
+		ExpiresAt:   &expiresAt,
+	}
+
+	_, err := s.repository.Create(ctx, keyModify)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, ErrIdempotencyKeyExists) {
+		return nil, fmt.Errorf("reserve idempotency key: %w", err)
+	}
+
+	stored, err := s.repository.Get(ctx, key, scope)
+	if err != nil {
+		// исходный запрос завершился ошибкой и освободил ключ между вставкой и чтением -
+		// клиенту достаточно повторить запрос
+		if errors.Is(err, ErrIdempotencyKeyNotFound) {
+			return nil, ErrRequestInProgress
+		}
+		return nil, fmt.Errorf("get idempotency key: %w", err)
+	}
+
+	if stored.Fingerprint != fingerprint {
+		return nil, ErrFingerprintMismatch
+	}
+	if !stored.IsCompleted() {
+		return nil, ErrRequestInProgress
+	}
+
+	return stored, nil
+}
+
+// Complete сохраняет ответ, который будет возвращаться на повторы запроса
+func (s *Idempotency) Complete(ctx context.Context, key, scope string, statusCode int, contentType string, body []byte) error {
+	keyModify := entities.IdempotencyKeyModify{
+		Key:          &key,
+		Scope:        &scope,
+		StatusCode:   &statusCode,
+		ContentType:  &contentType,
+		ResponseBody: body,
+	}
+
+	err := s.repository.SaveResponse(ctx, keyModify)
+	if err != nil {
+		return fmt.Errorf("save idempotent response: %w", err)
+	}
+
+	return nil
+}
+
+// Release освобождает ключ, чтобы запрос можно было выполнить повторно
+func (s *Idempotency) Release(ctx context.Context, key, scope string) error {
+	err := s.repository.Delete(ctx, key, scope)
+	if err != nil && !errors.Is(err, ErrIdempotencyKeyNotFound) {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Idempotency) CleanupExpiredKeys(ctx context.Context) (int64, error) {
+	rowsAffected, err := s.repository.DeleteExpired(ctx, time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return 0, fmt.Errorf("cleanup timed out: %w", err)
+		}
+		return 0, fmt.Errorf("cleanup: %w", err)
+	}
+
+	return rowsAffected, nil
+}
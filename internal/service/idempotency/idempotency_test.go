@@ -0,0 +1,350 @@
+package idempotency_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/service/idempotency"
+)
+
+type mock struct {
+	*MockRepository
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockRepository: NewMockRepository(ctrl),
+	}
+}
+
+func errorAssertion(expectedError error, expectedErrMsg string) require.ErrorAssertionFunc {
+	return func(t require.TestingT, err error, msgAndArgs ...interface{}) {
+		require.Error(t, err, msgAndArgs...)
+
+		if expectedError != nil {
+			assert.ErrorIs(t, err, expectedError, msgAndArgs...)
+		}
+
+		if expectedErrMsg != "" {
+			assert.Contains(t, err.Error(), expectedErrMsg, msgAndArgs...)
+		}
+	}
+}
+
+func TestIdempotencyService_Begin(t *testing.T) {
+	t.Parallel()
+
+	const (
+		key         = "3f2a9c1e-key"
+		scope       = "POST /courier"
+		fingerprint = "fingerprint-1"
+	)
+
+	completedKey := &entities.IdempotencyKey{
+		Key:          key,
+		Scope:        scope,
+		Fingerprint:  fingerprint,
+		StatusCode:   http.StatusCreated,
+		ContentType:  "application/json",
+		ResponseBody: []byte(`{"ID":1}`),
+	}
+
+	tests := []struct {
+		name           string
+		key            string
+		mockSetup      func(m *mock)
+		expectedResult *entities.IdempotencyKey
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Новый ключ резервируется, запрос нужно выполнить",
+			key:  key,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, keyModify entities.IdempotencyKeyModify) (*entities.IdempotencyKey, error) {
+						assert.Equal(t, key, *keyModify.Key)
+						assert.Equal(t, scope, *keyModify.Scope)
+						assert.Equal(t, fingerprint, *keyModify.Fingerprint)
+						assert.Equal(t, 24*time.Hour, keyModify.ExpiresAt.Sub(*keyModify.CreatedAt))
+						return &entities.IdempotencyKey{Key: key, Scope: scope, Fingerprint: fingerprint}, nil
+					})
+			},
+			expectedResult: nil,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Повтор выполненного запроса возвращает сохраненный ответ",
+			key:  key,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, idempotency.ErrIdempotencyKeyExists)
+				m.MockRepository.EXPECT().
+					Get(gomock.Any(), key, scope).
+					Return(completedKey, nil)
+			},
+			expectedResult: completedKey,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Тот же ключ с другим телом запроса",
+			key:  key,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, idempotency.ErrIdempotencyKeyExists)
+				m.MockRepository.EXPECT().
+					Get(gomock.Any(), key, scope).
+					Return(&entities.IdempotencyKey{Key: key, Scope: scope, Fingerprint: "fingerprint-2"}, nil)
+			},
+			errorAssertion: errorAssertion(idempotency.ErrFingerprintMismatch, ""),
+		},
+		{
+			name: "Исходный запрос еще выполняется",
+			key:  key,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, idempotency.ErrIdempotencyKeyExists)
+				m.MockRepository.EXPECT().
+					Get(gomock.Any(), key, scope).
+					Return(&entities.IdempotencyKey{Key: key, Scope: scope, Fingerprint: fingerprint}, nil)
+			},
+			errorAssertion: errorAssertion(idempotency.ErrRequestInProgress, ""),
+		},
+		{
+			name: "Ключ освобожден между резервированием и чтением",
+			key:  key,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, idempotency.ErrIdempotencyKeyExists)
+				m.MockRepository.EXPECT().
+					Get(gomock.Any(), key, scope).
+					Return(nil, idempotency.ErrIdempotencyKeyNotFound)
+			},
+			errorAssertion: errorAssertion(idempotency.ErrRequestInProgress, ""),
+		},
+		{
+			name:           "Отклонение пустого ключа",
+			key:            "",
+			errorAssertion: errorAssertion(idempotency.ErrInvalidIdempotencyKey, ""),
+		},
+		{
+			name:           "Отклонение ключа с пробелами по краям",
+			key:            " " + key,
+			errorAssertion: errorAssertion(idempotency.ErrInvalidIdempotencyKey, ""),
+		},
+		{
+			name: "Ошибка репозитория при резервировании ключа",
+			key:  key,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database connection error"))
+			},
+			errorAssertion: errorAssertion(nil, "reserve idempotency key"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := idempotency.New(m.MockRepository, 24*time.Hour)
+
+			result, err := service.Begin(context.Background(), tt.key, scope, fingerprint)
+
+			assert.Equal(t, tt.expectedResult, result)
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
+
+func TestIdempotencyService_Complete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Успешное сохранение ответа",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					SaveResponse(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, keyModify entities.IdempotencyKeyModify) error {
+						assert.Equal(t, http.StatusOK, *keyModify.StatusCode)
+						assert.Equal(t, "application/json", *keyModify.ContentType)
+						assert.Equal(t, []byte(`{"order_ID":"order-1"}`), keyModify.ResponseBody)
+						return nil
+					})
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Ключ удален до сохранения ответа",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					SaveResponse(gomock.Any(), gomock.Any()).
+					Return(idempotency.ErrIdempotencyKeyNotFound)
+			},
+			errorAssertion: errorAssertion(idempotency.ErrIdempotencyKeyNotFound, "save idempotent response"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := idempotency.New(m.MockRepository, 24*time.Hour)
+
+			err := service.Complete(context.Background(), "key-1", "POST /delivery/assign",
+				http.StatusOK, "application/json", []byte(`{"order_ID":"order-1"}`))
+
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
+
+func TestIdempotencyService_Release(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Успешное освобождение ключа",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Delete(gomock.Any(), "key-1", "POST /courier").
+					Return(nil)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Ключ уже удален",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Delete(gomock.Any(), "key-1", "POST /courier").
+					Return(idempotency.ErrIdempotencyKeyNotFound)
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Ошибка репозитория при освобождении ключа",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Delete(gomock.Any(), "key-1", "POST /courier").
+					Return(errors.New("database connection error"))
+			},
+			errorAssertion: errorAssertion(nil, "release idempotency key"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := idempotency.New(m.MockRepository, 24*time.Hour)
+
+			err := service.Release(context.Background(), "key-1", "POST /courier")
+
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
+
+func TestIdempotencyService_CleanupExpiredKeys(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		expectedRows   int64
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Успешное удаление истекших ключей",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					DeleteExpired(gomock.Any(), gomock.Any()).
+					Return(int64(3), nil)
+			},
+			expectedRows:   3,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Таймаут контекста при удалении",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					DeleteExpired(gomock.Any(), gomock.Any()).
+					Return(int64(0), context.DeadlineExceeded)
+			},
+			expectedRows:   0,
+			errorAssertion: errorAssertion(context.DeadlineExceeded, "cleanup timed out"),
+		},
+		{
+			name: "Ошибка репозитория при удалении",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					DeleteExpired(gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("database connection error"))
+			},
+			expectedRows:   0,
+			errorAssertion: errorAssertion(nil, "cleanup"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := idempotency.New(m.MockRepository, 24*time.Hour)
+
+			rowsAffected, err := service.CleanupExpiredKeys(context.Background())
+
+			assert.Equal(t, tt.expectedRows, rowsAffected)
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package idempotency
+
+import "strings"
+
+const maxKeyLength = 255
+
+func isValidKey(key string) bool {
+	trimmed := strings.TrimSpace(key)
+	return trimmed != "" && trimmed == key && len(key) <= maxKeyLength
+}
@@ -12,13 +12,16 @@ type OrderGateway interface {
 }
 
 type DeliveryService interface {
-	DeliveryAssign(ctx context.Context, orderID string) (*entities.DeliveryAssignment, error)
+	DeliveryAssignBatched(ctx context.Context, params entities.DeliveryAssignParams) (*entities.DeliveryAssignment, error)
+	IsDeliveryScheduled(ctx context.Context, orderID string) (bool, error)
 	DeliveryUnassign(ctx context.Context, orderID string) (*entities.DeliveryUnassignment, error)
 	FreeCourierByOrderID(ctx context.Context, orderID string) error
+	CancelPendingAssignment(ctx context.Context, orderID string) error
+	CancelScheduledDelivery(ctx context.Context, orderID string) (*entities.ScheduledDelivery, error)
 }
 
 type (
-	ExecuteFn      func(ctx context.Context, orderID string) error
+	ExecuteFn      func(ctx context.Context, order *entities.Order) error
 	HandlerFactory interface {
 		GetHandler(status entities.OrderStatusType) (ExecuteFn, error)
 	}
@@ -81,19 +81,48 @@ func (m *MockDeliveryService) EXPECT() *MockDeliveryServiceMockRecorder {
 	return m.recorder
 }
 
-// DeliveryAssign mocks base method.
-func (m *MockDeliveryService) DeliveryAssign(ctx context.Context, orderID string) (*entities.DeliveryAssignment, error) {
+// CancelPendingAssignment mocks base method.
+func (m *MockDeliveryService) CancelPendingAssignment(ctx context.Context, orderID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeliveryAssign", ctx, orderID)
+	ret := m.ctrl.Call(m, "CancelPendingAssignment", ctx, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelPendingAssignment indicates an expected call of CancelPendingAssignment.
+func (mr *MockDeliveryServiceMockRecorder) CancelPendingAssignment(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPendingAssignment", reflect.TypeOf((*MockDeliveryService)(nil).CancelPendingAssignment), ctx, orderID)
+}
+
+// CancelScheduledDelivery mocks base method.
+func (m *MockDeliveryService) CancelScheduledDelivery(ctx context.Context, orderID string) (*entities.ScheduledDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduledDelivery", ctx, orderID)
+	ret0, _ := ret[0].(*entities.ScheduledDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelScheduledDelivery indicates an expected call of CancelScheduledDelivery.
+func (mr *MockDeliveryServiceMockRecorder) CancelScheduledDelivery(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduledDelivery", reflect.TypeOf((*MockDeliveryService)(nil).CancelScheduledDelivery), ctx, orderID)
+}
+
+// DeliveryAssignBatched mocks base method.
+func (m *MockDeliveryService) DeliveryAssignBatched(ctx context.Context, params entities.DeliveryAssignParams) (*entities.DeliveryAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliveryAssignBatched", ctx, params)
 	ret0, _ := ret[0].(*entities.DeliveryAssignment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeliveryAssign indicates an expected call of DeliveryAssign.
-func (mr *MockDeliveryServiceMockRecorder) DeliveryAssign(ctx, orderID any) *gomock.Call {
+// DeliveryAssignBatched indicates an expected call of DeliveryAssignBatched.
+func (mr *MockDeliveryServiceMockRecorder) DeliveryAssignBatched(ctx, params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryAssign", reflect.TypeOf((*MockDeliveryService)(nil).DeliveryAssign), ctx, orderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryAssignBatched", reflect.TypeOf((*MockDeliveryService)(nil).DeliveryAssignBatched), ctx, params)
 }
 
 // DeliveryUnassign mocks base method.
@@ -125,6 +154,21 @@ func (mr *MockDeliveryServiceMockRecorder) FreeCourierByOrderID(ctx, orderID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreeCourierByOrderID", reflect.TypeOf((*MockDeliveryService)(nil).FreeCourierByOrderID), ctx, orderID)
 }
 
+// IsDeliveryScheduled mocks base method.
+func (m *MockDeliveryService) IsDeliveryScheduled(ctx context.Context, orderID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDeliveryScheduled", ctx, orderID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsDeliveryScheduled indicates an expected call of IsDeliveryScheduled.
+func (mr *MockDeliveryServiceMockRecorder) IsDeliveryScheduled(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDeliveryScheduled", reflect.TypeOf((*MockDeliveryService)(nil).IsDeliveryScheduled), ctx, orderID)
+}
+
 // MockHandlerFactory is a mock of HandlerFactory interface.
 type MockHandlerFactory struct {
 	ctrl     *gomock.Controller
@@ -42,7 +42,7 @@ func (s *Service) ProcessOrderStatusChange(ctx context.Context, orderModify enti
 	}
 
 	// Выполняем функцию!
-	if err := executeFn(ctx, order.ID); err != nil {
+	if err := executeFn(ctx, order); err != nil {
 		return nil, err
 	}
 
@@ -12,6 +12,8 @@ import (
 	"go.uber.org/mock/gomock"
 	"service/internal/entities"
 	"service/internal/pkg/factory/order_handle"
+	"service/internal/pkg/factory/order_priority"
+	"service/internal/pkg/factory/order_requirements"
 	service_order "service/internal/service/order"
 )
 
@@ -106,7 +108,7 @@ func TestServiceProcessOrderStatusChange(t *testing.T) {
 				m.MockHandlerFactory.EXPECT().
 					GetHandler(entities.OrderCreated).
 					Return(
-						func(ctx context.Context, orderID string) error {
+						func(ctx context.Context, order *entities.Order) error {
 							return nil
 						},
 						nil,
@@ -138,7 +140,7 @@ func TestServiceProcessOrderStatusChange(t *testing.T) {
 				m.MockHandlerFactory.EXPECT().
 					GetHandler(entities.OrderCreated).
 					Return(
-						func(ctx context.Context, orderID string) error {
+						func(ctx context.Context, order *entities.Order) error {
 							return nil
 						},
 						nil,
@@ -200,7 +202,7 @@ func TestServiceProcessOrderStatusChange(t *testing.T) {
 				m.MockHandlerFactory.EXPECT().
 					GetHandler(entities.OrderCreated).
 					Return(
-						func(ctx context.Context, orderID string) error {
+						func(ctx context.Context, order *entities.Order) error {
 							return errors.New("handler execution failed")
 						},
 						nil,
@@ -267,7 +269,11 @@ func TestStatusHandlerFactoryGetHandler(t *testing.T) {
 			defer ctrl.Finish()
 
 			m := NewMockDeliveryService(ctrl)
-			factory := order_handle.NewStatusHandlerFactory(m)
+			factory := order_handle.NewStatusHandlerFactory(
+				m,
+				order_requirements.New(order_requirements.Rules{}),
+				order_priority.New(order_priority.Rules{}),
+			)
 
 			_, err := factory.GetHandler(tt.status)
 			if tt.expectedErrMsg != "" {
@@ -279,3 +285,131 @@ func TestStatusHandlerFactoryGetHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusHandlerFactoryCreatedHandlerCashAmount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		paymentMethod      entities.OrderPaymentMethod
+		expectedCashAmount int64
+	}{
+		{
+			name:               "оплата наличными",
+			paymentMethod:      entities.PaymentCash,
+			expectedCashAmount: 150000,
+		},
+		{
+			name:          "оплата картой",
+			paymentMethod: entities.PaymentCard,
+		},
+		{
+			name: "способ оплаты неизвестен",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDeliveryService(ctrl)
+			m.EXPECT().
+				IsDeliveryScheduled(gomock.Any(), "order-2026-001").
+				Return(false, nil)
+			m.EXPECT().
+				DeliveryAssignBatched(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, params entities.DeliveryAssignParams) (*entities.DeliveryAssignment, error) {
+					assert.Equal(t, tt.expectedCashAmount, params.CashAmount)
+					return &entities.DeliveryAssignment{OrderID: params.OrderID}, nil
+				})
+
+			factory := order_handle.NewStatusHandlerFactory(
+				m,
+				order_requirements.New(order_requirements.Rules{}),
+				order_priority.New(order_priority.Rules{}),
+			)
+			handler, err := factory.GetHandler(entities.OrderCreated)
+			require.NoError(t, err)
+
+			err = handler(context.Background(), &entities.Order{
+				ID:            "order-2026-001",
+				Status:        entities.OrderCreated,
+				TotalPrice:    150000,
+				PaymentMethod: tt.paymentMethod,
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestStatusHandlerFactoryCreatedHandlerScheduled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *MockDeliveryService)
+		expectedErrMsg string
+	}{
+		{
+			name: "Запланированный заказ не назначается сразу",
+			mockSetup: func(m *MockDeliveryService) {
+				m.EXPECT().
+					IsDeliveryScheduled(gomock.Any(), "order-2026-001").
+					Return(true, nil)
+			},
+		},
+		{
+			name: "Заказ без расписания назначается сразу",
+			mockSetup: func(m *MockDeliveryService) {
+				m.EXPECT().
+					IsDeliveryScheduled(gomock.Any(), "order-2026-001").
+					Return(false, nil)
+				m.EXPECT().
+					DeliveryAssignBatched(gomock.Any(), gomock.Any()).
+					Return(&entities.DeliveryAssignment{OrderID: "order-2026-001"}, nil)
+			},
+		},
+		{
+			name: "Ошибка проверки расписания",
+			mockSetup: func(m *MockDeliveryService) {
+				m.EXPECT().
+					IsDeliveryScheduled(gomock.Any(), "order-2026-001").
+					Return(false, errors.New("connection refused"))
+			},
+			expectedErrMsg: "check scheduled delivery for created order order-2026-001: connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := NewMockDeliveryService(ctrl)
+			tt.mockSetup(m)
+
+			factory := order_handle.NewStatusHandlerFactory(
+				m,
+				order_requirements.New(order_requirements.Rules{}),
+				order_priority.New(order_priority.Rules{}),
+			)
+			handler, err := factory.GetHandler(entities.OrderCreated)
+			require.NoError(t, err)
+
+			err = handler(context.Background(), &entities.Order{
+				ID:     "order-2026-001",
+				Status: entities.OrderCreated,
+			})
+			if tt.expectedErrMsg != "" {
+				assert.EqualError(t, err, tt.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=overdue_test
+package overdue
+
+import (
+	"context"
+	"time"
+
+	"service/internal/entities"
+)
+
+type Repository interface {
+	MarkOverdue(ctx context.Context, now time.Time) ([]entities.Delivery, error)
+	GetOverdue(ctx context.Context) ([]entities.Delivery, error)
+	ReleaseCouriersForOverdueDeliveries(ctx context.Context, deadlineBefore, releasedAt time.Time) (int64, error)
+}
+
+// Escalator публикует событие о просроченных доставках для поддержки и order-service
+type Escalator interface {
+	EscalateOverdue(ctx context.Context, deliveries []entities.Delivery) error
+}
+
+type TxManager interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// AvailabilityNotifier сообщает, что появился свободный курьер и очередь ожидания можно разбирать
+type AvailabilityNotifier interface {
+	Notify()
+}
@@ -0,0 +1,200 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contract.go -destination=./contract_mocks_test.go -package=overdue_test
+//
+
+// Package overdue_test is a generated GoMock package.
+package overdue_test
+
+import (
+	context "context"
+	reflect "reflect"
+	entities "service/internal/entities"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetOverdue mocks base method.
+func (m *MockRepository) GetOverdue(ctx context.Context) ([]entities.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdue", ctx)
+	ret0, _ := ret[0].([]entities.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdue indicates an expected call of GetOverdue.
+func (mr *MockRepositoryMockRecorder) GetOverdue(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdue", reflect.TypeOf((*MockRepository)(nil).GetOverdue), ctx)
+}
+
+// MarkOverdue mocks base method.
+func (m *MockRepository) MarkOverdue(ctx context.Context, now time.Time) ([]entities.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOverdue", ctx, now)
+	ret0, _ := ret[0].([]entities.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOverdue indicates an expected call of MarkOverdue.
+func (mr *MockRepositoryMockRecorder) MarkOverdue(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOverdue", reflect.TypeOf((*MockRepository)(nil).MarkOverdue), ctx, now)
+}
+
+// ReleaseCouriersForOverdueDeliveries mocks base method.
+func (m *MockRepository) ReleaseCouriersForOverdueDeliveries(ctx context.Context, deadlineBefore, releasedAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseCouriersForOverdueDeliveries", ctx, deadlineBefore, releasedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseCouriersForOverdueDeliveries indicates an expected call of ReleaseCouriersForOverdueDeliveries.
+func (mr *MockRepositoryMockRecorder) ReleaseCouriersForOverdueDeliveries(ctx, deadlineBefore, releasedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseCouriersForOverdueDeliveries", reflect.TypeOf((*MockRepository)(nil).ReleaseCouriersForOverdueDeliveries), ctx, deadlineBefore, releasedAt)
+}
+
+// MockEscalator is a mock of Escalator interface.
+type MockEscalator struct {
+	ctrl     *gomock.Controller
+	recorder *MockEscalatorMockRecorder
+	isgomock struct{}
+}
+
+// MockEscalatorMockRecorder is the mock recorder for MockEscalator.
+type MockEscalatorMockRecorder struct {
+	mock *MockEscalator
+}
+
+// NewMockEscalator creates a new mock instance.
+func NewMockEscalator(ctrl *gomock.Controller) *MockEscalator {
+	mock := &MockEscalator{ctrl: ctrl}
+	mock.recorder = &MockEscalatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEscalator) EXPECT() *MockEscalatorMockRecorder {
+	return m.recorder
+}
+
+// EscalateOverdue mocks base method.
+func (m *MockEscalator) EscalateOverdue(ctx context.Context, deliveries []entities.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EscalateOverdue", ctx, deliveries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EscalateOverdue indicates an expected call of EscalateOverdue.
+func (mr *MockEscalatorMockRecorder) EscalateOverdue(ctx, deliveries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EscalateOverdue", reflect.TypeOf((*MockEscalator)(nil).EscalateOverdue), ctx, deliveries)
+}
+
+// MockTxManager is a mock of TxManager interface.
+type MockTxManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockTxManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockTxManagerMockRecorder is the mock recorder for MockTxManager.
+type MockTxManagerMockRecorder struct {
+	mock *MockTxManager
+}
+
+// NewMockTxManager creates a new mock instance.
+func NewMockTxManager(ctrl *gomock.Controller) *MockTxManager {
+	mock := &MockTxManager{ctrl: ctrl}
+	mock.recorder = &MockTxManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTxManager) EXPECT() *MockTxManagerMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockTxManager) Do(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockTxManagerMockRecorder) Do(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockTxManager)(nil).Do), ctx, fn)
+}
+
+// MockAvailabilityNotifier is a mock of AvailabilityNotifier interface.
+type MockAvailabilityNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockAvailabilityNotifierMockRecorder
+	isgomock struct{}
+}
+
+// MockAvailabilityNotifierMockRecorder is the mock recorder for MockAvailabilityNotifier.
+type MockAvailabilityNotifierMockRecorder struct {
+	mock *MockAvailabilityNotifier
+}
+
+// NewMockAvailabilityNotifier creates a new mock instance.
+func NewMockAvailabilityNotifier(ctrl *gomock.Controller) *MockAvailabilityNotifier {
+	mock := &MockAvailabilityNotifier{ctrl: ctrl}
+	mock.recorder = &MockAvailabilityNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAvailabilityNotifier) EXPECT() *MockAvailabilityNotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockAvailabilityNotifier) Notify() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Notify")
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockAvailabilityNotifierMockRecorder) Notify() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockAvailabilityNotifier)(nil).Notify))
+}
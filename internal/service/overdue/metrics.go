@@ -0,0 +1,22 @@
+package overdue
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	DeliveryDeadlineBreachesTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "delivery_deadline_breaches_total",
+			Help: "Total number of deliveries marked overdue and escalated because the deadline has passed",
+		},
+	)
+
+	DeliveryOverdueReleasesTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "delivery_overdue_courier_releases_total",
+			Help: "Total number of couriers released by the auto-release policy after the overdue grace period",
+		},
+	)
+)
@@ -0,0 +1,113 @@
+package overdue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"service/internal/entities"
+)
+
+// ReleasePolicy что делать с курьером просроченной доставки.
+// Без AutoRelease курьер остается занятым до снятия заказа вручную или по событию из order-service
+type ReleasePolicy struct {
+	AutoRelease bool
+	// GracePeriod сколько ждать после дедлайна, прежде чем вернуть курьера в пул
+	GracePeriod time.Duration
+}
+
+type Overdue struct {
+	repository Repository
+	escalator  Escalator
+	txManager  TxManager
+	notifier   AvailabilityNotifier
+	policy     ReleasePolicy
+}
+
+func New(
+	repository Repository,
+	escalator Escalator,
+	txManager TxManager,
+	notifier AvailabilityNotifier,
+	policy ReleasePolicy,
+) *Overdue {
+	return &Overdue{
+		repository: repository,
+		escalator:  escalator,
+		txManager:  txManager,
+		notifier:   notifier,
+		policy:     policy,
+	}
+}
+
+// ProcessOverdueDeliveries отмечает доставки с прошедшим дедлайном, эскалирует их и применяет политику освобождения курьеров
+func (o *Overdue) ProcessOverdueDeliveries(ctx context.Context) (*entities.OverdueProcessing, error) {
+	now := time.Now().UTC()
+
+	result, err := o.processOverdueDeliveries(ctx, now)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("overdue processing timed out: %w", err)
+		}
+		return nil, fmt.Errorf("overdue processing: %w", err)
+	}
+
+	return result, nil
+}
+
+func (o *Overdue) processOverdueDeliveries(ctx context.Context, now time.Time) (*entities.OverdueProcessing, error) {
+	var overdue []entities.Delivery
+	// отметка и эскалация в одной транзакции: если событие не ушло, отметка откатится и следующий запуск повторит попытку
+	err := o.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		overdue, err = o.repository.MarkOverdue(ctx, now)
+		if err != nil {
+			return fmt.Errorf("mark overdue: %w", err)
+		}
+
+		if len(overdue) == 0 {
+			return nil
+		}
+
+		err = o.escalator.EscalateOverdue(ctx, overdue)
+		if err != nil {
+			return fmt.Errorf("escalate overdue: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	DeliveryDeadlineBreachesTotal.Add(float64(len(overdue)))
+
+	result := &entities.OverdueProcessing{
+		Overdue: overdue,
+	}
+	if !o.policy.AutoRelease {
+		return result, nil
+	}
+
+	releasedCouriers, err := o.repository.ReleaseCouriersForOverdueDeliveries(ctx, now.Add(-o.policy.GracePeriod), now)
+	if err != nil {
+		return nil, fmt.Errorf("release couriers: %w", err)
+	}
+
+	if releasedCouriers > 0 {
+		DeliveryOverdueReleasesTotal.Add(float64(releasedCouriers))
+		o.notifier.Notify()
+	}
+
+	result.ReleasedCouriers = releasedCouriers
+	return result, nil
+}
+
+func (o *Overdue) GetOverdueDeliveries(ctx context.Context) ([]entities.Delivery, error) {
+	overdue, err := o.repository.GetOverdue(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get overdue deliveries: %w", err)
+	}
+
+	return overdue, nil
+}
@@ -0,0 +1,282 @@
+package overdue_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/service/overdue"
+)
+
+type mock struct {
+	*MockRepository
+	*MockEscalator
+	*MockTxManager
+	*MockAvailabilityNotifier
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockRepository:           NewMockRepository(ctrl),
+		MockEscalator:            NewMockEscalator(ctrl),
+		MockTxManager:            NewMockTxManager(ctrl),
+		MockAvailabilityNotifier: NewMockAvailabilityNotifier(ctrl),
+	}
+}
+
+func errorAssertion(expectedError error, expectedErrMsg string) require.ErrorAssertionFunc {
+	return func(t require.TestingT, err error, msgAndArgs ...interface{}) {
+		require.Error(t, err, msgAndArgs...)
+
+		if expectedError != nil {
+			assert.ErrorIs(t, err, expectedError, msgAndArgs...)
+		}
+
+		if expectedErrMsg != "" {
+			assert.Contains(t, err.Error(), expectedErrMsg, msgAndArgs...)
+		}
+	}
+}
+
+func passthroughTx(m *mock) {
+	m.MockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+func TestOverdueService_ProcessOverdueDeliveries(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	overdueAt := fixedTime.Add(time.Minute)
+
+	overdueDeliveries := []entities.Delivery{
+		{
+			ID:         1,
+			CourierID:  7,
+			OrderID:    "order-2026-001",
+			AssignedAt: fixedTime.Add(-30 * time.Minute),
+			Deadline:   fixedTime,
+			OverdueAt:  &overdueAt,
+		},
+	}
+
+	gracePeriod := 15 * time.Minute
+
+	tests := []struct {
+		name           string
+		policy         overdue.ReleasePolicy
+		mockSetup      func(m *mock)
+		expectedResult *entities.OverdueProcessing
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name:   "Просроченная доставка эскалируется, курьер без авто-освобождения остается занятым",
+			policy: overdue.ReleasePolicy{AutoRelease: false},
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					MarkOverdue(gomock.Any(), gomock.Any()).
+					Return(overdueDeliveries, nil)
+				m.MockEscalator.EXPECT().
+					EscalateOverdue(gomock.Any(), overdueDeliveries).
+					Return(nil)
+			},
+			expectedResult: &entities.OverdueProcessing{
+				Overdue: overdueDeliveries,
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:   "Авто-освобождение после льготного периода освобождает курьеров и будит очередь ожидания",
+			policy: overdue.ReleasePolicy{AutoRelease: true, GracePeriod: gracePeriod},
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					MarkOverdue(gomock.Any(), gomock.Any()).
+					Return(overdueDeliveries, nil)
+				m.MockEscalator.EXPECT().
+					EscalateOverdue(gomock.Any(), overdueDeliveries).
+					Return(nil)
+				m.MockRepository.EXPECT().
+					ReleaseCouriersForOverdueDeliveries(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, deadlineBefore, releasedAt time.Time) (int64, error) {
+						assert.Equal(t, gracePeriod, releasedAt.Sub(deadlineBefore))
+						return 2, nil
+					})
+				m.MockAvailabilityNotifier.EXPECT().
+					Notify()
+			},
+			expectedResult: &entities.OverdueProcessing{
+				Overdue:          overdueDeliveries,
+				ReleasedCouriers: 2,
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:   "Нет новых просроченных доставок и некого освобождать - эскалации и уведомления нет",
+			policy: overdue.ReleasePolicy{AutoRelease: true},
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					MarkOverdue(gomock.Any(), gomock.Any()).
+					Return([]entities.Delivery{}, nil)
+				m.MockRepository.EXPECT().
+					ReleaseCouriersForOverdueDeliveries(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(0), nil)
+			},
+			expectedResult: &entities.OverdueProcessing{
+				Overdue: []entities.Delivery{},
+			},
+			errorAssertion: require.NoError,
+		},
+		{
+			name:   "Ошибка отметки просроченных доставок",
+			policy: overdue.ReleasePolicy{AutoRelease: true},
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					MarkOverdue(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database deadlock"))
+			},
+			errorAssertion: errorAssertion(nil, "overdue processing: mark overdue: database deadlock"),
+		},
+		{
+			name:   "Ошибка эскалации откатывает отметку и не освобождает курьеров",
+			policy: overdue.ReleasePolicy{AutoRelease: true},
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					MarkOverdue(gomock.Any(), gomock.Any()).
+					Return(overdueDeliveries, nil)
+				m.MockEscalator.EXPECT().
+					EscalateOverdue(gomock.Any(), overdueDeliveries).
+					Return(errors.New("kafka: broker not available"))
+			},
+			errorAssertion: errorAssertion(nil, "overdue processing: escalate overdue: kafka: broker not available"),
+		},
+		{
+			name:   "Ошибка освобождения курьеров",
+			policy: overdue.ReleasePolicy{AutoRelease: true},
+			mockSetup: func(m *mock) {
+				passthroughTx(m)
+				m.MockRepository.EXPECT().
+					MarkOverdue(gomock.Any(), gomock.Any()).
+					Return([]entities.Delivery{}, nil)
+				m.MockRepository.EXPECT().
+					ReleaseCouriersForOverdueDeliveries(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("release query execution failed"))
+			},
+			errorAssertion: errorAssertion(nil, "overdue processing: release couriers: release query execution failed"),
+		},
+		{
+			name:   "Таймаут контекста при проверке дедлайнов",
+			policy: overdue.ReleasePolicy{AutoRelease: false},
+			mockSetup: func(m *mock) {
+				m.MockTxManager.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					Return(context.DeadlineExceeded)
+			},
+			errorAssertion: errorAssertion(context.DeadlineExceeded, "overdue processing timed out"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := overdue.New(
+				m.MockRepository,
+				m.MockEscalator,
+				m.MockTxManager,
+				m.MockAvailabilityNotifier,
+				tt.policy,
+			)
+
+			result, err := service.ProcessOverdueDeliveries(context.Background())
+
+			if tt.expectedResult != nil {
+				assert.Equal(t, tt.expectedResult, result)
+			} else {
+				assert.Nil(t, result)
+			}
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
+
+func TestOverdueService_GetOverdueDeliveries(t *testing.T) {
+	t.Parallel()
+
+	fixedTime := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	overdueDeliveries := []entities.Delivery{
+		{ID: 1, CourierID: 7, OrderID: "order-2026-001", Deadline: fixedTime, OverdueAt: &fixedTime},
+	}
+
+	tests := []struct {
+		name           string
+		mockSetup      func(m *mock)
+		expectedResult []entities.Delivery
+		errorAssertion require.ErrorAssertionFunc
+	}{
+		{
+			name: "Успешное получение просроченных доставок",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetOverdue(gomock.Any()).
+					Return(overdueDeliveries, nil)
+			},
+			expectedResult: overdueDeliveries,
+			errorAssertion: require.NoError,
+		},
+		{
+			name: "Ошибка репозитория",
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetOverdue(gomock.Any()).
+					Return(nil, errors.New("connection refused"))
+			},
+			errorAssertion: errorAssertion(nil, "get overdue deliveries: connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := overdue.New(
+				m.MockRepository,
+				m.MockEscalator,
+				m.MockTxManager,
+				m.MockAvailabilityNotifier,
+				overdue.ReleasePolicy{},
+			)
+
+			result, err := service.GetOverdueDeliveries(context.Background())
+
+			assert.Equal(t, tt.expectedResult, result)
+			tt.errorAssertion(t, err, tt.name)
+		})
+	}
+}
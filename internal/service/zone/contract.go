@@ -0,0 +1,22 @@
+//go:generate mockgen -source=contract.go -destination=./contract_mocks_test.go -package=zone_test
+package zone
+
+import (
+	"context"
+
+	"service/internal/entities"
+)
+
+type Repository interface {
+	Create(ctx context.Context, zoneModify entities.ZoneModify) (*entities.Zone, error)
+	GetByID(ctx context.Context, id int64) (*entities.Zone, error)
+	GetAll(ctx context.Context) ([]entities.Zone, error)
+	// GetByBoundsContaining зоны, в описанный прямоугольник которых попадает точка
+	GetByBoundsContaining(ctx context.Context, point entities.Location) ([]entities.Zone, error)
+	Update(ctx context.Context, zoneModify entities.ZoneModify) (*entities.Zone, error)
+	Delete(ctx context.Context, id int64) error
+
+	GetCourierIDs(ctx context.Context, zoneID int64) ([]int64, error)
+	AddCourier(ctx context.Context, zoneID, courierID int64) error
+	RemoveCourier(ctx context.Context, zoneID, courierID int64) error
+}
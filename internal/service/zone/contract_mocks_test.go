@@ -0,0 +1,174 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contract.go -destination=./contract_mocks_test.go -package=zone_test
+//
+
+// Package zone_test is a generated GoMock package.
+package zone_test
+
+import (
+	context "context"
+	reflect "reflect"
+	entities "service/internal/entities"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddCourier mocks base method.
+func (m *MockRepository) AddCourier(ctx context.Context, zoneID, courierID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCourier", ctx, zoneID, courierID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCourier indicates an expected call of AddCourier.
+func (mr *MockRepositoryMockRecorder) AddCourier(ctx, zoneID, courierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCourier", reflect.TypeOf((*MockRepository)(nil).AddCourier), ctx, zoneID, courierID)
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, zoneModify entities.ZoneModify) (*entities.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, zoneModify)
+	ret0, _ := ret[0].(*entities.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, zoneModify any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, zoneModify)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockRepository) GetAll(ctx context.Context) ([]entities.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]entities.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRepository)(nil).GetAll), ctx)
+}
+
+// GetByBoundsContaining mocks base method.
+func (m *MockRepository) GetByBoundsContaining(ctx context.Context, point entities.Location) ([]entities.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByBoundsContaining", ctx, point)
+	ret0, _ := ret[0].([]entities.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByBoundsContaining indicates an expected call of GetByBoundsContaining.
+func (mr *MockRepositoryMockRecorder) GetByBoundsContaining(ctx, point any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByBoundsContaining", reflect.TypeOf((*MockRepository)(nil).GetByBoundsContaining), ctx, point)
+}
+
+// GetByID mocks base method.
+func (m *MockRepository) GetByID(ctx context.Context, id int64) (*entities.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entities.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
+}
+
+// GetCourierIDs mocks base method.
+func (m *MockRepository) GetCourierIDs(ctx context.Context, zoneID int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourierIDs", ctx, zoneID)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourierIDs indicates an expected call of GetCourierIDs.
+func (mr *MockRepositoryMockRecorder) GetCourierIDs(ctx, zoneID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierIDs", reflect.TypeOf((*MockRepository)(nil).GetCourierIDs), ctx, zoneID)
+}
+
+// RemoveCourier mocks base method.
+func (m *MockRepository) RemoveCourier(ctx context.Context, zoneID, courierID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCourier", ctx, zoneID, courierID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCourier indicates an expected call of RemoveCourier.
+func (mr *MockRepositoryMockRecorder) RemoveCourier(ctx, zoneID, courierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCourier", reflect.TypeOf((*MockRepository)(nil).RemoveCourier), ctx, zoneID, courierID)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(ctx context.Context, zoneModify entities.ZoneModify) (*entities.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, zoneModify)
+	ret0, _ := ret[0].(*entities.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(ctx, zoneModify any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, zoneModify)
+}
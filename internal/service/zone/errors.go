@@ -0,0 +1,16 @@
+package zone
+
+import "errors"
+
+var (
+	ErrInvalidZoneID    = errors.New("invalid zone id")
+	ErrInvalidZoneName  = errors.New("invalid zone name")
+	ErrInvalidPolygon   = errors.New("invalid zone polygon")
+	ErrInvalidCourierID = errors.New("invalid courier id")
+	ErrInvalidPoint     = errors.New("invalid point coordinates")
+
+	ErrZoneNotFound     = errors.New("zone not found")
+	ErrZoneConflict     = errors.New("zone with this name already exists")
+	ErrCourierNotFound  = errors.New("courier not found")
+	ErrCourierNotInZone = errors.New("courier is not a member of the zone")
+)
@@ -0,0 +1,44 @@
+package zone
+
+import (
+	"strings"
+
+	"service/internal/entities"
+	"service/pkg/geo"
+)
+
+const (
+	maxZoneNameLength = 200
+	// maxPolygonVertices район города с запасом, больше - скорее ошибка выгрузки из картографии
+	maxPolygonVertices = 1000
+)
+
+func isValidZoneName(name string) bool {
+	trimmed := strings.TrimSpace(name)
+	return trimmed != "" && len(trimmed) <= maxZoneNameLength
+}
+
+func isValidPolygon(polygon []entities.Location) bool {
+	if len(polygon) > maxPolygonVertices {
+		return false
+	}
+
+	return toGeoPolygon(polygon).IsValid()
+}
+
+func isValidPoint(point entities.Location) bool {
+	return toGeoPoint(point).IsValid()
+}
+
+func toGeoPoint(location entities.Location) geo.Point {
+	return geo.Point{Lat: location.Latitude, Lon: location.Longitude}
+}
+
+func toGeoPolygon(polygon []entities.Location) geo.Polygon {
+	geoPolygon := make(geo.Polygon, len(polygon))
+	for i, location := range polygon {
+		geoPolygon[i] = toGeoPoint(location)
+	}
+
+	return geoPolygon
+}
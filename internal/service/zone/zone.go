@@ -0,0 +1,180 @@
+package zone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"service/internal/entities"
+)
+
+type Zone struct {
+	repository Repository
+}
+
+func New(repository Repository) *Zone {
+	return &Zone{
+		repository: repository,
+	}
+}
+
+func (s *Zone) CreateZone(ctx context.Context, zoneModify entities.ZoneModify) (*entities.Zone, error) {
+	err := validateZoneModify(zoneModify)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(*zoneModify.Name)
+	zoneModify.Name = &name
+
+	zone, err := s.repository.Create(ctx, zoneModify)
+	if err != nil {
+		return nil, fmt.Errorf("create zone: %w", err)
+	}
+
+	return zone, nil
+}
+
+func (s *Zone) GetZone(ctx context.Context, id int64) (*entities.Zone, error) {
+	if id <= 0 {
+		return nil, ErrInvalidZoneID
+	}
+
+	zone, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get zone: %w", err)
+	}
+
+	return zone, nil
+}
+
+func (s *Zone) GetZones(ctx context.Context) ([]entities.Zone, error) {
+	zones, err := s.repository.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get zones: %w", err)
+	}
+
+	return zones, nil
+}
+
+// UpdateZone полностью заменяет имя и контур зоны, состав курьеров не меняется
+func (s *Zone) UpdateZone(ctx context.Context, zoneModify entities.ZoneModify) (*entities.Zone, error) {
+	if zoneModify.ID == nil || *zoneModify.ID <= 0 {
+		return nil, ErrInvalidZoneID
+	}
+
+	err := validateZoneModify(zoneModify)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(*zoneModify.Name)
+	zoneModify.Name = &name
+
+	zone, err := s.repository.Update(ctx, zoneModify)
+	if err != nil {
+		return nil, fmt.Errorf("update zone: %w", err)
+	}
+
+	return zone, nil
+}
+
+// DeleteZone удаляет зону вместе с составом курьеров, сами курьеры остаются
+func (s *Zone) DeleteZone(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return ErrInvalidZoneID
+	}
+
+	err := s.repository.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("delete zone: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Zone) GetZoneCourierIDs(ctx context.Context, zoneID int64) ([]int64, error) {
+	if zoneID <= 0 {
+		return nil, ErrInvalidZoneID
+	}
+
+	// пустой список у существующей зоны и несуществующая зона - разные ответы
+	_, err := s.repository.GetByID(ctx, zoneID)
+	if err != nil {
+		return nil, fmt.Errorf("get zone: %w", err)
+	}
+
+	courierIDs, err := s.repository.GetCourierIDs(ctx, zoneID)
+	if err != nil {
+		return nil, fmt.Errorf("get zone couriers: %w", err)
+	}
+
+	return courierIDs, nil
+}
+
+// AddCourierToZone идемпотентно: повторное добавление не ошибка
+func (s *Zone) AddCourierToZone(ctx context.Context, zoneID, courierID int64) error {
+	if zoneID <= 0 {
+		return ErrInvalidZoneID
+	}
+	if courierID <= 0 {
+		return ErrInvalidCourierID
+	}
+
+	err := s.repository.AddCourier(ctx, zoneID, courierID)
+	if err != nil {
+		return fmt.Errorf("add courier to zone: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Zone) RemoveCourierFromZone(ctx context.Context, zoneID, courierID int64) error {
+	if zoneID <= 0 {
+		return ErrInvalidZoneID
+	}
+	if courierID <= 0 {
+		return ErrInvalidCourierID
+	}
+
+	err := s.repository.RemoveCourier(ctx, zoneID, courierID)
+	if err != nil {
+		return fmt.Errorf("remove courier from zone: %w", err)
+	}
+
+	return nil
+}
+
+// FindZoneIDsByPoint зоны, в которые попадает точка. Прямоугольником отсеивает БД,
+// точную проверку по контуру делает Go, поэтому PostGIS не нужен
+func (s *Zone) FindZoneIDsByPoint(ctx context.Context, point entities.Location) ([]int64, error) {
+	if !isValidPoint(point) {
+		return nil, ErrInvalidPoint
+	}
+
+	candidates, err := s.repository.GetByBoundsContaining(ctx, point)
+	if err != nil {
+		return nil, fmt.Errorf("get zones by point: %w", err)
+	}
+
+	geoPoint := toGeoPoint(point)
+	var zoneIDs []int64
+	for _, zone := range candidates {
+		if toGeoPolygon(zone.Polygon).Contains(geoPoint) {
+			zoneIDs = append(zoneIDs, zone.ID)
+		}
+	}
+
+	return zoneIDs, nil
+}
+
+func validateZoneModify(zoneModify entities.ZoneModify) error {
+	if zoneModify.Name == nil || !isValidZoneName(*zoneModify.Name) {
+		return ErrInvalidZoneName
+	}
+	if !isValidPolygon(zoneModify.Polygon) {
+		return ErrInvalidPolygon
+	}
+
+	return nil
+}
@@ -0,0 +1,407 @@
+package zone_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"service/internal/entities"
+	"service/internal/service/zone"
+)
+
+type mock struct {
+	*MockRepository
+}
+
+func newMock(ctrl *gomock.Controller) *mock {
+	return &mock{
+		MockRepository: NewMockRepository(ctrl),
+	}
+}
+
+func errorAssertion(expectedError error, expectedErrMsg string) require.ErrorAssertionFunc {
+	return func(t require.TestingT, err error, msgAndArgs ...interface{}) {
+		require.Error(t, err, msgAndArgs...)
+
+		if expectedError != nil {
+			assert.ErrorIs(t, err, expectedError, msgAndArgs...)
+		}
+
+		if expectedErrMsg != "" {
+			assert.Contains(t, err.Error(), expectedErrMsg, msgAndArgs...)
+		}
+	}
+}
+
+// square квадрат со стороной size от точки (lat, lon)
+func square(lat, lon, size float64) []entities.Location {
+	return []entities.Location{
+		{Latitude: lat, Longitude: lon},
+		{Latitude: lat, Longitude: lon + size},
+		{Latitude: lat + size, Longitude: lon + size},
+		{Latitude: lat + size, Longitude: lon},
+	}
+}
+
+func TestZoneService_CreateZone(t *testing.T) {
+	t.Parallel()
+
+	polygon := square(55.7, 37.5, 0.1)
+	createdZone := &entities.Zone{ID: 1, Name: "Центр", Polygon: polygon}
+
+	tests := []struct {
+		name      string
+		modify    entities.ZoneModify
+		mockSetup func(m *mock)
+		expected  *entities.Zone
+		assertion require.ErrorAssertionFunc
+	}{
+		{
+			name:   "Успешное создание зоны, имя обрезается",
+			modify: entities.ZoneModify{Name: pointer.To("  Центр "), Polygon: polygon},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), entities.ZoneModify{Name: pointer.To("Центр"), Polygon: polygon}).
+					Return(createdZone, nil)
+			},
+			expected:  createdZone,
+			assertion: require.NoError,
+		},
+		{
+			name:      "Отклонение зоны без имени",
+			modify:    entities.ZoneModify{Polygon: polygon},
+			assertion: errorAssertion(zone.ErrInvalidZoneName, ""),
+		},
+		{
+			name:      "Отклонение зоны с именем из пробелов",
+			modify:    entities.ZoneModify{Name: pointer.To("   "), Polygon: polygon},
+			assertion: errorAssertion(zone.ErrInvalidZoneName, ""),
+		},
+		{
+			name:      "Отклонение контура из двух вершин",
+			modify:    entities.ZoneModify{Name: pointer.To("Центр"), Polygon: polygon[:2]},
+			assertion: errorAssertion(zone.ErrInvalidPolygon, ""),
+		},
+		{
+			name: "Отклонение вырожденного контура",
+			modify: entities.ZoneModify{Name: pointer.To("Центр"), Polygon: []entities.Location{
+				{Latitude: 1, Longitude: 1},
+				{Latitude: 2, Longitude: 2},
+				{Latitude: 3, Longitude: 3},
+			}},
+			assertion: errorAssertion(zone.ErrInvalidPolygon, ""),
+		},
+		{
+			name: "Отклонение контура с координатами вне диапазона",
+			modify: entities.ZoneModify{Name: pointer.To("Центр"), Polygon: []entities.Location{
+				{Latitude: 95, Longitude: 1},
+				{Latitude: 1, Longitude: 2},
+				{Latitude: 2, Longitude: 1},
+			}},
+			assertion: errorAssertion(zone.ErrInvalidPolygon, ""),
+		},
+		{
+			name:   "Конфликт имени",
+			modify: entities.ZoneModify{Name: pointer.To("Центр"), Polygon: polygon},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, zone.ErrZoneConflict)
+			},
+			assertion: errorAssertion(zone.ErrZoneConflict, "create zone"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := zone.New(m.MockRepository)
+			actual, err := service.CreateZone(context.Background(), tt.modify)
+
+			assert.Equal(t, tt.expected, actual)
+			tt.assertion(t, err)
+		})
+	}
+}
+
+func TestZoneService_UpdateZone(t *testing.T) {
+	t.Parallel()
+
+	polygon := square(55.7, 37.5, 0.1)
+	updatedZone := &entities.Zone{ID: 1, Name: "Центр", Polygon: polygon}
+
+	tests := []struct {
+		name      string
+		modify    entities.ZoneModify
+		mockSetup func(m *mock)
+		expected  *entities.Zone
+		assertion require.ErrorAssertionFunc
+	}{
+		{
+			name:   "Успешное обновление зоны",
+			modify: entities.ZoneModify{ID: pointer.To(int64(1)), Name: pointer.To("Центр"), Polygon: polygon},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Update(gomock.Any(), entities.ZoneModify{ID: pointer.To(int64(1)), Name: pointer.To("Центр"), Polygon: polygon}).
+					Return(updatedZone, nil)
+			},
+			expected:  updatedZone,
+			assertion: require.NoError,
+		},
+		{
+			name:      "Отклонение без идентификатора",
+			modify:    entities.ZoneModify{Name: pointer.To("Центр"), Polygon: polygon},
+			assertion: errorAssertion(zone.ErrInvalidZoneID, ""),
+		},
+		{
+			name:      "Отклонение невалидного контура",
+			modify:    entities.ZoneModify{ID: pointer.To(int64(1)), Name: pointer.To("Центр")},
+			assertion: errorAssertion(zone.ErrInvalidPolygon, ""),
+		},
+		{
+			name:   "Зона не найдена",
+			modify: entities.ZoneModify{ID: pointer.To(int64(2)), Name: pointer.To("Центр"), Polygon: polygon},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil, zone.ErrZoneNotFound)
+			},
+			assertion: errorAssertion(zone.ErrZoneNotFound, "update zone"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := zone.New(m.MockRepository)
+			actual, err := service.UpdateZone(context.Background(), tt.modify)
+
+			assert.Equal(t, tt.expected, actual)
+			tt.assertion(t, err)
+		})
+	}
+}
+
+func TestZoneService_GetZoneCourierIDs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		zoneID    int64
+		mockSetup func(m *mock)
+		expected  []int64
+		assertion require.ErrorAssertionFunc
+	}{
+		{
+			name:   "Успешное получение состава зоны",
+			zoneID: 1,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&entities.Zone{ID: 1}, nil)
+				m.MockRepository.EXPECT().GetCourierIDs(gomock.Any(), int64(1)).Return([]int64{3, 5}, nil)
+			},
+			expected:  []int64{3, 5},
+			assertion: require.NoError,
+		},
+		{
+			name:      "Отклонение невалидного идентификатора",
+			zoneID:    0,
+			assertion: errorAssertion(zone.ErrInvalidZoneID, ""),
+		},
+		{
+			name:   "Зона не найдена",
+			zoneID: 2,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().GetByID(gomock.Any(), int64(2)).Return(nil, zone.ErrZoneNotFound)
+			},
+			assertion: errorAssertion(zone.ErrZoneNotFound, "get zone"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := zone.New(m.MockRepository)
+			actual, err := service.GetZoneCourierIDs(context.Background(), tt.zoneID)
+
+			assert.Equal(t, tt.expected, actual)
+			tt.assertion(t, err)
+		})
+	}
+}
+
+func TestZoneService_AddCourierToZone(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		zoneID    int64
+		courierID int64
+		mockSetup func(m *mock)
+		assertion require.ErrorAssertionFunc
+	}{
+		{
+			name:      "Успешное добавление курьера",
+			zoneID:    1,
+			courierID: 2,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().AddCourier(gomock.Any(), int64(1), int64(2)).Return(nil)
+			},
+			assertion: require.NoError,
+		},
+		{
+			name:      "Отклонение невалидной зоны",
+			zoneID:    -1,
+			courierID: 2,
+			assertion: errorAssertion(zone.ErrInvalidZoneID, ""),
+		},
+		{
+			name:      "Отклонение невалидного курьера",
+			zoneID:    1,
+			courierID: 0,
+			assertion: errorAssertion(zone.ErrInvalidCourierID, ""),
+		},
+		{
+			name:      "Курьер не найден",
+			zoneID:    1,
+			courierID: 99,
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().AddCourier(gomock.Any(), int64(1), int64(99)).Return(zone.ErrCourierNotFound)
+			},
+			assertion: errorAssertion(zone.ErrCourierNotFound, "add courier to zone"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := zone.New(m.MockRepository)
+			err := service.AddCourierToZone(context.Background(), tt.zoneID, tt.courierID)
+
+			tt.assertion(t, err)
+		})
+	}
+}
+
+func TestZoneService_FindZoneIDsByPoint(t *testing.T) {
+	t.Parallel()
+
+	// треугольник: прямоугольник 0..1 x 0..1 содержит и точки вне контура
+	triangle := []entities.Location{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 0, Longitude: 1},
+		{Latitude: 1, Longitude: 0},
+	}
+
+	tests := []struct {
+		name      string
+		point     entities.Location
+		mockSetup func(m *mock)
+		expected  []int64
+		assertion require.ErrorAssertionFunc
+	}{
+		{
+			name:  "Точка внутри контура",
+			point: entities.Location{Latitude: 0.2, Longitude: 0.2},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetByBoundsContaining(gomock.Any(), entities.Location{Latitude: 0.2, Longitude: 0.2}).
+					Return([]entities.Zone{
+						{ID: 1, Polygon: triangle},
+						{ID: 2, Polygon: square(0, 0, 1)},
+					}, nil)
+			},
+			expected:  []int64{1, 2},
+			assertion: require.NoError,
+		},
+		{
+			name:  "Точка в прямоугольнике, но вне контура",
+			point: entities.Location{Latitude: 0.8, Longitude: 0.8},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetByBoundsContaining(gomock.Any(), gomock.Any()).
+					Return([]entities.Zone{
+						{ID: 1, Polygon: triangle},
+						{ID: 2, Polygon: square(0, 0, 1)},
+					}, nil)
+			},
+			expected:  []int64{2},
+			assertion: require.NoError,
+		},
+		{
+			name:  "Нет зон",
+			point: entities.Location{Latitude: 10, Longitude: 10},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetByBoundsContaining(gomock.Any(), gomock.Any()).
+					Return([]entities.Zone{}, nil)
+			},
+			expected:  nil,
+			assertion: require.NoError,
+		},
+		{
+			name:      "Невалидная точка",
+			point:     entities.Location{Latitude: 100, Longitude: 0},
+			assertion: errorAssertion(zone.ErrInvalidPoint, ""),
+		},
+		{
+			name:  "Ошибка репозитория",
+			point: entities.Location{Latitude: 0.2, Longitude: 0.2},
+			mockSetup: func(m *mock) {
+				m.MockRepository.EXPECT().
+					GetByBoundsContaining(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("db down"))
+			},
+			assertion: errorAssertion(nil, "get zones by point"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			if tt.mockSetup != nil {
+				tt.mockSetup(m)
+			}
+
+			service := zone.New(m.MockRepository)
+			actual, err := service.FindZoneIDsByPoint(context.Background(), tt.point)
+
+			assert.Equal(t, tt.expected, actual)
+			tt.assertion(t, err)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS pending_assignments (
+    id          BIGSERIAL PRIMARY KEY,
+    order_id    VARCHAR(255) NOT NULL,
+    priority    INTEGER NOT NULL DEFAULT 0,
+    enqueued_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_pending_assignments_order_unique ON pending_assignments USING BTREE (order_id);
+
+-- порядок разбора очереди: сначала приоритетные, затем самые старые
+CREATE INDEX idx_pending_assignments_queue ON pending_assignments USING BTREE (priority DESC, enqueued_at ASC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS pending_assignments;
+-- +goose StatementEnd
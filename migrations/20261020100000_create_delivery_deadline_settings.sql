@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS delivery_transport_speeds (
+    transport_type            TEXT PRIMARY KEY
+        CHECK (transport_type IN ('on_foot', 'scooter', 'car')),
+    average_speed_kmh         DOUBLE PRECISION NOT NULL CHECK (average_speed_kmh > 0),
+    handling_overhead_seconds INTEGER NOT NULL DEFAULT 0 CHECK (handling_overhead_seconds >= 0),
+    updated_at                TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- часы по UTC, интервал [start_hour, end_hour)
+CREATE TABLE IF NOT EXISTS delivery_peak_hours (
+    id          BIGSERIAL PRIMARY KEY,
+    start_hour  SMALLINT NOT NULL CHECK (start_hour BETWEEN 0 AND 23),
+    end_hour    SMALLINT NOT NULL CHECK (end_hour BETWEEN 1 AND 24),
+    multiplier  DOUBLE PRECISION NOT NULL CHECK (multiplier >= 1),
+    CHECK (start_hour < end_hour)
+);
+
+INSERT INTO delivery_transport_speeds (transport_type, average_speed_kmh, handling_overhead_seconds)
+VALUES
+    ('on_foot', 5, 300),
+    ('scooter', 15, 300),
+    ('car', 25, 300);
+
+-- маршрут нужен, чтобы посчитать дедлайн, когда заказ дождется курьера
+ALTER TABLE pending_assignments
+    ADD COLUMN IF NOT EXISTS pickup_lat  DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS pickup_lon  DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS dropoff_lat DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS dropoff_lon DOUBLE PRECISION;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pending_assignments
+    DROP COLUMN IF EXISTS pickup_lat,
+    DROP COLUMN IF EXISTS pickup_lon,
+    DROP COLUMN IF EXISTS dropoff_lat,
+    DROP COLUMN IF EXISTS dropoff_lon;
+
+DROP TABLE IF EXISTS delivery_peak_hours;
+DROP TABLE IF EXISTS delivery_transport_speeds;
+-- +goose StatementEnd
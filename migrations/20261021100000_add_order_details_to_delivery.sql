@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- данные заказа из order-service, для старых записей остаются пустыми
+ALTER TABLE delivery
+    ADD COLUMN IF NOT EXISTS restaurant_id      TEXT,
+    ADD COLUMN IF NOT EXISTS address            JSONB,
+    ADD COLUMN IF NOT EXISTS estimated_delivery TIMESTAMP;
+
+ALTER TABLE pending_assignments
+    ADD COLUMN IF NOT EXISTS restaurant_id      TEXT,
+    ADD COLUMN IF NOT EXISTS address            JSONB,
+    ADD COLUMN IF NOT EXISTS estimated_delivery TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pending_assignments
+    DROP COLUMN IF EXISTS restaurant_id,
+    DROP COLUMN IF EXISTS address,
+    DROP COLUMN IF EXISTS estimated_delivery;
+
+ALTER TABLE delivery
+    DROP COLUMN IF EXISTS restaurant_id,
+    DROP COLUMN IF EXISTS address,
+    DROP COLUMN IF EXISTS estimated_delivery;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- scope - метод и шаблон пути: один и тот же ключ можно использовать для разных эндпоинтов
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key           VARCHAR(255) NOT NULL,
+    scope         VARCHAR(255) NOT NULL,
+    fingerprint   VARCHAR(64)  NOT NULL,
+    status_code   INTEGER,
+    content_type  VARCHAR(255),
+    response_body BYTEA,
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at    TIMESTAMP NOT NULL,
+    PRIMARY KEY (key, scope)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys USING BTREE (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS idempotency_keys;
+-- +goose StatementEnd
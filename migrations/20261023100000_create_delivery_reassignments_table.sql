@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- история передачи заказов между курьерами, запись не удаляется вместе с доставкой
+CREATE TABLE IF NOT EXISTS delivery_reassignments (
+    id                  BIGSERIAL PRIMARY KEY,
+    order_id            VARCHAR(255) NOT NULL,
+    previous_courier_id BIGINT NOT NULL,
+    courier_id          BIGINT NOT NULL,
+    reason              TEXT NOT NULL,
+    deadline            TIMESTAMP NOT NULL,
+    reassigned_at       TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_delivery_reassignments_order_id ON delivery_reassignments USING BTREE (order_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS delivery_reassignments;
+-- +goose StatementEnd
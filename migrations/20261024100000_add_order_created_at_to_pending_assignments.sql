@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- время создания заказа в order-service, нужно для метрики time-to-assign
+ALTER TABLE pending_assignments
+    ADD COLUMN IF NOT EXISTS order_created_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pending_assignments
+    DROP COLUMN IF EXISTS order_created_at;
+-- +goose StatementEnd
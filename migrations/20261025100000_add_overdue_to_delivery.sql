@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- overdue_at выставляется задачей проверки дедлайнов, courier_released_at - когда курьер освобожден по политике авто-освобождения
+ALTER TABLE delivery
+    ADD COLUMN IF NOT EXISTS overdue_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS courier_released_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_delivery_overdue_at
+    ON delivery (overdue_at)
+    WHERE overdue_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_delivery_overdue_at;
+
+ALTER TABLE delivery
+    DROP COLUMN IF EXISTS courier_released_at,
+    DROP COLUMN IF EXISTS overdue_at;
+-- +goose StatementEnd
@@ -0,0 +1,169 @@
+-- +goose Up
+-- +goose StatementBegin
+-- delivery разбивается на месячные партиции по created_at.
+-- Уникальный индекс по order_id на партиционированной таблице обязан включать created_at,
+-- поэтому уникальность заказа держит отдельная таблица delivery_order_ids, которую заполняют триггеры
+ALTER TABLE delivery RENAME TO delivery_legacy;
+ALTER TABLE delivery_legacy RENAME CONSTRAINT delivery_pkey TO delivery_legacy_pkey;
+ALTER INDEX idx_delivery_courier_id RENAME TO idx_delivery_legacy_courier_id;
+ALTER INDEX idx_delivery_order_unique RENAME TO idx_delivery_legacy_order_unique;
+ALTER INDEX idx_delivery_deadline RENAME TO idx_delivery_legacy_deadline;
+ALTER INDEX idx_delivery_created_at RENAME TO idx_delivery_legacy_created_at;
+ALTER INDEX idx_delivery_overdue_at RENAME TO idx_delivery_legacy_overdue_at;
+-- последовательность переживет удаление старой таблицы
+ALTER SEQUENCE delivery_id_seq OWNED BY NONE;
+
+CREATE TABLE delivery (
+    id                  BIGINT NOT NULL DEFAULT nextval('delivery_id_seq'),
+    courier_id          BIGINT NOT NULL REFERENCES couriers(id)
+        ON DELETE RESTRICT
+        ON UPDATE RESTRICT,
+    order_id            VARCHAR(255) NOT NULL,
+    created_at          TIMESTAMP NOT NULL,
+    assigned_at         TIMESTAMP NOT NULL DEFAULT NOW(),
+    deadline            TIMESTAMP NOT NULL,
+    restaurant_id       TEXT,
+    address             JSONB,
+    estimated_delivery  TIMESTAMP,
+    overdue_at          TIMESTAMP,
+    courier_released_at TIMESTAMP,
+    PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+ALTER SEQUENCE delivery_id_seq OWNED BY delivery.id;
+
+CREATE INDEX idx_delivery_courier_id ON delivery USING BTREE (courier_id);
+CREATE INDEX idx_delivery_order_id ON delivery USING BTREE (order_id);
+CREATE INDEX idx_delivery_deadline ON delivery USING BTREE (deadline);
+CREATE INDEX idx_delivery_created_at ON delivery USING BTREE (created_at DESC);
+CREATE INDEX idx_delivery_overdue_at ON delivery (overdue_at) WHERE overdue_at IS NOT NULL;
+
+-- строки вне созданных партиций попадают сюда, задача обслуживания переносит их при создании партиции
+CREATE TABLE delivery_default PARTITION OF delivery DEFAULT;
+
+-- партиции от самой старой доставки до месяца через два от текущего, дальше их создает задача обслуживания
+DO $$
+DECLARE
+    month_start DATE;
+    last_month  DATE := date_trunc('month', NOW()) + INTERVAL '2 months';
+BEGIN
+    SELECT COALESCE(date_trunc('month', MIN(created_at)), date_trunc('month', NOW()))
+    INTO month_start
+    FROM delivery_legacy;
+
+    WHILE month_start <= last_month LOOP
+        EXECUTE format(
+            'CREATE TABLE IF NOT EXISTS %I PARTITION OF delivery FOR VALUES FROM (%L) TO (%L)',
+            'delivery_y' || to_char(month_start, 'YYYY') || 'm' || to_char(month_start, 'MM'),
+            month_start,
+            (month_start + INTERVAL '1 month')::DATE
+        );
+        month_start := (month_start + INTERVAL '1 month')::DATE;
+    END LOOP;
+END $$;
+
+CREATE TABLE delivery_order_ids (
+    order_id   VARCHAR(255) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_delivery_order_ids_created_at ON delivery_order_ids USING BTREE (created_at);
+
+CREATE OR REPLACE FUNCTION delivery_order_ids_insert() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO delivery_order_ids (order_id, created_at) VALUES (NEW.order_id, NEW.created_at);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION delivery_order_ids_delete() RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM delivery_order_ids WHERE order_id = OLD.order_id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- повторный order_id падает с unique_violation из delivery_order_ids, как раньше из idx_delivery_order_unique
+CREATE TRIGGER trg_delivery_order_ids_insert
+    AFTER INSERT ON delivery
+    FOR EACH ROW EXECUTE FUNCTION delivery_order_ids_insert();
+
+CREATE TRIGGER trg_delivery_order_ids_delete
+    AFTER DELETE ON delivery
+    FOR EACH ROW EXECUTE FUNCTION delivery_order_ids_delete();
+
+INSERT INTO delivery (
+    id, courier_id, order_id, created_at, assigned_at, deadline,
+    restaurant_id, address, estimated_delivery, overdue_at, courier_released_at
+)
+SELECT
+    id, courier_id, order_id, created_at, assigned_at, deadline,
+    restaurant_id, address, estimated_delivery, overdue_at, courier_released_at
+FROM delivery_legacy;
+
+DROP TABLE delivery_legacy;
+
+-- партиции старше срока хранения переносятся сюда, если архив не выгружается в файлы
+CREATE TABLE IF NOT EXISTS delivery_archive (
+    id                  BIGINT NOT NULL,
+    courier_id          BIGINT NOT NULL,
+    order_id            VARCHAR(255) NOT NULL,
+    created_at          TIMESTAMP NOT NULL,
+    assigned_at         TIMESTAMP NOT NULL,
+    deadline            TIMESTAMP NOT NULL,
+    restaurant_id       TEXT,
+    address             JSONB,
+    estimated_delivery  TIMESTAMP,
+    overdue_at          TIMESTAMP,
+    courier_released_at TIMESTAMP,
+    archived_at         TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_delivery_archive_order_id ON delivery_archive USING BTREE (order_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- архив в delivery не возвращается, старые доставки остаются только в выгрузках
+CREATE TABLE delivery_plain (
+    id                  BIGINT NOT NULL DEFAULT nextval('delivery_id_seq'),
+    courier_id          BIGINT NOT NULL REFERENCES couriers(id)
+        ON DELETE RESTRICT
+        ON UPDATE RESTRICT,
+    order_id            VARCHAR(255) NOT NULL,
+    created_at          TIMESTAMP NOT NULL,
+    assigned_at         TIMESTAMP NOT NULL DEFAULT NOW(),
+    deadline            TIMESTAMP NOT NULL,
+    restaurant_id       TEXT,
+    address             JSONB,
+    estimated_delivery  TIMESTAMP,
+    overdue_at          TIMESTAMP,
+    courier_released_at TIMESTAMP
+);
+
+INSERT INTO delivery_plain (
+    id, courier_id, order_id, created_at, assigned_at, deadline,
+    restaurant_id, address, estimated_delivery, overdue_at, courier_released_at
+)
+SELECT
+    id, courier_id, order_id, created_at, assigned_at, deadline,
+    restaurant_id, address, estimated_delivery, overdue_at, courier_released_at
+FROM delivery;
+
+ALTER SEQUENCE delivery_id_seq OWNED BY NONE;
+DROP TABLE delivery;
+DROP FUNCTION IF EXISTS delivery_order_ids_insert();
+DROP FUNCTION IF EXISTS delivery_order_ids_delete();
+DROP TABLE IF EXISTS delivery_order_ids;
+DROP TABLE IF EXISTS delivery_archive;
+
+ALTER TABLE delivery_plain RENAME TO delivery;
+ALTER TABLE delivery ADD CONSTRAINT delivery_pkey PRIMARY KEY (id);
+ALTER SEQUENCE delivery_id_seq OWNED BY delivery.id;
+
+CREATE INDEX idx_delivery_courier_id ON delivery USING BTREE (courier_id);
+CREATE UNIQUE INDEX idx_delivery_order_unique ON delivery USING BTREE (order_id);
+CREATE INDEX idx_delivery_deadline ON delivery USING BTREE (deadline);
+CREATE INDEX idx_delivery_created_at ON delivery USING BTREE (created_at DESC);
+CREATE INDEX idx_delivery_overdue_at ON delivery (overdue_at) WHERE overdue_at IS NOT NULL;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- курьеры не удаляются: delivery.courier_id ссылается на них с ON DELETE RESTRICT
+ALTER TABLE couriers
+    ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deactivation_reason TEXT;
+
+-- телефон уникален только среди активных курьеров: номер отключенного курьера можно выдать новому
+ALTER TABLE couriers DROP CONSTRAINT IF EXISTS couriers_phone_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_couriers_phone_active
+ON couriers (phone)
+WHERE deactivated_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- откат не пройдет, если номер отключенного курьера уже занят активным
+DROP INDEX IF EXISTS idx_couriers_phone_active;
+ALTER TABLE couriers ADD CONSTRAINT couriers_phone_key UNIQUE (phone);
+
+ALTER TABLE couriers
+    DROP COLUMN IF EXISTS deactivation_reason,
+    DROP COLUMN IF EXISTS deactivated_at;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- версия строки для оптимистичной блокировки: каждое изменение курьера увеличивает ее на 1
+ALTER TABLE couriers
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE couriers
+    DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+-- контур зоны хранится как JSON массив вершин [{"lat": .., "lon": ..}], попадание точки проверяется в сервисе,
+-- описанный прямоугольник нужен, чтобы отсеять заведомо далекие зоны в запросе без PostGIS
+CREATE TABLE IF NOT EXISTS zones (
+    id         BIGSERIAL PRIMARY KEY,
+    name       TEXT NOT NULL UNIQUE,
+    polygon    JSONB NOT NULL,
+    min_lat    DOUBLE PRECISION NOT NULL,
+    min_lon    DOUBLE PRECISION NOT NULL,
+    max_lat    DOUBLE PRECISION NOT NULL,
+    max_lon    DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- курьер может работать в нескольких соседних зонах
+CREATE TABLE IF NOT EXISTS courier_zones (
+    zone_id    BIGINT NOT NULL REFERENCES zones (id) ON DELETE CASCADE,
+    courier_id BIGINT NOT NULL REFERENCES couriers (id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (zone_id, courier_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_courier_zones_courier_id ON courier_zones (courier_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS courier_zones;
+DROP TABLE IF EXISTS zones;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS courier_skills (
+    courier_id BIGINT NOT NULL REFERENCES couriers (id) ON DELETE CASCADE,
+    skill      TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (courier_id, skill)
+);
+
+-- требования заказа из очереди ожидания должны дожить до назначения
+ALTER TABLE pending_assignments
+    ADD COLUMN IF NOT EXISTS required_skills TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS allowed_transport_types TEXT[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pending_assignments
+    DROP COLUMN IF EXISTS allowed_transport_types,
+    DROP COLUMN IF EXISTS required_skills;
+
+DROP TABLE IF EXISTS courier_skills;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS delivery_offers (
+    id           BIGSERIAL PRIMARY KEY,
+    order_id     VARCHAR(255) NOT NULL,
+    courier_id   BIGINT NOT NULL REFERENCES couriers (id) ON DELETE CASCADE,
+    status       VARCHAR(16) NOT NULL DEFAULT 'pending',
+    offered_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at   TIMESTAMP NOT NULL,
+    responded_at TIMESTAMP
+);
+
+-- у заказа и у курьера одновременно не больше одного открытого предложения
+CREATE UNIQUE INDEX idx_delivery_offers_pending_order ON delivery_offers USING BTREE (order_id) WHERE status = 'pending';
+CREATE UNIQUE INDEX idx_delivery_offers_pending_courier ON delivery_offers USING BTREE (courier_id) WHERE status = 'pending';
+
+-- поиск просроченных предложений задачей повторного предложения
+CREATE INDEX idx_delivery_offers_expires ON delivery_offers USING BTREE (expires_at) WHERE status = 'pending';
+
+-- история предложений заказа и статистика курьера
+CREATE INDEX idx_delivery_offers_order ON delivery_offers USING BTREE (order_id);
+CREATE INDEX idx_delivery_offers_courier ON delivery_offers USING BTREE (courier_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS delivery_offers;
+-- +goose StatementEnd
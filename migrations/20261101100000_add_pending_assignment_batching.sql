@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- заказ ресторана ждет batch_until, чтобы к нему успели присоединиться другие заказы того же ресторана
+ALTER TABLE pending_assignments
+    ADD COLUMN IF NOT EXISTS batch_until TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_pending_assignments_batch
+    ON pending_assignments USING BTREE (restaurant_id, enqueued_at)
+    WHERE batch_until IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_pending_assignments_batch;
+
+ALTER TABLE pending_assignments
+    DROP COLUMN IF EXISTS batch_until;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- класс срочности и требования заказа хранятся в доставке, чтобы вернуть заказ в очередь ожидания,
+-- если его курьера заберет приоритетный заказ
+ALTER TABLE delivery
+    ADD COLUMN IF NOT EXISTS priority                TEXT   NOT NULL DEFAULT 'normal',
+    ADD COLUMN IF NOT EXISTS required_skills         TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS allowed_transport_types TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_delivery_preemption
+    ON delivery USING BTREE (assigned_at)
+    WHERE priority = 'normal';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_delivery_preemption;
+
+ALTER TABLE delivery
+    DROP COLUMN IF EXISTS priority,
+    DROP COLUMN IF EXISTS required_skills,
+    DROP COLUMN IF EXISTS allowed_transport_types;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS scheduled_deliveries (
+    id                      BIGSERIAL PRIMARY KEY,
+    order_id                VARCHAR(255) NOT NULL,
+    deliver_at              TIMESTAMP NOT NULL,
+    assign_at               TIMESTAMP NOT NULL,
+    pickup_lat              DOUBLE PRECISION,
+    pickup_lon              DOUBLE PRECISION,
+    dropoff_lat             DOUBLE PRECISION,
+    dropoff_lon             DOUBLE PRECISION,
+    restaurant_id           VARCHAR(255),
+    address                 JSONB,
+    order_created_at        TIMESTAMP,
+    required_skills         TEXT[] NOT NULL DEFAULT '{}',
+    allowed_transport_types TEXT[] NOT NULL DEFAULT '{}',
+    priority                TEXT NOT NULL DEFAULT 'normal',
+    status                  VARCHAR(16) NOT NULL DEFAULT 'scheduled',
+    created_at              TIMESTAMP NOT NULL DEFAULT NOW(),
+    assigned_at             TIMESTAMP,
+    cancelled_at            TIMESTAMP
+);
+
+-- у заказа не больше одной ожидающей назначения запланированной доставки
+CREATE UNIQUE INDEX idx_scheduled_deliveries_order ON scheduled_deliveries USING BTREE (order_id) WHERE status = 'scheduled';
+
+-- поиск доставок, которым пора искать курьера
+CREATE INDEX idx_scheduled_deliveries_assign_at ON scheduled_deliveries USING BTREE (assign_at) WHERE status = 'scheduled';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS scheduled_deliveries;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- completed_at выставляется, когда order-service сообщает о выполнении заказа: по нему обучается модель ETA
+ALTER TABLE delivery
+    ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP;
+
+-- модель ETA: квантили длительности доставки (assigned_at -> completed_at) по виду транспорта,
+-- дню недели и часу назначения в UTC. Строка с weekday = -1 и hour = -1 - квантили по всему виду транспорта,
+-- они используются, если в ячейке мало доставок
+CREATE TABLE IF NOT EXISTS delivery_eta_quantiles (
+    transport_type TEXT NOT NULL,
+    weekday        SMALLINT NOT NULL,
+    hour           SMALLINT NOT NULL,
+    sample_count   BIGINT NOT NULL,
+    p50_seconds    DOUBLE PRECISION NOT NULL,
+    p90_seconds    DOUBLE PRECISION NOT NULL,
+    trained_at     TIMESTAMP NOT NULL,
+    PRIMARY KEY (transport_type, weekday, hour)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS delivery_eta_quantiles;
+
+ALTER TABLE delivery
+    DROP COLUMN IF EXISTS completed_at;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- оценка выполненной доставки от клиента или ресторана. courier_id копируется из доставки,
+-- чтобы средняя оценка курьера считалась без обращения к доставкам и их архиву
+CREATE TABLE IF NOT EXISTS delivery_ratings (
+    id         BIGSERIAL PRIMARY KEY,
+    order_id   VARCHAR(255) NOT NULL,
+    courier_id BIGINT NOT NULL,
+    author     VARCHAR(16) NOT NULL,
+    score      SMALLINT NOT NULL CHECK (score BETWEEN 1 AND 5),
+    tags       TEXT[] NOT NULL DEFAULT '{}',
+    comment    TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- клиент и ресторан оценивают доставку по одному разу
+CREATE UNIQUE INDEX idx_delivery_ratings_order_author ON delivery_ratings USING BTREE (order_id, author);
+
+-- средняя оценка курьера за скользящее окно
+CREATE INDEX idx_delivery_ratings_courier_created_at ON delivery_ratings USING BTREE (courier_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS delivery_ratings;
+-- +goose StatementEnd
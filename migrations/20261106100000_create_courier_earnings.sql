@@ -0,0 +1,78 @@
+-- +goose Up
+-- +goose StatementBegin
+-- маршрут доставки нужен для оплаты курьеру: по нему считается расстояние и зона точки забора
+ALTER TABLE delivery
+    ADD COLUMN IF NOT EXISTS pickup_lat  DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS pickup_lon  DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS dropoff_lat DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS dropoff_lon DOUBLE PRECISION;
+
+-- тарифы оплаты курьеров в копейках. Тариф без зоны действует для транспорта там,
+-- где у зоны точки забора нет своего тарифа
+CREATE TABLE IF NOT EXISTS courier_tariffs (
+    id             BIGSERIAL PRIMARY KEY,
+    transport_type TEXT NOT NULL CHECK (transport_type IN ('on_foot', 'scooter', 'car')),
+    zone_id        BIGINT REFERENCES zones (id) ON DELETE CASCADE,
+    base_fee       BIGINT NOT NULL CHECK (base_fee >= 0),
+    per_km_rate    BIGINT NOT NULL CHECK (per_km_rate >= 0),
+    peak_bonus     BIGINT NOT NULL CHECK (peak_bonus >= 0),
+    late_penalty   BIGINT NOT NULL CHECK (late_penalty >= 0),
+    updated_at     TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_courier_tariffs_transport ON courier_tariffs USING BTREE (transport_type) WHERE zone_id IS NULL;
+CREATE UNIQUE INDEX idx_courier_tariffs_transport_zone ON courier_tariffs USING BTREE (transport_type, zone_id) WHERE zone_id IS NOT NULL;
+
+INSERT INTO courier_tariffs (transport_type, base_fee, per_km_rate, peak_bonus, late_penalty)
+VALUES
+    ('on_foot', 10000, 2000, 5000, 5000),
+    ('scooter', 12000, 1500, 5000, 5000),
+    ('car', 15000, 1200, 5000, 5000);
+
+-- закрытые расчетные периоды: период начинается там, где закончился предыдущий
+CREATE TABLE IF NOT EXISTS payout_periods (
+    id           BIGSERIAL PRIMARY KEY,
+    period_start TIMESTAMP,
+    period_end   TIMESTAMP NOT NULL,
+    closed_at    TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- начисление курьеру за выполненную доставку, суммы в копейках. Тариф копируется в строку,
+-- чтобы изменение тарифов не меняло уже начисленное. payout_period_id выставляется при закрытии периода
+CREATE TABLE IF NOT EXISTS courier_earnings (
+    id               BIGSERIAL PRIMARY KEY,
+    order_id         VARCHAR(255) NOT NULL UNIQUE,
+    courier_id       BIGINT NOT NULL,
+    transport_type   TEXT NOT NULL,
+    zone_id          BIGINT,
+    distance_km      DOUBLE PRECISION NOT NULL DEFAULT 0,
+    base_fee         BIGINT NOT NULL,
+    distance_fee     BIGINT NOT NULL,
+    peak_bonus       BIGINT NOT NULL,
+    late_penalty     BIGINT NOT NULL,
+    total            BIGINT NOT NULL,
+    assigned_at      TIMESTAMP NOT NULL,
+    deadline         TIMESTAMP NOT NULL,
+    completed_at     TIMESTAMP NOT NULL,
+    payout_period_id BIGINT REFERENCES payout_periods (id),
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_courier_earnings_courier_completed_at ON courier_earnings USING BTREE (courier_id, completed_at);
+
+-- начисления, еще не попавшие в закрытый период
+CREATE INDEX idx_courier_earnings_unpaid ON courier_earnings USING BTREE (completed_at) WHERE payout_period_id IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS courier_earnings;
+DROP TABLE IF EXISTS payout_periods;
+DROP TABLE IF EXISTS courier_tariffs;
+
+ALTER TABLE delivery
+    DROP COLUMN IF EXISTS pickup_lat,
+    DROP COLUMN IF EXISTS pickup_lon,
+    DROP COLUMN IF EXISTS dropoff_lat,
+    DROP COLUMN IF EXISTS dropoff_lon;
+-- +goose StatementEnd
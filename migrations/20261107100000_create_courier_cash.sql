@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+-- наличные, которые курьер получит от клиента, в копейках. Хранятся в очереди ожидания для лимита
+-- наличных при назначении и в доставке, чтобы зачислить их в кассу курьера при выполнении
+ALTER TABLE delivery
+    ADD COLUMN IF NOT EXISTS cash_amount BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE pending_assignments
+    ADD COLUMN IF NOT EXISTS cash_amount BIGINT NOT NULL DEFAULT 0;
+
+-- движения наличных курьера: получение от клиента за заказ и сдача оператору, суммы в копейках
+CREATE TABLE IF NOT EXISTS courier_cash_transactions (
+    id         BIGSERIAL PRIMARY KEY,
+    courier_id BIGINT NOT NULL REFERENCES couriers (id),
+    kind       TEXT   NOT NULL CHECK (kind IN ('collected', 'handover')),
+    amount     BIGINT NOT NULL CHECK (amount > 0),
+    order_id   TEXT,
+    operator   TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK ((kind = 'collected') = (order_id IS NOT NULL))
+);
+
+-- за заказ наличные зачисляются один раз, даже если событие о выполнении пришло повторно
+CREATE UNIQUE INDEX idx_courier_cash_transactions_order ON courier_cash_transactions USING BTREE (order_id) WHERE order_id IS NOT NULL;
+CREATE INDEX idx_courier_cash_transactions_courier ON courier_cash_transactions USING BTREE (courier_id, kind, created_at);
+
+-- остаток наличных на руках у курьера, меняется в одной транзакции с движением.
+-- Отдельная таблица, чтобы подбор курьера не суммировал движения
+CREATE TABLE IF NOT EXISTS courier_cash_balances (
+    courier_id BIGINT PRIMARY KEY REFERENCES couriers (id),
+    balance    BIGINT NOT NULL CHECK (balance >= 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS courier_cash_balances;
+DROP TABLE IF EXISTS courier_cash_transactions;
+
+ALTER TABLE pending_assignments
+    DROP COLUMN IF EXISTS cash_amount;
+
+ALTER TABLE delivery
+    DROP COLUMN IF EXISTS cash_amount;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- колонки, добавленные в delivery после появления архива: без них перенос партиции
+-- в delivery_archive терял класс срочности, требования, время выполнения, маршрут и наличные
+ALTER TABLE delivery_archive
+    ADD COLUMN IF NOT EXISTS priority                TEXT   NOT NULL DEFAULT 'normal',
+    ADD COLUMN IF NOT EXISTS required_skills         TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS allowed_transport_types TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS completed_at            TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS pickup_lat              DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS pickup_lon              DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS dropoff_lat             DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS dropoff_lon             DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS cash_amount             BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE delivery_archive
+    DROP COLUMN IF EXISTS cash_amount,
+    DROP COLUMN IF EXISTS dropoff_lon,
+    DROP COLUMN IF EXISTS dropoff_lat,
+    DROP COLUMN IF EXISTS pickup_lon,
+    DROP COLUMN IF EXISTS pickup_lat,
+    DROP COLUMN IF EXISTS completed_at,
+    DROP COLUMN IF EXISTS allowed_transport_types,
+    DROP COLUMN IF EXISTS required_skills,
+    DROP COLUMN IF EXISTS priority;
+-- +goose StatementEnd
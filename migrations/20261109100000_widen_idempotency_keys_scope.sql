@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- scope - метод и путь запроса вместе с параметрами: ключ одного курьера не должен возвращать
+-- сохраненный ответ для другого. Длину параметров пути до обработчика никто не проверяет
+ALTER TABLE idempotency_keys ALTER COLUMN scope TYPE TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- ключи живут недолго, слишком длинные удаляются, чтобы вернуть прежний тип
+DELETE FROM idempotency_keys WHERE length(scope) > 255;
+ALTER TABLE idempotency_keys ALTER COLUMN scope TYPE VARCHAR(255);
+-- +goose StatementEnd
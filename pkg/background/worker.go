@@ -22,6 +22,13 @@ type Task interface {
 	Info() string
 }
 
+// Triggerable опциональный интерфейс для задач, которые помимо периодического запуска
+// нужно выполнять немедленно по внешнему сигналу (например, освободился курьер).
+type Triggerable interface {
+	// Trigger возвращает канал сигналов на внеочередное выполнение задачи.
+	Trigger() <-chan struct{}
+}
+
 type handlerLogger interface {
 	Info(msg string, fields ...logger.Field)
 	Warn(msg string, fields ...logger.Field)
@@ -108,6 +115,12 @@ func (w *Worker) runBackgroundTask(ctx context.Context, task Task) {
 	ticker := time.NewTicker(ttl)
 	defer ticker.Stop()
 
+	// nil канал никогда не сработает в select, поэтому обычные задачи работают только по тикеру
+	var trigger <-chan struct{}
+	if triggerable, ok := task.(Triggerable); ok {
+		trigger = triggerable.Trigger()
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -117,6 +130,9 @@ func (w *Worker) runBackgroundTask(ctx context.Context, task Task) {
 			return
 		case <-ticker.C:
 			w.executeTaskSafely(ctx, task)
+		case <-trigger:
+			w.executeTaskSafely(ctx, task)
+			ticker.Reset(ttl)
 		}
 	}
 }
@@ -0,0 +1,34 @@
+package geo
+
+import "math"
+
+// earthRadiusKm средний радиус Земли, достаточная точность для городских расстояний
+const earthRadiusKm = 6371.0
+
+// Point географическая точка в градусах.
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+// IsValid проверяет, что координаты лежат в допустимых диапазонах.
+func (p Point) IsValid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lon >= -180 && p.Lon <= 180
+}
+
+// DistanceKm возвращает расстояние между точками по дуге большого круга (формула гаверсинусов).
+func DistanceKm(a, b Point) float64 {
+	lat1 := toRadians(a.Lat)
+	lat2 := toRadians(b.Lat)
+	dLat := toRadians(b.Lat - a.Lat)
+	dLon := toRadians(b.Lon - a.Lon)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,60 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"service/pkg/geo"
+)
+
+func TestDistanceKm(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		a        geo.Point
+		b        geo.Point
+		expected float64
+		delta    float64
+	}{
+		{
+			name:     "Одна и та же точка",
+			a:        geo.Point{Lat: 55.7558, Lon: 37.6173},
+			b:        geo.Point{Lat: 55.7558, Lon: 37.6173},
+			expected: 0,
+			delta:    1e-9,
+		},
+		{
+			name:     "Москва - Санкт-Петербург",
+			a:        geo.Point{Lat: 55.7558, Lon: 37.6173},
+			b:        geo.Point{Lat: 59.9343, Lon: 30.3351},
+			expected: 634,
+			delta:    2,
+		},
+		{
+			name:     "Один градус по меридиану",
+			a:        geo.Point{Lat: 0, Lon: 0},
+			b:        geo.Point{Lat: 1, Lon: 0},
+			expected: 111.19,
+			delta:    0.01,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.InDelta(t, tt.expected, geo.DistanceKm(tt.a, tt.b), tt.delta)
+			assert.InDelta(t, tt.expected, geo.DistanceKm(tt.b, tt.a), tt.delta)
+		})
+	}
+}
+
+func TestPoint_IsValid(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, geo.Point{Lat: 55.7558, Lon: 37.6173}.IsValid())
+	assert.True(t, geo.Point{Lat: -90, Lon: 180}.IsValid())
+	assert.False(t, geo.Point{Lat: 91, Lon: 0}.IsValid())
+	assert.False(t, geo.Point{Lat: 0, Lon: -181}.IsValid())
+}
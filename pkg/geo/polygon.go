@@ -0,0 +1,91 @@
+package geo
+
+import "math"
+
+// minPolygonVertices меньше трех вершин площади нет
+const minPolygonVertices = 3
+
+// Polygon замкнутый контур, последняя вершина соединяется с первой.
+// Стороны считаются прямыми в градусах: для районов города кривизна Земли не важна,
+// переход через 180-й меридиан не поддерживается
+type Polygon []Point
+
+// IsValid проверяет вершины и то, что контур не вырожден в линию или точку
+func (p Polygon) IsValid() bool {
+	if len(p) < minPolygonVertices {
+		return false
+	}
+
+	for _, point := range p {
+		if !point.IsValid() {
+			return false
+		}
+	}
+
+	return p.signedArea() != 0
+}
+
+// Bounds возвращает углы прямоугольника, описанного вокруг контура
+func (p Polygon) Bounds() (Point, Point) {
+	if len(p) == 0 {
+		return Point{}, Point{}
+	}
+
+	minPoint, maxPoint := p[0], p[0]
+	for _, point := range p[1:] {
+		minPoint.Lat = math.Min(minPoint.Lat, point.Lat)
+		minPoint.Lon = math.Min(minPoint.Lon, point.Lon)
+		maxPoint.Lat = math.Max(maxPoint.Lat, point.Lat)
+		maxPoint.Lon = math.Max(maxPoint.Lon, point.Lon)
+	}
+
+	return minPoint, maxPoint
+}
+
+// Contains проверяет попадание точки лучом (even-odd rule).
+// Точка на границе считается внутри: заказ на улице между районами должен попасть хотя бы в один
+func (p Polygon) Contains(point Point) bool {
+	if len(p) < minPolygonVertices {
+		return false
+	}
+
+	inside := false
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		a, b := p[j], p[i]
+		if onSegment(a, b, point) {
+			return true
+		}
+
+		// ребро пересекает горизонтальный луч из точки вправо
+		if (a.Lat > point.Lat) != (b.Lat > point.Lat) {
+			crossLon := a.Lon + (point.Lat-a.Lat)*(b.Lon-a.Lon)/(b.Lat-a.Lat)
+			if point.Lon < crossLon {
+				inside = !inside
+			}
+		}
+	}
+
+	return inside
+}
+
+// signedArea площадь по формуле шнурования, знак зависит от направления обхода
+func (p Polygon) signedArea() float64 {
+	var area float64
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		area += p[j].Lon*p[i].Lat - p[i].Lon*p[j].Lat
+	}
+
+	return area / 2
+}
+
+func onSegment(a, b, point Point) bool {
+	const epsilon = 1e-12
+
+	cross := (b.Lon-a.Lon)*(point.Lat-a.Lat) - (b.Lat-a.Lat)*(point.Lon-a.Lon)
+	if math.Abs(cross) > epsilon {
+		return false
+	}
+
+	return point.Lon >= math.Min(a.Lon, b.Lon) && point.Lon <= math.Max(a.Lon, b.Lon) &&
+		point.Lat >= math.Min(a.Lat, b.Lat) && point.Lat <= math.Max(a.Lat, b.Lat)
+}
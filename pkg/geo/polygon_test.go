@@ -0,0 +1,89 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"service/pkg/geo"
+)
+
+// square район 1x1 градус с левым нижним углом в (55, 37)
+var square = geo.Polygon{
+	{Lat: 55, Lon: 37},
+	{Lat: 55, Lon: 38},
+	{Lat: 56, Lon: 38},
+	{Lat: 56, Lon: 37},
+}
+
+func TestPolygon_Contains(t *testing.T) {
+	t.Parallel()
+
+	// П-образный район: точка в вырезе снаружи, хотя попадает в описанный прямоугольник
+	uShape := geo.Polygon{
+		{Lat: 0, Lon: 0},
+		{Lat: 0, Lon: 3},
+		{Lat: 3, Lon: 3},
+		{Lat: 3, Lon: 2},
+		{Lat: 1, Lon: 2},
+		{Lat: 1, Lon: 1},
+		{Lat: 3, Lon: 1},
+		{Lat: 3, Lon: 0},
+	}
+
+	tests := []struct {
+		name     string
+		polygon  geo.Polygon
+		point    geo.Point
+		expected bool
+	}{
+		{name: "Точка внутри квадрата", polygon: square, point: geo.Point{Lat: 55.5, Lon: 37.5}, expected: true},
+		{name: "Точка снаружи квадрата", polygon: square, point: geo.Point{Lat: 54.9, Lon: 37.5}, expected: false},
+		{name: "Точка на стороне", polygon: square, point: geo.Point{Lat: 55, Lon: 37.5}, expected: true},
+		{name: "Точка в вершине", polygon: square, point: geo.Point{Lat: 56, Lon: 38}, expected: true},
+		{name: "Точка на продолжении стороны снаружи", polygon: square, point: geo.Point{Lat: 55, Lon: 39}, expected: false},
+		{name: "Точка в вырезе невыпуклого района", polygon: uShape, point: geo.Point{Lat: 2, Lon: 1.5}, expected: false},
+		{name: "Точка в ножке невыпуклого района", polygon: uShape, point: geo.Point{Lat: 2, Lon: 0.5}, expected: true},
+		{name: "Луч проходит через вершину", polygon: uShape, point: geo.Point{Lat: 1, Lon: 0.5}, expected: true},
+		{name: "Меньше трех вершин", polygon: square[:2], point: geo.Point{Lat: 55, Lon: 37.5}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, tt.polygon.Contains(tt.point))
+		})
+	}
+}
+
+func TestPolygon_IsValid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		polygon  geo.Polygon
+		expected bool
+	}{
+		{name: "Квадрат", polygon: square, expected: true},
+		{name: "Две вершины", polygon: square[:2], expected: false},
+		{name: "Все вершины на одной линии", polygon: geo.Polygon{{Lat: 0, Lon: 0}, {Lat: 1, Lon: 1}, {Lat: 2, Lon: 2}}, expected: false},
+		{name: "Широта вне диапазона", polygon: geo.Polygon{{Lat: 0, Lon: 0}, {Lat: 91, Lon: 1}, {Lat: 0, Lon: 2}}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, tt.polygon.IsValid())
+		})
+	}
+}
+
+func TestPolygon_Bounds(t *testing.T) {
+	t.Parallel()
+
+	minPoint, maxPoint := square.Bounds()
+
+	assert.Equal(t, geo.Point{Lat: 55, Lon: 37}, minPoint)
+	assert.Equal(t, geo.Point{Lat: 56, Lon: 38}, maxPoint)
+}
@@ -0,0 +1,159 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+/*
+Registry хранит именованные проверки зависимостей для /readyz.
+Каждая проверка выполняется со своим таймаутом, а результат кэшируется на cacheTTL:
+частые пробы Kubernetes с нескольких нод не превращаются в шквал запросов к Postgres и Kafka.
+*/
+
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// CheckFunc возвращает ошибку, если зависимость недоступна
+type CheckFunc func(ctx context.Context) error
+
+type CheckResult struct {
+	Name      string
+	Status    Status
+	Error     string
+	Duration  time.Duration
+	CheckedAt time.Time
+}
+
+type Report struct {
+	Status Status
+	Checks []CheckResult
+}
+
+type Registry struct {
+	cacheTTL time.Duration
+	mu       sync.RWMutex
+	checkers []*checker
+}
+
+type checker struct {
+	name    string
+	timeout time.Duration
+	check   CheckFunc
+
+	// mu держится на время проверки: параллельные запросы дожидаются текущей проверки
+	// и получают ее результат из кэша вместо повторного похода в зависимость
+	mu        sync.Mutex
+	last      CheckResult
+	hasResult bool
+}
+
+func NewRegistry(cacheTTL time.Duration) *Registry {
+	return &Registry{
+		cacheTTL: cacheTTL,
+	}
+}
+
+func (r *Registry) Register(name string, timeout time.Duration, check CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checkers = append(r.checkers, &checker{
+		name:    name,
+		timeout: timeout,
+		check:   check,
+	})
+}
+
+// Check запускает проверки параллельно и возвращает их результаты в порядке регистрации.
+// Отчет в статусе up, только если все проверки прошли.
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.RLock()
+	checkers := make([]*checker, len(r.checkers))
+	copy(checkers, r.checkers)
+	r.mu.RUnlock()
+
+	results := make([]CheckResult, len(checkers))
+
+	var wg sync.WaitGroup
+	for i, c := range checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx, r.cacheTTL)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: results,
+	}
+	for _, result := range results {
+		if result.Status != StatusUp {
+			report.Status = StatusDown
+			break
+		}
+	}
+
+	return report
+}
+
+func (c *checker) run(ctx context.Context, cacheTTL time.Duration) CheckResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.hasResult && time.Since(c.last.CheckedAt) < cacheTTL {
+		return c.last
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := safeCheck(checkCtx, c.check)
+	result := CheckResult{
+		Name:      c.name,
+		Status:    StatusUp,
+		Duration:  time.Since(start),
+		CheckedAt: start,
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	// отмена запроса клиентом ничего не говорит о зависимости - такой результат не кэшируем
+	if ctx.Err() == nil {
+		c.last = result
+		c.hasResult = true
+	}
+
+	return result
+}
+
+// safeCheck не дает зависшей проверке, игнорирующей контекст, задержать ответ дольше таймаута
+func safeCheck(ctx context.Context, check CheckFunc) error {
+	errCh := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				errCh <- fmt.Errorf("check panicked: %v", r)
+			}
+		}()
+		errCh <- check(ctx)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("check timed out: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,186 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"service/pkg/health"
+)
+
+func TestRegistry_Check(t *testing.T) {
+	t.Parallel()
+
+	up := func(ctx context.Context) error { return nil }
+	down := func(ctx context.Context) error { return errors.New("connection refused") }
+	hanging := func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}
+	panicking := func(ctx context.Context) error { panic("boom") }
+
+	type registration struct {
+		name  string
+		check health.CheckFunc
+	}
+
+	tests := []struct {
+		name           string
+		checks         []registration
+		expectedStatus health.Status
+		expectedChecks []health.Status
+		expectedErrors []string
+	}{
+		{
+			name:           "Все проверки прошли",
+			checks:         []registration{{"postgres", up}, {"order-service", up}},
+			expectedStatus: health.StatusUp,
+			expectedChecks: []health.Status{health.StatusUp, health.StatusUp},
+			expectedErrors: []string{"", ""},
+		},
+		{
+			name:           "Одна недоступная зависимость переводит отчет в down",
+			checks:         []registration{{"postgres", up}, {"order-service", down}},
+			expectedStatus: health.StatusDown,
+			expectedChecks: []health.Status{health.StatusUp, health.StatusDown},
+			expectedErrors: []string{"", "connection refused"},
+		},
+		{
+			name:           "Зависшая проверка прерывается по таймауту",
+			checks:         []registration{{"kafka", hanging}},
+			expectedStatus: health.StatusDown,
+			expectedChecks: []health.Status{health.StatusDown},
+			expectedErrors: []string{"check timed out"},
+		},
+		{
+			name:           "Паника в проверке считается ошибкой",
+			checks:         []registration{{"kafka", panicking}},
+			expectedStatus: health.StatusDown,
+			expectedChecks: []health.Status{health.StatusDown},
+			expectedErrors: []string{"check panicked: boom"},
+		},
+		{
+			name:           "Пустой реестр готов",
+			expectedStatus: health.StatusUp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			registry := health.NewRegistry(0)
+			for _, c := range tt.checks {
+				registry.Register(c.name, 50*time.Millisecond, c.check)
+			}
+
+			start := time.Now()
+			report := registry.Check(context.Background())
+
+			assert.Less(t, time.Since(start), 500*time.Millisecond)
+			assert.Equal(t, tt.expectedStatus, report.Status)
+			require.Len(t, report.Checks, len(tt.checks))
+			for i, result := range report.Checks {
+				assert.Equal(t, tt.checks[i].name, result.Name)
+				assert.Equal(t, tt.expectedChecks[i], result.Status)
+				if tt.expectedErrors[i] == "" {
+					assert.Empty(t, result.Error)
+				} else {
+					assert.Contains(t, result.Error, tt.expectedErrors[i])
+				}
+				assert.False(t, result.CheckedAt.IsZero())
+			}
+		})
+	}
+}
+
+func TestRegistry_Check_Cache(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		cacheTTL      time.Duration
+		calls         int
+		expectedCalls int64
+	}{
+		{
+			name:          "Результат берется из кэша в пределах TTL",
+			cacheTTL:      time.Hour,
+			calls:         5,
+			expectedCalls: 1,
+		},
+		{
+			name:          "Без кэша проверка выполняется на каждый запрос",
+			cacheTTL:      0,
+			calls:         5,
+			expectedCalls: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int64
+			registry := health.NewRegistry(tt.cacheTTL)
+			registry.Register("postgres", time.Second, func(ctx context.Context) error {
+				calls.Add(1)
+				return nil
+			})
+
+			for range tt.calls {
+				registry.Check(context.Background())
+			}
+
+			assert.Equal(t, tt.expectedCalls, calls.Load())
+		})
+	}
+}
+
+func TestRegistry_Check_ConcurrentRequestsShareResult(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	registry := health.NewRegistry(time.Hour)
+	registry.Register("postgres", time.Second, func(ctx context.Context) error {
+		calls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report := registry.Check(context.Background())
+			assert.Equal(t, health.StatusUp, report.Status)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(1), calls.Load())
+}
+
+func TestRegistry_Check_CanceledRequestIsNotCached(t *testing.T) {
+	t.Parallel()
+
+	registry := health.NewRegistry(time.Hour)
+	registry.Register("postgres", time.Second, func(ctx context.Context) error {
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report := registry.Check(ctx)
+	assert.Equal(t, health.StatusDown, report.Status)
+
+	report = registry.Check(context.Background())
+	assert.Equal(t, health.StatusUp, report.Status)
+}
@@ -0,0 +1,190 @@
+package hungarian
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+)
+
+// Forbidden стоимость недопустимой пары строки и столбца, такая пара никогда не попадает в назначение.
+var Forbidden = math.Inf(1)
+
+// Solve находит назначение строк столбцам с минимальной суммарной стоимостью венгерским алгоритмом
+// за O(n²·m). Матрица может быть прямоугольной, каждой строке достается не больше одного столбца.
+// Сначала назначается как можно больше допустимых пар, среди таких назначений выбирается самое дешевое.
+// assignment[i] - столбец строки i или -1, если строке столбца не досталось.
+// Решение прерывается с ошибкой контекста, когда контекст отменен или истек его срок.
+func Solve(ctx context.Context, cost [][]float64) ([]int, error) {
+	rows := len(cost)
+	if rows == 0 || len(cost[0]) == 0 {
+		return emptyAssignment(rows), nil
+	}
+	cols := len(cost[0])
+
+	// алгоритм назначает каждую строку, поэтому строк должно быть не больше, чем столбцов
+	if rows > cols {
+		columnAssignment, err := Solve(ctx, transpose(cost))
+		if err != nil {
+			return nil, err
+		}
+
+		assignment := emptyAssignment(rows)
+		for col, row := range columnAssignment {
+			if row >= 0 {
+				assignment[row] = col
+			}
+		}
+		return assignment, nil
+	}
+
+	a := withFiniteCost(cost)
+
+	// потенциалы строк u и столбцов v, p[j] - строка, назначенная столбцу j, way - путь увеличения.
+	// Индексация с единицы, нулевой столбец - фиктивный
+	u := make([]float64, rows+1)
+	v := make([]float64, cols+1)
+	p := make([]int, cols+1)
+	way := make([]int, cols+1)
+	minv := make([]float64, cols+1)
+	used := make([]bool, cols+1)
+
+	for i := 1; i <= rows; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		p[0] = i
+		j0 := 0
+		for j := range minv {
+			minv[j] = math.Inf(1)
+			used[j] = false
+		}
+
+		for {
+			used[j0] = true
+			i0 := p[j0]
+			delta := math.Inf(1)
+			j1 := 0
+			for j := 1; j <= cols; j++ {
+				if used[j] {
+					continue
+				}
+				current := a[i0-1][j-1] - u[i0] - v[j]
+				if current < minv[j] {
+					minv[j] = current
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+
+			for j := 0; j <= cols; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+
+			j0 = j1
+			if p[j0] == 0 {
+				break
+			}
+		}
+
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	assignment := emptyAssignment(rows)
+	for j := 1; j <= cols; j++ {
+		row := p[j] - 1
+		if row >= 0 && !math.IsInf(cost[row][j-1], 1) {
+			assignment[row] = j - 1
+		}
+	}
+	return assignment, nil
+}
+
+// Greedy назначает пары по возрастанию стоимости, пока у пары свободны и строка, и столбец.
+// Работает за O(n·m·log(n·m)), но не гарантирует минимальную суммарную стоимость.
+func Greedy(cost [][]float64) []int {
+	type pair struct {
+		row, col int
+	}
+
+	var pairs []pair
+	for row := range cost {
+		for col := range cost[row] {
+			if !math.IsInf(cost[row][col], 1) {
+				pairs = append(pairs, pair{row: row, col: col})
+			}
+		}
+	}
+	slices.SortStableFunc(pairs, func(a, b pair) int {
+		return cmp.Compare(cost[a.row][a.col], cost[b.row][b.col])
+	})
+
+	assignment := emptyAssignment(len(cost))
+	usedCols := make(map[int]bool)
+	for _, p := range pairs {
+		if assignment[p.row] >= 0 || usedCols[p.col] {
+			continue
+		}
+		assignment[p.row] = p.col
+		usedCols[p.col] = true
+	}
+	return assignment
+}
+
+// withFiniteCost заменяет запрещенные пары стоимостью, которая дороже любого назначения
+// только из допустимых пар, чтобы запрещенная пара выбиралась, лишь когда строке больше некуда деться
+func withFiniteCost(cost [][]float64) [][]float64 {
+	maxAbs := 0.0
+	for _, row := range cost {
+		for _, value := range row {
+			if !math.IsInf(value, 1) {
+				maxAbs = max(maxAbs, math.Abs(value))
+			}
+		}
+	}
+	forbidden := (maxAbs + 1) * float64(2*len(cost)+1)
+
+	result := make([][]float64, len(cost))
+	for i, row := range cost {
+		result[i] = make([]float64, len(row))
+		for j, value := range row {
+			if math.IsInf(value, 1) {
+				value = forbidden
+			}
+			result[i][j] = value
+		}
+	}
+	return result
+}
+
+func transpose(cost [][]float64) [][]float64 {
+	result := make([][]float64, len(cost[0]))
+	for j := range result {
+		result[j] = make([]float64, len(cost))
+		for i := range cost {
+			result[j][i] = cost[i][j]
+		}
+	}
+	return result
+}
+
+func emptyAssignment(rows int) []int {
+	assignment := make([]int, rows)
+	for i := range assignment {
+		assignment[i] = -1
+	}
+	return assignment
+}
@@ -0,0 +1,211 @@
+package hungarian_test
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"service/pkg/hungarian"
+)
+
+var inf = hungarian.Forbidden
+
+func TestSolve(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		cost     [][]float64
+		expected []int
+	}{
+		{
+			name:     "Пустая матрица",
+			cost:     [][]float64{},
+			expected: []int{},
+		},
+		{
+			name: "Квадратная матрица",
+			cost: [][]float64{
+				{4, 1, 3},
+				{2, 0, 5},
+				{3, 2, 2},
+			},
+			expected: []int{1, 0, 2},
+		},
+		{
+			name: "Жадный выбор самой дешевой пары не оптимален",
+			cost: [][]float64{
+				{1, 2},
+				{2, 100},
+			},
+			expected: []int{1, 0},
+		},
+		{
+			name: "Строк меньше, чем столбцов",
+			cost: [][]float64{
+				{5, 9, 1},
+				{10, 3, 2},
+			},
+			expected: []int{2, 1},
+		},
+		{
+			name: "Строк больше, чем столбцов",
+			cost: [][]float64{
+				{5, 9},
+				{1, 3},
+				{2, 8},
+			},
+			expected: []int{-1, 1, 0},
+		},
+		{
+			name: "Запрещенная пара не назначается",
+			cost: [][]float64{
+				{inf, inf},
+				{1, 2},
+			},
+			expected: []int{-1, 0},
+		},
+		{
+			name: "Назначается как можно больше допустимых пар",
+			cost: [][]float64{
+				{1, 100},
+				{2, inf},
+			},
+			expected: []int{1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := hungarian.Solve(context.Background(), tt.cost)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestSolve_MatchesBruteForce(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for range 200 {
+		rows, cols := 1+rnd.IntN(5), 1+rnd.IntN(5)
+		cost := make([][]float64, rows)
+		for i := range cost {
+			cost[i] = make([]float64, cols)
+			for j := range cost[i] {
+				cost[i][j] = float64(rnd.IntN(50))
+				if rnd.IntN(6) == 0 {
+					cost[i][j] = inf
+				}
+			}
+		}
+
+		actual, err := hungarian.Solve(context.Background(), cost)
+		require.NoError(t, err)
+
+		expectedPairs, expectedTotal := bruteForce(cost)
+		actualPairs, actualTotal := total(cost, actual)
+		assert.Equal(t, expectedPairs, actualPairs, "%v", cost)
+		assert.InDelta(t, expectedTotal, actualTotal, 1e-9, "%v", cost)
+	}
+}
+
+func TestSolve_ContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	actual, err := hungarian.Solve(ctx, [][]float64{{1, 2}, {3, 4}})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, actual)
+}
+
+func TestGreedy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		cost     [][]float64
+		expected []int
+	}{
+		{
+			name: "Сначала самая дешевая пара",
+			cost: [][]float64{
+				{1, 2},
+				{2, 100},
+			},
+			expected: []int{0, 1},
+		},
+		{
+			name: "Запрещенная пара не назначается",
+			cost: [][]float64{
+				{1, inf},
+				{2, inf},
+			},
+			expected: []int{0, -1},
+		},
+		{
+			name: "Строк больше, чем столбцов",
+			cost: [][]float64{
+				{5},
+				{1},
+			},
+			expected: []int{-1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, hungarian.Greedy(tt.cost))
+		})
+	}
+}
+
+// bruteForce перебирает все назначения: максимальное число допустимых пар и минимальная стоимость при нем
+func bruteForce(cost [][]float64) (int, float64) {
+	bestPairs, bestTotal := 0, 0.0
+	usedCols := make([]bool, len(cost[0]))
+
+	var walk func(row, pairs int, sum float64)
+	walk = func(row, pairs int, sum float64) {
+		if row == len(cost) {
+			if pairs > bestPairs || (pairs == bestPairs && sum < bestTotal) {
+				bestPairs, bestTotal = pairs, sum
+			}
+			return
+		}
+
+		walk(row+1, pairs, sum)
+		for col := range cost[row] {
+			if usedCols[col] || math.IsInf(cost[row][col], 1) {
+				continue
+			}
+			usedCols[col] = true
+			walk(row+1, pairs+1, sum+cost[row][col])
+			usedCols[col] = false
+		}
+	}
+	walk(0, 0, 0)
+
+	return bestPairs, bestTotal
+}
+
+func total(cost [][]float64, assignment []int) (int, float64) {
+	pairs, sum := 0, 0.0
+	for row, col := range assignment {
+		if col >= 0 {
+			pairs++
+			sum += cost[row][col]
+		}
+	}
+	return pairs, sum
+}
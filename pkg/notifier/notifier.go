@@ -0,0 +1,27 @@
+package notifier
+
+// Notifier неблокирующий сигнал "что-то изменилось" с коалесценцией:
+// сколько бы раз ни был вызван Notify, пока получатель занят,
+// он получит ровно одно уведомление.
+type Notifier struct {
+	ch chan struct{}
+}
+
+func New() *Notifier {
+	return &Notifier{
+		ch: make(chan struct{}, 1),
+	}
+}
+
+// Notify отправляет сигнал, не блокируясь, если предыдущий ещё не прочитан.
+func (n *Notifier) Notify() {
+	select {
+	case n.ch <- struct{}{}:
+	default:
+	}
+}
+
+// C возвращает канал, из которого читаются сигналы.
+func (n *Notifier) C() <-chan struct{} {
+	return n.ch
+}